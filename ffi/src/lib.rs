@@ -24,7 +24,8 @@ use redis::cluster_routing::{
     MultipleNodeRoutingInfo, Route, RoutingInfo, SingleNodeRoutingInfo, SlotAddr,
 };
 use redis::{ClusterScanArgs, RedisError};
-use redis::{Cmd, Pipeline, PipelineRetryStrategy, RedisResult, Value};
+use redis::{Cmd, ExecutionInfo, Pipeline, PipelineRetryStrategy, RedisResult, Value};
+use std::collections::HashMap;
 use std::ffi::CStr;
 use std::future::Future;
 use std::mem::ManuallyDrop;
@@ -32,6 +33,7 @@ use std::slice::from_raw_parts;
 use std::str;
 use std::str::FromStr;
 use std::sync::Arc;
+use std::time::{Duration, Instant};
 use std::{
     ffi::{CString, c_void},
     mem,
@@ -39,6 +41,7 @@ use std::{
 };
 use tokio::runtime::Builder;
 use tokio::runtime::Runtime;
+use tokio::task::AbortHandle;
 
 #[repr(C)]
 pub struct ScriptHashBuffer {
@@ -175,6 +178,9 @@ pub struct CommandResponse {
     /// `sets_value_len` represents the length of the set.
     pub sets_value: *mut CommandResponse,
     pub sets_value_len: c_long,
+
+    /// `attributes` represents the RESP3 attributes attached to the value by the server, as a map, or null if it has none.
+    pub attributes: *mut CommandResponse,
 }
 
 impl Default for CommandResponse {
@@ -192,6 +198,7 @@ impl Default for CommandResponse {
             map_value: std::ptr::null_mut(),
             sets_value: std::ptr::null_mut(),
             sets_value_len: 0,
+            attributes: std::ptr::null_mut(),
         }
     }
 }
@@ -210,6 +217,8 @@ pub enum ResponseType {
     Sets = 7,
     Ok = 8,
     Error = 9,
+    BigNumber = 10,
+    VerbatimString = 11,
 }
 
 /// A Send-safe wrapper around a raw buffer pointer and length.
@@ -217,6 +226,39 @@ pub enum ResponseType {
 struct ResponseBuffer(*mut u8, usize);
 unsafe impl Send for ResponseBuffer {}
 
+/// Reports how a command was executed by [`command_with_deadline`].
+#[repr(C)]
+pub struct CommandExecution {
+    /// The address of the node the last attempt of the command was sent to, or null if no attempt was sent.
+    /// Must be freed with [`free_c_string`].
+    pub node: *mut c_char,
+    /// The number of attempts of the command, including the retries after redirections and reconnections.
+    pub attempts: u32,
+}
+
+/// A Send-safe wrapper around the record of the execution of a command and the caller-provided
+/// [`CommandExecution`] it is reported to.
+/// The caller guarantees the [`CommandExecution`] remains valid until the result of the command is delivered.
+struct ExecutionOutput(Arc<ExecutionInfo>, *mut CommandExecution);
+unsafe impl Send for ExecutionOutput {}
+
+impl ExecutionOutput {
+    /// Reports the record of the execution to the caller-provided [`CommandExecution`].
+    fn report(self) {
+        let node = self
+            .0
+            .node()
+            .and_then(|node| CString::new(node).ok())
+            .map_or(std::ptr::null_mut(), CString::into_raw);
+        unsafe {
+            *self.1 = CommandExecution {
+                node,
+                attempts: self.0.attempts(),
+            };
+        }
+    }
+}
+
 /// Success callback that is called when a command succeeds.
 ///
 /// The success callback needs to copy the given string synchronously, since it will be dropped by Rust once the callback returns. The callback should be offloaded to a separate thread in order not to exhaust the client's thread pool.
@@ -425,6 +467,10 @@ pub struct ClientAdapter {
     runtime: Runtime,
     core: Arc<CommandExecutionCore>,
     pubsub_callback: Arc<std::sync::RwLock<Option<PubSubCallback>>>,
+    /// Abort handles of async requests that haven't delivered their result yet, keyed by request ID.
+    /// An entry is removed either by the request itself before it invokes a callback, or by
+    /// [`cancel_command`], whichever comes first.
+    pending_requests: Arc<std::sync::Mutex<HashMap<usize, AbortHandle>>>,
 }
 
 struct CommandExecutionCore {
@@ -442,14 +488,17 @@ impl ClientAdapter {
     where
         Fut: Future<Output = RedisResult<Value>> + Send + 'static,
     {
-        self.execute_request_with_buffer(request_id, request_future, None)
+        self.execute_request_with_buffer(request_id, request_future, None, None)
     }
 
+    /// Executes a command like [`Self::execute_request`], copying a BulkString response into `response_buf`, if any,
+    /// and reporting the execution of the command to `execution`, if any, before its result is delivered.
     fn execute_request_with_buffer<Fut>(
         &self,
         request_id: usize,
         request_future: Fut,
         response_buf: Option<ResponseBuffer>,
+        execution: Option<ExecutionOutput>,
     ) -> *mut CommandResult
     where
         Fut: Future<Output = RedisResult<Value>> + Send + 'static,
@@ -459,9 +508,27 @@ impl ClientAdapter {
                 success_callback,
                 failure_callback,
             } => {
+                // Hold the lock while spawning, so the task can't claim its entry before it is registered.
+                let mut pending = self
+                    .pending_requests
+                    .lock()
+                    .unwrap_or_else(|poisoned| poisoned.into_inner());
+                let pending_requests = self.pending_requests.clone();
                 // Spawn the request for async client
-                self.runtime.spawn(async move {
+                let handle = self.runtime.spawn(async move {
                     let result = request_future.await;
+                    // If the entry is gone the request was cancelled, and no callback is expected.
+                    let claimed = pending_requests
+                        .lock()
+                        .unwrap_or_else(|poisoned| poisoned.into_inner())
+                        .remove(&request_id)
+                        .is_some();
+                    if !claimed {
+                        return;
+                    }
+                    if let Some(execution) = execution {
+                        execution.report();
+                    }
                     let _ = Self::handle_result(
                         result,
                         Some(success_callback),
@@ -470,16 +537,40 @@ impl ClientAdapter {
                         response_buf,
                     );
                 });
+                pending.insert(request_id, handle.abort_handle());
                 std::ptr::null_mut()
             }
             ClientType::SyncClient => {
                 // Block on the request for sync client
                 let result = self.runtime.block_on(request_future);
+                if let Some(execution) = execution {
+                    execution.report();
+                }
                 Self::handle_result(result, None, None, request_id, response_buf)
             }
         }
     }
 
+    /// Cancels a pending async request, dropping its future.
+    ///
+    /// Returns `true` if the request was cancelled before it delivered its result. Dropping the
+    /// future releases the inflight slot held by the request, and no callback will be invoked for it.
+    /// Returns `false` if the request is unknown, or already completed and is (or was) invoking its callback.
+    fn cancel_request(&self, request_id: usize) -> bool {
+        let handle = self
+            .pending_requests
+            .lock()
+            .unwrap_or_else(|poisoned| poisoned.into_inner())
+            .remove(&request_id);
+        match handle {
+            Some(handle) => {
+                handle.abort();
+                true
+            }
+            None => false,
+        }
+    }
+
     /// Handles the result of a command and returns a `CommandResult`.
     ///
     /// For async clients, invokes the appropriate callback and returns null.
@@ -796,6 +887,7 @@ fn create_client_internal(
         runtime,
         core,
         pubsub_callback: pubsub_callback_store.clone(),
+        pending_requests: Arc::new(std::sync::Mutex::new(HashMap::new())),
     });
     let client_adapter_ptr = Arc::as_ptr(&client_adapter).addr();
 
@@ -893,6 +985,30 @@ pub unsafe extern "C" fn close_client(client_adapter_ptr: *const c_void) {
     unsafe { Arc::decrement_strong_count(client_adapter_ptr as *const ClientAdapter) };
 }
 
+/// Cancels a command, batch or script invocation that was submitted with `request_id` and hasn't completed yet.
+///
+/// Returns `true` if the request was cancelled. Its future is dropped, which releases the inflight slot it held,
+/// and neither `success_callback` nor `failure_callback` will be invoked for it.
+/// Returns `false` if the request already completed, or is about to invoke its callback. The callback is then
+/// invoked as usual, and the caller remains responsible for freeing the response.
+///
+/// Cancellation only stops the client from waiting. A command that was already written to the server may still
+/// be executed, and its reply is discarded when it arrives.
+///
+/// # Safety
+///
+/// * `client_adapter_ptr` must not be `null` and must be obtained from the `ConnectionResponse` returned from [`create_client`].
+/// * This function should only be called with a `client_adapter_ptr` created by [`create_client`], before [`close_client`] was called with the pointer.
+#[unsafe(no_mangle)]
+pub unsafe extern "C" fn cancel_command(
+    client_adapter_ptr: *const c_void,
+    request_id: usize,
+) -> bool {
+    assert!(!client_adapter_ptr.is_null());
+    let client_adapter = unsafe { &*(client_adapter_ptr as *const ClientAdapter) };
+    client_adapter.cancel_request(request_id)
+}
+
 /// Deallocates a `ConnectionResponse`.
 ///
 /// This function also frees the contained error. If the contained error is a null pointer, the function returns and only the `ConnectionResponse` is freed.
@@ -937,6 +1053,8 @@ pub extern "C" fn get_response_type_string(response_type: ResponseType) -> *cons
         ResponseType::Sets => c"Sets",
         ResponseType::Ok => c"Ok",
         ResponseType::Error => c"Error",
+        ResponseType::BigNumber => c"BigNumber",
+        ResponseType::VerbatimString => c"VerbatimString",
     };
     c_str.as_ptr()
 }
@@ -972,6 +1090,8 @@ pub unsafe extern "C" fn free_command_response(command_response_ptr: *mut Comman
 /// * The contained `map_key` must be valid until `free_command_response` is called and it must outlive the `CommandResponse` that contains it.
 /// * The contained `map_value` must be obtained from the `CommandResponse` returned in [`SuccessCallback`] from [`command`].
 /// * The contained `map_value` must be valid until `free_command_response` is called and it must outlive the `CommandResponse` that contains it.
+/// * The contained `attributes` must be obtained from the `CommandResponse` returned in [`SuccessCallback`] from [`command`].
+/// * The contained `attributes` must be valid until `free_command_response` is called and it must outlive the `CommandResponse` that contains it.
 unsafe fn free_command_response_elements(command_response: CommandResponse) {
     let string_value = command_response.string_value;
     let string_value_len = command_response.string_value_len;
@@ -981,6 +1101,7 @@ unsafe fn free_command_response_elements(command_response: CommandResponse) {
     let map_value = command_response.map_value;
     let sets_value = command_response.sets_value;
     let sets_value_len = command_response.sets_value_len;
+    let attributes = command_response.attributes;
     if !string_value.is_null() {
         let len = string_value_len as usize;
         unsafe { Vec::from_raw_parts(string_value, len, len) };
@@ -1005,6 +1126,9 @@ unsafe fn free_command_response_elements(command_response: CommandResponse) {
             unsafe { free_command_response_elements(element) };
         }
     }
+    if !attributes.is_null() {
+        unsafe { free_command_response(attributes) };
+    }
 }
 
 /// Converts a double pointer to a vec.
@@ -1080,12 +1204,22 @@ fn valkey_value_to_command_response(
             command_response.response_type = ResponseType::String;
             Ok(command_response)
         }
-        Value::VerbatimString { format: _, text } => {
-            let vec: Vec<u8> = text.into_bytes();
+        Value::VerbatimString { format, text } => {
+            // The format is kept as a prefix of the text, as sent by the server: e.g. "txt:Some string".
+            let vec: Vec<u8> = format!("{format}:{text}").into_bytes();
             let (vec_ptr, len) = convert_vec_to_pointer(vec);
             command_response.string_value = vec_ptr as *mut c_char;
             command_response.string_value_len = len;
-            command_response.response_type = ResponseType::String;
+            command_response.response_type = ResponseType::VerbatimString;
+            Ok(command_response)
+        }
+        Value::BigNumber(number) => {
+            // Big numbers are passed in their decimal representation.
+            let vec: Vec<u8> = number.to_string().into_bytes();
+            let (vec_ptr, len) = convert_vec_to_pointer(vec);
+            command_response.string_value = vec_ptr as *mut c_char;
+            command_response.string_value_len = len;
+            command_response.response_type = ResponseType::BigNumber;
             Ok(command_response)
         }
         Value::Okay => {
@@ -1195,6 +1329,12 @@ fn valkey_value_to_command_response(
 
             Ok(command_response)
         }
+        Value::Attribute { data, attributes } => {
+            let mut command_response = valkey_value_to_command_response(*data, response_buf)?;
+            let attributes = valkey_value_to_command_response(Value::Map(attributes), None)?;
+            command_response.attributes = Box::into_raw(Box::new(attributes));
+            Ok(command_response)
+        }
         // TODO: Add support for other return types.
         _ => todo!(),
     };
@@ -1247,6 +1387,55 @@ pub unsafe extern "C-unwind" fn command(
     }
 }
 
+/// Executes a command that the caller stops waiting for after `timeout_ms` milliseconds.
+///
+/// The deadline of the caller bounds the deadline that the cluster client splits across the attempts of the
+/// command, when configured to. When `timeout_ms` is 0, behaves identically to [`command`].
+///
+/// When `execution` is non-null, the node the command was last sent to and its number of attempts are written
+/// to it before the result of the command is delivered. They aren't written if the command is cancelled.
+///
+/// # Safety
+///
+/// * When non-null, `execution` must point to a writable [`CommandExecution`] that remains valid until the result
+///   of the command is delivered, or the command is cancelled by [`cancel_command`].
+/// * The `node` written to `execution` must be freed with [`free_c_string`].
+/// * The requirements of [`command`] apply to all the other parameters.
+/// * This function should only be called with a `client_adapter_ptr` created by [`create_client`], before [`close_client`] was called with the pointer.
+#[unsafe(no_mangle)]
+pub unsafe extern "C-unwind" fn command_with_deadline(
+    client_adapter_ptr: *const c_void,
+    request_id: usize,
+    command_type: RequestType,
+    arg_count: c_ulong,
+    args: *const usize,
+    args_len: *const c_ulong,
+    route_bytes: *const u8,
+    route_bytes_len: usize,
+    span_ptr: u64,
+    timeout_ms: u64,
+    execution: *mut CommandExecution,
+) -> *mut CommandResult {
+    let deadline = (timeout_ms != 0).then(|| Instant::now() + Duration::from_millis(timeout_ms));
+    unsafe {
+        execute_command(
+            client_adapter_ptr,
+            request_id,
+            command_type,
+            arg_count,
+            args,
+            args_len,
+            route_bytes,
+            route_bytes_len,
+            std::ptr::null_mut(),
+            0,
+            span_ptr,
+            deadline,
+            execution,
+        )
+    }
+}
+
 /// Executes a command, optionally copying a BulkString response directly into a
 /// caller-provided buffer instead of returning it as a heap-allocated value.
 ///
@@ -1287,6 +1476,47 @@ pub unsafe extern "C-unwind" fn command_with_buffer(
     response_buf: *mut u8,
     response_buf_len: usize,
     span_ptr: u64,
+) -> *mut CommandResult {
+    unsafe {
+        execute_command(
+            client_adapter_ptr,
+            request_id,
+            command_type,
+            arg_count,
+            args,
+            args_len,
+            route_bytes,
+            route_bytes_len,
+            response_buf,
+            response_buf_len,
+            span_ptr,
+            None,
+            std::ptr::null_mut(),
+        )
+    }
+}
+
+/// Executes a command for [`command_with_buffer`] and [`command_with_deadline`], with the deadline of the caller,
+/// if any, reporting its execution to `execution` when non-null.
+///
+/// # Safety
+///
+/// * The requirements of [`command_with_deadline`] apply to `execution`.
+/// * The requirements of [`command_with_buffer`] apply to all the other parameters.
+unsafe fn execute_command(
+    client_adapter_ptr: *const c_void,
+    request_id: usize,
+    command_type: RequestType,
+    arg_count: c_ulong,
+    args: *const usize,
+    args_len: *const c_ulong,
+    route_bytes: *const u8,
+    route_bytes_len: usize,
+    response_buf: *mut u8,
+    response_buf_len: usize,
+    span_ptr: u64,
+    deadline: Option<Instant>,
+    execution: *mut CommandExecution,
 ) -> *mut CommandResult {
     let client_adapter = unsafe {
         // we increment the strong count to ensure that the client is not dropped just because we turned it into an Arc.
@@ -1350,6 +1580,16 @@ pub unsafe extern "C-unwind" fn command_with_buffer(
         cmd.set_span(unsafe { get_unsafe_span_from_ptr(Some(span_ptr)) });
     }
 
+    if let Some(deadline) = deadline {
+        cmd.set_deadline(deadline);
+    }
+
+    let execution_output = (!execution.is_null()).then(|| {
+        let info = Arc::new(ExecutionInfo::default());
+        cmd.set_execution_info(info.clone());
+        ExecutionOutput(info, execution)
+    });
+
     let route = if !route_bytes.is_null() {
         let r_bytes = unsafe { std::slice::from_raw_parts(route_bytes, route_bytes_len) };
         match Routes::parse_from_bytes(r_bytes) {
@@ -1384,6 +1624,7 @@ pub unsafe extern "C-unwind" fn command_with_buffer(
             client.send_command(&mut cmd, routing_info).await
         },
         buf_option,
+        execution_output,
     );
     if let Ok(span) = child_span {
         span.end();
@@ -3104,6 +3345,21 @@ pub extern "C" fn get_statistics() -> Statistics {
     }
 }
 
+/// Get the compression statistics of the compression prefix rules.
+///
+/// Returns a JSON object mapping each prefix with an activity to its statistics: `values_compressed`,
+/// `original_bytes`, `bytes_compressed`, `skipped_small_count` and `skipped_count`.
+///
+/// # Returns
+///
+/// A C string which must be freed with [`free_c_string`].
+#[unsafe(no_mangle)]
+pub extern "C" fn get_compression_prefix_statistics() -> *mut c_char {
+    CString::new(Telemetry::prefix_compression_stats_json())
+        .unwrap_or_else(|_| CString::new("{}").unwrap())
+        .into_raw()
+}
+
 /// Returns the minimum size in bytes for compression.
 ///
 /// This constant represents the minimum size a value must be to be eligible for compression.
@@ -3121,6 +3377,15 @@ pub extern "C" fn get_min_compressed_size() -> c_ulong {
     glide_core::compression::MIN_COMPRESSED_SIZE as c_ulong
 }
 
+/// Returns the default request timeout of the core in milliseconds, applied to the clients created without a request
+/// timeout.
+///
+/// This function allows language bindings to apply the default request timeout themselves without hardcoding it.
+#[unsafe(no_mangle)]
+pub extern "C" fn get_default_request_timeout() -> c_ulong {
+    glide_core::client::DEFAULT_RESPONSE_TIMEOUT.as_millis() as c_ulong
+}
+
 /// Register a pubsub callback for an existing client.
 ///
 /// # Safety
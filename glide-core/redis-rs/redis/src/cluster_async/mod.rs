@@ -60,7 +60,7 @@ use std::{
     net::{IpAddr, SocketAddr},
     pin::Pin,
     sync::{
-        atomic::{self, AtomicIsize, AtomicUsize, Ordering},
+        atomic::{self, AtomicBool, AtomicIsize, AtomicUsize, Ordering},
         Arc, Mutex,
     },
     task::{self, Poll},
@@ -764,6 +764,7 @@ pub(crate) enum OperationTarget {
     FanOut,
     NotFound,
     FatalError,
+    AttemptTimedOut,
 }
 type OperationResult = Result<Response, (OperationTarget, RedisError)>;
 
@@ -932,11 +933,23 @@ pin_project! {
 /// For fan-out commands, `Arc<Cmd>` is cloned per shard — each clone
 /// shares the same tracker. The slot is released only when all
 /// sub-commands finish.
-struct InflightSlotGuard(Arc<AtomicIsize>);
+struct InflightSlotGuard {
+    counter: Arc<AtomicIsize>,
+    released: AtomicBool,
+}
+
+impl InflightSlotGuard {
+    /// Releases the slot, unless it was already released.
+    fn release(&self) {
+        if !self.released.swap(true, Ordering::SeqCst) {
+            self.counter.fetch_add(1, Ordering::SeqCst);
+        }
+    }
+}
 
 impl Drop for InflightSlotGuard {
     fn drop(&mut self) {
-        self.0.fetch_add(1, Ordering::SeqCst);
+        self.release();
     }
 }
 
@@ -944,8 +957,8 @@ impl Drop for InflightSlotGuard {
 /// Last drop triggers `InflightSlotGuard::Drop` which releases the slot.
 #[derive(Clone)]
 pub struct InflightRequestTracker {
-    /// Held solely for its `Drop` impl which releases the inflight slot.
-    _guard: Arc<InflightSlotGuard>,
+    /// Releases the inflight slot on `Drop`, or on [`Self::release`].
+    guard: Arc<InflightSlotGuard>,
 }
 
 impl InflightRequestTracker {
@@ -962,11 +975,21 @@ impl InflightRequestTracker {
                 .is_ok()
             {
                 return Some(Self {
-                    _guard: Arc::new(InflightSlotGuard(counter)),
+                    guard: Arc::new(InflightSlotGuard {
+                        counter,
+                        released: AtomicBool::new(false),
+                    }),
                 });
             }
         }
     }
+
+    /// Releases the slot now, before the last clone is dropped. Used when the
+    /// request is abandoned by its caller while its sub-commands are still held
+    /// by the event loop. Releasing more than once has no further effect.
+    pub fn release(&self) {
+        self.guard.release();
+    }
 }
 
 #[cfg(test)]
@@ -1008,6 +1031,23 @@ mod inflight_tracker_tests {
         drop(clone2);
         assert_eq!(counter.load(Ordering::Relaxed), 5); // last clone → released
     }
+
+    #[test]
+    fn release_frees_slot_once_while_clones_are_held() {
+        let counter = Arc::new(AtomicIsize::new(5));
+        let tracker = InflightRequestTracker::try_new(counter.clone()).unwrap();
+        let clone = tracker.clone();
+        assert_eq!(counter.load(Ordering::Relaxed), 4);
+
+        tracker.release();
+        assert_eq!(counter.load(Ordering::Relaxed), 5); // released early
+        tracker.release();
+        assert_eq!(counter.load(Ordering::Relaxed), 5); // no double release
+
+        drop(tracker);
+        drop(clone);
+        assert_eq!(counter.load(Ordering::Relaxed), 5); // not released again
+    }
 }
 
 #[cfg(test)]
@@ -1156,6 +1196,27 @@ struct PendingRequest<C> {
     info: RequestInfo<C>,
 }
 
+impl<C> PendingRequest<C> {
+    /// Returns the timeout of the next attempt of the request, if the deadline of its command is split across its
+    /// attempts. Only the commands routed to a single node are timed out per attempt: the multi-node commands are
+    /// retried per node.
+    fn attempt_timeout(&self, retry_params: &RetryParams) -> Option<Duration> {
+        retry_params.attempt_timeout(self.split_deadline()?, self.retry)
+    }
+
+    /// Returns the deadline of the command of the request, if it's split across the attempts of the request.
+    /// Only readonly commands are split, since a write whose attempt timed out may already have been applied.
+    fn split_deadline(&self) -> Option<Instant> {
+        match &self.info.cmd {
+            CmdArg::Cmd {
+                cmd,
+                routing: InternalRoutingInfo::SingleNode(_),
+            } if cluster_routing::is_readonly(cmd.as_ref()) => cmd.deadline(),
+            _ => None,
+        }
+    }
+}
+
 pin_project! {
     struct Request<C> {
         retry_params: RetryParams,
@@ -1286,7 +1347,9 @@ impl<C> Future for Request<C> {
                     .into();
                 }
 
-                let sleep_duration = this.retry_params.wait_time_for_retry(request.retry);
+                let sleep_duration = this
+                    .retry_params
+                    .wait_time_before_deadline(request.split_deadline(), request.retry);
 
                 let address = match target {
                     OperationTarget::Node { address } => address,
@@ -1313,6 +1376,15 @@ impl<C> Future for Request<C> {
                         self.respond(Err(err));
                         return Next::Done.into();
                     }
+                    OperationTarget::AttemptTimedOut => {
+                        // The remaining time until the deadline is left to the next attempts, so the
+                        // request is retried right away, without waiting for a refresh of the slots.
+                        trace!("Attempt timed out, retrying");
+                        return Next::Retry {
+                            request: this.request.take().unwrap(),
+                        }
+                        .into();
+                    }
                 };
 
                 warn!("Received request error {} on node {:?}.", err, address);
@@ -1351,7 +1423,9 @@ impl<C> Future for Request<C> {
                         .into()
                     }
                     RetryMethod::WaitAndRetry => {
-                        let sleep_duration = this.retry_params.wait_time_for_retry(request.retry);
+                        let sleep_duration = this
+                            .retry_params
+                            .wait_time_before_deadline(request.split_deadline(), request.retry);
                         // Sleep and retry.
                         this.future.set(RequestState::Sleep {
                             sleep: boxed_sleep(sleep_duration),
@@ -2872,6 +2946,9 @@ where
         if let Some(span) = cmd.span() {
             set_routed_node_on_span(&span, &address);
         }
+        if let Some(execution_info) = cmd.execution_info() {
+            execution_info.record_attempt(&address);
+        }
 
         conn.req_packed_command(&cmd)
             .await
@@ -2897,6 +2974,29 @@ where
             .map_err(|err| (OperationTarget::Node { address }, err))
     }
 
+    /// Attempts `request`, timing the attempt out if the deadline of the request is split across its attempts, so
+    /// that a hung attempt leaves time for the retries. A timed out attempt is retried right away.
+    fn try_request_with_deadline(
+        request: &PendingRequest<C>,
+        core: Core<C>,
+        retry_params: &RetryParams,
+    ) -> BoxFuture<'static, OperationResult> {
+        let future = Self::try_request(request.info.clone(), core);
+        match request.attempt_timeout(retry_params) {
+            Some(attempt_timeout) => async move {
+                match tokio::time::timeout(attempt_timeout, future).await {
+                    Ok(result) => result,
+                    Err(_) => Err((
+                        OperationTarget::AttemptTimedOut,
+                        io::Error::new(io::ErrorKind::TimedOut, "The attempt timed out").into(),
+                    )),
+                }
+            }
+            .boxed(),
+            None => future.boxed(),
+        }
+    }
+
     async fn try_request(info: RequestInfo<C>, core: Core<C>) -> OperationResult {
         match info.cmd {
             CmdArg::Cmd { cmd, routing } => Self::try_cmd_request(cmd, routing, core).await,
@@ -3490,7 +3590,8 @@ where
                 continue;
             }
 
-            let future = Self::try_request(request.info.clone(), self.inner.clone()).boxed();
+            let future =
+                Self::try_request_with_deadline(&request, self.inner.clone(), &retry_params);
             self.in_flight_requests.push(Box::pin(Request {
                 retry_params: retry_params.clone(),
                 request: Some(request),
@@ -3515,13 +3616,15 @@ where
             match result {
                 Next::Done => {}
                 Next::Retry { request } => {
-                    let future = Self::try_request(request.info.clone(), self.inner.clone());
+                    let future = Self::try_request_with_deadline(
+                        &request,
+                        self.inner.clone(),
+                        &retry_params,
+                    );
                     self.in_flight_requests.push(Box::pin(Request {
                         retry_params: retry_params.clone(),
                         request: Some(request),
-                        future: RequestState::Future {
-                            future: Box::pin(future),
-                        },
+                        future: RequestState::Future { future },
                     }));
                 }
                 Next::RetryBusyLoadingError { request, address } => {
@@ -3564,10 +3667,11 @@ where
                                 sleep: boxed_sleep(sleep_duration),
                             }),
                             None => Some(RequestState::Future {
-                                future: Box::pin(Self::try_request(
-                                    request.info.clone(),
+                                future: Self::try_request_with_deadline(
+                                    request,
                                     self.inner.clone(),
-                                )),
+                                    &retry_params,
+                                ),
                             }),
                         }
                     } else {
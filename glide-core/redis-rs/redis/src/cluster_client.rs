@@ -11,7 +11,7 @@ use rand::Rng;
 #[cfg(feature = "cluster-async")]
 use std::ops::Add;
 use std::sync::Arc;
-use std::time::Duration;
+use std::time::{Duration, Instant};
 
 use crate::tls::TlsConnParams;
 
@@ -57,6 +57,9 @@ pub(crate) struct RetryParams {
     min_wait_time: u64,
     exponent_base: u64,
     factor: u64,
+    /// When set, the time remaining until the deadline of a command is split across its remaining attempts, each
+    /// attempt lasting at least this long.
+    pub(crate) min_attempt_timeout: Option<Duration>,
 }
 
 impl Default for RetryParams {
@@ -72,6 +75,7 @@ impl Default for RetryParams {
             min_wait_time: DEFAULT_MIN_RETRY_WAIT_TIME,
             exponent_base: DEFAULT_EXPONENT_BASE,
             factor: DEFAULT_FACTOR,
+            min_attempt_timeout: None,
         }
     }
 }
@@ -85,6 +89,45 @@ impl RetryParams {
         let jittered_wait = rand::rng().random_range(self.min_wait_time..clamped_wait);
         Duration::from_millis(jittered_wait)
     }
+
+    /// Returns the timeout of the next attempt of a command due by `deadline`, after `retry` retries: the time
+    /// remaining until the deadline divided by the number of remaining attempts, but at least `min_attempt_timeout`,
+    /// so that a hung attempt doesn't consume the time of the retries. A timed out attempt is retried without
+    /// waiting, and the wait after another error is taken from the time remaining, see
+    /// [`Self::wait_time_before_deadline`], so the attempts and the waits together end by the deadline. Returns
+    /// `None` if the deadline isn't split.
+    pub(crate) fn attempt_timeout(&self, deadline: Instant, retry: u32) -> Option<Duration> {
+        let min_attempt_timeout = self.min_attempt_timeout?;
+        let remaining = deadline.saturating_duration_since(Instant::now());
+        let attempts = self
+            .number_of_retries
+            .saturating_sub(retry)
+            .saturating_add(1);
+        Some(
+            (remaining / attempts)
+                .max(min_attempt_timeout)
+                .min(remaining),
+        )
+    }
+
+    /// Returns the time to wait before retry `retry` of a command, see [`Self::wait_time_for_retry`]. If the deadline
+    /// of the command is split across its attempts, the wait is shortened to leave at least `min_attempt_timeout` to
+    /// the retry before the deadline.
+    pub(crate) fn wait_time_before_deadline(
+        &self,
+        deadline: Option<Instant>,
+        retry: u32,
+    ) -> Duration {
+        let wait = self.wait_time_for_retry(retry);
+        match (self.min_attempt_timeout, deadline) {
+            (Some(min_attempt_timeout), Some(deadline)) => wait.min(
+                deadline
+                    .saturating_duration_since(Instant::now())
+                    .saturating_sub(min_attempt_timeout),
+            ),
+            _ => wait,
+        }
+    }
 }
 
 /// Configuration for rate limiting slot refresh operations in a Redis cluster.
@@ -378,6 +421,19 @@ impl ClusterClientBuilder {
         self
     }
 
+    /// Splits the time remaining until the deadline of a command, see [`Cmd::set_deadline`], across its remaining
+    /// attempts rather than letting a hung attempt consume it all, each attempt lasting at least
+    /// `min_attempt_timeout`. A timed out attempt is retried right away, and the wait before the other retries is
+    /// shortened to leave time to the retry.
+    ///
+    /// [`Cmd::set_deadline`]: crate::Cmd::set_deadline
+    pub fn deadline_splitting(mut self, min_attempt_timeout: Duration) -> ClusterClientBuilder {
+        self.builder_params
+            .retries_configuration
+            .min_attempt_timeout = Some(min_attempt_timeout);
+        self
+    }
+
     /// Sets the factor and exponent base for the retry wait time.
     /// The formula for the wait is rand(min_wait_retry .. min(max_retry_wait , factor * exponent_base ^ retry))ms.
     pub fn retry_wait_formula(mut self, factor: u64, exponent_base: u64) -> ClusterClientBuilder {
@@ -847,4 +903,64 @@ mod tests {
             DEFAULT_SLOTS_REFRESH_MAX_JITTER_MILLI
         );
     }
+
+    #[test]
+    fn deadline_splitting_divides_the_remaining_time_across_attempts() {
+        use std::time::{Duration, Instant};
+
+        let client = ClusterClientBuilder::new(get_connection_data())
+            .retries(3)
+            .deadline_splitting(Duration::from_millis(50))
+            .build()
+            .unwrap();
+        let retry_params = &client.cluster_params.retry_params;
+        let deadline = Instant::now() + Duration::from_secs(4);
+
+        let first = retry_params.attempt_timeout(deadline, 0).unwrap();
+        assert!(first <= Duration::from_secs(1) && first > Duration::from_millis(900));
+        // The last attempt may use all the remaining time.
+        let last = retry_params.attempt_timeout(deadline, 3).unwrap();
+        assert!(last <= Duration::from_secs(4) && last > Duration::from_millis(3900));
+        // Each attempt lasts at least the minimum, unless the deadline is closer.
+        let short = retry_params.attempt_timeout(Instant::now() + Duration::from_millis(100), 0);
+        assert_eq!(short, Some(Duration::from_millis(50)));
+        let expired = retry_params.attempt_timeout(Instant::now(), 0);
+        assert_eq!(expired, Some(Duration::ZERO));
+    }
+
+    #[test]
+    fn deadline_splitting_shortens_the_wait_before_a_retry() {
+        use std::time::{Duration, Instant};
+
+        let client = ClusterClientBuilder::new(get_connection_data())
+            .retries(3)
+            .min_retry_wait(1000)
+            .deadline_splitting(Duration::from_millis(50))
+            .build()
+            .unwrap();
+        let retry_params = &client.cluster_params.retry_params;
+
+        // The retry is left the minimum attempt timeout before the deadline.
+        let deadline = Instant::now() + Duration::from_millis(250);
+        let wait = retry_params.wait_time_before_deadline(Some(deadline), 1);
+        assert!(wait <= Duration::from_millis(200), "{wait:?}");
+        // Without a deadline, the wait is the backoff.
+        let wait = retry_params.wait_time_before_deadline(None, 1);
+        assert!(wait >= Duration::from_millis(1000), "{wait:?}");
+    }
+
+    #[test]
+    fn deadline_splitting_is_disabled_by_default() {
+        let client = ClusterClientBuilder::new(get_connection_data())
+            .build()
+            .unwrap();
+        let deadline = std::time::Instant::now() + std::time::Duration::from_secs(1);
+        assert_eq!(
+            client
+                .cluster_params
+                .retry_params
+                .attempt_timeout(deadline, 0),
+            None
+        );
+    }
 }
@@ -598,9 +598,10 @@ impl ResponsePolicy {
             b"ACL SETUSER" | b"ACL DELUSER" | b"ACL SAVE" | b"AUTH" | b"CLIENT SETNAME"
             | b"CLIENT SETINFO" | b"CONFIG SET" | b"CONFIG RESETSTAT" | b"CONFIG REWRITE"
             | b"FLUSHALL" | b"FLUSHDB" | b"FUNCTION DELETE" | b"FUNCTION FLUSH"
-            | b"FUNCTION LOAD" | b"FUNCTION RESTORE" | b"MEMORY PURGE" | b"MSET" | b"JSON.MSET"
-            | b"PING" | b"SCRIPT FLUSH" | b"SCRIPT LOAD" | b"SELECT" | b"SLOWLOG RESET"
-            | b"UNWATCH" | b"WATCH" => Some(ResponsePolicy::AllSucceeded),
+            | b"FUNCTION LOAD" | b"FUNCTION RESTORE" | b"MEMORY PURGE" | b"MODULE LOAD"
+            | b"MODULE LOADEX" | b"MODULE UNLOAD" | b"MSET" | b"JSON.MSET" | b"PING"
+            | b"SCRIPT FLUSH" | b"SCRIPT LOAD" | b"SELECT" | b"SLOWLOG RESET" | b"UNWATCH"
+            | b"WATCH" => Some(ResponsePolicy::AllSucceeded),
 
             b"KEYS"
             | b"FT._ALIASLIST"
@@ -1617,6 +1618,26 @@ mod tests_routing {
             )))
         );
 
+        // Module commands are sent to a random node, unless routed to all nodes, in which case they must succeed
+        // on every node.
+        for name in [
+            b"MODULE LOAD".as_slice(),
+            b"MODULE LOADEX".as_slice(),
+            b"MODULE UNLOAD".as_slice(),
+        ] {
+            assert_eq!(
+                ResponsePolicy::for_command(name),
+                Some(ResponsePolicy::AllSucceeded)
+            );
+        }
+        assert_eq!(ResponsePolicy::for_command(b"MODULE LIST"), None);
+        let mut module_load = cmd("MODULE");
+        module_load.arg("LOAD").arg("/path/to/module.so");
+        assert_eq!(
+            RoutingInfo::for_routable(&module_load),
+            Some(RoutingInfo::SingleNode(SingleNodeRoutingInfo::Random))
+        );
+
         for cmd in vec![
             cmd("SCAN"),
             cmd("SHUTDOWN"),
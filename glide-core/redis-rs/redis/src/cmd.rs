@@ -6,6 +6,9 @@ use futures_util::{
 };
 #[cfg(feature = "aio")]
 use std::pin::Pin;
+use std::sync::atomic::{AtomicU32, Ordering};
+use std::sync::{Arc, Mutex};
+use std::time::Instant;
 use std::{borrow::Borrow, fmt, io};
 
 use crate::connection::ConnectionLike;
@@ -35,6 +38,10 @@ pub struct Cmd {
     span: Option<GlideSpan>,
     //  A flag indicating whether this is a fenced command  (will have PING appended to ensure ordering)
     is_fenced: bool,
+    /// The instant by which the caller stops waiting for the response, across all the attempts of the command
+    deadline: Option<Instant>,
+    /// Records the attempts of the command, when the caller asked for them
+    execution_info: Option<Arc<ExecutionInfo>>,
     /// Inflight slot tracker. When set, the slot is released when the last
     /// clone of this Cmd (or its Arc) is dropped. Used to decouple user-facing
     /// timeout from internal pipeline cleanup.
@@ -42,6 +49,38 @@ pub struct Cmd {
     inflight_tracker: Option<crate::cluster_async::InflightRequestTracker>,
 }
 
+/// Records how a command is executed: the address of the node its last attempt was sent to, and its number of
+/// attempts, including the retries after redirections and reconnections. It's shared by the clones of the command.
+#[derive(Debug, Default)]
+pub struct ExecutionInfo {
+    node: Mutex<Option<String>>,
+    attempts: AtomicU32,
+}
+
+impl ExecutionInfo {
+    /// Records an attempt of the command, sent to the node at `address`.
+    pub fn record_attempt(&self, address: &str) {
+        *self
+            .node
+            .lock()
+            .unwrap_or_else(|poisoned| poisoned.into_inner()) = Some(address.to_string());
+        self.attempts.fetch_add(1, Ordering::Relaxed);
+    }
+
+    /// Returns the address of the node the last attempt of the command was sent to, if any.
+    pub fn node(&self) -> Option<String> {
+        self.node
+            .lock()
+            .unwrap_or_else(|poisoned| poisoned.into_inner())
+            .clone()
+    }
+
+    /// Returns the number of attempts of the command.
+    pub fn attempts(&self) -> u32 {
+        self.attempts.load(Ordering::Relaxed)
+    }
+}
+
 /// The PING command used to fence other commands for ordering guarantees
 const FENCE_COMMAND: &[u8] = b"*1\r\n$4\r\nPING\r\n";
 
@@ -349,6 +388,8 @@ impl Cmd {
             no_response: false,
             span: None,
             is_fenced: false,
+            deadline: None,
+            execution_info: None,
             #[cfg(feature = "cluster-async")]
             inflight_tracker: None,
         }
@@ -365,6 +406,8 @@ impl Cmd {
             #[cfg(feature = "cluster-async")]
             inflight_tracker: None,
             is_fenced: false,
+            deadline: None,
+            execution_info: None,
         }
     }
 
@@ -658,6 +701,40 @@ impl Cmd {
         self.is_fenced
     }
 
+    /// Set the instant by which the caller stops waiting for the response of this command. The cluster connection
+    /// splits the time remaining until the deadline across the attempts of a readonly command, when configured to.
+    #[inline]
+    pub fn set_deadline(&mut self, deadline: Instant) -> &mut Cmd {
+        self.deadline = Some(deadline);
+        self
+    }
+
+    /// Remove the deadline of this command, if any.
+    #[inline]
+    pub fn clear_deadline(&mut self) -> &mut Cmd {
+        self.deadline = None;
+        self
+    }
+
+    /// Return the deadline of this command, if any.
+    #[inline]
+    pub fn deadline(&self) -> Option<Instant> {
+        self.deadline
+    }
+
+    /// Record the attempts of this command, and of its clones, in `execution_info`.
+    #[inline]
+    pub fn set_execution_info(&mut self, execution_info: Arc<ExecutionInfo>) -> &mut Cmd {
+        self.execution_info = Some(execution_info);
+        self
+    }
+
+    /// Return the record of the attempts of this command, if any.
+    #[inline]
+    pub fn execution_info(&self) -> Option<&ExecutionInfo> {
+        self.execution_info.as_deref()
+    }
+
     /// Attach an inflight slot tracker. The slot is released when the last
     /// clone of this Cmd (or its `Arc<Cmd>`) is dropped.
     #[cfg(feature = "cluster-async")]
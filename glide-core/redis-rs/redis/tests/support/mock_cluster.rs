@@ -234,6 +234,13 @@ pub fn contains_slice(xs: &[u8], ys: &[u8]) -> bool {
     false
 }
 
+const NO_RESPONSE: &str = "The mock doesn't respond to the command";
+
+/// A response of a handler which never replies to the command, as a hung node.
+pub fn no_response() -> Result<(), RedisResult<Value>> {
+    Err(Err(RedisError::from((ErrorKind::ClientError, NO_RESPONSE))))
+}
+
 pub fn respond_startup(name: &str, cmd: &[u8]) -> Result<(), RedisResult<Value>> {
     if contains_slice(cmd, b"PING") || contains_slice(cmd, b"SETNAME") {
         Err(Ok(Value::SimpleString("OK".into())))
@@ -349,11 +356,13 @@ pub fn respond_startup_with_config(
 #[cfg(feature = "cluster-async")]
 impl aio::ConnectionLike for MockConnection {
     fn req_packed_command<'a>(&'a mut self, cmd: &'a redis::Cmd) -> RedisFuture<'a, Value> {
-        Box::pin(future::ready(
-            (self.handler)(&cmd.get_packed_command(), self.port)
-                .map_err(|err| err.and_then(|v| v.extract_error()))
-                .expect_err("Handler did not specify a response"),
-        ))
+        let response = (self.handler)(&cmd.get_packed_command(), self.port)
+            .map_err(|err| err.and_then(|v| v.extract_error()))
+            .expect_err("Handler did not specify a response");
+        if matches!(&response, Err(err) if err.to_string().contains(NO_RESPONSE)) {
+            return Box::pin(future::pending());
+        }
+        Box::pin(future::ready(response))
     }
 
     fn req_packed_commands<'a>(
@@ -1865,6 +1865,89 @@ mod cluster_async {
         assert_eq!(value, Ok(Some(123)));
     }
 
+    #[test]
+    #[serial_test::serial]
+    fn test_async_cluster_retries_timed_out_attempt_before_deadline() {
+        let name = "retries_timed_out_attempt_before_deadline";
+
+        let requests = Arc::new(atomic::AtomicUsize::new(0));
+        let MockEnv {
+            runtime,
+            async_connection: mut connection,
+            handler: _handler,
+            ..
+        } = MockEnv::with_client_builder(
+            ClusterClient::builder(vec![&*format!("redis://{name}")])
+                .retries(3)
+                .deadline_splitting(Duration::from_millis(50)),
+            name,
+            {
+                let requests = requests.clone();
+                move |cmd: &[u8], _| {
+                    respond_startup(name, cmd)?;
+                    match requests.fetch_add(1, atomic::Ordering::SeqCst) {
+                        0 => no_response(),
+                        _ => Err(Ok(Value::BulkString(b"123".to_vec()))),
+                    }
+                }
+            },
+        );
+
+        // The deadline is split across the 4 attempts, so the first one times out after 125ms, and is retried
+        // right away rather than after a refresh of the slots and a backoff, which would outlast the deadline.
+        let timeout = Duration::from_millis(500);
+        let started = std::time::Instant::now();
+        let mut get = cmd("GET");
+        get.arg("test").set_deadline(started + timeout);
+        let value = runtime.block_on(get.query_async::<_, Option<i32>>(&mut connection));
+
+        assert_eq!(value, Ok(Some(123)));
+        assert_eq!(requests.load(atomic::Ordering::SeqCst), 2);
+        assert!(started.elapsed() < timeout, "{:?}", started.elapsed());
+    }
+
+    #[test]
+    #[serial_test::serial]
+    fn test_async_cluster_does_not_retry_timed_out_attempt_of_write() {
+        let name = "does_not_retry_timed_out_attempt_of_write";
+
+        let requests = Arc::new(atomic::AtomicUsize::new(0));
+        let MockEnv {
+            runtime,
+            async_connection: mut connection,
+            handler: _handler,
+            ..
+        } = MockEnv::with_client_builder(
+            ClusterClient::builder(vec![&*format!("redis://{name}")])
+                .retries(3)
+                .deadline_splitting(Duration::from_millis(50)),
+            name,
+            {
+                let requests = requests.clone();
+                move |cmd: &[u8], _| {
+                    respond_startup(name, cmd)?;
+                    match requests.fetch_add(1, atomic::Ordering::SeqCst) {
+                        0 => no_response(),
+                        _ => Err(Ok(Value::Int(2))),
+                    }
+                }
+            },
+        );
+
+        // The write may already have been applied by the node that didn't respond, so its deadline isn't split,
+        // and the only attempt waits until the caller stops waiting.
+        let timeout = Duration::from_millis(500);
+        let mut incr = cmd("INCR");
+        incr.arg("test")
+            .set_deadline(std::time::Instant::now() + timeout);
+        let value = runtime.block_on(async {
+            tokio::time::timeout(timeout, incr.query_async::<_, i32>(&mut connection)).await
+        });
+
+        assert!(value.is_err(), "{value:?}");
+        assert_eq!(requests.load(atomic::Ordering::SeqCst), 1);
+    }
+
     #[test]
     #[serial_test::serial]
     fn test_async_cluster_tryagain_exhaust_retries() {
@@ -3424,6 +3507,85 @@ mod cluster_async {
         );
     }
 
+    #[test]
+    #[serial_test::serial]
+    fn test_async_cluster_does_not_follow_moved_without_retries() {
+        let name = "does_not_follow_moved_without_retries";
+        let requests = Arc::new(AtomicI32::new(0));
+        let MockEnv {
+            async_connection: mut connection,
+            handler: _handler,
+            runtime,
+            ..
+        } = MockEnv::with_client_builder(
+            ClusterClient::builder(vec![&*format!("redis://{name}")]).retries(0),
+            name,
+            {
+                let requests = requests.clone();
+                move |cmd: &[u8], port| {
+                    respond_startup_two_nodes(name, cmd)?;
+                    if contains_slice(cmd, b"CLUSTER") {
+                        return Err(Ok(Value::Okay));
+                    }
+                    requests.fetch_add(1, Ordering::SeqCst);
+                    match port {
+                        6379 => Err(parse_redis_value(
+                            format!("-MOVED 123 {name}:6380\r\n").as_bytes(),
+                        )),
+                        _ => panic!("The redirection shouldn't be followed"),
+                    }
+                }
+            },
+        );
+
+        let value = runtime.block_on(
+            cmd("GET")
+                .arg("test")
+                .query_async::<_, Option<i32>>(&mut connection),
+        );
+
+        let err = value.unwrap_err();
+        assert_eq!(err.kind(), ErrorKind::Moved, "{err}");
+        assert_eq!(requests.load(Ordering::SeqCst), 1);
+    }
+
+    #[test]
+    #[serial_test::serial]
+    fn test_async_cluster_does_not_retry_after_disconnect_without_retries() {
+        let name = "does_not_retry_after_disconnect_without_retries";
+        let requests = Arc::new(AtomicI32::new(0));
+        let MockEnv {
+            async_connection: mut connection,
+            handler: _handler,
+            runtime,
+            ..
+        } = MockEnv::with_client_builder(
+            ClusterClient::builder(vec![&*format!("redis://{name}")]).retries(0),
+            name,
+            {
+                let requests = requests.clone();
+                move |cmd: &[u8], _port| {
+                    respond_startup(name, cmd)?;
+                    if contains_slice(cmd, b"GET") {
+                        requests.fetch_add(1, Ordering::SeqCst);
+                        return Err(Err(broken_pipe_error()));
+                    }
+                    Err(Ok(Value::Okay))
+                }
+            },
+        );
+
+        let value = runtime.block_on(
+            cmd("GET")
+                .arg("test")
+                .query_async::<_, Option<i32>>(&mut connection),
+        );
+
+        let err = value.unwrap_err();
+        assert!(err.is_unrecoverable_error(), "{err}");
+        assert_eq!(requests.load(Ordering::SeqCst), 1);
+    }
+
     #[test]
     #[serial_test::serial]
     fn test_async_cluster_ask_redirect() {
@@ -3474,6 +3636,42 @@ mod cluster_async {
         assert_eq!(value, Ok(Some(123)));
     }
 
+    #[test]
+    #[serial_test::serial]
+    fn test_async_cluster_records_the_attempts_of_a_command() {
+        let name = "records_the_attempts_of_a_command";
+        let MockEnv {
+            async_connection: mut connection,
+            handler: _handler,
+            runtime,
+            ..
+        } = MockEnv::with_client_builder(
+            ClusterClient::builder(vec![&*format!("redis://{name}")]),
+            name,
+            move |cmd: &[u8], port| {
+                respond_startup_two_nodes(name, cmd)?;
+                if contains_slice(cmd, b"ASKING") {
+                    return Err(Ok(Value::Okay));
+                }
+                match port {
+                    6379 => Err(parse_redis_value(
+                        format!("-ASK 14000 {name}:6380\r\n").as_bytes(),
+                    )),
+                    _ => Err(Ok(Value::BulkString(b"123".to_vec()))),
+                }
+            },
+        );
+
+        let execution_info = Arc::new(redis::ExecutionInfo::default());
+        let mut get = cmd("GET");
+        get.arg("test").set_execution_info(execution_info.clone());
+        let value = runtime.block_on(get.query_async::<_, Option<i32>>(&mut connection));
+
+        assert_eq!(value, Ok(Some(123)));
+        assert_eq!(execution_info.attempts(), 2);
+        assert_eq!(execution_info.node(), Some(format!("{name}:6380")));
+    }
+
     #[test]
     #[serial_test::serial]
     fn test_async_cluster_ask_save_new_connection() {
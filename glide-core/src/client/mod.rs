@@ -27,7 +27,7 @@ use std::sync::Arc;
 use std::sync::atomic::{AtomicIsize, Ordering};
 use std::thread;
 use std::thread::JoinHandle;
-use std::time::Duration;
+use std::time::{Duration, Instant};
 use tokio::runtime::{Builder, Handle};
 pub use types::*;
 
@@ -242,6 +242,24 @@ pub enum ClientWrapper {
     Lazy(Box<LazyClient>),
 }
 
+/// Releases the inflight slot of a request when dropped, unless disarmed once
+/// the request completed or timed out.
+struct ReleaseOnAbandon(Option<redis::cluster_async::InflightRequestTracker>);
+
+impl ReleaseOnAbandon {
+    fn disarm(&mut self) {
+        self.0 = None;
+    }
+}
+
+impl Drop for ReleaseOnAbandon {
+    fn drop(&mut self) {
+        if let Some(tracker) = self.0.take() {
+            tracker.release();
+        }
+    }
+}
+
 /// A client wrapper that defers connection until the first command is executed.
 #[derive(Clone)]
 pub struct LazyClient {
@@ -262,6 +280,8 @@ pub struct Client {
     compression_manager: Option<Arc<CompressionManager>>,
     pubsub_synchronizer: Arc<dyn PubSubSynchronizer>,
     otel_metadata: types::OTelMetadata,
+    // When set, pipelines are never retried, regardless of their retry strategy
+    disable_retries: bool,
 }
 
 async fn run_with_timeout<T>(
@@ -369,8 +389,18 @@ fn get_timeout_from_cmd_arg(
 }
 
 fn get_request_timeout(cmd: &Cmd, default_timeout: Duration) -> RedisResult<Option<Duration>> {
+    match get_request_timeout_option(cmd)? {
+        RequestTimeoutOption::NoTimeout => Ok(None),
+        RequestTimeoutOption::ClientConfig => Ok(Some(default_timeout)),
+        RequestTimeoutOption::BlockingCommand(blocking_cmd_duration) => {
+            Ok(Some(blocking_cmd_duration))
+        }
+    }
+}
+
+fn get_request_timeout_option(cmd: &Cmd) -> RedisResult<RequestTimeoutOption> {
     let command = cmd.command().unwrap_or_default();
-    let timeout = match command.as_slice() {
+    match command.as_slice() {
         b"BLPOP" | b"BRPOP" | b"BLMOVE" | b"BZPOPMAX" | b"BZPOPMIN" | b"BRPOPLPUSH" => {
             get_timeout_from_cmd_arg(cmd, cmd.args_iter().len() - 1, TimeUnit::Seconds)
         }
@@ -387,15 +417,9 @@ fn get_request_timeout(cmd: &Cmd, default_timeout: Duration) -> RedisResult<Opti
             };
             get_timeout_from_cmd_arg(cmd, idx, TimeUnit::Milliseconds)
         }
+        // MIGRATE host port key|"" destination-db timeout
+        b"MIGRATE" => get_timeout_from_cmd_arg(cmd, 5, TimeUnit::Milliseconds),
         _ => Ok(RequestTimeoutOption::ClientConfig),
-    }?;
-
-    match timeout {
-        RequestTimeoutOption::NoTimeout => Ok(None),
-        RequestTimeoutOption::ClientConfig => Ok(Some(default_timeout)),
-        RequestTimeoutOption::BlockingCommand(blocking_cmd_duration) => {
-            Ok(Some(blocking_cmd_duration))
-        }
     }
 }
 
@@ -927,11 +951,26 @@ impl Client {
             }
 
             let request_timeout = get_request_timeout(cmd, self.request_timeout)?;
+            // The cluster client splits the remaining time across the retries of the command, when configured to.
+            // The deadline is the earliest of the request timeout and the deadline of the caller, if it set one.
+            // The blocking commands are not split, as an attempt must wait for their whole timeout.
+            match (request_timeout, get_request_timeout_option(cmd)?) {
+                (Some(duration), RequestTimeoutOption::ClientConfig) => {
+                    let deadline = Instant::now() + duration;
+                    cmd.set_deadline(
+                        cmd.deadline()
+                            .map_or(deadline, |caller| caller.min(deadline)),
+                    );
+                }
+                _ => {
+                    cmd.clear_deadline();
+                }
+            }
 
             // Reserve an inflight slot. The tracker holds the slot until the
             // last clone of the Cmd is dropped (i.e. all sub-commands in the
-            // cluster event loop finish). This decouples user-facing timeout
-            // from internal pipeline cleanup.
+            // cluster event loop finish), or until the request is cancelled.
+            // This decouples user-facing timeout from internal pipeline cleanup.
             let tracker = match self.reserve_inflight_request() {
                 Some(t) => t,
                 None => {
@@ -962,6 +1001,10 @@ impl Client {
                 }
             }
 
+            // If this future is dropped before the command completes, i.e. the
+            // caller cancelled the request, the slot is released right away even
+            // though the event loop still holds the Cmd.
+            let mut abandoned = ReleaseOnAbandon(Some(tracker.clone()));
             cmd.set_inflight_tracker(tracker);
 
             // Clone compression_manager reference only if compression is enabled
@@ -981,7 +1024,7 @@ impl Client {
                 compression_manager,
             );
 
-            match request_timeout {
+            let result = match request_timeout {
                 Some(duration) => {
                     tokio::pin!(execute);
                     tokio::select! {
@@ -1002,7 +1045,9 @@ impl Client {
                     }
                 }
                 None => execute.await,
-            }
+            };
+            abandoned.disarm();
+            result
         })
     }
 
@@ -1213,6 +1258,11 @@ impl Client {
     ) -> redis::RedisFuture<'a, Value> {
         Box::pin(async move {
             let client = self.get_or_initialize_client().await?;
+            let pipeline_retry_strategy = if self.disable_retries {
+                PipelineRetryStrategy::new(false, false)
+            } else {
+                pipeline_retry_strategy
+            };
 
             let command_count = pipeline.cmd_iter().count();
             if pipeline.is_empty() {
@@ -1629,7 +1679,11 @@ async fn create_cluster_client(
 
     let mut builder = redis::cluster::ClusterClientBuilder::new(initial_nodes)
         .connection_timeout(connection_timeout)
-        .retries(DEFAULT_RETRIES);
+        .retries(if request.disable_retries {
+            0
+        } else {
+            DEFAULT_RETRIES
+        });
     let read_from_strategy = request.read_from.unwrap_or_default();
     builder = builder.read_from(match read_from_strategy {
         ReadFrom::AZAffinity(az) => ReadFromReplicaStrategy::AZAffinity(az),
@@ -1678,6 +1732,10 @@ async fn create_cluster_client(
 
     builder = builder.tcp_nodelay(request.tcp_nodelay);
 
+    if let Some(min_attempt_timeout) = request.min_attempt_timeout {
+        builder = builder.deadline_splitting(Duration::from_millis(min_attempt_timeout as u64));
+    }
+
     // Always use with Glide
     builder = builder.periodic_connections_checks(Some(CONNECTION_CHECKS_INTERVAL));
 
@@ -1872,8 +1930,17 @@ fn sanitized_request_string(request: &ConnectionRequest) -> String {
         request.inflight_requests_limit,
     );
 
+    let disable_retries = if request.disable_retries {
+        "\nRetries: Disabled"
+    } else {
+        ""
+    };
+
+    let min_attempt_timeout =
+        format_optional_value("Minimum attempt timeout (ms)", request.min_attempt_timeout);
+
     format!(
-        "\nAddresses: {addresses}{tls_mode}{cluster_mode}{request_timeout}{connection_timeout}{rfr_strategy}{connection_retry_strategy}{database_id}{protocol}{client_name}{periodic_checks}{pubsub_subscriptions}{inflight_requests_limit}",
+        "\nAddresses: {addresses}{tls_mode}{cluster_mode}{request_timeout}{connection_timeout}{rfr_strategy}{connection_retry_strategy}{database_id}{protocol}{client_name}{periodic_checks}{pubsub_subscriptions}{inflight_requests_limit}{disable_retries}{min_attempt_timeout}",
     )
 }
 
@@ -1981,6 +2048,7 @@ impl Client {
                 iam_token_manager: None,
                 pubsub_synchronizer: pubsub_synchronizer.clone(),
                 otel_metadata,
+                disable_retries: request.disable_retries,
             };
 
             let client_arc = Arc::new(RwLock::new(client));
@@ -2291,6 +2359,24 @@ mod tests {
             ))
         );
 
+        // MIGRATE
+        let mut cmd = Cmd::new();
+        cmd.arg("MIGRATE")
+            .arg("host")
+            .arg(6379)
+            .arg("")
+            .arg(0)
+            .arg("5000")
+            .arg("KEYS")
+            .arg("key");
+        let result = get_request_timeout(&cmd, Duration::from_millis(250)).unwrap();
+        assert_eq!(
+            result,
+            Some(Duration::from_secs_f64(
+                5.0 + BLOCKING_CMD_TIMEOUT_EXTENSION
+            ))
+        );
+
         // Infinite block (0) — returns None (no client timeout)
         let mut cmd = Cmd::new();
         cmd.arg("BLPOP").arg("key").arg("0");
@@ -2430,6 +2516,7 @@ mod tests {
                 },
                 db_namespace: "0".to_string(),
             },
+            disable_retries: false,
         }
     }
 
@@ -526,6 +526,9 @@ impl StandaloneClient {
         reconnecting_connection: &ReconnectingConnection,
     ) -> RedisResult<Value> {
         let mut connection = reconnecting_connection.get_connection().await?;
+        if let Some(execution_info) = cmd.execution_info() {
+            execution_info.record_attempt(&reconnecting_connection.node_address());
+        }
         let result = connection.send_packed_command(cmd).await;
         match result {
             Err(err) if err.is_unrecoverable_error() => {
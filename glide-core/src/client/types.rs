@@ -9,6 +9,7 @@ use std::time::Duration;
 #[cfg(feature = "proto")]
 use crate::compression::CompressionBackendType;
 use crate::compression::CompressionConfig;
+use crate::compression::CompressionPrefixRule;
 #[cfg(feature = "proto")]
 use crate::connection_request as protobuf;
 use crate::iam::ServiceType;
@@ -42,6 +43,11 @@ pub struct ConnectionRequest {
     pub tcp_nodelay: bool,
     pub pubsub_reconciliation_interval_ms: Option<u32>,
     pub read_only: bool,
+    /// When set, requests are never retried by the client, e.g. after a redirection or a reconnection.
+    pub disable_retries: bool,
+    /// When set, the request timeout of a command of a cluster client is split across its attempts, each attempt
+    /// being timed out after at least this number of milliseconds.
+    pub min_attempt_timeout: Option<u32>,
 }
 
 /// Default connection timeout used when not specified in the request.
@@ -321,26 +327,39 @@ impl From<protobuf::ConnectionRequest> for ConnectionRequest {
 
         // Convert protobuf compression config to internal compression config
         let compression_config = value.compression_config.as_ref().map(|proto_config| {
-            let backend = match proto_config.backend.enum_value() {
+            let to_backend = |backend: EnumOrUnknown<protobuf::CompressionBackend>| match backend
+                .enum_value()
+            {
                 Ok(protobuf::CompressionBackend::ZSTD) => CompressionBackendType::Zstd,
                 Ok(protobuf::CompressionBackend::LZ4) => CompressionBackendType::Lz4,
                 Err(_) => {
                     log_warn(
                         "types",
-                        format!(
-                            "Unknown compression backend: {:?}. Falling back to Zstd",
-                            proto_config.backend
-                        ),
+                        format!("Unknown compression backend: {backend:?}. Falling back to Zstd"),
                     );
                     CompressionBackendType::Zstd
                 }
             };
+            let backend = to_backend(proto_config.backend);
+
+            let prefix_rules = proto_config
+                .prefix_rules
+                .iter()
+                .map(|rule| CompressionPrefixRule {
+                    prefix: rule.prefix.to_vec(),
+                    enabled: rule.enabled,
+                    backend: rule.backend.map(to_backend),
+                    compression_level: rule.compression_level,
+                    min_compression_size: rule.min_compression_size.map(|size| size as usize),
+                })
+                .collect();
 
             CompressionConfig {
                 enabled: proto_config.enabled,
                 backend,
                 compression_level: proto_config.compression_level,
                 min_compression_size: proto_config.min_compression_size as usize,
+                prefix_rules,
             }
         });
 
@@ -348,6 +367,8 @@ impl From<protobuf::ConnectionRequest> for ConnectionRequest {
         let pubsub_reconciliation_interval_ms =
             value.pubsub_reconciliation_interval_ms.filter(|&v| v != 0);
         let read_only = value.read_only.unwrap_or(false);
+        let disable_retries = value.disable_retries.unwrap_or(false);
+        let min_attempt_timeout = value.min_attempt_timeout.filter(|&v| v != 0);
 
         ConnectionRequest {
             read_from,
@@ -374,6 +395,8 @@ impl From<protobuf::ConnectionRequest> for ConnectionRequest {
             tcp_nodelay,
             pubsub_reconciliation_interval_ms,
             read_only,
+            disable_retries,
+            min_attempt_timeout,
         }
     }
 }
@@ -454,6 +477,51 @@ mod tests {
             assert_eq!(config.min_compression_size, 64);
         }
 
+        #[test]
+        fn test_compression_config_conversion_prefix_rules() {
+            let mut proto_request = protobuf::ConnectionRequest::new();
+            proto_request.addresses.push(protobuf::NodeAddress {
+                host: "localhost".into(),
+                port: 6379,
+                ..Default::default()
+            });
+
+            let mut compression_config = protobuf::CompressionConfig::new();
+            compression_config.enabled = true;
+            compression_config.backend = protobuf::CompressionBackend::ZSTD.into();
+            compression_config.min_compression_size = 64;
+
+            let mut sessions_rule = protobuf::CompressionPrefixRule::new();
+            sessions_rule.prefix = b"session:".to_vec().into();
+            sessions_rule.enabled = true;
+            sessions_rule.backend = Some(protobuf::CompressionBackend::LZ4.into());
+            sessions_rule.min_compression_size = Some(512);
+            compression_config.prefix_rules.push(sessions_rule);
+
+            let mut counters_rule = protobuf::CompressionPrefixRule::new();
+            counters_rule.prefix = b"counter:".to_vec().into();
+            counters_rule.enabled = false;
+            compression_config.prefix_rules.push(counters_rule);
+
+            proto_request.compression_config = ::protobuf::MessageField::some(compression_config);
+
+            let request: ConnectionRequest = proto_request.into();
+            let config = request.compression_config.unwrap();
+            assert_eq!(config.prefix_rules.len(), 2);
+
+            let sessions = &config.prefix_rules[0];
+            assert_eq!(sessions.prefix, b"session:".to_vec());
+            assert!(sessions.enabled);
+            assert_eq!(sessions.backend, Some(CompressionBackendType::Lz4));
+            assert_eq!(sessions.compression_level, None);
+            assert_eq!(sessions.min_compression_size, Some(512));
+
+            let counters = &config.prefix_rules[1];
+            assert!(!counters.enabled);
+            assert_eq!(counters.backend, None);
+            assert_eq!(counters.min_compression_size, None);
+        }
+
         #[test]
         fn test_compression_config_conversion_unknown_backend() {
             let mut proto_request = protobuf::ConnectionRequest::new();
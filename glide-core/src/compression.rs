@@ -213,12 +213,63 @@ impl std::str::FromStr for CompressionBackendType {
     }
 }
 
+/// Overrides the compression settings for the values of the keys starting with `prefix`.
+/// The settings left unset are the ones of the enclosing [`CompressionConfig`].
+#[derive(Debug, Clone, PartialEq)]
+pub struct CompressionPrefixRule {
+    pub prefix: Vec<u8>,
+    pub enabled: bool,
+    pub backend: Option<CompressionBackendType>,
+    pub compression_level: Option<i32>,
+    pub min_compression_size: Option<usize>,
+}
+
+impl CompressionPrefixRule {
+    pub fn new(prefix: impl Into<Vec<u8>>) -> Self {
+        Self {
+            prefix: prefix.into(),
+            enabled: true,
+            backend: None,
+            compression_level: None,
+            min_compression_size: None,
+        }
+    }
+
+    pub fn disabled(prefix: impl Into<Vec<u8>>) -> Self {
+        Self {
+            enabled: false,
+            ..Self::new(prefix)
+        }
+    }
+
+    pub fn with_backend(mut self, backend: CompressionBackendType) -> Self {
+        self.backend = Some(backend);
+        self
+    }
+
+    pub fn with_compression_level(mut self, level: Option<i32>) -> Self {
+        self.compression_level = level;
+        self
+    }
+
+    pub fn with_min_compression_size(mut self, size: usize) -> Self {
+        self.min_compression_size = Some(size);
+        self
+    }
+
+    /// The name the statistics of the rule are recorded under
+    pub fn name(&self) -> String {
+        String::from_utf8_lossy(&self.prefix).into_owned()
+    }
+}
+
 #[derive(Debug, Clone, PartialEq)]
 pub struct CompressionConfig {
     pub enabled: bool,
     pub backend: CompressionBackendType,
     pub compression_level: Option<i32>,
     pub min_compression_size: usize,
+    pub prefix_rules: Vec<CompressionPrefixRule>,
 }
 
 impl CompressionConfig {
@@ -228,6 +279,7 @@ impl CompressionConfig {
             backend,
             compression_level: backend.default_level(),
             min_compression_size: 64,
+            prefix_rules: Vec::new(),
         }
     }
 
@@ -237,6 +289,7 @@ impl CompressionConfig {
             backend: CompressionBackendType::Zstd,
             compression_level: None,
             min_compression_size: 64,
+            prefix_rules: Vec::new(),
         }
     }
 
@@ -250,6 +303,11 @@ impl CompressionConfig {
         self
     }
 
+    pub fn with_prefix_rule(mut self, rule: CompressionPrefixRule) -> Self {
+        self.prefix_rules.push(rule);
+        self
+    }
+
     pub fn validate(&self) -> CompressionResult<()> {
         if self.min_compression_size < MIN_COMPRESSED_SIZE {
             return Err(CompressionError::invalid_configuration(
@@ -261,9 +319,50 @@ impl CompressionConfig {
             ));
         }
 
+        for (i, rule) in self.prefix_rules.iter().enumerate() {
+            let backend = rule.backend.unwrap_or(self.backend);
+            if rule.prefix.is_empty() {
+                return Err(CompressionError::invalid_configuration(
+                    backend.backend_name(),
+                    "prefix rules must have a non-empty prefix",
+                ));
+            }
+            if self.prefix_rules[..i]
+                .iter()
+                .any(|other| other.prefix == rule.prefix)
+            {
+                return Err(CompressionError::invalid_configuration(
+                    backend.backend_name(),
+                    format!("duplicate prefix rule for prefix '{}'", rule.name()),
+                ));
+            }
+            if let Some(size) = rule
+                .min_compression_size
+                .filter(|&size| size < MIN_COMPRESSED_SIZE)
+            {
+                return Err(CompressionError::invalid_configuration(
+                    backend.backend_name(),
+                    format!(
+                        "min_compression_size ({}) of prefix '{}' must be at least {}",
+                        size,
+                        rule.name(),
+                        MIN_COMPRESSED_SIZE
+                    ),
+                ));
+            }
+        }
+
         Ok(())
     }
 
+    /// Returns the prefix rule with the longest prefix matching `key`, if any
+    pub fn prefix_rule_for_key(&self, key: &[u8]) -> Option<&CompressionPrefixRule> {
+        self.prefix_rules
+            .iter()
+            .filter(|rule| key.starts_with(&rule.prefix))
+            .max_by_key(|rule| rule.prefix.len())
+    }
+
     pub fn should_compress(&self, data_size: usize) -> bool {
         self.enabled && data_size >= self.min_compression_size
     }
@@ -306,10 +405,32 @@ impl std::fmt::Display for CommandCompressionBehavior {
     }
 }
 
+/// A prefix rule with the settings it inherits resolved
+#[derive(Debug)]
+struct ResolvedPrefixRule {
+    prefix: Vec<u8>,
+    name: String,
+    enabled: bool,
+    backend: &'static dyn CompressionBackend,
+    compression_level: Option<i32>,
+    min_compression_size: usize,
+}
+
+/// The outcome of an attempt to compress a value
+enum CompressionOutcome {
+    Compressed {
+        original_bytes: usize,
+        compressed_bytes: usize,
+    },
+    SkippedSmall,
+    Skipped,
+}
+
 #[derive(Debug)]
 pub struct CompressionManager {
     backend: Box<dyn CompressionBackend>,
     config: CompressionConfig,
+    prefix_rules: Vec<ResolvedPrefixRule>,
 }
 
 impl CompressionManager {
@@ -334,7 +455,43 @@ impl CompressionManager {
         // Validate compression level using backend-specific validation
         backend.validate_compression_level(config.compression_level)?;
 
-        Ok(Self { backend, config })
+        let prefix_rules = config
+            .prefix_rules
+            .iter()
+            .map(|rule| Self::resolve_prefix_rule(&config, rule))
+            .collect::<CompressionResult<Vec<_>>>()?;
+
+        Ok(Self {
+            backend,
+            config,
+            prefix_rules,
+        })
+    }
+
+    fn resolve_prefix_rule(
+        config: &CompressionConfig,
+        rule: &CompressionPrefixRule,
+    ) -> CompressionResult<ResolvedPrefixRule> {
+        let backend_type = rule.backend.unwrap_or(config.backend);
+        // A rule switching backends doesn't inherit a level meant for another backend
+        let compression_level = match rule.compression_level {
+            Some(level) => Some(level),
+            None if backend_type == config.backend => config.compression_level,
+            None => backend_type.default_level(),
+        };
+        let backend = get_backend_for_decompression(backend_type.backend_id())?;
+        backend.validate_compression_level(compression_level)?;
+
+        Ok(ResolvedPrefixRule {
+            prefix: rule.prefix.clone(),
+            name: rule.name(),
+            enabled: rule.enabled,
+            backend,
+            compression_level,
+            min_compression_size: rule
+                .min_compression_size
+                .unwrap_or(config.min_compression_size),
+        })
     }
 
     pub fn should_compress(&self, data: &[u8]) -> bool {
@@ -343,33 +500,98 @@ impl CompressionManager {
 
     /// Attempts to compress the value with graceful fallback to original data
     pub fn compress_value<'a>(&self, value: &'a [u8]) -> Cow<'a, [u8]> {
-        if !self.config.enabled || !self.should_compress(value) {
+        let (result, _) = Self::compress_with(
+            self.backend.as_ref(),
+            self.config.compression_level,
+            self.config.enabled,
+            self.config.min_compression_size,
+            value,
+        );
+        result
+    }
+
+    /// Attempts to compress the value of `key` with the settings of the prefix rule with the longest
+    /// prefix matching `key`, or of the configuration if none does, with graceful fallback to original data.
+    /// The outcome is recorded in the statistics of the prefix rule, if any.
+    pub fn compress_value_for_key<'a>(&self, key: &[u8], value: &'a [u8]) -> Cow<'a, [u8]> {
+        let Some(rule) = self
+            .prefix_rules
+            .iter()
+            .filter(|rule| key.starts_with(&rule.prefix))
+            .max_by_key(|rule| rule.prefix.len())
+        else {
+            return self.compress_value(value);
+        };
+
+        let (result, outcome) = Self::compress_with(
+            rule.backend,
+            rule.compression_level,
+            rule.enabled,
+            rule.min_compression_size,
+            value,
+        );
+        Telemetry::update_prefix_compression_stats(&rule.name, |stats| match outcome {
+            CompressionOutcome::Compressed {
+                original_bytes,
+                compressed_bytes,
+            } => {
+                stats.values_compressed = stats.values_compressed.saturating_add(1);
+                stats.original_bytes = stats.original_bytes.saturating_add(original_bytes);
+                stats.bytes_compressed = stats.bytes_compressed.saturating_add(compressed_bytes);
+            }
+            CompressionOutcome::SkippedSmall => {
+                stats.skipped_small_count = stats.skipped_small_count.saturating_add(1);
+            }
+            CompressionOutcome::Skipped => {
+                stats.skipped_count = stats.skipped_count.saturating_add(1);
+            }
+        });
+        result
+    }
+
+    fn compress_with<'a>(
+        backend: &dyn CompressionBackend,
+        compression_level: Option<i32>,
+        enabled: bool,
+        min_compression_size: usize,
+        value: &'a [u8],
+    ) -> (Cow<'a, [u8]>, CompressionOutcome) {
+        if !enabled {
             Telemetry::incr_compression_skipped_count(1);
-            return Cow::Borrowed(value);
+            return (Cow::Borrowed(value), CompressionOutcome::Skipped);
         }
 
-        if self.backend.is_compressed(value) {
+        if value.len() < min_compression_size {
             Telemetry::incr_compression_skipped_count(1);
-            return Cow::Borrowed(value);
+            return (Cow::Borrowed(value), CompressionOutcome::SkippedSmall);
         }
 
-        match self.backend.compress(value, self.config.compression_level) {
+        if backend.is_compressed(value) {
+            Telemetry::incr_compression_skipped_count(1);
+            return (Cow::Borrowed(value), CompressionOutcome::Skipped);
+        }
+
+        match backend.compress(value, compression_level) {
             Ok(compressed) => {
                 if compressed.len() < value.len() {
                     // Successfully compressed and reduced size
                     Telemetry::incr_total_values_compressed(1);
                     Telemetry::incr_total_original_bytes(value.len());
                     Telemetry::incr_total_bytes_compressed(compressed.len());
-                    Cow::Owned(compressed)
+                    let outcome = CompressionOutcome::Compressed {
+                        original_bytes: value.len(),
+                        compressed_bytes: compressed.len(),
+                    };
+                    (Cow::Owned(compressed), outcome)
                 } else {
                     // Compression didn't reduce size, skip it
                     Telemetry::incr_compression_skipped_count(1);
-                    Cow::Borrowed(value)
+                    (Cow::Borrowed(value), CompressionOutcome::Skipped)
                 }
             }
             Err(_) => {
                 Telemetry::incr_compression_skipped_count(1);
-                Cow::Borrowed(value)
+                (Cow::Borrowed(value), CompressionOutcome::Skipped)
             }
         }
     }
@@ -730,7 +952,11 @@ fn compress_single_value_command(
         return Ok(());
     }
 
-    let compressed_value = manager.compress_value(&args[value_index]);
+    // The key precedes the value, and selects the prefix rule the value is compressed with
+    let compressed_value = match value_index.checked_sub(1) {
+        Some(key_index) => manager.compress_value_for_key(&args[key_index], &args[value_index]),
+        None => manager.compress_value(&args[value_index]),
+    };
     args[value_index] = compressed_value.into_owned();
     Ok(())
 }
@@ -1,5 +1,6 @@
 use lazy_static::lazy_static;
 use serde::Serialize;
+use std::collections::HashMap;
 use std::sync::RwLock as StdRwLock;
 mod metrics_exporter_file;
 mod open_telemetry;
@@ -9,6 +10,22 @@ pub use metrics_exporter_file::FileMetricExporter;
 pub use open_telemetry::*;
 pub use span_exporter_file::SpanExporterFile;
 
+/// Compression statistics of the values of the keys matching a compression prefix rule
+#[derive(Default, Serialize, Clone, Debug, PartialEq)]
+pub struct PrefixCompressionStats {
+    /// Number of values compressed
+    pub values_compressed: usize,
+    /// Original bytes of the values compressed
+    pub original_bytes: usize,
+    /// Bytes of the values compressed after compression
+    pub bytes_compressed: usize,
+    /// Number of values not compressed because they were smaller than the minimum compression size
+    pub skipped_small_count: usize,
+    /// Number of values not compressed for another reason, e.g. because compression is disabled for
+    /// the prefix or didn't reduce their size
+    pub skipped_count: usize,
+}
+
 #[derive(Default, Serialize)]
 #[allow(dead_code)]
 pub struct Telemetry {
@@ -32,6 +49,8 @@ pub struct Telemetry {
     subscription_out_of_sync_count: usize,
     /// Unix timestamp (in milliseconds) of the last time subscriptions were in sync
     subscription_last_sync_timestamp: u64,
+    /// Compression statistics by compression prefix rule
+    prefix_compression: HashMap<String, PrefixCompressionStats>,
 }
 
 lazy_static! {
@@ -170,6 +189,37 @@ impl Telemetry {
             .expect(MUTEX_READ_ERR)
             .compression_skipped_count
     }
+
+    /// Update the compression statistics of the prefix rule `prefix` with `update`
+    pub fn update_prefix_compression_stats(
+        prefix: &str,
+        update: impl FnOnce(&mut PrefixCompressionStats),
+    ) {
+        let mut t = TELEMETRY.write().expect(MUTEX_WRITE_ERR);
+        match t.prefix_compression.get_mut(prefix) {
+            Some(stats) => update(stats),
+            None => {
+                let mut stats = PrefixCompressionStats::default();
+                update(&mut stats);
+                t.prefix_compression.insert(prefix.to_string(), stats);
+            }
+        }
+    }
+
+    /// Return the compression statistics by prefix rule
+    pub fn prefix_compression_stats() -> HashMap<String, PrefixCompressionStats> {
+        TELEMETRY
+            .read()
+            .expect(MUTEX_READ_ERR)
+            .prefix_compression
+            .clone()
+    }
+
+    /// Return the compression statistics by prefix rule, as a JSON object by prefix
+    pub fn prefix_compression_stats_json() -> String {
+        let t = TELEMETRY.read().expect(MUTEX_READ_ERR);
+        serde_json::to_string(&t.prefix_compression).unwrap_or_else(|_| "{}".to_string())
+    }
     /// Increment the subscription out of sync count
     /// Return the new count after increment
     pub fn incr_subscription_out_of_sync() -> usize {
@@ -1416,6 +1416,60 @@ pub(crate) mod shared_client_tests {
         });
     }
 
+    #[rstest]
+    #[serial_test::serial]
+    #[timeout(SHORT_CLUSTER_TEST_TIMEOUT)]
+    fn test_cancelled_requests_release_inflight_slots(#[values(false, true)] use_cluster: bool) {
+        // Requests the caller stops waiting for are still held by the internal pipeline until the server
+        // replies. We test that dropping them releases their inflight slots right away, so that more requests
+        // than the inflight limit can be cancelled while the server doesn't reply, and new requests are still admitted.
+        block_on_all(async {
+            let mut test_basics = setup_test_basics(
+                use_cluster,
+                TestConfiguration {
+                    request_timeout: Some(10_000), // milliseconds
+                    shared_server: false,
+                    ..Default::default()
+                },
+            )
+            .await;
+
+            // Pause the server, so that no request completes while they are cancelled.
+            let mut cmd = redis::cmd("CLIENT");
+            cmd.arg("PAUSE").arg(2000);
+            test_basics
+                .client
+                .send_command(
+                    &mut cmd,
+                    Some(RoutingInfo::MultiNode((
+                        MultipleNodeRoutingInfo::AllNodes,
+                        None,
+                    ))),
+                )
+                .await
+                .unwrap();
+
+            let limit = glide_core::client::DEFAULT_MAX_INFLIGHT_REQUESTS as isize;
+            for _ in 0..=limit {
+                let mut client = test_basics.client.clone();
+                let request = async move {
+                    let mut cmd = redis::cmd("GET");
+                    cmd.arg(generate_random_string(10));
+                    client.send_command(&mut cmd, None).await
+                };
+                // The request is sent, then dropped before the server replies.
+                let result =
+                    tokio::time::timeout(std::time::Duration::from_millis(1), request).await;
+                assert!(result.is_err(), "Received {result:?}");
+            }
+            assert_eq!(test_basics.client.available_inflight_count(), limit);
+
+            let mut cmd = redis::cmd("PING");
+            let result = test_basics.client.send_command(&mut cmd, None).await;
+            assert!(result.is_ok(), "Received {result:?}");
+        });
+    }
+
     #[rstest]
     #[serial_test::serial]
     #[timeout(SHORT_CLUSTER_TEST_TIMEOUT)]
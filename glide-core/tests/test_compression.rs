@@ -652,6 +652,91 @@ mod compression_tests {
         assert!(manager.is_enabled());
     }
 
+    #[test]
+    fn test_compression_manager_prefix_rules() {
+        use glide_core::Telemetry;
+        use glide_core::compression::zstd_backend::ZstdBackend;
+
+        let config = CompressionConfig::new(CompressionBackendType::Zstd)
+            .with_min_compression_size(64)
+            .with_prefix_rule(
+                CompressionPrefixRule::new("prefix-rules-test:large:")
+                    .with_min_compression_size(256),
+            )
+            .with_prefix_rule(
+                CompressionPrefixRule::new("prefix-rules-test:lz4:")
+                    .with_backend(CompressionBackendType::Lz4),
+            )
+            .with_prefix_rule(CompressionPrefixRule::disabled(
+                "prefix-rules-test:lz4:disabled:",
+            ));
+        let manager = CompressionManager::new(Box::new(ZstdBackend::new()), config).unwrap();
+
+        let value = "A".repeat(128);
+
+        // Keys without a rule use the configuration
+        let result = manager.compress_value_for_key(b"prefix-rules-test:other", value.as_bytes());
+        assert_eq!(extract_backend_id(&result), Some(0x01));
+
+        // The rule's threshold applies to its keys
+        let result = manager.compress_value_for_key(b"prefix-rules-test:large:1", value.as_bytes());
+        assert_eq!(result, value.as_bytes());
+        let large_value = "A".repeat(512);
+        let result =
+            manager.compress_value_for_key(b"prefix-rules-test:large:2", large_value.as_bytes());
+        assert!(has_magic_header(&result));
+
+        // The rule's backend applies to its keys
+        let result = manager.compress_value_for_key(b"prefix-rules-test:lz4:1", value.as_bytes());
+        assert_eq!(extract_backend_id(&result), Some(0x02));
+        assert_eq!(manager.decompress_value(&result).unwrap(), value.as_bytes());
+
+        // The longest matching prefix wins
+        let result =
+            manager.compress_value_for_key(b"prefix-rules-test:lz4:disabled:1", value.as_bytes());
+        assert_eq!(result, value.as_bytes());
+
+        let stats = Telemetry::prefix_compression_stats();
+        let large = &stats["prefix-rules-test:large:"];
+        assert_eq!(large.values_compressed, 1);
+        assert_eq!(large.original_bytes, 512);
+        assert!(large.bytes_compressed < 512);
+        assert_eq!(large.skipped_small_count, 1);
+        assert_eq!(stats["prefix-rules-test:lz4:"].values_compressed, 1);
+        assert_eq!(stats["prefix-rules-test:lz4:disabled:"].skipped_count, 1);
+        assert!(!stats.contains_key("prefix-rules-test:other"));
+    }
+
+    #[test]
+    fn test_compression_config_prefix_rules_validation() {
+        let config = CompressionConfig::new(CompressionBackendType::Zstd)
+            .with_prefix_rule(CompressionPrefixRule::new("a:").with_min_compression_size(4));
+        assert!(config.validate().is_err());
+
+        let config = CompressionConfig::new(CompressionBackendType::Zstd)
+            .with_prefix_rule(CompressionPrefixRule::new("a:"))
+            .with_prefix_rule(CompressionPrefixRule::disabled("a:"));
+        assert!(config.validate().is_err());
+
+        let config = CompressionConfig::new(CompressionBackendType::Zstd)
+            .with_prefix_rule(CompressionPrefixRule::new(""));
+        assert!(config.validate().is_err());
+
+        let config = CompressionConfig::new(CompressionBackendType::Zstd)
+            .with_prefix_rule(CompressionPrefixRule::new("a:"))
+            .with_prefix_rule(CompressionPrefixRule::new("a:b:"));
+        assert!(config.validate().is_ok());
+        assert_eq!(
+            config.prefix_rule_for_key(b"a:b:c").map(|rule| rule.name()),
+            Some("a:b:".to_string())
+        );
+        assert_eq!(
+            config.prefix_rule_for_key(b"a:c").map(|rule| rule.name()),
+            Some("a:".to_string())
+        );
+        assert!(config.prefix_rule_for_key(b"b:").is_none());
+    }
+
     #[test]
     fn test_compression_manager_decompress_scenarios() {
         use glide_core::compression::zstd_backend::ZstdBackend;
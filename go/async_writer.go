@@ -0,0 +1,264 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+)
+
+const (
+	// DefaultAsyncWriteQueueSize is the number of writes an [AsyncWriter] queues at most, unless configured otherwise.
+	DefaultAsyncWriteQueueSize = 10000
+	// DefaultAsyncWriteBatchSize is the number of writes an [AsyncWriter] sends in a pipeline at most, unless
+	// configured otherwise.
+	DefaultAsyncWriteBatchSize = 100
+)
+
+// ErrAsyncWriteQueueFull is returned by the writes of an [AsyncWriter] whose queue is full: the write is dropped.
+var ErrAsyncWriteQueueFull = errors.New("the queue of the asynchronous writes is full")
+
+// AsyncWriteCallback is called with the response of an asynchronous write, or its error, once it completes.
+type AsyncWriteCallback func(response any, err error)
+
+// AsyncWriterStats reports the writes of an [AsyncWriter].
+type AsyncWriterStats struct {
+	// Written is the number of writes which succeeded.
+	Written uint64
+	// Failed is the number of writes which failed.
+	Failed uint64
+	// Dropped is the number of writes dropped as the queue was full.
+	Dropped uint64
+	// Pending is the number of writes queued and not sent yet.
+	Pending int
+}
+
+// batchExecutor executes the batches of an [AsyncWriter], e.g. a [Client] or a [ClusterClient].
+type batchExecutor interface {
+	executeBatch(ctx context.Context, batch internal.Batch, raiseOnError bool, options *internal.BatchOptions) (
+		[]any, error)
+}
+
+// asyncWrite is a write queued by an [AsyncWriter].
+type asyncWrite struct {
+	command internal.Cmd
+	onDone  AsyncWriteCallback
+}
+
+// AsyncWriter sends fire-and-forget writes, e.g. best-effort telemetry: the writes are queued, and return right away
+// without waiting for their response, which is reported to their optional callback. The queued writes are sent in
+// order by a background goroutine, pipelined in non-atomic batches of up to the configured batch size, so that a
+// burst of writes costs a few round trips.
+//
+// The queue is bounded: a write issued while it's full is dropped, and fails with [ErrAsyncWriteQueueFull], rather
+// than blocking its caller. The writes are sent with the request timeout of the client.
+//
+// The writes are sent through the connections of the client of the writer, so that a writer over a client dedicated
+// to it, e.g. a [DedicatedClient], doesn't delay the requests of the other clients. CLIENT REPLY OFF isn't used to
+// spare the replies: the client matches each request with its reply, so a connection with replies off would fail its
+// requests once they time out.
+//
+// Example usage:
+//
+//	writer := glide.NewAsyncWriter(client)
+//	defer writer.Close()
+//
+//	_ = writer.IncrByAsync("hits:"+page, 1, nil)
+type AsyncWriter struct {
+	client    batchExecutor
+	batchSize int
+	start     sync.Once
+	queueSize int
+
+	mu      sync.RWMutex
+	queue   chan asyncWrite
+	closed  bool
+	stopped chan struct{}
+
+	written atomic.Uint64
+	failed  atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// NewAsyncWriter returns an [AsyncWriter] sending its writes through client, a [Client], a [ClusterClient] or a
+// [DedicatedClient].
+func NewAsyncWriter(client batchExecutor) *AsyncWriter {
+	return &AsyncWriter{
+		client:    client,
+		batchSize: DefaultAsyncWriteBatchSize,
+		queueSize: DefaultAsyncWriteQueueSize,
+		stopped:   make(chan struct{}),
+	}
+}
+
+// WithQueueSize sets the number of writes queued at most. Defaults to DefaultAsyncWriteQueueSize. It must be set
+// before the first write.
+func (writer *AsyncWriter) WithQueueSize(queueSize int) *AsyncWriter {
+	writer.queueSize = max(queueSize, 1)
+	return writer
+}
+
+// WithBatchSize sets the number of writes sent in a pipeline at most. Defaults to DefaultAsyncWriteBatchSize.
+func (writer *AsyncWriter) WithBatchSize(batchSize int) *AsyncWriter {
+	writer.batchSize = max(batchSize, 1)
+	return writer
+}
+
+// init starts the goroutine sending the writes.
+func (writer *AsyncWriter) init() {
+	writer.start.Do(func() {
+		writer.queue = make(chan asyncWrite, writer.queueSize)
+		go writer.run()
+	})
+}
+
+// SetAsync queues the write of value to key, see Set.
+func (writer *AsyncWriter) SetAsync(key string, value string, onDone AsyncWriteCallback) error {
+	return writer.enqueue(protobuf.RequestType_Set, []string{key, value}, onDone)
+}
+
+// SetWithTTLAsync queues the write of value to key with a time to live of ttl, with millisecond precision, see Set.
+func (writer *AsyncWriter) SetWithTTLAsync(key string, value string, ttl time.Duration, onDone AsyncWriteCallback) error {
+	if ttl < time.Millisecond {
+		return errors.New("the TTL must be at least 1ms")
+	}
+	return writer.enqueue(protobuf.RequestType_Set,
+		[]string{key, value, "PX", strconv.FormatInt(ttl.Milliseconds(), 10)}, onDone)
+}
+
+// DelAsync queues the delete of keys, see Del.
+func (writer *AsyncWriter) DelAsync(keys []string, onDone AsyncWriteCallback) error {
+	return writer.enqueue(protobuf.RequestType_Del, keys, onDone)
+}
+
+// IncrByAsync queues the increment of the number stored at key by amount, see IncrBy.
+func (writer *AsyncWriter) IncrByAsync(key string, amount int64, onDone AsyncWriteCallback) error {
+	return writer.enqueue(protobuf.RequestType_IncrBy, []string{key, strconv.FormatInt(amount, 10)}, onDone)
+}
+
+// HSetAsync queues the write of the fields of values to the hash stored at key, see HSet.
+func (writer *AsyncWriter) HSetAsync(key string, values map[string]string, onDone AsyncWriteCallback) error {
+	return writer.enqueue(protobuf.RequestType_HSet, utils.ConvertMapToKeyValueStringArray(key, values), onDone)
+}
+
+// HIncrByAsync queues the increment of field of the hash stored at key by amount, see HIncrBy.
+func (writer *AsyncWriter) HIncrByAsync(key string, field string, amount int64, onDone AsyncWriteCallback) error {
+	return writer.enqueue(protobuf.RequestType_HIncrBy, []string{key, field, strconv.FormatInt(amount, 10)}, onDone)
+}
+
+// PExpireAsync queues setting the time to live of key to ttl, with millisecond precision, see PExpire.
+func (writer *AsyncWriter) PExpireAsync(key string, ttl time.Duration, onDone AsyncWriteCallback) error {
+	return writer.enqueue(protobuf.RequestType_PExpire, []string{key, strconv.FormatInt(ttl.Milliseconds(), 10)}, onDone)
+}
+
+// CustomCommandAsync queues the command args, e.g. []string{"XADD", "events", "*", "type", "click"}, see
+// CustomCommand.
+func (writer *AsyncWriter) CustomCommandAsync(args []string, onDone AsyncWriteCallback) error {
+	if len(args) == 0 {
+		return errors.New("no command to write")
+	}
+	return writer.enqueue(protobuf.RequestType_CustomCommand, args, onDone)
+}
+
+// enqueue queues the command requestType of args, or returns [ErrAsyncWriteQueueFull] if the queue is full, or a
+// [ClosingError] if the writer is closed.
+func (writer *AsyncWriter) enqueue(requestType protobuf.RequestType, args []string, onDone AsyncWriteCallback) error {
+	writer.init()
+	write := asyncWrite{
+		command: internal.MakeCmd(uint32(requestType), args, func(response any) (any, error) { return response, nil }),
+		onDone:  onDone,
+	}
+	writer.mu.RLock()
+	defer writer.mu.RUnlock()
+	if writer.closed {
+		return NewClosingError("the asynchronous writer is closed")
+	}
+	select {
+	case writer.queue <- write:
+		return nil
+	default:
+		writer.dropped.Add(1)
+		return ErrAsyncWriteQueueFull
+	}
+}
+
+// run sends the queued writes by batches until the queue is closed.
+func (writer *AsyncWriter) run() {
+	defer close(writer.stopped)
+	writes := make([]asyncWrite, 0, writer.batchSize)
+	for write := range writer.queue {
+		writes = append(writes[:0], write)
+	drain:
+		for len(writes) < writer.batchSize {
+			select {
+			case write, ok := <-writer.queue:
+				if !ok {
+					break drain
+				}
+				writes = append(writes, write)
+			default:
+				break drain
+			}
+		}
+		writer.send(writes)
+	}
+}
+
+// send sends writes in a non-atomic batch, and reports their responses.
+func (writer *AsyncWriter) send(writes []asyncWrite) {
+	batch := internal.Batch{Commands: make([]internal.Cmd, len(writes))}
+	for i, write := range writes {
+		batch.Commands[i] = write.command
+	}
+	responses, err := writer.client.executeBatch(context.Background(), batch, false, nil)
+	for i, write := range writes {
+		var response any
+		writeErr := err
+		if err == nil {
+			response = responses[i]
+			if responseErr, ok := response.(error); ok {
+				response, writeErr = nil, responseErr
+			}
+		}
+		if writeErr != nil {
+			writer.failed.Add(1)
+		} else {
+			writer.written.Add(1)
+		}
+		if write.onDone != nil {
+			write.onDone(response, writeErr)
+		}
+	}
+}
+
+// Stats returns the writes of the writer.
+func (writer *AsyncWriter) Stats() AsyncWriterStats {
+	writer.init()
+	return AsyncWriterStats{
+		Written: writer.written.Load(),
+		Failed:  writer.failed.Load(),
+		Dropped: writer.dropped.Load(),
+		Pending: len(writer.queue),
+	}
+}
+
+// Close sends the queued writes, and waits until their callbacks return. The writes fail with a [ClosingError] once
+// the writer is closed. Close must be called before closing the client.
+func (writer *AsyncWriter) Close() {
+	writer.init()
+	writer.mu.Lock()
+	if !writer.closed {
+		writer.closed = true
+		close(writer.queue)
+	}
+	writer.mu.Unlock()
+	<-writer.stopped
+}
@@ -0,0 +1,109 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+// asyncWriteTarget records the batches of an AsyncWriter, and blocks them until release is closed.
+type asyncWriteTarget struct {
+	mu      sync.Mutex
+	batches [][]internal.Cmd
+	release chan struct{}
+	err     error
+}
+
+func (target *asyncWriteTarget) executeBatch(
+	ctx context.Context,
+	batch internal.Batch,
+	raiseOnError bool,
+	options *internal.BatchOptions,
+) ([]any, error) {
+	<-target.release
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	target.batches = append(target.batches, batch.Commands)
+	if target.err != nil {
+		return nil, target.err
+	}
+	responses := make([]any, len(batch.Commands))
+	for i, command := range batch.Commands {
+		if command.RequestType == uint32(protobuf.RequestType_IncrBy) {
+			responses[i] = NewRequestError("ERR value is not an integer or out of range")
+		} else {
+			responses[i] = OK
+		}
+	}
+	return responses, nil
+}
+
+func TestAsyncWriter_Batches(t *testing.T) {
+	target := &asyncWriteTarget{release: make(chan struct{})}
+	writer := NewAsyncWriter(target).WithBatchSize(2)
+
+	var mu sync.Mutex
+	var completed []string
+	record := func(name string) AsyncWriteCallback {
+		return func(response any, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				completed = append(completed, name+": "+err.Error())
+			} else {
+				completed = append(completed, name+": "+response.(string))
+			}
+		}
+	}
+	// The first write is sent on its own, while the next ones are queued.
+	require.NoError(t, writer.SetAsync("a", "1", record("set")))
+	require.Eventually(t, func() bool { return writer.Stats().Pending == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, writer.IncrByAsync("a", 1, record("incr")))
+	require.NoError(t, writer.HSetAsync("h", map[string]string{"f": "v"}, nil))
+	require.NoError(t, writer.DelAsync([]string{"a"}, record("del")))
+	assert.Equal(t, 3, writer.Stats().Pending)
+	close(target.release)
+	writer.Close()
+
+	require.Len(t, target.batches, 3)
+	assert.Len(t, target.batches[0], 1)
+	assert.Equal(t, []string{"a", "1"}, target.batches[0][0].Args)
+	assert.Len(t, target.batches[1], 2)
+	assert.Equal(t, []string{"h", "f", "v"}, target.batches[1][1].Args)
+	assert.Equal(t, []string{"a"}, target.batches[2][0].Args)
+	assert.Equal(t, []string{"set: OK", "incr: ERR value is not an integer or out of range", "del: OK"}, completed)
+	assert.Equal(t, AsyncWriterStats{Written: 3, Failed: 1}, writer.Stats())
+
+	assert.IsType(t, &ClosingError{}, writer.SetAsync("a", "1", nil))
+}
+
+func TestAsyncWriter_QueueFull(t *testing.T) {
+	target := &asyncWriteTarget{release: make(chan struct{}), err: errors.New("connection refused")}
+	writer := NewAsyncWriter(target).WithQueueSize(1)
+
+	require.NoError(t, writer.SetAsync("a", "1", nil))
+	require.Eventually(t, func() bool { return writer.Stats().Pending == 0 }, time.Second, time.Millisecond)
+	var failure error
+	require.NoError(t, writer.SetAsync("b", "1", func(_ any, err error) { failure = err }))
+	assert.ErrorIs(t, writer.SetAsync("c", "1", nil), ErrAsyncWriteQueueFull)
+
+	assert.ErrorIs(t, writer.CustomCommandAsync([]string{"XADD", "events", "*", "type", "click"}, nil),
+		ErrAsyncWriteQueueFull)
+
+	close(target.release)
+	writer.Close()
+	// The writes of a batch which fails fail with its error.
+	assert.Equal(t, target.err, failure)
+	assert.Equal(t, AsyncWriterStats{Failed: 2, Dropped: 2}, writer.Stats())
+	assert.ErrorContains(t, writer.CustomCommandAsync(nil, nil), "no command")
+}
@@ -27,8 +27,10 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
 
@@ -51,13 +53,68 @@ type payload struct {
 
 type clientConfiguration interface {
 	ToProtobuf() (*protobuf.ConnectionRequest, error)
+	GetSchedulingConfiguration() *config.SchedulingConfiguration
+	GetFaultInjectionConfiguration() *config.FaultInjectionConfiguration
+	GetMetricsConfiguration() *config.MetricsConfiguration
+	GetKeySamplingConfiguration() *config.KeySamplingConfiguration
+	GetMicroCacheConfiguration() *config.MicroCacheConfiguration
+	GetHedgingConfiguration() *config.HedgingConfiguration
+	GetRequestTimeout() time.Duration
+	GetTimeoutClasses() map[string]time.Duration
+	GetCapabilityDetection() bool
+}
+
+// coreClient holds the core client of a client, shared with the views of the client, see [Client.With].
+type coreClient struct {
+	// ptr is the pointer of the core client, or nil once the client is closed.
+	ptr unsafe.Pointer
 }
 
 type baseClient struct {
 	pending        map[unsafe.Pointer]struct{}
-	coreClient     unsafe.Pointer
+	core           *coreClient
 	mu             *sync.Mutex
 	messageHandler *MessageHandler
+	scheduler      *requestScheduler
+	// requestTimeout is the request timeout set at runtime in nanoseconds, or 0 if none.
+	requestTimeout *atomic.Int64
+	// timeoutClasses holds the request timeout of each timeout class, by name, see WithTimeoutClass.
+	timeoutClasses *atomic.Pointer[map[string]time.Duration]
+	// defaultTimeout is the request timeout the client was created with, applied to the requests without a timeout
+	// class if the client has timeout classes, as the core then waits for the longest of the timeouts. Otherwise 0.
+	defaultTimeout time.Duration
+	// coreTimeout is the request timeout of the core, which bounds the requests whatever their timeout.
+	coreTimeout    time.Duration
+	faultInjector  *faultInjector
+	metrics        *requestMetrics
+	keySampler     *keySampler
+	microCache     *microCache
+	hedger         *readHedger
+	primaryMonitor *primaryChangeMonitor
+	capabilities   *capabilityDetector
+	// capture holds the capture recording the commands of the client, shared with its views, see CaptureCommands.
+	capture *atomic.Pointer[CommandCapture]
+	// journal holds the journal of the write commands of the client, shared with its views, see JournalWrites.
+	journal *atomic.Pointer[RequestJournal]
+	// database holds the index of the database selected by the client, shared with the views of the client.
+	database *atomic.Int64
+	// username is the username the client authenticates with, and password holds the password, updated by
+	// UpdateConnectionPassword and shared with the views of the client. The password is empty without password-based
+	// authentication.
+	username string
+	password *atomic.Pointer[string]
+	// lastActivity holds when the client last sent a request or received a response, in Unix nanoseconds, for the
+	// handles of a [DedicatedPool], or is nil if the activity of the client isn't tracked.
+	lastActivity *atomic.Int64
+	// activeRequests counts the requests of the client waiting for their response, if its activity is tracked.
+	activeRequests *atomic.Int64
+	// subscriber holds the isolated subscriber connections of the client, which its subscription commands go through,
+	// or is nil if its subscriptions share the connections of the requests.
+	subscriber *isolatedSubscriber
+	// view holds the per-call defaults of a view of a client, or nil if the client isn't a view.
+	view *clientView
+	// protocol is the protocol of the connections of the client, e.g. "RESP3".
+	protocol string
 }
 
 // setMessageHandler assigns a message handler to the client for processing pub/sub messages
@@ -138,6 +195,11 @@ func createClient(config clientConfiguration) (*baseClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	coreDefaultTimeout := time.Duration(C.get_default_request_timeout()) * time.Millisecond
+	if len(config.GetTimeoutClasses()) > 0 && config.GetRequestTimeout() == 0 {
+		// The core also waits for the requests without a timeout class, for its default request timeout.
+		request.RequestTimeout = max(request.GetRequestTimeout(), uint32(coreDefaultTimeout.Milliseconds()))
+	}
 	msg, err := proto.Marshal(request)
 	if err != nil {
 		return nil, err
@@ -154,7 +216,54 @@ func createClient(config clientConfiguration) (*baseClient, error) {
 	if err != nil {
 		return nil, NewClosingError(err.Error())
 	}
-	client := &baseClient{pending: make(map[unsafe.Pointer]struct{}), mu: &sync.Mutex{}}
+	client := &baseClient{
+		pending:        make(map[unsafe.Pointer]struct{}),
+		core:           &coreClient{},
+		mu:             &sync.Mutex{},
+		requestTimeout: &atomic.Int64{},
+		timeoutClasses: &atomic.Pointer[map[string]time.Duration]{},
+		capture:        &atomic.Pointer[CommandCapture]{},
+		journal:        &atomic.Pointer[RequestJournal]{},
+		protocol:       request.GetProtocol().String(),
+		username:       request.GetAuthenticationInfo().GetUsername(),
+		password:       &atomic.Pointer[string]{},
+		database:       &atomic.Int64{},
+	}
+	client.database.Store(int64(request.GetDatabaseId()))
+	password := request.GetAuthenticationInfo().GetPassword()
+	client.password.Store(&password)
+	client.updateTimeoutClasses(config.GetTimeoutClasses())
+	client.coreTimeout = coreDefaultTimeout
+	if request.RequestTimeout != 0 {
+		client.coreTimeout = time.Duration(request.GetRequestTimeout()) * time.Millisecond
+	}
+	if len(config.GetTimeoutClasses()) > 0 {
+		client.defaultTimeout = config.GetRequestTimeout()
+		if client.defaultTimeout == 0 {
+			client.defaultTimeout = coreDefaultTimeout
+		}
+	}
+	if schedulingConfig := config.GetSchedulingConfiguration(); schedulingConfig != nil {
+		client.scheduler = newRequestScheduler(
+			schedulingConfig.GetMaxConcurrentRequests(),
+			schedulingConfig.GetMaxBatchCommands(),
+		)
+	}
+	if faultConfig := config.GetFaultInjectionConfiguration(); faultConfig != nil {
+		client.faultInjector = newFaultInjector(faultConfig)
+	}
+	if metricsConfig := config.GetMetricsConfiguration(); metricsConfig != nil {
+		client.metrics = newRequestMetrics(metricsConfig)
+	}
+	if samplingConfig := config.GetKeySamplingConfiguration(); samplingConfig != nil {
+		client.keySampler = newKeySampler(samplingConfig)
+	}
+	if cacheConfig := config.GetMicroCacheConfiguration(); cacheConfig != nil {
+		client.microCache = newMicroCache(cacheConfig)
+	}
+	if hedgingConfig := config.GetHedgingConfiguration(); hedgingConfig != nil && readsFromReplicas(request) {
+		client.hedger = newReadHedger(hedgingConfig)
+	}
 
 	cResponse := (*C.struct_ConnectionResponse)(
 		C.create_client(
@@ -171,27 +280,46 @@ func createClient(config clientConfiguration) (*baseClient, error) {
 		return nil, NewConnectionError(message)
 	}
 
-	client.coreClient = cResponse.conn_ptr
+	client.core.ptr = cResponse.conn_ptr
 
 	// Register the client in our registry using the pointer value from C
 	registerClient(client, uintptr(cResponse.conn_ptr))
 
+	if config.GetCapabilityDetection() {
+		client.capabilities = client.newCapabilityDetector(request.GetClusterModeEnabled())
+		if !request.GetLazyConnect() {
+			// Detected in the background, so that the creation of the client isn't delayed. Until then, the commands
+			// aren't checked.
+			go func() { _, _ = client.capabilities.capabilities(context.Background()) }()
+		}
+	}
+
 	return client, nil
 }
 
 // Close terminates the client by closing all associated resources.
+// Closing a view of a client is a no-op, see [Client.With].
 func (client *baseClient) Close() {
+	if client.view != nil {
+		return
+	}
 	client.mu.Lock()
 	defer client.mu.Unlock()
 
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		return
 	}
 
-	unregisterClient(uintptr(client.coreClient))
+	if client.primaryMonitor != nil {
+		client.primaryMonitor.close()
+	}
+	if client.subscriber != nil {
+		client.subscriber.close()
+	}
+	unregisterClient(uintptr(client.core.ptr))
 
-	C.close_client(client.coreClient)
-	client.coreClient = nil
+	C.close_client(client.core.ptr)
+	client.core.ptr = nil
 
 	// iterating the channel map while holding the lock guarantees those unsafe.Pointers is still valid
 	// because holding the lock guarantees the owner of the unsafe.Pointer hasn't exit.
@@ -202,6 +330,61 @@ func (client *baseClient) Close() {
 	client.pending = nil
 }
 
+// touch records that the client sends a request, if its activity is tracked, and returns the function recording that
+// the request completed.
+func (client *baseClient) touch() func() {
+	if client.lastActivity == nil {
+		return func() {}
+	}
+	client.lastActivity.Store(time.Now().UnixNano())
+	client.activeRequests.Add(1)
+	return func() {
+		client.lastActivity.Store(time.Now().UnixNano())
+		client.activeRequests.Add(-1)
+	}
+}
+
+// isClosed reports whether the client was closed.
+func (client *baseClient) isClosed() bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.core.ptr == nil
+}
+
+// abandonRequest stops tracking a request whose context is done before the response arrived.
+// The core is asked to cancel the request, which drops it and frees its inflight slot right away,
+// so requests timing out under load don't keep the client at its inflight limit.
+// If the core has already produced the response, the response is freed once it's delivered.
+func (client *baseClient) abandonRequest(
+	resultChannelPtr unsafe.Pointer,
+	pinnedChannelPtr uintptr,
+	resultChannel chan payload,
+	execution *C.CommandExecution,
+) {
+	cancelled := false
+	client.mu.Lock()
+	if client.pending != nil {
+		delete(client.pending, resultChannelPtr)
+	}
+	if client.core.ptr != nil {
+		cancelled = bool(C.cancel_command(client.core.ptr, C.uintptr_t(pinnedChannelPtr)))
+	}
+	client.mu.Unlock()
+	if cancelled {
+		// No callback will be invoked for a cancelled request, and its execution won't be reported.
+		freeCommandExecution(execution)
+		return
+	}
+	// Start cleanup goroutine
+	go func() {
+		// Wait for payload on separate channel
+		if payload := <-resultChannel; payload.value != nil {
+			C.free_command_response(payload.value)
+		}
+		freeCommandExecution(execution)
+	}()
+}
+
 func (client *baseClient) executeCommand(
 	ctx context.Context,
 	requestType C.RequestType,
@@ -281,6 +464,72 @@ func (client *baseClient) executeCommandWithRoute(
 	requestType C.RequestType,
 	args []string,
 	route config.Route,
+) (response *C.struct_CommandResponse, err error) {
+	target, err := client.view.routeCommand(uint32(requestType), args, route)
+	if err != nil {
+		return nil, err
+	}
+	if target != nil {
+		return target.executeCommandWithRoute(ctx, requestType, args, route)
+	}
+	ctx = client.view.context(ctx)
+	if args, route, err = client.view.prefixCommand(uint32(requestType), args, route); err != nil {
+		return nil, err
+	}
+	if err = client.capabilities.check(uint32(requestType)); err != nil {
+		return nil, err
+	}
+	defer func() { client.capabilities.redetectAfter(err) }()
+	if client.subscriber != nil && subscriptionRequests[requestType] {
+		// Neither scheduled nor delayed by the requests of the client.
+		return client.subscriber.client.sendCommand(ctx, requestType, args, route)
+	}
+	if err = client.captureCommand(uint32(requestType), args, route); err != nil {
+		return nil, err
+	}
+	finishJournal, err := client.journalCommand(ctx, uint32(requestType), args, route)
+	if err != nil {
+		return nil, err
+	}
+	if finishJournal != nil {
+		defer func() { finishJournal(err) }()
+	}
+	defer client.touch()()
+	if client.microCache != nil {
+		// The cached reads of the keys are invalidated even if the command fails, as it may have been applied.
+		defer client.microCache.invalidate(uint32(requestType), args)
+	}
+	if client.metrics != nil {
+		start := time.Now()
+		defer func() { client.recordRequest(describeCommand(requestType, args, route), start, err) }()
+	}
+	if client.keySampler != nil && client.keySampler.sampled() {
+		client.keySampler.record(describeCommand(requestType, args, route).key, args)
+	}
+	if trace, ok := contextTrace(ctx); ok {
+		start := time.Now()
+		defer func() { traceCommand(trace, requestType, args, route, start, response, err) }()
+	}
+	if client.hedger != nil && client.hedger.hedges(uint32(requestType), route) {
+		return hedge(
+			ctx,
+			client.hedger,
+			func(ctx context.Context) (*C.struct_CommandResponse, error) {
+				return client.sendCommand(ctx, requestType, args, route)
+			},
+			func(response *C.struct_CommandResponse) { C.free_command_response(response) },
+		)
+	}
+	return client.sendCommand(ctx, requestType, args, route)
+}
+
+// sendCommand sends a command, whose arguments and route are prefixed for the view of the client, and waits for its
+// response.
+func (client *baseClient) sendCommand(
+	ctx context.Context,
+	requestType C.RequestType,
+	args []string,
+	route config.Route,
 ) (*C.struct_CommandResponse, error) {
 	// Check if context is already done
 	select {
@@ -289,6 +538,20 @@ func (client *baseClient) executeCommandWithRoute(
 	default:
 		// Continue with execution
 	}
+	release, err := client.acquireSchedulerSlot(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	ctx, cancel, err := client.withRequestTimeout(ctx, isBlockingCommand(commandNameArgs(uint32(requestType), args)))
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	if err := client.injectFault(ctx); err != nil {
+		return nil, withErrorMetadata(err, describeCommand(requestType, args, route), true)
+	}
+
 	// Create span if OpenTelemetry is enabled and sampling is configured
 	var spanPtr uint64
 	otelInstance := GetOtelInstance()
@@ -336,13 +599,19 @@ func (client *baseClient) executeCommandWithRoute(
 	defer pinner.Unpin()
 
 	client.mu.Lock()
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		client.mu.Unlock()
-		return nil, NewClosingError("executeCommand failed: the client is closed")
+		return nil, withErrorMetadata(
+			NewClosingError("executeCommand failed: the client is closed"),
+			describeCommand(requestType, args, route),
+			false,
+		)
 	}
 	client.pending[resultChannelPtr] = struct{}{}
-	C.command(
-		client.coreClient,
+	client.recordExecution(ctx, describeCommand(requestType, args, route))
+	execution := newCommandExecution()
+	C.command_with_deadline(
+		client.core.ptr,
 		C.uintptr_t(pinnedChannelPtr),
 		uint32(requestType),
 		C.size_t(len(args)),
@@ -351,28 +620,21 @@ func (client *baseClient) executeCommandWithRoute(
 		routeBytesPtr,
 		routeBytesCount,
 		C.uint64_t(spanPtr),
+		C.uint64_t(remainingMillis(ctx)),
+		execution,
 	)
 	client.mu.Unlock()
 	// Wait for result or context cancellation
 	var payload payload
 	select {
 	case <-ctx.Done():
-		client.mu.Lock()
-		if client.pending != nil {
-			delete(client.pending, resultChannelPtr)
-		}
-		client.mu.Unlock()
-		// Start cleanup goroutine
-		go func() {
-			// Wait for payload on separate channel
-			if payload := <-resultChannel; payload.value != nil {
-				C.free_command_response(payload.value)
-			}
-		}()
-		return nil, ctx.Err()
+		client.abandonRequest(resultChannelPtr, pinnedChannelPtr, resultChannel, execution)
+		return nil, withErrorMetadata(contextErr(ctx), describeCommand(requestType, args, route), true)
 	case payload = <-resultChannel:
 		// Continue with normal processing
 	}
+	executed := takeCommandExecution(execution)
+	recordCommandExecution(ctx, executed)
 
 	client.mu.Lock()
 	if client.pending != nil {
@@ -381,7 +643,8 @@ func (client *baseClient) executeCommandWithRoute(
 	client.mu.Unlock()
 
 	if payload.error != nil {
-		return nil, payload.error
+		client.observeError(payload.error)
+		return nil, withErrorMetadata(payload.error, describeCommand(requestType, args, route).executedAs(executed), true)
 	}
 	return payload.value, nil
 }
@@ -407,7 +670,44 @@ func (client *baseClient) executeBatch(
 	batch internal.Batch,
 	raiseOnError bool,
 	options *internal.BatchOptions,
-) ([]any, error) {
+) (result []any, err error) {
+	target, err := client.view.routeBatch(batch, options)
+	if err != nil {
+		return nil, err
+	}
+	if target != nil {
+		return target.executeBatch(ctx, batch, raiseOnError, options)
+	}
+	ctx = client.view.context(ctx)
+	if batch, options, err = client.view.prefixBatch(batch, options); err != nil {
+		return nil, err
+	}
+	if err = client.captureBatch(batch, options); err != nil {
+		return nil, err
+	}
+	finishJournal, err := client.journalBatch(ctx, batch, options)
+	if err != nil {
+		return nil, err
+	}
+	if finishJournal != nil {
+		defer func() { finishJournal(result, err) }()
+	}
+	defer client.touch()()
+	if client.microCache != nil {
+		defer func() {
+			for _, command := range batch.Commands {
+				client.microCache.invalidate(command.RequestType, command.Args)
+			}
+		}()
+	}
+	if client.metrics != nil {
+		start := time.Now()
+		defer func() { client.recordRequest(describeBatch(batch.IsAtomic, options), start, err) }()
+	}
+	if trace, ok := contextTrace(ctx); ok {
+		start := time.Now()
+		defer func() { traceBatch(trace, batch, options, start, result, err) }()
+	}
 	// Check if context is already done
 	select {
 	case <-ctx.Done():
@@ -415,6 +715,20 @@ func (client *baseClient) executeBatch(
 	default:
 		// Continue with execution
 	}
+	release, err := client.acquireSchedulerSlot(ctx, len(batch.Commands))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	ctx, cancel, err := client.withRequestTimeout(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	if err := client.injectFault(ctx); err != nil {
+		return nil, withErrorMetadata(err, describeBatch(batch.IsAtomic, options), true)
+	}
+
 	if len(batch.Errors) > 0 {
 		return nil, NewBatchError(batch.Errors)
 	}
@@ -445,11 +759,16 @@ func (client *baseClient) executeBatch(
 	defer pinner.Unpin()
 
 	client.mu.Lock()
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		client.mu.Unlock()
-		return nil, NewClosingError("ExecuteBatch failed. The client is closed.")
+		return nil, withErrorMetadata(
+			NewClosingError("ExecuteBatch failed. The client is closed."),
+			describeBatch(batch.IsAtomic, options),
+			false,
+		)
 	}
 	client.pending[resultChannelPtr] = struct{}{}
+	client.recordExecution(ctx, describeBatch(batch.IsAtomic, options))
 
 	batchInfo := createBatchInfo(pinner, batch)
 	var optionsPtr *C.BatchOptionsInfo
@@ -459,7 +778,7 @@ func (client *baseClient) executeBatch(
 	}
 
 	C.batch(
-		client.coreClient,
+		client.core.ptr,
 		C.uintptr_t(pinnedChannelPtr),
 		&batchInfo,
 		C._Bool(raiseOnError),
@@ -472,19 +791,8 @@ func (client *baseClient) executeBatch(
 	var payload payload
 	select {
 	case <-ctx.Done():
-		client.mu.Lock()
-		if client.pending != nil {
-			delete(client.pending, resultChannelPtr)
-		}
-		client.mu.Unlock()
-		// Start cleanup goroutine
-		go func() {
-			// Wait for payload on separate channel
-			if payload := <-resultChannel; payload.value != nil {
-				C.free_command_response(payload.value)
-			}
-		}()
-		return nil, ctx.Err()
+		client.abandonRequest(resultChannelPtr, pinnedChannelPtr, resultChannel, nil)
+		return nil, withErrorMetadata(contextErr(ctx), describeBatch(batch.IsAtomic, options), true)
 	case payload = <-resultChannel:
 		// Continue with normal processing
 	}
@@ -496,7 +804,7 @@ func (client *baseClient) executeBatch(
 	client.mu.Unlock()
 
 	if payload.error != nil {
-		return nil, payload.error
+		return nil, withErrorMetadata(payload.error, describeBatch(batch.IsAtomic, options), true)
 	}
 	response, err := handleAnyArrayOrNilResponse(payload.value)
 	if err != nil {
@@ -628,7 +936,7 @@ func (client *baseClient) submitConnectionPasswordUpdate(
 	defer pinner.Unpin()
 
 	client.mu.Lock()
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		client.mu.Unlock()
 		return models.DefaultStringResponse, NewClosingError("UpdatePassword failed. The client is closed.")
 	}
@@ -637,7 +945,7 @@ func (client *baseClient) submitConnectionPasswordUpdate(
 	password_cstring := C.CString(password)
 	defer C.free(unsafe.Pointer(password_cstring))
 	C.update_connection_password(
-		client.coreClient,
+		client.core.ptr,
 		C.uintptr_t(pinnedChannelPtr),
 		password_cstring,
 		C._Bool(immediateAuth),
@@ -648,18 +956,7 @@ func (client *baseClient) submitConnectionPasswordUpdate(
 	var payload payload
 	select {
 	case <-ctx.Done():
-		client.mu.Lock()
-		if client.pending != nil {
-			delete(client.pending, resultChannelPtr)
-		}
-		client.mu.Unlock()
-		// Start cleanup goroutine
-		go func() {
-			// Wait for payload on separate channel
-			if payload := <-resultChannel; payload.value != nil {
-				C.free_command_response(payload.value)
-			}
-		}()
+		client.abandonRequest(resultChannelPtr, pinnedChannelPtr, resultChannel, nil)
 		return models.DefaultStringResponse, ctx.Err()
 	case payload = <-resultChannel:
 		// Continue with normal processing
@@ -674,6 +971,9 @@ func (client *baseClient) submitConnectionPasswordUpdate(
 	if payload.error != nil {
 		return models.DefaultStringResponse, payload.error
 	}
+	if client.password != nil {
+		client.password.Store(&password)
+	}
 
 	return handleOkResponse(payload.value)
 }
@@ -730,6 +1030,23 @@ func (client *baseClient) ResetConnectionPassword(ctx context.Context) (string,
 	return client.submitConnectionPasswordUpdate(ctx, "", false)
 }
 
+// selectedDatabase returns the index of the database selected by the client, with its configuration or SELECT.
+func (client *baseClient) selectedDatabase() int64 {
+	if client.database == nil {
+		return 0
+	}
+	return client.database.Load()
+}
+
+// credentials returns the username and the password the client authenticates with, e.g. to authenticate the
+// connections the server opens on behalf of the client. The password is empty without password-based authentication.
+func (client *baseClient) credentials() (string, string) {
+	if client.password == nil {
+		return client.username, ""
+	}
+	return client.username, *client.password.Load()
+}
+
 // submitRefreshIamToken is the internal implementation for manually refreshing the IAM authentication token.
 //
 // This method sends a refresh request to the core client to generate a new IAM token and update
@@ -763,14 +1080,14 @@ func (client *baseClient) submitRefreshIamToken(ctx context.Context) (string, er
 	defer pinner.Unpin()
 
 	client.mu.Lock()
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		client.mu.Unlock()
 		return models.DefaultStringResponse, NewClosingError("RefreshIamToken failed. The client is closed.")
 	}
 	client.pending[resultChannelPtr] = struct{}{}
 
 	C.refresh_iam_token(
-		client.coreClient,
+		client.core.ptr,
 		C.uintptr_t(pinnedChannelPtr),
 	)
 	client.mu.Unlock()
@@ -779,18 +1096,7 @@ func (client *baseClient) submitRefreshIamToken(ctx context.Context) (string, er
 	var payload payload
 	select {
 	case <-ctx.Done():
-		client.mu.Lock()
-		if client.pending != nil {
-			delete(client.pending, resultChannelPtr)
-		}
-		client.mu.Unlock()
-		// Start cleanup goroutine
-		go func() {
-			// Wait for payload on separate channel
-			if payload := <-resultChannel; payload.value != nil {
-				C.free_command_response(payload.value)
-			}
-		}()
+		client.abandonRequest(resultChannelPtr, pinnedChannelPtr, resultChannel, nil)
 		return models.DefaultStringResponse, ctx.Err()
 	case payload = <-resultChannel:
 		// Continue with normal processing
@@ -921,9 +1227,58 @@ func (client *baseClient) SetWithOptions(
 	return handleOkOrStringOrNilResponse(result)
 }
 
+// SetDurable sets the given key with the given value, then waits with WAIT until at least numberOfReplicas replicas
+// acknowledged the write, or until timeout is reached, whichever is earlier, for the writes which must survive the
+// failure of their primary. In cluster mode, WAIT is sent to the primary of the slot of key.
+//
+// Being acknowledged by fewer replicas than numberOfReplicas isn't an error: the write was performed on the primary
+// regardless, and may be retried or reported by the caller. WAIT also waits for the writes of the other commands sent
+// through the connection of the client before it. Like other blocking commands, WAIT isn't bounded by the request
+// timeout of the client but by timeout.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx              - The context for controlling the command execution.
+//	key              - The key to store.
+//	value            - The value to store with the given key.
+//	numberOfReplicas - The number of replicas to reach.
+//	timeout          - The timeout of WAIT. A value of `0` will block indefinitely.
+//
+// Return value:
+//
+//	The number of replicas which acknowledged the write.
+//
+// [valkey.io]: https://valkey.io/commands/wait/
+func (client *baseClient) SetDurable(
+	ctx context.Context,
+	key string,
+	value string,
+	numberOfReplicas int64,
+	timeout time.Duration,
+) (int64, error) {
+	if _, err := client.Set(ctx, key, value); err != nil {
+		return models.DefaultIntResponse, err
+	}
+	result, err := client.executeCommandWithRoute(
+		ctx,
+		C.Wait,
+		[]string{utils.IntToString(numberOfReplicas), utils.IntToString(timeout.Milliseconds())},
+		config.NewSlotKeyRoute(config.SlotTypePrimary, key),
+	)
+	if err != nil {
+		return models.DefaultIntResponse, err
+	}
+	return handleIntResponse(result)
+}
+
 // Get string value associated with the given key, or models.CreateNilStringResult() is returned if no such key
 // exists.
 //
+// The value of a key cached by the micro-cache of the client may be served locally, see
+// [config.MicroCacheConfiguration].
+//
 // See [valkey.io] for details.
 //
 // Parameters:
@@ -937,12 +1292,14 @@ func (client *baseClient) SetWithOptions(
 //
 // [valkey.io]: https://valkey.io/commands/get/
 func (client *baseClient) Get(ctx context.Context, key string) (models.Result[string], error) {
-	result, err := client.executeCommand(ctx, C.Get, []string{key})
-	if err != nil {
-		return models.CreateNilStringResult(), err
-	}
+	return microCached(ctx, client, []string{"GET", key}, func() (models.Result[string], error) {
+		result, err := client.executeCommand(ctx, C.Get, []string{key})
+		if err != nil {
+			return models.CreateNilStringResult(), err
+		}
 
-	return handleStringOrNilResponse(result)
+		return handleStringOrNilResponse(result)
+	})
 }
 
 // Get string value associated with the given key, or an empty string is returned [models.CreateNilStringResult()] if no such
@@ -1006,6 +1363,34 @@ func (client *baseClient) GetExWithOptions(
 	return handleStringOrNilResponse(result)
 }
 
+// Gets the string value associated with the given key and resets its time to live to ttl, atomically, with GETEX.
+// Use it for sliding expirations, e.g. of sessions which expire after some inactivity, instead of a GET followed by
+// an EXPIRE, which may let the key expire in between, or extend the TTL of a key set again by another client.
+//
+// Since:
+//
+//	Valkey 6.2.0 and above.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key to be retrieved from the database.
+//	ttl - The new time to live of the key, with millisecond precision. It must be positive.
+//
+// Return value:
+//
+//	If key exists, returns the value of key as a models.Result[string]. Otherwise, return [models.CreateNilStringResult()].
+//
+// [valkey.io]: https://valkey.io/commands/getex/
+func (client *baseClient) GetAndRefresh(ctx context.Context, key string, ttl time.Duration) (models.Result[string], error) {
+	if ttl < time.Millisecond {
+		return models.CreateNilStringResult(), fmt.Errorf("the TTL must be at least 1ms, got %v", ttl)
+	}
+	return client.GetExWithOptions(ctx, key, *options.NewGetExOptions().SetExpiry(options.NewExpiryIn(ttl)))
+}
+
 // Sets multiple keys to multiple values in a single operation.
 //
 // Note:
@@ -1278,6 +1663,8 @@ func (client *baseClient) Strlen(ctx context.Context, key string) (int64, error)
 // If the offset is larger than the current length of the string at key, the string is padded with zero bytes to make
 // offset fit.
 // Creates the key if it doesn't exist.
+// Together with [baseClient.GetRange], it can be used to store fixed-layout binary records, whose fields are read and
+// written at known offsets without reading or rewriting the whole value.
 //
 // See [valkey.io] for details.
 //
@@ -1285,7 +1672,8 @@ func (client *baseClient) Strlen(ctx context.Context, key string) (int64, error)
 //
 //	ctx    - The context for controlling the command execution.
 //	key    - The key of the string to update.
-//	offset - The position in the string where value should be written.
+//	offset - The position in the string where value should be written. Unlike the offsets of GetRange, it can't be
+//	         negative: negative offsets are rejected without sending the command.
 //	value  - The string written with offset.
 //
 // Return value:
@@ -1294,6 +1682,9 @@ func (client *baseClient) Strlen(ctx context.Context, key string) (int64, error)
 //
 // [valkey.io]: https://valkey.io/commands/setrange/
 func (client *baseClient) SetRange(ctx context.Context, key string, offset int, value string) (int64, error) {
+	if offset < 0 {
+		return models.DefaultIntResponse, fmt.Errorf("the offset of SETRANGE must not be negative, got %d", offset)
+	}
 	result, err := client.executeCommand(ctx, C.SetRange, []string{key, strconv.Itoa(offset), value})
 	if err != nil {
 		return models.DefaultIntResponse, err
@@ -1307,6 +1698,9 @@ func (client *baseClient) SetRange(ctx context.Context, key string, offset int,
 // Negative offsets can be used in order to provide an offset starting from the end of the string. So `-1` means the last
 // character, `-2` the penultimate and so forth.
 //
+// The offsets are clamped to the string: the substring is empty if start is past its end, or after end once both are
+// resolved.
+//
 // See [valkey.io] for details.
 //
 // Parameters:
@@ -1493,8 +1887,53 @@ func (client *baseClient) GetDel(ctx context.Context, key string) (models.Result
 	return handleStringOrNilResponse(result)
 }
 
+// Take gets the value associated with the given key and deletes the key, for one-shot values such as tokens or
+// claims, which must be consumed by a single caller. It's executed with GETDEL, or with GET and DEL in a transaction
+// on servers which don't support GETDEL, i.e. older than 6.2, as reported by the server or by the capabilities of
+// the client, see [config.ClientConfiguration.WithCapabilityDetection].
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key to take.
+//
+// Return value:
+//
+//	The value of the key, an empty string if it's empty, or a [models.Result[string]](models.CreateNilStringResult())
+//	if the key doesn't exist, i.e. if it was already taken.
+//
+// [valkey.io]: https://valkey.io/commands/getdel/
+func (client *baseClient) Take(ctx context.Context, key string) (models.Result[string], error) {
+	result, err := client.GetDel(ctx, key)
+	if err == nil || !errors.Is(err, ErrUnsupported) && !isUnknownCommandError(err) {
+		return result, err
+	}
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{IsAtomic: true, Commands: []internal.Cmd{
+		internal.MakeCmd(uint32(C.Get), []string{key}, identity),
+		internal.MakeCmd(uint32(C.Del), []string{key}, identity),
+	}}
+	responses, err := client.executeBatch(ctx, batch, true, nil)
+	if err != nil {
+		return models.CreateNilStringResult(), err
+	}
+	if len(responses) == 0 || responses[0] == nil {
+		return models.CreateNilStringResult(), nil
+	}
+	value, ok := responses[0].(string)
+	if !ok {
+		return models.CreateNilStringResult(), fmt.Errorf("unexpected type of the value of GET: %T", responses[0])
+	}
+	return models.CreateStringResult(value), nil
+}
+
 // HGet returns the value associated with field in the hash stored at key.
 //
+// The value of a key cached by the micro-cache of the client may be served locally, see
+// [config.MicroCacheConfiguration].
+//
 // See [valkey.io] for details.
 //
 // Parameters:
@@ -1510,12 +1949,14 @@ func (client *baseClient) GetDel(ctx context.Context, key string) (models.Result
 //
 // [valkey.io]: https://valkey.io/commands/hget/
 func (client *baseClient) HGet(ctx context.Context, key string, field string) (models.Result[string], error) {
-	result, err := client.executeCommand(ctx, C.HGet, []string{key, field})
-	if err != nil {
-		return models.CreateNilStringResult(), err
-	}
+	return microCached(ctx, client, []string{"HGET", key, field}, func() (models.Result[string], error) {
+		result, err := client.executeCommand(ctx, C.HGet, []string{key, field})
+		if err != nil {
+			return models.CreateNilStringResult(), err
+		}
 
-	return handleStringOrNilResponse(result)
+		return handleStringOrNilResponse(result)
+	})
 }
 
 // HGetAll returns all fields and values of the hash stored at key.
@@ -1567,6 +2008,44 @@ func (client *baseClient) HMGet(ctx context.Context, key string, fields []string
 	return handleStringOrNilArrayResponse(result)
 }
 
+// HMGetMulti returns the values associated with fields in the hashes stored at keys, read with an HMGET per key sent in
+// a single non-atomic pipeline. In cluster mode, the pipeline is split by the client across the nodes serving the
+// keys.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx    - The context for controlling the command execution.
+//	keys   - The keys of the hashes.
+//	fields - The fields to retrieve from each hash.
+//
+// Return value:
+//
+//	The values of fields in each hash, by key and by field. For every field that doesn't exist in a hash, or key that
+//	doesn't exist, a [models.CreateNilStringResult()] is returned.
+//
+// [valkey.io]: https://valkey.io/commands/hmget/
+func (client *baseClient) HMGetMulti(
+	ctx context.Context,
+	keys []string,
+	fields []string,
+) (map[string]map[string]models.Result[string], error) {
+	if len(keys) == 0 || len(fields) == 0 {
+		return parseHMGetMulti(keys, fields, make([]any, len(keys)))
+	}
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{Commands: make([]internal.Cmd, len(keys))}
+	for i, key := range keys {
+		batch.Commands[i] = internal.MakeCmd(uint32(C.HMGet), append([]string{key}, fields...), identity)
+	}
+	responses, err := client.executeBatch(ctx, batch, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseHMGetMulti(keys, fields, responses)
+}
+
 // HSet sets the specified fields to their respective values in the hash stored at key.
 // This command overwrites the values of specified fields that exist in the hash.
 // If key doesn't exist, a new key holding a hash is created.
@@ -1593,6 +2072,79 @@ func (client *baseClient) HSet(ctx context.Context, key string, values map[strin
 	return handleIntResponse(result)
 }
 
+// HSetAllChunked sets all the given fields of the hash stored at key, split into HSET commands bounded by chunkOptions
+// and sent in a single non-atomic pipeline, so that huge hashes don't exceed the request size limits in a single
+// command. The pipeline isn't atomic: other clients may observe the hash between the commands, and the chunks written
+// before a failing command remain in the hash. With chunkOptions.VerifyLength, HLEN is sent at the end of the pipeline,
+// and an error is returned if the hash holds fewer fields than written.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx          - The context for controlling the command execution.
+//	key          - The key of the hash.
+//	values       - A map of field-value pairs to set in the hash.
+//	chunkOptions - The bounds of each command, see [options.NewChunkOptions].
+//
+// Return value:
+//
+//	The number of fields that were added.
+//
+// [valkey.io]: https://valkey.io/commands/hset/
+func (client *baseClient) HSetAllChunked(
+	ctx context.Context,
+	key string,
+	values map[string]string,
+	chunkOptions options.ChunkOptions,
+) (int64, error) {
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	pairs := make([]string, 0, 2*len(fields))
+	for _, field := range fields {
+		pairs = append(pairs, field, values[field])
+	}
+	chunks, err := chunkElements(pairs, 2, chunkOptions)
+	if err != nil || len(chunks) == 0 {
+		return models.DefaultIntResponse, err
+	}
+
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{}
+	for _, chunk := range chunks {
+		batch.Commands = append(batch.Commands, internal.MakeCmd(uint32(C.HSet), append([]string{key}, chunk...), identity))
+	}
+	if chunkOptions.VerifyLength {
+		batch.Commands = append(batch.Commands, internal.MakeCmd(uint32(C.HLen), []string{key}, identity))
+	}
+	responses, err := client.executeBatch(ctx, batch, true, nil)
+	if err != nil {
+		return models.DefaultIntResponse, err
+	}
+	if len(responses) != len(batch.Commands) {
+		return models.DefaultIntResponse, fmt.Errorf("unexpected number of responses to HSET: %d", len(responses))
+	}
+	var added int64
+	for _, response := range responses[:len(chunks)] {
+		count, ok := response.(int64)
+		if !ok {
+			return models.DefaultIntResponse, fmt.Errorf("unexpected type of the response of HSET: %T", response)
+		}
+		added += count
+	}
+	if chunkOptions.VerifyLength {
+		if length, ok := responses[len(chunks)].(int64); !ok || length < int64(len(values)) {
+			return models.DefaultIntResponse, fmt.Errorf(
+				"the hash %q holds %v fields after writing %d fields", key, responses[len(chunks)], len(values),
+			)
+		}
+	}
+	return added, nil
+}
+
 // HSetNX sets field in the hash stored at key to value, only if field does not yet exist.
 // If key does not exist, a new key holding a hash is created.
 // If field already exists, this operation has no effect.
@@ -1966,6 +2518,8 @@ func (client *baseClient) HRandFieldWithCount(ctx context.Context, key string, c
 //	field name from the hash and `value` is the associated value of the field name.
 //	If the hash does not exist or is empty, the response will be an empty array.
 //
+// Deprecated: Use HRandFieldWithCountWithFieldValues instead.
+//
 // [valkey.io]: https://valkey.io/commands/hrandfield/
 func (client *baseClient) HRandFieldWithCountWithValues(ctx context.Context, key string, count int64) ([][]string, error) {
 	result, err := client.executeCommand(
@@ -1979,6 +2533,45 @@ func (client *baseClient) HRandFieldWithCountWithValues(ctx context.Context, key
 	return handle2DStringArrayResponse(result)
 }
 
+// Retrieves up to `count` random field names along with their values from the hash
+// value stored at `key`.
+//
+// Since:
+//
+//	Valkey 6.2.0 and above.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key of the hash.
+//	count - The number of field names to return.
+//	  	If `count` is positive, returns unique elements.
+//		If negative, allows for duplicates.
+//
+// Return value:
+//
+//	An array of [models.FieldValue], where `Field` is a random field name from the hash and `Value` is the
+//	associated value of the field name. If the hash does not exist or is empty, the response will be an empty array.
+//
+// [valkey.io]: https://valkey.io/commands/hrandfield/
+func (client *baseClient) HRandFieldWithCountWithFieldValues(
+	ctx context.Context,
+	key string,
+	count int64,
+) ([]models.FieldValue, error) {
+	result, err := client.executeCommand(
+		ctx,
+		C.HRandField,
+		[]string{key, utils.IntToString(count), constants.WithValuesKeyword},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return handleFieldValueArrayResponse(result)
+}
+
 // Sets the value of one or more fields of a given hash key, and optionally set their expiration time or time-to-live
 // (TTL).
 // This command overwrites the values and expirations of specified fields that exist in the hash.
@@ -2467,6 +3060,26 @@ func (client *baseClient) LPush(ctx context.Context, key string, elements []stri
 	return handleIntResponse(result)
 }
 
+// LPushValues inserts the given values at the head of the list stored at key, as [baseClient.LPush] does for a slice
+// of elements.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx    - The context for controlling the command execution.
+//	key    - The key of the list.
+//	values - The values to insert at the head of the list stored at key.
+//
+// Return value:
+//
+//	The length of the list after the push operation.
+//
+// [valkey.io]: https://valkey.io/commands/lpush/
+func (client *baseClient) LPushValues(ctx context.Context, key string, values ...string) (int64, error) {
+	return client.LPush(ctx, key, values)
+}
+
 // Removes and returns the first elements of the list stored at key. The command pops a single element from the beginning
 // of the list.
 //
@@ -2669,6 +3282,67 @@ func (client *baseClient) RPush(ctx context.Context, key string, elements []stri
 	return handleIntResponse(result)
 }
 
+// RPushValues inserts the given values at the tail of the list stored at key, as [baseClient.RPush] does for a slice
+// of elements.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx    - The context for controlling the command execution.
+//	key    - The key of the list.
+//	values - The values to insert at the tail of the list stored at key.
+//
+// Return value:
+//
+//	The length of the list after the push operation.
+//
+// [valkey.io]: https://valkey.io/commands/rpush/
+func (client *baseClient) RPushValues(ctx context.Context, key string, values ...string) (int64, error) {
+	return client.RPush(ctx, key, values)
+}
+
+// RPushAllChunked inserts all the given elements at the tail of the list stored at key, split into several RPUSH
+// requests bounded by chunkOptions, so that very large writes don't exceed the request size limits. The elements are
+// inserted in order, but the requests aren't atomic: other clients may observe, or write to, the list between them,
+// and the chunks pushed before a failing request remain in the list.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx          - The context for controlling the command execution.
+//	key          - The key of the list.
+//	elements     - The elements to insert at the tail of the list stored at key.
+//	chunkOptions - The bounds of each request, see [options.NewChunkOptions].
+//
+// Return value:
+//
+//	The length of the list after the last push operation, or the length of the list if elements is empty.
+//
+// [valkey.io]: https://valkey.io/commands/rpush/
+func (client *baseClient) RPushAllChunked(
+	ctx context.Context,
+	key string,
+	elements []string,
+	chunkOptions options.ChunkOptions,
+) (int64, error) {
+	chunks, err := chunkElements(elements, 1, chunkOptions)
+	if err != nil {
+		return models.DefaultIntResponse, err
+	}
+	if len(chunks) == 0 {
+		return client.LLen(ctx, key)
+	}
+	var length int64
+	for _, chunk := range chunks {
+		if length, err = client.RPush(ctx, key, chunk); err != nil {
+			return models.DefaultIntResponse, err
+		}
+	}
+	return length, nil
+}
+
 // SAdd adds specified members to the set stored at key.
 //
 // See [valkey.io] for details.
@@ -9274,7 +9948,7 @@ func (client *baseClient) FCallReadOnlyWithKeysAndArgs(
 // Lists the currently active channels.
 //
 // When used in cluster mode, the command is routed to all nodes and aggregates
-// the responses into a single array.
+// the responses into a single array, listing each channel once.
 //
 // See [valkey.io] for details.
 //
@@ -9293,7 +9967,7 @@ func (client *baseClient) PubSubChannels(ctx context.Context) ([]string, error)
 		return nil, err
 	}
 
-	return handleStringArrayResponse(result)
+	return handleChannelArrayResponse(result)
 }
 
 // Lists the currently active channels matching the specified pattern.
@@ -9304,7 +9978,7 @@ func (client *baseClient) PubSubChannels(ctx context.Context) ([]string, error)
 // - h[ae]llo matches hello and hallo, but not hillo
 //
 // When used in cluster mode, the command is routed to all nodes and aggregates
-// the responses into a single array.
+// the responses into a single array, listing each channel once.
 //
 // See [valkey.io] for details.
 //
@@ -9325,7 +9999,7 @@ func (client *baseClient) PubSubChannelsWithPattern(ctx context.Context, pattern
 		return nil, err
 	}
 
-	return handleStringArrayResponse(result)
+	return handleChannelArrayResponse(result)
 }
 
 // Returns the number of patterns that are subscribed to by clients.
@@ -9333,7 +10007,7 @@ func (client *baseClient) PubSubChannelsWithPattern(ctx context.Context, pattern
 // This returns the total number of unique patterns that all clients are subscribed to,
 // not the count of clients subscribed to patterns.
 //
-// When used in cluster mode, the command is routed to all nodes and aggregates
+// When used in cluster mode, the command is routed to all nodes and sums
 // the responses.
 //
 // See [valkey.io] for details.
@@ -9362,7 +10036,7 @@ func (client *baseClient) PubSubNumPat(ctx context.Context) (int64, error) {
 // If no channels are specified, an empty map is returned.
 //
 // When used in cluster mode, the command is routed to all nodes and aggregates
-// the responses into a single map.
+// the responses into a single map, summing the subscriber counts of each channel.
 //
 // See [valkey.io] for details.
 //
@@ -9478,7 +10152,41 @@ func (client *baseClient) executeScriptWithRoute(
 	keys []string,
 	args []string,
 	route config.Route,
-) (*C.struct_CommandResponse, error) {
+) (response *C.struct_CommandResponse, err error) {
+	target, err := client.view.routeScript(keys, route)
+	if err != nil {
+		return nil, err
+	}
+	if target != nil {
+		return target.executeScriptWithRoute(ctx, hash, keys, args, route)
+	}
+	ctx = client.view.context(ctx)
+	keys, route = client.view.prefixScript(keys, route)
+	if err = client.captureScript(hash, keys, args, route); err != nil {
+		return nil, err
+	}
+	finishJournal, err := client.journalScript(ctx, hash, keys, args, route)
+	if err != nil {
+		return nil, err
+	}
+	if finishJournal != nil {
+		defer func() { finishJournal(err) }()
+	}
+	defer client.touch()()
+	if client.microCache != nil {
+		defer client.microCache.invalidateKeys(keys)
+	}
+	if client.metrics != nil {
+		start := time.Now()
+		defer func() { client.recordRequest(describeScript(keys, route), start, err) }()
+	}
+	if client.keySampler != nil && client.keySampler.sampled() {
+		client.keySampler.record(describeScript(keys, route).key, append(append([]string(nil), keys...), args...))
+	}
+	if trace, ok := contextTrace(ctx); ok {
+		start := time.Now()
+		defer func() { traceScript(trace, hash, keys, args, route, start, response, err) }()
+	}
 	// Check if context is already done
 	select {
 	case <-ctx.Done():
@@ -9486,6 +10194,20 @@ func (client *baseClient) executeScriptWithRoute(
 	default:
 		// Continue with execution
 	}
+	release, err := client.acquireSchedulerSlot(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	ctx, cancel, err := client.withRequestTimeout(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	if err := client.injectFault(ctx); err != nil {
+		return nil, withErrorMetadata(err, describeScript(keys, route), true)
+	}
+
 	var cKeysPtr *C.uintptr_t = nil
 	var keysLengthsPtr *C.ulong = nil
 	if len(keys) > 0 {
@@ -9529,15 +10251,16 @@ func (client *baseClient) executeScriptWithRoute(
 	defer pinner.Unpin()
 
 	client.mu.Lock()
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		client.mu.Unlock()
-		return nil, NewClosingError("ExecuteScript failed. The client is closed.")
+		return nil, withErrorMetadata(NewClosingError("ExecuteScript failed. The client is closed."), describeScript(keys, route), false)
 	}
 	client.pending[resultChannelPtr] = struct{}{}
+	client.recordExecution(ctx, describeScript(keys, route))
 	hash_cstring := C.CString(hash)
 	defer C.free(unsafe.Pointer(hash_cstring))
 	C.invoke_script(
-		client.coreClient,
+		client.core.ptr,
 		C.uintptr_t(pinnedChannelPtr),
 		hash_cstring,
 		C.size_t(len(keys)),
@@ -9555,19 +10278,8 @@ func (client *baseClient) executeScriptWithRoute(
 	var payload payload
 	select {
 	case <-ctx.Done():
-		client.mu.Lock()
-		if client.pending != nil {
-			delete(client.pending, resultChannelPtr)
-		}
-		client.mu.Unlock()
-		// Start cleanup goroutine
-		go func() {
-			// Wait for payload on separate channel
-			if payload := <-resultChannel; payload.value != nil {
-				C.free_command_response(payload.value)
-			}
-		}()
-		return nil, ctx.Err()
+		client.abandonRequest(resultChannelPtr, pinnedChannelPtr, resultChannel, nil)
+		return nil, withErrorMetadata(contextErr(ctx), describeScript(keys, route), true)
 	case payload = <-resultChannel:
 		// Continue with normal processing
 	}
@@ -9579,7 +10291,7 @@ func (client *baseClient) executeScriptWithRoute(
 	client.mu.Unlock()
 
 	if payload.error != nil {
-		return nil, payload.error
+		return nil, withErrorMetadata(payload.error, describeScript(keys, route), true)
 	}
 	return payload.value, nil
 }
@@ -9770,6 +10482,22 @@ func (client *baseClient) GetStatistics() map[string]uint64 {
 	}
 }
 
+// GetCompressionPrefixStatistics retrieves the compression statistics of the compression prefix rules, see
+// [config.CompressionConfiguration.WithPrefixRule], e.g. to quantify the benefit of compression per namespace.
+//
+// Return value:
+//
+//	The statistics of each prefix of which a value was written, by prefix. Like the statistics of GetStatistics,
+//	they are shared by all the clients of the process.
+func (client *baseClient) GetCompressionPrefixStatistics() map[string]models.CompressionPrefixStatistics {
+	data := C.get_compression_prefix_statistics()
+	if data == nil {
+		return map[string]models.CompressionPrefixStatistics{}
+	}
+	defer C.free_c_string(data)
+	return parseCompressionPrefixStatistics(C.GoString(data))
+}
+
 // AllChannels represents "unsubscribe from all channels".
 // Pass nil to Unsubscribe or UnsubscribeLazy to unsubscribe from all channels.
 var AllChannels []string = nil
@@ -10428,3 +11156,148 @@ func (client *baseClient) AclWhoAmI(ctx context.Context) (string, error) {
 	}
 	return handleStringResponse(result)
 }
+
+// jsonNumIncrBy increments the numbers at path of the JSON document of key by number, a JSON number, with
+// JSON.NUMINCRBY, and returns the new values, as a JSON array for JSONPath paths.
+func (client *baseClient) jsonNumIncrBy(ctx context.Context, key string, path string, number string) (string, error) {
+	result, err := client.executeCommand(ctx, C.JsonNumIncrBy, []string{key, path, number})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleStringResponse(result)
+}
+
+// jsonArrAppend appends values, JSON values, to the arrays at path of the JSON document of key with JSON.ARRAPPEND,
+// and returns their new lengths, or nil for the values at path which aren't arrays.
+func (client *baseClient) jsonArrAppend(
+	ctx context.Context,
+	key string,
+	path string,
+	values []string,
+) ([]models.Result[int64], error) {
+	result, err := client.executeCommand(ctx, C.JsonArrAppend, append([]string{key, path}, values...))
+	if err != nil {
+		return nil, err
+	}
+	return handleIntOrNilArrayResponse(result)
+}
+
+// ftCreate creates the search index index from args, the arguments following the name of the index in FT.CREATE.
+func (client *baseClient) ftCreate(ctx context.Context, index string, args []string) (string, error) {
+	result, err := client.executeCommand(ctx, C.FtCreate, append([]string{index}, args...))
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleOkResponse(result)
+}
+
+// ftDropIndex drops the search index index with FT.DROPINDEX, without deleting the indexed keys.
+func (client *baseClient) ftDropIndex(ctx context.Context, index string) (string, error) {
+	result, err := client.executeCommand(ctx, C.FtDropIndex, []string{index})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleOkResponse(result)
+}
+
+// ftInfo returns the information of the search index index returned by FT.INFO, as a map or as a flat array of
+// names and values, depending on the protocol.
+func (client *baseClient) ftInfo(ctx context.Context, index string) (any, error) {
+	result, err := client.executeCommand(ctx, C.FtInfo, []string{index})
+	if err != nil {
+		return nil, err
+	}
+	return handleAnyResponse(result)
+}
+
+// Adds alias to the search index index, so that the index can be queried through the alias, e.g. to deploy a new
+// version of the index without changing the queries.
+//
+// The search module must be loaded on the server.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	alias - The alias to add.
+//	index - The index the alias points to.
+//
+// Return value:
+//
+//	`"OK"`, or an error matching [ErrAliasExists] if the alias already exists, or [ErrIndexNotFound] if the index
+//	doesn't exist.
+//
+// [valkey.io]: https://valkey.io/commands/ft.aliasadd/
+func (client *baseClient) FtAliasAdd(ctx context.Context, alias string, index string) (string, error) {
+	result, err := client.executeCommand(ctx, C.FtAliasAdd, []string{alias, index})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleOkResponse(result)
+}
+
+// Points alias to the search index index, atomically, adding the alias if it doesn't exist, e.g. to switch the
+// queries to a new version of an index in a blue/green deployment.
+//
+// The search module must be loaded on the server.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	alias - The alias to update.
+//	index - The index the alias points to.
+//
+// Return value:
+//
+//	`"OK"`, or an error matching [ErrIndexNotFound] if the index doesn't exist.
+//
+// [valkey.io]: https://valkey.io/commands/ft.aliasupdate/
+func (client *baseClient) FtAliasUpdate(ctx context.Context, alias string, index string) (string, error) {
+	result, err := client.executeCommand(ctx, C.FtAliasUpdate, []string{alias, index})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleOkResponse(result)
+}
+
+// Removes alias from the index it points to. The index itself is kept.
+//
+// The search module must be loaded on the server.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	alias - The alias to remove.
+//
+// Return value:
+//
+//	`"OK"`, or an error matching [ErrAliasNotFound] if the alias doesn't exist.
+//
+// [valkey.io]: https://valkey.io/commands/ft.aliasdel/
+func (client *baseClient) FtAliasDel(ctx context.Context, alias string) (string, error) {
+	result, err := client.executeCommand(ctx, C.FtAliasDel, []string{alias})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleOkResponse(result)
+}
+
+// ftSearch searches the index index for query with FT.SEARCH and args, the arguments following the query, and
+// returns the total number of matching documents and the documents read, in order.
+func (client *baseClient) ftSearch(
+	ctx context.Context,
+	index string,
+	query string,
+	args []string,
+) (int64, []searchReplyDocument, error) {
+	result, err := client.executeCommand(ctx, C.FtSearch, append([]string{index, query}, args...))
+	if err != nil {
+		return 0, nil, err
+	}
+	return handleSearchResponse(result)
+}
@@ -0,0 +1,129 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/pipeline"
+)
+
+// ErrBatchCommandSkipped is the response of the commands of a batch executed with ExecBestEffort which weren't sent
+// because the time budget of the batch was exhausted.
+var ErrBatchCommandSkipped = errors.New("the command was skipped as the time budget of the batch was exhausted")
+
+// ExecBestEffort executes a non-atomic batch within a total time budget, e.g. for opportunistic prefetches which must
+// never delay the main request path. The commands are sent by chunks, one chunk after the other, until the budget is
+// exhausted: the commands of the chunks which weren't sent then are skipped, and their response is
+// [ErrBatchCommandSkipped].
+//
+// The errors of the commands are returned as their response, as with Exec without raiseOnError: the commands of a
+// chunk whose response didn't arrive within the budget fail with a [TimeoutError], they may have been applied.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	batch - A non-atomic `StandaloneBatch` object containing a list of commands to be executed.
+//	options - A [pipeline.BestEffortBatchOptions] object holding the budget and the chunk size.
+//
+// Return value:
+//
+//	A response per command of the batch: its value, or its error, [ErrBatchCommandSkipped] for the skipped commands.
+//	An error is returned if the batch is atomic, or if the options are invalid.
+func (client *Client) ExecBestEffort(
+	ctx context.Context,
+	batch pipeline.StandaloneBatch,
+	options pipeline.BestEffortBatchOptions,
+) ([]any, error) {
+	return execBestEffort(ctx, batch.Batch, options, client.executeBatchChunk)
+}
+
+// ExecBestEffort executes a non-atomic batch within a total time budget, e.g. for opportunistic prefetches which must
+// never delay the main request path. The commands are sent by chunks, one chunk after the other, until the budget is
+// exhausted: the commands of the chunks which weren't sent then are skipped, and their response is
+// [ErrBatchCommandSkipped].
+//
+// The errors of the commands are returned as their response, as with Exec without raiseOnError: the commands of a
+// chunk whose response didn't arrive within the budget fail with a [TimeoutError], they may have been applied.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	batch - A non-atomic `ClusterBatch` object containing a list of commands to be executed.
+//	options - A [pipeline.BestEffortBatchOptions] object holding the budget and the chunk size.
+//
+// Return value:
+//
+//	A response per command of the batch: its value, or its error, [ErrBatchCommandSkipped] for the skipped commands.
+//	An error is returned if the batch is atomic, or if the options are invalid.
+func (client *ClusterClient) ExecBestEffort(
+	ctx context.Context,
+	batch pipeline.ClusterBatch,
+	options pipeline.BestEffortBatchOptions,
+) ([]any, error) {
+	return execBestEffort(ctx, batch.Batch, options, client.executeBatchChunk)
+}
+
+// executeBatchChunk executes chunk, a non-atomic batch, whose response must arrive within timeout.
+func (client *baseClient) executeBatchChunk(ctx context.Context, chunk internal.Batch, timeout time.Duration) (
+	[]any,
+	error,
+) {
+	milliseconds := uint32(max(timeout.Milliseconds(), 1))
+	return client.executeBatch(ctx, chunk, false, &internal.BatchOptions{Timeout: &milliseconds})
+}
+
+// execBestEffort executes batch by chunks with execute until the budget of options is exhausted.
+func execBestEffort(
+	ctx context.Context,
+	batch internal.Batch,
+	options pipeline.BestEffortBatchOptions,
+	execute func(ctx context.Context, chunk internal.Batch, timeout time.Duration) ([]any, error),
+) ([]any, error) {
+	if batch.IsAtomic {
+		return nil, errors.New("atomic batches (transactions) can't be executed in best-effort mode")
+	}
+	if options.Budget <= 0 {
+		return nil, fmt.Errorf("the time budget must be positive, got %v", options.Budget)
+	}
+	if options.ChunkSize <= 0 {
+		return nil, fmt.Errorf("the chunk size must be positive, got %d", options.ChunkSize)
+	}
+	if len(batch.Errors) > 0 {
+		return nil, NewBatchError(batch.Errors)
+	}
+	ctx, cancel := context.WithTimeout(ctx, options.Budget)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	responses := make([]any, 0, len(batch.Commands))
+	for start := 0; start < len(batch.Commands); start += options.ChunkSize {
+		chunk := internal.Batch{Commands: batch.Commands[start:min(start+options.ChunkSize, len(batch.Commands))]}
+		remaining := time.Until(deadline)
+		if remaining <= 0 || ctx.Err() != nil {
+			for range batch.Commands[start:] {
+				responses = append(responses, ErrBatchCommandSkipped)
+			}
+			break
+		}
+		chunkResponses, err := execute(ctx, chunk, remaining)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+			err = NewTimeoutError("the time budget of the batch was exhausted while waiting for the response")
+		}
+		if err == nil && len(chunkResponses) != len(chunk.Commands) {
+			err = fmt.Errorf("unexpected number of responses to %d commands: %d", len(chunk.Commands), len(chunkResponses))
+		}
+		if err != nil {
+			for range chunk.Commands {
+				responses = append(responses, err)
+			}
+			continue
+		}
+		responses = append(responses, chunkResponses...)
+	}
+	return responses, nil
+}
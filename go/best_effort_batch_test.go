@@ -0,0 +1,109 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/pipeline"
+)
+
+func getBatch(keys ...string) internal.Batch {
+	batch := internal.Batch{}
+	for _, key := range keys {
+		batch.Commands = append(batch.Commands, internal.Cmd{RequestType: uint32(protobuf.RequestType_Get), Args: []string{key}})
+	}
+	return batch
+}
+
+func TestExecBestEffort(t *testing.T) {
+	var chunks [][]string
+	execute := func(ctx context.Context, chunk internal.Batch, timeout time.Duration) ([]any, error) {
+		var keys []string
+		responses := make([]any, len(chunk.Commands))
+		for i, command := range chunk.Commands {
+			keys = append(keys, command.Args[0])
+			responses[i] = "value of " + command.Args[0]
+		}
+		chunks = append(chunks, keys)
+		assert.Positive(t, timeout)
+		return responses, nil
+	}
+
+	responses, err := execBestEffort(
+		context.Background(),
+		getBatch("a", "b", "c", "d", "e"),
+		*pipeline.NewBestEffortBatchOptions(time.Second).WithChunkSize(2),
+		execute,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"value of a", "value of b", "value of c", "value of d", "value of e"}, responses)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, chunks)
+}
+
+func TestExecBestEffort_BudgetExhausted(t *testing.T) {
+	chunks := 0
+	execute := func(ctx context.Context, chunk internal.Batch, timeout time.Duration) ([]any, error) {
+		chunks++
+		if chunks == 2 {
+			// The second chunk waits for its response past the budget.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return make([]any, len(chunk.Commands)), nil
+	}
+
+	responses, err := execBestEffort(
+		context.Background(),
+		getBatch("a", "b", "c", "d", "e"),
+		*pipeline.NewBestEffortBatchOptions(20 * time.Millisecond).WithChunkSize(2),
+		execute,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, chunks)
+	require.Len(t, responses, 5)
+	assert.Equal(t, []any{nil, nil}, responses[:2])
+	assert.IsType(t, &TimeoutError{}, responses[2])
+	assert.IsType(t, &TimeoutError{}, responses[3])
+	assert.Equal(t, ErrBatchCommandSkipped, responses[4])
+}
+
+func TestExecBestEffort_Errors(t *testing.T) {
+	failure := NewDisconnectError("connection closed")
+	chunks := 0
+	execute := func(ctx context.Context, chunk internal.Batch, timeout time.Duration) ([]any, error) {
+		chunks++
+		if chunks == 1 {
+			return nil, failure
+		}
+		return []any{"value"}, nil
+	}
+	responses, err := execBestEffort(
+		context.Background(),
+		getBatch("a", "b"),
+		*pipeline.NewBestEffortBatchOptions(time.Second).WithChunkSize(1),
+		execute,
+	)
+	require.NoError(t, err)
+	// The next chunks are still sent while the budget lasts.
+	assert.Equal(t, []any{failure, "value"}, responses)
+
+	atomic := getBatch("a")
+	atomic.IsAtomic = true
+	_, err = execBestEffort(context.Background(), atomic, *pipeline.NewBestEffortBatchOptions(time.Second), execute)
+	assert.EqualError(t, err, "atomic batches (transactions) can't be executed in best-effort mode")
+	_, err = execBestEffort(context.Background(), getBatch("a"), pipeline.BestEffortBatchOptions{}, execute)
+	assert.EqualError(t, err, "the time budget must be positive, got 0s")
+	invalid := getBatch("a")
+	invalid.Errors = []error{errors.New("invalid argument")}
+	_, err = execBestEffort(context.Background(), invalid, *pipeline.NewBestEffortBatchOptions(time.Second), execute)
+	assert.IsType(t, &BatchError{}, err)
+}
@@ -0,0 +1,159 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+)
+
+// DefaultInvalidationDelay is the delay of the second delete of a [CacheInvalidator], unless configured otherwise.
+const DefaultInvalidationDelay = 500 * time.Millisecond
+
+// pendingInvalidation is a second delete scheduled by a [CacheInvalidator].
+type pendingInvalidation struct {
+	ctx   context.Context
+	keys  []string
+	timer *time.Timer
+}
+
+// CacheInvalidator invalidates cached values after writes to the system of record with the delayed double-delete
+// pattern: the keys are deleted right away, and deleted again after a delay. The second delete removes the stale
+// values cached meanwhile by readers which loaded them before the write was visible, e.g. from a lagging database
+// replica, so the delay should exceed the time it takes a reader to load and cache a value.
+//
+// The second deletes are scheduled in the process, they're lost if it exits before. Close runs the pending second
+// deletes right away, e.g. on shutdown. A second delete that fails isn't retried, it's reported to the error handler.
+//
+// Example usage:
+//
+//	invalidator := glide.NewCacheInvalidator(client).WithDelay(time.Second)
+//	defer invalidator.Close()
+//
+//	if err := db.UpdateUser(user); err != nil {
+//		return err
+//	}
+//	_, err := invalidator.Invalidate(ctx, "user:"+user.ID)
+type CacheInvalidator struct {
+	target  interfaces.BaseClientCommands
+	delay   time.Duration
+	onError func(keys []string, err error)
+
+	mu      sync.Mutex
+	pending map[*pendingInvalidation]struct{}
+	closed  bool
+	running sync.WaitGroup
+}
+
+// NewCacheInvalidator returns a [CacheInvalidator] deleting the keys of target.
+func NewCacheInvalidator(target interfaces.BaseClientCommands) *CacheInvalidator {
+	return &CacheInvalidator{
+		target:  target,
+		delay:   DefaultInvalidationDelay,
+		pending: make(map[*pendingInvalidation]struct{}),
+	}
+}
+
+// WithDelay sets the delay between the first and the second delete of the keys. Defaults to DefaultInvalidationDelay.
+func (invalidator *CacheInvalidator) WithDelay(delay time.Duration) *CacheInvalidator {
+	invalidator.delay = delay
+	return invalidator
+}
+
+// WithErrorHandler sets a function called with the keys and the error of the second deletes that fail. Calls are made
+// from the goroutines running the second deletes. By default failures of the second deletes are ignored.
+func (invalidator *CacheInvalidator) WithErrorHandler(onError func(keys []string, err error)) *CacheInvalidator {
+	invalidator.onError = onError
+	return invalidator
+}
+
+// Invalidate deletes keys, and schedules their second delete after the configured delay.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the first delete. The second delete only keeps its values, e.g. its priority,
+//	  it isn't canceled with ctx.
+//	keys - The keys to invalidate.
+//
+// Return value:
+//
+//	The number of keys deleted by the first delete, or its error, in which case no second delete is scheduled, or a
+//	[ClosingError] if the invalidator is closed.
+func (invalidator *CacheInvalidator) Invalidate(ctx context.Context, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, errors.New("no keys to invalidate")
+	}
+	invalidator.mu.Lock()
+	if invalidator.closed {
+		invalidator.mu.Unlock()
+		return 0, NewClosingError("the cache invalidator is closed")
+	}
+	// Close waits for the invalidations in progress.
+	invalidator.running.Add(1)
+	invalidator.mu.Unlock()
+	defer invalidator.running.Done()
+	deleted, err := invalidator.target.Del(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+	invalidation := &pendingInvalidation{ctx: context.WithoutCancel(ctx), keys: append([]string(nil), keys...)}
+	invalidator.mu.Lock()
+	if invalidator.closed {
+		invalidator.mu.Unlock()
+		invalidator.secondDelete(invalidation)
+		return deleted, nil
+	}
+	invalidator.pending[invalidation] = struct{}{}
+	invalidator.running.Add(1)
+	invalidation.timer = time.AfterFunc(invalidator.delay, func() {
+		defer invalidator.running.Done()
+		invalidator.mu.Lock()
+		_, ok := invalidator.pending[invalidation]
+		delete(invalidator.pending, invalidation)
+		invalidator.mu.Unlock()
+		if ok {
+			invalidator.secondDelete(invalidation)
+		}
+	})
+	invalidator.mu.Unlock()
+	return deleted, nil
+}
+
+// secondDelete deletes the keys of invalidation, and reports its failure to the error handler.
+func (invalidator *CacheInvalidator) secondDelete(invalidation *pendingInvalidation) {
+	if _, err := invalidator.target.Del(invalidation.ctx, invalidation.keys); err != nil && invalidator.onError != nil {
+		invalidator.onError(invalidation.keys, err)
+	}
+}
+
+// Pending returns the number of second deletes scheduled and not run yet.
+func (invalidator *CacheInvalidator) Pending() int {
+	invalidator.mu.Lock()
+	defer invalidator.mu.Unlock()
+	return len(invalidator.pending)
+}
+
+// Close runs the pending second deletes right away, and waits until all the second deletes are done. Invalidate fails
+// with a [ClosingError] once the invalidator is closed. Close must be called before closing the client.
+func (invalidator *CacheInvalidator) Close() {
+	invalidator.mu.Lock()
+	invalidator.closed = true
+	var due []*pendingInvalidation
+	for invalidation := range invalidator.pending {
+		// The second deletes whose timer fired already are run by their timer.
+		if invalidation.timer.Stop() {
+			delete(invalidator.pending, invalidation)
+			invalidator.running.Done()
+			due = append(due, invalidation)
+		}
+	}
+	invalidator.mu.Unlock()
+	for _, invalidation := range due {
+		invalidator.secondDelete(invalidation)
+	}
+	invalidator.running.Wait()
+}
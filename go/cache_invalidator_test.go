@@ -0,0 +1,109 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+)
+
+// invalidationTarget records the deletes of a CacheInvalidator. Only Del is implemented.
+type invalidationTarget struct {
+	interfaces.BaseClientCommands
+	mu      sync.Mutex
+	deletes [][]string
+	err     error
+}
+
+func (target *invalidationTarget) Del(ctx context.Context, keys []string) (int64, error) {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	target.deletes = append(target.deletes, keys)
+	if target.err != nil {
+		return 0, target.err
+	}
+	return int64(len(keys)), nil
+}
+
+func (target *invalidationTarget) deleted() [][]string {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	return append([][]string(nil), target.deletes...)
+}
+
+func TestCacheInvalidator_Invalidate(t *testing.T) {
+	target := &invalidationTarget{}
+	invalidator := NewCacheInvalidator(target).WithDelay(20 * time.Millisecond)
+	defer invalidator.Close()
+
+	deleted, err := invalidator.Invalidate(context.Background(), "user:1", "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.Equal(t, [][]string{{"user:1", "user:2"}}, target.deleted())
+	assert.Equal(t, 1, invalidator.Pending())
+
+	assert.Eventually(t, func() bool { return len(target.deleted()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"user:1", "user:2"}, target.deleted()[1])
+	assert.Equal(t, 0, invalidator.Pending())
+
+	_, err = invalidator.Invalidate(context.Background())
+	assert.ErrorContains(t, err, "no keys to invalidate")
+}
+
+func TestCacheInvalidator_SecondDeleteOutlivesContext(t *testing.T) {
+	target := &invalidationTarget{}
+	invalidator := NewCacheInvalidator(target).WithDelay(10 * time.Millisecond)
+	defer invalidator.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := invalidator.Invalidate(ctx, "user:1")
+	require.NoError(t, err)
+	cancel()
+
+	assert.Eventually(t, func() bool { return len(target.deleted()) == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestCacheInvalidator_Errors(t *testing.T) {
+	delErr := errors.New("READONLY You can't write against a read only replica.")
+	target := &invalidationTarget{err: delErr}
+	invalidator := NewCacheInvalidator(target).WithDelay(time.Hour)
+	_, err := invalidator.Invalidate(context.Background(), "user:1")
+	assert.ErrorIs(t, err, delErr)
+	// No second delete is scheduled when the first one fails.
+	assert.Equal(t, 0, invalidator.Pending())
+
+	var failed []string
+	target.err = nil
+	invalidator.WithErrorHandler(func(keys []string, err error) { failed = keys })
+	_, err = invalidator.Invalidate(context.Background(), "user:2")
+	require.NoError(t, err)
+	target.err = delErr
+	invalidator.Close()
+	assert.Equal(t, []string{"user:2"}, failed)
+}
+
+func TestCacheInvalidator_Close(t *testing.T) {
+	target := &invalidationTarget{}
+	invalidator := NewCacheInvalidator(target).WithDelay(time.Hour)
+	_, err := invalidator.Invalidate(context.Background(), "user:1")
+	require.NoError(t, err)
+	_, err = invalidator.Invalidate(context.Background(), "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, invalidator.Pending())
+
+	// The pending second deletes run right away.
+	invalidator.Close()
+	assert.Len(t, target.deleted(), 4)
+	assert.Equal(t, 0, invalidator.Pending())
+
+	_, err = invalidator.Invalidate(context.Background(), "user:3")
+	assert.IsType(t, &ClosingError{}, err)
+}
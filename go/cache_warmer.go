@@ -0,0 +1,231 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// DefaultWarmConcurrency is the number of keys a [CacheWarmer] loads concurrently, unless configured otherwise.
+const DefaultWarmConcurrency = 16
+
+// CacheLoader returns the value to cache for key, loaded from the system of record. found is false if there is no
+// value for key, in which case nothing is cached.
+type CacheLoader func(ctx context.Context, key string) (value string, found bool, err error)
+
+// WarmProgress reports the progress of a [CacheWarmer].
+type WarmProgress struct {
+	// Processed is the number of keys processed so far, whatever their outcome.
+	Processed int64
+	// Loaded is the number of keys cached.
+	Loaded int64
+	// Skipped is the number of keys left untouched because they were cached already.
+	Skipped int64
+	// Missing is the number of keys the loader found no value for.
+	Missing int64
+	// Failed is the number of keys which couldn't be loaded or cached.
+	Failed int64
+}
+
+// CacheWarmer populates a cache with the values returned by a [CacheLoader], e.g. after a cold start or a failover.
+//
+// Keys are loaded and cached concurrently, by at most the configured concurrency. By default keys which are cached
+// already are left untouched, so values written by the application while the cache warms up are not overwritten.
+// A key failing to load or to be cached doesn't stop the warmer, it's counted in [WarmProgress.Failed].
+//
+// Example usage:
+//
+//	warmer := glide.NewCacheWarmer(client, loadFromDatabase).
+//		WithTTL(time.Hour).
+//		WithProgress(func(progress glide.WarmProgress) { log.Printf("%+v", progress) })
+//	progress, err := warmer.WarmFromScan(ctx, sourceClusterClient, "user:*")
+type CacheWarmer struct {
+	target      interfaces.BaseClientCommands
+	loader      CacheLoader
+	concurrency int
+	ttl         time.Duration
+	overwrite   bool
+	onProgress  func(WarmProgress)
+}
+
+// NewCacheWarmer returns a [CacheWarmer] caching the values returned by loader into target.
+func NewCacheWarmer(target interfaces.BaseClientCommands, loader CacheLoader) *CacheWarmer {
+	return &CacheWarmer{target: target, loader: loader, concurrency: DefaultWarmConcurrency}
+}
+
+// WithConcurrency sets the number of keys loaded concurrently. Defaults to DefaultWarmConcurrency.
+func (warmer *CacheWarmer) WithConcurrency(concurrency int) *CacheWarmer {
+	warmer.concurrency = concurrency
+	return warmer
+}
+
+// WithTTL sets the time to live of the cached values. By default cached values don't expire.
+func (warmer *CacheWarmer) WithTTL(ttl time.Duration) *CacheWarmer {
+	warmer.ttl = ttl
+	return warmer
+}
+
+// WithOverwrite sets whether keys which are cached already are overwritten with the loaded value. Defaults to false.
+func (warmer *CacheWarmer) WithOverwrite(overwrite bool) *CacheWarmer {
+	warmer.overwrite = overwrite
+	return warmer
+}
+
+// WithProgress sets a function called with the progress of the warmer every time a key is processed. Calls are
+// serialized, but made from the goroutines loading the keys: the function must return quickly.
+func (warmer *CacheWarmer) WithProgress(onProgress func(WarmProgress)) *CacheWarmer {
+	warmer.onProgress = onProgress
+	return warmer
+}
+
+// WarmKeys loads and caches the given keys.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the warm up. The warm up stops once ctx is done.
+//	keys - The keys to warm up.
+//
+// Return value:
+//
+//	The progress of the warm up once all the keys were processed, and ctx.Err() if ctx is done before.
+func (warmer *CacheWarmer) WarmKeys(ctx context.Context, keys []string) (WarmProgress, error) {
+	return warmer.warm(ctx, func(ctx context.Context, send func([]string) bool) error {
+		send(keys)
+		return nil
+	})
+}
+
+// WarmFromScan loads and caches the keys of a source cluster matching a glob-style pattern, scanning the source
+// cluster while the previously scanned keys are warmed up.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the warm up. The warm up stops once ctx is done.
+//	source - The cluster to scan the keys of. It may be the cluster the values are loaded from.
+//	match - The pattern of the keys to warm up.
+//
+// Return value:
+//
+//	The progress of the warm up once all the keys were processed, and the error of the scan if it failed, or
+//	ctx.Err() if ctx is done before.
+func (warmer *CacheWarmer) WarmFromScan(ctx context.Context, source *ClusterClient, match string) (WarmProgress, error) {
+	return warmer.warm(ctx, func(ctx context.Context, send func([]string) bool) error {
+		scanOptions := options.NewClusterScanOptions().SetMatch(match)
+		cursor := models.NewClusterScanCursor()
+		for !cursor.IsFinished() {
+			result, err := source.ScanWithOptions(ctx, cursor, *scanOptions)
+			if err != nil {
+				return err
+			}
+			if !send(result.Keys) {
+				return nil
+			}
+			cursor = result.Cursor
+		}
+		return nil
+	})
+}
+
+// warm runs the workers warming up the keys sent by produce, until produce returns and all the keys sent were
+// processed. send returns false once ctx is done.
+func (warmer *CacheWarmer) warm(
+	ctx context.Context,
+	produce func(ctx context.Context, send func([]string) bool) error,
+) (WarmProgress, error) {
+	keys := make(chan string)
+	var mu sync.Mutex
+	var progress WarmProgress
+	var wg sync.WaitGroup
+	for i := 0; i < max(warmer.concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				outcome := warmer.warmKey(ctx, key)
+				mu.Lock()
+				progress.record(outcome)
+				if warmer.onProgress != nil {
+					warmer.onProgress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	err := produce(ctx, func(batch []string) bool {
+		for _, key := range batch {
+			if ctx.Err() != nil {
+				return false
+			}
+			select {
+			case keys <- key:
+			case <-ctx.Done():
+				return false
+			}
+		}
+		return true
+	})
+	close(keys)
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return progress, err
+}
+
+// warmOutcome is the outcome of warming up a key.
+type warmOutcome int
+
+const (
+	warmLoaded warmOutcome = iota
+	warmSkipped
+	warmMissing
+	warmFailed
+)
+
+func (progress *WarmProgress) record(outcome warmOutcome) {
+	progress.Processed++
+	switch outcome {
+	case warmLoaded:
+		progress.Loaded++
+	case warmSkipped:
+		progress.Skipped++
+	case warmMissing:
+		progress.Missing++
+	case warmFailed:
+		progress.Failed++
+	}
+}
+
+// warmKey loads and caches a key.
+func (warmer *CacheWarmer) warmKey(ctx context.Context, key string) warmOutcome {
+	value, found, err := warmer.loader(ctx, key)
+	if err != nil {
+		return warmFailed
+	}
+	if !found {
+		return warmMissing
+	}
+	setOptions := options.NewSetOptions()
+	if !warmer.overwrite {
+		setOptions.SetOnlyIfDoesNotExist()
+	}
+	if warmer.ttl > 0 {
+		setOptions.SetExpiry(options.NewExpiryIn(warmer.ttl))
+	}
+	result, err := warmer.target.SetWithOptions(ctx, key, value, *setOptions)
+	switch {
+	case err != nil:
+		return warmFailed
+	case result.IsNil():
+		return warmSkipped
+	default:
+		return warmLoaded
+	}
+}
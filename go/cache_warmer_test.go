@@ -0,0 +1,82 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// warmTarget is an in-memory target of a CacheWarmer. Only SetWithOptions is implemented.
+type warmTarget struct {
+	interfaces.BaseClientCommands
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (target *warmTarget) SetWithOptions(
+	ctx context.Context,
+	key string,
+	value string,
+	setOptions options.SetOptions,
+) (models.Result[string], error) {
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	if _, ok := target.values[key]; ok && setOptions.ConditionalSet == constants.OnlyIfDoesNotExist {
+		return models.CreateNilStringResult(), nil
+	}
+	target.values[key] = value
+	return models.CreateStringResult("OK"), nil
+}
+
+func TestCacheWarmer_WarmKeys(t *testing.T) {
+	target := &warmTarget{values: map[string]string{"cached": "fresh"}}
+	loader := func(ctx context.Context, key string) (string, bool, error) {
+		switch key {
+		case "missing":
+			return "", false, nil
+		case "failing":
+			return "", false, errors.New("database unavailable")
+		default:
+			return "loaded:" + key, true, nil
+		}
+	}
+	var progressCalls []WarmProgress
+	warmer := NewCacheWarmer(target, loader).
+		WithConcurrency(3).
+		WithProgress(func(progress WarmProgress) { progressCalls = append(progressCalls, progress) })
+
+	progress, err := warmer.WarmKeys(context.Background(), []string{"a", "b", "cached", "missing", "failing"})
+	assert.NoError(t, err)
+	assert.Equal(t, WarmProgress{Processed: 5, Loaded: 2, Skipped: 1, Missing: 1, Failed: 1}, progress)
+	assert.Equal(t, map[string]string{"a": "loaded:a", "b": "loaded:b", "cached": "fresh"}, target.values)
+	assert.Len(t, progressCalls, 5)
+	assert.Equal(t, progress, progressCalls[4])
+
+	progress, err = warmer.WithOverwrite(true).WarmKeys(context.Background(), []string{"cached"})
+	assert.NoError(t, err)
+	assert.Equal(t, WarmProgress{Processed: 1, Loaded: 1}, progress)
+	assert.Equal(t, "loaded:cached", target.values["cached"])
+}
+
+func TestCacheWarmer_StopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	loader := func(ctx context.Context, key string) (string, bool, error) {
+		cancel()
+		return "value", true, nil
+	}
+	warmer := NewCacheWarmer(&warmTarget{values: map[string]string{}}, loader).WithConcurrency(1)
+
+	progress, err := warmer.WarmKeys(ctx, []string{"a", "b", "c", "d"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, progress.Processed, int64(4))
+}
@@ -77,23 +77,19 @@ func pubSubCallback(
 		pat = models.CreateStringResult(string(C.GoBytes(pattern, pattern_len)))
 	}
 
-	go func() {
-		// Process different types of push messages
-		message := models.NewPubSubMessageWithPattern(msg, cha, pat)
+	// Process different types of push messages
+	pubSubMessage := models.NewPubSubMessageWithPattern(msg, cha, pat)
 
-		if clientPtr != nil {
-			// Look up the client in our registry using the pointer address
-			ptrValue := uintptr(clientPtr)
-			client := getClientByPtr(ptrValue)
+	// Look up the client in our registry using the pointer address
+	ptrValue := uintptr(clientPtr)
+	client := getClientByPtr(ptrValue)
 
-			if client != nil {
-				// If the client has a message handler, use it
-				if handler := client.getMessageHandler(); handler != nil {
-					handler.handleMessage(message)
-				}
-			} else {
-				log.Printf("Client not found for pointer: %v\n", ptrValue)
-			}
+	if client != nil {
+		// If the client has a message handler, use it
+		if handler := client.getMessageHandler(); handler != nil {
+			handler.dispatchMessage(pubSubMessage)
 		}
-	}()
+	} else {
+		log.Printf("Client not found for pointer: %v\n", ptrValue)
+	}
 }
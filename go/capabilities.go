@@ -0,0 +1,364 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// capabilityRequirement is the version of the server or the module required by a command.
+type capabilityRequirement struct {
+	version string
+	// modules are the names under which the required module may be loaded, the first one being reported.
+	modules []string
+}
+
+var (
+	requiresValkey62 = capabilityRequirement{version: "6.2.0"}
+	requiresValkey70 = capabilityRequirement{version: "7.0.0"}
+	requiresValkey90 = capabilityRequirement{version: "9.0.0"}
+	requiresJSON     = capabilityRequirement{modules: []string{"json", "ReJSON"}}
+	requiresSearch   = capabilityRequirement{modules: []string{"search"}}
+)
+
+// commandRequirements are the requirements of the typed commands which aren't supported by all the servers.
+var commandRequirements = map[protobuf.RequestType]capabilityRequirement{
+	protobuf.RequestType_Copy:           requiresValkey62,
+	protobuf.RequestType_GeoSearch:      requiresValkey62,
+	protobuf.RequestType_GeoSearchStore: requiresValkey62,
+	protobuf.RequestType_GetDel:         requiresValkey62,
+	protobuf.RequestType_GetEx:          requiresValkey62,
+	protobuf.RequestType_HRandField:     requiresValkey62,
+	protobuf.RequestType_BLMove:         requiresValkey62,
+	protobuf.RequestType_LMove:          requiresValkey62,
+	protobuf.RequestType_SMIsMember:     requiresValkey62,
+	protobuf.RequestType_XAutoClaim:     requiresValkey62,
+	protobuf.RequestType_ZDiff:          requiresValkey62,
+	protobuf.RequestType_ZDiffStore:     requiresValkey62,
+	protobuf.RequestType_ZInter:         requiresValkey62,
+	protobuf.RequestType_ZMScore:        requiresValkey62,
+	protobuf.RequestType_ZRandMember:    requiresValkey62,
+	protobuf.RequestType_ZRangeStore:    requiresValkey62,
+	protobuf.RequestType_ZUnion:         requiresValkey62,
+
+	protobuf.RequestType_BLMPop:          requiresValkey70,
+	protobuf.RequestType_BZMPop:          requiresValkey70,
+	protobuf.RequestType_ExpireTime:      requiresValkey70,
+	protobuf.RequestType_FCall:           requiresValkey70,
+	protobuf.RequestType_FCallReadOnly:   requiresValkey70,
+	protobuf.RequestType_FunctionDelete:  requiresValkey70,
+	protobuf.RequestType_FunctionDump:    requiresValkey70,
+	protobuf.RequestType_FunctionFlush:   requiresValkey70,
+	protobuf.RequestType_FunctionKill:    requiresValkey70,
+	protobuf.RequestType_FunctionList:    requiresValkey70,
+	protobuf.RequestType_FunctionLoad:    requiresValkey70,
+	protobuf.RequestType_FunctionRestore: requiresValkey70,
+	protobuf.RequestType_FunctionStats:   requiresValkey70,
+	protobuf.RequestType_LCS:             requiresValkey70,
+	protobuf.RequestType_LMPop:           requiresValkey70,
+	protobuf.RequestType_PExpireTime:     requiresValkey70,
+	protobuf.RequestType_SInterCard:      requiresValkey70,
+	protobuf.RequestType_SPublish:        requiresValkey70,
+	protobuf.RequestType_ZInterCard:      requiresValkey70,
+	protobuf.RequestType_ZMPop:           requiresValkey70,
+
+	protobuf.RequestType_HExpire:      requiresValkey90,
+	protobuf.RequestType_HExpireAt:    requiresValkey90,
+	protobuf.RequestType_HExpireTime:  requiresValkey90,
+	protobuf.RequestType_HGetEx:       requiresValkey90,
+	protobuf.RequestType_HPExpire:     requiresValkey90,
+	protobuf.RequestType_HPExpireAt:   requiresValkey90,
+	protobuf.RequestType_HPExpireTime: requiresValkey90,
+	protobuf.RequestType_HPersist:     requiresValkey90,
+	protobuf.RequestType_HPTtl:        requiresValkey90,
+	protobuf.RequestType_HSetEx:       requiresValkey90,
+	protobuf.RequestType_HTtl:         requiresValkey90,
+
+	protobuf.RequestType_JsonArrAppend: requiresJSON,
+	protobuf.RequestType_JsonArrIndex:  requiresJSON,
+	protobuf.RequestType_JsonArrInsert: requiresJSON,
+	protobuf.RequestType_JsonArrLen:    requiresJSON,
+	protobuf.RequestType_JsonArrPop:    requiresJSON,
+	protobuf.RequestType_JsonArrTrim:   requiresJSON,
+	protobuf.RequestType_JsonClear:     requiresJSON,
+	protobuf.RequestType_JsonDebug:     requiresJSON,
+	protobuf.RequestType_JsonDel:       requiresJSON,
+	protobuf.RequestType_JsonForget:    requiresJSON,
+	protobuf.RequestType_JsonGet:       requiresJSON,
+	protobuf.RequestType_JsonMGet:      requiresJSON,
+	protobuf.RequestType_JsonNumIncrBy: requiresJSON,
+	protobuf.RequestType_JsonNumMultBy: requiresJSON,
+	protobuf.RequestType_JsonObjKeys:   requiresJSON,
+	protobuf.RequestType_JsonObjLen:    requiresJSON,
+	protobuf.RequestType_JsonResp:      requiresJSON,
+	protobuf.RequestType_JsonSet:       requiresJSON,
+	protobuf.RequestType_JsonStrAppend: requiresJSON,
+	protobuf.RequestType_JsonStrLen:    requiresJSON,
+	protobuf.RequestType_JsonToggle:    requiresJSON,
+	protobuf.RequestType_JsonType:      requiresJSON,
+
+	protobuf.RequestType_FtAggregate:   requiresSearch,
+	protobuf.RequestType_FtAliasAdd:    requiresSearch,
+	protobuf.RequestType_FtAliasDel:    requiresSearch,
+	protobuf.RequestType_FtAliasList:   requiresSearch,
+	protobuf.RequestType_FtAliasUpdate: requiresSearch,
+	protobuf.RequestType_FtCreate:      requiresSearch,
+	protobuf.RequestType_FtDropIndex:   requiresSearch,
+	protobuf.RequestType_FtExplain:     requiresSearch,
+	protobuf.RequestType_FtExplainCli:  requiresSearch,
+	protobuf.RequestType_FtInfo:        requiresSearch,
+	protobuf.RequestType_FtList:        requiresSearch,
+	protobuf.RequestType_FtProfile:     requiresSearch,
+	protobuf.RequestType_FtSearch:      requiresSearch,
+}
+
+// containerCommands are the commands whose subcommands have request types of their own, e.g. ConfigGet for CONFIG
+// GET.
+var containerCommands = []string{
+	"Acl", "Client", "Cluster", "Command", "Config", "Function", "Latency", "Memory", "Module", "Object", "PubSub",
+	"Script", "SlowLog", "XGroup", "XInfo",
+}
+
+// commandName returns the name of the command of requestType, e.g. "HSETEX", "JSON.GET" or "CONFIG GET".
+func commandName(requestType protobuf.RequestType) string {
+	name := requestType.String()
+	switch {
+	case requestType == protobuf.RequestType_FCallReadOnly:
+		return "FCALL_RO"
+	case requestType == protobuf.RequestType_ClientKillSimple:
+		return "CLIENT KILL"
+	case strings.HasPrefix(name, "Json"):
+		return "JSON." + strings.ToUpper(strings.TrimPrefix(name, "Json"))
+	case strings.HasPrefix(name, "Ft"):
+		return "FT." + strings.ToUpper(strings.TrimPrefix(name, "Ft"))
+	}
+	for _, container := range containerCommands {
+		if subcommand, ok := strings.CutPrefix(name, container); ok && subcommand != "" {
+			return strings.ToUpper(container) + " " + strings.ToUpper(subcommand)
+		}
+	}
+	return strings.ToUpper(name)
+}
+
+// checkRequirement returns an [UnsupportedError] if the server with capabilities doesn't meet the requirement of
+// the command of requestType.
+func checkRequirement(
+	requestType protobuf.RequestType,
+	requirement capabilityRequirement,
+	capabilities models.Capabilities,
+) error {
+	if requirement.version != "" && !capabilities.AtLeast(requirement.version) {
+		return &UnsupportedError{command: commandName(requestType), version: requirement.version, detected: capabilities}
+	}
+	if len(requirement.modules) == 0 {
+		return nil
+	}
+	for _, module := range requirement.modules {
+		if capabilities.HasModule(module) {
+			return nil
+		}
+	}
+	return &UnsupportedError{command: commandName(requestType), module: requirement.modules[0], detected: capabilities}
+}
+
+// capabilityDetector detects the capabilities of the server of a client, once they're successfully detected.
+type capabilityDetector struct {
+	detect func(ctx context.Context) (models.Capabilities, error)
+
+	// mu serializes the detections.
+	mu       sync.Mutex
+	detected atomic.Pointer[models.Capabilities]
+}
+
+// newCapabilityDetector returns a [capabilityDetector] of the server of the client, or of a random node of a cluster.
+func (client *baseClient) newCapabilityDetector(clusterMode bool) *capabilityDetector {
+	var route config.Route
+	if clusterMode {
+		route = config.RandomRoute
+	}
+	return &capabilityDetector{detect: func(ctx context.Context) (models.Capabilities, error) {
+		return client.detectCapabilities(ctx, route)
+	}}
+}
+
+// capabilities returns the detected capabilities, detecting them if they weren't yet.
+func (detector *capabilityDetector) capabilities(ctx context.Context) (models.Capabilities, error) {
+	if detected := detector.detected.Load(); detected != nil {
+		return *detected, nil
+	}
+	detector.mu.Lock()
+	defer detector.mu.Unlock()
+	if detected := detector.detected.Load(); detected != nil {
+		return *detected, nil
+	}
+	capabilities, err := detector.detect(ctx)
+	if err != nil {
+		return models.Capabilities{}, err
+	}
+	detector.detected.Store(&capabilities)
+	return capabilities, nil
+}
+
+// forget forgets the detected capabilities, once the modules of the server changed, so that they're detected again
+// when next requested. Until then, the commands aren't checked.
+func (detector *capabilityDetector) forget() {
+	if detector != nil {
+		detector.detected.Store(nil)
+	}
+}
+
+// redetectAfter forgets the detected capabilities, and detects them again in the background, once a request failed
+// with err because its connection was lost, see [ConnectionError] and [DisconnectError]: the client then reconnects,
+// possibly to an upgraded server or to another node. Until they're detected again, the commands aren't checked.
+func (detector *capabilityDetector) redetectAfter(err error) {
+	var connectionErr *ConnectionError
+	var disconnectErr *DisconnectError
+	if detector == nil || !errors.As(err, &connectionErr) && !errors.As(err, &disconnectErr) {
+		return
+	}
+	// Only the first of the requests failing together starts a detection.
+	if detector.detected.Swap(nil) != nil {
+		go func() { _, _ = detector.capabilities(context.Background()) }()
+	}
+}
+
+// check returns an [UnsupportedError] if the command of requestType isn't supported by the server. Commands are only
+// rejected once the capabilities are detected, never waiting for their detection.
+func (detector *capabilityDetector) check(requestType uint32) error {
+	if detector == nil {
+		return nil
+	}
+	requirement, ok := commandRequirements[protobuf.RequestType(requestType)]
+	if !ok {
+		return nil
+	}
+	detected := detector.detected.Load()
+	if detected == nil {
+		return nil
+	}
+	return checkRequirement(protobuf.RequestType(requestType), requirement, *detected)
+}
+
+// Capabilities returns the version of the server of the client and the modules it loaded, as detected when the
+// client connected, or when first called for a client with lazy connection, for the clients created with
+// WithCapabilityDetection, see [config.ClientConfiguration.WithCapabilityDetection]. They're detected again once a request
+// fails because its connection was lost, as the client may reconnect to an upgraded server or to another node. The
+// capabilities of a cluster are those of a random node, which may differ from the other nodes during a rolling
+// upgrade.
+//
+// Once detected, the typed commands which the server doesn't support, e.g. HSETEX before Valkey 9.0 or JSON.GET without
+// the JSON module, fail fast with an [UnsupportedError] instead of being sent. Batches and custom commands aren't
+// checked.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the detection, if the capabilities weren't detected yet.
+//
+// Return value:
+//
+//	The capabilities of the server, or an error if they couldn't be detected, e.g. if INFO, COMMAND COUNT or MODULE
+//	LIST isn't permitted for the user of the client, or a [ConfigurationError] if the client doesn't detect them.
+func (client *baseClient) Capabilities(ctx context.Context) (models.Capabilities, error) {
+	if client.capabilities == nil {
+		return models.Capabilities{}, NewConfigurationError("the capabilities are only detected by the clients " +
+			"created with WithCapabilityDetection")
+	}
+	return client.capabilities.capabilities(ctx)
+}
+
+// detectCapabilities detects the capabilities of the server serving route with INFO SERVER, COMMAND COUNT and MODULE
+// LIST. They're sent directly, so that they aren't captured, traced or counted as requests of the client.
+func (client *baseClient) detectCapabilities(ctx context.Context, route config.Route) (models.Capabilities, error) {
+	response, err := client.sendCommand(ctx, C.Info, []string{"server"}, route)
+	if err != nil {
+		return models.Capabilities{}, fmt.Errorf("failed to detect the version of the server: %w", err)
+	}
+	info, err := handleStringResponse(response)
+	if err != nil {
+		return models.Capabilities{}, err
+	}
+	capabilities := parseServerVersion(info)
+
+	response, err = client.sendCommand(ctx, C.CommandCount, []string{}, route)
+	if err != nil {
+		return models.Capabilities{}, fmt.Errorf("failed to count the commands of the server: %w", err)
+	}
+	if capabilities.CommandCount, err = handleIntResponse(response); err != nil {
+		return models.Capabilities{}, err
+	}
+
+	response, err = client.sendCommand(ctx, C.ModuleList, []string{}, route)
+	if err != nil {
+		return models.Capabilities{}, fmt.Errorf("failed to list the modules of the server: %w", err)
+	}
+	if capabilities.Modules, err = handleModuleListResponse(response); err != nil {
+		return models.Capabilities{}, err
+	}
+	return capabilities, nil
+}
+
+// parseServerVersion returns the name and the version of a server from the server section of its INFO. Valkey
+// reports the version of Redis it's compatible with as redis_version, and its own as valkey_version.
+func parseServerVersion(info string) models.Capabilities {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if name, value, found := strings.Cut(strings.TrimSpace(line), ":"); found {
+			fields[name] = value
+		}
+	}
+	capabilities := models.Capabilities{ServerName: fields["server_name"], Version: fields["valkey_version"]}
+	if capabilities.ServerName == "" {
+		capabilities.ServerName = "redis"
+	}
+	if capabilities.Version == "" {
+		capabilities.Version = fields["redis_version"]
+	}
+	return capabilities
+}
+
+// parseServerModules returns the modules listed by MODULE LIST, a list of maps with RESP3, or of flat arrays of
+// names and values with RESP2.
+func parseServerModules(response any) ([]models.ServerModule, error) {
+	entries, ok := response.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of the response of MODULE LIST: %T", response)
+	}
+	modules := make([]models.ServerModule, 0, len(entries))
+	for _, entry := range entries {
+		fields := make(map[string]any)
+		switch entry := entry.(type) {
+		case map[string]any:
+			fields = entry
+		case []any:
+			for i := 0; i+1 < len(entry); i += 2 {
+				if name, ok := entry[i].(string); ok {
+					fields[name] = entry[i+1]
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unexpected type of a module listed by MODULE LIST: %T", entry)
+		}
+		module := models.ServerModule{}
+		module.Name, _ = fields["name"].(string)
+		module.Version, _ = fields["ver"].(int64)
+		module.Path, _ = fields["path"].(string)
+		args, _ := fields["args"].([]any)
+		for _, arg := range args {
+			module.Args = append(module.Args, fmt.Sprint(arg))
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
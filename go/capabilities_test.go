@@ -0,0 +1,165 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	valkey := parseServerVersion("# Server\r\nredis_version:7.2.4\r\nserver_name:valkey\r\nvalkey_version:8.1.3\r\n")
+	assert.Equal(t, models.Capabilities{ServerName: "valkey", Version: "8.1.3"}, valkey)
+
+	redis := parseServerVersion("# Server\r\nredis_version:6.2.14\r\nredis_mode:standalone\r\n")
+	assert.Equal(t, models.Capabilities{ServerName: "redis", Version: "6.2.14"}, redis)
+}
+
+func TestParseServerModules(t *testing.T) {
+	resp3 := []any{
+		map[string]any{"name": "json", "ver": int64(10002), "path": "/usr/lib/libjson.so", "args": []any{}},
+	}
+	modules, err := parseServerModules(resp3)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ServerModule{{Name: "json", Version: 10002, Path: "/usr/lib/libjson.so"}}, modules)
+
+	resp2 := []any{
+		[]any{"name", "search", "ver", int64(10000), "path", "/usr/lib/libsearch.so", "args", []any{"--threads", "4"}},
+	}
+	modules, err = parseServerModules(resp2)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ServerModule{
+		{Name: "search", Version: 10000, Path: "/usr/lib/libsearch.so", Args: []string{"--threads", "4"}},
+	}, modules)
+
+	modules, err = parseServerModules([]any{})
+	require.NoError(t, err)
+	assert.Empty(t, modules)
+
+	_, err = parseServerModules("OK")
+	assert.Error(t, err)
+}
+
+func TestCapabilities_AtLeast(t *testing.T) {
+	capabilities := models.Capabilities{Version: "8.1.3"}
+	assert.True(t, capabilities.AtLeast("8.1"))
+	assert.True(t, capabilities.AtLeast("8.1.3"))
+	assert.True(t, capabilities.AtLeast("7.2.10"))
+	assert.False(t, capabilities.AtLeast("8.1.4"))
+	assert.False(t, capabilities.AtLeast("9.0"))
+
+	capabilities.Modules = []models.ServerModule{{Name: "ReJSON"}}
+	assert.True(t, capabilities.HasModule("rejson"))
+	assert.False(t, capabilities.HasModule("search"))
+}
+
+func TestCommandName(t *testing.T) {
+	assert.Equal(t, "HSETEX", commandName(protobuf.RequestType_HSetEx))
+	assert.Equal(t, "JSON.GET", commandName(protobuf.RequestType_JsonGet))
+	assert.Equal(t, "FT.SEARCH", commandName(protobuf.RequestType_FtSearch))
+	assert.Equal(t, "FUNCTION LOAD", commandName(protobuf.RequestType_FunctionLoad))
+	assert.Equal(t, "FCALL_RO", commandName(protobuf.RequestType_FCallReadOnly))
+	assert.Equal(t, "CONFIG GET", commandName(protobuf.RequestType_ConfigGet))
+	assert.Equal(t, "XGROUP CREATECONSUMER", commandName(protobuf.RequestType_XGroupCreateConsumer))
+	assert.Equal(t, "CLIENT KILL", commandName(protobuf.RequestType_ClientKillSimple))
+	assert.Equal(t, "UNLINK", commandName(protobuf.RequestType_Unlink))
+}
+
+func TestCapabilityDetector_Check(t *testing.T) {
+	detections := 0
+	detector := &capabilityDetector{detect: func(ctx context.Context) (models.Capabilities, error) {
+		detections++
+		if detections == 1 {
+			return models.Capabilities{}, errors.New("NOPERM")
+		}
+		return models.Capabilities{ServerName: "valkey", Version: "8.1.3"}, nil
+	}}
+
+	// The commands aren't checked until the capabilities are detected.
+	assert.NoError(t, detector.check(uint32(protobuf.RequestType_HSetEx)))
+	_, err := detector.capabilities(context.Background())
+	assert.Error(t, err)
+	assert.NoError(t, detector.check(uint32(protobuf.RequestType_HSetEx)))
+
+	capabilities, err := detector.capabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "8.1.3", capabilities.Version)
+	_, err = detector.capabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, detections)
+
+	err = detector.check(uint32(protobuf.RequestType_HSetEx))
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.EqualError(t, err, "HSETEX requires Valkey 9.0.0, but the server is valkey 8.1.3")
+	var unsupported *UnsupportedError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, "9.0.0", unsupported.RequiredVersion())
+
+	err = detector.check(uint32(protobuf.RequestType_JsonGet))
+	assert.EqualError(t, err, "JSON.GET requires the json module, which the server didn't load")
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, "json", unsupported.RequiredModule())
+
+	assert.NoError(t, detector.check(uint32(protobuf.RequestType_GetDel)))
+	assert.NoError(t, detector.check(uint32(protobuf.RequestType_Get)))
+
+	// The capabilities are detected again once forgotten, e.g. after MODULE LOAD.
+	detector.forget()
+	assert.NoError(t, detector.check(uint32(protobuf.RequestType_JsonGet)))
+	_, err = detector.capabilities(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, detections)
+
+	// A nil detector, of a client created without one, checks nothing.
+	assert.NoError(t, (*capabilityDetector)(nil).check(uint32(protobuf.RequestType_HSetEx)))
+}
+
+func TestCapabilityDetector_RedetectAfter(t *testing.T) {
+	var detections atomic.Int32
+	detector := &capabilityDetector{detect: func(ctx context.Context) (models.Capabilities, error) {
+		detections.Add(1)
+		return models.Capabilities{ServerName: "valkey", Version: "9.0.0"}, nil
+	}}
+	detector.detected.Store(&models.Capabilities{ServerName: "valkey", Version: "8.1.3"})
+
+	// The other errors don't make the client reconnect.
+	detector.redetectAfter(NewRequestError("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	detector.redetectAfter(nil)
+	assert.ErrorIs(t, detector.check(uint32(protobuf.RequestType_HSetEx)), ErrUnsupported)
+
+	// The server may be upgraded by the time the client reconnects.
+	detector.redetectAfter(NewDisconnectError("connection dropped"))
+	detector.redetectAfter(NewConnectionError("connection refused"))
+	require.Eventually(t, func() bool { return detector.detected.Load() != nil }, time.Second, time.Millisecond)
+	assert.NoError(t, detector.check(uint32(protobuf.RequestType_HSetEx)))
+	assert.Equal(t, int32(1), detections.Load())
+
+	(*capabilityDetector)(nil).redetectAfter(NewDisconnectError("connection dropped"))
+}
+
+func TestTake_FallsBackWhenGetDelIsUnsupported(t *testing.T) {
+	detector := &capabilityDetector{}
+	detector.detected.Store(&models.Capabilities{ServerName: "redis", Version: "6.0.16"})
+	client := &baseClient{
+		core:           &coreClient{},
+		mu:             &sync.Mutex{},
+		requestTimeout: &atomic.Int64{},
+		timeoutClasses: &atomic.Pointer[map[string]time.Duration]{},
+		capabilities:   detector,
+	}
+
+	// GETDEL is rejected by the capabilities, so the transaction of GET and DEL is sent instead, which fails as the
+	// client is closed.
+	_, err := client.Take(context.Background(), "token")
+	assert.NotErrorIs(t, err, ErrUnsupported)
+	assert.ErrorIs(t, err, ErrConnectionClosed)
+}
@@ -0,0 +1,36 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"fmt"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// chunkElements splits elements into chunks bounded by chunkOptions, keeping the groups of width consecutive
+// elements, e.g. the field-value pairs of a hash, in the same chunk.
+func chunkElements(elements []string, width int, chunkOptions options.ChunkOptions) ([][]string, error) {
+	if chunkOptions.MaxBytes <= 0 || chunkOptions.MaxElements < width {
+		return nil, fmt.Errorf("invalid chunk options: the chunks must hold at least %d elements and a positive number "+
+			"of bytes, got %d elements and %d bytes", width, chunkOptions.MaxElements, chunkOptions.MaxBytes)
+	}
+	var chunks [][]string
+	start, size := 0, 0
+	for i := 0; i < len(elements); i += width {
+		end := min(i+width, len(elements))
+		groupSize := 0
+		for _, element := range elements[i:end] {
+			groupSize += len(element)
+		}
+		if i > start && (size+groupSize > chunkOptions.MaxBytes || end-start > chunkOptions.MaxElements) {
+			chunks = append(chunks, elements[start:i])
+			start, size = i, 0
+		}
+		size += groupSize
+	}
+	if start < len(elements) {
+		chunks = append(chunks, elements[start:])
+	}
+	return chunks, nil
+}
@@ -0,0 +1,43 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+func TestChunkElements(t *testing.T) {
+	elements := []string{"a", "bb", "ccc", "d", "eeeeeeee", "f"}
+
+	chunks, err := chunkElements(elements, 1, *options.NewChunkOptions().SetMaxElements(2))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "d"}, {"eeeeeeee", "f"}}, chunks)
+
+	// An element longer than the byte bound is sent alone.
+	chunks, err = chunkElements(elements, 1, *options.NewChunkOptions().SetMaxBytes(4))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "d"}, {"eeeeeeee"}, {"f"}}, chunks)
+
+	// Groups aren't split across chunks.
+	pairs := []string{"f1", "v1", "f2", "v2", "f3", "v3"}
+	chunks, err = chunkElements(pairs, 2, *options.NewChunkOptions().SetMaxElements(3))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"f1", "v1"}, {"f2", "v2"}, {"f3", "v3"}}, chunks)
+	chunks, err = chunkElements(pairs, 2, *options.NewChunkOptions())
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{pairs}, chunks)
+
+	chunks, err = chunkElements(nil, 1, *options.NewChunkOptions())
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	_, err = chunkElements(pairs, 2, *options.NewChunkOptions().SetMaxElements(1))
+	assert.Error(t, err)
+	_, err = chunkElements(pairs, 1, *options.NewChunkOptions().SetMaxBytes(0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// glideLibName is the library name the connections of this library report to the servers with CLIENT SETINFO.
+const glideLibName = "GlideGo"
+
+// clientKiller is implemented by [Client] and [ClusterClient].
+type clientKiller interface {
+	// listClients returns the output of CLIENT LIST with args, by node address, "" for standalone servers.
+	listClients(ctx context.Context, args []string) (map[string]string, error)
+	// killClient kills the connection id to node, unless it's the connection issuing the kill.
+	killClient(ctx context.Context, node string, id int64) (int64, error)
+}
+
+// previewKillClients returns the connections of client matching filter, as KillClients would kill them.
+func previewKillClients(
+	ctx context.Context,
+	client clientKiller,
+	filter options.ClientKillFilter,
+) ([]models.ClientConnection, error) {
+	args, err := filter.ToArgs()
+	if err != nil {
+		return nil, err
+	}
+	lists, err := client.listClients(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0, len(lists))
+	for node := range lists {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	var connections []models.ClientConnection
+	for _, node := range nodes {
+		for _, connection := range parseClientList(node, lists[node]) {
+			if matchesClientKillFilter(filter, connection) {
+				connections = append(connections, connection)
+			}
+		}
+	}
+	return connections, nil
+}
+
+// killClients kills the connections of client matching filter, and returns how many were killed.
+func killClients(ctx context.Context, client clientKiller, filter options.ClientKillFilter) (int64, error) {
+	connections, err := previewKillClients(ctx, client, filter)
+	if err != nil {
+		return 0, err
+	}
+	var killed int64
+	var errs []error
+	for _, connection := range connections {
+		count, err := client.killClient(ctx, connection.Node, connection.Id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return killed, errors.Join(append(errs, err)...)
+			}
+			errs = append(errs, fmt.Errorf("failed to kill connection %d: %w", connection.Id, err))
+			continue
+		}
+		killed += count
+	}
+	return killed, errors.Join(errs...)
+}
+
+// parseClientList parses the connections listed by CLIENT LIST on node, one per line.
+func parseClientList(node string, list string) []models.ClientConnection {
+	var connections []models.ClientConnection
+	for _, line := range strings.Split(list, "\n") {
+		properties := parseClientInfo(line)
+		id, err := strconv.ParseInt(properties["id"], 10, 64)
+		if err != nil {
+			continue
+		}
+		connections = append(connections, models.ClientConnection{
+			Node:       node,
+			Id:         id,
+			Addr:       properties["addr"],
+			LAddr:      properties["laddr"],
+			Name:       properties["name"],
+			User:       properties["user"],
+			LibName:    properties["lib-name"],
+			Flags:      properties["flags"],
+			Age:        clientListSeconds(properties["age"]),
+			Idle:       clientListSeconds(properties["idle"]),
+			Properties: properties,
+		})
+	}
+	return connections
+}
+
+// clientListSeconds parses a duration listed by CLIENT LIST in seconds.
+func clientListSeconds(value string) time.Duration {
+	seconds, _ := strconv.ParseInt(value, 10, 64)
+	return time.Duration(seconds) * time.Second
+}
+
+// matchesClientKillFilter reports whether connection matches the criteria of filter, the type being matched by
+// CLIENT LIST.
+func matchesClientKillFilter(filter options.ClientKillFilter, connection models.ClientConnection) bool {
+	if connection.LibName == glideLibName && !filter.IncludeLibraryConnections {
+		return false
+	}
+	if len(filter.Ids) > 0 {
+		found := false
+		for _, id := range filter.Ids {
+			found = found || id == connection.Id
+		}
+		if !found {
+			return false
+		}
+	}
+	return (filter.User == "" || filter.User == connection.User) &&
+		(filter.Addr == "" || filter.Addr == connection.Addr) &&
+		(filter.LAddr == "" || filter.LAddr == connection.LAddr) &&
+		(filter.Name == "" || filter.Name == connection.Name) &&
+		(filter.LibName == "" || filter.LibName == connection.LibName) &&
+		connection.Idle >= filter.MinIdle &&
+		connection.Age >= filter.MinAge
+}
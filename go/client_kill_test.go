@@ -0,0 +1,110 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+type fakeClientKiller struct {
+	lists    map[string]string
+	listArgs []string
+	killed   []string
+	killErr  error
+}
+
+func (killer *fakeClientKiller) listClients(_ context.Context, args []string) (map[string]string, error) {
+	killer.listArgs = args
+	return killer.lists, nil
+}
+
+func (killer *fakeClientKiller) killClient(_ context.Context, node string, id int64) (int64, error) {
+	if killer.killErr != nil {
+		return 0, killer.killErr
+	}
+	killer.killed = append(killer.killed, node+"/"+strconv.FormatInt(id, 10))
+	return 1, nil
+}
+
+const testClientList = "id=3 addr=10.0.0.5:50412 laddr=10.0.0.1:6379 name=worker age=3600 idle=600 flags=N " +
+	"user=default lib-name=redis-py cmd=get\n" +
+	"id=4 addr=10.0.0.6:50413 laddr=10.0.0.1:6379 name=worker age=10 idle=0 flags=N user=default " +
+	"lib-name=redis-py cmd=set\n" +
+	"id=5 addr=10.0.0.7:50414 laddr=10.0.0.1:6379 name=worker age=3600 idle=600 flags=N user=default " +
+	"lib-name=GlideGo cmd=get\n"
+
+func TestParseClientList(t *testing.T) {
+	connections := parseClientList("10.0.0.1:6379", testClientList)
+
+	assert.Len(t, connections, 3)
+	assert.Equal(t, "10.0.0.1:6379", connections[0].Node)
+	assert.Equal(t, int64(3), connections[0].Id)
+	assert.Equal(t, "10.0.0.5:50412", connections[0].Addr)
+	assert.Equal(t, "10.0.0.1:6379", connections[0].LAddr)
+	assert.Equal(t, "worker", connections[0].Name)
+	assert.Equal(t, "default", connections[0].User)
+	assert.Equal(t, "redis-py", connections[0].LibName)
+	assert.Equal(t, "N", connections[0].Flags)
+	assert.Equal(t, time.Hour, connections[0].Age)
+	assert.Equal(t, 10*time.Minute, connections[0].Idle)
+	assert.Equal(t, "get", connections[0].Properties["cmd"])
+}
+
+func TestPreviewKillClients(t *testing.T) {
+	killer := &fakeClientKiller{lists: map[string]string{"10.0.0.2:6379": testClientList, "10.0.0.1:6379": ""}}
+
+	connections, err := previewKillClients(
+		context.Background(),
+		killer,
+		*options.NewClientKillFilter().SetName("worker").SetMinIdle(time.Minute).SetType("normal"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TYPE", "normal"}, killer.listArgs)
+	// The idle connection of the library is excluded
+	assert.Len(t, connections, 1)
+	assert.Equal(t, int64(3), connections[0].Id)
+	assert.Equal(t, "10.0.0.2:6379", connections[0].Node)
+
+	connections, err = previewKillClients(
+		context.Background(),
+		killer,
+		*options.NewClientKillFilter().SetMinIdle(time.Minute).SetIncludeLibraryConnections(true),
+	)
+	assert.NoError(t, err)
+	assert.Len(t, connections, 2)
+
+	connections, err = previewKillClients(context.Background(), killer, *options.NewClientKillFilter().SetIds(4, 5))
+	assert.NoError(t, err)
+	assert.Len(t, connections, 1)
+	assert.Equal(t, int64(4), connections[0].Id)
+}
+
+func TestKillClients(t *testing.T) {
+	killer := &fakeClientKiller{lists: map[string]string{"": testClientList}}
+
+	killed, err := killClients(context.Background(), killer, *options.NewClientKillFilter().SetLibName("redis-py"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), killed)
+	assert.Len(t, killer.killed, 2)
+
+	killer.killErr = errors.New("NOPERM")
+	killed, err = killClients(context.Background(), killer, *options.NewClientKillFilter().SetLibName("redis-py"))
+	assert.ErrorContains(t, err, "NOPERM")
+	assert.Zero(t, killed)
+}
+
+func TestKillClients_RequiresCriteria(t *testing.T) {
+	killer := &fakeClientKiller{lists: map[string]string{"": testClientList}}
+
+	_, err := killClients(context.Background(), killer, *options.NewClientKillFilter().SetIncludeLibraryConnections(true))
+	assert.ErrorContains(t, err, "at least one criterion")
+	assert.Nil(t, killer.listArgs)
+	assert.Empty(t, killer.killed)
+}
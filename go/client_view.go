@@ -0,0 +1,381 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+)
+
+// ViewOptions represents the per-call defaults overridden by a view of a client, see [Client.With] and
+// [ClusterClient.With]. Only the options set with the With* methods are overridden, the others are inherited from
+// the viewed client.
+//
+// The read strategy of a view can't be overridden: it's applied by the connections of the client, shared by its
+// views.
+type ViewOptions struct {
+	timeout   *time.Duration
+	priority  *Priority
+	keyPrefix string
+	routes    []prefixRoute
+}
+
+// prefixRouteTarget is a client the commands of a view can be routed to, a [Client] or a [ClusterClient].
+type prefixRouteTarget interface {
+	routeTarget() *baseClient
+}
+
+// routeTarget returns the client the commands routed to it are sent by.
+func (client *baseClient) routeTarget() *baseClient {
+	return client
+}
+
+// prefixRoute routes the commands whose keys start with prefix to target.
+type prefixRoute struct {
+	prefix string
+	target *baseClient
+}
+
+// NewViewOptions returns [ViewOptions] which don't override any default.
+func NewViewOptions() *ViewOptions {
+	return &ViewOptions{}
+}
+
+// WithTimeout sets the time the view waits for the response of a request, for the requests whose context has no
+// earlier deadline. It overrides the request timeout set with UpdateRuntimeConfiguration, but, like it, can't extend
+// the request timeout the client was created with. The blocking commands, e.g. BLPOP or WAIT, wait for their own
+// timeout instead.
+func (options *ViewOptions) WithTimeout(timeout time.Duration) *ViewOptions {
+	options.timeout = &timeout
+	return options
+}
+
+// WithPriority sets the [Priority] of the requests of the view whose context has none, see [WithPriority]. It only
+// matters to clients configured with a request scheduler.
+func (options *ViewOptions) WithPriority(priority Priority) *ViewOptions {
+	options.priority = &priority
+	return options
+}
+
+// WithKeyPrefix sets a prefix prepended to the keys of the commands, scripts and batches of the view, e.g. to keep
+// the keys of a subsystem in a namespace of their own.
+//
+// The keys are prefixed in the requests only: the keys returned by commands such as SCAN or KEYS, and the patterns
+// and keys of cluster scans, aren't prefixed or stripped of the prefix. Commands whose keys aren't known to the
+// client, e.g. most custom commands, fail with a [ConfigurationError] rather than being sent with unprefixed keys.
+// The prefix of a view of a view is appended to the prefix of the viewed view.
+func (options *ViewOptions) WithKeyPrefix(keyPrefix string) *ViewOptions {
+	options.keyPrefix = keyPrefix
+	return options
+}
+
+// WithPrefixRoute routes the commands, scripts and batches of the view whose keys start with prefix to target,
+// another client, e.g. to move the keys of a namespace to a cluster of their own while the application keeps using a
+// single client. The route of prefix replaces the previous one, if any. When several prefixes match a key, the route
+// of the longest one applies, and the keys matching none are sent by the viewed client, as are the commands without
+// keys.
+//
+// The routed requests are sent with the per-call defaults of target, rather than the ones of the view. The keys of a
+// request must all be routed to the same client, and the commands whose keys aren't known to the client, e.g. most
+// custom commands, fail with a [ConfigurationError] rather than being sent to the wrong client. The commands without
+// keys, such as SCAN, KEYS or the cluster scans, are only sent by the viewed client. The routes of a view of a view are
+// added to the routes of the viewed view.
+//
+// Example usage:
+//
+//	client := defaultClient.With(glide.NewViewOptions().
+//		WithPrefixRoute("sess:", sessionsCluster).
+//		WithPrefixRoute("feed:", feedsCluster))
+//	_, err := client.Get(ctx, "sess:abc") // Sent by sessionsCluster
+func (options *ViewOptions) WithPrefixRoute(prefix string, target prefixRouteTarget) *ViewOptions {
+	options.routes = withPrefixRoute(options.routes, prefixRoute{prefix: prefix, target: target.routeTarget()})
+	return options
+}
+
+// withPrefixRoute returns routes with route, replacing the route of the same prefix, if any. routes isn't modified.
+func withPrefixRoute(routes []prefixRoute, route prefixRoute) []prefixRoute {
+	updated := make([]prefixRoute, 0, len(routes)+1)
+	for _, existing := range routes {
+		if existing.prefix != route.prefix {
+			updated = append(updated, existing)
+		}
+	}
+	return append(updated, route)
+}
+
+// clientView holds the per-call defaults of a view of a client.
+type clientView struct {
+	timeout   *time.Duration
+	priority  *Priority
+	keyPrefix string
+	routes    []prefixRoute
+}
+
+// newClientView returns the defaults of parent, nil unless the viewed client is a view, overridden by options.
+func newClientView(parent *clientView, options *ViewOptions) *clientView {
+	view := &clientView{}
+	if parent != nil {
+		*view = *parent
+	}
+	if options == nil {
+		return view
+	}
+	if options.timeout != nil {
+		view.timeout = options.timeout
+	}
+	if options.priority != nil {
+		view.priority = options.priority
+	}
+	view.keyPrefix += options.keyPrefix
+	for _, route := range options.routes {
+		view.routes = withPrefixRoute(view.routes, route)
+	}
+	return view
+}
+
+// newView returns a view of the client, sharing its connections, overridden by options.
+func (client *baseClient) newView(options *ViewOptions) baseClient {
+	view := *client
+	view.view = newClientView(client.view, options)
+	return view
+}
+
+// With returns a view of the client: a lightweight client sharing the connections of the client, whose requests
+// use the per-call defaults set in viewOptions, so that the subsystems of a service can have their own policies
+// without connection pools of their own.
+//
+// Closing a view is a no-op: the connections are closed by closing the client, which also makes its views fail
+// with a [ClosingError].
+//
+// Example usage:
+//
+//	sessions := client.With(glide.NewViewOptions().WithKeyPrefix("session:").WithTimeout(50 * time.Millisecond))
+//	reports := client.With(glide.NewViewOptions().WithPriority(glide.PriorityLow))
+//	_, err := sessions.Set(ctx, "abc", "data") // Sets session:abc
+//
+// Parameters:
+//
+//	viewOptions - The per-call defaults of the view.
+//
+// Return value:
+//
+//	A view of the client.
+func (client *Client) With(viewOptions *ViewOptions) *Client {
+	return &Client{client.newView(viewOptions)}
+}
+
+// With returns a view of the client: a lightweight client sharing the connections of the client, whose requests
+// use the per-call defaults set in viewOptions, so that the subsystems of a service can have their own policies
+// without connection pools of their own.
+//
+// Closing a view is a no-op: the connections are closed by closing the client, which also makes its views fail
+// with a [ClosingError].
+//
+// Example usage:
+//
+//	sessions := client.With(glide.NewViewOptions().WithKeyPrefix("{session}:").WithTimeout(50 * time.Millisecond))
+//	reports := client.With(glide.NewViewOptions().WithPriority(glide.PriorityLow))
+//	_, err := sessions.Set(ctx, "abc", "data") // Sets {session}:abc
+//
+// Parameters:
+//
+//	viewOptions - The per-call defaults of the view.
+//
+// Return value:
+//
+//	A view of the client.
+func (client *ClusterClient) With(viewOptions *ViewOptions) *ClusterClient {
+	return &ClusterClient{client.newView(viewOptions)}
+}
+
+// prefix returns the key prefix of the view, "" if there is none.
+func (view *clientView) prefix() string {
+	if view == nil {
+		return ""
+	}
+	return view.keyPrefix
+}
+
+// context returns ctx tagged with the priority of the view, unless ctx has a priority already.
+func (view *clientView) context(ctx context.Context) context.Context {
+	if view == nil || view.priority == nil {
+		return ctx
+	}
+	if _, ok := ctx.Value(PriorityContextKey).(Priority); ok {
+		return ctx
+	}
+	return WithPriority(ctx, *view.priority)
+}
+
+// prefixCommand returns the arguments of a command of type requestType with the key prefix of the view prepended
+// to its keys, and its route to the slot of a prefixed key, if it's routed by key.
+func (view *clientView) prefixCommand(
+	requestType uint32,
+	args []string,
+	route config.Route,
+) ([]string, config.Route, error) {
+	if view == nil || view.keyPrefix == "" {
+		return args, route, nil
+	}
+	command, commandArgs := commandNameArgs(requestType, args)
+	prefixed, err := prefixKeys(command, commandArgs, view.keyPrefix)
+	if err != nil {
+		return nil, nil, NewConfigurationError(err.Error())
+	}
+	if len(commandArgs) < len(args) {
+		prefixed = append([]string{args[0]}, prefixed...)
+	}
+	args = prefixed
+	return args, view.prefixRoute(route), nil
+}
+
+// prefixBatch returns batch with the key prefix of the view prepended to the keys of its commands. batch isn't
+// modified.
+func (view *clientView) prefixBatch(batch internal.Batch, options *internal.BatchOptions) (
+	internal.Batch, *internal.BatchOptions, error,
+) {
+	if view == nil || view.keyPrefix == "" {
+		return batch, options, nil
+	}
+	commands := make([]internal.Cmd, len(batch.Commands))
+	for i, cmd := range batch.Commands {
+		args, _, err := view.prefixCommand(cmd.RequestType, cmd.Args, nil)
+		if err != nil {
+			return batch, options, err
+		}
+		cmd.Args = args
+		commands[i] = cmd
+	}
+	batch.Commands = commands
+	if options != nil && options.Route != nil {
+		prefixedOptions := *options
+		prefixedOptions.Route = view.prefixRoute(options.Route)
+		options = &prefixedOptions
+	}
+	return batch, options, nil
+}
+
+// prefixScript returns the keys of a script with the key prefix of the view prepended, and its route to the slot of
+// a prefixed key, if it's routed by key.
+func (view *clientView) prefixScript(keys []string, route config.Route) ([]string, config.Route) {
+	if view == nil || view.keyPrefix == "" {
+		return keys, route
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = view.keyPrefix + key
+	}
+	return prefixed, view.prefixRoute(route)
+}
+
+// prefixRoute returns route with the key prefix of the view prepended to its key, if it's routed by key.
+func (view *clientView) prefixRoute(route config.Route) config.Route {
+	if slotKeyRoute, ok := route.(*config.SlotKeyRoute); ok {
+		return config.NewSlotKeyRoute(slotKeyRoute.SlotType, view.keyPrefix+slotKeyRoute.SlotKey)
+	}
+	return route
+}
+
+// routeKey returns the client key is routed to by the prefix routes of the view, or nil if it's sent by the viewed
+// client.
+func (view *clientView) routeKey(key string) *baseClient {
+	if view == nil {
+		return nil
+	}
+	var target *baseClient
+	longest := -1
+	for _, route := range view.routes {
+		if len(route.prefix) > longest && strings.HasPrefix(key, route.prefix) {
+			target, longest = route.target, len(route.prefix)
+		}
+	}
+	return target
+}
+
+// routeKeys returns the client keys are routed to, or nil if they're sent by the viewed client, and an error if
+// they're routed to different clients. request describes the request of the keys in the error.
+func (view *clientView) routeKeys(request string, keys []string) (*baseClient, error) {
+	var target *baseClient
+	for i, key := range keys {
+		keyTarget := view.routeKey(key)
+		if i > 0 && keyTarget != target {
+			return nil, NewConfigurationError(fmt.Sprintf("the keys of %s are routed to different clients", request))
+		}
+		target = keyTarget
+	}
+	return target, nil
+}
+
+// commandRouteKeys returns the keys the command of type requestType is routed by: its keys, and the key of its route,
+// if it's routed by key.
+func commandRouteKeys(requestType uint32, args []string, route config.Route) (string, []string, error) {
+	command, commandArgs := commandNameArgs(requestType, args)
+	indexes, known, err := keyIndexes(command, commandArgs)
+	if err != nil {
+		return command, nil, NewConfigurationError(err.Error())
+	}
+	if !known {
+		return command, nil, NewConfigurationError(command + " isn't supported by clients with prefix routes")
+	}
+	keys := make([]string, 0, len(indexes)+1)
+	for _, index := range indexes {
+		keys = append(keys, commandArgs[index])
+	}
+	if slotKeyRoute, ok := route.(*config.SlotKeyRoute); ok {
+		keys = append(keys, slotKeyRoute.SlotKey)
+	}
+	return command, keys, nil
+}
+
+// routeCommand returns the client the command of type requestType is routed to by the prefix routes of the view, or
+// nil if it's sent by the viewed client.
+func (view *clientView) routeCommand(requestType uint32, args []string, route config.Route) (*baseClient, error) {
+	if view == nil || len(view.routes) == 0 {
+		return nil, nil
+	}
+	command, keys, err := commandRouteKeys(requestType, args, route)
+	if err != nil {
+		return nil, err
+	}
+	return view.routeKeys(command, keys)
+}
+
+// routeBatch returns the client batch is routed to by the prefix routes of the view, or nil if it's sent by the
+// viewed client. The keys of all the commands of the batch must be routed to the same client, the commands without
+// keys being sent along with the others.
+func (view *clientView) routeBatch(batch internal.Batch, options *internal.BatchOptions) (*baseClient, error) {
+	if view == nil || len(view.routes) == 0 {
+		return nil, nil
+	}
+	var keys []string
+	for _, cmd := range batch.Commands {
+		_, commandKeys, err := commandRouteKeys(cmd.RequestType, cmd.Args, nil)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, commandKeys...)
+	}
+	if options != nil {
+		if slotKeyRoute, ok := options.Route.(*config.SlotKeyRoute); ok {
+			keys = append(keys, slotKeyRoute.SlotKey)
+		}
+	}
+	return view.routeKeys("the batch", keys)
+}
+
+// routeScript returns the client a script is routed to by the prefix routes of the view, or nil if it's sent by the
+// viewed client.
+func (view *clientView) routeScript(keys []string, route config.Route) (*baseClient, error) {
+	if view == nil || len(view.routes) == 0 {
+		return nil, nil
+	}
+	if slotKeyRoute, ok := route.(*config.SlotKeyRoute); ok {
+		keys = append(append([]string(nil), keys...), slotKeyRoute.SlotKey)
+	}
+	return view.routeKeys("the script", keys)
+}
@@ -0,0 +1,184 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestPrefixKeys(t *testing.T) {
+	tests := []struct {
+		command  string
+		args     []string
+		expected []string
+	}{
+		{"GET", []string{"key"}, []string{"p:key"}},
+		{"SET", []string{"key", "value", "EX", "10"}, []string{"p:key", "value", "EX", "10"}},
+		{"RENAME", []string{"a", "b"}, []string{"p:a", "p:b"}},
+		{"DEL", []string{"a", "b", "c"}, []string{"p:a", "p:b", "p:c"}},
+		{"BLPOP", []string{"a", "b", "1"}, []string{"p:a", "p:b", "1"}},
+		{"MSET", []string{"a", "1", "b", "2"}, []string{"p:a", "1", "p:b", "2"}},
+		{"ZUNION", []string{"2", "a", "b", "WITHSCORES"}, []string{"2", "p:a", "p:b", "WITHSCORES"}},
+		{"FCALL", []string{"f", "1", "a", "arg"}, []string{"f", "1", "p:a", "arg"}},
+		{"ZUNIONSTORE", []string{"d", "2", "a", "b"}, []string{"p:d", "2", "p:a", "p:b"}},
+		{"XREAD", []string{"COUNT", "1", "STREAMS", "a", "b", "0", "0"}, []string{"COUNT", "1", "STREAMS", "p:a", "p:b", "0", "0"}},
+		{"PING", []string{"hello"}, []string{"hello"}},
+		{"CONFIGGET", []string{"maxmemory"}, []string{"maxmemory"}},
+	}
+	for _, test := range tests {
+		args := append([]string(nil), test.args...)
+		prefixed, err := prefixKeys(test.command, args, "p:")
+		require.NoError(t, err, test.command)
+		assert.Equal(t, test.expected, prefixed, test.command)
+		assert.Equal(t, test.args, args, "%s modified its arguments", test.command)
+	}
+
+	_, err := prefixKeys("SORT", []string{"key", "STORE", "dest"}, "p:")
+	assert.ErrorContains(t, err, "SORT isn't supported")
+	_, err = prefixKeys("ZUNION", []string{"two", "a"}, "p:")
+	assert.ErrorContains(t, err, "invalid number of keys")
+}
+
+func TestClientView_Options(t *testing.T) {
+	parent := newClientView(nil, NewViewOptions().WithKeyPrefix("app:").WithTimeout(time.Second))
+	view := newClientView(parent, NewViewOptions().WithKeyPrefix("sessions:").WithPriority(PriorityLow))
+	assert.Equal(t, "app:sessions:", view.keyPrefix)
+	assert.Equal(t, time.Second, *view.timeout)
+	assert.Equal(t, PriorityLow, *view.priority)
+	assert.Equal(t, "app:", parent.keyPrefix)
+	assert.Nil(t, parent.priority)
+
+	ctx := view.context(context.Background())
+	assert.Equal(t, PriorityLow, PriorityFromContext(ctx))
+	ctx = view.context(WithPriority(context.Background(), PriorityHigh))
+	assert.Equal(t, PriorityHigh, PriorityFromContext(ctx))
+}
+
+func TestClientView_Timeout(t *testing.T) {
+	client := newRuntimeConfigTestClient(nil)
+	client.requestTimeout.Store(int64(time.Hour))
+	view := client.newView(NewViewOptions().WithTimeout(10 * time.Millisecond))
+
+	ctx, cancel, err := view.withRequestTimeout(context.Background(), false)
+	require.NoError(t, err)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(10*time.Millisecond), deadline, time.Second)
+
+	// The blocking commands wait for their own timeout.
+	ctx, cancel, err = view.withRequestTimeout(context.Background(), true)
+	require.NoError(t, err)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+
+	// Closing a view doesn't close the client.
+	view.Close()
+	assert.False(t, client.isClosed())
+}
+
+func TestClientView_PrefixRequests(t *testing.T) {
+	view := newClientView(nil, NewViewOptions().WithKeyPrefix("p:"))
+
+	args, route, err := view.prefixCommand(
+		uint32(protobuf.RequestType_Get),
+		[]string{"key"},
+		config.NewSlotKeyRoute(config.SlotTypePrimary, "key"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p:key"}, args)
+	assert.Equal(t, config.NewSlotKeyRoute(config.SlotTypePrimary, "p:key"), route)
+
+	args, _, err = view.prefixCommand(uint32(protobuf.RequestType_CustomCommand), []string{"get", "key"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"get", "p:key"}, args)
+
+	_, _, err = view.prefixCommand(uint32(protobuf.RequestType_CustomCommand), []string{"SORT", "key"}, nil)
+	assert.IsType(t, &ConfigurationError{}, err)
+
+	batch := internal.Batch{Commands: []internal.Cmd{
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"a", "1"}, nil),
+		internal.MakeCmd(uint32(protobuf.RequestType_MGet), []string{"a", "b"}, nil),
+	}}
+	prefixed, _, err := view.prefixBatch(batch, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p:a", "1"}, prefixed.Commands[0].Args)
+	assert.Equal(t, []string{"p:a", "p:b"}, prefixed.Commands[1].Args)
+	assert.Equal(t, []string{"a", "1"}, batch.Commands[0].Args)
+
+	keys, _ := view.prefixScript([]string{"a", "b"}, nil)
+	assert.Equal(t, []string{"p:a", "p:b"}, keys)
+
+	// Clients which aren't views send their requests unchanged.
+	var notView *clientView
+	args, _, err = notView.prefixCommand(uint32(protobuf.RequestType_CustomCommand), []string{"SORT", "key"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SORT", "key"}, args)
+}
+
+func TestClientView_PrefixRoutes(t *testing.T) {
+	sessions, feeds, users := &baseClient{}, &baseClient{}, &baseClient{}
+	parent := newClientView(nil, NewViewOptions().WithPrefixRoute("sess:", sessions).WithPrefixRoute("feed:", users))
+	view := newClientView(parent, NewViewOptions().WithPrefixRoute("feed:", feeds).WithPrefixRoute("sess:admin:", users))
+	assert.Same(t, users, parent.routeKey("feed:1"))
+
+	// The route of the longest prefix applies.
+	assert.Same(t, sessions, view.routeKey("sess:abc"))
+	assert.Same(t, users, view.routeKey("sess:admin:abc"))
+	assert.Same(t, feeds, view.routeKey("feed:1"))
+	assert.Nil(t, view.routeKey("cart:1"))
+
+	target, err := view.routeCommand(uint32(protobuf.RequestType_MGet), []string{"feed:1", "feed:2"}, nil)
+	require.NoError(t, err)
+	assert.Same(t, feeds, target)
+	target, err = view.routeCommand(uint32(protobuf.RequestType_CustomCommand), []string{"get", "sess:abc"}, nil)
+	require.NoError(t, err)
+	assert.Same(t, sessions, target)
+	target, err = view.routeCommand(uint32(protobuf.RequestType_Ping), []string{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, target)
+	target, err = view.routeCommand(
+		uint32(protobuf.RequestType_CustomCommand),
+		[]string{"PING"},
+		config.NewSlotKeyRoute(config.SlotTypePrimary, "sess:abc"),
+	)
+	require.NoError(t, err)
+	assert.Same(t, sessions, target)
+
+	_, err = view.routeCommand(uint32(protobuf.RequestType_MGet), []string{"feed:1", "cart:1"}, nil)
+	assert.IsType(t, &ConfigurationError{}, err)
+	_, err = view.routeCommand(uint32(protobuf.RequestType_CustomCommand), []string{"SORT", "feed:1"}, nil)
+	assert.ErrorContains(t, err, "SORT isn't supported by clients with prefix routes")
+
+	// The commands without keys are sent along with the others of a batch.
+	batch := internal.Batch{Commands: []internal.Cmd{
+		internal.MakeCmd(uint32(protobuf.RequestType_Ping), []string{}, nil),
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"feed:1", "a"}, nil),
+	}}
+	target, err = view.routeBatch(batch, nil)
+	require.NoError(t, err)
+	assert.Same(t, feeds, target)
+	batch.Commands = append(batch.Commands, internal.MakeCmd(uint32(protobuf.RequestType_Get), []string{"cart:1"}, nil))
+	_, err = view.routeBatch(batch, nil)
+	assert.ErrorContains(t, err, "the keys of the batch are routed to different clients")
+
+	target, err = view.routeScript([]string{"sess:a", "sess:b"}, nil)
+	require.NoError(t, err)
+	assert.Same(t, sessions, target)
+
+	// Views without routes send their requests themselves.
+	plain := newClientView(nil, NewViewOptions().WithKeyPrefix("p:"))
+	target, err = plain.routeCommand(uint32(protobuf.RequestType_CustomCommand), []string{"SORT", "feed:1"}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, target)
+}
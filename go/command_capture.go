@@ -0,0 +1,192 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+// ErrCommandCaptured is the error of the commands of a client capturing its commands with a [CommandCapture] in dry
+// run, see [CommandCapture.WithDryRun].
+var ErrCommandCaptured = errors.New("the command was captured and not sent")
+
+// CapturedCommand is a command issued by a client, as recorded by a [CommandCapture].
+type CapturedCommand struct {
+	// Name is the name of the command in upper case, e.g. "UNLINK" or "CONFIG GET", and "EVALSHA" for scripts.
+	Name string
+	// Args are the arguments of the command following its name, with the keys prefixed for the view of the client
+	// which issued it, e.g. the keys of UNLINK, or the hash, the number of keys, the keys and the arguments of EVALSHA.
+	Args []string
+	// Node is the address of the node the command was routed to, when it was routed to a given node with
+	// [config.ByAddressRoute]. It's empty otherwise, as the node is then picked by the client.
+	Node string
+	// Batch is set for the commands of a batch.
+	Batch bool
+}
+
+// CommandCapture records the commands issued by a [Client] or a [ClusterClient] with CaptureCommands, so that tests
+// can assert on the commands the code under test sent, e.g. that exactly one UNLINK of a key was sent.
+//
+// In dry run, the commands are recorded but not sent, and fail with [ErrCommandCaptured]: with a client created with
+// lazy connect, which doesn't connect before its first command is sent, the commands are thus captured without a
+// server.
+//
+// Example usage:
+//
+//	capture := glide.NewCommandCapture()
+//	client.CaptureCommands(capture)
+//	evictSession(ctx, client, "session:1")
+//	if capture.Count("UNLINK", "session:1") != 1 {
+//		t.Error("expected exactly one UNLINK of session:1")
+//	}
+type CommandCapture struct {
+	dryRun bool
+
+	mu       sync.Mutex
+	commands []CapturedCommand
+}
+
+// NewCommandCapture returns a [CommandCapture] recording the commands which are sent.
+func NewCommandCapture() *CommandCapture {
+	return &CommandCapture{}
+}
+
+// WithDryRun makes the capture record the commands without sending them: they fail with [ErrCommandCaptured].
+func (capture *CommandCapture) WithDryRun() *CommandCapture {
+	capture.dryRun = true
+	return capture
+}
+
+// record records command, and returns ErrCommandCaptured if it must not be sent. A nil capture records nothing.
+func (capture *CommandCapture) record(commands ...CapturedCommand) error {
+	if capture == nil {
+		return nil
+	}
+	capture.mu.Lock()
+	capture.commands = append(capture.commands, commands...)
+	capture.mu.Unlock()
+	if capture.dryRun {
+		return ErrCommandCaptured
+	}
+	return nil
+}
+
+// Commands returns the commands recorded so far, in the order they were issued.
+func (capture *CommandCapture) Commands() []CapturedCommand {
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	return append([]CapturedCommand(nil), capture.commands...)
+}
+
+// Matching returns the recorded commands named name, ignoring case, whose arguments start with args, e.g.
+// Matching("HSET", "user:1") for the HSETs of the hash user:1.
+func (capture *CommandCapture) Matching(name string, args ...string) []CapturedCommand {
+	var matching []CapturedCommand
+	for _, command := range capture.Commands() {
+		if command.matches(name, args) {
+			matching = append(matching, command)
+		}
+	}
+	return matching
+}
+
+// Count returns the number of recorded commands named name, ignoring case, whose arguments start with args.
+func (capture *CommandCapture) Count(name string, args ...string) int {
+	return len(capture.Matching(name, args...))
+}
+
+// Reset forgets the commands recorded so far.
+func (capture *CommandCapture) Reset() {
+	capture.mu.Lock()
+	capture.commands = nil
+	capture.mu.Unlock()
+}
+
+func (command CapturedCommand) matches(name string, args []string) bool {
+	if !strings.EqualFold(command.Name, name) || len(command.Args) < len(args) {
+		return false
+	}
+	for i, arg := range args {
+		if command.Args[i] != arg {
+			return false
+		}
+	}
+	return true
+}
+
+// capturedCommand describes the command of requestType with args routed with route, as recorded by a
+// [CommandCapture].
+func capturedCommand(requestType uint32, args []string, route config.Route) CapturedCommand {
+	name := commandName(protobuf.RequestType(requestType))
+	if protobuf.RequestType(requestType) == protobuf.RequestType_CustomCommand && len(args) > 0 {
+		name, args = strings.ToUpper(args[0]), args[1:]
+	}
+	return CapturedCommand{Name: name, Args: append([]string(nil), args...), Node: routeNode(route)}
+}
+
+// captureCommand records the command of requestType, if the client captures its commands, and returns
+// ErrCommandCaptured if it must not be sent.
+func (client *baseClient) captureCommand(requestType uint32, args []string, route config.Route) error {
+	if client.capture == nil {
+		return nil
+	}
+	return client.capture.Load().record(capturedCommand(requestType, args, route))
+}
+
+// captureScript records the EVALSHA of the script of hash, if the client captures its commands, and returns
+// ErrCommandCaptured if it must not be sent.
+func (client *baseClient) captureScript(hash string, keys []string, args []string, route config.Route) error {
+	if client.capture == nil {
+		return nil
+	}
+	capture := client.capture.Load()
+	if capture == nil {
+		return nil
+	}
+	scriptArgs := append(append([]string{hash, strconv.Itoa(len(keys))}, keys...), args...)
+	return capture.record(CapturedCommand{Name: "EVALSHA", Args: scriptArgs, Node: routeNode(route)})
+}
+
+// captureBatch records the commands of batch, if the client captures its commands, and returns ErrCommandCaptured if
+// the batch must not be sent.
+func (client *baseClient) captureBatch(batch internal.Batch, options *internal.BatchOptions) error {
+	if client.capture == nil {
+		return nil
+	}
+	capture := client.capture.Load()
+	if capture == nil {
+		return nil
+	}
+	var route config.Route
+	if options != nil {
+		route = options.Route
+	}
+	commands := make([]CapturedCommand, len(batch.Commands))
+	for i, command := range batch.Commands {
+		commands[i] = capturedCommand(command.RequestType, command.Args, route)
+		commands[i].Batch = true
+	}
+	return capture.record(commands...)
+}
+
+// CaptureCommands makes the client, and its views, record the commands they issue with capture, until it's called
+// again. A nil capture stops the recording.
+//
+// The commands are recorded once prefixed for the view which issued them, before they're sent: the commands which
+// fail, or time out, are recorded too.
+//
+// Parameters:
+//
+//	capture - The capture recording the commands, or nil.
+func (client *baseClient) CaptureCommands(capture *CommandCapture) {
+	if client.capture != nil {
+		client.capture.Store(capture)
+	}
+}
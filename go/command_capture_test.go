@@ -0,0 +1,76 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestCommandCapture_DryRun(t *testing.T) {
+	client := &Client{baseClient{capture: &atomic.Pointer[CommandCapture]{}}}
+	capture := NewCommandCapture().WithDryRun()
+	client.CaptureCommands(capture)
+	ctx := context.Background()
+
+	_, err := client.Unlink(ctx, []string{"session:1", "session:2"})
+	assert.ErrorIs(t, err, ErrCommandCaptured)
+	_, err = client.HSet(ctx, "user:1", map[string]string{"name": "Ada"})
+	assert.ErrorIs(t, err, ErrCommandCaptured)
+	_, err = client.CustomCommand(ctx, []string{"object", "freq", "user:1"})
+	assert.ErrorIs(t, err, ErrCommandCaptured)
+
+	// The commands of views are recorded with their prefixed keys.
+	sessions := client.With(NewViewOptions().WithKeyPrefix("session:"))
+	_, err = sessions.Unlink(ctx, []string{"3"})
+	assert.ErrorIs(t, err, ErrCommandCaptured)
+
+	assert.Equal(t, []CapturedCommand{
+		{Name: "UNLINK", Args: []string{"session:1", "session:2"}},
+		{Name: "HSET", Args: []string{"user:1", "name", "Ada"}},
+		{Name: "OBJECT", Args: []string{"freq", "user:1"}},
+		{Name: "UNLINK", Args: []string{"session:3"}},
+	}, capture.Commands())
+	assert.Equal(t, 1, capture.Count("unlink", "session:1"))
+	assert.Equal(t, 2, capture.Count("UNLINK"))
+	assert.Equal(t, 0, capture.Count("UNLINK", "session:2"))
+	assert.Len(t, capture.Matching("HSET", "user:1", "name"), 1)
+
+	capture.Reset()
+	assert.Empty(t, capture.Commands())
+
+	// The commands aren't recorded once the capture is detached.
+	client.CaptureCommands(nil)
+	require.NoError(t, client.captureCommand(uint32(protobuf.RequestType_Get), []string{"key"}, nil))
+	assert.Empty(t, capture.Commands())
+}
+
+func TestCommandCapture_Batch(t *testing.T) {
+	client := &baseClient{capture: &atomic.Pointer[CommandCapture]{}}
+	capture := NewCommandCapture()
+	client.CaptureCommands(capture)
+
+	batch := internal.Batch{Commands: []internal.Cmd{
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"key", "value"}, nil),
+		internal.MakeCmd(uint32(protobuf.RequestType_ConfigGet), []string{"maxmemory"}, nil),
+	}}
+	route := config.NewByAddressRoute("10.0.0.1", 6380)
+	require.NoError(t, client.captureBatch(batch, &internal.BatchOptions{Route: route}))
+	require.NoError(t, client.captureScript("abc123", []string{"key"}, []string{"arg"}, nil))
+	assert.Equal(t, []CapturedCommand{
+		{Name: "SET", Args: []string{"key", "value"}, Node: "10.0.0.1:6380", Batch: true},
+		{Name: "CONFIG GET", Args: []string{"maxmemory"}, Node: "10.0.0.1:6380", Batch: true},
+		{Name: "EVALSHA", Args: []string{"abc123", "1", "key", "arg"}},
+	}, capture.Commands())
+
+	// A client without a capture, e.g. created by the tests of other features, records nothing.
+	assert.NoError(t, (&baseClient{}).captureCommand(uint32(protobuf.RequestType_Get), []string{"key"}, nil))
+}
@@ -0,0 +1,19 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"encoding/json"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// parseCompressionPrefixStatistics parses the compression statistics of the prefix rules reported by the core, a JSON
+// object by prefix, or returns no statistics if they are malformed.
+func parseCompressionPrefixStatistics(data string) map[string]models.CompressionPrefixStatistics {
+	statistics := map[string]models.CompressionPrefixStatistics{}
+	if err := json.Unmarshal([]byte(data), &statistics); err != nil {
+		return map[string]models.CompressionPrefixStatistics{}
+	}
+	return statistics
+}
@@ -0,0 +1,36 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParseCompressionPrefixStatistics(t *testing.T) {
+	statistics := parseCompressionPrefixStatistics(`{"session:":{"values_compressed":2,"original_bytes":4000,` +
+		`"bytes_compressed":1000,"skipped_small_count":3,"skipped_count":1},"counter:":{"values_compressed":0,` +
+		`"original_bytes":0,"bytes_compressed":0,"skipped_small_count":0,"skipped_count":5}}`)
+
+	assert.Equal(t, map[string]models.CompressionPrefixStatistics{
+		"session:": {
+			ValuesCompressed:  2,
+			OriginalBytes:     4000,
+			BytesCompressed:   1000,
+			SkippedSmallCount: 3,
+			SkippedCount:      1,
+		},
+		"counter:": {SkippedCount: 5},
+	}, statistics)
+	assert.Equal(t, 4.0, statistics["session:"].CompressionRatio())
+	assert.Equal(t, uint64(3000), statistics["session:"].SavedBytes())
+	assert.Zero(t, statistics["counter:"].CompressionRatio())
+	assert.Zero(t, statistics["counter:"].SavedBytes())
+}
+
+func TestParseCompressionPrefixStatistics_Malformed(t *testing.T) {
+	assert.Empty(t, parseCompressionPrefixStatistics(""))
+	assert.Empty(t, parseCompressionPrefixStatistics("[1]"))
+}
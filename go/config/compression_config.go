@@ -33,6 +33,10 @@ const (
 // When enabled, values sent to the server will be compressed using the specified backend
 // if they meet the minimum size threshold. Compressed values are automatically decompressed
 // on retrieval.
+//
+// Value compression is the way to reduce the bandwidth of the clients, e.g. over WAN links to a remote cache: RESP has
+// no transport-level compression for the client and the server to negotiate, so the connections themselves are never
+// compressed. A compressing tunnel between the client and the server is transparent to the client.
 type CompressionConfiguration struct {
 	// Whether compression is enabled.
 	enabled bool
@@ -43,6 +47,57 @@ type CompressionConfiguration struct {
 	compressionLevel *int32
 	// Minimum size in bytes for values to be compressed. Defaults to 64.
 	minCompressionSize uint32
+	// The rules overriding the settings above for key prefixes, in the order they were added.
+	prefixRules []compressionPrefixRule
+}
+
+// compressionPrefixRule is a [CompressionPrefixRule] with its prefix.
+type compressionPrefixRule struct {
+	prefix string
+	rule   *CompressionPrefixRule
+}
+
+// CompressionPrefixRule overrides the settings of a [CompressionConfiguration] for the values of the keys starting
+// with a prefix, e.g. to compress the large documents of a namespace harder, or not to compress the values of a
+// namespace which don't compress well. The settings which aren't set are the ones of the configuration, except for
+// the compression level of a rule switching backends, which defaults to the level of its backend.
+type CompressionPrefixRule struct {
+	enabled            bool
+	backend            *CompressionBackend
+	compressionLevel   *int32
+	minCompressionSize *uint32
+}
+
+// NewCompressionPrefixRule returns a [CompressionPrefixRule] with compression enabled, and the other settings of the
+// configuration.
+func NewCompressionPrefixRule() *CompressionPrefixRule {
+	return &CompressionPrefixRule{enabled: true}
+}
+
+// WithEnabled sets whether the values of the keys matching the rule are compressed.
+func (r *CompressionPrefixRule) WithEnabled(enabled bool) *CompressionPrefixRule {
+	r.enabled = enabled
+	return r
+}
+
+// WithBackend sets the compression backend of the values of the keys matching the rule.
+func (r *CompressionPrefixRule) WithBackend(backend CompressionBackend) *CompressionPrefixRule {
+	r.backend = &backend
+	return r
+}
+
+// WithCompressionLevel sets the compression level of the values of the keys matching the rule. See
+// [CompressionConfiguration.WithCompressionLevel] for the valid ranges.
+func (r *CompressionPrefixRule) WithCompressionLevel(level int32) *CompressionPrefixRule {
+	r.compressionLevel = &level
+	return r
+}
+
+// WithMinCompressionSize sets the minimum size in bytes of the values of the keys matching the rule to be
+// compressed. Must be at least MinCompressionSize (6) bytes.
+func (r *CompressionPrefixRule) WithMinCompressionSize(size uint32) *CompressionPrefixRule {
+	r.minCompressionSize = &size
+	return r
 }
 
 // NewCompressionConfiguration returns a [CompressionConfiguration] with compression enabled,
@@ -83,6 +138,24 @@ func (c *CompressionConfiguration) WithMinCompressionSize(size uint32) *Compress
 	return c
 }
 
+// WithPrefixRule sets the rule overriding the settings of the configuration for the values of the keys starting with
+// prefix, replacing the previous rule of prefix, if any. When several prefixes match a key, the rule of the longest
+// one applies. The outcome of the compression of the values of each prefix is reported by
+// GetCompressionPrefixStatistics on the clients.
+//
+// Compression must be enabled in the configuration for the rules to apply: a rule can only disable compression for
+// its keys.
+func (c *CompressionConfiguration) WithPrefixRule(prefix string, rule *CompressionPrefixRule) *CompressionConfiguration {
+	for i := range c.prefixRules {
+		if c.prefixRules[i].prefix == prefix {
+			c.prefixRules[i].rule = rule
+			return c
+		}
+	}
+	c.prefixRules = append(c.prefixRules, compressionPrefixRule{prefix: prefix, rule: rule})
+	return c
+}
+
 // Validate checks that the compression configuration is valid.
 func (c *CompressionConfiguration) Validate() error {
 	if c.minCompressionSize < MinCompressionSize {
@@ -92,6 +165,22 @@ func (c *CompressionConfiguration) Validate() error {
 			c.minCompressionSize,
 		)
 	}
+	for _, prefixRule := range c.prefixRules {
+		if prefixRule.prefix == "" {
+			return fmt.Errorf("the prefix of a compression prefix rule must not be empty")
+		}
+		if prefixRule.rule == nil {
+			return fmt.Errorf("the compression prefix rule of prefix %q must not be nil", prefixRule.prefix)
+		}
+		if size := prefixRule.rule.minCompressionSize; size != nil && *size < MinCompressionSize {
+			return fmt.Errorf(
+				"min_compression_size of prefix %q must be at least %d bytes, got %d",
+				prefixRule.prefix,
+				MinCompressionSize,
+				*size,
+			)
+		}
+	}
 	return nil
 }
 
@@ -110,5 +199,19 @@ func (c *CompressionConfiguration) toProtobuf() (*protobuf.CompressionConfig, er
 		pbConfig.CompressionLevel = c.compressionLevel
 	}
 
+	for _, prefixRule := range c.prefixRules {
+		pbRule := &protobuf.CompressionPrefixRule{
+			Prefix:             []byte(prefixRule.prefix),
+			Enabled:            prefixRule.rule.enabled,
+			CompressionLevel:   prefixRule.rule.compressionLevel,
+			MinCompressionSize: prefixRule.rule.minCompressionSize,
+		}
+		if prefixRule.rule.backend != nil {
+			backend := protobuf.CompressionBackend(*prefixRule.rule.backend)
+			pbRule.Backend = &backend
+		}
+		pbConfig.PrefixRules = append(pbConfig.PrefixRules, pbRule)
+	}
+
 	return pbConfig, nil
 }
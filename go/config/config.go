@@ -195,8 +195,20 @@ type baseClientConfiguration struct {
 	clientAZ          string
 	reconnectStrategy *BackoffStrategy
 	lazyConnect       bool
+	disableRetries    bool
 	DatabaseId        *int `json:"database_id,omitempty"`
 	compressionConfig *CompressionConfiguration
+	schedulingConfig  *SchedulingConfiguration
+	faultInjection    *FaultInjectionConfiguration
+	metrics           *MetricsConfiguration
+	keySampling       *KeySamplingConfiguration
+	microCache        *MicroCacheConfiguration
+	hedging           *HedgingConfiguration
+	timeoutClasses    map[string]time.Duration
+	// capabilityDetection detects the capabilities of the server, see WithCapabilityDetection.
+	capabilityDetection bool
+	// subscriberIsolation isolates the subscriptions of the client on their own connections, if not nil.
+	subscriberIsolation *SubscriberIsolationConfiguration
 }
 
 func (config *baseClientConfiguration) toProtobuf() (*protobuf.ConnectionRequest, error) {
@@ -247,10 +259,74 @@ func (config *baseClientConfiguration) toProtobuf() (*protobuf.ConnectionRequest
 		request.LazyConnect = config.lazyConnect
 	}
 
+	if config.disableRetries {
+		request.DisableRetries = &config.disableRetries
+	}
+
 	if config.DatabaseId != nil {
 		request.DatabaseId = uint32(*config.DatabaseId)
 	}
 
+	if config.schedulingConfig != nil {
+		if err := config.schedulingConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scheduling configuration: %w", err)
+		}
+	}
+
+	if config.faultInjection != nil {
+		if err := config.faultInjection.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid fault injection configuration: %w", err)
+		}
+	}
+
+	if config.metrics != nil {
+		if err := config.metrics.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid metrics configuration: %w", err)
+		}
+	}
+
+	if config.keySampling != nil {
+		if err := config.keySampling.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid key sampling configuration: %w", err)
+		}
+	}
+
+	if config.microCache != nil {
+		if err := config.microCache.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid micro-cache configuration: %w", err)
+		}
+	}
+
+	if config.hedging != nil {
+		if err := config.hedging.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid hedging configuration: %w", err)
+		}
+	}
+
+	if config.subscriberIsolation != nil {
+		if err := config.subscriberIsolation.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid subscriber isolation configuration: %w", err)
+		}
+	}
+
+	if err := ValidateTimeoutClasses(config.timeoutClasses); err != nil {
+		return nil, err
+	}
+	if len(config.timeoutClasses) > 0 {
+		// The core waits for the longest of the timeouts, and the request timeout of the client is applied by the
+		// client to the requests without a timeout class. The default request timeout of the core is included by
+		// the client when the request timeout isn't set.
+		coreTimeout := config.GetRequestTimeout()
+		for _, timeout := range config.timeoutClasses {
+			coreTimeout = max(coreTimeout, timeout)
+		}
+		requestTimeout, err := utils.DurationToMilliseconds(coreTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("setting request timeout returned an error: %w", err)
+		}
+		request.RequestTimeout = requestTimeout
+	}
+
 	if config.compressionConfig != nil {
 		compressionPb, err := config.compressionConfig.toProtobuf()
 		if err != nil {
@@ -262,6 +338,94 @@ func (config *baseClientConfiguration) toProtobuf() (*protobuf.ConnectionRequest
 	return &request, nil
 }
 
+// GetSchedulingConfiguration returns the request scheduling configuration, or nil if none was set.
+func (config *baseClientConfiguration) GetSchedulingConfiguration() *SchedulingConfiguration {
+	return config.schedulingConfig
+}
+
+// GetFaultInjectionConfiguration returns the fault injection configuration, or nil if fault injection is disabled.
+func (config *baseClientConfiguration) GetFaultInjectionConfiguration() *FaultInjectionConfiguration {
+	return config.faultInjection
+}
+
+// GetMetricsConfiguration returns the request metrics configuration, or nil if request metrics are disabled.
+func (config *baseClientConfiguration) GetMetricsConfiguration() *MetricsConfiguration {
+	return config.metrics
+}
+
+// GetKeySamplingConfiguration returns the key sampling configuration, or nil if key sampling is disabled.
+func (config *baseClientConfiguration) GetKeySamplingConfiguration() *KeySamplingConfiguration {
+	return config.keySampling
+}
+
+// GetMicroCacheConfiguration returns the micro-cache configuration, or nil if the micro-cache is disabled.
+func (config *baseClientConfiguration) GetMicroCacheConfiguration() *MicroCacheConfiguration {
+	return config.microCache
+}
+
+// GetHedgingConfiguration returns the read hedging configuration, or nil if read hedging is disabled.
+func (config *baseClientConfiguration) GetHedgingConfiguration() *HedgingConfiguration {
+	return config.hedging
+}
+
+// GetSubscriberIsolationConfiguration returns the subscriber isolation configuration, or nil if the subscriptions
+// share the connections of the requests.
+func (config *baseClientConfiguration) GetSubscriberIsolationConfiguration() *SubscriberIsolationConfiguration {
+	return config.subscriberIsolation
+}
+
+// subscriberConfiguration returns the configuration of the isolated subscriber connections of the client: the
+// connection settings of the client, without the features of its requests.
+func (config baseClientConfiguration) subscriberConfiguration() baseClientConfiguration {
+	config.schedulingConfig = nil
+	config.faultInjection = nil
+	config.metrics = nil
+	config.keySampling = nil
+	config.microCache = nil
+	config.hedging = nil
+	config.timeoutClasses = nil
+	config.capabilityDetection = false
+	config.subscriberIsolation = nil
+	return config
+}
+
+// GetRequestTimeout returns the request timeout of the client, or 0 if it wasn't set, in which case the default
+// request timeout of the core applies.
+func (config *baseClientConfiguration) GetRequestTimeout() time.Duration {
+	return config.requestTimeout
+}
+
+// GetTimeoutClasses returns the request timeout of each timeout class, by name, or nil if none was defined.
+func (config *baseClientConfiguration) GetTimeoutClasses() map[string]time.Duration {
+	return config.timeoutClasses
+}
+
+// GetCapabilityDetection returns whether the client detects the capabilities of the server.
+func (config *baseClientConfiguration) GetCapabilityDetection() bool {
+	return config.capabilityDetection
+}
+
+// addTimeoutClass defines the timeout class name, replacing its timeout if it's defined already.
+func (config *baseClientConfiguration) addTimeoutClass(name string, timeout time.Duration) {
+	if config.timeoutClasses == nil {
+		config.timeoutClasses = make(map[string]time.Duration)
+	}
+	config.timeoutClasses[name] = timeout
+}
+
+// ValidateTimeoutClasses checks that the timeout classes have a name and a positive timeout.
+func ValidateTimeoutClasses(timeoutClasses map[string]time.Duration) error {
+	for name, timeout := range timeoutClasses {
+		if name == "" {
+			return errors.New("invalid timeout class: the name of a timeout class must not be empty")
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("invalid timeout class %q: the timeout must be positive, got %v", name, timeout)
+		}
+	}
+	return nil
+}
+
 // BackoffStrategy defines how and when the client should attempt to reconnect after a connection failure.
 // The time between retry attempts increases exponentially according to the formula:
 //
@@ -352,7 +516,8 @@ func (config *ClientConfiguration) ToProtobuf() (*protobuf.ConnectionRequest, er
 		request.ReadOnly = &config.readOnly
 	}
 
-	if config.subscriptionConfig != nil {
+	// The subscriptions of an isolated subscriber are established by its own connections.
+	if config.subscriptionConfig != nil && config.subscriberIsolation == nil {
 		request.PubsubSubscriptions = config.subscriptionConfig.toProtobuf()
 	}
 
@@ -433,6 +598,15 @@ func (config *ClientConfiguration) WithLazyConnect(lazyConnect bool) *ClientConf
 	return config
 }
 
+// WithDisableRetries configures whether the client retries requests internally. When set to true, a failed request
+// is never retried by the client, e.g. after a MOVED or ASK redirection or a reconnection, and the retry options of
+// batches are ignored: every failure is returned to the caller, which is responsible for retrying the request.
+// Reconnecting to the server after a disconnection is not affected.
+func (config *ClientConfiguration) WithDisableRetries(disableRetries bool) *ClientConfiguration {
+	config.disableRetries = disableRetries
+	return config
+}
+
 // WithCredentials sets the credentials for the authentication process. If none are set, the client will not authenticate
 // itself with the server.
 func (config *ClientConfiguration) WithCredentials(credentials *ServerCredentials) *ClientConfiguration {
@@ -494,6 +668,74 @@ func (config *ClientConfiguration) WithCompressionConfiguration(
 	return config
 }
 
+// WithSchedulingConfiguration sets the request scheduling configuration for the client.
+// When configured, the number of outstanding requests is limited, and waiting requests are sent in
+// order of priority. See [SchedulingConfiguration] for details.
+func (config *ClientConfiguration) WithSchedulingConfiguration(
+	schedulingConfig *SchedulingConfiguration,
+) *ClientConfiguration {
+	config.schedulingConfig = schedulingConfig
+	return config
+}
+
+// WithFaultInjection enables fault injection, making the client fail or delay a share of its requests as
+// configured. This is meant for resilience testing only. See [FaultInjectionConfiguration] for details.
+func (config *ClientConfiguration) WithFaultInjection(
+	faultInjection *FaultInjectionConfiguration,
+) *ClientConfiguration {
+	config.faultInjection = faultInjection
+	return config
+}
+
+// WithMetrics enables the request metrics of the client, labelled as configured. See [MetricsConfiguration] for
+// details.
+func (config *ClientConfiguration) WithMetrics(metrics *MetricsConfiguration) *ClientConfiguration {
+	config.metrics = metrics
+	return config
+}
+
+// WithKeySampling enables the key sampler of the client, which estimates the accesses of the hottest keys. See
+// [KeySamplingConfiguration] for details.
+func (config *ClientConfiguration) WithKeySampling(keySampling *KeySamplingConfiguration) *ClientConfiguration {
+	config.keySampling = keySampling
+	return config
+}
+
+// WithMicroCache enables the micro-cache of the client, which serves repeated reads of hot keys locally for a tiny
+// TTL. See [MicroCacheConfiguration] for details.
+func (config *ClientConfiguration) WithMicroCache(microCache *MicroCacheConfiguration) *ClientConfiguration {
+	config.microCache = microCache
+	return config
+}
+
+// WithReadHedging enables the read hedging of the client, which sends a duplicate of the reads not completed within
+// a delay and returns the first response. See [HedgingConfiguration] for details.
+func (config *ClientConfiguration) WithReadHedging(hedging *HedgingConfiguration) *ClientConfiguration {
+	config.hedging = hedging
+	return config
+}
+
+// WithTimeoutClass defines the timeout class name, e.g. "fast" or "bulk": the requests of the contexts tagged with
+// the class, see glide.WithTimeoutClass, wait timeout for their response instead of the request timeout of the
+// client. Defining a class again replaces its timeout.
+//
+// The client waits for the responses of the requests without a class for its request timeout, see WithRequestTimeout,
+// also when a class is longer. The blocking commands, e.g. BLPOP or WAIT, wait for their own timeout instead. The
+// timeout of a class defined at runtime can't exceed the longest of the timeouts the client was created with.
+func (config *ClientConfiguration) WithTimeoutClass(name string, timeout time.Duration) *ClientConfiguration {
+	config.addTimeoutClass(name, timeout)
+	return config
+}
+
+// WithCapabilityDetection configures whether the client detects the version of the server and its modules with
+// INFO SERVER, COMMAND COUNT and MODULE LIST, see glide.Capabilities, so that the typed commands the server doesn't
+// support fail fast with an UnsupportedError. It's disabled by default, as MODULE LIST is often denied by the ACLs of
+// the users of the applications.
+func (config *ClientConfiguration) WithCapabilityDetection(capabilityDetection bool) *ClientConfiguration {
+	config.capabilityDetection = capabilityDetection
+	return config
+}
+
 // WithAdvancedConfiguration sets the advanced configuration settings for the client.
 func (config *ClientConfiguration) WithAdvancedConfiguration(
 	advancedConfig *AdvancedClientConfiguration,
@@ -522,6 +764,27 @@ func (config *ClientConfiguration) WithReadOnly(readOnly bool) *ClientConfigurat
 	return config
 }
 
+// WithSubscriberIsolation isolates the subscriptions of the client on their own connections, so that the messages
+// don't delay the responses of the requests. See [SubscriberIsolationConfiguration] for details.
+func (config *ClientConfiguration) WithSubscriberIsolation(
+	isolation *SubscriberIsolationConfiguration,
+) *ClientConfiguration {
+	config.subscriberIsolation = isolation
+	return config
+}
+
+// SubscriberConfiguration returns the configuration of the isolated subscriber connections of the client, or nil if
+// its subscriptions aren't isolated, see WithSubscriberIsolation. It has the connection settings and the
+// subscriptions of the client, without the features of its requests, e.g. the scheduling or the metrics.
+func (config *ClientConfiguration) SubscriberConfiguration() *ClientConfiguration {
+	if config.subscriberIsolation == nil {
+		return nil
+	}
+	subscriberConfig := *config
+	subscriberConfig.baseClientConfiguration = config.baseClientConfiguration.subscriberConfiguration()
+	return &subscriberConfig
+}
+
 func (config *ClientConfiguration) HasSubscription() bool {
 	return config.subscriptionConfig != nil
 }
@@ -539,6 +802,8 @@ func (config *ClientConfiguration) GetSubscription() *StandaloneSubscriptionConf
 type ClusterClientConfiguration struct {
 	baseClientConfiguration
 	subscriptionConfig *ClusterSubscriptionConfig
+	primaryChange      *PrimaryChangeConfiguration
+	minAttemptTimeout  time.Duration
 	AdvancedClusterClientConfiguration
 }
 
@@ -558,6 +823,22 @@ func (config *ClusterClientConfiguration) ToProtobuf() (*protobuf.ConnectionRequ
 	}
 
 	request.ClusterModeEnabled = true
+	if config.primaryChange != nil {
+		if err := config.primaryChange.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid primary change configuration: %w", err)
+		}
+	}
+	if config.minAttemptTimeout != 0 {
+		if config.minAttemptTimeout < time.Millisecond {
+			return nil, fmt.Errorf(
+				"the minimum attempt timeout must be at least 1ms, got %v", config.minAttemptTimeout)
+		}
+		minAttemptTimeout, err := utils.DurationToMilliseconds(config.minAttemptTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("setting minimum attempt timeout returned an error: %w", err)
+		}
+		request.MinAttemptTimeout = &minAttemptTimeout
+	}
 	if (config.AdvancedClusterClientConfiguration.connectionTimeout) != 0 {
 		connectionTimeout, err := utils.DurationToMilliseconds(config.AdvancedClusterClientConfiguration.connectionTimeout)
 		if err != nil {
@@ -565,7 +846,8 @@ func (config *ClusterClientConfiguration) ToProtobuf() (*protobuf.ConnectionRequ
 		}
 		request.ConnectionTimeout = connectionTimeout
 	}
-	if config.subscriptionConfig != nil {
+	// The subscriptions of an isolated subscriber are established by its own connections.
+	if config.subscriptionConfig != nil && config.subscriberIsolation == nil {
 		request.PubsubSubscriptions = config.subscriptionConfig.toProtobuf()
 	}
 	request.RefreshTopologyFromInitialNodes = config.AdvancedClusterClientConfiguration.refreshTopologyFromInitialNodes
@@ -639,6 +921,15 @@ func (config *ClusterClientConfiguration) WithLazyConnect(lazyConnect bool) *Clu
 	return config
 }
 
+// WithDisableRetries configures whether the client retries requests internally. When set to true, a failed request
+// is never retried by the client, e.g. after a MOVED or ASK redirection or a reconnection, and the retry options of
+// batches are ignored: every failure is returned to the caller, which is responsible for retrying the request.
+// Reconnecting to the server after a disconnection is not affected.
+func (config *ClusterClientConfiguration) WithDisableRetries(disableRetries bool) *ClusterClientConfiguration {
+	config.disableRetries = disableRetries
+	return config
+}
+
 // WithCredentials sets the credentials for the authentication process. If none are set, the client will not authenticate
 // itself with the server.
 func (config *ClusterClientConfiguration) WithCredentials(
@@ -704,6 +995,96 @@ func (config *ClusterClientConfiguration) WithCompressionConfiguration(
 	return config
 }
 
+// WithSchedulingConfiguration sets the request scheduling configuration for the client.
+// When configured, the number of outstanding requests is limited, and waiting requests are sent in
+// order of priority. See [SchedulingConfiguration] for details.
+func (config *ClusterClientConfiguration) WithSchedulingConfiguration(
+	schedulingConfig *SchedulingConfiguration,
+) *ClusterClientConfiguration {
+	config.schedulingConfig = schedulingConfig
+	return config
+}
+
+// WithFaultInjection enables fault injection, making the client fail or delay a share of its requests as
+// configured. This is meant for resilience testing only. See [FaultInjectionConfiguration] for details.
+func (config *ClusterClientConfiguration) WithFaultInjection(
+	faultInjection *FaultInjectionConfiguration,
+) *ClusterClientConfiguration {
+	config.faultInjection = faultInjection
+	return config
+}
+
+// WithMetrics enables the request metrics of the client, labelled as configured. See [MetricsConfiguration] for
+// details.
+func (config *ClusterClientConfiguration) WithMetrics(metrics *MetricsConfiguration) *ClusterClientConfiguration {
+	config.metrics = metrics
+	return config
+}
+
+// WithKeySampling enables the key sampler of the client, which estimates the accesses of the hottest keys. See
+// [KeySamplingConfiguration] for details.
+func (config *ClusterClientConfiguration) WithKeySampling(keySampling *KeySamplingConfiguration) *ClusterClientConfiguration {
+	config.keySampling = keySampling
+	return config
+}
+
+// WithMicroCache enables the micro-cache of the client, which serves repeated reads of hot keys locally for a tiny
+// TTL. See [MicroCacheConfiguration] for details.
+func (config *ClusterClientConfiguration) WithMicroCache(microCache *MicroCacheConfiguration) *ClusterClientConfiguration {
+	config.microCache = microCache
+	return config
+}
+
+// WithReadHedging enables the read hedging of the client, which sends a duplicate of the reads not completed within
+// a delay and returns the first response. See [HedgingConfiguration] for details.
+func (config *ClusterClientConfiguration) WithReadHedging(hedging *HedgingConfiguration) *ClusterClientConfiguration {
+	config.hedging = hedging
+	return config
+}
+
+// WithTimeoutClass defines the timeout class name, e.g. "fast" or "bulk": the requests of the contexts tagged with
+// the class, see glide.WithTimeoutClass, wait timeout for their response instead of the request timeout of the
+// client. Defining a class again replaces its timeout.
+//
+// The client waits for the responses of the requests without a class for its request timeout, see WithRequestTimeout,
+// also when a class is longer. The blocking commands, e.g. BLPOP or WAIT, wait for their own timeout instead. The
+// timeout of a class defined at runtime can't exceed the longest of the timeouts the client was created with.
+func (config *ClusterClientConfiguration) WithTimeoutClass(name string, timeout time.Duration) *ClusterClientConfiguration {
+	config.addTimeoutClass(name, timeout)
+	return config
+}
+
+// WithDeadlineSplitting splits the time each command is waited for across its attempts, so that an attempt hung on a
+// failing node doesn't consume the time of the retries after a redirection or a reconnection. The time is the request
+// timeout, see [ClusterClientConfiguration.WithRequestTimeout], or the time left until the deadline of the context of
+// the command if it's earlier, e.g. the timeout of its timeout class. Each attempt is timed out after the time
+// remaining divided by the number of attempts remaining, but at least minAttemptTimeout, and is then retried right
+// away. The wait before the retries after other errors is shortened to leave minAttemptTimeout to the retry.
+//
+// Only the readonly commands routed to a single node are timed out per attempt: a timed out attempt may still be
+// executed by the server, so that a write would be applied twice. The blocking commands, which wait for their own
+// timeout, aren't either. minAttemptTimeout should exceed the normal latency of the commands.
+//
+// Using a value of less than 1ms or exceeding the max duration of 2^32 - 1 milliseconds will lead to an invalid
+// configuration.
+func (config *ClusterClientConfiguration) WithDeadlineSplitting(
+	minAttemptTimeout time.Duration,
+) *ClusterClientConfiguration {
+	config.minAttemptTimeout = minAttemptTimeout
+	return config
+}
+
+// WithCapabilityDetection configures whether the client detects the version of a random node and its modules with
+// INFO SERVER, COMMAND COUNT and MODULE LIST, see glide.Capabilities, so that the typed commands the server doesn't
+// support fail fast with an UnsupportedError. It's disabled by default, as MODULE LIST is often denied by the ACLs of
+// the users of the applications.
+func (config *ClusterClientConfiguration) WithCapabilityDetection(
+	capabilityDetection bool,
+) *ClusterClientConfiguration {
+	config.capabilityDetection = capabilityDetection
+	return config
+}
+
 // WithAdvancedConfiguration sets the advanced configuration settings for the client.
 func (config *ClusterClientConfiguration) WithAdvancedConfiguration(
 	advancedConfig *AdvancedClusterClientConfiguration,
@@ -720,6 +1101,44 @@ func (config *ClusterClientConfiguration) WithSubscriptionConfig(
 	return config
 }
 
+// WithPrimaryChangeConfiguration makes the client report the primary changes of the cluster, e.g. failovers. See
+// [PrimaryChangeConfiguration] for details.
+func (config *ClusterClientConfiguration) WithPrimaryChangeConfiguration(
+	primaryChange *PrimaryChangeConfiguration,
+) *ClusterClientConfiguration {
+	config.primaryChange = primaryChange
+	return config
+}
+
+// GetPrimaryChangeConfiguration returns the primary change configuration of the client, or nil if primary changes
+// aren't reported.
+func (config *ClusterClientConfiguration) GetPrimaryChangeConfiguration() *PrimaryChangeConfiguration {
+	return config.primaryChange
+}
+
+// WithSubscriberIsolation isolates the subscriptions of the client on their own connections, so that the messages
+// don't delay the responses of the requests. See [SubscriberIsolationConfiguration] for details.
+func (config *ClusterClientConfiguration) WithSubscriberIsolation(
+	isolation *SubscriberIsolationConfiguration,
+) *ClusterClientConfiguration {
+	config.subscriberIsolation = isolation
+	return config
+}
+
+// SubscriberConfiguration returns the configuration of the isolated subscriber connections of the client, or nil if
+// its subscriptions aren't isolated, see WithSubscriberIsolation. It has the connection settings and the
+// subscriptions of the client, without the features of its requests, e.g. the scheduling or the metrics, nor the
+// monitoring of the primary changes.
+func (config *ClusterClientConfiguration) SubscriberConfiguration() *ClusterClientConfiguration {
+	if config.subscriberIsolation == nil {
+		return nil
+	}
+	subscriberConfig := *config
+	subscriberConfig.baseClientConfiguration = config.baseClientConfiguration.subscriberConfiguration()
+	subscriberConfig.primaryChange = nil
+	return &subscriberConfig
+}
+
 func (config *ClusterClientConfiguration) HasSubscription() bool {
 	return config.subscriptionConfig != nil
 }
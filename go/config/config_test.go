@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
 )
 
 // Test certificate constants
@@ -989,6 +990,50 @@ func TestCompressionConfiguration_ToProtobufFailsOnInvalidConfig(t *testing.T) {
 	assert.Contains(t, err.Error(), fmt.Sprintf("min_compression_size must be at least %d bytes", MinCompressionSize))
 }
 
+func TestCompressionConfiguration_WithPrefixRules(t *testing.T) {
+	compressionConfig := NewCompressionConfiguration().
+		WithPrefixRule("session:", NewCompressionPrefixRule().WithBackend(LZ4).WithMinCompressionSize(512)).
+		WithPrefixRule("counter:", NewCompressionPrefixRule().WithEnabled(false)).
+		WithPrefixRule("session:", NewCompressionPrefixRule().WithCompressionLevel(9))
+
+	pb, err := compressionConfig.toProtobuf()
+	assert.NoError(t, err)
+	assert.Len(t, pb.PrefixRules, 2)
+
+	session := pb.PrefixRules[0]
+	assert.Equal(t, []byte("session:"), session.Prefix)
+	assert.True(t, session.Enabled)
+	assert.Nil(t, session.Backend)
+	assert.Equal(t, int32(9), *session.CompressionLevel)
+	assert.Nil(t, session.MinCompressionSize)
+
+	counter := pb.PrefixRules[1]
+	assert.Equal(t, []byte("counter:"), counter.Prefix)
+	assert.False(t, counter.Enabled)
+
+	pb, err = NewCompressionConfiguration().
+		WithPrefixRule("doc:", NewCompressionPrefixRule().WithBackend(LZ4).WithMinCompressionSize(256)).
+		toProtobuf()
+	assert.NoError(t, err)
+	assert.Equal(t, protobuf.CompressionBackend_LZ4, *pb.PrefixRules[0].Backend)
+	assert.Equal(t, uint32(256), *pb.PrefixRules[0].MinCompressionSize)
+	assert.Nil(t, pb.PrefixRules[0].CompressionLevel)
+}
+
+func TestCompressionConfiguration_ValidationPrefixRules(t *testing.T) {
+	err := NewCompressionConfiguration().
+		WithPrefixRule("doc:", NewCompressionPrefixRule().WithMinCompressionSize(MinCompressionSize-1)).
+		Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "min_compression_size of prefix \"doc:\" must be at least")
+
+	err = NewCompressionConfiguration().WithPrefixRule("", NewCompressionPrefixRule()).Validate()
+	assert.Error(t, err)
+
+	err = NewCompressionConfiguration().WithPrefixRule("doc:", nil).Validate()
+	assert.Error(t, err)
+}
+
 func TestStandaloneConfig_WithCompression(t *testing.T) {
 	var level int32 = 3
 	compressionConfig := NewCompressionConfiguration().
@@ -1314,3 +1359,379 @@ func TestConfig_ReadOnly_FluentAPI(t *testing.T) {
 	assert.Equal(t, "localhost", result.Addresses[0].Host)
 	assert.Equal(t, uint32(6379), result.Addresses[0].Port)
 }
+
+// ============================================================================
+// Scheduling Configuration Tests
+// ============================================================================
+
+func TestSchedulingConfiguration(t *testing.T) {
+	schedulingConfig := NewSchedulingConfiguration(16)
+	assert.NoError(t, schedulingConfig.Validate())
+	assert.Equal(t, 16, schedulingConfig.GetMaxConcurrentRequests())
+
+	config := NewClientConfiguration().WithSchedulingConfiguration(schedulingConfig)
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, schedulingConfig, config.GetSchedulingConfiguration())
+
+	clusterConfig := NewClusterClientConfiguration().WithSchedulingConfiguration(schedulingConfig)
+	_, err = clusterConfig.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, schedulingConfig, clusterConfig.GetSchedulingConfiguration())
+
+	assert.Nil(t, NewClientConfiguration().GetSchedulingConfiguration())
+}
+
+func TestSchedulingConfiguration_Invalid(t *testing.T) {
+	assert.Error(t, NewSchedulingConfiguration(0).Validate())
+
+	_, err := NewClientConfiguration().WithSchedulingConfiguration(NewSchedulingConfiguration(-1)).ToProtobuf()
+	assert.ErrorContains(t, err, "invalid scheduling configuration")
+
+	_, err = NewClusterClientConfiguration().WithSchedulingConfiguration(NewSchedulingConfiguration(0)).ToProtobuf()
+	assert.ErrorContains(t, err, "invalid scheduling configuration")
+}
+
+func TestSchedulingConfiguration_MaxBatchCommands(t *testing.T) {
+	assert.Equal(t, 0, NewSchedulingConfiguration(16).GetMaxBatchCommands())
+
+	schedulingConfig := NewSchedulingConfiguration(16).WithMaxBatchCommands(8)
+	assert.NoError(t, schedulingConfig.Validate())
+	assert.Equal(t, 8, schedulingConfig.GetMaxBatchCommands())
+
+	assert.Error(t, NewSchedulingConfiguration(16).WithMaxBatchCommands(0).Validate())
+	assert.Error(t, NewSchedulingConfiguration(16).WithMaxBatchCommands(17).Validate())
+}
+
+func TestFaultInjectionConfiguration(t *testing.T) {
+	faultConfig := NewFaultInjectionConfiguration().
+		WithLatency(10*time.Millisecond, 5).
+		WithTimeouts(1).
+		WithMovedErrors(2).
+		WithConnectionDrops(3)
+	assert.NoError(t, faultConfig.Validate())
+	latency, percentage := faultConfig.GetLatency()
+	assert.Equal(t, 10*time.Millisecond, latency)
+	assert.Equal(t, 5.0, percentage)
+	assert.Equal(t, 1.0, faultConfig.GetTimeoutPercentage())
+	assert.Equal(t, 2.0, faultConfig.GetMovedErrorPercentage())
+	assert.Equal(t, 3.0, faultConfig.GetConnectionDropPercentage())
+
+	config := NewClusterClientConfiguration().WithFaultInjection(faultConfig)
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, faultConfig, config.GetFaultInjectionConfiguration())
+	assert.Nil(t, NewClientConfiguration().GetFaultInjectionConfiguration())
+}
+
+func TestFaultInjectionConfiguration_Invalid(t *testing.T) {
+	assert.Error(t, NewFaultInjectionConfiguration().WithLatency(-time.Second, 10).Validate())
+	assert.Error(t, NewFaultInjectionConfiguration().WithTimeouts(101).Validate())
+	assert.Error(t, NewFaultInjectionConfiguration().WithMovedErrors(-1).Validate())
+	assert.Error(t, NewFaultInjectionConfiguration().WithTimeouts(60).WithConnectionDrops(60).Validate())
+
+	_, err := NewClientConfiguration().
+		WithFaultInjection(NewFaultInjectionConfiguration().WithTimeouts(200)).
+		ToProtobuf()
+	assert.ErrorContains(t, err, "invalid fault injection configuration")
+}
+
+func TestMetricsConfiguration(t *testing.T) {
+	metricsConfig := NewMetricsConfiguration()
+	assert.True(t, metricsConfig.HasCommandLabel())
+	delimiter, maxPrefixes := metricsConfig.GetKeyPrefixLabel()
+	assert.Equal(t, "", delimiter)
+	assert.Equal(t, 0, maxPrefixes)
+
+	metricsConfig.WithCommandLabel(false).WithKeyPrefixLabel(":", DefaultMaxKeyPrefixes)
+	assert.False(t, metricsConfig.HasCommandLabel())
+	delimiter, maxPrefixes = metricsConfig.GetKeyPrefixLabel()
+	assert.Equal(t, ":", delimiter)
+	assert.Equal(t, DefaultMaxKeyPrefixes, maxPrefixes)
+
+	config := NewClusterClientConfiguration().WithMetrics(metricsConfig)
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, metricsConfig, config.GetMetricsConfiguration())
+	assert.Nil(t, NewClientConfiguration().GetMetricsConfiguration())
+
+	_, err = NewClientConfiguration().
+		WithMetrics(NewMetricsConfiguration().WithKeyPrefixLabel(":", 0)).
+		ToProtobuf()
+	assert.ErrorContains(t, err, "invalid metrics configuration")
+}
+
+func TestKeySamplingConfiguration(t *testing.T) {
+	samplingConfig := NewKeySamplingConfiguration()
+	assert.Equal(t, DefaultKeySamplePercentage, samplingConfig.GetSamplePercentage())
+	assert.Equal(t, "", samplingConfig.GetKeyPrefix())
+	assert.Equal(t, DefaultKeySketchWidth, samplingConfig.GetSketchWidth())
+	assert.Equal(t, DefaultMaxHotKeys, samplingConfig.GetMaxHotKeys())
+
+	samplingConfig.WithSamplePercentage(1).WithKeyPrefix(":").WithSketchWidth(512).WithMaxHotKeys(10)
+	assert.Equal(t, 1.0, samplingConfig.GetSamplePercentage())
+	assert.Equal(t, ":", samplingConfig.GetKeyPrefix())
+	assert.Equal(t, 512, samplingConfig.GetSketchWidth())
+	assert.Equal(t, 10, samplingConfig.GetMaxHotKeys())
+
+	config := NewClientConfiguration().WithKeySampling(samplingConfig)
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, samplingConfig, config.GetKeySamplingConfiguration())
+	assert.Nil(t, NewClusterClientConfiguration().GetKeySamplingConfiguration())
+
+	for _, invalid := range []*KeySamplingConfiguration{
+		NewKeySamplingConfiguration().WithSamplePercentage(0),
+		NewKeySamplingConfiguration().WithSamplePercentage(101),
+		NewKeySamplingConfiguration().WithSketchWidth(0),
+		NewKeySamplingConfiguration().WithMaxHotKeys(0),
+	} {
+		_, err = NewClusterClientConfiguration().WithKeySampling(invalid).ToProtobuf()
+		assert.ErrorContains(t, err, "invalid key sampling configuration")
+	}
+}
+
+func TestMicroCacheConfiguration(t *testing.T) {
+	cacheConfig := NewMicroCacheConfiguration("stock:*", "price:*")
+	assert.Equal(t, []string{"stock:*", "price:*"}, cacheConfig.GetPatterns())
+	assert.Equal(t, DefaultMicroCacheTTL, cacheConfig.GetTTL())
+	assert.Equal(t, DefaultMicroCacheMaxEntries, cacheConfig.GetMaxEntries())
+
+	cacheConfig.WithTTL(20 * time.Millisecond).WithMaxEntries(100)
+	assert.Equal(t, 20*time.Millisecond, cacheConfig.GetTTL())
+	assert.Equal(t, 100, cacheConfig.GetMaxEntries())
+
+	config := NewClusterClientConfiguration().WithMicroCache(cacheConfig)
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, cacheConfig, config.GetMicroCacheConfiguration())
+	assert.Nil(t, NewClientConfiguration().GetMicroCacheConfiguration())
+
+	for _, invalid := range []*MicroCacheConfiguration{
+		NewMicroCacheConfiguration(),
+		NewMicroCacheConfiguration("*").WithTTL(0),
+		NewMicroCacheConfiguration("*").WithMaxEntries(0),
+	} {
+		_, err = NewClientConfiguration().WithMicroCache(invalid).ToProtobuf()
+		assert.ErrorContains(t, err, "invalid micro-cache configuration")
+	}
+}
+
+func TestHedgingConfiguration(t *testing.T) {
+	hedgingConfig := NewHedgingConfiguration(20 * time.Millisecond)
+	assert.Equal(t, 20*time.Millisecond, hedgingConfig.GetDelay())
+	assert.Equal(t, DefaultHedgingBudgetPercentage, hedgingConfig.GetBudgetPercentage())
+	assert.Equal(t, DefaultHedgingBurst, hedgingConfig.GetBurst())
+
+	hedgingConfig.WithBudgetPercentage(10).WithBurst(5)
+	assert.Equal(t, 10.0, hedgingConfig.GetBudgetPercentage())
+	assert.Equal(t, 5, hedgingConfig.GetBurst())
+
+	config := NewClusterClientConfiguration().WithReadHedging(hedgingConfig)
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Same(t, hedgingConfig, config.GetHedgingConfiguration())
+	assert.Nil(t, NewClientConfiguration().GetHedgingConfiguration())
+
+	for _, invalid := range []*HedgingConfiguration{
+		NewHedgingConfiguration(0),
+		NewHedgingConfiguration(time.Millisecond).WithBudgetPercentage(0),
+		NewHedgingConfiguration(time.Millisecond).WithBudgetPercentage(101),
+		NewHedgingConfiguration(time.Millisecond).WithBurst(0),
+	} {
+		_, err = NewClientConfiguration().WithReadHedging(invalid).ToProtobuf()
+		assert.ErrorContains(t, err, "invalid hedging configuration")
+	}
+}
+
+func TestTimeoutClasses(t *testing.T) {
+	config := NewClusterClientConfiguration().
+		WithTimeoutClass("fast", 20*time.Millisecond).
+		WithTimeoutClass("bulk", time.Second).
+		WithTimeoutClass("bulk", 5*time.Second)
+	request, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"fast": 20 * time.Millisecond, "bulk": 5 * time.Second},
+		config.GetTimeoutClasses())
+	assert.Nil(t, NewClientConfiguration().GetTimeoutClasses())
+	// The core waits for the longest of the timeouts.
+	assert.Equal(t, uint32(5000), request.GetRequestTimeout())
+	assert.Equal(t, time.Duration(0), config.GetRequestTimeout())
+	request, err = NewClientConfiguration().
+		WithRequestTimeout(10*time.Second).
+		WithTimeoutClass("fast", 20*time.Millisecond).
+		ToProtobuf()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10000), request.GetRequestTimeout())
+
+	_, err = NewClientConfiguration().WithTimeoutClass("fast", 0).ToProtobuf()
+	assert.EqualError(t, err, `invalid timeout class "fast": the timeout must be positive, got 0s`)
+	_, err = NewClientConfiguration().WithTimeoutClass("", time.Second).ToProtobuf()
+	assert.ErrorContains(t, err, "the name of a timeout class must not be empty")
+}
+
+func TestRuntimeConfiguration(t *testing.T) {
+	runtimeConfig := NewRuntimeConfiguration()
+	_, ok := runtimeConfig.GetRequestTimeout()
+	assert.False(t, ok)
+	assert.Nil(t, runtimeConfig.GetScheduling())
+	assert.NoError(t, runtimeConfig.Validate())
+
+	scheduling := NewSchedulingConfiguration(100)
+	runtimeConfig.WithRequestTimeout(time.Second).WithScheduling(scheduling)
+	timeout, ok := runtimeConfig.GetRequestTimeout()
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, timeout)
+	assert.Same(t, scheduling, runtimeConfig.GetScheduling())
+	assert.NoError(t, runtimeConfig.Validate())
+
+	assert.Error(t, NewRuntimeConfiguration().WithRequestTimeout(-time.Second).Validate())
+	assert.ErrorContains(t, NewRuntimeConfiguration().WithScheduling(NewSchedulingConfiguration(0)).Validate(),
+		"invalid scheduling configuration")
+
+	runtimeConfig = NewRuntimeConfiguration().WithTimeoutClass("fast", 50*time.Millisecond)
+	assert.Equal(t, map[string]time.Duration{"fast": 50 * time.Millisecond}, runtimeConfig.GetTimeoutClasses())
+	assert.NoError(t, runtimeConfig.Validate())
+	assert.Error(t, NewRuntimeConfiguration().WithTimeoutClass("fast", -time.Second).Validate())
+}
+
+func TestConfig_DisableRetries(t *testing.T) {
+	request, err := NewClientConfiguration().WithDisableRetries(true).ToProtobuf()
+	assert.NoError(t, err)
+	assert.NotNil(t, request.DisableRetries)
+	assert.True(t, *request.DisableRetries)
+
+	request, err = NewClusterClientConfiguration().WithDisableRetries(true).ToProtobuf()
+	assert.NoError(t, err)
+	assert.NotNil(t, request.DisableRetries)
+	assert.True(t, *request.DisableRetries)
+
+	// Retries are enabled by default
+	request, err = NewClusterClientConfiguration().ToProtobuf()
+	assert.NoError(t, err)
+	assert.Nil(t, request.DisableRetries)
+}
+
+func TestConfig_CapabilityDetection(t *testing.T) {
+	assert.False(t, NewClientConfiguration().GetCapabilityDetection())
+	assert.True(t, NewClientConfiguration().WithCapabilityDetection(true).GetCapabilityDetection())
+	assert.True(t, NewClusterClientConfiguration().WithCapabilityDetection(true).GetCapabilityDetection())
+}
+
+func TestSubscriptionConfig_DispatchMode(t *testing.T) {
+	standalone := NewStandaloneSubscriptionConfig()
+	assert.Equal(t, DispatchPerMessage, standalone.GetDispatchMode())
+	assert.Equal(t, DefaultWorkerPoolSize, standalone.GetWorkerPoolSize())
+
+	standalone.WithDispatchMode(DispatchOrderedPerChannel)
+	assert.Equal(t, DispatchOrderedPerChannel, standalone.GetDispatchMode())
+
+	cluster := NewClusterSubscriptionConfig().WithDispatchMode(DispatchWorkerPool).WithWorkerPoolSize(4)
+	assert.Equal(t, DispatchWorkerPool, cluster.GetDispatchMode())
+	assert.Equal(t, 4, cluster.GetWorkerPoolSize())
+	assert.Equal(t, "WORKER_POOL", cluster.GetDispatchMode().String())
+}
+
+func TestSubscriptionConfig_MaxBufferedMessages(t *testing.T) {
+	assert.Equal(t, 0, NewStandaloneSubscriptionConfig().GetMaxBufferedMessages())
+	assert.Equal(t, 100, NewStandaloneSubscriptionConfig().WithMaxBufferedMessages(100).GetMaxBufferedMessages())
+	assert.Equal(t, 10, NewClusterSubscriptionConfig().WithMaxBufferedMessages(10).GetMaxBufferedMessages())
+}
+
+func TestSubscriptionConfig_KeyspaceEventFilters(t *testing.T) {
+	assert.Nil(t, NewStandaloneSubscriptionConfig().GetKeyspaceEventFilters())
+
+	filter := NewKeyspaceEventFilter().WithKeyPatterns("user:*").WithEvents(KeyspaceEventExpired, KeyspaceEventDel)
+	assert.Equal(t, []string{"user:*"}, filter.GetKeyPatterns())
+	assert.Equal(t, []KeyspaceEvent{KeyspaceEventExpired, KeyspaceEventDel}, filter.GetEvents())
+
+	cluster := NewClusterSubscriptionConfig().WithKeyspaceEventFilter(filter)
+	assert.Equal(t, []*KeyspaceEventFilter{filter}, cluster.GetKeyspaceEventFilters())
+}
+
+func TestConfig_PrimaryChangeConfiguration(t *testing.T) {
+	callback := func(models.PrimaryChangeEvent) {}
+	primaryChange := NewPrimaryChangeConfiguration(callback)
+	assert.Equal(t, DefaultPrimaryChangeCheckInterval, primaryChange.GetCheckInterval())
+	assert.NotNil(t, primaryChange.GetCallback())
+
+	config := NewClusterClientConfiguration().WithPrimaryChangeConfiguration(primaryChange.WithCheckInterval(time.Minute))
+	assert.Equal(t, time.Minute, config.GetPrimaryChangeConfiguration().GetCheckInterval())
+	_, err := config.ToProtobuf()
+	assert.NoError(t, err)
+
+	config.WithPrimaryChangeConfiguration(NewPrimaryChangeConfiguration(callback).WithCheckInterval(0))
+	_, err = config.ToProtobuf()
+	assert.ErrorContains(t, err, "invalid primary change configuration")
+
+	config.WithPrimaryChangeConfiguration(NewPrimaryChangeConfiguration(nil))
+	_, err = config.ToProtobuf()
+	assert.ErrorContains(t, err, "callback must not be nil")
+}
+
+func TestClusterConfig_DeadlineSplitting(t *testing.T) {
+	request, err := NewClusterClientConfiguration().WithDeadlineSplitting(50 * time.Millisecond).ToProtobuf()
+	assert.NoError(t, err)
+	assert.NotNil(t, request.MinAttemptTimeout)
+	assert.Equal(t, uint32(50), *request.MinAttemptTimeout)
+
+	// Deadlines are not split by default
+	request, err = NewClusterClientConfiguration().ToProtobuf()
+	assert.NoError(t, err)
+	assert.Nil(t, request.MinAttemptTimeout)
+
+	_, err = NewClusterClientConfiguration().WithDeadlineSplitting(time.Microsecond).ToProtobuf()
+	assert.ErrorContains(t, err, "the minimum attempt timeout must be at least 1ms")
+
+	_, err = NewClusterClientConfiguration().WithDeadlineSplitting(-time.Second).ToProtobuf()
+	assert.ErrorContains(t, err, "the minimum attempt timeout must be at least 1ms")
+}
+
+func TestConfig_SubscriberIsolation(t *testing.T) {
+	subscriptions := NewStandaloneSubscriptionConfig().WithSubscription(ExactChannelMode, "news")
+	config := NewClientConfiguration().
+		WithSubscriptionConfig(subscriptions).
+		WithMetrics(NewMetricsConfiguration()).
+		WithTimeoutClass("bulk", time.Second)
+	assert.Nil(t, config.GetSubscriberIsolationConfiguration())
+	assert.Nil(t, config.SubscriberConfiguration())
+	request, err := config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.NotNil(t, request.PubsubSubscriptions)
+
+	isolation := NewSubscriberIsolationConfiguration()
+	assert.Equal(t, DefaultMaxSubscriberConnectionsPerNode, isolation.GetMaxConnectionsPerNode())
+	config.WithSubscriberIsolation(isolation.WithMaxConnectionsPerNode(2))
+	assert.Same(t, isolation, config.GetSubscriberIsolationConfiguration())
+	request, err = config.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Nil(t, request.PubsubSubscriptions)
+
+	// The subscriber connections establish the subscriptions, without the features of the requests.
+	subscriberConfig := config.SubscriberConfiguration()
+	assert.Same(t, subscriptions, subscriberConfig.GetSubscription())
+	assert.Nil(t, subscriberConfig.GetSubscriberIsolationConfiguration())
+	assert.Nil(t, subscriberConfig.GetMetricsConfiguration())
+	assert.Nil(t, subscriberConfig.GetTimeoutClasses())
+	request, err = subscriberConfig.ToProtobuf()
+	assert.NoError(t, err)
+	assert.NotNil(t, request.PubsubSubscriptions)
+	assert.NotNil(t, config.GetMetricsConfiguration())
+
+	clusterConfig := NewClusterClientConfiguration().
+		WithSubscriptionConfig(NewClusterSubscriptionConfig().WithSubscription(ShardedClusterChannelMode, "news")).
+		WithPrimaryChangeConfiguration(NewPrimaryChangeConfiguration(func(models.PrimaryChangeEvent) {})).
+		WithSubscriberIsolation(NewSubscriberIsolationConfiguration())
+	request, err = clusterConfig.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Nil(t, request.PubsubSubscriptions)
+	assert.Nil(t, clusterConfig.SubscriberConfiguration().GetPrimaryChangeConfiguration())
+	request, err = clusterConfig.SubscriberConfiguration().ToProtobuf()
+	assert.NoError(t, err)
+	assert.NotNil(t, request.PubsubSubscriptions)
+
+	_, err = NewClientConfiguration().
+		WithSubscriberIsolation(NewSubscriberIsolationConfiguration().WithMaxConnectionsPerNode(0)).
+		ToProtobuf()
+	assert.ErrorContains(t, err, "invalid subscriber isolation configuration")
+}
@@ -0,0 +1,115 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// FaultInjectionConfiguration represents the configuration of the faults the client injects into its own requests,
+// to test how an application copes with a degraded server or network without an external proxy.
+//
+// Faults are injected in the client, before a request is sent: a request failed by an injected fault is never sent
+// to the server. Each request is delayed with the configured latency probability, and then failed with at most one
+// of the configured errors, picked with the configured probabilities. Probabilities are percentages of requests,
+// between 0 and 100.
+//
+// The faults simulate what the application observes, not what happens on the network: an injected timeout fails
+// the request once the request timeout elapses, as a request to an unresponsive server would, while an injected
+// MOVED error or connection drop fails the request right away with the error the client returns once it gave up on
+// the redirections or the reconnections. No connection is dropped, and the client doesn't follow the injected MOVED
+// errors nor refresh its view of the cluster: the handling of the faults by the client itself isn't exercised.
+//
+// Fault injection is only enabled on clients explicitly configured with a [FaultInjectionConfiguration], and is
+// meant for testing environments only.
+type FaultInjectionConfiguration struct {
+	// Artificial latency added to a request, and the percentage of requests it's added to.
+	latency           time.Duration
+	latencyPercentage float64
+	// Percentage of requests failed with a timeout error.
+	timeoutPercentage float64
+	// Percentage of requests failed with a MOVED error.
+	movedPercentage float64
+	// Percentage of requests failed with a disconnect error, as if the connection was dropped.
+	connectionDropPercentage float64
+}
+
+// NewFaultInjectionConfiguration returns a [FaultInjectionConfiguration] that doesn't inject any fault. Use the With*
+// methods to configure the faults to inject.
+func NewFaultInjectionConfiguration() *FaultInjectionConfiguration {
+	return &FaultInjectionConfiguration{}
+}
+
+// WithLatency delays the given percentage of requests by latency before they're sent.
+func (c *FaultInjectionConfiguration) WithLatency(latency time.Duration, percentage float64) *FaultInjectionConfiguration {
+	c.latency = latency
+	c.latencyPercentage = percentage
+	return c
+}
+
+// WithTimeouts fails the given percentage of requests with a timeout error, once the request timeout of the client, or
+// the deadline of the context of the request if sooner, elapses.
+func (c *FaultInjectionConfiguration) WithTimeouts(percentage float64) *FaultInjectionConfiguration {
+	c.timeoutPercentage = percentage
+	return c
+}
+
+// WithMovedErrors fails the given percentage of requests with a MOVED error, as returned by a cluster node which
+// doesn't serve the slot of the request. The error is returned to the application: the client doesn't follow it.
+func (c *FaultInjectionConfiguration) WithMovedErrors(percentage float64) *FaultInjectionConfiguration {
+	c.movedPercentage = percentage
+	return c
+}
+
+// WithConnectionDrops fails the given percentage of requests with a disconnect error, as if the connection to the
+// server was dropped while the request was in flight. The connections of the client are left open.
+func (c *FaultInjectionConfiguration) WithConnectionDrops(percentage float64) *FaultInjectionConfiguration {
+	c.connectionDropPercentage = percentage
+	return c
+}
+
+// GetLatency returns the injected latency, and the percentage of requests it's added to.
+func (c *FaultInjectionConfiguration) GetLatency() (time.Duration, float64) {
+	return c.latency, c.latencyPercentage
+}
+
+// GetTimeoutPercentage returns the percentage of requests failed with a timeout error.
+func (c *FaultInjectionConfiguration) GetTimeoutPercentage() float64 {
+	return c.timeoutPercentage
+}
+
+// GetMovedErrorPercentage returns the percentage of requests failed with a MOVED error.
+func (c *FaultInjectionConfiguration) GetMovedErrorPercentage() float64 {
+	return c.movedPercentage
+}
+
+// GetConnectionDropPercentage returns the percentage of requests failed with a disconnect error.
+func (c *FaultInjectionConfiguration) GetConnectionDropPercentage() float64 {
+	return c.connectionDropPercentage
+}
+
+// Validate checks that the fault injection configuration is valid.
+func (c *FaultInjectionConfiguration) Validate() error {
+	if c.latency < 0 {
+		return fmt.Errorf("latency must not be negative, got %v", c.latency)
+	}
+	percentages := []struct {
+		name  string
+		value float64
+	}{
+		{"latency", c.latencyPercentage},
+		{"timeout", c.timeoutPercentage},
+		{"moved", c.movedPercentage},
+		{"connection_drop", c.connectionDropPercentage},
+	}
+	for _, percentage := range percentages {
+		if percentage.value < 0 || percentage.value > 100 {
+			return fmt.Errorf("%s percentage must be between 0 and 100, got %v", percentage.name, percentage.value)
+		}
+	}
+	if total := c.timeoutPercentage + c.movedPercentage + c.connectionDropPercentage; total > 100 {
+		return fmt.Errorf("the error percentages must not add up to more than 100, got %v", total)
+	}
+	return nil
+}
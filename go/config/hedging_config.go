@@ -0,0 +1,87 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultHedgingBudgetPercentage is the percentage of the reads which may be hedged, unless configured otherwise.
+	DefaultHedgingBudgetPercentage = 5.0
+	// DefaultHedgingBurst is the number of hedges which may be sent in a burst, regardless of the reads sent before,
+	// unless configured otherwise.
+	DefaultHedgingBurst = 10
+)
+
+// HedgingConfiguration represents the configuration of the read hedging of the client, which cuts the tail latency
+// of the reads served by occasionally slow replicas.
+//
+// When a read, e.g. GET or HGETALL, hasn't completed within the configured delay, typically the p95 or p99 latency
+// of the reads, a duplicate of the read is sent, and the first response of the two is returned; the other one is
+// discarded. With the reads routed to the replicas, see [ReadFrom], the duplicate is sent to the next replica of the
+// round robin. Hedging is disabled when the reads aren't routed to replicas, as the duplicate would be sent to the
+// same primary: with the default [Primary] ReadFrom, or on a standalone client configured with the address of a
+// single node. Only the reads routed by their key are hedged: the writes, the transactions and the commands routed
+// explicitly never are.
+//
+// The hedges are bounded by a retry budget, so that a slow cluster isn't overloaded by duplicates: at most the
+// configured percentage of the reads are hedged, in addition to a burst of hedges.
+type HedgingConfiguration struct {
+	delay            time.Duration
+	budgetPercentage float64
+	burst            int
+}
+
+// NewHedgingConfiguration returns a [HedgingConfiguration] hedging the reads not completed within delay.
+func NewHedgingConfiguration(delay time.Duration) *HedgingConfiguration {
+	return &HedgingConfiguration{
+		delay:            delay,
+		budgetPercentage: DefaultHedgingBudgetPercentage,
+		burst:            DefaultHedgingBurst,
+	}
+}
+
+// WithBudgetPercentage sets the percentage of the reads which may be hedged, in (0, 100]. Defaults to
+// DefaultHedgingBudgetPercentage.
+func (c *HedgingConfiguration) WithBudgetPercentage(percentage float64) *HedgingConfiguration {
+	c.budgetPercentage = percentage
+	return c
+}
+
+// WithBurst sets the number of hedges which may be sent in a burst, e.g. when the client starts, regardless of the
+// reads sent before. Defaults to DefaultHedgingBurst.
+func (c *HedgingConfiguration) WithBurst(burst int) *HedgingConfiguration {
+	c.burst = burst
+	return c
+}
+
+// GetDelay returns how long a read runs before it's hedged.
+func (c *HedgingConfiguration) GetDelay() time.Duration {
+	return c.delay
+}
+
+// GetBudgetPercentage returns the percentage of the reads which may be hedged.
+func (c *HedgingConfiguration) GetBudgetPercentage() float64 {
+	return c.budgetPercentage
+}
+
+// GetBurst returns the number of hedges which may be sent in a burst.
+func (c *HedgingConfiguration) GetBurst() int {
+	return c.burst
+}
+
+// Validate checks that the hedging configuration is valid.
+func (c *HedgingConfiguration) Validate() error {
+	if c.delay <= 0 {
+		return fmt.Errorf("the hedging delay must be positive, got %v", c.delay)
+	}
+	if c.budgetPercentage <= 0 || c.budgetPercentage > 100 {
+		return fmt.Errorf("the budget percentage must be in (0, 100], got %v", c.budgetPercentage)
+	}
+	if c.burst < 1 {
+		return fmt.Errorf("the burst must be at least 1, got %d", c.burst)
+	}
+	return nil
+}
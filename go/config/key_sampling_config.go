@@ -0,0 +1,102 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import "fmt"
+
+const (
+	// DefaultKeySamplePercentage is the percentage of requests the key sampler records, unless configured otherwise.
+	DefaultKeySamplePercentage = 10.0
+	// DefaultKeySketchWidth is the number of counters of each row of the sketch of the key sampler, unless configured
+	// otherwise.
+	DefaultKeySketchWidth = 2048
+	// DefaultMaxHotKeys is the number of hottest key prefixes the key sampler tracks, unless configured otherwise.
+	DefaultMaxHotKeys = 100
+)
+
+// KeySamplingConfiguration represents the configuration of the key sampler of the client, which estimates how often
+// the keys of its requests are accessed, and how many bytes are sent for them, to find hot keys from the client side.
+//
+// The sampler records a percentage of the requests, by the prefix of their first key: the part of the key before a
+// delimiter, e.g. "user" for "user:1234", or the whole key if no delimiter is configured. Its memory is bounded: the
+// accesses are counted in a count-min sketch of a fixed width, whose estimates may exceed the actual counts but never
+// fall short of them, and only the hottest prefixes are kept to be listed.
+//
+// Keys are only sampled by clients configured with a [KeySamplingConfiguration].
+type KeySamplingConfiguration struct {
+	samplePercentage float64
+	delimiter        string
+	sketchWidth      int
+	maxHotKeys       int
+}
+
+// NewKeySamplingConfiguration returns a [KeySamplingConfiguration] sampling DefaultKeySamplePercentage of the requests
+// by whole key.
+func NewKeySamplingConfiguration() *KeySamplingConfiguration {
+	return &KeySamplingConfiguration{
+		samplePercentage: DefaultKeySamplePercentage,
+		sketchWidth:      DefaultKeySketchWidth,
+		maxHotKeys:       DefaultMaxHotKeys,
+	}
+}
+
+// WithSamplePercentage sets the percentage of requests recorded, between 0 and 100. Defaults to
+// DefaultKeySamplePercentage.
+func (c *KeySamplingConfiguration) WithSamplePercentage(percentage float64) *KeySamplingConfiguration {
+	c.samplePercentage = percentage
+	return c
+}
+
+// WithKeyPrefix buckets the keys by the part of the key before the first occurrence of delimiter, or the whole key if
+// it doesn't contain delimiter. Keys are sampled whole by default.
+func (c *KeySamplingConfiguration) WithKeyPrefix(delimiter string) *KeySamplingConfiguration {
+	c.delimiter = delimiter
+	return c
+}
+
+// WithSketchWidth sets the number of counters of each row of the sketch: the wider the sketch, the more accurate the
+// estimates, and the more memory used. Defaults to DefaultKeySketchWidth.
+func (c *KeySamplingConfiguration) WithSketchWidth(width int) *KeySamplingConfiguration {
+	c.sketchWidth = width
+	return c
+}
+
+// WithMaxHotKeys sets the number of hottest key prefixes tracked to be listed. Defaults to DefaultMaxHotKeys.
+func (c *KeySamplingConfiguration) WithMaxHotKeys(maxHotKeys int) *KeySamplingConfiguration {
+	c.maxHotKeys = maxHotKeys
+	return c
+}
+
+// GetSamplePercentage returns the percentage of requests recorded.
+func (c *KeySamplingConfiguration) GetSamplePercentage() float64 {
+	return c.samplePercentage
+}
+
+// GetKeyPrefix returns the delimiter of the key prefixes, empty if the keys are sampled whole.
+func (c *KeySamplingConfiguration) GetKeyPrefix() string {
+	return c.delimiter
+}
+
+// GetSketchWidth returns the number of counters of each row of the sketch.
+func (c *KeySamplingConfiguration) GetSketchWidth() int {
+	return c.sketchWidth
+}
+
+// GetMaxHotKeys returns the number of hottest key prefixes tracked.
+func (c *KeySamplingConfiguration) GetMaxHotKeys() int {
+	return c.maxHotKeys
+}
+
+// Validate checks that the key sampling configuration is valid.
+func (c *KeySamplingConfiguration) Validate() error {
+	if c.samplePercentage <= 0 || c.samplePercentage > 100 {
+		return fmt.Errorf("the sample percentage must be in (0, 100], got %v", c.samplePercentage)
+	}
+	if c.sketchWidth <= 0 {
+		return fmt.Errorf("the sketch width must be positive, got %d", c.sketchWidth)
+	}
+	if c.maxHotKeys <= 0 {
+		return fmt.Errorf("the maximum number of hot keys must be positive, got %d", c.maxHotKeys)
+	}
+	return nil
+}
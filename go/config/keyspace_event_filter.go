@@ -0,0 +1,63 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+// KeyspaceEvent is the name of an event reported by keyspace notifications, e.g. "expired" or "del".
+//
+// See [valkey.io] for the events emitted by each command.
+//
+// [valkey.io]: https://valkey.io/topics/notifications
+type KeyspaceEvent string
+
+const (
+	KeyspaceEventSet        KeyspaceEvent = "set"
+	KeyspaceEventDel        KeyspaceEvent = "del"
+	KeyspaceEventExpire     KeyspaceEvent = "expire"
+	KeyspaceEventExpired    KeyspaceEvent = "expired"
+	KeyspaceEventEvicted    KeyspaceEvent = "evicted"
+	KeyspaceEventRenameFrom KeyspaceEvent = "rename_from"
+	KeyspaceEventRenameTo   KeyspaceEvent = "rename_to"
+	KeyspaceEventNew        KeyspaceEvent = "new"
+)
+
+// KeyspaceEventFilter selects the keyspace notifications dispatched to a subscriber, by key and by event.
+//
+// A notification matches the filter if its key matches one of the key patterns and its event is one of the events of
+// the filter. A filter without key patterns matches any key, and a filter without events matches any event. Key
+// patterns are glob-style patterns, matched as by the KEYS command.
+//
+// Filters are evaluated by the client, on the notifications received on `__keyspace@<db>__:<key>` and
+// `__keyevent@<db>__:<event>` channels, before they're dispatched to the callback or the message queue. They don't
+// reduce the traffic from the server, but spare the handlers from the notifications they would discard.
+type KeyspaceEventFilter struct {
+	keyPatterns []string
+	events      []KeyspaceEvent
+}
+
+// NewKeyspaceEventFilter returns a [KeyspaceEventFilter] that matches every notification. Use the With* methods to
+// restrict the notifications it matches.
+func NewKeyspaceEventFilter() *KeyspaceEventFilter {
+	return &KeyspaceEventFilter{}
+}
+
+// WithKeyPatterns restricts the filter to the keys matching any of the given glob-style patterns.
+func (filter *KeyspaceEventFilter) WithKeyPatterns(patterns ...string) *KeyspaceEventFilter {
+	filter.keyPatterns = append(filter.keyPatterns, patterns...)
+	return filter
+}
+
+// WithEvents restricts the filter to the given events.
+func (filter *KeyspaceEventFilter) WithEvents(events ...KeyspaceEvent) *KeyspaceEventFilter {
+	filter.events = append(filter.events, events...)
+	return filter
+}
+
+// GetKeyPatterns returns the key patterns of the filter, or nil if it matches any key.
+func (filter *KeyspaceEventFilter) GetKeyPatterns() []string {
+	return filter.keyPatterns
+}
+
+// GetEvents returns the events of the filter, or nil if it matches any event.
+func (filter *KeyspaceEventFilter) GetEvents() []KeyspaceEvent {
+	return filter.events
+}
@@ -0,0 +1,71 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import "fmt"
+
+const (
+	// DefaultMaxKeyPrefixes is the number of distinct key prefixes the request metrics are labelled with, unless
+	// configured otherwise. See [MetricsConfiguration.WithKeyPrefixLabel].
+	DefaultMaxKeyPrefixes = 100
+	// OtherKeyPrefix is the key prefix label of the requests whose key prefix is beyond the maximum number of
+	// prefixes.
+	OtherKeyPrefix = "other"
+)
+
+// MetricsConfiguration represents the configuration of the request metrics recorded by the client: the number of
+// requests, their errors and their latency, per set of labels.
+//
+// Each distinct combination of labels is a time series of its own once the metrics are exported, so the labels can
+// be tuned to keep the number of series bounded on large clusters or keyspaces:
+//   - The command label, e.g. "GET", is attached by default. It can be dropped with WithCommandLabel.
+//   - The key prefix label is only attached when enabled with WithKeyPrefixLabel. It buckets the keys of the requests
+//     by the part of the key before a delimiter, e.g. "user" for "user:1234", and is capped to a maximum number of
+//     prefixes: the keys of further prefixes are labelled with OtherKeyPrefix.
+//
+// Request metrics are only recorded by clients configured with a [MetricsConfiguration].
+type MetricsConfiguration struct {
+	commandLabel       bool
+	keyPrefixDelimiter string
+	maxKeyPrefixes     int
+}
+
+// NewMetricsConfiguration returns a [MetricsConfiguration] labelling the metrics by command.
+func NewMetricsConfiguration() *MetricsConfiguration {
+	return &MetricsConfiguration{commandLabel: true}
+}
+
+// WithCommandLabel sets whether the metrics are labelled with the command of the requests. Enabled by default.
+func (c *MetricsConfiguration) WithCommandLabel(enabled bool) *MetricsConfiguration {
+	c.commandLabel = enabled
+	return c
+}
+
+// WithKeyPrefixLabel labels the metrics with the prefix of the key of the requests, i.e. the part of the key before
+// the first occurrence of delimiter, or the whole key if it doesn't contain delimiter. At most maxPrefixes distinct
+// prefixes are tracked, e.g. [DefaultMaxKeyPrefixes]: the requests with other prefixes are labelled with
+// OtherKeyPrefix. Requests without keys, and batches, are labelled with an empty prefix.
+func (c *MetricsConfiguration) WithKeyPrefixLabel(delimiter string, maxPrefixes int) *MetricsConfiguration {
+	c.keyPrefixDelimiter = delimiter
+	c.maxKeyPrefixes = maxPrefixes
+	return c
+}
+
+// HasCommandLabel returns whether the metrics are labelled with the command of the requests.
+func (c *MetricsConfiguration) HasCommandLabel() bool {
+	return c.commandLabel
+}
+
+// GetKeyPrefixLabel returns the delimiter of the key prefixes, and the maximum number of prefixes tracked. The
+// delimiter is empty if the metrics aren't labelled by key prefix.
+func (c *MetricsConfiguration) GetKeyPrefixLabel() (string, int) {
+	return c.keyPrefixDelimiter, c.maxKeyPrefixes
+}
+
+// Validate checks that the metrics configuration is valid.
+func (c *MetricsConfiguration) Validate() error {
+	if c.keyPrefixDelimiter != "" && c.maxKeyPrefixes <= 0 {
+		return fmt.Errorf("the maximum number of key prefixes must be positive, got %d", c.maxKeyPrefixes)
+	}
+	return nil
+}
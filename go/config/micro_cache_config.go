@@ -0,0 +1,83 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultMicroCacheTTL is how long the micro-cache serves a value, unless configured otherwise.
+	DefaultMicroCacheTTL = 50 * time.Millisecond
+	// DefaultMicroCacheMaxEntries is the number of values the micro-cache holds, unless configured otherwise.
+	DefaultMicroCacheMaxEntries = 10000
+)
+
+// MicroCacheConfiguration represents the configuration of the micro-cache of the client, which protects hot keys,
+// e.g. the stock of a flash sale, from bursts of identical reads.
+//
+// The values read with GET and HGET from the keys matching the configured glob-style patterns are kept for a tiny
+// TTL, e.g. 50ms, and repeated reads of the same key within the TTL are served locally, without a round trip. The
+// concurrent reads of a key missing from the cache are coalesced into a single request. The values of a key are
+// invalidated as soon as the client, or one of its views, gets the response of any command with the key but the
+// reads, e.g. SET, DEL, EXPIRE, HSET, LMOVE or SUNIONSTORE, or of a batch or a script with the key, so that the reads
+// of a process see its own writes. All the values are invalidated by FLUSHALL, FLUSHDB and SWAPDB, and by the
+// commands whose keys the client doesn't know, e.g. SORT with STORE. Values written by other clients may be stale
+// for up to the TTL: only keys which tolerate it should be cached. Failed reads aren't cached.
+//
+// The micro-cache doesn't rely on client-side tracking, and is only enabled on clients configured with a
+// [MicroCacheConfiguration].
+type MicroCacheConfiguration struct {
+	patterns   []string
+	ttl        time.Duration
+	maxEntries int
+}
+
+// NewMicroCacheConfiguration returns a [MicroCacheConfiguration] caching the keys matching any of patterns, e.g.
+// "stock:*", for DefaultMicroCacheTTL.
+func NewMicroCacheConfiguration(patterns ...string) *MicroCacheConfiguration {
+	return &MicroCacheConfiguration{patterns: patterns, ttl: DefaultMicroCacheTTL, maxEntries: DefaultMicroCacheMaxEntries}
+}
+
+// WithTTL sets how long a value is served from the micro-cache. Defaults to DefaultMicroCacheTTL.
+func (c *MicroCacheConfiguration) WithTTL(ttl time.Duration) *MicroCacheConfiguration {
+	c.ttl = ttl
+	return c
+}
+
+// WithMaxEntries sets the number of values the micro-cache holds: further values aren't cached until entries
+// expire. Defaults to DefaultMicroCacheMaxEntries.
+func (c *MicroCacheConfiguration) WithMaxEntries(maxEntries int) *MicroCacheConfiguration {
+	c.maxEntries = maxEntries
+	return c
+}
+
+// GetPatterns returns the glob-style patterns of the cached keys.
+func (c *MicroCacheConfiguration) GetPatterns() []string {
+	return c.patterns
+}
+
+// GetTTL returns how long a value is served from the micro-cache.
+func (c *MicroCacheConfiguration) GetTTL() time.Duration {
+	return c.ttl
+}
+
+// GetMaxEntries returns the number of values the micro-cache holds.
+func (c *MicroCacheConfiguration) GetMaxEntries() int {
+	return c.maxEntries
+}
+
+// Validate checks that the micro-cache configuration is valid.
+func (c *MicroCacheConfiguration) Validate() error {
+	if len(c.patterns) == 0 {
+		return fmt.Errorf("at least one key pattern must be cached")
+	}
+	if c.ttl <= 0 {
+		return fmt.Errorf("the TTL must be positive, got %v", c.ttl)
+	}
+	if c.maxEntries <= 0 {
+		return fmt.Errorf("the maximum number of entries must be positive, got %d", c.maxEntries)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// DefaultPrimaryChangeCheckInterval is the interval between two checks of the cluster topology for primary changes,
+// unless configured otherwise.
+const DefaultPrimaryChangeCheckInterval = time.Second
+
+// PrimaryChangeCallback is called with the primary changes detected by a cluster client.
+type PrimaryChangeCallback func(event models.PrimaryChangeEvent)
+
+// PrimaryChangeConfiguration represents the configuration of the primary change events of a cluster client, e.g. to
+// track the time it takes for failovers to be noticed by the application.
+//
+// The client checks the primaries serving the slots of the cluster periodically, and right away when a command fails
+// with a MOVED or REDIRECT error. The topology is fetched from all the primaries, and the changes are only reported
+// once they all agree on it, so that a failover in progress isn't reported back and forth. The callback is called
+// once per range of slots whose primary changed, from a dedicated goroutine, one event at a time.
+type PrimaryChangeConfiguration struct {
+	callback      PrimaryChangeCallback
+	checkInterval time.Duration
+}
+
+// NewPrimaryChangeConfiguration returns a [PrimaryChangeConfiguration] calling callback with the primary changes
+// detected by the client.
+func NewPrimaryChangeConfiguration(callback PrimaryChangeCallback) *PrimaryChangeConfiguration {
+	return &PrimaryChangeConfiguration{callback: callback, checkInterval: DefaultPrimaryChangeCheckInterval}
+}
+
+// WithCheckInterval sets the interval between two periodic checks of the cluster topology. Defaults to
+// DefaultPrimaryChangeCheckInterval.
+func (c *PrimaryChangeConfiguration) WithCheckInterval(checkInterval time.Duration) *PrimaryChangeConfiguration {
+	c.checkInterval = checkInterval
+	return c
+}
+
+// GetCallback returns the function called with the detected primary changes.
+func (c *PrimaryChangeConfiguration) GetCallback() PrimaryChangeCallback {
+	return c.callback
+}
+
+// GetCheckInterval returns the interval between two periodic checks of the cluster topology.
+func (c *PrimaryChangeConfiguration) GetCheckInterval() time.Duration {
+	return c.checkInterval
+}
+
+// Validate checks that the primary change configuration is valid.
+func (c *PrimaryChangeConfiguration) Validate() error {
+	if c.callback == nil {
+		return errors.New("the primary change callback must not be nil")
+	}
+	if c.checkInterval <= 0 {
+		return fmt.Errorf("check interval must be positive, got %v", c.checkInterval)
+	}
+	return nil
+}
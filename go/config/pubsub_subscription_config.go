@@ -10,10 +10,44 @@ import (
 // *** BaseSubscriptionConfig ***
 type MessageCallback func(message *models.PubSubMessage, ctx any)
 
+// PubSubDispatchMode defines how the messages received by a subscriber are dispatched to its [MessageCallback].
+//
+// DispatchOrderedPerChannel and DispatchWorkerPool guarantee that the messages of a channel are delivered to the
+// callback one at a time, in the order they were published: the callback isn't invoked for a message before it
+// returned for the previous message of the same channel. Messages of different channels are delivered
+// concurrently. DispatchPerMessage gives no ordering guarantee.
+type PubSubDispatchMode int
+
+const (
+	// DispatchPerMessage invokes the callback in a new goroutine for every message. This is the default mode.
+	// Messages are processed concurrently, and may be processed in any order.
+	DispatchPerMessage PubSubDispatchMode = iota
+	// DispatchOrderedPerChannel processes the messages of a channel one at a time, in publish order, while the
+	// messages of different channels are processed concurrently, without limit.
+	DispatchOrderedPerChannel
+	// DispatchWorkerPool processes messages with a bounded pool of goroutines, set with WithWorkerPoolSize. As with
+	// DispatchOrderedPerChannel, the messages of a channel are processed one at a time, in publish order, and at
+	// most pool size channels have a message processed concurrently.
+	DispatchWorkerPool
+)
+
+func (mode PubSubDispatchMode) String() string {
+	return [...]string{"PER_MESSAGE", "ORDERED_PER_CHANNEL", "WORKER_POOL"}[mode]
+}
+
+// DefaultWorkerPoolSize is the number of goroutines used by DispatchWorkerPool, unless configured otherwise.
+const DefaultWorkerPoolSize = 8
+
 type BaseSubscriptionConfig struct {
-	callback      MessageCallback
-	context       any
-	subscriptions map[uint32][]string
+	callback       MessageCallback
+	context        any
+	subscriptions  map[uint32][]string
+	dispatchMode   PubSubDispatchMode
+	workerPoolSize int
+	// The number of buffered messages beyond which received messages are dropped, or 0 for no limit.
+	maxBufferedMessages int
+	// Keyspace notifications matching none of the filters are discarded, unless there are no filters.
+	keyspaceFilters []*KeyspaceEventFilter
 }
 
 func NewBaseSubscriptionConfig() *BaseSubscriptionConfig {
@@ -49,6 +83,31 @@ func (config *BaseSubscriptionConfig) GetContext() any {
 	return config.context
 }
 
+// GetDispatchMode returns how messages are dispatched to the callback.
+func (config *BaseSubscriptionConfig) GetDispatchMode() PubSubDispatchMode {
+	return config.dispatchMode
+}
+
+// GetWorkerPoolSize returns the number of goroutines used by DispatchWorkerPool.
+func (config *BaseSubscriptionConfig) GetWorkerPoolSize() int {
+	if config.workerPoolSize <= 0 {
+		return DefaultWorkerPoolSize
+	}
+	return config.workerPoolSize
+}
+
+// GetMaxBufferedMessages returns the number of buffered messages beyond which received messages are dropped, or 0
+// if messages are never dropped.
+func (config *BaseSubscriptionConfig) GetMaxBufferedMessages() int {
+	return max(config.maxBufferedMessages, 0)
+}
+
+// GetKeyspaceEventFilters returns the filters of the keyspace notifications, or nil if all notifications are
+// dispatched.
+func (config *BaseSubscriptionConfig) GetKeyspaceEventFilters() []*KeyspaceEventFilter {
+	return config.keyspaceFilters
+}
+
 // *** StandaloneSubscriptionConfig ***
 
 type PubSubChannelMode int
@@ -78,6 +137,35 @@ func (config *StandaloneSubscriptionConfig) WithCallback(callback MessageCallbac
 	return config
 }
 
+// WithDispatchMode sets how messages are dispatched to the callback, trading ordering guarantees for throughput.
+// See [PubSubDispatchMode] for the available modes. Defaults to DispatchPerMessage.
+func (config *StandaloneSubscriptionConfig) WithDispatchMode(mode PubSubDispatchMode) *StandaloneSubscriptionConfig {
+	config.dispatchMode = mode
+	return config
+}
+
+// WithWorkerPoolSize sets the number of goroutines used by DispatchWorkerPool. Defaults to DefaultWorkerPoolSize.
+func (config *StandaloneSubscriptionConfig) WithWorkerPoolSize(size int) *StandaloneSubscriptionConfig {
+	config.workerPoolSize = size
+	return config
+}
+
+// WithMaxBufferedMessages limits the number of received messages waiting to be processed, by the callback or by a
+// consumer of the message queue. Messages received while the limit is reached are dropped, and counted in
+// [models.PubSubMetrics.DroppedMessages]. By default messages are never dropped.
+func (config *StandaloneSubscriptionConfig) WithMaxBufferedMessages(maxBufferedMessages int) *StandaloneSubscriptionConfig {
+	config.maxBufferedMessages = maxBufferedMessages
+	return config
+}
+
+// WithKeyspaceEventFilter adds a filter of the keyspace notifications dispatched to the subscriber. Once a filter is
+// added, keyspace notifications matching none of the filters are discarded. Other messages are always dispatched.
+// See [KeyspaceEventFilter] for details.
+func (config *StandaloneSubscriptionConfig) WithKeyspaceEventFilter(filter *KeyspaceEventFilter) *StandaloneSubscriptionConfig {
+	config.keyspaceFilters = append(config.keyspaceFilters, filter)
+	return config
+}
+
 func (config *StandaloneSubscriptionConfig) WithSubscription(
 	mode PubSubChannelMode,
 	channelOrPattern string,
@@ -124,6 +212,35 @@ func (config *ClusterSubscriptionConfig) WithCallback(callback MessageCallback,
 	return config
 }
 
+// WithDispatchMode sets how messages are dispatched to the callback, trading ordering guarantees for throughput.
+// See [PubSubDispatchMode] for the available modes. Defaults to DispatchPerMessage.
+func (config *ClusterSubscriptionConfig) WithDispatchMode(mode PubSubDispatchMode) *ClusterSubscriptionConfig {
+	config.dispatchMode = mode
+	return config
+}
+
+// WithWorkerPoolSize sets the number of goroutines used by DispatchWorkerPool. Defaults to DefaultWorkerPoolSize.
+func (config *ClusterSubscriptionConfig) WithWorkerPoolSize(size int) *ClusterSubscriptionConfig {
+	config.workerPoolSize = size
+	return config
+}
+
+// WithMaxBufferedMessages limits the number of received messages waiting to be processed, by the callback or by a
+// consumer of the message queue. Messages received while the limit is reached are dropped, and counted in
+// [models.PubSubMetrics.DroppedMessages]. By default messages are never dropped.
+func (config *ClusterSubscriptionConfig) WithMaxBufferedMessages(maxBufferedMessages int) *ClusterSubscriptionConfig {
+	config.maxBufferedMessages = maxBufferedMessages
+	return config
+}
+
+// WithKeyspaceEventFilter adds a filter of the keyspace notifications dispatched to the subscriber. Once a filter is
+// added, keyspace notifications matching none of the filters are discarded. Other messages are always dispatched.
+// See [KeyspaceEventFilter] for details.
+func (config *ClusterSubscriptionConfig) WithKeyspaceEventFilter(filter *KeyspaceEventFilter) *ClusterSubscriptionConfig {
+	config.keyspaceFilters = append(config.keyspaceFilters, filter)
+	return config
+}
+
 func (config *ClusterSubscriptionConfig) WithSubscription(
 	mode PubSubClusterChannelMode,
 	channelOrPattern string,
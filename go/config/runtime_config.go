@@ -0,0 +1,94 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RuntimeConfiguration represents the options of a client which can be updated while it's in use, with
+// UpdateRuntimeConfiguration, e.g. to tune a client from a configuration service without recreating it. Only the
+// options set with the With* methods are updated, the others keep their current value.
+//
+// Updated options apply to the requests submitted after the update. Requests already sent or waiting for the
+// scheduler keep the options they were submitted with, except that waiting requests are admitted according to the
+// updated scheduling limits.
+//
+// Only the request timeout, the timeout classes and the scheduling limits can be updated. The other options of the
+// client, e.g. its read strategy, its retry strategy and the number of its connections, are applied by the core to
+// the connections when they're established, and can only be changed by creating a new client.
+type RuntimeConfiguration struct {
+	requestTimeout *time.Duration
+	scheduling     *SchedulingConfiguration
+	timeoutClasses map[string]time.Duration
+}
+
+// NewRuntimeConfiguration returns a [RuntimeConfiguration] which doesn't update any option.
+func NewRuntimeConfiguration() *RuntimeConfiguration {
+	return &RuntimeConfiguration{}
+}
+
+// WithRequestTimeout sets the time the client waits for the response of a request, for the requests whose context
+// has no earlier deadline, or 0 to only use the request timeout the client was created with. The blocking commands,
+// e.g. BLPOP or WAIT, wait for their own timeout instead.
+//
+// The request timeout can only be lowered: the request timeout the client was created with still applies, and
+// UpdateRuntimeConfiguration rejects a longer one. To be able to raise the timeout later on, create the client with
+// the longest timeout the requests may need, and lower it with WithRequestTimeout.
+func (c *RuntimeConfiguration) WithRequestTimeout(requestTimeout time.Duration) *RuntimeConfiguration {
+	c.requestTimeout = &requestTimeout
+	return c
+}
+
+// WithScheduling replaces the limits of the request scheduler of the client. The client must have been created with
+// a [SchedulingConfiguration], see WithSchedulingConfiguration.
+func (c *RuntimeConfiguration) WithScheduling(scheduling *SchedulingConfiguration) *RuntimeConfiguration {
+	c.scheduling = scheduling
+	return c
+}
+
+// WithTimeoutClass defines the timeout class name, or replaces its timeout, see
+// [ClientConfiguration.WithTimeoutClass]. The other timeout classes of the client are kept. The timeout can't exceed
+// the longest of the request timeout and the timeout classes the client was created with.
+func (c *RuntimeConfiguration) WithTimeoutClass(name string, timeout time.Duration) *RuntimeConfiguration {
+	if c.timeoutClasses == nil {
+		c.timeoutClasses = make(map[string]time.Duration)
+	}
+	c.timeoutClasses[name] = timeout
+	return c
+}
+
+// GetRequestTimeout returns the request timeout to set, or false if it's not updated.
+func (c *RuntimeConfiguration) GetRequestTimeout() (time.Duration, bool) {
+	if c.requestTimeout == nil {
+		return 0, false
+	}
+	return *c.requestTimeout, true
+}
+
+// GetScheduling returns the limits of the request scheduler to set, or nil if they're not updated.
+func (c *RuntimeConfiguration) GetScheduling() *SchedulingConfiguration {
+	return c.scheduling
+}
+
+// GetTimeoutClasses returns the timeout classes to define or update, by name, or nil if none is updated.
+func (c *RuntimeConfiguration) GetTimeoutClasses() map[string]time.Duration {
+	return c.timeoutClasses
+}
+
+// Validate checks that the runtime configuration is valid.
+func (c *RuntimeConfiguration) Validate() error {
+	if c.requestTimeout != nil && *c.requestTimeout < 0 {
+		return fmt.Errorf("request timeout must not be negative, got %v", *c.requestTimeout)
+	}
+	if err := ValidateTimeoutClasses(c.timeoutClasses); err != nil {
+		return err
+	}
+	if c.scheduling != nil {
+		if err := c.scheduling.Validate(); err != nil {
+			return fmt.Errorf("invalid scheduling configuration: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import "fmt"
+
+// SchedulingConfiguration represents the configuration of the client side request scheduler.
+//
+// When configured, the client limits the number of requests it has outstanding at any time. Requests
+// submitted while the limit is reached wait in a queue, and are sent in order of priority: requests
+// tagged as high priority (the default) are always sent before requests tagged as low priority. Use
+// `glide.WithPriority` to tag the requests of background jobs, such as scans, so they cannot starve
+// interactive requests sharing the same client.
+//
+// By default a batch counts as a single request. Use [SchedulingConfiguration.WithMaxBatchCommands] to bound the
+// share of the client a large batch can take, so bulk jobs sharing the client don't inflate the latency of single
+// commands.
+//
+// Without a scheduling configuration, requests are sent as soon as they're submitted.
+type SchedulingConfiguration struct {
+	// The maximum number of requests the client has outstanding at the same time.
+	maxConcurrentRequests int
+	// The maximum number of slots the outstanding batches take overall, or nil if a batch counts as a single request.
+	maxBatchCommands *int
+}
+
+// NewSchedulingConfiguration returns a [SchedulingConfiguration] that allows at most maxConcurrentRequests
+// requests to be outstanding at the same time.
+func NewSchedulingConfiguration(maxConcurrentRequests int) *SchedulingConfiguration {
+	return &SchedulingConfiguration{maxConcurrentRequests: maxConcurrentRequests}
+}
+
+// GetMaxConcurrentRequests returns the maximum number of requests the client has outstanding at the same time.
+func (c *SchedulingConfiguration) GetMaxConcurrentRequests() int {
+	return c.maxConcurrentRequests
+}
+
+// WithMaxBatchCommands makes batches count as one request per command, and limits the number of commands of the
+// batches outstanding at the same time to maxBatchCommands. A batch with more commands counts as maxBatchCommands
+// requests. While batches wait for other batches to complete, the single commands queued behind them are sent,
+// so single commands are interleaved with batches instead of waiting for all the batches submitted before them.
+//
+// Setting maxBatchCommands below the maximum number of concurrent requests reserves the remaining slots for
+// single commands.
+func (c *SchedulingConfiguration) WithMaxBatchCommands(maxBatchCommands int) *SchedulingConfiguration {
+	c.maxBatchCommands = &maxBatchCommands
+	return c
+}
+
+// GetMaxBatchCommands returns the maximum number of commands of the batches outstanding at the same time, or 0 if
+// a batch counts as a single request.
+func (c *SchedulingConfiguration) GetMaxBatchCommands() int {
+	if c.maxBatchCommands == nil {
+		return 0
+	}
+	return *c.maxBatchCommands
+}
+
+// Validate checks that the scheduling configuration is valid.
+func (c *SchedulingConfiguration) Validate() error {
+	if c.maxConcurrentRequests <= 0 {
+		return fmt.Errorf("max_concurrent_requests must be a positive number, got %d", c.maxConcurrentRequests)
+	}
+	if c.maxBatchCommands != nil && (*c.maxBatchCommands <= 0 || *c.maxBatchCommands > c.maxConcurrentRequests) {
+		return fmt.Errorf(
+			"max_batch_commands must be between 1 and max_concurrent_requests (%d), got %d",
+			c.maxConcurrentRequests,
+			*c.maxBatchCommands,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package config
+
+import "fmt"
+
+// DefaultMaxSubscriberConnectionsPerNode is the number of isolated subscriber connections the clients of the process
+// may open to a node, unless configured otherwise.
+const DefaultMaxSubscriberConnectionsPerNode = 4
+
+// SubscriberIsolationConfiguration represents the configuration of the isolation of the subscriptions of the client
+// from its command traffic.
+//
+// Without isolation, the subscriptions of a client share its connections with the requests: the messages are pushed
+// on the connections the responses are read from, so that a flood of messages delays the responses. With isolation,
+// the client opens a dedicated connection to each node for its subscriptions, which the subscription commands, e.g.
+// SUBSCRIBE, UNSUBSCRIBE or SSUBSCRIBE, and the messages go through, while the other commands, including PUBLISH, go
+// through the connections of the requests. The messages are received by the callback or the queue of the subscription
+// configuration of the client, as without isolation.
+//
+// The isolated subscriber connections of the clients of the process are capped per node, the nodes being identified by
+// their address: the addresses a standalone client is configured with, and the addresses of the primaries and replicas
+// serving the slots of a cluster, as reported by CLUSTER SLOTS when the client is created. Creating a client which
+// would exceed the cap fails with a ConfigurationError, until a client with isolated subscriptions to the node is
+// closed. The nodes added to a cluster later on aren't counted.
+type SubscriberIsolationConfiguration struct {
+	maxConnectionsPerNode int
+}
+
+// NewSubscriberIsolationConfiguration returns a [SubscriberIsolationConfiguration] with
+// DefaultMaxSubscriberConnectionsPerNode.
+func NewSubscriberIsolationConfiguration() *SubscriberIsolationConfiguration {
+	return &SubscriberIsolationConfiguration{maxConnectionsPerNode: DefaultMaxSubscriberConnectionsPerNode}
+}
+
+// WithMaxConnectionsPerNode sets the number of isolated subscriber connections the clients of the process may open to
+// a node. Defaults to DefaultMaxSubscriberConnectionsPerNode.
+func (c *SubscriberIsolationConfiguration) WithMaxConnectionsPerNode(maxConnections int) *SubscriberIsolationConfiguration {
+	c.maxConnectionsPerNode = maxConnections
+	return c
+}
+
+// GetMaxConnectionsPerNode returns the number of isolated subscriber connections the clients of the process may open
+// to a node.
+func (c *SubscriberIsolationConfiguration) GetMaxConnectionsPerNode() int {
+	return c.maxConnectionsPerNode
+}
+
+// Validate checks that the subscriber isolation configuration is valid.
+func (c *SubscriberIsolationConfiguration) Validate() error {
+	if c.maxConnectionsPerNode <= 0 {
+		return fmt.Errorf("the maximum number of subscriber connections per node must be positive, got %d",
+			c.maxConnectionsPerNode)
+	}
+	return nil
+}
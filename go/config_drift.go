@@ -0,0 +1,87 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sort"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// ConfigDrift gets the values of configuration parameters from all the nodes of the cluster with CONFIG GET, and
+// reports the nodes whose values differ from the value of most of the nodes, i.e. the configuration drift left by
+// CONFIG SET calls which didn't reach all the nodes, or by nodes restarted with an outdated configuration file.
+//
+// See [valkey.io] for details.
+//
+// Note:
+//
+// Prior to Version 7.0.0, only one parameter can be sent.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	parameters - The names of the configuration parameters to compare, which may be glob-style patterns, e.g.
+//	             "maxmemory*".
+//
+// Return value:
+//
+//	The mismatches, ordered by parameter and node, or none if all the nodes have the same values. The value of a
+//	parameter held by as many nodes as another value is expected if it's the lowest one.
+//
+// [valkey.io]: https://valkey.io/commands/config-get/
+func (client *ClusterClient) ConfigDrift(ctx context.Context, parameters []string) ([]models.ConfigMismatch, error) {
+	values, err := client.ConfigGetWithOptions(ctx, parameters, options.RouteOption{Route: config.AllNodes})
+	if err != nil {
+		return nil, err
+	}
+	return findConfigDrift(values.MultiValue()), nil
+}
+
+// findConfigDrift returns the mismatches of the configuration parameters of the nodes of values, by node address.
+func findConfigDrift(values map[string]map[string]string) []models.ConfigMismatch {
+	parameters := make(map[string]bool)
+	for _, nodeValues := range values {
+		for parameter := range nodeValues {
+			parameters[parameter] = true
+		}
+	}
+	var mismatches []models.ConfigMismatch
+	for parameter := range parameters {
+		counts := make(map[string]int)
+		for _, nodeValues := range values {
+			if value, ok := nodeValues[parameter]; ok {
+				counts[value]++
+			}
+		}
+		expected := ""
+		for value, count := range counts {
+			if count > counts[expected] || (count == counts[expected] && value < expected) {
+				expected = value
+			}
+		}
+		for node, nodeValues := range values {
+			value, ok := nodeValues[parameter]
+			if ok && value == expected {
+				continue
+			}
+			mismatches = append(mismatches, models.ConfigMismatch{
+				Node:          node,
+				Parameter:     parameter,
+				Value:         value,
+				Missing:       !ok,
+				ExpectedValue: expected,
+			})
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].Parameter != mismatches[j].Parameter {
+			return mismatches[i].Parameter < mismatches[j].Parameter
+		}
+		return mismatches[i].Node < mismatches[j].Node
+	})
+	return mismatches
+}
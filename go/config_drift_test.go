@@ -0,0 +1,39 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestFindConfigDrift(t *testing.T) {
+	values := map[string]map[string]string{
+		"10.0.0.1:6379": {"maxmemory": "1073741824", "maxmemory-policy": "allkeys-lru", "lazyfree-lazy-user-del": "yes"},
+		"10.0.0.2:6379": {"maxmemory": "1073741824", "maxmemory-policy": "noeviction", "lazyfree-lazy-user-del": "yes"},
+		"10.0.0.3:6379": {"maxmemory": "0", "maxmemory-policy": "allkeys-lru"},
+	}
+
+	assert.Equal(t, []models.ConfigMismatch{
+		{Node: "10.0.0.3:6379", Parameter: "lazyfree-lazy-user-del", Missing: true, ExpectedValue: "yes"},
+		{Node: "10.0.0.3:6379", Parameter: "maxmemory", Value: "0", ExpectedValue: "1073741824"},
+		{Node: "10.0.0.2:6379", Parameter: "maxmemory-policy", Value: "noeviction", ExpectedValue: "allkeys-lru"},
+	}, findConfigDrift(values))
+
+	// Ties are broken by the lowest value.
+	values = map[string]map[string]string{
+		"10.0.0.1:6379": {"save": ""},
+		"10.0.0.2:6379": {"save": "3600 1"},
+	}
+	assert.Equal(t, []models.ConfigMismatch{
+		{Node: "10.0.0.2:6379", Parameter: "save", Value: "3600 1", ExpectedValue: ""},
+	}, findConfigDrift(values))
+
+	assert.Empty(t, findConfigDrift(map[string]map[string]string{
+		"10.0.0.1:6379": {"maxmemory": "0"},
+		"10.0.0.2:6379": {"maxmemory": "0"},
+	}))
+}
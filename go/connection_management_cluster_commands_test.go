@@ -87,6 +87,30 @@ func ExampleClusterClient_ClientIdWithOptions() {
 	// Output: true
 }
 
+func ExampleClusterClient_ClientInfo() {
+	var client *ClusterClient = getExampleClusterClient() // example helper function
+	result, err := client.ClientInfo(context.Background())
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	_, hasId := result.SingleValue()["id"]
+	fmt.Println(hasId)
+
+	// Output: true
+}
+
+func ExampleClusterClient_ClientInfoWithOptions() {
+	var client *ClusterClient = getExampleClusterClient() // example helper function
+	opts := options.RouteOption{Route: config.AllPrimaries}
+	result, err := client.ClientInfoWithOptions(context.Background(), opts)
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(result.IsMultiValue())
+
+	// Output: true
+}
+
 func ExampleClusterClient_ClientSetName() {
 	var client *ClusterClient = getExampleClusterClient() // example helper function
 	connectionName := "ConnectionName-" + uuid.NewString()
@@ -57,6 +57,18 @@ func ExampleClient_ClientId() {
 	// Output: true
 }
 
+func ExampleClient_ClientInfo() {
+	var client *Client = getExampleClient() // example helper function
+	result, err := client.ClientInfo(context.Background())
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	_, hasId := result["id"]
+	fmt.Println(hasId)
+
+	// Output: true
+}
+
 func ExampleClient_ClientSetName() {
 	var client *Client = getExampleClient() // example helper function
 	result, err := client.ClientSetName(context.Background(), "ConnectionName")
@@ -0,0 +1,101 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// Connections returns the identity of the connection of the client to the server, as assigned by the server: its
+// client ID, its addresses, its protocol and its creation time, from CLIENT INFO.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The identity of the connection, whose ID matches the "id" of the connection in CLIENT LIST. The connection is
+//	replaced when the client reconnects, with a new ID.
+//
+// [valkey.io]: https://valkey.io/commands/client-info/
+func (client *Client) Connections(ctx context.Context) ([]models.ConnectionIdentity, error) {
+	info, err := client.ClientInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := parseConnectionIdentity("", info, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return []models.ConnectionIdentity{identity}, nil
+}
+
+// Connections returns the identities of the connections of the client to all the nodes of the cluster, as assigned
+// by the nodes: their client IDs, their addresses, their protocol and their creation time, from CLIENT INFO.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The identity of the connection to each node, ordered by node address, whose ID matches the "id" of the
+//	connection in the CLIENT LIST of the node. The connections are replaced when the client reconnects, with new IDs.
+//
+// [valkey.io]: https://valkey.io/commands/client-info/
+func (client *ClusterClient) Connections(ctx context.Context) ([]models.ConnectionIdentity, error) {
+	infos, err := client.ClientInfoWithOptions(ctx, options.RouteOption{Route: config.AllNodes})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	identities := make([]models.ConnectionIdentity, 0, len(infos.MultiValue()))
+	for node, info := range infos.MultiValue() {
+		identity, err := parseConnectionIdentity(node, info, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection information of %s: %w", node, err)
+		}
+		identities = append(identities, identity)
+	}
+	sort.Slice(identities, func(i, j int) bool { return identities[i].Node < identities[j].Node })
+	return identities, nil
+}
+
+// parseConnectionIdentity returns the identity of the connection of info, the properties listed by CLIENT INFO, at
+// time now.
+func parseConnectionIdentity(node string, info map[string]string, now time.Time) (models.ConnectionIdentity, error) {
+	id, err := strconv.ParseInt(info["id"], 10, 64)
+	if err != nil {
+		return models.ConnectionIdentity{}, fmt.Errorf("invalid id: %w", err)
+	}
+	// The protocol is listed since version 7.0, the connections being RESP2 before.
+	protocol := int64(2)
+	if resp, ok := info["resp"]; ok {
+		if protocol, err = strconv.ParseInt(resp, 10, 64); err != nil {
+			return models.ConnectionIdentity{}, fmt.Errorf("invalid resp: %w", err)
+		}
+	}
+	age, _ := strconv.ParseInt(info["age"], 10, 64)
+	return models.ConnectionIdentity{
+		Node:      node,
+		ID:        id,
+		Addr:      info["addr"],
+		LAddr:     info["laddr"],
+		Protocol:  protocol,
+		Name:      info["name"],
+		CreatedAt: now.Add(-time.Duration(age) * time.Second).Truncate(time.Second),
+	}, nil
+}
@@ -0,0 +1,39 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParseConnectionIdentity(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	info := parseClientInfo("id=42 addr=10.0.0.9:53126 laddr=10.0.0.1:6379 fd=8 name=orders age=100 idle=0 " +
+		"flags=N db=0 sub=0 psub=0 multi=-1 cmd=client|info user=default lib-name=GlidePy resp=3")
+
+	identity, err := parseConnectionIdentity("10.0.0.1:6379", info, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.ConnectionIdentity{
+		Node:      "10.0.0.1:6379",
+		ID:        42,
+		Addr:      "10.0.0.9:53126",
+		LAddr:     "10.0.0.1:6379",
+		Protocol:  3,
+		Name:      "orders",
+		CreatedAt: time.Unix(1700000000, 0),
+	}, identity)
+
+	// The protocol isn't listed before version 7.0.
+	identity, err = parseConnectionIdentity("", parseClientInfo("id=7 addr=10.0.0.9:53127 age=0"), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), identity.Protocol)
+
+	_, err = parseConnectionIdentity("", parseClientInfo("addr=10.0.0.9:53127"), now)
+	assert.ErrorContains(t, err, "invalid id")
+}
@@ -0,0 +1,289 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// clampedIncrScript increments KEYS[1] with the command ARGV[1] by ARGV[2], and keeps its value within ARGV[3] and
+// ARGV[4], compared as integers, or as floats if ARGV[6] is "float". A value the increment would overflow is set to
+// ARGV[5]. The integers are compared as strings, since the numbers of Lua are doubles.
+var clampedIncrScript = sync.OnceValue(func() *options.Script {
+	return options.NewScript(`
+local function compare(a, b)
+	local negativeA, negativeB = a:sub(1, 1) == '-', b:sub(1, 1) == '-'
+	if negativeA ~= negativeB then
+		return negativeA and -1 or 1
+	end
+	if negativeA then
+		a, b = b:sub(2), a:sub(2)
+	end
+	if #a ~= #b then
+		return #a < #b and -1 or 1
+	end
+	if a == b then
+		return 0
+	end
+	return a < b and -1 or 1
+end
+local reply = redis.pcall(ARGV[1], KEYS[1], ARGV[2])
+if type(reply) == 'table' and reply.err then
+	if string.find(reply.err, 'would overflow', 1, true) or string.find(reply.err, 'NaN or Infinity', 1, true) then
+		redis.call('SET', KEYS[1], ARGV[5], 'KEEPTTL')
+		return ARGV[5]
+	end
+	return reply
+end
+local value = redis.call('GET', KEYS[1])
+local bound
+if ARGV[6] == 'float' then
+	if tonumber(value) < tonumber(ARGV[3]) then
+		bound = ARGV[3]
+	elseif tonumber(value) > tonumber(ARGV[4]) then
+		bound = ARGV[4]
+	end
+elseif compare(value, ARGV[3]) < 0 then
+	bound = ARGV[3]
+elseif compare(value, ARGV[4]) > 0 then
+	bound = ARGV[4]
+end
+if bound then
+	redis.call('SET', KEYS[1], bound, 'KEEPTTL')
+	return bound
+end
+return value
+`)
+})
+
+// incrWithTTLScript increments KEYS[1], and sets its time to live to ARGV[1] milliseconds if it has none, i.e. on
+// its first increment. The value is returned as a string, since the numbers of Lua are doubles.
+var incrWithTTLScript = sync.OnceValue(func() *options.Script {
+	return options.NewScript(`
+redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) == -1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return redis.call('GET', KEYS[1])
+`)
+})
+
+// Increments the number stored at key by amount, like IncrBy, and reports an increment which would overflow the value
+// as an [OverflowError], matching [ErrOverflow]. With [options.IncrOptions.Clamp], the value is kept within the bounds
+// of incrOptions instead, atomically, by a Lua script.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx         - The context for controlling the command execution.
+//	key         - The key to increment its value.
+//	amount      - The amount to increment.
+//	incrOptions - The clamping of the value.
+//
+// Return value:
+//
+//	The value of `key` after the increment, clamped if the increment is clamped.
+//
+// [valkey.io]: https://valkey.io/commands/incrby/
+func (client *baseClient) IncrByWithOptions(
+	ctx context.Context,
+	key string,
+	amount int64,
+	incrOptions options.IncrOptions,
+) (int64, error) {
+	return client.incrInt(ctx, "INCRBY", key, amount, amount < 0, incrOptions)
+}
+
+// Decrements the number stored at key by amount, like DecrBy, and reports a decrement which would overflow the value
+// as an [OverflowError], matching [ErrOverflow]. With [options.IncrOptions.Clamp], the value is kept within the bounds
+// of incrOptions instead, atomically, by a Lua script.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx         - The context for controlling the command execution.
+//	key         - The key to decrement its value.
+//	amount      - The amount to decrement.
+//	incrOptions - The clamping of the value.
+//
+// Return value:
+//
+//	The value of `key` after the decrement, clamped if the decrement is clamped.
+//
+// [valkey.io]: https://valkey.io/commands/decrby/
+func (client *baseClient) DecrByWithOptions(
+	ctx context.Context,
+	key string,
+	amount int64,
+	incrOptions options.IncrOptions,
+) (int64, error) {
+	return client.incrInt(ctx, "DECRBY", key, amount, amount > 0, incrOptions)
+}
+
+// incrInt increments key with command, INCRBY or DECRBY, by amount. decreasing tells whether the increment decreases
+// the value, to clamp an overflowing value to the lowest or the highest bound.
+func (client *baseClient) incrInt(
+	ctx context.Context,
+	command string,
+	key string,
+	amount int64,
+	decreasing bool,
+	incrOptions options.IncrOptions,
+) (int64, error) {
+	if err := incrOptions.Validate(); err != nil {
+		return 0, err
+	}
+	if !incrOptions.Clamp {
+		var value int64
+		var err error
+		if command == "DECRBY" {
+			value, err = client.DecrBy(ctx, key, amount)
+		} else {
+			value, err = client.IncrBy(ctx, key, amount)
+		}
+		return value, overflowError(key, err)
+	}
+	overflowBound := incrOptions.Max
+	if decreasing {
+		overflowBound = incrOptions.Min
+	}
+	value, err := client.clampedIncr(ctx, key, []string{
+		command,
+		strconv.FormatInt(amount, 10),
+		strconv.FormatInt(incrOptions.Min, 10),
+		strconv.FormatInt(incrOptions.Max, 10),
+		strconv.FormatInt(overflowBound, 10),
+		"int",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// Increments the floating-point number stored at key by amount, like IncrByFloat, and reports an increment which
+// would make the value infinite as an [OverflowError], matching [ErrOverflow]. With [options.IncrByFloatOptions.Clamp],
+// the value is kept within the bounds of incrOptions instead, atomically, by a Lua script.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx         - The context for controlling the command execution.
+//	key         - The key to increment its value.
+//	amount      - The amount to increment.
+//	incrOptions - The clamping of the value.
+//
+// Return value:
+//
+//	The value of `key` after the increment, clamped if the increment is clamped.
+//
+// [valkey.io]: https://valkey.io/commands/incrbyfloat/
+func (client *baseClient) IncrByFloatWithOptions(
+	ctx context.Context,
+	key string,
+	amount float64,
+	incrOptions options.IncrByFloatOptions,
+) (float64, error) {
+	if err := incrOptions.Validate(); err != nil {
+		return 0, err
+	}
+	if !incrOptions.Clamp {
+		value, err := client.IncrByFloat(ctx, key, amount)
+		return value, overflowError(key, err)
+	}
+	overflowBound := incrOptions.Max
+	if amount < 0 {
+		overflowBound = incrOptions.Min
+	}
+	value, err := client.clampedIncr(ctx, key, []string{
+		"INCRBYFLOAT",
+		strconv.FormatFloat(amount, 'g', -1, 64),
+		strconv.FormatFloat(incrOptions.Min, 'f', -1, 64),
+		strconv.FormatFloat(incrOptions.Max, 'f', -1, 64),
+		strconv.FormatFloat(overflowBound, 'f', -1, 64),
+		"float",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// clampedIncr runs clampedIncrScript against key with args, and returns the value of key after the increment.
+func (client *baseClient) clampedIncr(ctx context.Context, key string, args []string) (string, error) {
+	result, err := client.InvokeScriptWithOptions(
+		ctx,
+		*clampedIncrScript(),
+		*options.NewScriptOptions().WithKeys([]string{key}).WithArgs(args),
+	)
+	if err != nil {
+		return "", err
+	}
+	value, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected reply to a clamped increment of %q: %v", key, result)
+	}
+	return value, nil
+}
+
+// overflowError returns an [OverflowError] for key if err matches [ErrOverflow], or err otherwise.
+func overflowError(key string, err error) error {
+	if err != nil && errors.Is(err, ErrOverflow) {
+		return &OverflowError{key: key, cause: err}
+	}
+	return err
+}
+
+// Increments the number stored at key by one, and sets its time to live to ttl on its first increment, atomically,
+// by a Lua script. The TTL isn't reset by the following increments, so that the key counts the increments of a fixed
+// window, e.g. the requests of a client to rate limit in the current minute. A key without a TTL, e.g. set without
+// one, gets ttl as well.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key to increment its value.
+//	ttl - The time to live of the key, with millisecond precision. It must be positive.
+//
+// Return value:
+//
+//	The value of `key` after the increment, 1 for the first increment of the window.
+//
+// Example usage:
+//
+//	requests, err := client.IncrWithTTL(ctx, "rate:"+clientID, time.Minute)
+//	if err == nil && requests > 100 {
+//		return errTooManyRequests
+//	}
+//
+// [valkey.io]: https://valkey.io/commands/incr/
+func (client *baseClient) IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	if ttl < time.Millisecond {
+		return 0, fmt.Errorf("the TTL must be at least 1ms, got %v", ttl)
+	}
+	result, err := client.InvokeScriptWithOptions(
+		ctx,
+		*incrWithTTLScript(),
+		*options.NewScriptOptions().WithKeys([]string{key}).WithArgs([]string{strconv.FormatInt(ttl.Milliseconds(), 10)}),
+	)
+	if err != nil {
+		return 0, overflowError(key, err)
+	}
+	value, ok := result.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected reply to the increment of %q: %v", key, result)
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,38 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+func TestOverflowError(t *testing.T) {
+	cause := NewRequestError("An error was signalled by the server: - ResponseError: increment or decrement would overflow")
+	err := overflowError("counter", cause)
+	var overflowErr *OverflowError
+	assert.ErrorAs(t, err, &overflowErr)
+	assert.Equal(t, "counter", overflowErr.Key())
+	assert.ErrorIs(t, err, ErrOverflow)
+	assert.ErrorIs(t, err, cause)
+
+	wrongType := NewRequestError("WRONGTYPE: Operation against a key holding the wrong kind of value")
+	assert.Same(t, wrongType, overflowError("counter", wrongType))
+	assert.NoError(t, overflowError("counter", nil))
+}
+
+func TestIncrOptions_Validate(t *testing.T) {
+	assert.NoError(t, options.NewIncrOptions().Validate())
+	assert.NoError(t, options.NewIncrOptions().SetClamp(0, 0).Validate())
+	assert.ErrorContains(t, options.NewIncrOptions().SetClamp(10, 0).Validate(), "must not exceed")
+
+	assert.NoError(t, options.NewIncrByFloatOptions().Validate())
+	assert.NoError(t, options.NewIncrByFloatOptions().SetClamp(-1.5, 1.5).Validate())
+	assert.ErrorContains(t, options.NewIncrByFloatOptions().SetClamp(1.5, -1.5).Validate(), "must not exceed")
+	assert.ErrorContains(t, options.NewIncrByFloatOptions().SetClamp(0, math.Inf(1)).Validate(), "finite")
+	assert.ErrorContains(t, options.NewIncrByFloatOptions().SetClamp(math.NaN(), 0).Validate(), "finite")
+}
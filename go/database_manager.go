@@ -0,0 +1,102 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// DatabaseManager maintains a client per logical database of a standalone server, for applications partitioning
+// their data across the databases selected with SELECT.
+//
+// The clients share the configuration the manager is created with, e.g. its addresses, TLS and credentials, and
+// only differ by their database. As each client is bound to its database, a client of the manager must not be used
+// to SELECT another database.
+//
+// Example usage:
+//
+//	manager, err := glide.NewDatabaseManager(clientConfig, 0, 3)
+//	if err != nil {
+//		return err
+//	}
+//	defer manager.Close()
+//	client, err := manager.DB(3)
+//	if err != nil {
+//		return err
+//	}
+//	value, err := client.Get(ctx, "key")
+type DatabaseManager struct {
+	config  *config.ClientConfiguration
+	mu      sync.Mutex
+	clients map[int]*Client
+}
+
+// NewDatabaseManager returns a [DatabaseManager] with a client connected to each of the given databases.
+//
+// Parameters:
+//
+//	clientConfig - The configuration of the clients. Its database, if any, is ignored. Pub/Sub subscriptions
+//	  aren't supported, as they don't depend on the database: use a separate client to subscribe.
+//	databases - The indexes of the databases to connect to. Clients for other databases can be added with Open.
+//
+// Return value:
+//
+//	The manager, or an error if a client couldn't be created. On error, the clients created so far are closed.
+func NewDatabaseManager(clientConfig *config.ClientConfiguration, databases ...int) (*DatabaseManager, error) {
+	if clientConfig.HasSubscription() {
+		return nil, errors.New("a database manager doesn't support Pub/Sub subscriptions")
+	}
+	manager := &DatabaseManager{config: clientConfig, clients: make(map[int]*Client)}
+	for _, database := range databases {
+		if _, err := manager.Open(database); err != nil {
+			manager.Close()
+			return nil, err
+		}
+	}
+	return manager, nil
+}
+
+// Open returns the client of a database, and creates it if the manager has none yet.
+func (manager *DatabaseManager) Open(database int) (*Client, error) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	if client, ok := manager.clients[database]; ok {
+		return client, nil
+	}
+	if database < 0 {
+		return nil, fmt.Errorf("invalid database index %d", database)
+	}
+	databaseConfig := *manager.config
+	client, err := NewClient(databaseConfig.WithDatabaseId(database))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client of database %d: %w", database, err)
+	}
+	manager.clients[database] = client
+	return client, nil
+}
+
+// DB returns the client of a database opened with [NewDatabaseManager] or [DatabaseManager.Open], or an error if the
+// database wasn't opened. Use Open to get the client of a database created on demand.
+func (manager *DatabaseManager) DB(database int) (*Client, error) {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	client, ok := manager.clients[database]
+	if !ok {
+		return nil, fmt.Errorf("database %d wasn't opened by the database manager", database)
+	}
+	return client, nil
+}
+
+// Close closes the clients of all the databases.
+func (manager *DatabaseManager) Close() {
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	for database, client := range manager.clients {
+		client.Close()
+		delete(manager.clients, database)
+	}
+}
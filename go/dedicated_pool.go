@@ -0,0 +1,640 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/pipeline"
+)
+
+const (
+	// DefaultDedicatedMaxIdle is the number of idle handles a [DedicatedPool] keeps, unless configured otherwise.
+	DefaultDedicatedMaxIdle = 4
+	// DefaultDedicatedWatchdogInterval is the interval between two checks of the handles in use by the watchdog of a
+	// [DedicatedPool], unless configured otherwise.
+	DefaultDedicatedWatchdogInterval = 10 * time.Second
+	// dedicatedResetTimeout bounds the RESET of a handle released with a connection state.
+	dedicatedResetTimeout = time.Second
+)
+
+// OrphanedDedicatedClient describes a handle of a [DedicatedPool] held without activity for longer than the
+// threshold of the watchdog of the pool, see [DedicatedPool.WithWatchdog].
+type OrphanedDedicatedClient struct {
+	// AcquiredAt is when the handle was acquired.
+	AcquiredAt time.Time
+	// LastActivity is when the handle last sent a request, or AcquiredAt if it sent none.
+	LastActivity time.Time
+	// Idle is how long the handle was held without activity when it was detected.
+	Idle time.Duration
+	// Stack is the stack trace of the acquisition of the handle, if the stacks are captured, see
+	// [DedicatedPool.WithAcquisitionStacks].
+	Stack string
+	// Reclaimed tells whether the handle was closed by the watchdog, see [DedicatedPool.WithReclaim].
+	Reclaimed bool
+}
+
+// DedicatedPool hands out [DedicatedClient]s: standalone clients whose connection isn't shared with the other
+// requests of the service, for the commands whose state belongs to the connection, e.g. WATCH, which would otherwise
+// apply to the requests of every goroutine sharing a [Client]. Released handles are kept idle, up to a limit, and
+// handed out again by later acquisitions, to spare the cost of connecting.
+//
+// With validation enabled, an idle handle is checked before being handed out: it's sent a PING, and its WATCH state
+// is cleared with UNWATCH. Handles failing the check are closed and replaced transparently. RESET isn't used, as it
+// also reverts the authentication, the database and the protocol the client set up when connecting.
+//
+// With a watchdog, the handles held without sending a request for longer than a threshold, e.g. by a borrower which
+// forgot to release its handle after a WATCH, are reported, with the stack trace of their acquisition if captured,
+// and optionally reclaimed, so that leaked handles don't accumulate connections.
+//
+// Example usage:
+//
+//	pool := glide.NewDedicatedPool(clientConfig).WithValidation(true)
+//	defer pool.Close()
+//
+//	handle, err := pool.Acquire(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer handle.Release()
+//	_, err = handle.Watch(ctx, []string{"balance"})
+type DedicatedPool struct {
+	clientConfig *config.ClientConfiguration
+	connect      func() (*Client, error)
+	// check validates an idle handle before it's handed out again.
+	check func(ctx context.Context, client *Client) error
+	// identify returns the identity of the connection of a new handle, or is nil to leave it unknown.
+	identify func(ctx context.Context, client *Client) (models.ConnectionIdentity, error)
+	maxIdle  int
+	validate bool
+	// idleThreshold is the inactivity after which a handle in use is orphaned, or 0 without a watchdog.
+	idleThreshold    time.Duration
+	watchdogInterval time.Duration
+	captureStacks    bool
+	reclaim          bool
+	onOrphan         func(OrphanedDedicatedClient)
+	now              func() time.Time
+
+	mu     sync.Mutex
+	idle   []*Client
+	inUse  map[*DedicatedClient]struct{}
+	closed bool
+	// identities are the identities of the connections of the handles, by client, until they're closed.
+	identities map[*Client]models.ConnectionIdentity
+	// stopWatchdog stops the watchdog, started by the first acquisition, or is nil if it isn't running.
+	stopWatchdog chan struct{}
+}
+
+// NewDedicatedPool returns a [DedicatedPool] connecting its handles with clientConfig.
+func NewDedicatedPool(clientConfig *config.ClientConfiguration) *DedicatedPool {
+	return &DedicatedPool{
+		clientConfig: clientConfig,
+		connect:      func() (*Client, error) { return NewClient(clientConfig) },
+		check:        validateDedicatedClient,
+		identify:     identifyDedicatedClient,
+		maxIdle:      DefaultDedicatedMaxIdle,
+	}
+}
+
+// WithWatchdog enables the watchdog, reporting the handles held without sending a request for longer than
+// idleThreshold, once each, to the callback set with WithOnOrphan. A handle waiting for the response of a request,
+// e.g. of a blocking command, isn't idle. The handles are checked every
+// DefaultDedicatedWatchdogInterval, unless configured otherwise with WithWatchdogInterval, from the first
+// acquisition until the pool is closed. Disabled by default.
+func (pool *DedicatedPool) WithWatchdog(idleThreshold time.Duration) *DedicatedPool {
+	pool.idleThreshold = idleThreshold
+	return pool
+}
+
+// WithWatchdogInterval sets the interval between two checks of the handles in use by the watchdog. Defaults to
+// DefaultDedicatedWatchdogInterval.
+func (pool *DedicatedPool) WithWatchdogInterval(interval time.Duration) *DedicatedPool {
+	pool.watchdogInterval = interval
+	return pool
+}
+
+// WithAcquisitionStacks sets whether the stack trace of each acquisition is captured, to report where the orphaned
+// handles were acquired. Capturing the stacks slows down the acquisitions. Disabled by default.
+func (pool *DedicatedPool) WithAcquisitionStacks(enabled bool) *DedicatedPool {
+	pool.captureStacks = enabled
+	return pool
+}
+
+// WithReclaim sets whether the watchdog reclaims the orphaned handles: they're closed, which drops the state left on
+// their connection, their requests fail with a [ClosingError], and releasing them is a no-op. Disabled by default.
+func (pool *DedicatedPool) WithReclaim(enabled bool) *DedicatedPool {
+	pool.reclaim = enabled
+	return pool
+}
+
+// WithOnOrphan sets the callback the watchdog reports the orphaned handles to. The orphaned handles are logged with
+// the standard logger by default.
+func (pool *DedicatedPool) WithOnOrphan(onOrphan func(OrphanedDedicatedClient)) *DedicatedPool {
+	pool.onOrphan = onOrphan
+	return pool
+}
+
+// logOrphanedDedicatedClient is the default callback of the watchdog of a [DedicatedPool].
+func logOrphanedDedicatedClient(orphan OrphanedDedicatedClient) {
+	action := "held"
+	if orphan.Reclaimed {
+		action = "reclaimed"
+	}
+	log.Printf("glide: dedicated client %s after %v without activity, acquired at %v\n%s",
+		action, orphan.Idle, orphan.AcquiredAt, orphan.Stack)
+}
+
+// WithMaxIdle sets the number of released handles kept idle, the others being closed. Defaults to
+// DefaultDedicatedMaxIdle.
+func (pool *DedicatedPool) WithMaxIdle(maxIdle int) *DedicatedPool {
+	pool.maxIdle = maxIdle
+	return pool
+}
+
+// WithValidation sets whether idle handles are validated before being handed out again. Disabled by default.
+func (pool *DedicatedPool) WithValidation(enabled bool) *DedicatedPool {
+	pool.validate = enabled
+	return pool
+}
+
+// validateDedicatedClient checks that client is connected, and clears its WATCH state.
+func validateDedicatedClient(ctx context.Context, client *Client) error {
+	if _, err := client.Ping(ctx); err != nil {
+		return err
+	}
+	_, err := client.Unwatch(ctx)
+	return err
+}
+
+// Acquire returns an idle handle of the pool, validated if validation is enabled, or a new handle if none is idle.
+// The handle must be released once it's not used anymore, see [DedicatedClient.Release].
+//
+// Return value:
+//
+//	The handle, or a [ClosingError] if the pool is closed, or the error of the connection of a new handle, or the
+//	error of ctx if it's done while validating idle handles.
+func (pool *DedicatedPool) Acquire(ctx context.Context) (*DedicatedClient, error) {
+	for {
+		pool.mu.Lock()
+		if pool.closed {
+			pool.mu.Unlock()
+			return nil, NewClosingError("the dedicated pool is closed")
+		}
+		var client *Client
+		if n := len(pool.idle); n > 0 {
+			client = pool.idle[n-1]
+			pool.idle = pool.idle[:n-1]
+		}
+		pool.mu.Unlock()
+
+		if client == nil {
+			created, err := pool.connect()
+			if err != nil {
+				return nil, err
+			}
+			pool.recordIdentity(ctx, created)
+			return pool.handOut(created), nil
+		}
+		if !pool.validate {
+			return pool.handOut(client), nil
+		}
+		err := pool.check(ctx, client)
+		if err == nil {
+			return pool.handOut(client), nil
+		}
+		client.Close()
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to validate an idle dedicated client: %w", ctx.Err())
+		}
+	}
+}
+
+// identifyDedicatedClient returns the identity of the connection of client, from CLIENT INFO.
+func identifyDedicatedClient(ctx context.Context, client *Client) (models.ConnectionIdentity, error) {
+	connections, err := client.Connections(ctx)
+	if err != nil {
+		return models.ConnectionIdentity{}, err
+	}
+	return connections[0], nil
+}
+
+// recordIdentity records the identity of the connection of client, a new handle, for Connections. The identity is
+// left unknown if it can't be read.
+func (pool *DedicatedPool) recordIdentity(ctx context.Context, client *Client) {
+	if pool.identify == nil {
+		return
+	}
+	identity, err := pool.identify(ctx, client)
+	if err != nil {
+		return
+	}
+	identity.Dedicated = true
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.identities == nil {
+		pool.identities = make(map[*Client]models.ConnectionIdentity)
+	}
+	pool.pruneIdentities()
+	pool.identities[client] = identity
+}
+
+// pruneIdentities forgets the identities of the closed handles. It must be called with pool.mu held.
+func (pool *DedicatedPool) pruneIdentities() {
+	for client := range pool.identities {
+		if client.isClosed() {
+			delete(pool.identities, client)
+		}
+	}
+}
+
+// Connections returns the identities of the connections of the handles of the pool, idle or in use, as read with
+// CLIENT INFO when they were opened, so that the dedicated connections listed by CLIENT LIST can be told from the
+// shared ones, and correlated with the handles of the process. It doesn't send any request, so that the handles
+// blocked in a command aren't delayed.
+//
+// Return value:
+//
+//	The identities of the connections, ordered by ID. The connections whose identity couldn't be read when they
+//	were opened are missing.
+func (pool *DedicatedPool) Connections() []models.ConnectionIdentity {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.pruneIdentities()
+	identities := make([]models.ConnectionIdentity, 0, len(pool.identities))
+	for _, identity := range pool.identities {
+		identities = append(identities, identity)
+	}
+	sort.Slice(identities, func(i, j int) bool { return identities[i].ID < identities[j].ID })
+	return identities
+}
+
+// handOut returns a handle of client, tracked by the watchdog if the pool has one.
+func (pool *DedicatedPool) handOut(client *Client) *DedicatedClient {
+	handle := &DedicatedClient{Client: client, pool: pool}
+	if pool.idleThreshold <= 0 {
+		return handle
+	}
+	now := pool.clock()
+	handle.acquiredAt = now
+	if pool.captureStacks {
+		handle.stack = string(debug.Stack())
+	}
+	if client.lastActivity == nil {
+		client.lastActivity = &atomic.Int64{}
+		client.activeRequests = &atomic.Int64{}
+	}
+	client.lastActivity.Store(now.UnixNano())
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.inUse == nil {
+		pool.inUse = make(map[*DedicatedClient]struct{})
+	}
+	pool.inUse[handle] = struct{}{}
+	if pool.stopWatchdog == nil && !pool.closed {
+		pool.stopWatchdog = make(chan struct{})
+		go pool.runWatchdog(pool.stopWatchdog)
+	}
+	return handle
+}
+
+func (pool *DedicatedPool) clock() time.Time {
+	if pool.now != nil {
+		return pool.now()
+	}
+	return time.Now()
+}
+
+func (pool *DedicatedPool) runWatchdog(stop <-chan struct{}) {
+	interval := pool.watchdogInterval
+	if interval <= 0 {
+		interval = DefaultDedicatedWatchdogInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			pool.CheckOrphans()
+		}
+	}
+}
+
+// CheckOrphans runs a check of the watchdog: the handles held without activity for longer than the threshold of the
+// watchdog, and not reported yet since their last activity, are reported, and reclaimed if enabled. The handles
+// waiting for the response of a request are skipped. It's a no-op without a watchdog.
+//
+// Return value:
+//
+//	The orphaned handles reported by the check.
+func (pool *DedicatedPool) CheckOrphans() []OrphanedDedicatedClient {
+	if pool.idleThreshold <= 0 {
+		return nil
+	}
+	now := pool.clock()
+	pool.mu.Lock()
+	var handles []*DedicatedClient
+	var orphans []OrphanedDedicatedClient
+	for handle := range pool.inUse {
+		if handle.Client.activeRequests.Load() > 0 {
+			continue
+		}
+		lastActivity := handle.Client.lastActivity.Load()
+		if now.Sub(time.Unix(0, lastActivity)) <= pool.idleThreshold || lastActivity == handle.reportedActivity {
+			continue
+		}
+		handle.reportedActivity = lastActivity
+		handles = append(handles, handle)
+		orphans = append(orphans, OrphanedDedicatedClient{
+			AcquiredAt:   handle.acquiredAt,
+			LastActivity: time.Unix(0, lastActivity),
+			Idle:         now.Sub(time.Unix(0, lastActivity)),
+			Stack:        handle.stack,
+		})
+	}
+	pool.mu.Unlock()
+
+	onOrphan := pool.onOrphan
+	if onOrphan == nil {
+		onOrphan = logOrphanedDedicatedClient
+	}
+	for i, handle := range handles {
+		orphans[i].Reclaimed = pool.reclaim && handle.reclaim()
+		onOrphan(orphans[i])
+	}
+	return orphans
+}
+
+// untrack stops tracking handle, released or reclaimed, with the watchdog.
+func (pool *DedicatedPool) untrack(handle *DedicatedClient) {
+	pool.mu.Lock()
+	delete(pool.inUse, handle)
+	pool.mu.Unlock()
+}
+
+// put returns client to the idle handles, or closes it if the pool is closed or has enough idle handles.
+func (pool *DedicatedPool) put(client *Client) {
+	pool.mu.Lock()
+	if !pool.closed && len(pool.idle) < pool.maxIdle && !client.isClosed() {
+		pool.idle = append(pool.idle, client)
+		pool.mu.Unlock()
+		return
+	}
+	pool.mu.Unlock()
+	client.Close()
+}
+
+// Close closes the idle handles of the pool, and stops its watchdog. The handles in use are closed once they're
+// released. Acquisitions fail with a [ClosingError] once the pool is closed.
+func (pool *DedicatedPool) Close() {
+	pool.mu.Lock()
+	pool.closed = true
+	idle := pool.idle
+	pool.idle = nil
+	if pool.stopWatchdog != nil {
+		close(pool.stopWatchdog)
+		pool.stopWatchdog = nil
+	}
+	pool.mu.Unlock()
+	for _, client := range idle {
+		client.Close()
+	}
+}
+
+// connectionState is the state a borrower may leave on the connection of a [DedicatedClient].
+type connectionState uint8
+
+const (
+	// stateWatch tells that keys may be watched.
+	stateWatch connectionState = 1 << iota
+	// stateMulti tells that a transaction may be in progress, started with a custom MULTI.
+	stateMulti
+	// stateSubscribed tells that the connection may be subscribed to channels or patterns.
+	stateSubscribed
+	// stateReset tells that the connection was reset, reverting the setup of the client.
+	stateReset
+)
+
+// DedicatedClient is a handle of a [DedicatedPool]: a [Client] whose connection is used by a single borrower at a
+// time. Closing the handle closes its connection rather than returning it to the pool.
+//
+// The handle tracks the state its borrower leaves on the connection: watched keys, a transaction started with a
+// custom MULTI, or subscriptions. A handle released with such a state is reset with RESET and closed, rather than
+// returned to the pool, so that the state doesn't leak to the next borrower.
+type DedicatedClient struct {
+	*Client
+	pool *DedicatedPool
+
+	// acquiredAt, stack and reportedActivity are set for the handles tracked by the watchdog of the pool:
+	// reportedActivity is the last activity of the handle when it was last reported as orphaned.
+	acquiredAt       time.Time
+	stack            string
+	reportedActivity int64
+
+	mu       sync.Mutex
+	state    connectionState
+	released bool
+}
+
+func (handle *DedicatedClient) setState(set connectionState, clear connectionState) {
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
+	handle.state = handle.state&^clear | set
+}
+
+// Release returns the handle to its pool, or resets and closes it if its borrower left a state on the connection.
+// The handle must not be used anymore once released. Releasing a handle more than once is a no-op.
+func (handle *DedicatedClient) Release() {
+	handle.mu.Lock()
+	if handle.released {
+		handle.mu.Unlock()
+		return
+	}
+	handle.released = true
+	state := handle.state
+	handle.mu.Unlock()
+	handle.pool.untrack(handle)
+
+	if state != 0 {
+		if state&stateReset == 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), dedicatedResetTimeout)
+			_, _ = handle.Client.Reset(ctx)
+			cancel()
+		}
+		handle.Client.Close()
+		return
+	}
+	handle.pool.put(handle.Client)
+}
+
+// reclaim closes the handle, unless it's released already, and reports whether it was closed.
+func (handle *DedicatedClient) reclaim() bool {
+	handle.mu.Lock()
+	if handle.released {
+		handle.mu.Unlock()
+		return false
+	}
+	handle.released = true
+	handle.mu.Unlock()
+	handle.pool.untrack(handle)
+	handle.Client.Close()
+	return true
+}
+
+// Watch marks the given keys to be watched for conditional execution of a transaction, see [Client.Watch]. The
+// handle is reset once released, unless the keys are unwatched, or a transaction is executed, before.
+func (handle *DedicatedClient) Watch(ctx context.Context, keys []string) (string, error) {
+	handle.setState(stateWatch, 0)
+	return handle.Client.Watch(ctx, keys)
+}
+
+// SnapshotRead watches keys and reads their values in a single round trip, pipelining WATCH and MGET, for the
+// check-and-set workflows: a transaction executed next with Exec is aborted, and Exec returns nil, if any of the keys
+// was modified since the snapshot. The handle is reset once released, unless the keys are unwatched, or a transaction
+// is executed, before.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx  - The context for controlling the command execution.
+//	keys - The keys to watch and read.
+//
+// Return value:
+//
+//	The values of keys, in order. For every key that doesn't exist, or doesn't hold a string, a
+//	[models.CreateNilStringResult()] is returned.
+//
+// [valkey.io]: https://valkey.io/commands/watch/
+func (handle *DedicatedClient) SnapshotRead(ctx context.Context, keys []string) ([]models.Result[string], error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no keys to snapshot")
+	}
+	handle.setState(stateWatch, 0)
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{Commands: []internal.Cmd{
+		internal.MakeCmd(uint32(protobuf.RequestType_Watch), keys, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_MGet), keys, identity),
+	}}
+	responses, err := handle.Client.executeBatch(ctx, batch, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseSnapshotRead(keys, responses)
+}
+
+// Unwatch flushes all the previously watched keys, see [Client.Unwatch].
+func (handle *DedicatedClient) Unwatch(ctx context.Context) (string, error) {
+	result, err := handle.Client.Unwatch(ctx)
+	if err == nil {
+		handle.setState(0, stateWatch)
+	}
+	return result, err
+}
+
+// Exec executes a batch, see [Client.Exec]. Executing a transaction unwatches all the keys.
+func (handle *DedicatedClient) Exec(ctx context.Context, batch pipeline.StandaloneBatch, raiseOnError bool) ([]any, error) {
+	result, err := handle.Client.Exec(ctx, batch, raiseOnError)
+	handle.execDone(batch, err)
+	return result, err
+}
+
+// ExecWithOptions executes a batch with options, see [Client.ExecWithOptions]. Executing a transaction unwatches all
+// the keys.
+func (handle *DedicatedClient) ExecWithOptions(
+	ctx context.Context,
+	batch pipeline.StandaloneBatch,
+	raiseOnError bool,
+	options pipeline.StandaloneBatchOptions,
+) ([]any, error) {
+	result, err := handle.Client.ExecWithOptions(ctx, batch, raiseOnError, options)
+	handle.execDone(batch, err)
+	return result, err
+}
+
+func (handle *DedicatedClient) execDone(batch pipeline.StandaloneBatch, err error) {
+	// The keys are unwatched once the server ran EXEC, even if the transaction was aborted.
+	if batch.IsAtomic && (err == nil || errors.Is(err, ErrExecAbort)) {
+		handle.setState(0, stateWatch)
+	}
+}
+
+// CustomCommand executes a single command, see [Client.CustomCommand]. The state left by MULTI, WATCH and the
+// subscription commands is tracked as for the dedicated methods.
+func (handle *DedicatedClient) CustomCommand(ctx context.Context, args []string) (any, error) {
+	if len(args) > 0 {
+		switch strings.ToUpper(args[0]) {
+		case "WATCH":
+			handle.setState(stateWatch, 0)
+		case "MULTI":
+			handle.setState(stateMulti, 0)
+		case "SUBSCRIBE", "PSUBSCRIBE", "SSUBSCRIBE":
+			handle.setState(stateSubscribed, 0)
+		case "RESET":
+			handle.setState(stateReset, 0)
+		}
+	}
+	result, err := handle.Client.CustomCommand(ctx, args)
+	if err == nil && len(args) > 0 {
+		switch strings.ToUpper(args[0]) {
+		case "UNWATCH":
+			handle.setState(0, stateWatch)
+		case "EXEC", "DISCARD":
+			handle.setState(0, stateWatch|stateMulti)
+		}
+	}
+	return result, err
+}
+
+// Subscribe subscribes the handle to channels, see [Client.Subscribe]. The handle is reset once released.
+func (handle *DedicatedClient) Subscribe(ctx context.Context, channels []string, timeoutMs int) error {
+	handle.setState(stateSubscribed, 0)
+	return handle.Client.Subscribe(ctx, channels, timeoutMs)
+}
+
+// SubscribeLazy subscribes the handle to channels, see [Client.SubscribeLazy]. The handle is reset once released.
+func (handle *DedicatedClient) SubscribeLazy(ctx context.Context, channels []string) error {
+	handle.setState(stateSubscribed, 0)
+	return handle.Client.SubscribeLazy(ctx, channels)
+}
+
+// PSubscribe subscribes the handle to patterns, see [Client.PSubscribe]. The handle is reset once released.
+func (handle *DedicatedClient) PSubscribe(ctx context.Context, patterns []string, timeoutMs int) error {
+	handle.setState(stateSubscribed, 0)
+	return handle.Client.PSubscribe(ctx, patterns, timeoutMs)
+}
+
+// PSubscribeLazy subscribes the handle to patterns, see [Client.PSubscribeLazy]. The handle is reset once released.
+func (handle *DedicatedClient) PSubscribeLazy(ctx context.Context, patterns []string) error {
+	handle.setState(stateSubscribed, 0)
+	return handle.Client.PSubscribeLazy(ctx, patterns)
+}
+
+// PSubscribeBlocking subscribes the handle to patterns, see [Client.PSubscribeBlocking]. The handle is reset once
+// released.
+func (handle *DedicatedClient) PSubscribeBlocking(ctx context.Context, patterns []string, timeoutMs int) error {
+	handle.setState(stateSubscribed, 0)
+	return handle.Client.PSubscribeBlocking(ctx, patterns, timeoutMs)
+}
+
+// Reset resets the state of the connection, see [Client.Reset]. The handle is closed once released, as RESET
+// reverts the setup of the connection.
+func (handle *DedicatedClient) Reset(ctx context.Context) (string, error) {
+	handle.setState(stateReset, 0)
+	return handle.Client.Reset(ctx)
+}
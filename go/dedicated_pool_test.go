@@ -0,0 +1,219 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func newTestDedicatedPool() (*DedicatedPool, *int) {
+	connects := 0
+	pool := &DedicatedPool{
+		connect: func() (*Client, error) {
+			connects++
+			return &Client{*newRuntimeConfigTestClient(nil)}, nil
+		},
+		check:   func(ctx context.Context, client *Client) error { return nil },
+		maxIdle: 1,
+	}
+	return pool, &connects
+}
+
+func TestDedicatedPool_ReusesReleasedHandles(t *testing.T) {
+	pool, connects := newTestDedicatedPool()
+
+	first, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	second, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, *connects)
+
+	first.Release()
+	first.Release()
+	second.Release()
+	// Only one handle is kept idle, the other one is closed.
+	assert.Len(t, pool.idle, 1)
+	assert.False(t, first.isClosed())
+	assert.True(t, second.isClosed())
+
+	third, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first.Client, third.Client)
+	assert.Equal(t, 2, *connects)
+
+	pool.Close()
+	_, err = pool.Acquire(context.Background())
+	assert.IsType(t, &ClosingError{}, err)
+	third.Release()
+	assert.True(t, third.isClosed())
+}
+
+func TestDedicatedPool_DiscardsBrokenHandles(t *testing.T) {
+	pool, connects := newTestDedicatedPool()
+	pool.WithValidation(true).WithMaxIdle(2)
+	broken, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	healthy, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	healthy.Release()
+	broken.Release()
+	pool.check = func(ctx context.Context, client *Client) error {
+		if client == broken.Client {
+			return errors.New("connection reset")
+		}
+		return nil
+	}
+
+	handle, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, healthy.Client, handle.Client)
+	assert.True(t, broken.isClosed())
+	assert.Equal(t, 2, *connects)
+}
+
+func TestDedicatedClient_TracksConnectionState(t *testing.T) {
+	pool, _ := newTestDedicatedPool()
+	handle, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = handle.Watch(cancelled, []string{"key"})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = handle.Unwatch(cancelled)
+	assert.Error(t, err)
+	assert.Equal(t, stateWatch, handle.state)
+
+	_, _ = handle.CustomCommand(cancelled, []string{"multi"})
+	_ = handle.SubscribeLazy(cancelled, []string{"channel"})
+	assert.Equal(t, stateWatch|stateMulti|stateSubscribed, handle.state)
+
+	// A handle released with a state isn't returned to the pool. It's already reset here, so it's only closed.
+	_, _ = handle.Reset(cancelled)
+	handle.Release()
+	assert.Empty(t, pool.idle)
+	assert.True(t, handle.isClosed())
+}
+
+func TestDedicatedPool_Watchdog(t *testing.T) {
+	pool, _ := newTestDedicatedPool()
+	var reported []OrphanedDedicatedClient
+	offset := time.Duration(0)
+	pool.now = func() time.Time { return time.Now().Add(offset) }
+	pool.WithWatchdog(time.Minute).
+		WithWatchdogInterval(time.Hour).
+		WithAcquisitionStacks(true).
+		WithOnOrphan(func(orphan OrphanedDedicatedClient) { reported = append(reported, orphan) })
+	defer pool.Close()
+
+	leaked, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	active, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	released, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	released.Release()
+
+	// The requests of a handle are its activity, even if they fail.
+	acquired := active.lastActivity.Load()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	time.Sleep(time.Millisecond)
+	_, _ = active.CustomCommand(cancelled, []string{"PING"})
+	assert.Greater(t, active.lastActivity.Load(), acquired)
+	active.lastActivity.Store(time.Now().Add(90 * time.Second).UnixNano())
+
+	offset = 2 * time.Minute
+	orphans := pool.CheckOrphans()
+	require.Len(t, orphans, 1)
+	assert.Equal(t, reported, orphans)
+	assert.Equal(t, leaked.acquiredAt, orphans[0].AcquiredAt)
+	assert.GreaterOrEqual(t, orphans[0].Idle, 2*time.Minute)
+	assert.Contains(t, orphans[0].Stack, "TestDedicatedPool_Watchdog")
+	assert.False(t, orphans[0].Reclaimed)
+	assert.False(t, leaked.isClosed())
+
+	// The orphaned handles are reported once, until they're active again.
+	assert.Empty(t, pool.CheckOrphans())
+	leaked.Release()
+	offset = 10 * time.Minute
+	assert.Len(t, pool.CheckOrphans(), 1)
+	assert.Len(t, reported, 2)
+
+	// A handle waiting for a response, e.g. of a blocking command, isn't orphaned.
+	blocked, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	done := blocked.touch()
+	offset = 20 * time.Minute
+	assert.Empty(t, pool.CheckOrphans())
+	done()
+	offset = 30 * time.Minute
+	assert.Len(t, pool.CheckOrphans(), 1)
+}
+
+func TestDedicatedPool_WatchdogReclaims(t *testing.T) {
+	pool, _ := newTestDedicatedPool()
+	offset := time.Duration(0)
+	pool.now = func() time.Time { return time.Now().Add(offset) }
+	pool.WithWatchdog(time.Minute).
+		WithWatchdogInterval(time.Hour).
+		WithReclaim(true).
+		WithOnOrphan(func(OrphanedDedicatedClient) {})
+	defer pool.Close()
+
+	leaked, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	offset = 2 * time.Minute
+	orphans := pool.CheckOrphans()
+	require.Len(t, orphans, 1)
+	assert.True(t, orphans[0].Reclaimed)
+	assert.Empty(t, orphans[0].Stack)
+	assert.True(t, leaked.isClosed())
+	assert.Empty(t, pool.inUse)
+
+	// Releasing a reclaimed handle doesn't return it to the pool.
+	leaked.Release()
+	assert.Empty(t, pool.idle)
+}
+
+func TestDedicatedPool_Connections(t *testing.T) {
+	pool, _ := newTestDedicatedPool()
+	nextID := int64(10)
+	pool.identify = func(ctx context.Context, client *Client) (models.ConnectionIdentity, error) {
+		nextID++
+		if nextID == 12 {
+			return models.ConnectionIdentity{}, errors.New("NOPERM")
+		}
+		return models.ConnectionIdentity{ID: nextID, Addr: "10.0.0.9:5000" + strconv.FormatInt(nextID, 10)}, nil
+	}
+
+	first, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	second, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+	third, err := pool.Acquire(context.Background())
+	require.NoError(t, err)
+
+	// The identity of the second handle couldn't be read.
+	assert.Equal(t, []models.ConnectionIdentity{
+		{ID: 11, Addr: "10.0.0.9:500011", Dedicated: true},
+		{ID: 13, Addr: "10.0.0.9:500013", Dedicated: true},
+	}, pool.Connections())
+
+	// The idle handles are listed until they're closed.
+	first.Release()
+	second.Release()
+	third.Release()
+	connections := pool.Connections()
+	require.Len(t, connections, 1)
+	assert.Equal(t, int64(11), connections[0].ID)
+}
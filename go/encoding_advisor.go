@@ -0,0 +1,241 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+)
+
+// DefaultEncodingLimitsRefreshInterval is how long an [EncodingAdvisor] uses the encoding limits read from the server
+// configuration, unless configured otherwise.
+const DefaultEncodingLimitsRefreshInterval = 5 * time.Minute
+
+// encodingLimitParameters are the configuration parameters bounding the compact encodings of hashes, sorted sets and
+// sets.
+var encodingLimitParameters = []string{
+	"hash-max-listpack-entries",
+	"hash-max-listpack-value",
+	"zset-max-listpack-entries",
+	"zset-max-listpack-value",
+	"set-max-intset-entries",
+	"set-max-listpack-entries",
+	"set-max-listpack-value",
+}
+
+// EncodingAdvice tells whether a write would convert a value from a compact encoding to a larger one, as returned by
+// the methods of [EncodingAdvisor].
+type EncodingAdvice struct {
+	// Encoding is the current encoding of the value, as returned by OBJECT ENCODING, or "" if the key doesn't exist.
+	Encoding string
+	// Target is the encoding of the value after the write, e.g. "listpack" or "hashtable".
+	Target string
+	// Converts tells whether the write would convert a value which is compactly encoded, or doesn't exist yet, to
+	// "hashtable" or "skiplist".
+	Converts bool
+	// Reason explains the conversion, e.g. "129 entries exceed hash-max-listpack-entries 128".
+	Reason string
+}
+
+// encodingAdvisorClient is implemented by [Client] and [ClusterClient].
+type encodingAdvisorClient interface {
+	interfaces.BaseClientCommands
+	ConfigGet(ctx context.Context, parameters []string) (map[string]string, error)
+}
+
+// EncodingAdvisor tells capacity-sensitive writers whether a write to a hash, a sorted set or a set would convert it
+// from its compact encoding, listpack or intset, to its large encoding, hashtable or skiplist, which takes several
+// times as much memory, e.g. to shard a value before it grows past the limits of its encoding.
+//
+// The advice is made from the current encoding and length of the value, read with OBJECT ENCODING and HLEN, ZCARD or
+// SCARD, and from the encoding limits of the server, e.g. hash-max-listpack-entries, read with CONFIG GET and cached.
+// The length after the write is estimated as the current length plus the number of written elements, as if none of
+// them existed: the advice errs on the side of a conversion. The value may also change between the advice and the
+// write.
+//
+// Example usage:
+//
+//	advisor := glide.NewEncodingAdvisor(client)
+//	advice, err := advisor.AdviseHSet(ctx, "user:1000", fields)
+//	if advice.Converts {
+//		// Write the fields to another shard of the hash.
+//	}
+type EncodingAdvisor struct {
+	client          encodingAdvisorClient
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu      sync.Mutex
+	limits  map[string]int64
+	fetched time.Time
+}
+
+// NewEncodingAdvisor returns an [EncodingAdvisor] for the values of client, a [Client] or a [ClusterClient]. In
+// clusters, the encoding limits are read from a random node, and thus assumed to be the same on all the nodes.
+func NewEncodingAdvisor(client encodingAdvisorClient) *EncodingAdvisor {
+	return &EncodingAdvisor{client: client, refreshInterval: DefaultEncodingLimitsRefreshInterval, now: time.Now}
+}
+
+// WithRefreshInterval sets how long the encoding limits read from the server configuration are used before they're
+// read again. Defaults to DefaultEncodingLimitsRefreshInterval.
+func (advisor *EncodingAdvisor) WithRefreshInterval(interval time.Duration) *EncodingAdvisor {
+	advisor.refreshInterval = interval
+	return advisor
+}
+
+// Refresh reads the encoding limits from the server configuration again, e.g. after a CONFIG SET.
+func (advisor *EncodingAdvisor) Refresh(ctx context.Context) error {
+	values, err := advisor.client.ConfigGet(ctx, encodingLimitParameters)
+	if err != nil {
+		return err
+	}
+	limits := make(map[string]int64, len(encodingLimitParameters))
+	for _, parameter := range encodingLimitParameters {
+		value, ok := values[parameter]
+		if !ok {
+			// The parameter doesn't exist on this server, e.g. set-max-listpack-entries before valkey 7.2, so that
+			// the encoding isn't used.
+			limits[parameter] = -1
+			continue
+		}
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value %q of %s: %w", value, parameter, err)
+		}
+		limits[parameter] = limit
+	}
+	advisor.mu.Lock()
+	advisor.limits, advisor.fetched = limits, advisor.now()
+	advisor.mu.Unlock()
+	return nil
+}
+
+// encodingLimits returns the cached encoding limits, read again if they're older than the refresh interval.
+func (advisor *EncodingAdvisor) encodingLimits(ctx context.Context) (map[string]int64, error) {
+	advisor.mu.Lock()
+	limits, fetched := advisor.limits, advisor.fetched
+	advisor.mu.Unlock()
+	if limits != nil && advisor.now().Sub(fetched) < advisor.refreshInterval {
+		return limits, nil
+	}
+	if err := advisor.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	advisor.mu.Lock()
+	defer advisor.mu.Unlock()
+	return advisor.limits, nil
+}
+
+// AdviseHSet tells whether setting values, field-value pairs, in the hash of key would convert it from listpack to
+// hashtable.
+func (advisor *EncodingAdvisor) AdviseHSet(ctx context.Context, key string, values map[string]string) (EncodingAdvice, error) {
+	elements := make([]string, 0, 2*len(values))
+	for field, value := range values {
+		elements = append(elements, field, value)
+	}
+	return advisor.advise(ctx, key, "hash", "hashtable", advisor.client.HLen, len(values), elements)
+}
+
+// AdviseZAdd tells whether adding membersScoreMap to the sorted set of key would convert it from listpack to
+// skiplist.
+func (advisor *EncodingAdvisor) AdviseZAdd(
+	ctx context.Context,
+	key string,
+	membersScoreMap map[string]float64,
+) (EncodingAdvice, error) {
+	members := make([]string, 0, len(membersScoreMap))
+	for member := range membersScoreMap {
+		members = append(members, member)
+	}
+	return advisor.advise(ctx, key, "zset", "skiplist", advisor.client.ZCard, len(members), members)
+}
+
+// AdviseSAdd tells whether adding members to the set of key would convert it from intset or listpack to hashtable.
+// Converting a set from intset to listpack, e.g. when adding a member which isn't an integer, isn't a conversion to
+// the large encoding.
+func (advisor *EncodingAdvisor) AdviseSAdd(ctx context.Context, key string, members []string) (EncodingAdvice, error) {
+	return advisor.advise(ctx, key, "set", "hashtable", advisor.client.SCard, len(members), members)
+}
+
+// advise returns the advice for writing added elements of values, fields and values or members, to the value of key
+// of valueType. large is the large encoding of valueType, and length returns the current length of the value.
+func (advisor *EncodingAdvisor) advise(
+	ctx context.Context,
+	key string,
+	valueType string,
+	large string,
+	length func(ctx context.Context, key string) (int64, error),
+	added int,
+	values []string,
+) (EncodingAdvice, error) {
+	limits, err := advisor.encodingLimits(ctx)
+	if err != nil {
+		return EncodingAdvice{}, err
+	}
+	encoding, err := advisor.client.ObjectEncoding(ctx, key)
+	if err != nil {
+		return EncodingAdvice{}, err
+	}
+	advice := EncodingAdvice{Encoding: encoding.Value()}
+	if advice.Encoding != "" && advice.Encoding != "listpack" && advice.Encoding != "intset" {
+		advice.Target = advice.Encoding
+		return advice, nil
+	}
+	entries := int64(added)
+	if advice.Encoding != "" {
+		current, err := length(ctx, key)
+		if err != nil {
+			return EncodingAdvice{}, err
+		}
+		entries += current
+	}
+	advice.Target, advice.Reason = targetEncoding(limits, valueType, advice.Encoding, entries, values)
+	if advice.Target == "" {
+		advice.Target = large
+	}
+	advice.Converts = advice.Target == large
+	return advice, nil
+}
+
+// targetEncoding returns the compact encoding of a value of valueType with entries elements after adding values, or
+// "" and the reason why the value takes the large encoding.
+func targetEncoding(
+	limits map[string]int64,
+	valueType string,
+	encoding string,
+	entries int64,
+	values []string,
+) (string, string) {
+	if valueType == "set" && (encoding == "" || encoding == "intset") && allIntegers(values) &&
+		entries <= limits["set-max-intset-entries"] {
+		return "intset", ""
+	}
+	entriesParameter, valueParameter := valueType+"-max-listpack-entries", valueType+"-max-listpack-value"
+	if limits[entriesParameter] < 0 {
+		return "", fmt.Sprintf("%s isn't supported by the server", entriesParameter)
+	}
+	if entries > limits[entriesParameter] {
+		return "", fmt.Sprintf("%d entries exceed %s %d", entries, entriesParameter, limits[entriesParameter])
+	}
+	for _, value := range values {
+		if int64(len(value)) > limits[valueParameter] {
+			return "", fmt.Sprintf("a value of %d bytes exceeds %s %d", len(value), valueParameter, limits[valueParameter])
+		}
+	}
+	return "listpack", ""
+}
+
+// allIntegers reports whether all of values are 64-bit integers, which sets encode as intset.
+func allIntegers(values []string) bool {
+	for _, value := range values {
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
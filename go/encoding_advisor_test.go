@@ -0,0 +1,167 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// fakeEncodingClient serves OBJECT ENCODING and lengths of values, and encoding limits.
+type fakeEncodingClient struct {
+	interfaces.BaseClientCommands
+	config    map[string]string
+	configGet int
+	encodings map[string]string
+	lengths   map[string]int64
+}
+
+func newFakeEncodingClient() *fakeEncodingClient {
+	return &fakeEncodingClient{
+		config: map[string]string{
+			"hash-max-listpack-entries": "4",
+			"hash-max-listpack-value":   "8",
+			"zset-max-listpack-entries": "4",
+			"zset-max-listpack-value":   "8",
+			"set-max-intset-entries":    "6",
+			"set-max-listpack-entries":  "4",
+			"set-max-listpack-value":    "8",
+		},
+		encodings: make(map[string]string),
+		lengths:   make(map[string]int64),
+	}
+}
+
+func (client *fakeEncodingClient) ConfigGet(ctx context.Context, parameters []string) (map[string]string, error) {
+	client.configGet++
+	values := make(map[string]string)
+	for _, parameter := range parameters {
+		if value, ok := client.config[parameter]; ok {
+			values[parameter] = value
+		}
+	}
+	return values, nil
+}
+
+func (client *fakeEncodingClient) ObjectEncoding(ctx context.Context, key string) (models.Result[string], error) {
+	if encoding, ok := client.encodings[key]; ok {
+		return models.CreateStringResult(encoding), nil
+	}
+	return models.CreateNilStringResult(), nil
+}
+
+func (client *fakeEncodingClient) length(ctx context.Context, key string) (int64, error) {
+	return client.lengths[key], nil
+}
+
+func (client *fakeEncodingClient) HLen(ctx context.Context, key string) (int64, error) {
+	return client.length(ctx, key)
+}
+
+func (client *fakeEncodingClient) ZCard(ctx context.Context, key string) (int64, error) {
+	return client.length(ctx, key)
+}
+
+func (client *fakeEncodingClient) SCard(ctx context.Context, key string) (int64, error) {
+	return client.length(ctx, key)
+}
+
+func TestEncodingAdvisor_Hash(t *testing.T) {
+	client := newFakeEncodingClient()
+	client.encodings["hash"], client.lengths["hash"] = "listpack", 3
+	client.encodings["large"] = "hashtable"
+	advisor := NewEncodingAdvisor(client)
+	ctx := context.Background()
+
+	advice, err := advisor.AdviseHSet(ctx, "hash", map[string]string{"a": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, EncodingAdvice{Encoding: "listpack", Target: "listpack"}, advice)
+
+	advice, err = advisor.AdviseHSet(ctx, "hash", map[string]string{"a": "1", "b": "2"})
+	require.NoError(t, err)
+	assert.Equal(t, EncodingAdvice{
+		Encoding: "listpack",
+		Target:   "hashtable",
+		Converts: true,
+		Reason:   "5 entries exceed hash-max-listpack-entries 4",
+	}, advice)
+
+	advice, err = advisor.AdviseHSet(ctx, "missing", map[string]string{"a": strings.Repeat("x", 9)})
+	require.NoError(t, err)
+	assert.True(t, advice.Converts)
+	assert.Equal(t, "", advice.Encoding)
+	assert.Equal(t, "a value of 9 bytes exceeds hash-max-listpack-value 8", advice.Reason)
+
+	advice, err = advisor.AdviseHSet(ctx, "large", map[string]string{"a": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, EncodingAdvice{Encoding: "hashtable", Target: "hashtable"}, advice)
+
+	assert.Equal(t, 1, client.configGet)
+}
+
+func TestEncodingAdvisor_SortedSet(t *testing.T) {
+	client := newFakeEncodingClient()
+	client.encodings["zset"], client.lengths["zset"] = "listpack", 4
+	advisor := NewEncodingAdvisor(client)
+
+	advice, err := advisor.AdviseZAdd(context.Background(), "zset", map[string]float64{"member": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "skiplist", advice.Target)
+	assert.True(t, advice.Converts)
+}
+
+func TestEncodingAdvisor_Set(t *testing.T) {
+	client := newFakeEncodingClient()
+	client.encodings["ints"], client.lengths["ints"] = "intset", 5
+	advisor := NewEncodingAdvisor(client)
+	ctx := context.Background()
+
+	advice, err := advisor.AdviseSAdd(ctx, "ints", []string{"6"})
+	require.NoError(t, err)
+	assert.Equal(t, EncodingAdvice{Encoding: "intset", Target: "intset"}, advice)
+
+	advice, err = advisor.AdviseSAdd(ctx, "ints", []string{"6", "7"})
+	require.NoError(t, err)
+	assert.True(t, advice.Converts)
+
+	advice, err = advisor.AdviseSAdd(ctx, "missing", []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, EncodingAdvice{Target: "listpack"}, advice)
+
+	// Before valkey 7.2, sets of strings are always encoded as hashtable.
+	delete(client.config, "set-max-listpack-entries")
+	require.NoError(t, advisor.Refresh(ctx))
+	advice, err = advisor.AdviseSAdd(ctx, "missing", []string{"a"})
+	require.NoError(t, err)
+	assert.True(t, advice.Converts)
+	assert.Equal(t, "set-max-listpack-entries isn't supported by the server", advice.Reason)
+}
+
+func TestEncodingAdvisor_RefreshInterval(t *testing.T) {
+	client := newFakeEncodingClient()
+	now := time.Now()
+	advisor := NewEncodingAdvisor(client).WithRefreshInterval(time.Minute)
+	advisor.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := advisor.AdviseHSet(ctx, "hash", map[string]string{"a": "1"})
+	require.NoError(t, err)
+	client.config["hash-max-listpack-entries"] = "0"
+	_, err = advisor.AdviseHSet(ctx, "hash", map[string]string{"a": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.configGet)
+
+	now = now.Add(time.Minute)
+	advice, err := advisor.AdviseHSet(ctx, "hash", map[string]string{"a": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.configGet)
+	assert.True(t, advice.Converts)
+}
@@ -0,0 +1,262 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+// ErrorMetadata describes the request which failed with an error returned by a client. See [ErrorDetails].
+type ErrorMetadata struct {
+	// Node is the address of the node the core sent the last attempt of the request to, or the node the request was
+	// routed to with [config.ByAddressRoute] if the core didn't report it, e.g. for batches and scripts, or when
+	// the request timed out. It's empty if it isn't known.
+	Node string
+	// Command is the name of the command in upper case, e.g. "SET", EVALSHA for scripts, and EXEC or PIPELINE for
+	// atomic and non-atomic batches.
+	Command string
+	// Attempts is the number of attempts of the request by the core, including its retries after redirections
+	// and reconnections. It's 0 when the core didn't report it: the request wasn't sent, it timed out, or it's a
+	// batch or a script.
+	Attempts int
+	// MayHaveExecuted is false when the request is known to have had no effect on the server: it wasn't sent, or
+	// it was rejected by the server. When true, the request, or a part of it, may have been executed even though
+	// it failed, e.g. if it timed out waiting for the response, or a script failed after writing keys.
+	MayHaveExecuted bool
+}
+
+// ErrorDetails returns the metadata of the request which failed with err, or false if err doesn't carry any, e.g.
+// if it's a context error, or an error of the arguments of a command detected before it was sent.
+//
+// Example usage:
+//
+//	_, err := client.Incr(ctx, "counter")
+//	if details, ok := glide.ErrorDetails(err); ok && !details.MayHaveExecuted {
+//		// The counter wasn't incremented: it's safe to retry.
+//	}
+func ErrorDetails(err error) (ErrorMetadata, bool) {
+	var carrier errorMetadataCarrier
+	if !errors.As(err, &carrier) || carrier.getErrorMetadata() == nil {
+		return ErrorMetadata{}, false
+	}
+	return *carrier.getErrorMetadata(), true
+}
+
+// errorMetadataCarrier is implemented by the errors holding the metadata of the request which failed.
+type errorMetadataCarrier interface {
+	getErrorMetadata() *ErrorMetadata
+	setErrorMetadata(metadata ErrorMetadata)
+}
+
+// errorMetadataHolder implements errorMetadataCarrier for the error types embedding it.
+type errorMetadataHolder struct {
+	metadata *ErrorMetadata
+}
+
+func (holder *errorMetadataHolder) getErrorMetadata() *ErrorMetadata { return holder.metadata }
+
+func (holder *errorMetadataHolder) setErrorMetadata(metadata ErrorMetadata) {
+	holder.metadata = &metadata
+}
+
+// requestDescription describes a request for the metadata of its errors.
+type requestDescription struct {
+	node    string
+	command string
+	// key is the first key of the request, or "" if it has none or isn't known, e.g. for batches.
+	key string
+	// partial is set for requests which may be executed in part before failing: scripts, functions and batches.
+	partial bool
+	// attempts is the number of attempts of the request reported by the core, or 0 if it isn't known.
+	attempts int
+}
+
+// executedAs returns the description of request as executed by the core.
+func (request requestDescription) executedAs(execution commandExecution) requestDescription {
+	if execution.node != "" {
+		request.node = execution.node
+	}
+	request.attempts = execution.attempts
+	return request
+}
+
+// commandExecution is how the core executed a command, as reported to a C.CommandExecution.
+type commandExecution struct {
+	node     string
+	attempts int
+}
+
+// newCommandExecution allocates the C.CommandExecution the core reports the execution of a command to. It must be
+// freed with takeCommandExecution or freeCommandExecution once the result of the command is delivered, or right
+// away if the command is cancelled.
+func newCommandExecution() *C.CommandExecution {
+	return (*C.CommandExecution)(C.calloc(1, C.sizeof_CommandExecution))
+}
+
+// takeCommandExecution returns the execution reported to execution, and frees it.
+func takeCommandExecution(execution *C.CommandExecution) commandExecution {
+	result := commandExecution{attempts: int(execution.attempts)}
+	if execution.node != nil {
+		result.node = C.GoString(execution.node)
+	}
+	freeCommandExecution(execution)
+	return result
+}
+
+// freeCommandExecution frees execution, if not nil.
+func freeCommandExecution(execution *C.CommandExecution) {
+	if execution == nil {
+		return
+	}
+	C.free_c_string(execution.node)
+	C.free(unsafe.Pointer(execution))
+}
+
+func describeCommand(requestType C.RequestType, args []string, route config.Route) requestDescription {
+	command := strings.ToUpper(protobuf.RequestType_name[int32(requestType)])
+	if requestType == C.CustomCommand && len(args) > 0 {
+		command = strings.ToUpper(args[0])
+		args = args[1:]
+	}
+	var partial bool
+	switch command {
+	case "EVAL", "EVALREADONLY", "EVAL_RO", "EVALSHA", "EVALSHAREADONLY", "EVALSHA_RO", "FCALL", "FCALLREADONLY",
+		"FCALL_RO":
+		partial = true
+	}
+	return requestDescription{node: routeNode(route), command: command, key: commandKey(command, args), partial: partial}
+}
+
+func describeScript(keys []string, route config.Route) requestDescription {
+	var key string
+	if len(keys) > 0 {
+		key = keys[0]
+	}
+	return requestDescription{node: routeNode(route), command: "EVALSHA", key: key, partial: true}
+}
+
+func describeBatch(isAtomic bool, options *internal.BatchOptions) requestDescription {
+	command := "PIPELINE"
+	if isAtomic {
+		command = "EXEC"
+	}
+	var route config.Route
+	if options != nil {
+		route = options.Route
+	}
+	return requestDescription{node: routeNode(route), command: command, partial: true}
+}
+
+// routeNode returns the address of the node a route targets, or "" if the route doesn't target a given node.
+func routeNode(route config.Route) string {
+	if byAddress, ok := route.(*config.ByAddressRoute); ok {
+		return fmt.Sprintf("%s:%d", byAddress.Host, byAddress.Port)
+	}
+	return ""
+}
+
+// withErrorMetadata attaches the metadata of the failed request to err, if it's an error carrying metadata, and
+// returns err. sent tells whether the request was submitted to the server before failing.
+func withErrorMetadata(err error, request requestDescription, sent bool) error {
+	var carrier errorMetadataCarrier
+	if errors.As(err, &carrier) {
+		carrier.setErrorMetadata(ErrorMetadata{
+			Node:            request.node,
+			Command:         request.command,
+			Attempts:        request.attempts,
+			MayHaveExecuted: sent && mayHaveExecuted(err, request.partial),
+		})
+	}
+	return err
+}
+
+// mayHaveExecuted reports whether a request sent to the server which failed with err may have been executed.
+func mayHaveExecuted(err error, partial bool) bool {
+	var requestErr *RequestError
+	switch {
+	case errors.Is(err, ErrExecAbort):
+		// The transaction was discarded.
+		return false
+	case errors.As(err, &requestErr):
+		// Commands failing on the server have no effect, unless the request is made of several commands.
+		return partial
+	default:
+		// The request may have been executed before its response was lost, e.g. on timeouts and disconnections.
+		return true
+	}
+}
+
+// retryableErrorCodes are the codes of the server errors reporting a transient state of the server or the cluster.
+var retryableErrorCodes = map[string]bool{
+	"TRYAGAIN":    true,
+	"LOADING":     true,
+	"BUSY":        true,
+	"CLUSTERDOWN": true,
+	"MASTERDOWN":  true,
+	"MOVED":       true,
+	"ASK":         true,
+	"READONLY":    true,
+}
+
+// IsRetryable reports whether the request which failed with err may succeed if retried as is, because it failed on
+// a transient condition: a timeout, a lost connection, or a server error such as TRYAGAIN, LOADING or CLUSTERDOWN.
+//
+// Errors of the request itself, e.g. WRONGTYPE or NOSCRIPT errors, aren't retryable, nor are errors of a closed
+// client or of a done context. IsRetryable doesn't tell whether retrying is safe: a request which timed out may
+// have been executed, see [MayHaveExecuted].
+//
+// Example usage:
+//
+//	result, err := client.Get(ctx, key)
+//	for attempt := 1; glide.IsRetryable(err) && attempt < maxAttempts; attempt++ {
+//		result, err = client.Get(ctx, key)
+//	}
+func IsRetryable(err error) bool {
+	var timeoutErr *TimeoutError
+	var disconnectErr *DisconnectError
+	var requestErr *RequestError
+	switch {
+	case err == nil:
+		return false
+	case errors.As(err, &timeoutErr), errors.As(err, &disconnectErr):
+		return true
+	case errors.As(err, &requestErr):
+		return retryableErrorCodes[requestErr.Code()]
+	default:
+		return false
+	}
+}
+
+// MayHaveExecuted reports whether the request which failed with err may have been executed by the server, in full
+// or in part. When false, the request is known to have had no effect, and can be retried whether it's idempotent
+// or not.
+//
+// The metadata of err is used if it has some, see [ErrorDetails]. Otherwise, errors of the server and errors
+// detected before the request is sent, such as [BatchError], are reported as not executed, while requests which
+// failed because their context is done, and errors unknown to the client, are conservatively reported as having
+// possibly executed.
+func MayHaveExecuted(err error) bool {
+	if err == nil {
+		return false
+	}
+	if details, ok := ErrorDetails(err); ok {
+		return details.MayHaveExecuted
+	}
+	var batchErr *BatchError
+	var configurationErr *ConfigurationError
+	var closingErr *ClosingError
+	if errors.As(err, &batchErr) || errors.As(err, &configurationErr) || errors.As(err, &closingErr) {
+		return false
+	}
+	return mayHaveExecuted(err, false)
+}
@@ -0,0 +1,123 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+)
+
+func TestErrorDetails(t *testing.T) {
+	request := requestDescription{node: "10.0.0.1:6379", command: "SET"}
+	err := fmt.Errorf("wrapped: %w", withErrorMetadata(NewTimeoutError("timed out"), request, true))
+
+	details, ok := ErrorDetails(err)
+	assert.True(t, ok)
+	assert.Equal(t, ErrorMetadata{Node: "10.0.0.1:6379", Command: "SET", MayHaveExecuted: true}, details)
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestErrorDetails_ExecutedAs(t *testing.T) {
+	request := requestDescription{command: "GET"}.executedAs(commandExecution{node: "10.0.0.2:6379", attempts: 2})
+	details, ok := ErrorDetails(withErrorMetadata(NewRequestError("MOVED 1234 10.0.0.3:6379"), request, true))
+	assert.True(t, ok)
+	assert.Equal(t, ErrorMetadata{Node: "10.0.0.2:6379", Command: "GET", Attempts: 2}, details)
+
+	// The route is kept when the core doesn't report the node.
+	request = requestDescription{node: "10.0.0.1:6379", command: "GET"}.executedAs(commandExecution{})
+	assert.Equal(t, requestDescription{node: "10.0.0.1:6379", command: "GET"}, request)
+}
+
+func TestErrorDetails_NoMetadata(t *testing.T) {
+	_, ok := ErrorDetails(context.DeadlineExceeded)
+	assert.False(t, ok)
+	_, ok = ErrorDetails(NewTimeoutError("timed out"))
+	assert.False(t, ok)
+	_, ok = ErrorDetails(withErrorMetadata(context.Canceled, requestDescription{command: "GET"}, true))
+	assert.False(t, ok)
+	_, ok = ErrorDetails(nil)
+	assert.False(t, ok)
+}
+
+func TestErrorDetails_MayHaveExecuted(t *testing.T) {
+	command := requestDescription{command: "INCR"}
+	script := describeScript(nil, nil)
+	tests := []struct {
+		name     string
+		err      error
+		request  requestDescription
+		sent     bool
+		expected bool
+	}{
+		{"not sent", NewClosingError("closed"), command, false, false},
+		{"closed while pending", NewClosingError("closed"), command, true, true},
+		{"timeout", NewTimeoutError("timed out"), command, true, true},
+		{"disconnect", NewDisconnectError("disconnected"), command, true, true},
+		{"rejected", NewRequestError("WRONGTYPE: Operation against a key holding the wrong kind of value"), command, true, false},
+		{"script error", NewRequestError("ERR user_script:1: Script attempted to access nonexistent global"), script, true, true},
+		{"transaction discarded", NewExecAbortError("EXECABORT: Transaction discarded"), describeBatch(true, nil), true, false},
+		{"pipeline error", NewRequestError("WRONGTYPE: Operation against a key holding the wrong kind of value"), describeBatch(false, nil), true, true},
+	}
+	for _, test := range tests {
+		details, ok := ErrorDetails(withErrorMetadata(test.err, test.request, test.sent))
+		assert.True(t, ok, test.name)
+		assert.Equal(t, test.expected, details.MayHaveExecuted, test.name)
+	}
+}
+
+func TestErrorDetails_Node(t *testing.T) {
+	assert.Equal(t, "10.0.0.1:6380", describeScript(nil, config.NewByAddressRoute("10.0.0.1", 6380)).node)
+	assert.Equal(t, "", describeScript(nil, config.AllPrimaries).node)
+	batch := describeBatch(false, &internal.BatchOptions{Route: config.NewByAddressRoute("10.0.0.2", 6379)})
+	assert.Equal(t, "10.0.0.2:6379", batch.node)
+	assert.Equal(t, "PIPELINE", batch.command)
+	assert.Equal(t, "EXEC", describeBatch(true, nil).command)
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{NewTimeoutError("timed out"), true},
+		{fmt.Errorf("wrapped: %w", NewDisconnectError("disconnected")), true},
+		{NewRequestError("TRYAGAIN: Multiple keys request during rehashing of slot"), true},
+		{NewRequestError("An error was signalled by the server: - BusyLoadingError: Valkey is loading"), true},
+		{NewRequestError("An error was signalled by the server: - ClusterDown: The cluster is down"), true},
+		{NewRequestError("WRONGTYPE: Operation against a key holding the wrong kind of value"), false},
+		{NewRequestError("An error was signalled by the server: - NoScriptError: No matching script."), false},
+		{NewExecAbortError("EXECABORT: Transaction discarded"), false},
+		{NewClosingError("closed"), false},
+		{context.DeadlineExceeded, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsRetryable(test.err), "%v", test.err)
+	}
+}
+
+func TestMayHaveExecuted(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{withErrorMetadata(NewTimeoutError("timed out"), requestDescription{command: "INCR"}, false), false},
+		{withErrorMetadata(NewTimeoutError("timed out"), requestDescription{command: "INCR"}, true), true},
+		{NewRequestError("WRONGTYPE: Operation against a key holding the wrong kind of value"), false},
+		{NewBatchError([]error{fmt.Errorf("invalid argument")}), false},
+		{NewClosingError("closed"), false},
+		{NewTimeoutError("timed out"), true},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("unexpected response"), true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, MayHaveExecuted(test.err), "%v", test.err)
+	}
+}
@@ -9,6 +9,50 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// Sentinel errors matched by the errors returned by the clients, so the errors can be told apart with [errors.Is]
+// instead of their message. The error types, e.g. [RequestError] or [TimeoutError], can be retrieved with
+// [errors.As] for further details.
+var (
+	// ErrWrongType matches the WRONGTYPE errors of commands run against a key holding another type of value.
+	ErrWrongType = errors.New("WRONGTYPE")
+	// ErrOOM matches the OOM errors of commands rejected because the server reached its memory limit.
+	ErrOOM = errors.New("OOM")
+	// ErrNoScript matches the NOSCRIPT errors of EVALSHA and FCALL when the script or function isn't loaded.
+	ErrNoScript = errors.New("NOSCRIPT")
+	// ErrReadOnly matches the READONLY errors of write commands sent to a replica.
+	ErrReadOnly = errors.New("READONLY")
+	// ErrMoved matches the MOVED redirections of cluster nodes not serving the slot of a key.
+	ErrMoved = errors.New("MOVED")
+	// ErrAsk matches the ASK redirections of cluster nodes migrating the slot of a key.
+	ErrAsk = errors.New("ASK")
+	// ErrExecAbort matches the errors of transactions aborted by the server, as [ExecAbortError].
+	ErrExecAbort = errors.New("EXECABORT")
+	// ErrTimeout matches the errors of requests which timed out, as [TimeoutError].
+	ErrTimeout = errors.New("timeout")
+	// ErrConnectionClosed matches the errors of requests which failed because the connection to the server was
+	// lost, as [DisconnectError], or because the client is closed, as [ClosingError].
+	ErrConnectionClosed = errors.New("connection closed")
+	// ErrAuth matches the authentication failures, e.g. WRONGPASS or NOAUTH errors.
+	ErrAuth = errors.New("authentication failed")
+	// ErrCrossSlot matches the CROSSSLOT errors of requests whose keys don't map to the same cluster slot, as
+	// [CrossSlotError] for the transactions rejected before being sent.
+	ErrCrossSlot = errors.New("CROSSSLOT")
+	// ErrIndexNotFound matches the errors of the search commands run against an index which doesn't exist.
+	ErrIndexNotFound = errors.New("index not found")
+	// ErrAliasExists matches the errors of FT.ALIASADD when the alias already exists.
+	ErrAliasExists = errors.New("alias already exists")
+	// ErrAliasNotFound matches the errors of the search commands run against an alias which doesn't exist.
+	ErrAliasNotFound = errors.New("alias not found")
+	// ErrUnsupported matches the errors of commands rejected before being sent because the server doesn't support
+	// them, as [UnsupportedError].
+	ErrUnsupported = errors.New("unsupported by the server")
+	// ErrOverflow matches the errors of increments and decrements which would overflow an integer value, or make a
+	// float value NaN or infinite, as [OverflowError] for the checked increments, e.g. `IncrByWithOptions`.
+	ErrOverflow = errors.New("overflow")
 )
 
 // ConnectionError is a client error that occurs when there is an error while connecting or when a connection
@@ -23,9 +67,15 @@ func NewConnectionError(message string) *ConnectionError {
 
 func (e *ConnectionError) Error() string { return e.msg }
 
+// Is reports whether the connection failed to authenticate, for target ErrAuth.
+func (e *ConnectionError) Is(target error) bool {
+	return target == ErrAuth && isAuthErrorMessage(e.msg)
+}
+
 // ExecAbortError is a client error that occurs when a transaction is aborted.
 type ExecAbortError struct {
 	msg string
+	errorMetadataHolder
 }
 
 func NewExecAbortError(message string) *ExecAbortError {
@@ -34,9 +84,12 @@ func NewExecAbortError(message string) *ExecAbortError {
 
 func (e *ExecAbortError) Error() string { return e.msg }
 
+func (e *ExecAbortError) Is(target error) bool { return target == ErrExecAbort }
+
 // TimeoutError is a client error that occurs when a request times out.
 type TimeoutError struct {
 	msg string
+	errorMetadataHolder
 }
 
 func NewTimeoutError(message string) *TimeoutError {
@@ -45,9 +98,12 @@ func NewTimeoutError(message string) *TimeoutError {
 
 func (e *TimeoutError) Error() string { return e.msg }
 
+func (e *TimeoutError) Is(target error) bool { return target == ErrTimeout }
+
 // DisconnectError is a client error that indicates a connection problem between Glide and server.
 type DisconnectError struct {
 	msg string
+	errorMetadataHolder
 }
 
 func NewDisconnectError(message string) *DisconnectError {
@@ -56,9 +112,12 @@ func NewDisconnectError(message string) *DisconnectError {
 
 func (e *DisconnectError) Error() string { return e.msg }
 
+func (e *DisconnectError) Is(target error) bool { return target == ErrConnectionClosed }
+
 // ClosingError is a client error that indicates that the client has closed and is no longer usable.
 type ClosingError struct {
 	msg string
+	errorMetadataHolder
 }
 
 func NewClosingError(message string) *ClosingError {
@@ -67,6 +126,8 @@ func NewClosingError(message string) *ClosingError {
 
 func (e *ClosingError) Error() string { return e.msg }
 
+func (e *ClosingError) Is(target error) bool { return target == ErrConnectionClosed }
+
 // ConfigurationError is a client error that occurs when there is an issue with client configuration.
 type ConfigurationError struct {
 	msg string
@@ -78,6 +139,193 @@ func NewConfigurationError(message string) *ConfigurationError {
 
 func (e *ConfigurationError) Error() string { return e.msg }
 
+// CrossSlotError is a client error that occurs when the keys of a transaction executed by a cluster client don't map
+// to the same slot. The transaction is rejected before being sent, as the server would reject it.
+type CrossSlotError struct {
+	slot int
+	keys []string
+}
+
+func (e *CrossSlotError) Error() string {
+	offending := make([]string, len(e.keys))
+	for i, key := range e.keys {
+		offending[i] = fmt.Sprintf("%q (slot %d)", key, keySlot(key))
+	}
+	return fmt.Sprintf("CROSSSLOT: the keys of a transaction must map to the same slot, but %s don't map to slot %d",
+		strings.Join(offending, ", "), e.slot)
+}
+
+// Slot returns the slot of the first key of the transaction.
+func (e *CrossSlotError) Slot() int { return e.slot }
+
+// Keys returns the keys of the transaction which don't map to the slot of its first key.
+func (e *CrossSlotError) Keys() []string { return e.keys }
+
+func (e *CrossSlotError) Is(target error) bool { return target == ErrCrossSlot }
+
+// UnsupportedError is a client error that occurs when a command requires a version of the server or a module which
+// the server of the client doesn't have, according to its capabilities detected by the clients created with
+// WithCapabilityDetection. The command is rejected before being sent, rather than failing with an unknown command
+// error of the server.
+type UnsupportedError struct {
+	command  string
+	version  string
+	module   string
+	detected models.Capabilities
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.module != "" {
+		return fmt.Sprintf("%s requires the %s module, which the server didn't load", e.command, e.module)
+	}
+	return fmt.Sprintf("%s requires Valkey %s, but the server is %s %s",
+		e.command, e.version, e.detected.ServerName, e.detected.Version)
+}
+
+// Command returns the name of the rejected command, e.g. "HSETEX".
+func (e *UnsupportedError) Command() string { return e.command }
+
+// RequiredVersion returns the version of the server required by the command, or "" if it requires a module.
+func (e *UnsupportedError) RequiredVersion() string { return e.version }
+
+// RequiredModule returns the name of the module required by the command, or "" if it requires a version.
+func (e *UnsupportedError) RequiredModule() string { return e.module }
+
+// Capabilities returns the detected capabilities of the server which rejected the command.
+func (e *UnsupportedError) Capabilities() models.Capabilities { return e.detected }
+
+func (e *UnsupportedError) Is(target error) bool { return target == ErrUnsupported }
+
+// RequestError is an error returned by the server for a request, e.g. a WRONGTYPE error. It's returned by the
+// commands, and held by the results of batches executed without raising errors.
+type RequestError struct {
+	msg  string
+	code string
+	errorMetadataHolder
+}
+
+func NewRequestError(message string) *RequestError {
+	return &RequestError{msg: message, code: errorCode(message)}
+}
+
+func (e *RequestError) Error() string { return e.msg }
+
+// Code returns the error code sent by the server, e.g. "WRONGTYPE" or "ERR", or "" if the error has none.
+func (e *RequestError) Code() string { return e.code }
+
+// Is reports whether the error has the code of target, for the sentinel errors of server error codes.
+func (e *RequestError) Is(target error) bool {
+	switch target {
+	case ErrAuth:
+		return e.code == "NOAUTH" || e.code == "WRONGPASS" || isAuthErrorMessage(e.msg)
+	case ErrWrongType, ErrOOM, ErrNoScript, ErrReadOnly, ErrMoved, ErrAsk, ErrExecAbort, ErrCrossSlot:
+		return e.code == target.Error()
+	case ErrIndexNotFound, ErrAliasExists, ErrAliasNotFound:
+		return searchErrorOf(e.msg) == target
+	case ErrOverflow:
+		return isOverflowMessage(e.msg)
+	default:
+		return false
+	}
+}
+
+// serverErrorPrefix starts the messages of the errors the core recognizes, followed by the kind of the error, e.g.
+// "An error was signalled by the server: - NoScriptError: No matching script.". Other server errors are reported
+// with their code, e.g. "WRONGTYPE: Operation against a key holding the wrong kind of value".
+const serverErrorPrefix = "An error was signalled by the server:"
+
+// errorCodesByKind maps the kinds of errors recognized by the core to their server error codes.
+var errorCodesByKind = map[string]string{
+	"ResponseError":    "ERR",
+	"ExecAbortError":   "EXECABORT",
+	"BusyLoadingError": "LOADING",
+	"NoScriptError":    "NOSCRIPT",
+	"Moved":            "MOVED",
+	"Ask":              "ASK",
+	"TryAgain":         "TRYAGAIN",
+	"ClusterDown":      "CLUSTERDOWN",
+	"CrossSlot":        "CROSSSLOT",
+	"MasterDown":       "MASTERDOWN",
+	"ReadOnly":         "READONLY",
+	"NotBusy":          "NOTBUSY",
+	"PermissionDenied": "NOPERM",
+}
+
+// errorCode returns the server error code of an error message, or "" if it has none.
+func errorCode(message string) string {
+	if rest, ok := strings.CutPrefix(message, serverErrorPrefix); ok {
+		kind, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(rest), "- "), ":")
+		return errorCodesByKind[kind]
+	}
+	code := message
+	if end := strings.IndexAny(message, ": "); end >= 0 {
+		code = message[:end]
+	}
+	if code == "" {
+		return ""
+	}
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && c != '_' {
+			return ""
+		}
+	}
+	return code
+}
+
+// OverflowError is the error of a checked increment or decrement of a key, e.g. `IncrByWithOptions`, which would
+// overflow its integer value, or make its float value NaN or infinite. The value of the key is left unchanged.
+type OverflowError struct {
+	key   string
+	cause error
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("the increment of %q would overflow: %v", e.key, e.cause)
+}
+
+// Key returns the key whose increment would overflow.
+func (e *OverflowError) Key() string { return e.key }
+
+// Unwrap returns the error sent by the server.
+func (e *OverflowError) Unwrap() error { return e.cause }
+
+func (e *OverflowError) Is(target error) bool { return target == ErrOverflow }
+
+// isOverflowMessage reports whether an error message reports an increment which would overflow, e.g. "ERR increment
+// or decrement would overflow" or "ERR increment would produce NaN or Infinity".
+func isOverflowMessage(message string) bool {
+	return strings.Contains(message, "would overflow") || strings.Contains(message, "NaN or Infinity")
+}
+
+// isUnknownCommandError reports whether err reports that the server doesn't support a command.
+func isUnknownCommandError(err error) bool {
+	var requestErr *RequestError
+	return errors.As(err, &requestErr) && strings.Contains(strings.ToLower(requestErr.msg), "unknown command")
+}
+
+// isAuthErrorMessage reports whether an error message reports an authentication failure.
+func isAuthErrorMessage(message string) bool {
+	return strings.Contains(message, "WRONGPASS") || strings.Contains(message, "NOAUTH") ||
+		strings.Contains(message, "AuthenticationFailed")
+}
+
+// searchErrorOf returns the sentinel error matching the message of an error of the search module, or nil. The
+// messages of the module have no error codes, e.g. "Unknown index name" or "Alias already exists".
+func searchErrorOf(message string) error {
+	message = strings.ToLower(message)
+	notFound := strings.Contains(message, "not found") || strings.Contains(message, "does not exist") ||
+		strings.Contains(message, "doesn't exist") || strings.Contains(message, "unknown")
+	switch {
+	case strings.Contains(message, "alias") && strings.Contains(message, "already exists"):
+		return ErrAliasExists
+	case strings.Contains(message, "alias") && notFound:
+		return ErrAliasNotFound
+	case strings.Contains(message, "index") && (notFound || strings.Contains(message, "no such index")):
+		return ErrIndexNotFound
+	}
+	return nil
+}
+
 type BatchError struct {
 	errors []error
 }
@@ -102,13 +350,13 @@ func IsError(val any) error {
 func GoError(cErrorType uint32, errorMessage string) error {
 	switch cErrorType {
 	case C.ExecAbort:
-		return &ExecAbortError{errorMessage}
+		return &ExecAbortError{msg: errorMessage}
 	case C.Timeout:
-		return &TimeoutError{errorMessage}
+		return &TimeoutError{msg: errorMessage}
 	case C.Disconnect:
-		return &DisconnectError{errorMessage}
+		return &DisconnectError{msg: errorMessage}
 	default:
-		return errors.New(errorMessage)
+		return NewRequestError(errorMessage)
 	}
 }
 
@@ -0,0 +1,83 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestRequestError_Code(t *testing.T) {
+	tests := map[string]string{
+		"WRONGTYPE: Operation against a key holding the wrong kind of value":                  "WRONGTYPE",
+		"OOM: command not allowed when used memory > 'maxmemory'.":                            "OOM",
+		"An error was signalled by the server: - NoScriptError: No matching script.":          "NOSCRIPT",
+		"An error was signalled by the server: - ReadOnly: You can't write against a replica": "READONLY",
+		"An error was signalled by the server: - Moved: 3999 127.0.0.1:6381":                  "MOVED",
+		"MOVED 3999 127.0.0.1:6381":               "MOVED",
+		"Received connection error `broken pipe`": "",
+		"": "",
+	}
+	for message, code := range tests {
+		assert.Equal(t, code, NewRequestError(message).Code(), message)
+	}
+}
+
+func TestRequestError_Is(t *testing.T) {
+	sentinels := []error{
+		ErrWrongType, ErrOOM, ErrNoScript, ErrReadOnly, ErrMoved, ErrAsk, ErrExecAbort, ErrAuth,
+		ErrCrossSlot, ErrIndexNotFound, ErrAliasExists, ErrAliasNotFound, ErrOverflow,
+	}
+	tests := map[string]error{
+		"WRONGTYPE: Operation against a key holding the wrong kind of value":                             ErrWrongType,
+		"OOM: command not allowed when used memory > 'maxmemory'.":                                       ErrOOM,
+		"An error was signalled by the server: - NoScriptError: No matching script.":                     ErrNoScript,
+		"An error was signalled by the server: - ReadOnly: You can't write against a replica":            ErrReadOnly,
+		"An error was signalled by the server: - Moved: 3999 127.0.0.1:6381":                             ErrMoved,
+		"An error was signalled by the server: - Ask: 3999 127.0.0.1:6381":                               ErrAsk,
+		"An error was signalled by the server: - ExecAbortError: Transaction discarded":                  ErrExecAbort,
+		"WRONGPASS: invalid username-password pair or user is disabled.":                                 ErrAuth,
+		"NOAUTH: Authentication required.":                                                               ErrAuth,
+		"An error was signalled by the server: - CrossSlot: Keys in request don't hash to the same slot": ErrCrossSlot,
+		"An error was signalled by the server: - ResponseError: Unknown index name":                      ErrIndexNotFound,
+		"An error was signalled by the server: - ResponseError: Index with name 'idx' not found":         ErrIndexNotFound,
+		"An error was signalled by the server: - ResponseError: Alias already exists":                    ErrAliasExists,
+		"An error was signalled by the server: - ResponseError: Alias does not exist":                    ErrAliasNotFound,
+		"An error was signalled by the server: - ResponseError: increment or decrement would overflow":   ErrOverflow,
+		"ERR increment would produce NaN or Infinity":                                                    ErrOverflow,
+		"ERR value is not an integer or out of range":                                                    nil,
+	}
+	for message, expected := range tests {
+		err := fmt.Errorf("wrapped: %w", NewRequestError(message))
+		for _, sentinel := range sentinels {
+			assert.Equal(t, sentinel == expected, errors.Is(err, sentinel), "%s is %v", message, sentinel)
+		}
+	}
+}
+
+func TestGoError_MatchesSentinels(t *testing.T) {
+	assert.ErrorIs(t, GoError(uint32(protobuf.RequestErrorType_Timeout), "timed out"), ErrTimeout)
+	assert.ErrorIs(t, GoError(uint32(protobuf.RequestErrorType_ExecAbort), "aborted"), ErrExecAbort)
+	assert.ErrorIs(t, GoError(uint32(protobuf.RequestErrorType_Disconnect), "disconnected"), ErrConnectionClosed)
+	assert.ErrorIs(t, NewClosingError("closed"), ErrConnectionClosed)
+	assert.ErrorIs(t, NewConnectionError("WRONGPASS: invalid username-password pair"), ErrAuth)
+	assert.NotErrorIs(t, NewConnectionError("connection refused"), ErrAuth)
+
+	err := GoError(uint32(protobuf.RequestErrorType_Unspecified), "WRONGTYPE: Operation against a key holding the wrong kind of value")
+	var requestErr *RequestError
+	assert.ErrorAs(t, err, &requestErr)
+	assert.Equal(t, "WRONGTYPE", requestErr.Code())
+	assert.ErrorIs(t, err, ErrWrongType)
+	assert.NotErrorIs(t, err, ErrTimeout)
+}
+
+func TestIsUnknownCommandError(t *testing.T) {
+	assert.True(t, isUnknownCommandError(NewRequestError("ERR unknown command 'GETDEL', with args beginning with: 'key'")))
+	assert.False(t, isUnknownCommandError(NewRequestError("WRONGTYPE Operation against a key holding the wrong kind of value")))
+	assert.False(t, isUnknownCommandError(NewTimeoutError("unknown command")))
+}
@@ -0,0 +1,103 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ExecutionMetadata describes the requests a call submitted to a client, see [WithExecutionMetadata].
+type ExecutionMetadata struct {
+	// RoutedNode is the address of the node the core sent the last attempt of the last request of the call to. For
+	// the requests the core doesn't report it for, e.g. batches and scripts, or requests which timed out, it's the
+	// node the request was routed to with [config.ByAddressRoute], or empty.
+	RoutedNode string
+	// Command is the name of the command of the last request in upper case, e.g. "SET", EVALSHA for scripts, and EXEC
+	// or PIPELINE for atomic and non-atomic batches.
+	Command string
+	// Requests is the number of requests the call submitted, e.g. 2 for a command the call retried once. The retries
+	// of the core aren't counted, see Attempts.
+	Requests int
+	// Attempts is the number of attempts of the last request by the core, including its retries after redirections
+	// and reconnections. It's 0 when the core doesn't report it: for batches and scripts, and requests which timed out.
+	Attempts int
+	// Duration is the time the call took, from its start until it returned.
+	Duration time.Duration
+	// Protocol is the protocol of the connections of the client, e.g. "RESP3".
+	Protocol string
+}
+
+// executionRecorderKey is the context key of the executionRecorder of a call.
+type executionRecorderKey struct{}
+
+// executionRecorder collects the metadata of the requests of a call.
+type executionRecorder struct {
+	mu       sync.Mutex
+	metadata ExecutionMetadata
+}
+
+// WithExecutionMetadata runs call with a context recording how its requests are executed, and returns the result of
+// call along with the metadata of its requests, to debug a given request without enabling verbose logging for all of
+// them. The metadata is recorded for the requests of any client using the context passed to call.
+//
+// Example usage:
+//
+//	value, metadata, err := glide.WithExecutionMetadata(ctx, func(ctx context.Context) (models.Result[string], error) {
+//		return client.Get(ctx, "user:1000")
+//	})
+//	log.Printf("GET took %v over %s in %d requests", metadata.Duration, metadata.Protocol, metadata.Requests)
+//
+// Parameters:
+//
+//	ctx - The context for controlling the execution of call.
+//	call - The function sending the requests, with the context to send them with.
+//
+// Return value:
+//
+//	The result and the error of call, and the metadata of its requests. The metadata describes the last request
+//	submitted by call, if call submitted several requests.
+func WithExecutionMetadata[T any](
+	ctx context.Context,
+	call func(ctx context.Context) (T, error),
+) (T, ExecutionMetadata, error) {
+	recorder := &executionRecorder{}
+	start := time.Now()
+	result, err := call(context.WithValue(ctx, executionRecorderKey{}, recorder))
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	metadata := recorder.metadata
+	metadata.Duration = time.Since(start)
+	return result, metadata, err
+}
+
+// recordExecution records the submission of request to the recorder of ctx, if there is one.
+func (client *baseClient) recordExecution(ctx context.Context, request requestDescription) {
+	recorder, ok := ctx.Value(executionRecorderKey{}).(*executionRecorder)
+	if !ok {
+		return
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.metadata.RoutedNode = request.node
+	recorder.metadata.Command = request.command
+	recorder.metadata.Requests++
+	recorder.metadata.Attempts = 0
+	recorder.metadata.Protocol = client.protocol
+}
+
+// recordCommandExecution records how the core executed the last request submitted with ctx to the recorder of ctx,
+// if there is one.
+func recordCommandExecution(ctx context.Context, execution commandExecution) {
+	recorder, ok := ctx.Value(executionRecorderKey{}).(*executionRecorder)
+	if !ok {
+		return
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if execution.node != "" {
+		recorder.metadata.RoutedNode = execution.node
+	}
+	recorder.metadata.Attempts = execution.attempts
+}
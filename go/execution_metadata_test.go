@@ -0,0 +1,60 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+func TestWithExecutionMetadata(t *testing.T) {
+	client := &baseClient{protocol: "RESP3"}
+	route := config.NewByAddressRoute("10.0.0.2", 6379)
+
+	value, metadata, err := WithExecutionMetadata(context.Background(), func(ctx context.Context) (string, error) {
+		client.recordExecution(ctx, requestDescription{command: "GETDEL"})
+		recordCommandExecution(ctx, commandExecution{node: "10.0.0.1:6379", attempts: 2})
+		client.recordExecution(ctx, describeBatch(true, nil))
+		client.recordExecution(ctx, requestDescription{node: routeNode(route), command: "GET"})
+		time.Sleep(time.Millisecond)
+		return "value", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, "10.0.0.2:6379", metadata.RoutedNode)
+	assert.Equal(t, "GET", metadata.Command)
+	assert.Equal(t, 3, metadata.Requests)
+	assert.Zero(t, metadata.Attempts)
+	assert.Equal(t, "RESP3", metadata.Protocol)
+	assert.GreaterOrEqual(t, metadata.Duration, time.Millisecond)
+
+	// Requests sent outside of the call aren't recorded.
+	client.recordExecution(context.Background(), requestDescription{command: "SET"})
+	failure := errors.New("failed")
+	_, metadata, err = WithExecutionMetadata(context.Background(), func(ctx context.Context) (int64, error) {
+		return 0, failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Zero(t, metadata.Requests)
+	assert.Empty(t, metadata.Command)
+}
+
+func TestWithExecutionMetadata_CommandExecution(t *testing.T) {
+	client := &baseClient{protocol: "RESP2"}
+
+	_, metadata, _ := WithExecutionMetadata(context.Background(), func(ctx context.Context) (string, error) {
+		client.recordExecution(ctx, requestDescription{command: "GET"})
+		recordCommandExecution(ctx, commandExecution{node: "10.0.0.3:6379", attempts: 2})
+		return "", nil
+	})
+	assert.Equal(t, "10.0.0.3:6379", metadata.RoutedNode)
+	assert.Equal(t, "GET", metadata.Command)
+	assert.Equal(t, 1, metadata.Requests)
+	assert.Equal(t, 2, metadata.Attempts)
+}
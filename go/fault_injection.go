@@ -0,0 +1,80 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// faultInjector injects the faults of a [config.FaultInjectionConfiguration] into the requests of a client.
+type faultInjector struct {
+	latency                  time.Duration
+	latencyPercentage        float64
+	timeoutPercentage        float64
+	movedPercentage          float64
+	connectionDropPercentage float64
+	// roll returns a pseudo-random percentage in [0, 100).
+	roll func() float64
+}
+
+func newFaultInjector(faultConfig *config.FaultInjectionConfiguration) *faultInjector {
+	latency, latencyPercentage := faultConfig.GetLatency()
+	return &faultInjector{
+		latency:                  latency,
+		latencyPercentage:        latencyPercentage,
+		timeoutPercentage:        faultConfig.GetTimeoutPercentage(),
+		movedPercentage:          faultConfig.GetMovedErrorPercentage(),
+		connectionDropPercentage: faultConfig.GetConnectionDropPercentage(),
+		roll:                     func() float64 { return rand.Float64() * 100 },
+	}
+}
+
+// inject delays the request and returns the error it must fail with, if any. A nil error means the request must be
+// sent as usual. The injected timeouts fail once timeout, the request timeout of the client, elapses, unless ctx is
+// done first.
+func (f *faultInjector) inject(ctx context.Context, timeout time.Duration) error {
+	if f.latency > 0 && f.roll() < f.latencyPercentage {
+		if err := sleepContext(ctx, f.latency); err != nil {
+			return err
+		}
+	}
+
+	roll := f.roll()
+	switch {
+	case roll < f.timeoutPercentage:
+		if err := sleepContext(ctx, timeout); err != nil {
+			return err
+		}
+		return NewTimeoutError("Injected fault: request timed out")
+	case roll < f.timeoutPercentage+f.movedPercentage:
+		return NewRequestError("MOVED 0 127.0.0.1:6379 (injected fault)")
+	case roll < f.timeoutPercentage+f.movedPercentage+f.connectionDropPercentage:
+		return NewDisconnectError("Injected fault: connection dropped")
+	default:
+		return nil
+	}
+}
+
+// sleepContext waits for duration, or returns the error of ctx if it's done first.
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return contextErr(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
+
+// injectFault applies the fault injection of the client, if enabled, to a request executed with ctx.
+func (client *baseClient) injectFault(ctx context.Context) error {
+	if client.faultInjector == nil {
+		return nil
+	}
+	return client.faultInjector.inject(ctx, client.coreTimeout)
+}
@@ -0,0 +1,78 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// fixedRolls returns a roll function returning the given values in turn.
+func fixedRolls(values ...float64) func() float64 {
+	return func() float64 {
+		value := values[0]
+		values = values[1:]
+		return value
+	}
+}
+
+func TestFaultInjector_Errors(t *testing.T) {
+	injector := newFaultInjector(config.NewFaultInjectionConfiguration().
+		WithTimeouts(10).
+		WithMovedErrors(20).
+		WithConnectionDrops(30))
+
+	injector.roll = fixedRolls(5)
+	assert.IsType(t, &TimeoutError{}, injector.inject(context.Background(), 0))
+
+	injector.roll = fixedRolls(15)
+	assert.ErrorContains(t, injector.inject(context.Background(), 0), "MOVED")
+
+	injector.roll = fixedRolls(45)
+	assert.IsType(t, &DisconnectError{}, injector.inject(context.Background(), 0))
+
+	injector.roll = fixedRolls(60)
+	assert.NoError(t, injector.inject(context.Background(), 0))
+}
+
+func TestFaultInjector_Latency(t *testing.T) {
+	injector := newFaultInjector(config.NewFaultInjectionConfiguration().WithLatency(20*time.Millisecond, 50))
+
+	injector.roll = fixedRolls(10, 99)
+	start := time.Now()
+	assert.NoError(t, injector.inject(context.Background(), 0))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// The delay is interrupted when the context is done
+	injector.roll = fixedRolls(10, 99)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, injector.inject(ctx, 0), context.DeadlineExceeded)
+
+	// Requests outside the latency percentage aren't delayed
+	injector.roll = fixedRolls(60, 99)
+	start = time.Now()
+	assert.NoError(t, injector.inject(context.Background(), 0))
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestFaultInjector_Timeout(t *testing.T) {
+	injector := newFaultInjector(config.NewFaultInjectionConfiguration().WithTimeouts(100))
+
+	// The injected timeouts wait for the request timeout.
+	injector.roll = fixedRolls(5)
+	start := time.Now()
+	assert.IsType(t, &TimeoutError{}, injector.inject(context.Background(), 20*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// Or for the deadline of the context, if sooner.
+	injector.roll = fixedRolls(5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, injector.inject(ctx, time.Minute), context.DeadlineExceeded)
+}
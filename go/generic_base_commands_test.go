@@ -545,6 +545,66 @@ func ExampleClusterClient_Unlink() {
 	// 2
 }
 
+func ExampleClient_UnlinkMulti() {
+	var client *Client = getExampleClient() // example helper function
+	client.MSet(context.Background(), map[string]string{"key1": "someValue", "key2": "someValue", "key3": "someValue"})
+	result, err := client.UnlinkMulti(
+		context.Background(),
+		[]string{"key1", "key2", "key3", "key4"},
+		*options.NewUnlinkMultiOptions().SetChunkSize(2).SetKeysPerSecond(1000),
+	)
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(result)
+
+	// Output: [2 1]
+}
+
+func ExampleClusterClient_UnlinkMulti() {
+	var client *ClusterClient = getExampleClusterClient() // example helper function
+	client.MSet(context.Background(), map[string]string{"{key}1": "someValue", "{key}2": "someValue", "{key}3": "someValue"})
+	result, err := client.UnlinkMulti(
+		context.Background(),
+		[]string{"{key}1", "{key}2", "{key}3", "{key}4"},
+		*options.NewUnlinkMultiOptions().SetChunkSize(2).SetKeysPerSecond(1000),
+	)
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(result)
+
+	// Output: [2 1]
+}
+
+func ExampleClient_SampleKeys() {
+	var client *Client = getExampleClient() // example helper function
+	client.Set(context.Background(), "key1", "someValue")
+	result, err := client.SampleKeys(context.Background(), 10, *options.NewSampleKeysOptions())
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(result)
+
+	// Output: [key1]
+}
+
+func ExampleClusterClient_SampleKeys() {
+	var client *ClusterClient = getExampleClusterClient() // example helper function
+	client.Set(context.Background(), "key1", "someValue")
+	result, err := client.SampleKeys(
+		context.Background(),
+		10,
+		*options.NewSampleKeysOptions().SetMethod(options.SampleWithScan).SetMatch("key*"),
+	)
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(result)
+
+	// Output: [key1]
+}
+
 func ExampleClient_Touch() {
 	var client *Client = getExampleClient() // example helper function
 	result, err := client.Set(context.Background(), "key1", "someValue")
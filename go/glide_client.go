@@ -7,6 +7,7 @@ import "C"
 
 import (
 	"context"
+	"errors"
 
 	"github.com/valkey-io/valkey-glide/go/v2/config"
 
@@ -61,10 +62,20 @@ func NewClient(config *config.ClientConfiguration) (*Client, error) {
 	}
 	if config.HasSubscription() {
 		subConfig := config.GetSubscription()
-		client.setMessageHandler(NewMessageHandler(subConfig.GetCallback(), subConfig.GetContext()))
+		handler := NewMessageHandler(subConfig.GetCallback(), subConfig.GetContext())
+		handler.setDispatchMode(subConfig.GetDispatchMode(), subConfig.GetWorkerPoolSize())
+		handler.setMaxBufferedMessages(subConfig.GetMaxBufferedMessages())
+		handler.keyspaceFilters = subConfig.GetKeyspaceEventFilters()
+		client.setMessageHandler(handler)
 	} else {
 		client.setMessageHandler(NewMessageHandler(nil, nil))
 	}
+	if isolation := config.GetSubscriberIsolationConfiguration(); isolation != nil {
+		if err := client.isolateSubscriber(config.SubscriberConfiguration(), isolation); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
 
 	return &Client{*client}, nil
 }
@@ -158,6 +169,31 @@ func (client *Client) CustomCommand(ctx context.Context, args []string) (any, er
 	return handleInterfaceResponse(res)
 }
 
+// CustomCommandWithAttributes executes a single command, specified by args, as [Client.CustomCommand] does,
+// and returns the returned value together with the RESP3 attributes the server attached to it, if any.
+//
+// Attributes are only sent on RESP3 connections. Typed commands, and CustomCommand, drop the attributes of the
+// values they return.
+//
+// The value keeps the RESP3 types which CustomCommand converts to strings: verbatim strings, e.g. the reply of
+// LOLWUT, are returned as [models.VerbatimString] with their format.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	args - Arguments for the custom command including the command name.
+//
+// Return value:
+//
+//	The returned value for the custom command and its attributes.
+func (client *Client) CustomCommandWithAttributes(ctx context.Context, args []string) (models.AttributedValue, error) {
+	res, err := client.executeCommand(ctx, C.CustomCommand, args)
+	if err != nil {
+		return models.AttributedValue{}, err
+	}
+	return handleAttributedResponse(res)
+}
+
 // Sets configuration parameters to the specified values.
 //
 // Note:
@@ -243,6 +279,9 @@ func (client *Client) Select(ctx context.Context, index int64) (string, error) {
 	if err != nil {
 		return models.DefaultStringResponse, err
 	}
+	if client.database != nil {
+		client.database.Store(index)
+	}
 
 	return handleOkResponse(result)
 }
@@ -531,6 +570,91 @@ func (client *Client) ClientId(ctx context.Context) (int64, error) {
 	return handleIntResponse(result)
 }
 
+// Gets information about the current connection, as returned by CLIENT LIST.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	A map of the properties of the connection to their value, e.g. "id", "addr" or "name".
+//
+// [valkey.io]: https://valkey.io/commands/client-info/
+func (client *Client) ClientInfo(ctx context.Context) (map[string]string, error) {
+	result, err := client.executeCommand(ctx, C.ClientInfo, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return handleClientInfoResponse(result)
+}
+
+// Lists the connections KillClients would kill with filter, without killing them, e.g. to review them first.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	filter - The criteria of the connections.
+//
+// Return value:
+//
+//	The connections matching filter, as listed by CLIENT LIST.
+//
+// [valkey.io]: https://valkey.io/commands/client-list/
+func (client *Client) PreviewKillClients(
+	ctx context.Context,
+	filter options.ClientKillFilter,
+) ([]models.ClientConnection, error) {
+	return previewKillClients(ctx, client, filter)
+}
+
+// Kills the connections matching filter, e.g. to shed the connections of a misbehaving service during an incident.
+// The connections are listed with CLIENT LIST, matched against filter, and killed one by one with CLIENT KILL.
+//
+// The connections of this library are only killed if filter confirms it with SetIncludeLibraryConnections, and the
+// connection issuing the kills is never killed. Use PreviewKillClients to review the connections first.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	filter - The criteria of the connections to kill.
+//
+// Return value:
+//
+//	The number of connections killed, which may be lower than the number of connections previewed if some were
+//	closed meanwhile.
+//
+// [valkey.io]: https://valkey.io/commands/client-kill/
+func (client *Client) KillClients(ctx context.Context, filter options.ClientKillFilter) (int64, error) {
+	return killClients(ctx, client, filter)
+}
+
+func (client *Client) listClients(ctx context.Context, args []string) (map[string]string, error) {
+	result, err := client.executeCommand(ctx, C.ClientList, args)
+	if err != nil {
+		return nil, err
+	}
+	list, err := handleStringResponse(result)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"": list}, nil
+}
+
+func (client *Client) killClient(ctx context.Context, _ string, id int64) (int64, error) {
+	result, err := client.executeCommand(ctx, C.ClientKill, []string{"ID", utils.IntToString(id), "SKIPME", "yes"})
+	if err != nil {
+		return models.DefaultIntResponse, err
+	}
+	return handleIntResponse(result)
+}
+
 // Returns UNIX TIME of the last DB save timestamp or startup timestamp if no save was made since then.
 //
 // See [valkey.io] for details.
@@ -617,6 +741,33 @@ func (client *Client) ClientSetName(ctx context.Context, connectionName string)
 	return handleOkResponse(result)
 }
 
+// Resets the state of the connection: it discards the transaction in progress, unwatches all the keys, unsubscribes
+// from all the channels and patterns, and reverts the database, the name, the protocol and the authentication of the
+// connection to their defaults.
+//
+// As the client set up the database, the name, the protocol and the authentication of its connection when
+// connecting, the client shouldn't be used for other commands after RESET: use it to clean up a connection before
+// closing it, e.g. a [DedicatedClient].
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	"RESET" - when the connection is reset.
+//
+// [valkey.io]: https://valkey.io/commands/reset/
+func (client *Client) Reset(ctx context.Context) (string, error) {
+	result, err := client.executeCommand(ctx, C.Reset, []string{})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleStringResponse(result)
+}
+
 // Iterates incrementally over a database for matching keys.
 //
 // See [valkey.io] for details.
@@ -695,6 +846,42 @@ func (client *Client) ConfigRewrite(ctx context.Context) (string, error) {
 	return handleOkResponse(response)
 }
 
+// Shuts the server down, e.g. to restart it from a runbook, or aborts an ongoing shutdown with
+// [options.ShutdownOptions.SetAbort]. The server closes the connection instead of replying once it's shutting down,
+// which isn't reported as an error. The client reconnects once the server is restarted.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	shutdownOptions - The SHUTDOWN options, e.g. whether the server saves the dataset before shutting down.
+//
+// Return value:
+//
+//	nil once the server is shutting down or the shutdown was aborted, otherwise the error preventing the server from
+//	shutting down, e.g. a failure to save the dataset.
+//
+// [valkey.io]: https://valkey.io/commands/shutdown/
+func (client *Client) Shutdown(ctx context.Context, shutdownOptions options.ShutdownOptions) error {
+	args, err := shutdownOptions.ToArgs()
+	if err != nil {
+		return err
+	}
+	_, err = client.executeCommand(ctx, C.CustomCommand, append([]string{"SHUTDOWN"}, args...))
+	return handleShutdownError(err)
+}
+
+// handleShutdownError returns the error of SHUTDOWN, or nil if the server closed the connection because it's shutting
+// down. The other connection errors, e.g. of a client closed locally, are returned: the server may still be running.
+func handleShutdownError(err error) error {
+	var disconnectErr *DisconnectError
+	if errors.As(err, &disconnectErr) {
+		return nil
+	}
+	return err
+}
+
 // Returns a random existing key name from the currently selected database.
 //
 // See [valkey.io] for details.
@@ -962,3 +1149,106 @@ func (client *Client) Unwatch(ctx context.Context) (string, error) {
 	}
 	return handleOkResponse(result)
 }
+
+// ModuleList returns the modules loaded by the server.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The name, version, path and arguments of each module loaded by the server.
+//
+// [valkey.io]: https://valkey.io/commands/module-list/
+func (client *Client) ModuleList(ctx context.Context) ([]models.ServerModule, error) {
+	response, err := client.executeCommand(ctx, C.ModuleList, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return handleModuleListResponse(response)
+}
+
+// ModuleLoad loads a module from the dynamic library at path on the server. The capabilities of the server, see
+// [Client.Capabilities], are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	path - The path of the dynamic library of the module, on the server.
+//	args - The arguments passed to the module.
+//
+// Return value:
+//
+//	"OK" if the module was loaded.
+//
+// [valkey.io]: https://valkey.io/commands/module-load/
+func (client *Client) ModuleLoad(ctx context.Context, path string, args []string) (string, error) {
+	response, err := client.executeCommand(ctx, C.ModuleLoad, append([]string{path}, args...))
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	client.capabilities.forget()
+	return handleOkResponse(response)
+}
+
+// ModuleLoadEx loads a module from the dynamic library at path on the server, with configuration parameters. The
+// capabilities of the server, see [Client.Capabilities], are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	path - The path of the dynamic library of the module, on the server.
+//	opts - The configuration parameters of the module and the arguments passed to it.
+//
+// Return value:
+//
+//	"OK" if the module was loaded.
+//
+// [valkey.io]: https://valkey.io/commands/module-loadex/
+func (client *Client) ModuleLoadEx(
+	ctx context.Context,
+	path string,
+	opts options.ModuleLoadExOptions,
+) (string, error) {
+	args, err := opts.ToArgs()
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	response, err := client.executeCommand(ctx, C.ModuleLoadEx, append([]string{path}, args...))
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	client.capabilities.forget()
+	return handleOkResponse(response)
+}
+
+// ModuleUnload unloads the module name from the server. The capabilities of the server, see [Client.Capabilities],
+// are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	name - The name of the module, as listed by [Client.ModuleList], not the path of its library.
+//
+// Return value:
+//
+//	"OK" if the module was unloaded.
+//
+// [valkey.io]: https://valkey.io/commands/module-unload/
+func (client *Client) ModuleUnload(ctx context.Context, name string) (string, error) {
+	response, err := client.executeCommand(ctx, C.ModuleUnload, []string{name})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	client.capabilities.forget()
+	return handleOkResponse(response)
+}
@@ -68,12 +68,28 @@ func NewClusterClient(config *config.ClusterClientConfiguration) (*ClusterClient
 	}
 	if config.HasSubscription() {
 		subConfig := config.GetSubscription()
-		client.setMessageHandler(NewMessageHandler(subConfig.GetCallback(), subConfig.GetContext()))
+		handler := NewMessageHandler(subConfig.GetCallback(), subConfig.GetContext())
+		handler.setDispatchMode(subConfig.GetDispatchMode(), subConfig.GetWorkerPoolSize())
+		handler.setMaxBufferedMessages(subConfig.GetMaxBufferedMessages())
+		handler.keyspaceFilters = subConfig.GetKeyspaceEventFilters()
+		client.setMessageHandler(handler)
 	} else {
 		client.setMessageHandler(NewMessageHandler(nil, nil))
 	}
+	if isolation := config.GetSubscriberIsolationConfiguration(); isolation != nil {
+		if err := client.isolateSubscriber(config.SubscriberConfiguration(), isolation); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
 
-	return &ClusterClient{*client}, nil
+	clusterClient := &ClusterClient{*client}
+	if primaryChange := config.GetPrimaryChangeConfiguration(); primaryChange != nil {
+		// The monitor must use the client returned to the caller, which is the one closed.
+		clusterClient.primaryMonitor = newPrimaryChangeMonitor(&clusterClient.baseClient, primaryChange)
+		go clusterClient.primaryMonitor.run()
+	}
+	return clusterClient, nil
 }
 
 // Executes a batch by processing the queued commands.
@@ -91,8 +107,8 @@ func NewClusterClient(config *config.ClusterClientConfiguration) (*ClusterClient
 // Behavior notes:
 //
 // Atomic Batches (Transactions): All key-based commands must map to the same hash slot.
-// If keys span different slots, the transaction will fail. If the transaction fails due to a
-// `WATCH` command, `Exec` will return `nil`.
+// If keys span different slots, the transaction is rejected with a [CrossSlotError] before being sent.
+// If the transaction fails due to a `WATCH` command, `Exec` will return `nil`.
 //
 // Retry and Redirection:
 //
@@ -122,6 +138,11 @@ func NewClusterClient(config *config.ClusterClientConfiguration) (*ClusterClient
 // [Valkey Transactions (Atomic Batches)]: https://valkey.io/docs/topics/transactions/
 // [Valkey Pipelines (Non-Atomic Batches)]: https://valkey.io/docs/topics/pipelining/
 func (client *ClusterClient) Exec(ctx context.Context, batch pipeline.ClusterBatch, raiseOnError bool) ([]any, error) {
+	if batch.Batch.IsAtomic {
+		if err := checkTransactionSlot(batch.Batch, client.view.prefix()); err != nil {
+			return nil, err
+		}
+	}
 	return client.executeBatch(ctx, batch.Batch, raiseOnError, nil)
 }
 
@@ -144,8 +165,8 @@ func (client *ClusterClient) Exec(ctx context.Context, batch pipeline.ClusterBat
 // # Behavior notes
 //
 // Atomic Batches (Transactions): All key-based commands must map to the same hash slot.
-// If keys span different slots, the transaction will fail. If the transaction fails due to a
-// `WATCH` command, `Exec` will return `nil`.
+// If keys span different slots, the transaction is rejected with a [CrossSlotError] before being sent.
+// If the transaction fails due to a `WATCH` command, `Exec` will return `nil`.
 //
 // # Retry and Redirection
 //
@@ -184,6 +205,11 @@ func (client *ClusterClient) ExecWithOptions(
 	if batch.Batch.IsAtomic && options.RetryStrategy != nil {
 		return nil, errors.New("retry strategy is not supported for atomic batches (transactions)")
 	}
+	if batch.Batch.IsAtomic {
+		if err := checkTransactionSlot(batch.Batch, client.view.prefix()); err != nil {
+			return nil, err
+		}
+	}
 	converted := options.Convert()
 	return client.executeBatch(ctx, batch.Batch, raiseOnError, &converted)
 }
@@ -222,6 +248,37 @@ func (client *ClusterClient) CustomCommand(ctx context.Context, args []string) (
 	return models.CreateClusterValue[any](data), nil
 }
 
+// CustomCommandWithAttributes executes a single command, specified by args, as [ClusterClient.CustomCommand] does,
+// and returns the returned value together with the RESP3 attributes the server attached to it, if any.
+//
+// The command will be routed automatically based on the passed command's default request policy. When it's routed to
+// several nodes, the value is the map of the values returned by each node, and the attributes are dropped.
+//
+// Attributes are only sent on RESP3 connections. Typed commands, and CustomCommand, drop the attributes of the
+// values they return.
+//
+// The value keeps the RESP3 types which CustomCommand converts to strings: verbatim strings, e.g. the reply of
+// LOLWUT, are returned as [models.VerbatimString] with their format.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	args - Arguments for the custom command including the command name.
+//
+// Return value:
+//
+//	The returned value for the custom command and its attributes.
+func (client *ClusterClient) CustomCommandWithAttributes(
+	ctx context.Context,
+	args []string,
+) (models.AttributedValue, error) {
+	res, err := client.executeCommand(ctx, C.CustomCommand, args)
+	if err != nil {
+		return models.AttributedValue{}, err
+	}
+	return handleAttributedResponse(res)
+}
+
 // Select changes the currently selected database.
 //
 // See [valkey.io] for details.
@@ -241,6 +298,9 @@ func (client *ClusterClient) Select(ctx context.Context, index int64) (string, e
 	if err != nil {
 		return models.DefaultStringResponse, err
 	}
+	if client.database != nil {
+		client.database.Store(index)
+	}
 
 	return handleOkResponse(result)
 }
@@ -653,6 +713,14 @@ func (client *ClusterClient) clusterScan(
 	default:
 		// Continue with execution
 	}
+	release, err := client.acquireSchedulerSlot(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if err := client.injectFault(ctx); err != nil {
+		return nil, err
+	}
 
 	// make the channel buffered, so that we don't need to acquire the client.mu in the successCallback and failureCallback.
 	resultChannel := make(chan payload, 1)
@@ -663,7 +731,7 @@ func (client *ClusterClient) clusterScan(
 	defer pinner.Unpin()
 
 	client.mu.Lock()
-	if client.coreClient == nil {
+	if client.core.ptr == nil {
 		client.mu.Unlock()
 		return nil, NewClosingError("Cluster Scan failed. The client is closed.")
 	}
@@ -688,7 +756,7 @@ func (client *ClusterClient) clusterScan(
 	}
 
 	C.request_cluster_scan(
-		client.coreClient,
+		client.core.ptr,
 		C.uintptr_t(pinnedChannelPtr),
 		c_cursor,
 		C.size_t(len(args)),
@@ -701,18 +769,7 @@ func (client *ClusterClient) clusterScan(
 	var payload payload
 	select {
 	case <-ctx.Done():
-		client.mu.Lock()
-		if client.pending != nil {
-			delete(client.pending, resultChannelPtr)
-		}
-		client.mu.Unlock()
-		// Start cleanup goroutine
-		go func() {
-			// Wait for payload on separate channel
-			if payload := <-resultChannel; payload.value != nil {
-				C.free_command_response(payload.value)
-			}
-		}()
+		client.abandonRequest(resultChannelPtr, pinnedChannelPtr, resultChannel, nil)
 		return nil, ctx.Err()
 	case payload = <-resultChannel:
 		// Continue with normal processing
@@ -951,6 +1008,139 @@ func (client *ClusterClient) ClientIdWithOptions(
 	return models.CreateClusterSingleValue[int64](data), nil
 }
 
+// Gets information about the current connection, as returned by CLIENT LIST.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	A map of the properties of the connection to their value, e.g. "id", "addr" or "name".
+//
+// [valkey.io]: https://valkey.io/commands/client-info/
+func (client *ClusterClient) ClientInfo(ctx context.Context) (models.ClusterValue[map[string]string], error) {
+	response, err := client.executeCommand(ctx, C.ClientInfo, []string{})
+	if err != nil {
+		return models.CreateEmptyClusterValue[map[string]string](), err
+	}
+	data, err := handleClientInfoResponse(response)
+	if err != nil {
+		return models.CreateEmptyClusterValue[map[string]string](), err
+	}
+	return models.CreateClusterSingleValue[map[string]string](data), nil
+}
+
+// Gets information about the current connection, as returned by CLIENT LIST.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	opts - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	A map of the properties of the connection to their value, e.g. "id", "addr" or "name".
+//	When routed to multiple nodes, a map of the node addresses to the properties of the connection to each node.
+//
+// [valkey.io]: https://valkey.io/commands/client-info/
+func (client *ClusterClient) ClientInfoWithOptions(
+	ctx context.Context,
+	opts options.RouteOption,
+) (models.ClusterValue[map[string]string], error) {
+	response, err := client.executeCommandWithRoute(ctx, C.ClientInfo, []string{}, opts.Route)
+	if err != nil {
+		return models.CreateEmptyClusterValue[map[string]string](), err
+	}
+	if opts.Route != nil &&
+		(opts.Route).IsMultiNode() {
+		data, err := handleClientInfoMapResponse(response)
+		if err != nil {
+			return models.CreateEmptyClusterValue[map[string]string](), err
+		}
+		return models.CreateClusterMultiValue[map[string]string](data), nil
+	}
+	data, err := handleClientInfoResponse(response)
+	if err != nil {
+		return models.CreateEmptyClusterValue[map[string]string](), err
+	}
+	return models.CreateClusterSingleValue[map[string]string](data), nil
+}
+
+// Lists the connections KillClients would kill with filter, on all the nodes, without killing them, e.g. to
+// review them first.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	filter - The criteria of the connections.
+//
+// Return value:
+//
+//	The connections matching filter, as listed by CLIENT LIST.
+//
+// [valkey.io]: https://valkey.io/commands/client-list/
+func (client *ClusterClient) PreviewKillClients(
+	ctx context.Context,
+	filter options.ClientKillFilter,
+) ([]models.ClientConnection, error) {
+	return previewKillClients(ctx, client, filter)
+}
+
+// Kills the connections matching filter on all the nodes, e.g. to shed the connections of a misbehaving service
+// during an incident. The connections are listed with CLIENT LIST, matched against filter, and killed one by one with
+// CLIENT KILL on their node.
+//
+// The connections of this library are only killed if filter confirms it with SetIncludeLibraryConnections, and the
+// connection issuing the kills is never killed. Use PreviewKillClients to review the connections first.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	filter - The criteria of the connections to kill.
+//
+// Return value:
+//
+//	The number of connections killed, which may be lower than the number of connections previewed if some were
+//	closed meanwhile.
+//
+// [valkey.io]: https://valkey.io/commands/client-kill/
+func (client *ClusterClient) KillClients(ctx context.Context, filter options.ClientKillFilter) (int64, error) {
+	return killClients(ctx, client, filter)
+}
+
+func (client *ClusterClient) listClients(ctx context.Context, args []string) (map[string]string, error) {
+	response, err := client.executeCommandWithRoute(ctx, C.ClientList, args, config.AllNodes)
+	if err != nil {
+		return nil, err
+	}
+	return handleStringToStringMapResponse(response)
+}
+
+func (client *ClusterClient) killClient(ctx context.Context, node string, id int64) (int64, error) {
+	host, port, ok := splitNodeAddress(node)
+	if !ok {
+		return models.DefaultIntResponse, fmt.Errorf("invalid node address %q", node)
+	}
+	response, err := client.executeCommandWithRoute(
+		ctx,
+		C.ClientKill,
+		[]string{"ID", utils.IntToString(id), "SKIPME", "yes"},
+		config.NewByAddressRoute(host, port),
+	)
+	if err != nil {
+		return models.DefaultIntResponse, err
+	}
+	return handleIntResponse(response)
+}
+
 // Returns UNIX TIME of the last DB save timestamp or startup timestamp if no save was made since then.
 // The command is routed to a random node by default, which is safe for read-only commands.
 //
@@ -1338,6 +1528,41 @@ func (client *ClusterClient) ConfigRewriteWithOptions(ctx context.Context, opts
 	return handleOkResponse(response)
 }
 
+// Shuts down the nodes of route, e.g. to restart a node from a runbook, or aborts their ongoing shutdown with
+// [options.ShutdownOptions.SetAbort]. A route is required, so that the whole cluster can't be shut down by mistake. The
+// nodes close the connection instead of replying once they're shutting down, which isn't reported as an error.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	shutdownOptions - The SHUTDOWN options, e.g. whether the nodes save their dataset before shutting down.
+//	route - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	nil once the nodes are shutting down or their shutdown was aborted, otherwise the error preventing a node from
+//	shutting down, e.g. a failure to save its dataset.
+//
+// [valkey.io]: https://valkey.io/commands/shutdown/
+func (client *ClusterClient) Shutdown(
+	ctx context.Context,
+	shutdownOptions options.ShutdownOptions,
+	route options.RouteOption,
+) error {
+	if route.Route == nil {
+		return errors.New("SHUTDOWN requires a route to the nodes to shut down")
+	}
+	args, err := shutdownOptions.ToArgs()
+	if err != nil {
+		return err
+	}
+	_, err = client.executeCommandWithRoute(ctx, C.CustomCommand, append([]string{"SHUTDOWN"}, args...), route.Route)
+	return handleShutdownError(err)
+}
+
 // Returns a random key.
 //
 // See [valkey.io] for details.
@@ -2037,6 +2262,9 @@ func (client *ClusterClient) FunctionListWithRoute(ctx context.Context,
 // Channel can be any string, but common patterns include using "." to create namespaces like
 // "news.sports" or "news.weather".
 //
+// A sharded message is routed by the slot of the channel, see [ClusterClient.SPublish]. For a non-sharded channel, the
+// returned count only includes the clients connected to the node which received the message.
+//
 // See [valkey.io] for details.
 //
 // Parameters:
@@ -2068,8 +2296,37 @@ func (client *ClusterClient) Publish(ctx context.Context, channel string, messag
 	return handleIntResponse(result)
 }
 
+// SPublish posts a message to the specified sharded channel. Returns the number of clients that received the message.
+//
+// The command is routed to the primary of the slot of the channel, and the message is only propagated to the nodes of
+// its shard. A result of 0 means nobody is subscribed to the channel, so producers can fall back to a durable delivery
+// mechanism instead.
+//
+// Since:
+//
+//	Valkey 7.0 and above.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	channel - The sharded channel to publish the message to.
+//	message - The message to publish.
+//
+// Return value:
+//
+//	The number of clients that received the message.
+//
+// [valkey.io]: https://valkey.io/commands/spublish
+func (client *ClusterClient) SPublish(ctx context.Context, channel string, message string) (int64, error) {
+	return client.Publish(ctx, channel, message, true)
+}
+
 // Returns a list of all sharded channels.
 //
+// The command is routed to all nodes and aggregates the responses into a single array, listing each channel once.
+//
 // Since:
 //
 //	Valkey 7.0 and above.
@@ -2091,11 +2348,13 @@ func (client *ClusterClient) PubSubShardChannels(ctx context.Context) ([]string,
 		return nil, err
 	}
 
-	return handleStringArrayResponse(result)
+	return handleChannelArrayResponse(result)
 }
 
 // Returns a list of all sharded channels that match the given pattern.
 //
+// The command is routed to all nodes and aggregates the responses into a single array, listing each channel once.
+//
 // Since:
 //
 //	Valkey 7.0 and above.
@@ -2118,10 +2377,13 @@ func (client *ClusterClient) PubSubShardChannelsWithPattern(ctx context.Context,
 		return nil, err
 	}
 
-	return handleStringArrayResponse(result)
+	return handleChannelArrayResponse(result)
 }
 
-// Returns the number of subscribers for a sharded channel.
+// Returns the number of subscribers for the specified sharded channels.
+//
+// The command is routed to all nodes and aggregates the responses into a single map, summing the subscriber counts of
+// each channel.
 //
 // Since:
 //
@@ -2132,11 +2394,11 @@ func (client *ClusterClient) PubSubShardChannelsWithPattern(ctx context.Context,
 // Parameters:
 //
 //	ctx - The context for controlling the command execution.
-//	channels - The channel to get the number of subscribers for.
+//	channels - The sharded channel names to get subscriber counts for.
 //
 // Return value:
 //
-//	The number of subscribers for the sharded channel.
+//	A map of sharded channel names to their subscriber counts.
 //
 // [valkey.io]: https://valkey.io/commands/pubsub-shard-numsub
 func (client *ClusterClient) PubSubShardNumSub(ctx context.Context, channels ...string) (map[string]int64, error) {
@@ -3214,3 +3476,379 @@ func (client *ClusterClient) ClusterLinksWithRoute(
 	}
 	return models.CreateClusterSingleValue[[]map[string]any](data), nil
 }
+
+// executeOkWithRoute executes a command replying OK on the nodes defined by route, and returns OK once every node did.
+func (client *ClusterClient) executeOkWithRoute(
+	ctx context.Context,
+	requestType C.RequestType,
+	args []string,
+	route config.Route,
+) (string, error) {
+	response, err := client.executeCommandWithRoute(ctx, requestType, args, route)
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	if route == nil || !route.IsMultiNode() {
+		return handleOkResponse(response)
+	}
+	replies, err := handleStringToStringMapResponse(response)
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	for address, reply := range replies {
+		if reply != "OK" {
+			return models.DefaultStringResponse, fmt.Errorf("unexpected reply from %s: %s", address, reply)
+		}
+	}
+	return "OK", nil
+}
+
+// ClusterFailover makes a replica take over its primary, after the primary stopped accepting writes and the replica
+// caught up with it. The command must be routed to the replica, e.g. with [config.NewByAddressRoute].
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - The replica to promote.
+//
+// Return value:
+//
+//	OK once the failover is scheduled. The failover completes asynchronously: check the result with ClusterNodes.
+//
+// [valkey.io]: https://valkey.io/commands/cluster-failover/
+func (client *ClusterClient) ClusterFailover(ctx context.Context, route config.Route) (string, error) {
+	return client.executeOkWithRoute(ctx, C.ClusterFailover, []string{}, route)
+}
+
+// ClusterFailoverWithMode makes a replica take over its primary, without waiting for the agreement of its primary
+// or of the cluster. The command must be routed to the replica, e.g. with [config.NewByAddressRoute].
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - The replica to promote.
+//	mode - The failover mode, see [options.ClusterFailoverMode].
+//
+// Return value:
+//
+//	OK once the failover is scheduled. The failover completes asynchronously: check the result with ClusterNodes.
+//
+// [valkey.io]: https://valkey.io/commands/cluster-failover/
+func (client *ClusterClient) ClusterFailoverWithMode(
+	ctx context.Context,
+	route config.Route,
+	mode options.ClusterFailoverMode,
+) (string, error) {
+	return client.executeOkWithRoute(ctx, C.ClusterFailover, []string{string(mode)}, route)
+}
+
+// ClusterMeet connects a node to another node, to add the other node to the cluster of the node.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - The node of the cluster the other node joins.
+//	host - The IP address of the node to meet.
+//	port - The port of the node to meet.
+//
+// Return value:
+//
+//	OK once the handshake is started. The node joins the cluster asynchronously.
+//
+// [valkey.io]: https://valkey.io/commands/cluster-meet/
+func (client *ClusterClient) ClusterMeet(ctx context.Context, route config.Route, host string, port int64) (string, error) {
+	return client.executeOkWithRoute(ctx, C.ClusterMeet, []string{host, utils.IntToString(port)}, route)
+}
+
+// ClusterForget removes a node from the nodes known by the nodes the command is routed to.
+//
+// To remove a node from the cluster, the command must reach every other node within 60 seconds, before they learn
+// about the node again through gossip: route it with [config.AllNodes].
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - The nodes which must forget the node.
+//	nodeId - The ID of the node to forget.
+//
+// Return value:
+//
+//	OK once every node the command was routed to forgot the node.
+//
+// [valkey.io]: https://valkey.io/commands/cluster-forget/
+func (client *ClusterClient) ClusterForget(ctx context.Context, route config.Route, nodeId string) (string, error) {
+	return client.executeOkWithRoute(ctx, C.ClusterForget, []string{nodeId}, route)
+}
+
+// ClusterReset resets a node, which forgets the other nodes of the cluster. The node must hold no key.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - The node to reset.
+//	mode - The kind of reset, see [options.ClusterResetMode].
+//
+// Return value:
+//
+//	OK once the node was reset.
+//
+// [valkey.io]: https://valkey.io/commands/cluster-reset/
+func (client *ClusterClient) ClusterReset(
+	ctx context.Context,
+	route config.Route,
+	mode options.ClusterResetMode,
+) (string, error) {
+	return client.executeOkWithRoute(ctx, C.ClusterReset, []string{string(mode)}, route)
+}
+
+// ClusterSetConfigEpoch sets the configuration epoch of a new node. The node must know no other node and have a
+// configuration epoch of zero.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - The node to set the configuration epoch of.
+//	epoch - The configuration epoch.
+//
+// Return value:
+//
+//	OK once the configuration epoch was set.
+//
+// [valkey.io]: https://valkey.io/commands/cluster-set-config-epoch/
+func (client *ClusterClient) ClusterSetConfigEpoch(ctx context.Context, route config.Route, epoch int64) (string, error) {
+	return client.executeOkWithRoute(ctx, C.ClusterSetConfigEpoch, []string{utils.IntToString(epoch)}, route)
+}
+
+// ModuleList returns the modules loaded by a random node of the cluster.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The name, version, path and arguments of each module loaded by the node.
+//
+// [valkey.io]: https://valkey.io/commands/module-list/
+func (client *ClusterClient) ModuleList(ctx context.Context) ([]models.ServerModule, error) {
+	response, err := client.executeCommand(ctx, C.ModuleList, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return handleModuleListResponse(response)
+}
+
+// ModuleListWithOptions returns the modules loaded by the nodes of the cluster defined by the route, e.g. to check
+// that a module is loaded by all the nodes.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	opts - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	The modules loaded by the node, or by each node for a multi-node route.
+//
+// [valkey.io]: https://valkey.io/commands/module-list/
+func (client *ClusterClient) ModuleListWithOptions(
+	ctx context.Context,
+	opts options.RouteOption,
+) (models.ClusterValue[[]models.ServerModule], error) {
+	response, err := client.executeCommandWithRoute(ctx, C.ModuleList, []string{}, opts.Route)
+	if err != nil {
+		return models.CreateEmptyClusterValue[[]models.ServerModule](), err
+	}
+	if opts.Route != nil && opts.Route.IsMultiNode() {
+		data, err := handleModuleListMultiNodeResponse(response)
+		if err != nil {
+			return models.CreateEmptyClusterValue[[]models.ServerModule](), err
+		}
+		return models.CreateClusterMultiValue[[]models.ServerModule](data), nil
+	}
+	data, err := handleModuleListResponse(response)
+	if err != nil {
+		return models.CreateEmptyClusterValue[[]models.ServerModule](), err
+	}
+	return models.CreateClusterSingleValue[[]models.ServerModule](data), nil
+}
+
+// ModuleLoad loads a module from the dynamic library at path on all the nodes of the cluster, which must all have the
+// library. The capabilities of the cluster, see [ClusterClient.Capabilities], are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	path - The path of the dynamic library of the module, on the nodes.
+//	args - The arguments passed to the module.
+//
+// Return value:
+//
+//	"OK" if the module was loaded by all the nodes.
+//
+// [valkey.io]: https://valkey.io/commands/module-load/
+func (client *ClusterClient) ModuleLoad(ctx context.Context, path string, args []string) (string, error) {
+	return client.ModuleLoadWithOptions(ctx, path, args, options.RouteOption{Route: config.AllNodes})
+}
+
+// ModuleLoadWithOptions loads a module from the dynamic library at path on the nodes of the cluster defined by the
+// route. The capabilities of the cluster, see [ClusterClient.Capabilities], are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	path - The path of the dynamic library of the module, on the nodes.
+//	args - The arguments passed to the module.
+//	opts - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	"OK" if the module was loaded by all the nodes of the route.
+//
+// [valkey.io]: https://valkey.io/commands/module-load/
+func (client *ClusterClient) ModuleLoadWithOptions(
+	ctx context.Context,
+	path string,
+	args []string,
+	opts options.RouteOption,
+) (string, error) {
+	response, err := client.executeCommandWithRoute(ctx, C.ModuleLoad, append([]string{path}, args...), opts.Route)
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	client.capabilities.forget()
+	return handleOkResponse(response)
+}
+
+// ModuleLoadEx loads a module from the dynamic library at path on all the nodes of the cluster, with configuration
+// parameters. The capabilities of the cluster, see [ClusterClient.Capabilities], are detected again when next
+// requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	path - The path of the dynamic library of the module, on the nodes.
+//	moduleOpts - The configuration parameters of the module and the arguments passed to it.
+//
+// Return value:
+//
+//	"OK" if the module was loaded by all the nodes.
+//
+// [valkey.io]: https://valkey.io/commands/module-loadex/
+func (client *ClusterClient) ModuleLoadEx(
+	ctx context.Context,
+	path string,
+	moduleOpts options.ModuleLoadExOptions,
+) (string, error) {
+	return client.ModuleLoadExWithOptions(ctx, path, moduleOpts, options.RouteOption{Route: config.AllNodes})
+}
+
+// ModuleLoadExWithOptions loads a module from the dynamic library at path on the nodes of the cluster defined by the
+// route, with configuration parameters. The capabilities of the cluster, see [ClusterClient.Capabilities], are
+// detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	path - The path of the dynamic library of the module, on the nodes.
+//	moduleOpts - The configuration parameters of the module and the arguments passed to it.
+//	opts - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	"OK" if the module was loaded by all the nodes of the route.
+//
+// [valkey.io]: https://valkey.io/commands/module-loadex/
+func (client *ClusterClient) ModuleLoadExWithOptions(
+	ctx context.Context,
+	path string,
+	moduleOpts options.ModuleLoadExOptions,
+	opts options.RouteOption,
+) (string, error) {
+	args, err := moduleOpts.ToArgs()
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	response, err := client.executeCommandWithRoute(ctx, C.ModuleLoadEx, append([]string{path}, args...), opts.Route)
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	client.capabilities.forget()
+	return handleOkResponse(response)
+}
+
+// ModuleUnload unloads the module name from all the nodes of the cluster. The capabilities of the cluster, see
+// [ClusterClient.Capabilities], are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	name - The name of the module, as listed by [ClusterClient.ModuleList], not the path of its library.
+//
+// Return value:
+//
+//	"OK" if the module was unloaded by all the nodes.
+//
+// [valkey.io]: https://valkey.io/commands/module-unload/
+func (client *ClusterClient) ModuleUnload(ctx context.Context, name string) (string, error) {
+	return client.ModuleUnloadWithOptions(ctx, name, options.RouteOption{Route: config.AllNodes})
+}
+
+// ModuleUnloadWithOptions unloads the module name from the nodes of the cluster defined by the route. The
+// capabilities of the cluster, see [ClusterClient.Capabilities], are detected again when next requested.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	name - The name of the module, as listed by [ClusterClient.ModuleList], not the path of its library.
+//	opts - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	"OK" if the module was unloaded by all the nodes of the route.
+//
+// [valkey.io]: https://valkey.io/commands/module-unload/
+func (client *ClusterClient) ModuleUnloadWithOptions(
+	ctx context.Context,
+	name string,
+	opts options.RouteOption,
+) (string, error) {
+	response, err := client.executeCommandWithRoute(ctx, C.ModuleUnload, []string{name}, opts.Route)
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	client.capabilities.forget()
+	return handleOkResponse(response)
+}
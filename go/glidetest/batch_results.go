@@ -0,0 +1,141 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// resultAt returns the result of the command at index, or reports a failure if the batch returned no such result.
+func resultAt(t testing.TB, results []any, index int) (any, bool) {
+	t.Helper()
+	if index < 0 || index >= len(results) {
+		t.Errorf("the batch returned %d results, expected a result at index %d", len(results), index)
+		return nil, false
+	}
+	return results[index], true
+}
+
+// ResultAt returns the result of the command at index, of type T, e.g. map[string]string for HGETALL. It reports a
+// failure and returns the zero T if the result isn't a T, e.g. if the command failed.
+func ResultAt[T any](t testing.TB, results []any, index int) T {
+	t.Helper()
+	var zero T
+	result, ok := resultAt(t, results, index)
+	if !ok {
+		return zero
+	}
+	value, ok := result.(T)
+	if !ok {
+		t.Errorf("the result at index %d is %s, expected a %T", index, describeResult(result), zero)
+		return zero
+	}
+	return value
+}
+
+// AssertLen asserts that the batch returned count results.
+func AssertLen(t testing.TB, results []any, count int) bool {
+	t.Helper()
+	if len(results) != count {
+		t.Errorf("the batch returned %d results, expected %d", len(results), count)
+		return false
+	}
+	return true
+}
+
+// AssertOK asserts that the command at index returned "OK", e.g. SET.
+func AssertOK(t testing.TB, results []any, index int) bool {
+	t.Helper()
+	return AssertString(t, results, index, "OK")
+}
+
+// AssertString asserts that the command at index returned the string want.
+func AssertString(t testing.TB, results []any, index int, want string) bool {
+	t.Helper()
+	return assertResult(t, results, index, want)
+}
+
+// AssertInt asserts that the command at index returned the integer want.
+func AssertInt(t testing.TB, results []any, index int, want int64) bool {
+	t.Helper()
+	return assertResult(t, results, index, want)
+}
+
+// AssertFloat asserts that the command at index returned the float want.
+func AssertFloat(t testing.TB, results []any, index int, want float64) bool {
+	t.Helper()
+	return assertResult(t, results, index, want)
+}
+
+// AssertBool asserts that the command at index returned the boolean want.
+func AssertBool(t testing.TB, results []any, index int, want bool) bool {
+	t.Helper()
+	return assertResult(t, results, index, want)
+}
+
+// AssertNil asserts that the command at index returned nil, e.g. GET of a missing key.
+func AssertNil(t testing.TB, results []any, index int) bool {
+	t.Helper()
+	result, ok := resultAt(t, results, index)
+	if !ok {
+		return false
+	}
+	if result != nil {
+		t.Errorf("the result at index %d is %s, expected nil", index, describeResult(result))
+		return false
+	}
+	return true
+}
+
+// AssertEqual asserts that the command at index returned a value deeply equal to want, e.g. a []any, or a
+// map[string]string for HGETALL.
+func AssertEqual(t testing.TB, results []any, index int, want any) bool {
+	t.Helper()
+	return assertResult(t, results, index, want)
+}
+
+// AssertError asserts that the command at index failed, in a batch executed without raising errors, and returns its
+// error, e.g. to assert on the error message.
+func AssertError(t testing.TB, results []any, index int) error {
+	t.Helper()
+	result, ok := resultAt(t, results, index)
+	if !ok {
+		return nil
+	}
+	err, ok := result.(error)
+	if !ok {
+		t.Errorf("the result at index %d is %s, expected an error", index, describeResult(result))
+		return nil
+	}
+	return err
+}
+
+// assertResult asserts that the command at index returned a value deeply equal to want and of the same type.
+func assertResult(t testing.TB, results []any, index int, want any) bool {
+	t.Helper()
+	result, ok := resultAt(t, results, index)
+	if !ok {
+		return false
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("the result at index %d is %s, expected %s", index, describeResult(result), describeResult(want))
+		return false
+	}
+	return true
+}
+
+// describeResult describes result with its type, so that e.g. the string "5" and the integer 5 are told apart.
+func describeResult(result any) string {
+	switch result := result.(type) {
+	case nil:
+		return "nil"
+	case error:
+		return fmt.Sprintf("the error %q", result.Error())
+	case string:
+		return fmt.Sprintf("%q", result)
+	default:
+		return fmt.Sprintf("%T %v", result, result)
+	}
+}
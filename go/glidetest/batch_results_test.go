@@ -0,0 +1,53 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchResultAssertions(t *testing.T) {
+	results := []any{
+		"OK",
+		nil,
+		int64(5),
+		map[string]string{"field": "value"},
+		errors.New("WRONGTYPE Operation against a key holding the wrong kind of value"),
+		3.5,
+		true,
+	}
+	recorder := &recordingT{TB: t}
+
+	assert.True(t, AssertLen(recorder, results, 7))
+	assert.True(t, AssertOK(recorder, results, 0))
+	assert.True(t, AssertNil(recorder, results, 1))
+	assert.True(t, AssertInt(recorder, results, 2, 5))
+	assert.True(t, AssertEqual(recorder, results, 3, map[string]string{"field": "value"}))
+	assert.EqualError(t, AssertError(recorder, results, 4), "WRONGTYPE Operation against a key holding the wrong kind of value")
+	assert.True(t, AssertFloat(recorder, results, 5, 3.5))
+	assert.True(t, AssertBool(recorder, results, 6, true))
+	assert.Equal(t, map[string]string{"field": "value"}, ResultAt[map[string]string](recorder, results, 3))
+	assert.Empty(t, recorder.errors)
+
+	assert.False(t, AssertLen(recorder, results, 6))
+	assert.False(t, AssertOK(recorder, results, 1))
+	assert.False(t, AssertString(recorder, results, 2, "5"))
+	assert.False(t, AssertInt(recorder, results, 4, 5))
+	assert.False(t, AssertNil(recorder, results, 0))
+	assert.NoError(t, AssertError(recorder, results, 2))
+	assert.False(t, AssertInt(recorder, results, 7, 5))
+	assert.Nil(t, ResultAt[map[string]string](recorder, results, 2))
+	assert.Equal(t, []string{
+		"the batch returned 7 results, expected 6",
+		`the result at index 1 is nil, expected "OK"`,
+		`the result at index 2 is int64 5, expected "5"`,
+		`the result at index 4 is the error "WRONGTYPE Operation against a key holding the wrong kind of value", expected int64 5`,
+		`the result at index 0 is "OK", expected nil`,
+		"the result at index 2 is int64 5, expected an error",
+		"the batch returned 7 results, expected a result at index 7",
+		"the result at index 2 is int64 5, expected a map[string]string",
+	}, recorder.errors)
+}
@@ -0,0 +1,45 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"strings"
+	"testing"
+
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+)
+
+// AssertSent asserts that capture recorded exactly count commands named name, ignoring case, whose arguments start
+// with args, e.g.
+//
+//	glidetest.AssertSent(t, capture, 1, "UNLINK", "session:1")
+func AssertSent(t testing.TB, capture *glide.CommandCapture, count int, name string, args ...string) bool {
+	t.Helper()
+	if sent := capture.Count(name, args...); sent != count {
+		t.Errorf("%d commands %s were sent, expected %d; the commands sent were:\n%s",
+			sent, describeCommand(name, args), count, describeCommands(capture.Commands()))
+		return false
+	}
+	return true
+}
+
+// AssertNotSent asserts that capture recorded no command named name, ignoring case, whose arguments start with args.
+func AssertNotSent(t testing.TB, capture *glide.CommandCapture, name string, args ...string) bool {
+	t.Helper()
+	return AssertSent(t, capture, 0, name, args...)
+}
+
+func describeCommand(name string, args []string) string {
+	return strings.TrimSpace(strings.ToUpper(name) + " " + strings.Join(args, " "))
+}
+
+func describeCommands(commands []glide.CapturedCommand) string {
+	if len(commands) == 0 {
+		return "\t(none)"
+	}
+	lines := make([]string, len(commands))
+	for i, command := range commands {
+		lines[i] = "\t" + describeCommand(command.Name, command.Args)
+	}
+	return strings.Join(lines, "\n")
+}
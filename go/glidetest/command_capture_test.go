@@ -0,0 +1,20 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+)
+
+func TestAssertSent(t *testing.T) {
+	recorder := &recordingT{TB: t}
+	capture := glide.NewCommandCapture()
+
+	assert.True(t, AssertNotSent(recorder, capture, "UNLINK"))
+	assert.False(t, AssertSent(recorder, capture, 1, "UNLINK", "session:1"))
+	assert.Equal(t, []string{"0 commands UNLINK session:1 were sent, expected 1; the commands sent were:\n\t(none)"}, recorder.errors)
+}
@@ -0,0 +1,16 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+// Package glidetest provides utilities for testing code written against Valkey GLIDE clients, e.g. Lua scripts and
+// functions run in a [ScriptSandbox], or keys loaded from declarative [Fixtures] by a [FixtureLoader].
+//
+// The assertions on the results of a batch, as returned by `Exec` of [glide.Client] and [glide.ClusterClient], take
+// the position of the command in the batch, e.g.
+//
+//	results, err := client.Exec(ctx, *batch, false)
+//	glidetest.AssertOK(t, results, 0)
+//	glidetest.AssertInt(t, results, 2, 5)
+//	fields := glidetest.ResultAt[map[string]string](t, results, 3)
+//
+// They report a failure, rather than panic, when the batch returned fewer results or a result of another type, and
+// return whether the assertion held.
+package glidetest
@@ -0,0 +1,324 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// FixtureType is the type of the value of a [Fixture], as reported by TYPE.
+type FixtureType string
+
+const (
+	FixtureString FixtureType = "string"
+	FixtureHash   FixtureType = "hash"
+	FixtureList   FixtureType = "list"
+	FixtureSet    FixtureType = "set"
+	FixtureZSet   FixtureType = "zset"
+	FixtureStream FixtureType = "stream"
+	// FixtureMissing is a key which doesn't exist: it's deleted by Load, and asserted not to exist by Verify.
+	FixtureMissing FixtureType = "none"
+)
+
+// Fixture is the value of a key of [Fixtures]. Only the field of its type is used: Value for a string, Fields for a
+// hash, Elements for a list or a set, Members for a sorted set, and Entries for a stream.
+type Fixture struct {
+	Type FixtureType `json:"type"`
+	// Value is the value of a string.
+	Value string `json:"value,omitempty"`
+	// Fields are the fields of a hash.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Elements are the elements of a list, in order, or the members of a set.
+	Elements []string `json:"elements,omitempty"`
+	// Members are the scores of the members of a sorted set.
+	Members map[string]float64 `json:"members,omitempty"`
+	// Entries are the fields of the entries of a stream, in order. The fields of an entry are added sorted by name,
+	// with IDs generated by the server.
+	Entries []map[string]string `json:"entries,omitempty"`
+	// TTL is the time to live of the key, or 0 if it doesn't expire. Verify asserts that the key expires within TTL,
+	// if set, and doesn't check the expiry of the key otherwise. It's a duration string in JSON, e.g. "10s".
+	TTL time.Duration `json:"-"`
+}
+
+// fixtureJSON is a [Fixture] as parsed by [ParseFixtures].
+type fixtureJSON struct {
+	Fixture
+	TTL string `json:"ttl,omitempty"`
+}
+
+// Fixtures are the values of keys, by key, e.g.
+//
+//	glidetest.Fixtures{
+//		"user:1":      {Type: glidetest.FixtureHash, Fields: map[string]string{"name": "Ada"}},
+//		"sessions":    {Type: glidetest.FixtureSet, Elements: []string{"s1", "s2"}, TTL: time.Hour},
+//		"leaderboard": {Type: glidetest.FixtureZSet, Members: map[string]float64{"ada": 10}},
+//	}
+type Fixtures map[string]Fixture
+
+// ParseFixtures parses fixtures declared in JSON, keyed by key, e.g.
+//
+//	{
+//		"user:1": {"type": "hash", "fields": {"name": "Ada"}},
+//		"sessions": {"type": "set", "elements": ["s1", "s2"], "ttl": "1h"},
+//		"events": {"type": "stream", "entries": [{"kind": "login"}]}
+//	}
+func ParseFixtures(data []byte) (Fixtures, error) {
+	var parsed map[string]fixtureJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	fixtures := make(Fixtures, len(parsed))
+	for key, fixture := range parsed {
+		if fixture.TTL != "" {
+			ttl, err := time.ParseDuration(fixture.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TTL of %s: %w", key, err)
+			}
+			fixture.Fixture.TTL = ttl
+		}
+		fixtures[key] = fixture.Fixture
+	}
+	return fixtures, nil
+}
+
+// FixtureLoader loads [Fixtures] into the server of a client before a test, e.g. a server started for the tests, or
+// into a fake implementing the commands of the client, and verifies the keys the test leaves, so that the tests don't
+// need their own setup code. The keys are written under their names as declared, replacing their previous value.
+//
+// The keys loaded and verified are deleted when the test completes.
+//
+// Example usage:
+//
+//	func TestCheckout(t *testing.T) {
+//		fixtures := glidetest.NewFixtureLoader(t, client).Load(glidetest.Fixtures{
+//			"cart:1": {Type: glidetest.FixtureList, Elements: []string{"apple", "pear"}},
+//			"stock":  {Type: glidetest.FixtureHash, Fields: map[string]string{"apple": "3", "pear": "1"}},
+//		})
+//		checkout(ctx, client, "1")
+//		fixtures.Verify(glidetest.Fixtures{
+//			"cart:1": {Type: glidetest.FixtureMissing},
+//			"stock":  {Type: glidetest.FixtureHash, Fields: map[string]string{"apple": "2", "pear": "0"}},
+//		})
+//	}
+type FixtureLoader struct {
+	t      testing.TB
+	client interfaces.BaseClientCommands
+	ctx    context.Context
+	keys   map[string]struct{}
+}
+
+// NewFixtureLoader returns a [FixtureLoader] loading fixtures with client, a [glide.Client], a [glide.ClusterClient]
+// or a fake, cleaned up when t completes.
+func NewFixtureLoader(t testing.TB, client interfaces.BaseClientCommands) *FixtureLoader {
+	t.Helper()
+	loader := &FixtureLoader{t: t, client: client, ctx: context.Background(), keys: make(map[string]struct{})}
+	t.Cleanup(loader.cleanup)
+	return loader
+}
+
+// WithContext sets the context of the commands sent by the loader. Defaults to context.Background().
+func (loader *FixtureLoader) WithContext(ctx context.Context) *FixtureLoader {
+	loader.ctx = ctx
+	return loader
+}
+
+// Load writes fixtures, in the order of their keys. The test fails if a key can't be written.
+func (loader *FixtureLoader) Load(fixtures Fixtures) *FixtureLoader {
+	loader.t.Helper()
+	for _, key := range sortedKeys(fixtures) {
+		loader.keys[key] = struct{}{}
+		if err := loader.load(key, fixtures[key]); err != nil {
+			loader.t.Fatalf("loading the fixture %s: %v", key, err)
+		}
+	}
+	return loader
+}
+
+func (loader *FixtureLoader) load(key string, fixture Fixture) error {
+	ctx, client := loader.ctx, loader.client
+	if _, err := client.Del(ctx, []string{key}); err != nil {
+		return err
+	}
+	var err error
+	switch fixture.Type {
+	case FixtureMissing:
+		return nil
+	case FixtureString:
+		_, err = client.Set(ctx, key, fixture.Value)
+	case FixtureHash:
+		_, err = client.HSet(ctx, key, fixture.Fields)
+	case FixtureList:
+		_, err = client.RPush(ctx, key, fixture.Elements)
+	case FixtureSet:
+		_, err = client.SAdd(ctx, key, fixture.Elements)
+	case FixtureZSet:
+		_, err = client.ZAdd(ctx, key, fixture.Members)
+	case FixtureStream:
+		for _, entry := range fixture.Entries {
+			if _, err = client.XAdd(ctx, key, streamFields(entry)); err != nil {
+				break
+			}
+		}
+	default:
+		return fmt.Errorf("unknown fixture type %q", fixture.Type)
+	}
+	if err != nil || fixture.TTL <= 0 {
+		return err
+	}
+	set, err := client.PExpire(ctx, key, fixture.TTL)
+	if err == nil && !set {
+		err = fmt.Errorf("the key is empty, so it can't expire")
+	}
+	return err
+}
+
+// Verify asserts that the keys of want hold exactly their fixtures, and expire within their TTL, if set. Each
+// mismatch is reported as a failure of the test.
+func (loader *FixtureLoader) Verify(want Fixtures) {
+	loader.t.Helper()
+	for _, key := range sortedKeys(want) {
+		loader.keys[key] = struct{}{}
+		loader.verify(key, want[key])
+	}
+}
+
+func (loader *FixtureLoader) verify(key string, fixture Fixture) {
+	loader.t.Helper()
+	ctx, client := loader.ctx, loader.client
+	keyType, err := client.Type(ctx, key)
+	if err != nil {
+		loader.t.Errorf("reading the type of %s: %v", key, err)
+		return
+	}
+	if FixtureType(keyType) != fixture.Type && fixture.Type == FixtureMissing {
+		loader.t.Errorf("the key %s is a %s, expected it to be missing", key, keyType)
+		return
+	}
+	if FixtureType(keyType) != fixture.Type {
+		loader.t.Errorf("the key %s is a %s, expected a %s", key, keyType, fixture.Type)
+		return
+	}
+	var got, expected any
+	switch fixture.Type {
+	case FixtureMissing:
+		return
+	case FixtureString:
+		var value models.Result[string]
+		value, err = client.Get(ctx, key)
+		got, expected = value.Value(), fixture.Value
+	case FixtureHash:
+		got, err = client.HGetAll(ctx, key)
+		expected = fixture.Fields
+	case FixtureList:
+		got, err = client.LRange(ctx, key, 0, -1)
+		expected = fixture.Elements
+	case FixtureSet:
+		var members map[string]struct{}
+		members, err = client.SMembers(ctx, key)
+		got, expected = sortedKeys(members), sortedElements(fixture.Elements)
+	case FixtureZSet:
+		var members []models.MemberAndScore
+		members, err = client.ZRangeWithScores(ctx, key, options.NewRangeByIndexQuery(0, -1))
+		scores := make(map[string]float64, len(members))
+		for _, member := range members {
+			scores[member.Member] = member.Score
+		}
+		got, expected = scores, fixture.Members
+	case FixtureStream:
+		var entries []models.StreamEntry
+		entries, err = client.XRange(ctx, key,
+			options.NewInfiniteStreamBoundary(constants.NegativeInfinity),
+			options.NewInfiniteStreamBoundary(constants.PositiveInfinity))
+		fields := make([]map[string]string, len(entries))
+		for i, entry := range entries {
+			fields[i] = make(map[string]string, len(entry.Fields))
+			for _, field := range entry.Fields {
+				fields[i][field.Field] = field.Value
+			}
+		}
+		got, expected = fields, fixture.Entries
+	}
+	if err != nil {
+		loader.t.Errorf("reading the %s %s: %v", fixture.Type, key, err)
+		return
+	}
+	if !reflect.DeepEqual(got, expected) && !(isEmpty(got) && isEmpty(expected)) {
+		loader.t.Errorf("the %s %s holds %v, expected %v", fixture.Type, key, got, expected)
+	}
+	if fixture.TTL > 0 {
+		loader.verifyTTL(key, fixture.TTL)
+	}
+}
+
+func (loader *FixtureLoader) verifyTTL(key string, ttl time.Duration) {
+	loader.t.Helper()
+	milliseconds, err := loader.client.PTTL(loader.ctx, key)
+	switch {
+	case err != nil:
+		loader.t.Errorf("reading the TTL of %s: %v", key, err)
+	case milliseconds < 0:
+		loader.t.Errorf("the key %s doesn't expire, expected it to expire within %v", key, ttl)
+	case time.Duration(milliseconds)*time.Millisecond > ttl:
+		loader.t.Errorf("the key %s expires in %v, expected it to expire within %v",
+			key, time.Duration(milliseconds)*time.Millisecond, ttl)
+	}
+}
+
+// cleanup deletes the keys loaded and verified.
+func (loader *FixtureLoader) cleanup() {
+	if len(loader.keys) == 0 {
+		return
+	}
+	// The keys are deleted one by one, as they may map to different slots in clusters.
+	for _, key := range sortedKeys(loader.keys) {
+		if _, err := loader.client.Del(loader.ctx, []string{key}); err != nil {
+			loader.t.Logf("deleting the fixture %s: %v", key, err)
+		}
+	}
+}
+
+// streamFields returns the fields of a stream entry, sorted by name.
+func streamFields(entry map[string]string) []models.FieldValue {
+	fields := make([]models.FieldValue, 0, len(entry))
+	for _, field := range sortedKeys(entry) {
+		fields = append(fields, models.FieldValue{Field: field, Value: entry[field]})
+	}
+	return fields
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedElements(elements []string) []string {
+	sorted := append([]string{}, elements...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// isEmpty reports whether value is a nil or empty map or slice.
+func isEmpty(value any) bool {
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Map, reflect.Slice:
+		return reflected.Len() == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeKeyspace holds the values of keys in memory, with their TTL in milliseconds.
+type fakeKeyspace struct {
+	interfaces.BaseClientCommands
+	values map[string]any
+	ttls   map[string]int64
+}
+
+func newFakeKeyspace() *fakeKeyspace {
+	return &fakeKeyspace{values: make(map[string]any), ttls: make(map[string]int64)}
+}
+
+func (client *fakeKeyspace) Del(ctx context.Context, keys []string) (int64, error) {
+	var deleted int64
+	for _, key := range keys {
+		if _, ok := client.values[key]; ok {
+			deleted++
+		}
+		delete(client.values, key)
+		delete(client.ttls, key)
+	}
+	return deleted, nil
+}
+
+func (client *fakeKeyspace) Type(ctx context.Context, key string) (string, error) {
+	switch client.values[key].(type) {
+	case string:
+		return "string", nil
+	case map[string]string:
+		return "hash", nil
+	case []string:
+		return "list", nil
+	case map[string]struct{}:
+		return "set", nil
+	case map[string]float64:
+		return "zset", nil
+	case []models.StreamEntry:
+		return "stream", nil
+	default:
+		return "none", nil
+	}
+}
+
+func (client *fakeKeyspace) Set(ctx context.Context, key string, value string) (string, error) {
+	client.values[key] = value
+	return "OK", nil
+}
+
+func (client *fakeKeyspace) Get(ctx context.Context, key string) (models.Result[string], error) {
+	if value, ok := client.values[key].(string); ok {
+		return models.CreateStringResult(value), nil
+	}
+	return models.CreateNilStringResult(), nil
+}
+
+func (client *fakeKeyspace) HSet(ctx context.Context, key string, values map[string]string) (int64, error) {
+	client.values[key] = values
+	return int64(len(values)), nil
+}
+
+func (client *fakeKeyspace) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	hash, _ := client.values[key].(map[string]string)
+	return hash, nil
+}
+
+func (client *fakeKeyspace) RPush(ctx context.Context, key string, elements []string) (int64, error) {
+	client.values[key] = elements
+	return int64(len(elements)), nil
+}
+
+func (client *fakeKeyspace) LRange(ctx context.Context, key string, start int64, end int64) ([]string, error) {
+	list, _ := client.values[key].([]string)
+	return list, nil
+}
+
+func (client *fakeKeyspace) SAdd(ctx context.Context, key string, members []string) (int64, error) {
+	set := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		set[member] = struct{}{}
+	}
+	client.values[key] = set
+	return int64(len(set)), nil
+}
+
+func (client *fakeKeyspace) SMembers(ctx context.Context, key string) (map[string]struct{}, error) {
+	set, _ := client.values[key].(map[string]struct{})
+	return set, nil
+}
+
+func (client *fakeKeyspace) ZAdd(ctx context.Context, key string, membersScoreMap map[string]float64) (int64, error) {
+	client.values[key] = membersScoreMap
+	return int64(len(membersScoreMap)), nil
+}
+
+func (client *fakeKeyspace) ZRangeWithScores(
+	ctx context.Context,
+	key string,
+	rangeQuery options.ZRangeQueryWithScores,
+) ([]models.MemberAndScore, error) {
+	scores, _ := client.values[key].(map[string]float64)
+	members := make([]models.MemberAndScore, 0, len(scores))
+	for member, score := range scores {
+		members = append(members, models.MemberAndScore{Member: member, Score: score})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Score < members[j].Score })
+	return members, nil
+}
+
+func (client *fakeKeyspace) XAdd(ctx context.Context, key string, values []models.FieldValue) (string, error) {
+	entries, _ := client.values[key].([]models.StreamEntry)
+	id := fmt.Sprintf("1-%d", len(entries))
+	client.values[key] = append(entries, models.StreamEntry{ID: id, Fields: values})
+	return id, nil
+}
+
+func (client *fakeKeyspace) XRange(
+	ctx context.Context,
+	key string,
+	start options.StreamBoundary,
+	end options.StreamBoundary,
+) ([]models.StreamEntry, error) {
+	entries, _ := client.values[key].([]models.StreamEntry)
+	return entries, nil
+}
+
+func (client *fakeKeyspace) PExpire(ctx context.Context, key string, expireTime time.Duration) (bool, error) {
+	if _, ok := client.values[key]; !ok {
+		return false, nil
+	}
+	client.ttls[key] = expireTime.Milliseconds()
+	return true, nil
+}
+
+func (client *fakeKeyspace) PTTL(ctx context.Context, key string) (int64, error) {
+	if _, ok := client.values[key]; !ok {
+		return -2, nil
+	}
+	if ttl, ok := client.ttls[key]; ok {
+		return ttl, nil
+	}
+	return -1, nil
+}
+
+func TestParseFixtures(t *testing.T) {
+	fixtures, err := ParseFixtures([]byte(`{
+		"greeting": {"type": "string", "value": "hello", "ttl": "10s"},
+		"user:1": {"type": "hash", "fields": {"name": "Ada"}},
+		"leaderboard": {"type": "zset", "members": {"ada": 10}},
+		"events": {"type": "stream", "entries": [{"kind": "login"}]}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, Fixtures{
+		"greeting":    {Type: FixtureString, Value: "hello", TTL: 10 * time.Second},
+		"user:1":      {Type: FixtureHash, Fields: map[string]string{"name": "Ada"}},
+		"leaderboard": {Type: FixtureZSet, Members: map[string]float64{"ada": 10}},
+		"events":      {Type: FixtureStream, Entries: []map[string]string{{"kind": "login"}}},
+	}, fixtures)
+
+	_, err = ParseFixtures([]byte(`{"greeting": {"type": "string", "ttl": "soon"}}`))
+	assert.ErrorContains(t, err, "invalid TTL of greeting")
+}
+
+func TestFixtureLoader(t *testing.T) {
+	client := newFakeKeyspace()
+	client.values["stale"] = "left by another test"
+	recorder := &recordingT{TB: t}
+	fixtures := Fixtures{
+		"greeting":    {Type: FixtureString, Value: "hello", TTL: time.Minute},
+		"user:1":      {Type: FixtureHash, Fields: map[string]string{"name": "Ada"}},
+		"queue":       {Type: FixtureList, Elements: []string{"b", "a"}},
+		"tags":        {Type: FixtureSet, Elements: []string{"x", "y"}},
+		"leaderboard": {Type: FixtureZSet, Members: map[string]float64{"ada": 10, "bob": 5}},
+		"events":      {Type: FixtureStream, Entries: []map[string]string{{"kind": "login", "user": "ada"}}},
+		"stale":       {Type: FixtureMissing},
+	}
+	loader := NewFixtureLoader(recorder, client).Load(fixtures)
+	assert.Empty(t, recorder.errors)
+	assert.NotContains(t, client.values, "stale")
+	assert.Equal(t, []models.FieldValue{{Field: "kind", Value: "login"}, {Field: "user", Value: "ada"}},
+		client.values["events"].([]models.StreamEntry)[0].Fields)
+
+	loader.Verify(fixtures)
+	assert.Empty(t, recorder.errors)
+
+	client.values["queue"] = []string{"a"}
+	client.values["tags"] = map[string]struct{}{"x": {}, "z": {}}
+	delete(client.ttls, "greeting")
+	client.values["stale"] = "recreated"
+	loader.Verify(fixtures)
+	assert.ElementsMatch(t, []string{
+		"the key greeting doesn't expire, expected it to expire within 1m0s",
+		"the list queue holds [a], expected [b a]",
+		"the key stale is a string, expected it to be missing",
+		"the set tags holds [x z], expected [x y]",
+	}, recorder.errors)
+
+	// The keys loaded are deleted when the test completes.
+	client.values["unrelated"] = "kept"
+	for _, cleanup := range recorder.cleanups {
+		cleanup()
+	}
+	assert.Equal(t, map[string]any{"unrelated": "kept"}, client.values)
+}
+
+func TestFixtureLoader_LoadError(t *testing.T) {
+	recorder := &recordingT{TB: t}
+	NewFixtureLoader(recorder, newFakeKeyspace()).Load(Fixtures{"empty": {Type: "bitmap"}})
+	assert.Equal(t, []string{`loading the fixture empty: unknown fixture type "bitmap"`}, recorder.errors)
+}
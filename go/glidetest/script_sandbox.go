@@ -0,0 +1,346 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// functionDeleter is implemented by [glide.Client] and [glide.ClusterClient].
+type functionDeleter interface {
+	FunctionDelete(ctx context.Context, libName string) (string, error)
+}
+
+var (
+	_ functionDeleter = (*glide.Client)(nil)
+	_ functionDeleter = (*glide.ClusterClient)(nil)
+)
+
+// ScriptSandbox runs Lua scripts and functions against the server of a client, e.g. a server started for the tests,
+// from fixture keys, and asserts on the keys they leave, so that the logic of the scripts can be unit tested.
+//
+// The keys of a sandbox are named relative to it: the name "stock" is the key [ScriptSandbox.Key] returns, prefixed
+// with a hash tag unique to the sandbox. The sandboxes of concurrent tests thus don't share keys, and all the keys of
+// a sandbox map to the same slot in clusters, as required by scripts. The keys passed to the scripts and functions
+// are prefixed the same way, but not their arguments: a script which builds key names from its arguments must use
+// [ScriptSandbox.Key] for them.
+//
+// The fixture keys, the keys passed to the scripts and functions and the keys asserted on are deleted, and the loaded
+// libraries are deleted, when the test completes.
+//
+// Example usage:
+//
+//	func TestReserve(t *testing.T) {
+//		sandbox := glidetest.NewScriptSandbox(t, client)
+//		sandbox.SetHash("stock", map[string]string{"apples": "3"})
+//		result := sandbox.Run(reserveScript, []string{"stock", "reserved"}, "apples", "2")
+//		sandbox.AssertHash("stock", map[string]string{"apples": "1"})
+//		sandbox.AssertList("reserved", "apples", "apples")
+//	}
+type ScriptSandbox struct {
+	t         testing.TB
+	client    interfaces.BaseClientCommands
+	ctx       context.Context
+	prefix    string
+	keys      map[string]struct{}
+	libraries []string
+}
+
+// NewScriptSandbox returns a [ScriptSandbox] running scripts with client, a [glide.Client] or a [glide.ClusterClient],
+// cleaned up when t completes.
+func NewScriptSandbox(t testing.TB, client interfaces.BaseClientCommands) *ScriptSandbox {
+	t.Helper()
+	sandbox := &ScriptSandbox{
+		t:      t,
+		client: client,
+		ctx:    context.Background(),
+		prefix: "{glidetest:" + uuid.NewString() + "}:",
+		keys:   make(map[string]struct{}),
+	}
+	t.Cleanup(sandbox.cleanup)
+	return sandbox
+}
+
+// WithContext sets the context of the commands sent by the sandbox. Defaults to context.Background().
+func (sandbox *ScriptSandbox) WithContext(ctx context.Context) *ScriptSandbox {
+	sandbox.ctx = ctx
+	return sandbox
+}
+
+// Key returns the key of the sandbox named name.
+func (sandbox *ScriptSandbox) Key(name string) string {
+	sandbox.keys[name] = struct{}{}
+	return sandbox.prefix + name
+}
+
+// keysOf returns the keys of the sandbox named names.
+func (sandbox *ScriptSandbox) keysOf(names []string) []string {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = sandbox.Key(name)
+	}
+	return keys
+}
+
+// SetString sets the fixture string name to value.
+func (sandbox *ScriptSandbox) SetString(name string, value string) *ScriptSandbox {
+	sandbox.t.Helper()
+	if _, err := sandbox.client.Set(sandbox.ctx, sandbox.Key(name), value); err != nil {
+		sandbox.t.Fatalf("setting the string %s: %v", name, err)
+	}
+	return sandbox
+}
+
+// SetHash sets the fields of the fixture hash name.
+func (sandbox *ScriptSandbox) SetHash(name string, fields map[string]string) *ScriptSandbox {
+	sandbox.t.Helper()
+	if _, err := sandbox.client.HSet(sandbox.ctx, sandbox.Key(name), fields); err != nil {
+		sandbox.t.Fatalf("setting the hash %s: %v", name, err)
+	}
+	return sandbox
+}
+
+// SetList appends elements to the fixture list name.
+func (sandbox *ScriptSandbox) SetList(name string, elements ...string) *ScriptSandbox {
+	sandbox.t.Helper()
+	if _, err := sandbox.client.RPush(sandbox.ctx, sandbox.Key(name), elements); err != nil {
+		sandbox.t.Fatalf("setting the list %s: %v", name, err)
+	}
+	return sandbox
+}
+
+// SetSet adds members to the fixture set name.
+func (sandbox *ScriptSandbox) SetSet(name string, members ...string) *ScriptSandbox {
+	sandbox.t.Helper()
+	if _, err := sandbox.client.SAdd(sandbox.ctx, sandbox.Key(name), members); err != nil {
+		sandbox.t.Fatalf("setting the set %s: %v", name, err)
+	}
+	return sandbox
+}
+
+// SetZSet adds members, with their scores, to the fixture sorted set name.
+func (sandbox *ScriptSandbox) SetZSet(name string, members map[string]float64) *ScriptSandbox {
+	sandbox.t.Helper()
+	if _, err := sandbox.client.ZAdd(sandbox.ctx, sandbox.Key(name), members); err != nil {
+		sandbox.t.Fatalf("setting the sorted set %s: %v", name, err)
+	}
+	return sandbox
+}
+
+// SetTTL sets the time to live of the fixture key name, which must be set.
+func (sandbox *ScriptSandbox) SetTTL(name string, ttl time.Duration) *ScriptSandbox {
+	sandbox.t.Helper()
+	set, err := sandbox.client.PExpire(sandbox.ctx, sandbox.Key(name), ttl)
+	if err != nil {
+		sandbox.t.Fatalf("setting the TTL of %s: %v", name, err)
+	}
+	if !set {
+		sandbox.t.Fatalf("setting the TTL of %s: the key doesn't exist", name)
+	}
+	return sandbox
+}
+
+// Run runs script with the keys named keys and args, and returns its result. The test fails if the script fails.
+func (sandbox *ScriptSandbox) Run(script *options.Script, keys []string, args ...string) any {
+	sandbox.t.Helper()
+	result, err := sandbox.run(script, keys, args)
+	if err != nil {
+		sandbox.t.Fatalf("running the script: %v", err)
+	}
+	return result
+}
+
+// RunError runs script with the keys named keys and args, and returns its error. The test fails if the script
+// doesn't fail.
+func (sandbox *ScriptSandbox) RunError(script *options.Script, keys []string, args ...string) error {
+	sandbox.t.Helper()
+	result, err := sandbox.run(script, keys, args)
+	if err == nil {
+		sandbox.t.Fatalf("running the script: expected an error, got %v", result)
+	}
+	return err
+}
+
+func (sandbox *ScriptSandbox) run(script *options.Script, keys []string, args []string) (any, error) {
+	return sandbox.client.InvokeScriptWithOptions(
+		sandbox.ctx,
+		*script,
+		*options.NewScriptOptions().WithKeys(sandbox.keysOf(keys)).WithArgs(args),
+	)
+}
+
+// LoadLibrary loads the functions of the library code, replacing the library of the same name. The test fails if the
+// library fails to load.
+func (sandbox *ScriptSandbox) LoadLibrary(code string) *ScriptSandbox {
+	sandbox.t.Helper()
+	library, err := sandbox.client.FunctionLoad(sandbox.ctx, code, true)
+	if err != nil {
+		sandbox.t.Fatalf("loading the library: %v", err)
+	}
+	sandbox.libraries = append(sandbox.libraries, library)
+	return sandbox
+}
+
+// Call calls function with the keys named keys and args, and returns its result. The test fails if the function
+// fails.
+func (sandbox *ScriptSandbox) Call(function string, keys []string, args ...string) any {
+	sandbox.t.Helper()
+	result, err := sandbox.client.FCallWithKeysAndArgs(sandbox.ctx, function, sandbox.keysOf(keys), args)
+	if err != nil {
+		sandbox.t.Fatalf("calling %s: %v", function, err)
+	}
+	return result
+}
+
+// CallError calls function with the keys named keys and args, and returns its error. The test fails if the function
+// doesn't fail.
+func (sandbox *ScriptSandbox) CallError(function string, keys []string, args ...string) error {
+	sandbox.t.Helper()
+	result, err := sandbox.client.FCallWithKeysAndArgs(sandbox.ctx, function, sandbox.keysOf(keys), args)
+	if err == nil {
+		sandbox.t.Fatalf("calling %s: expected an error, got %v", function, result)
+	}
+	return err
+}
+
+// AssertString asserts that the string name holds want.
+func (sandbox *ScriptSandbox) AssertString(name string, want string) {
+	sandbox.t.Helper()
+	value, err := sandbox.client.Get(sandbox.ctx, sandbox.Key(name))
+	switch {
+	case err != nil:
+		sandbox.t.Errorf("reading the string %s: %v", name, err)
+	case value.IsNil():
+		sandbox.t.Errorf("the string %s doesn't exist, expected %q", name, want)
+	case value.Value() != want:
+		sandbox.t.Errorf("the string %s holds %q, expected %q", name, value.Value(), want)
+	}
+}
+
+// AssertHash asserts that the hash name holds exactly want.
+func (sandbox *ScriptSandbox) AssertHash(name string, want map[string]string) {
+	sandbox.t.Helper()
+	fields, err := sandbox.client.HGetAll(sandbox.ctx, sandbox.Key(name))
+	if err != nil {
+		sandbox.t.Errorf("reading the hash %s: %v", name, err)
+		return
+	}
+	if !reflect.DeepEqual(fields, want) && (len(fields) > 0 || len(want) > 0) {
+		sandbox.t.Errorf("the hash %s holds %v, expected %v", name, fields, want)
+	}
+}
+
+// AssertList asserts that the list name holds exactly want, in order.
+func (sandbox *ScriptSandbox) AssertList(name string, want ...string) {
+	sandbox.t.Helper()
+	elements, err := sandbox.client.LRange(sandbox.ctx, sandbox.Key(name), 0, -1)
+	if err != nil {
+		sandbox.t.Errorf("reading the list %s: %v", name, err)
+		return
+	}
+	if !reflect.DeepEqual(elements, want) && (len(elements) > 0 || len(want) > 0) {
+		sandbox.t.Errorf("the list %s holds %q, expected %q", name, elements, want)
+	}
+}
+
+// AssertSet asserts that the set name holds exactly want, in any order.
+func (sandbox *ScriptSandbox) AssertSet(name string, want ...string) {
+	sandbox.t.Helper()
+	members, err := sandbox.client.SMembers(sandbox.ctx, sandbox.Key(name))
+	if err != nil {
+		sandbox.t.Errorf("reading the set %s: %v", name, err)
+		return
+	}
+	got := make([]string, 0, len(members))
+	for member := range members {
+		got = append(got, member)
+	}
+	wanted := append([]string{}, want...)
+	sort.Strings(got)
+	sort.Strings(wanted)
+	if !reflect.DeepEqual(got, wanted) {
+		sandbox.t.Errorf("the set %s holds %q, expected %q", name, got, wanted)
+	}
+}
+
+// AssertZSet asserts that the sorted set name holds exactly want, the scores of its members.
+func (sandbox *ScriptSandbox) AssertZSet(name string, want map[string]float64) {
+	sandbox.t.Helper()
+	members, err := sandbox.client.ZRangeWithScores(sandbox.ctx, sandbox.Key(name), options.NewRangeByIndexQuery(0, -1))
+	if err != nil {
+		sandbox.t.Errorf("reading the sorted set %s: %v", name, err)
+		return
+	}
+	scores := make(map[string]float64, len(members))
+	for _, member := range members {
+		scores[member.Member] = member.Score
+	}
+	if !reflect.DeepEqual(scores, want) && (len(scores) > 0 || len(want) > 0) {
+		sandbox.t.Errorf("the sorted set %s holds %v, expected %v", name, scores, want)
+	}
+}
+
+// AssertMissing asserts that the key name doesn't exist.
+func (sandbox *ScriptSandbox) AssertMissing(name string) {
+	sandbox.t.Helper()
+	count, err := sandbox.client.Exists(sandbox.ctx, []string{sandbox.Key(name)})
+	switch {
+	case err != nil:
+		sandbox.t.Errorf("checking whether %s exists: %v", name, err)
+	case count != 0:
+		sandbox.t.Errorf("the key %s exists, expected it not to", name)
+	}
+}
+
+// AssertTTL asserts that the key name expires, within at most ttl.
+func (sandbox *ScriptSandbox) AssertTTL(name string, ttl time.Duration) {
+	sandbox.t.Helper()
+	milliseconds, err := sandbox.client.PTTL(sandbox.ctx, sandbox.Key(name))
+	switch {
+	case err != nil:
+		sandbox.t.Errorf("reading the TTL of %s: %v", name, err)
+	case milliseconds == -2:
+		sandbox.t.Errorf("the key %s doesn't exist, expected it to expire within %v", name, ttl)
+	case milliseconds == -1:
+		sandbox.t.Errorf("the key %s doesn't expire, expected it to expire within %v", name, ttl)
+	case time.Duration(milliseconds)*time.Millisecond > ttl:
+		sandbox.t.Errorf(
+			"the key %s expires in %v, expected it to expire within %v",
+			name,
+			time.Duration(milliseconds)*time.Millisecond,
+			ttl,
+		)
+	}
+}
+
+// cleanup deletes the keys of the sandbox and the libraries it loaded.
+func (sandbox *ScriptSandbox) cleanup() {
+	if len(sandbox.keys) > 0 {
+		keys := make([]string, 0, len(sandbox.keys))
+		for name := range sandbox.keys {
+			keys = append(keys, sandbox.prefix+name)
+		}
+		if _, err := sandbox.client.Del(sandbox.ctx, keys); err != nil {
+			sandbox.t.Logf("deleting the keys of the sandbox: %v", err)
+		}
+	}
+	deleter, ok := sandbox.client.(functionDeleter)
+	if !ok {
+		return
+	}
+	for _, library := range sandbox.libraries {
+		if _, err := deleter.FunctionDelete(sandbox.ctx, library); err != nil {
+			sandbox.t.Logf("deleting the library %s: %v", library, err)
+		}
+	}
+}
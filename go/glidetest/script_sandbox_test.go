@@ -0,0 +1,157 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glidetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeScriptClient holds hashes and lists, and runs script as the Lua script of every EVALSHA.
+type fakeScriptClient struct {
+	interfaces.BaseClientCommands
+	hashes  map[string]map[string]string
+	lists   map[string][]string
+	script  func(client *fakeScriptClient, keys []string, args []string) (any, error)
+	deleted []string
+}
+
+func newFakeScriptClient() *fakeScriptClient {
+	return &fakeScriptClient{hashes: make(map[string]map[string]string), lists: make(map[string][]string)}
+}
+
+func (client *fakeScriptClient) HSet(ctx context.Context, key string, values map[string]string) (int64, error) {
+	if client.hashes[key] == nil {
+		client.hashes[key] = make(map[string]string)
+	}
+	for field, value := range values {
+		client.hashes[key][field] = value
+	}
+	return int64(len(values)), nil
+}
+
+func (client *fakeScriptClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return client.hashes[key], nil
+}
+
+func (client *fakeScriptClient) LRange(ctx context.Context, key string, start int64, end int64) ([]string, error) {
+	return client.lists[key], nil
+}
+
+func (client *fakeScriptClient) Get(ctx context.Context, key string) (models.Result[string], error) {
+	if _, ok := client.hashes[key]; ok {
+		return models.CreateNilStringResult(), errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	}
+	return models.CreateNilStringResult(), nil
+}
+
+func (client *fakeScriptClient) Exists(ctx context.Context, keys []string) (int64, error) {
+	var count int64
+	for _, key := range keys {
+		if _, ok := client.hashes[key]; ok {
+			count++
+		} else if _, ok := client.lists[key]; ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (client *fakeScriptClient) Del(ctx context.Context, keys []string) (int64, error) {
+	client.deleted = append(client.deleted, keys...)
+	return int64(len(keys)), nil
+}
+
+func (client *fakeScriptClient) InvokeScriptWithOptions(
+	ctx context.Context,
+	script options.Script,
+	scriptOptions options.ScriptOptions,
+) (any, error) {
+	return client.script(client, scriptOptions.Keys, scriptOptions.Args)
+}
+
+// reserve moves count units of item from the stock of the hash KEYS[1] to the list KEYS[2].
+func reserve(client *fakeScriptClient, keys []string, args []string) (any, error) {
+	stock, _ := strconv.Atoi(client.hashes[keys[0]][args[0]])
+	count, _ := strconv.Atoi(args[1])
+	if count > stock {
+		return nil, fmt.Errorf("insufficient stock of %s", args[0])
+	}
+	client.hashes[keys[0]][args[0]] = strconv.Itoa(stock - count)
+	for i := 0; i < count; i++ {
+		client.lists[keys[1]] = append(client.lists[keys[1]], args[0])
+	}
+	return int64(stock - count), nil
+}
+
+// recordingT records the failures of a test instead of failing it.
+type recordingT struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+}
+
+func (t *recordingT) Cleanup(cleanup func()) {
+	t.cleanups = append(t.cleanups, cleanup)
+}
+
+func TestScriptSandbox(t *testing.T) {
+	client := newFakeScriptClient()
+	client.script = reserve
+	recorder := &recordingT{TB: t}
+	sandbox := NewScriptSandbox(recorder, client)
+
+	stock := sandbox.Key("stock")
+	assert.Regexp(t, `^\{glidetest:[0-9a-f-]+\}:stock$`, stock)
+
+	sandbox.SetHash("stock", map[string]string{"apples": "3"})
+	result := sandbox.Run(&options.Script{}, []string{"stock", "reserved"}, "apples", "2")
+	assert.Equal(t, int64(1), result)
+	sandbox.AssertHash("stock", map[string]string{"apples": "1"})
+	sandbox.AssertList("reserved", "apples", "apples")
+	sandbox.AssertMissing("released")
+	assert.Empty(t, recorder.errors)
+
+	err := sandbox.RunError(&options.Script{}, []string{"stock", "reserved"}, "apples", "2")
+	assert.EqualError(t, err, "insufficient stock of apples")
+	assert.Empty(t, recorder.errors)
+
+	// The failed assertions are reported with the names of the keys rather than the keys.
+	sandbox.AssertHash("stock", map[string]string{"apples": "3"})
+	sandbox.AssertList("reserved", "apples")
+	sandbox.AssertMissing("stock")
+	sandbox.AssertString("stock", "3")
+	sandbox.Run(&options.Script{}, []string{"stock", "reserved"}, "apples", "5")
+	assert.Equal(t, []string{
+		"the hash stock holds map[apples:1], expected map[apples:3]",
+		`the list reserved holds ["apples" "apples"], expected ["apples"]`,
+		"the key stock exists, expected it not to",
+		"reading the string stock: WRONGTYPE Operation against a key holding the wrong kind of value",
+		"running the script: insufficient stock of apples",
+	}, recorder.errors)
+
+	// The keys of the sandbox are deleted once the test completes.
+	require.Len(t, recorder.cleanups, 1)
+	recorder.cleanups[0]()
+	assert.ElementsMatch(t, []string{stock, sandbox.Key("reserved"), sandbox.Key("released")}, client.deleted)
+}
@@ -153,6 +153,44 @@ func ExampleClusterClient_HMGet() {
 	// {someOtherValue false}
 }
 
+func ExampleClient_HMGetMulti() {
+	var client *Client = getExampleClient() // example helper function
+
+	_, err := client.HSet(context.Background(), "user:1", map[string]string{"name": "Ada", "email": "ada@example.com"})
+	_, err = client.HSet(context.Background(), "user:2", map[string]string{"name": "Alan"})
+	values, err := client.HMGetMulti(context.Background(), []string{"user:1", "user:2"}, []string{"name", "email"})
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(values["user:1"]["email"])
+	fmt.Println(values["user:2"]["name"])
+	fmt.Println(values["user:2"]["email"].IsNil())
+
+	// Output:
+	// {ada@example.com false}
+	// {Alan false}
+	// true
+}
+
+func ExampleClusterClient_HMGetMulti() {
+	var client *ClusterClient = getExampleClusterClient() // example helper function
+
+	_, err := client.HSet(context.Background(), "user:1", map[string]string{"name": "Ada", "email": "ada@example.com"})
+	_, err = client.HSet(context.Background(), "user:2", map[string]string{"name": "Alan"})
+	values, err := client.HMGetMulti(context.Background(), []string{"user:1", "user:2"}, []string{"name", "email"})
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(values["user:1"]["email"])
+	fmt.Println(values["user:2"]["name"])
+	fmt.Println(values["user:2"]["email"].IsNil())
+
+	// Output:
+	// {ada@example.com false}
+	// {Alan false}
+	// true
+}
+
 func ExampleClient_HSet() {
 	var client *Client = getExampleClient() // example helper function
 
@@ -726,6 +764,41 @@ func ExampleClusterClient_HRandFieldWithCountWithValues() {
 	// Output: true
 }
 
+func ExampleClient_HRandFieldWithCountWithFieldValues() {
+	var client *Client = getExampleClient() // example helper function
+
+	fields := map[string]string{
+		"field1": "someValue",
+		"field2": "someOtherValue",
+	}
+	client.HSet(context.Background(), "my_hash", fields)
+	result, err := client.HRandFieldWithCountWithFieldValues(context.Background(), "my_hash", 2)
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(len(result) == 2)
+
+	// Output: true
+}
+
+func ExampleClusterClient_HRandFieldWithCountWithFieldValues() {
+	var client *ClusterClient = getExampleClusterClient() // example helper function
+
+	fields := map[string]string{
+		"field1": "someValue",
+		"field2": "someOtherValue",
+	}
+
+	client.HSet(context.Background(), "my_hash", fields)
+	result, err := client.HRandFieldWithCountWithFieldValues(context.Background(), "my_hash", 2)
+	if err != nil {
+		fmt.Println("Glide example failed with an error: ", err)
+	}
+	fmt.Println(len(result) == 2)
+
+	// Output: true
+}
+
 func ExampleClient_HScanWithOptions() {
 	var client *Client = getExampleClient() // example helper function
 
@@ -0,0 +1,227 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// DefaultHealthzTimeout bounds the checks of a [HealthHandler], unless configured otherwise.
+const DefaultHealthzTimeout = time.Second
+
+// HealthStatus is the overall status reported by a [HealthHandler].
+type HealthStatus string
+
+const (
+	// HealthOK reports that the client is open and all the nodes are connected.
+	HealthOK HealthStatus = "ok"
+	// HealthDegraded reports that some replicas aren't connected, while all the primaries are.
+	HealthDegraded HealthStatus = "degraded"
+	// HealthUnavailable reports that the client is closed, or that a primary isn't connected.
+	HealthUnavailable HealthStatus = "unavailable"
+)
+
+// NodeHealth reports the connectivity of a node.
+type NodeHealth struct {
+	// Address is the address of a cluster node. It's empty for the server of a standalone client.
+	Address string `json:"address,omitempty"`
+	// Primary tells whether the node is a primary.
+	Primary bool `json:"primary"`
+	// Connected tells whether the node answered the PING of the check, when pinging is enabled. Otherwise, for
+	// cluster nodes, it tells whether the node is connected to the cluster, as reported by CLUSTER NODES.
+	Connected bool `json:"connected"`
+	// PingLatencyMs is the round trip time of the PING of the check in milliseconds, if the node answered it.
+	PingLatencyMs float64 `json:"ping_latency_ms,omitempty"`
+	// Error is the error of the PING of the node, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// HealthReport is the report served by a [HealthHandler], encoded in JSON.
+type HealthReport struct {
+	Status HealthStatus `json:"status"`
+	// Nodes are the nodes checked. For standalone clients, they're only checked when pinging is enabled.
+	Nodes []NodeHealth `json:"nodes,omitempty"`
+	// Error explains why the client is unavailable, if the check itself failed.
+	Error string `json:"error,omitempty"`
+}
+
+// healthChecker is implemented by [Client] and [ClusterClient].
+type healthChecker interface {
+	checkHealth(ctx context.Context, ping bool) HealthReport
+}
+
+// HealthHandler is an [http.Handler] reporting the connectivity of a client, suitable as a readiness or liveness
+// probe, e.g. of Kubernetes, for services which can't serve without Valkey. It responds with 200 OK when the client
+// is healthy or degraded, and with 503 Service Unavailable otherwise, with a [HealthReport] in JSON.
+//
+// Without pinging, the handler only checks that the client is open and, for cluster clients, the nodes of the
+// cluster as reported by CLUSTER NODES. With pinging enabled, every node is sent a PING, all the pings being bounded
+// by the timeout of the handler: use it for readiness probes, and leave it disabled for cheap liveness probes.
+//
+// Example usage:
+//
+//	http.Handle("/healthz", glide.Healthz(client))
+//	http.Handle("/readyz", glide.Healthz(client).WithPing(true).WithTimeout(500*time.Millisecond))
+type HealthHandler struct {
+	client  healthChecker
+	ping    bool
+	timeout time.Duration
+}
+
+// Healthz returns a [HealthHandler] reporting the connectivity of client, a [Client] or a [ClusterClient].
+func Healthz(client healthChecker) *HealthHandler {
+	return &HealthHandler{client: client, timeout: DefaultHealthzTimeout}
+}
+
+// WithPing sets whether the nodes are sent a PING on every check. Disabled by default.
+func (handler *HealthHandler) WithPing(enabled bool) *HealthHandler {
+	handler.ping = enabled
+	return handler
+}
+
+// WithTimeout sets the time a check may take at most. Defaults to DefaultHealthzTimeout.
+func (handler *HealthHandler) WithTimeout(timeout time.Duration) *HealthHandler {
+	handler.timeout = timeout
+	return handler
+}
+
+// ServeHTTP checks the client, and responds with its [HealthReport].
+func (handler *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), handler.timeout)
+	defer cancel()
+	report := handler.client.checkHealth(ctx, handler.ping)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status == HealthUnavailable {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (client *Client) checkHealth(ctx context.Context, ping bool) HealthReport {
+	if client.isClosed() {
+		return HealthReport{Status: HealthUnavailable, Error: "the client is closed"}
+	}
+	if !ping {
+		return HealthReport{Status: HealthOK}
+	}
+	node := NodeHealth{Primary: true}
+	pingNode(&node, func() error {
+		_, err := client.Ping(ctx)
+		return err
+	})
+	return healthReport([]NodeHealth{node})
+}
+
+func (client *ClusterClient) checkHealth(ctx context.Context, ping bool) HealthReport {
+	if client.isClosed() {
+		return HealthReport{Status: HealthUnavailable, Error: "the client is closed"}
+	}
+	clusterNodes, err := client.ClusterNodes(ctx)
+	if err != nil {
+		return HealthReport{Status: HealthUnavailable, Error: "failed to get the nodes of the cluster: " + err.Error()}
+	}
+	nodes := parseClusterNodesHealth(clusterNodes)
+	if ping {
+		var wg sync.WaitGroup
+		for i := range nodes {
+			wg.Add(1)
+			go func(node *NodeHealth) {
+				defer wg.Done()
+				host, port, ok := splitNodeAddress(node.Address)
+				if !ok {
+					node.Connected = false
+					node.Error = "invalid node address"
+					return
+				}
+				route := config.NewByAddressRoute(host, port)
+				pingNode(node, func() error {
+					_, err := client.PingWithOptions(ctx, options.ClusterPingOptions{RouteOption: &options.RouteOption{Route: route}})
+					return err
+				})
+			}(&nodes[i])
+		}
+		wg.Wait()
+	}
+	return healthReport(nodes)
+}
+
+// pingNode sends a PING to node with ping, and records its outcome in node.
+func pingNode(node *NodeHealth, ping func() error) {
+	start := time.Now()
+	if err := ping(); err != nil {
+		node.Connected = false
+		node.Error = err.Error()
+		return
+	}
+	node.Connected = true
+	node.PingLatencyMs = float64(time.Since(start).Microseconds()) / 1000
+}
+
+// healthReport returns the report of the checked nodes: unavailable if a primary isn't connected, and degraded if a
+// replica isn't.
+func healthReport(nodes []NodeHealth) HealthReport {
+	report := HealthReport{Status: HealthOK, Nodes: nodes}
+	for _, node := range nodes {
+		switch {
+		case node.Connected:
+		case node.Primary:
+			report.Status = HealthUnavailable
+		case report.Status == HealthOK:
+			report.Status = HealthDegraded
+		}
+	}
+	return report
+}
+
+// parseClusterNodesHealth returns the nodes listed by CLUSTER NODES, connected unless they're flagged as failing
+// or their link to the node which reported them is down. Nodes without an address, or in handshake, are skipped.
+func parseClusterNodesHealth(clusterNodes string) []NodeHealth {
+	var nodes []NodeHealth
+	for _, line := range strings.Split(clusterNodes, "\n") {
+		// <id> <ip:port@cport[,hostname]> <flags> <primary> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot>...
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+		flags := make(map[string]bool)
+		for _, flag := range strings.Split(fields[2], ",") {
+			flags[flag] = true
+		}
+		if flags["noaddr"] || flags["handshake"] {
+			continue
+		}
+		address, _, _ := strings.Cut(fields[1], "@")
+		nodes = append(nodes, NodeHealth{
+			Address:   address,
+			Primary:   flags["master"],
+			Connected: flags["myself"] || (fields[7] == "connected" && !flags["fail"] && !flags["fail?"]),
+		})
+	}
+	return nodes
+}
+
+// splitNodeAddress splits a host:port address, whose host may be an IPv6 address without brackets.
+func splitNodeAddress(address string) (string, int32, bool) {
+	separator := strings.LastIndex(address, ":")
+	if separator <= 0 {
+		return "", 0, false
+	}
+	port, err := strconv.ParseInt(address[separator+1:], 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+	return strings.Trim(address[:separator], "[]"), int32(port), true
+}
@@ -0,0 +1,109 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHealthChecker struct {
+	report HealthReport
+	ping   bool
+	ctx    context.Context
+}
+
+func (checker *fakeHealthChecker) checkHealth(ctx context.Context, ping bool) HealthReport {
+	checker.ctx = ctx
+	checker.ping = ping
+	return checker.report
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		status HealthStatus
+		code   int
+	}{
+		{HealthOK, http.StatusOK},
+		{HealthDegraded, http.StatusOK},
+		{HealthUnavailable, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			checker := &fakeHealthChecker{report: HealthReport{
+				Status: tt.status,
+				Nodes:  []NodeHealth{{Address: "10.0.0.1:6379", Primary: true, Connected: true}},
+			}}
+			recorder := httptest.NewRecorder()
+			Healthz(checker).WithPing(true).WithTimeout(time.Minute).
+				ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.code, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.True(t, checker.ping)
+			deadline, ok := checker.ctx.Deadline()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+			var report HealthReport
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			assert.Equal(t, checker.report, report)
+		})
+	}
+}
+
+func TestHealthReport(t *testing.T) {
+	assert.Equal(t, HealthOK, healthReport(nil).Status)
+	assert.Equal(t, HealthOK, healthReport([]NodeHealth{{Primary: true, Connected: true}}).Status)
+	assert.Equal(t, HealthDegraded, healthReport([]NodeHealth{{Primary: true, Connected: true}, {}}).Status)
+	assert.Equal(t, HealthUnavailable, healthReport([]NodeHealth{{}, {Primary: true}}).Status)
+}
+
+func TestPingNode(t *testing.T) {
+	node := NodeHealth{}
+	pingNode(&node, func() error { return nil })
+	assert.True(t, node.Connected)
+	assert.Empty(t, node.Error)
+
+	pingNode(&node, func() error { return errors.New("connection refused") })
+	assert.False(t, node.Connected)
+	assert.Equal(t, "connection refused", node.Error)
+}
+
+func TestParseClusterNodesHealth(t *testing.T) {
+	clusterNodes := "" +
+		"e7d1eec 10.0.0.1:6379@16379,primary-1 myself,master - 0 0 1 connected 0-8191\n" +
+		"67ed2db 10.0.0.2:6379@16379 master,fail - 1 1 2 disconnected 8192-16383\n" +
+		"292f8b3 10.0.0.3:6379@16379 slave e7d1eec 0 1 1 connected\n" +
+		"6ec2392 10.0.0.4:6379@16379 slave,fail? 67ed2db 0 1 2 connected\n" +
+		"824fe11 :0@0 master,noaddr - 1 1 3 disconnected\n"
+	assert.Equal(t, []NodeHealth{
+		{Address: "10.0.0.1:6379", Primary: true, Connected: true},
+		{Address: "10.0.0.2:6379", Primary: true, Connected: false},
+		{Address: "10.0.0.3:6379", Connected: true},
+		{Address: "10.0.0.4:6379", Connected: false},
+	}, parseClusterNodesHealth(clusterNodes))
+}
+
+func TestSplitNodeAddress(t *testing.T) {
+	host, port, ok := splitNodeAddress("10.0.0.1:6379")
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.1", host)
+	assert.Equal(t, int32(6379), port)
+
+	host, port, ok = splitNodeAddress("::1:6380")
+	assert.True(t, ok)
+	assert.Equal(t, "::1", host)
+	assert.Equal(t, int32(6380), port)
+
+	_, _, ok = splitNodeAddress("invalid")
+	assert.False(t, ok)
+}
@@ -0,0 +1,214 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// hedgedRequestTypes are the reads routed by their key which are hedged.
+var hedgedRequestTypes = map[protobuf.RequestType]struct{}{
+	protobuf.RequestType_Get:              {},
+	protobuf.RequestType_MGet:             {},
+	protobuf.RequestType_GetRange:         {},
+	protobuf.RequestType_Strlen:           {},
+	protobuf.RequestType_Exists:           {},
+	protobuf.RequestType_Type:             {},
+	protobuf.RequestType_TTL:              {},
+	protobuf.RequestType_PTTL:             {},
+	protobuf.RequestType_GetBit:           {},
+	protobuf.RequestType_BitCount:         {},
+	protobuf.RequestType_HGet:             {},
+	protobuf.RequestType_HMGet:            {},
+	protobuf.RequestType_HGetAll:          {},
+	protobuf.RequestType_HExists:          {},
+	protobuf.RequestType_HKeys:            {},
+	protobuf.RequestType_HVals:            {},
+	protobuf.RequestType_HLen:             {},
+	protobuf.RequestType_HStrlen:          {},
+	protobuf.RequestType_LIndex:           {},
+	protobuf.RequestType_LLen:             {},
+	protobuf.RequestType_LPos:             {},
+	protobuf.RequestType_LRange:           {},
+	protobuf.RequestType_SCard:            {},
+	protobuf.RequestType_SIsMember:        {},
+	protobuf.RequestType_SMIsMember:       {},
+	protobuf.RequestType_SMembers:         {},
+	protobuf.RequestType_ZCard:            {},
+	protobuf.RequestType_ZCount:           {},
+	protobuf.RequestType_ZMScore:          {},
+	protobuf.RequestType_ZRange:           {},
+	protobuf.RequestType_ZRank:            {},
+	protobuf.RequestType_ZRevRank:         {},
+	protobuf.RequestType_ZScore:           {},
+	protobuf.RequestType_PfCount:          {},
+	protobuf.RequestType_GeoDist:          {},
+	protobuf.RequestType_GeoHash:          {},
+	protobuf.RequestType_GeoPos:           {},
+	protobuf.RequestType_GeoSearch:        {},
+	protobuf.RequestType_XLen:             {},
+	protobuf.RequestType_XRange:           {},
+	protobuf.RequestType_XRevRange:        {},
+	protobuf.RequestType_JsonGet:          {},
+	protobuf.RequestType_JsonMGet:         {},
+	protobuf.RequestType_ObjectEncoding:   {},
+	protobuf.RequestType_BitFieldReadOnly: {},
+}
+
+// readsFromReplicas reports whether the client created with request may route its reads to replicas: with a
+// [config.ReadFrom] other than [config.Primary], in cluster mode or with the addresses of several standalone nodes.
+// Hedging a read served by the primary would send its duplicate to the same node.
+func readsFromReplicas(request *protobuf.ConnectionRequest) bool {
+	return request.GetReadFrom() != protobuf.ReadFrom_Primary &&
+		(request.GetClusterModeEnabled() || len(request.GetAddresses()) > 1)
+}
+
+// readHedger hedges the reads of a client, as configured by a [config.HedgingConfiguration].
+type readHedger struct {
+	delay time.Duration
+	// ratio is the number of hedges earned by each read.
+	ratio float64
+	burst float64
+
+	mu sync.Mutex
+	// tokens is the number of hedges which may be sent, at most burst.
+	tokens float64
+
+	reads           atomic.Uint64
+	hedged          atomic.Uint64
+	hedgeWins       atomic.Uint64
+	budgetExhausted atomic.Uint64
+}
+
+func newReadHedger(hedgingConfig *config.HedgingConfiguration) *readHedger {
+	burst := float64(hedgingConfig.GetBurst())
+	return &readHedger{
+		delay:  hedgingConfig.GetDelay(),
+		ratio:  hedgingConfig.GetBudgetPercentage() / 100,
+		burst:  burst,
+		tokens: burst,
+	}
+}
+
+// hedges reports whether requests of requestType with route are hedged.
+func (hedger *readHedger) hedges(requestType uint32, route config.Route) bool {
+	if route != nil {
+		return false
+	}
+	_, ok := hedgedRequestTypes[protobuf.RequestType(requestType)]
+	return ok
+}
+
+// deposit records a read, which earns a fraction of a hedge.
+func (hedger *readHedger) deposit() {
+	hedger.reads.Add(1)
+	hedger.mu.Lock()
+	hedger.tokens = min(hedger.burst, hedger.tokens+hedger.ratio)
+	hedger.mu.Unlock()
+}
+
+// withdraw reports whether a hedge may be sent, and spends it if so.
+func (hedger *readHedger) withdraw() bool {
+	hedger.mu.Lock()
+	defer hedger.mu.Unlock()
+	if hedger.tokens < 1 {
+		hedger.budgetExhausted.Add(1)
+		return false
+	}
+	hedger.tokens--
+	hedger.hedged.Add(1)
+	return true
+}
+
+func (hedger *readHedger) statistics() models.HedgingStatistics {
+	return models.HedgingStatistics{
+		Reads:           hedger.reads.Load(),
+		Hedged:          hedger.hedged.Load(),
+		HedgeWins:       hedger.hedgeWins.Load(),
+		BudgetExhausted: hedger.budgetExhausted.Load(),
+	}
+}
+
+// hedgeResult is the result of an attempt of a hedged read.
+type hedgeResult[T any] struct {
+	value T
+	err   error
+	hedge bool
+}
+
+// hedge returns the result of send, or of a duplicate sent if send hasn't returned within the delay of hedger and the
+// budget allows it, whichever succeeds first. The attempt which loses is canceled, and its value, if it succeeded
+// anyway, is passed to discard.
+func hedge[T any](
+	ctx context.Context,
+	hedger *readHedger,
+	send func(ctx context.Context) (T, error),
+	discard func(T),
+) (T, error) {
+	hedger.deposit()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan hedgeResult[T], 2)
+	start := func(isHedge bool) {
+		go func() {
+			value, err := send(ctx)
+			results <- hedgeResult[T]{value: value, err: err, hedge: isHedge}
+		}()
+	}
+
+	start(false)
+	timer := time.NewTimer(hedger.delay)
+	defer timer.Stop()
+	select {
+	case result := <-results:
+		return result.value, result.err
+	case <-timer.C:
+	}
+	if !hedger.withdraw() {
+		result := <-results
+		return result.value, result.err
+	}
+	start(true)
+
+	result := <-results
+	if result.err != nil {
+		// The other attempt may still succeed.
+		if other := <-results; other.err == nil {
+			result = other
+		} else {
+			return result.value, result.err
+		}
+	} else {
+		cancel()
+		go func() {
+			if other := <-results; other.err == nil {
+				discard(other.value)
+			}
+		}()
+	}
+	if result.hedge {
+		hedger.hedgeWins.Add(1)
+	}
+	return result.value, nil
+}
+
+// GetHedgingStatistics returns the statistics of the read hedging of the client, when the client is configured with
+// a [config.HedgingConfiguration].
+//
+// Return value:
+//
+//	The statistics of the hedged reads since the client was created, or zero statistics if read hedging is
+//	disabled.
+func (client *baseClient) GetHedgingStatistics() models.HedgingStatistics {
+	if client.hedger == nil {
+		return models.HedgingStatistics{}
+	}
+	return client.hedger.statistics()
+}
@@ -0,0 +1,141 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestReadHedger_Hedges(t *testing.T) {
+	hedger := newReadHedger(config.NewHedgingConfiguration(time.Millisecond))
+	assert.True(t, hedger.hedges(uint32(protobuf.RequestType_Get), nil))
+	assert.True(t, hedger.hedges(uint32(protobuf.RequestType_HGetAll), nil))
+	assert.False(t, hedger.hedges(uint32(protobuf.RequestType_Set), nil))
+	assert.False(t, hedger.hedges(uint32(protobuf.RequestType_Get), config.RandomRoute))
+}
+
+func TestReadHedger_Budget(t *testing.T) {
+	hedger := newReadHedger(config.NewHedgingConfiguration(time.Millisecond).WithBudgetPercentage(50).WithBurst(1))
+	assert.True(t, hedger.withdraw())
+	assert.False(t, hedger.withdraw())
+	hedger.deposit()
+	assert.False(t, hedger.withdraw())
+	hedger.deposit()
+	assert.True(t, hedger.withdraw())
+	// The tokens don't accumulate beyond the burst.
+	for range 10 {
+		hedger.deposit()
+	}
+	assert.True(t, hedger.withdraw())
+	assert.False(t, hedger.withdraw())
+	assert.Equal(t, models.HedgingStatistics{Reads: 12, Hedged: 3, BudgetExhausted: 3}, hedger.statistics())
+}
+
+func TestHedge_Fast(t *testing.T) {
+	hedger := newReadHedger(config.NewHedgingConfiguration(time.Hour))
+	var attempts atomic.Int32
+	value, err := hedge(context.Background(), hedger, func(context.Context) (string, error) {
+		attempts.Add(1)
+		return "value", nil
+	}, func(string) {})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, int32(1), attempts.Load())
+	assert.Equal(t, models.HedgingStatistics{Reads: 1}, hedger.statistics())
+}
+
+func TestHedge_SlowAttempt(t *testing.T) {
+	hedger := newReadHedger(config.NewHedgingConfiguration(time.Millisecond))
+	var attempts atomic.Int32
+	discarded := make(chan string, 1)
+	value, err := hedge(context.Background(), hedger, func(ctx context.Context) (string, error) {
+		if attempts.Add(1) == 1 {
+			// The first attempt is slow, and succeeds anyway once canceled.
+			<-ctx.Done()
+			return "slow", nil
+		}
+		return "hedge", nil
+	}, func(value string) { discarded <- value })
+	assert.NoError(t, err)
+	assert.Equal(t, "hedge", value)
+	assert.Equal(t, "slow", <-discarded)
+	assert.Equal(t, models.HedgingStatistics{Reads: 1, Hedged: 1, HedgeWins: 1}, hedger.statistics())
+}
+
+func TestHedge_FailedAttempt(t *testing.T) {
+	hedger := newReadHedger(config.NewHedgingConfiguration(time.Millisecond))
+	release := make(chan struct{})
+	var attempts atomic.Int32
+	value, err := hedge(context.Background(), hedger, func(ctx context.Context) (string, error) {
+		if attempts.Add(1) == 2 {
+			close(release)
+			return "", errors.New("connection reset")
+		}
+		// The first attempt succeeds once the hedge failed.
+		<-release
+		return "value", nil
+	}, func(string) {})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, models.HedgingStatistics{Reads: 1, Hedged: 1}, hedger.statistics())
+
+	value, err = hedge(context.Background(), hedger, func(context.Context) (string, error) {
+		time.Sleep(5 * time.Millisecond)
+		return "", errors.New("timeout")
+	}, func(string) {})
+	assert.EqualError(t, err, "timeout")
+	assert.Empty(t, value)
+}
+
+func TestHedge_BudgetExhausted(t *testing.T) {
+	hedger := newReadHedger(config.NewHedgingConfiguration(time.Millisecond).WithBurst(1))
+	hedger.tokens = 0
+	var attempts atomic.Int32
+	value, err := hedge(context.Background(), hedger, func(context.Context) (string, error) {
+		attempts.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return "value", nil
+	}, func(string) {})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, int32(1), attempts.Load())
+	assert.Equal(t, models.HedgingStatistics{Reads: 1, BudgetExhausted: 1}, hedger.statistics())
+}
+
+func TestReadsFromReplicas(t *testing.T) {
+	address := &protobuf.NodeAddress{Host: "localhost", Port: 6379}
+	replica := &protobuf.NodeAddress{Host: "localhost", Port: 6380}
+	tests := []struct {
+		name     string
+		request  *protobuf.ConnectionRequest
+		expected bool
+	}{
+		{"primary", &protobuf.ConnectionRequest{ClusterModeEnabled: true}, false},
+		{"cluster replicas", &protobuf.ConnectionRequest{ClusterModeEnabled: true, ReadFrom: protobuf.ReadFrom_PreferReplica}, true},
+		{
+			"standalone node",
+			&protobuf.ConnectionRequest{Addresses: []*protobuf.NodeAddress{address}, ReadFrom: protobuf.ReadFrom_PreferReplica},
+			false,
+		},
+		{
+			"standalone replicas",
+			&protobuf.ConnectionRequest{
+				Addresses: []*protobuf.NodeAddress{address, replica},
+				ReadFrom:  protobuf.ReadFrom_AZAffinity,
+			},
+			true,
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, readsFromReplicas(test.request), test.name)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// inspectLengthCommands are the commands returning the length of a value, by type. They're sent after TYPE, OBJECT
+// ENCODING, PTTL and MEMORY USAGE in the pipeline of Inspect.
+var inspectLengthCommands = []struct {
+	valueType   string
+	requestType protobuf.RequestType
+}{
+	{"string", protobuf.RequestType_Strlen},
+	{"list", protobuf.RequestType_LLen},
+	{"set", protobuf.RequestType_SCard},
+	{"zset", protobuf.RequestType_ZCard},
+	{"hash", protobuf.RequestType_HLen},
+	{"stream", protobuf.RequestType_XLen},
+}
+
+// Inspect returns a summary of key and its value: its type, encoding, TTL, approximate memory usage and length. The
+// summary is read in a single non-atomic pipeline of TYPE, OBJECT ENCODING, PTTL, MEMORY USAGE and the length commands
+// of the types, e.g. STRLEN or ZCARD, of which only the one matching the type of the value is used: the key may
+// change between the commands.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key to inspect.
+//
+// Return value:
+//
+//	The summary of the key, with Exists set to false if the key doesn't exist.
+func (client *baseClient) Inspect(ctx context.Context, key string) (models.KeyInspection, error) {
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{Commands: []internal.Cmd{
+		internal.MakeCmd(uint32(protobuf.RequestType_Type), []string{key}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_ObjectEncoding), []string{key}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_PTTL), []string{key}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_MemoryUsage), []string{key}, identity),
+	}}
+	for _, length := range inspectLengthCommands {
+		batch.Commands = append(batch.Commands, internal.MakeCmd(uint32(length.requestType), []string{key}, identity))
+	}
+	responses, err := client.executeBatch(ctx, batch, false, nil)
+	if err != nil {
+		return models.KeyInspection{Key: key}, err
+	}
+	return parseKeyInspection(key, responses)
+}
+
+// parseKeyInspection returns the summary of key from the responses of the pipeline of Inspect.
+func parseKeyInspection(key string, responses []any) (models.KeyInspection, error) {
+	inspection := models.KeyInspection{
+		Key:         key,
+		MemoryUsage: models.CreateNilInt64Result(),
+		Length:      models.CreateNilInt64Result(),
+	}
+	if len(responses) != 4+len(inspectLengthCommands) {
+		return inspection, fmt.Errorf("unexpected number of responses to inspect a key: %d", len(responses))
+	}
+	// The errors of the summary itself are returned, the others leave their field empty.
+	for i, command := range []string{"TYPE", "OBJECT ENCODING", "PTTL"} {
+		if err, ok := responses[i].(error); ok {
+			return inspection, fmt.Errorf("failed to inspect key %q, %s failed: %w", key, command, err)
+		}
+	}
+	valueType, ok := responses[0].(string)
+	if !ok {
+		return inspection, fmt.Errorf("unexpected type of the response of TYPE: %T", responses[0])
+	}
+	if valueType == "none" {
+		return inspection, nil
+	}
+	inspection.Exists = true
+	inspection.Type = valueType
+	if encoding, ok := responses[1].(string); ok {
+		inspection.Encoding = encoding
+	}
+	if ttl, ok := responses[2].(int64); ok && ttl >= 0 {
+		inspection.Expires = true
+		inspection.TTL = time.Duration(ttl) * time.Millisecond
+	}
+	if memory, ok := responses[3].(int64); ok {
+		inspection.MemoryUsage = models.CreateInt64Result(memory)
+	}
+	for i, length := range inspectLengthCommands {
+		if length.valueType != valueType {
+			continue
+		}
+		if value, ok := responses[4+i].(int64); ok {
+			inspection.Length = models.CreateInt64Result(value)
+		}
+	}
+	return inspection, nil
+}
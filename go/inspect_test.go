@@ -0,0 +1,52 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyInspection(t *testing.T) {
+	wrongType := NewRequestError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	inspection, err := parseKeyInspection("leaderboard", []any{
+		"zset", "listpack", int64(1500), int64(128),
+		wrongType, wrongType, wrongType, int64(42), wrongType, wrongType,
+	})
+	require.NoError(t, err)
+	assert.True(t, inspection.Exists)
+	assert.Equal(t, "leaderboard", inspection.Key)
+	assert.Equal(t, "zset", inspection.Type)
+	assert.Equal(t, "listpack", inspection.Encoding)
+	assert.True(t, inspection.Expires)
+	assert.Equal(t, 1500*time.Millisecond, inspection.TTL)
+	assert.Equal(t, int64(128), inspection.MemoryUsage.Value())
+	assert.Equal(t, int64(42), inspection.Length.Value())
+
+	// MEMORY USAGE may be denied, and module types have no known length.
+	inspection, err = parseKeyInspection("doc", []any{
+		"ReJSON-RL", "raw", int64(-1), NewRequestError("NOPERM this user has no permissions"),
+		wrongType, wrongType, wrongType, wrongType, wrongType, wrongType,
+	})
+	require.NoError(t, err)
+	assert.False(t, inspection.Expires)
+	assert.True(t, inspection.MemoryUsage.IsNil())
+	assert.True(t, inspection.Length.IsNil())
+
+	inspection, err = parseKeyInspection("missing", []any{
+		"none", nil, int64(-2), nil, int64(0), int64(0), int64(0), int64(0), int64(0), int64(0),
+	})
+	require.NoError(t, err)
+	assert.False(t, inspection.Exists)
+	assert.Empty(t, inspection.Type)
+
+	_, err = parseKeyInspection("key", []any{
+		NewRequestError("NOPERM this user has no permissions"), nil, nil, nil, nil, nil, nil, nil, nil, nil,
+	})
+	assert.ErrorContains(t, err, "TYPE failed")
+	_, err = parseKeyInspection("key", []any{"string"})
+	assert.Error(t, err)
+}
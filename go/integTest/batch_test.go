@@ -4,7 +4,6 @@ package integTest
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -1247,6 +1246,15 @@ func CreateHashTest(batch *pipeline.ClusterBatch, isAtomic bool, serverVer strin
 		CommandTestData{ExpectedResponse: [][]string{{"counter", "10"}}, TestName: "HRandFieldWithCountWithValues(key, 1)"},
 	)
 
+	batch.HRandFieldWithCountWithFieldValues(key, 1)
+	testData = append(
+		testData,
+		CommandTestData{
+			ExpectedResponse: []models.FieldValue{{Field: "counter", Value: "10"}},
+			TestName:         "HRandFieldWithCountWithFieldValues(key, 1)",
+		},
+	)
+
 	// Hash field expiration commands (Valkey 9.0+)
 	if serverVer >= "9.0.0" {
 		// Test HSetEx - Set fields with expiration
@@ -2519,13 +2527,13 @@ func CreateScriptTest(batch *pipeline.ClusterBatch, isAtomic bool, serverVer str
 		batch.ScriptShow("abc")
 		testData = append(
 			testData,
-			CommandTestData{ExpectedResponse: errors.New(""), CheckTypeOnly: true, TestName: "ScriptShow()"},
+			CommandTestData{ExpectedResponse: &glide.RequestError{}, CheckTypeOnly: true, TestName: "ScriptShow()"},
 		)
 	}
 	batch.ScriptKill()
 	testData = append(
 		testData,
-		CommandTestData{ExpectedResponse: errors.New(""), CheckTypeOnly: true, TestName: "ScriptKill()"},
+		CommandTestData{ExpectedResponse: &glide.RequestError{}, CheckTypeOnly: true, TestName: "ScriptKill()"},
 	)
 
 	return BatchTestData{CommandTestData: testData, TestName: "Script commands"}
@@ -2598,20 +2606,20 @@ func CreateFunctionTest(batch *pipeline.ClusterBatch, isAtomic bool, serverVer s
 	batch.FunctionKill()
 	testData = append(
 		testData,
-		CommandTestData{ExpectedResponse: errors.New(""), CheckTypeOnly: true, TestName: "FunctionKill()"},
+		CommandTestData{ExpectedResponse: &glide.RequestError{}, CheckTypeOnly: true, TestName: "FunctionKill()"},
 	)
 	batch.FunctionDump()
 	testData = append(testData, CommandTestData{ExpectedResponse: "", CheckTypeOnly: true, TestName: "FunctionDump()"})
 	batch.FunctionRestore("payload")
 	testData = append(
 		testData,
-		CommandTestData{ExpectedResponse: errors.New(""), CheckTypeOnly: true, TestName: "FunctionRestore()"},
+		CommandTestData{ExpectedResponse: &glide.RequestError{}, CheckTypeOnly: true, TestName: "FunctionRestore()"},
 	)
 	batch.FunctionRestoreWithPolicy("payload", constants.FlushPolicy)
 	testData = append(
 		testData,
 		CommandTestData{
-			ExpectedResponse: errors.New(""),
+			ExpectedResponse: &glide.RequestError{},
 			CheckTypeOnly:    true,
 			TestName:         "FunctionRestoreWithPolicy(constants.FlushPolicy)",
 		},
@@ -4,9 +4,14 @@ package integTest
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -1277,6 +1282,23 @@ func (suite *GlideTestSuite) TestClientIdCluster() {
 	assert.True(t, response.IsSingleValue())
 }
 
+func (suite *GlideTestSuite) TestClientInfoCluster() {
+	client := suite.defaultClusterClient()
+
+	response, err := client.ClientInfo(context.Background())
+	suite.NoError(err)
+	suite.True(response.IsSingleValue())
+	suite.Contains(response.SingleValue(), "id")
+
+	opts := options.RouteOption{Route: config.AllPrimaries}
+	response, err = client.ClientInfoWithOptions(context.Background(), opts)
+	suite.NoError(err)
+	suite.True(response.IsMultiValue())
+	for _, info := range response.MultiValue() {
+		suite.Contains(info, "id")
+	}
+}
+
 func (suite *GlideTestSuite) TestClientIdWithOptionsCluster() {
 	client := suite.defaultClusterClient()
 	t := suite.T()
@@ -3263,3 +3285,362 @@ func (suite *GlideTestSuite) TestClusterLinks() {
 	assert.NoError(t, err)
 	assert.NotNil(t, clusterResult.SingleValue())
 }
+
+func (suite *GlideTestSuite) TestClusterMaintenanceCommands() {
+	client := suite.defaultClusterClient()
+	t := suite.T()
+	ctx := context.Background()
+
+	// A primary can't fail over, only its replicas can
+	_, err := client.ClusterFailover(ctx, config.RandomRoute)
+	assert.Error(t, err)
+	_, err = client.ClusterFailoverWithMode(ctx, config.RandomRoute, options.FailoverForce)
+	assert.Error(t, err)
+
+	// The nodes of a running cluster have a configuration epoch already
+	_, err = client.ClusterSetConfigEpoch(ctx, config.RandomRoute, 1)
+	assert.Error(t, err)
+
+	// Unknown nodes can't be forgotten
+	_, err = client.ClusterForget(ctx, config.AllNodes, strings.Repeat("0", 40))
+	assert.Error(t, err)
+
+	address := suite.clusterHosts[0]
+	route := config.NewByAddressRoute(address.Host, int32(address.Port))
+	_, err = client.ClusterMeet(ctx, route, "not-an-address", 0)
+	assert.Error(t, err)
+}
+
+// primaryAddresses returns the address of the primary serving slot, and of another primary, from CLUSTER NODES.
+func primaryAddresses(nodes string, slot int64) (owner config.NodeAddress, other config.NodeAddress, found bool) {
+	var primaries []config.NodeAddress
+	ownerIndex := -1
+	for _, line := range strings.Split(strings.TrimSpace(nodes), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 8 || !strings.Contains(fields[2], "master") {
+			continue
+		}
+		hostPort, _, _ := strings.Cut(fields[1], "@")
+		separator := strings.LastIndex(hostPort, ":")
+		port, err := strconv.Atoi(hostPort[separator+1:])
+		if err != nil {
+			continue
+		}
+		primaries = append(primaries, config.NodeAddress{Host: hostPort[:separator], Port: port})
+		for _, slotRange := range fields[8:] {
+			startSlot, endSlot, isRange := strings.Cut(slotRange, "-")
+			if !isRange {
+				endSlot = startSlot
+			}
+			start, startErr := strconv.ParseInt(startSlot, 10, 64)
+			end, endErr := strconv.ParseInt(endSlot, 10, 64)
+			if startErr == nil && endErr == nil && start <= slot && slot <= end {
+				ownerIndex = len(primaries) - 1
+			}
+		}
+	}
+	if ownerIndex < 0 || len(primaries) < 2 {
+		return owner, other, false
+	}
+	return primaries[ownerIndex], primaries[(ownerIndex+1)%len(primaries)], true
+}
+
+func (suite *GlideTestSuite) TestSlotMigrator_MigrateSlot() {
+	client := suite.defaultClusterClient()
+	t := suite.T()
+	ctx := context.Background()
+
+	key := "{slot_migration}" + uuid.NewString()
+	suite.verifyOK(client.Set(ctx, key, "value"))
+	slot, err := client.ClusterKeySlot(ctx, key)
+	require.NoError(t, err)
+	nodes, err := client.ClusterNodes(ctx)
+	require.NoError(t, err)
+	source, target, found := primaryAddresses(nodes, slot)
+	require.True(t, found, "the cluster must have two primaries")
+
+	var progress []glide.SlotMigrationProgress
+	migrator := glide.NewSlotMigrator(client).
+		WithBatchSize(10).
+		WithProgress(func(p glide.SlotMigrationProgress) { progress = append(progress, p) })
+	require.NoError(t, migrator.MigrateSlot(ctx, slot, source, target))
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	assert.Equal(t, slot, last.Slot)
+	assert.GreaterOrEqual(t, last.MigratedKeys, int64(1))
+	assert.Equal(t, int64(0), last.RemainingKeys)
+
+	// The client follows the slot to its new node
+	value, err := client.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, "value", value.Value())
+
+	// Give the slot back to its original node
+	require.NoError(t, migrator.MigrateSlot(ctx, slot, target, source))
+	value, err = client.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, "value", value.Value())
+
+	assert.Error(t, migrator.MigrateSlot(ctx, slot, source, source))
+}
+
+func (suite *GlideTestSuite) TestPrimaryChangeEvents() {
+	t := suite.T()
+	ctx := context.Background()
+
+	events := make(chan models.PrimaryChangeEvent, 10)
+	primaryChange := config.NewPrimaryChangeConfiguration(func(event models.PrimaryChangeEvent) { events <- event }).
+		WithCheckInterval(100 * time.Millisecond)
+	client, err := suite.clusterClient(suite.defaultClusterClientConfig().WithPrimaryChangeConfiguration(primaryChange))
+	require.NoError(t, err)
+	defer client.Close()
+
+	key := "{primary_change}" + uuid.NewString()
+	slot, err := client.ClusterKeySlot(ctx, key)
+	require.NoError(t, err)
+	nodes, err := client.ClusterNodes(ctx)
+	require.NoError(t, err)
+	source, target, found := primaryAddresses(nodes, slot)
+	require.True(t, found, "the cluster must have two primaries")
+
+	// Let the monitor fetch the initial topology
+	time.Sleep(300 * time.Millisecond)
+	migrator := glide.NewSlotMigrator(client)
+	require.NoError(t, migrator.MigrateSlot(ctx, slot, source, target))
+	defer func() { assert.NoError(t, migrator.MigrateSlot(ctx, slot, target, source)) }()
+
+	select {
+	case event := <-events:
+		assert.Equal(t, slot, event.SlotStart)
+		assert.Equal(t, slot, event.SlotEnd)
+		assert.Greater(t, event.DetectionLatency, time.Duration(0))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "no primary change event received")
+	}
+}
+
+func (suite *GlideTestSuite) TestMaintenanceWindow_Run() {
+	client := suite.defaultClusterClient()
+	key := uuid.NewString()
+	suite.verifyOK(client.Set(context.Background(), key, "before"))
+
+	window := glide.NewMaintenanceWindow(client, config.AllPrimaries).WithPause(5 * time.Second)
+	ran := false
+	err := window.Run(context.Background(), func(ctx context.Context) error {
+		ran = true
+		_, hasDeadline := ctx.Deadline()
+		suite.True(hasDeadline)
+		// Reads are served while writes are paused.
+		value, err := client.Get(ctx, key)
+		suite.NoError(err)
+		suite.Equal("before", value.Value())
+		return nil
+	})
+	suite.NoError(err)
+	suite.True(ran)
+	// Writes were resumed.
+	suite.verifyOK(client.Set(context.Background(), key, "after"))
+
+	operationErr := errors.New("operation failed")
+	err = window.Run(context.Background(), func(ctx context.Context) error { return operationErr })
+	suite.ErrorIs(err, operationErr)
+	suite.verifyOK(client.Set(context.Background(), key, "after"))
+}
+
+func (suite *GlideTestSuite) TestHealthz_Cluster() {
+	client := suite.defaultClusterClient()
+	recorder := httptest.NewRecorder()
+	glide.Healthz(client).WithPing(true).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	var report glide.HealthReport
+	suite.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &report))
+	suite.Equal(glide.HealthOK, report.Status)
+	suite.NotEmpty(report.Nodes)
+	for _, node := range report.Nodes {
+		suite.True(node.Connected, node.Address)
+	}
+
+	closedClient, err := suite.clusterClient(suite.defaultClusterClientConfig())
+	suite.Require().NoError(err)
+	closedClient.Close()
+	recorder = httptest.NewRecorder()
+	glide.Healthz(closedClient).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+}
+
+func (suite *GlideTestSuite) TestModuleCommandsCluster() {
+	client := suite.defaultClusterClient()
+	modules, err := client.ModuleList(context.Background())
+	suite.NoError(err)
+	for _, module := range modules {
+		suite.NotEmpty(module.Name)
+	}
+
+	perNode, err := client.ModuleListWithOptions(context.Background(), options.RouteOption{Route: config.AllPrimaries})
+	suite.NoError(err)
+	suite.True(perNode.IsMultiValue())
+	for _, nodeModules := range perNode.MultiValue() {
+		suite.Len(nodeModules, len(modules))
+	}
+
+	// The library doesn't exist, or loading modules at runtime is disabled by enable-module-command.
+	_, err = client.ModuleLoad(context.Background(), "/nonexistent/"+uuid.NewString()+".so", nil)
+	suite.Error(err)
+	_, err = client.ModuleUnload(context.Background(), uuid.NewString())
+	suite.Error(err)
+}
+
+func (suite *GlideTestSuite) TestResumableScan_Cluster() {
+	client := suite.defaultClusterClient()
+	prefix := "resumable:" + uuid.NewString() + ":"
+	expected := make([]string, 30)
+	for i := range expected {
+		expected[i] = fmt.Sprintf("%s%d", prefix, i)
+		suite.verifyOK(client.Set(context.Background(), expected[i], "value"))
+	}
+	scanOptions := *options.NewScanOptions().SetMatch(prefix + "*").SetCount(5)
+
+	// The scan is interrupted after its first page, and resumed from its cursor by a new scan.
+	scan, err := client.ResumableScan("", scanOptions)
+	suite.NoError(err)
+	keys, err := scan.Next(context.Background())
+	suite.NoError(err)
+	resumed, err := client.ResumableScan(scan.Cursor(), scanOptions)
+	suite.NoError(err)
+	for !resumed.Finished() {
+		page, err := resumed.Next(context.Background())
+		suite.NoError(err)
+		keys = append(keys, page...)
+	}
+	// SCAN may return a key more than once.
+	assert.Subset(suite.T(), keys, expected)
+	assert.Subset(suite.T(), expected, keys)
+}
+
+func (suite *GlideTestSuite) TestShutdown_Cluster() {
+	suite.SkipIfServerVersionLowerThan("7.0.0", suite.T())
+	client := suite.defaultClusterClient()
+
+	err := client.Shutdown(context.Background(), *options.NewShutdownOptions().SetAbort(), options.RouteOption{})
+	assert.ErrorContains(suite.T(), err, "SHUTDOWN requires a route")
+
+	route := options.RouteOption{Route: config.RandomRoute}
+	err = client.Shutdown(context.Background(), *options.NewShutdownOptions().SetAbort(), route)
+	assert.IsType(suite.T(), &glide.RequestError{}, err)
+}
+
+func (suite *GlideTestSuite) TestBgSave_Cluster() {
+	client := suite.defaultClusterClient()
+
+	statuses, err := client.BgSaveWithOptions(
+		context.Background(),
+		options.ClusterBgSaveOptions{Mode: options.BgSaveSchedule},
+	)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), statuses.IsMultiValue())
+	for _, status := range statuses.MultiValue() {
+		assert.Contains(suite.T(), status, "Background saving")
+	}
+
+	assert.Eventually(suite.T(), func() bool {
+		persistence, err := client.PersistenceInfo(context.Background(), options.RouteOption{})
+		if err != nil || len(persistence) != len(statuses.MultiValue()) {
+			return false
+		}
+		for _, node := range persistence {
+			if node.BgSaveInProgress || node.LastBgSaveStatus != "ok" {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+
+	persistence, err := client.PersistenceInfo(context.Background(), options.RouteOption{Route: config.RandomRoute})
+	require.NoError(suite.T(), err)
+	assert.Len(suite.T(), persistence, 1)
+}
+
+func (suite *GlideTestSuite) TestConfigDrift_Cluster() {
+	client := suite.defaultClusterClient()
+	ctx := context.Background()
+
+	mismatches, err := client.ConfigDrift(ctx, []string{"maxmemory-policy"})
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), mismatches)
+
+	previous, err := client.ConfigGet(ctx, []string{"maxmemory-policy"})
+	require.NoError(suite.T(), err)
+	route := options.RouteOption{Route: config.RandomRoute}
+	nodes, err := client.ConfigGetWithOptions(ctx, []string{"maxmemory-policy"}, options.RouteOption{Route: config.AllNodes})
+	require.NoError(suite.T(), err)
+	_, err = client.ConfigSetWithOptions(ctx, map[string]string{"maxmemory-policy": "volatile-ttl"}, route)
+	require.NoError(suite.T(), err)
+	defer client.ConfigSetWithOptions(ctx, previous, options.RouteOption{Route: config.AllNodes})
+
+	if len(nodes.MultiValue()) < 3 {
+		return
+	}
+	mismatches, err = client.ConfigDrift(ctx, []string{"maxmemory-policy"})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), mismatches, 1)
+	assert.Equal(suite.T(), "volatile-ttl", mismatches[0].Value)
+	assert.Equal(suite.T(), previous["maxmemory-policy"], mismatches[0].ExpectedValue)
+}
+
+func (suite *GlideTestSuite) TestExecBestEffort_Cluster() {
+	client := suite.defaultClusterClient()
+	key1, key2 := uuid.NewString(), uuid.NewString()
+
+	batch := pipeline.NewClusterBatch(false).Set(key1, "value1").Set(key2, "value2").Get(key1).Get(key2)
+	responses, err := client.ExecBestEffort(
+		context.Background(),
+		*batch,
+		*pipeline.NewBestEffortBatchOptions(5 * time.Second).WithChunkSize(3),
+	)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"OK", "OK", "value1", "value2"}, responses)
+}
+
+func (suite *GlideTestSuite) TestConnections_Cluster() {
+	client := suite.defaultClusterClient()
+	connections, err := client.Connections(context.Background())
+	suite.Require().NoError(err)
+	infos, err := client.ClientInfoWithOptions(context.Background(), options.RouteOption{Route: config.AllNodes})
+	suite.Require().NoError(err)
+	suite.Len(connections, len(infos.MultiValue()))
+	for _, connection := range connections {
+		suite.NotEmpty(connection.Node)
+		suite.Positive(connection.ID)
+		suite.NotEmpty(connection.Addr)
+	}
+}
+
+func (suite *GlideTestSuite) TestDisableRetries_DoesNotFollowRedirects() {
+	t := suite.T()
+	ctx := context.Background()
+	client := suite.defaultClusterClient()
+
+	key := "{disable_retries}" + uuid.NewString()
+	suite.verifyOK(client.Set(ctx, key, "value"))
+	slot, err := client.ClusterKeySlot(ctx, key)
+	require.NoError(t, err)
+	nodes, err := client.ClusterNodes(ctx)
+	require.NoError(t, err)
+	_, other, found := primaryAddresses(nodes, slot)
+	require.True(t, found, "the cluster must have two primaries")
+	wrongNode := config.NewByAddressRoute(other.Host, int32(other.Port))
+
+	// By default, the client follows the MOVED redirection of the node not serving the slot
+	result, err := client.CustomCommandWithRoute(ctx, []string{"GET", key}, wrongNode)
+	require.NoError(t, err)
+	assert.Equal(t, "value", result.SingleValue())
+
+	noRetries, err := suite.clusterClient(suite.defaultClusterClientConfig().WithDisableRetries(true))
+	require.NoError(t, err)
+	defer noRetries.Close()
+
+	// Without retries, the redirection fails the request
+	_, err = noRetries.CustomCommandWithRoute(ctx, []string{"GET", key}, wrongNode)
+	assert.ErrorIs(t, err, glide.ErrMoved)
+}
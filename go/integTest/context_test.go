@@ -6,6 +6,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
 	"github.com/valkey-io/valkey-glide/go/v2/models"
@@ -99,3 +100,24 @@ func (suite *GlideTestSuite) TestContext_CancelWithScan() {
 		assert.Equal(suite.T(), context.Canceled.Error(), err.Error())
 	})
 }
+
+// TestContext_CancelManyInFlight tests that requests abandoned on context
+// cancellation don't prevent the client from serving new commands
+func (suite *GlideTestSuite) TestContext_CancelManyInFlight() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.New().String()
+
+		// Abandon many requests, most of them before the server replies
+		for i := 0; i < 2000; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond)
+			_, _ = client.Get(ctx, key)
+			cancel()
+		}
+
+		// The client must still be able to execute commands
+		suite.verifyOK(client.Set(context.Background(), key, "value"))
+		result, err := client.Get(context.Background(), key)
+		suite.NoError(err)
+		assert.Equal(suite.T(), "value", result.Value())
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	glide "github.com/valkey-io/valkey-glide/go/v2"
@@ -1003,6 +1004,37 @@ func (suite *GlideTestSuite) TestNumSubAndShardNumSubSeparation() {
 	assert.Equal(suite.T(), int64(0), shardNumSub[regularChannel])
 }
 
+func (suite *GlideTestSuite) TestSPublishReturnsReceiverCount() {
+	suite.SkipIfServerVersionLowerThan("7.0.0", suite.T())
+
+	ctx := context.Background()
+
+	channel := "spublish_count_" + uuid.NewString()
+	receiver := suite.CreatePubSubReceiver(
+		ClusterClient,
+		[]ChannelDefn{{Channel: channel, Mode: ShardedMode}},
+		10,
+		false,
+		ConfigMethod,
+		suite.T(),
+	)
+	defer receiver.Close()
+
+	time.Sleep(200 * time.Millisecond)
+
+	publisher := suite.defaultClusterClient()
+	defer publisher.Close()
+
+	count, err := publisher.SPublish(ctx, channel, "message")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), count)
+
+	// Nobody listens on another channel of the same shard
+	count, err = publisher.SPublish(ctx, "{"+channel+"}_unused", "message")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), count)
+}
+
 func (suite *GlideTestSuite) TestRESP2RaisesError() {
 	// RESP2 is not supported for pubsub - this would be tested at client creation time
 	// Skipping as Go client enforces RESP3 for pubsub at compile time
@@ -3,10 +3,17 @@
 package integTest
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
 )
 
 // TestChannelSeparation tests that different channels don't interfere
@@ -112,3 +119,47 @@ func (suite *GlideTestSuite) TestMultipleClientsOneChannel() {
 		})
 	}
 }
+
+// TestSubscriberIsolation tests that the isolated subscriptions don't use the connections of the requests
+func (suite *GlideTestSuite) TestSubscriberIsolation() {
+	t := suite.T()
+	ctx := context.Background()
+	channel := "isolated_" + uuid.NewString()
+	subscriptions := config.NewStandaloneSubscriptionConfig().WithSubscription(config.ExactChannelMode, channel)
+	isolation := config.NewSubscriberIsolationConfiguration().WithMaxConnectionsPerNode(1)
+	client, err := suite.client(suite.defaultClientConfig().
+		WithSubscriptionConfig(subscriptions).
+		WithSubscriberIsolation(isolation))
+	require.NoError(t, err)
+	defer func() { client.Close() }()
+
+	queue, err := client.GetQueue()
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		receivers, err := client.Publish(ctx, channel, "message")
+		return err == nil && receivers == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	select {
+	case msg := <-queue.WaitForMessage():
+		assert.Equal(t, "message", msg.Message)
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timeout waiting for the message")
+	}
+
+	// The dynamic subscriptions go through the subscriber connection too.
+	other := "isolated_" + uuid.NewString()
+	require.NoError(t, client.Subscribe(ctx, []string{other}, 5000))
+	info, err := client.CustomCommand(ctx, []string{"CLIENT", "INFO"})
+	require.NoError(t, err)
+	assert.Contains(t, info, " sub=0 ")
+	subscribed, err := client.GetSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, subscribed.ActualSubscriptions[models.Exact], other)
+
+	// The subscriber connection to the node is the last one allowed.
+	_, err = suite.client(suite.defaultClientConfig().WithSubscriberIsolation(isolation))
+	assert.IsType(t, &glide.ConfigurationError{}, err)
+	client.Close()
+	client, err = suite.client(suite.defaultClientConfig().WithSubscriberIsolation(isolation))
+	require.NoError(t, err)
+}
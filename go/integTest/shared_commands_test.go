@@ -10,6 +10,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/valkey-io/valkey-glide/go/v2/config"
@@ -18,6 +20,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	glide "github.com/valkey-io/valkey-glide/go/v2"
+	"github.com/valkey-io/valkey-glide/go/v2/glidetest"
 	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
 	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
@@ -515,6 +519,21 @@ func (suite *GlideTestSuite) TestSetRange_existingAndNonExistingKeys_binaryStrin
 	})
 }
 
+func (suite *GlideTestSuite) TestSetRange_negativeOffset() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.New().String()
+		suite.verifyOK(client.Set(context.Background(), key, "record"))
+
+		res, err := client.SetRange(context.Background(), key, -1, "x")
+		suite.Error(err)
+		suite.Equal(int64(0), res)
+
+		value, err := client.Get(context.Background(), key)
+		suite.NoError(err)
+		suite.Equal("record", value.Value())
+	})
+}
+
 func (suite *GlideTestSuite) TestGetRange_existingAndNonExistingKeys() {
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
 		key := uuid.New().String()
@@ -748,6 +767,23 @@ func (suite *GlideTestSuite) TestGetDel_NonExistingKey() {
 	})
 }
 
+func (suite *GlideTestSuite) TestTake() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.New().String()
+		suite.verifyOK(client.Set(context.Background(), key, ""))
+
+		result, err := client.Take(context.Background(), key)
+		suite.NoError(err)
+		suite.False(result.IsNil())
+		suite.Equal("", result.Value())
+
+		// The key was taken already.
+		result, err = client.Take(context.Background(), key)
+		suite.NoError(err)
+		suite.True(result.IsNil())
+	})
+}
+
 func (suite *GlideTestSuite) TestGetDel_EmptyKey() {
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
 		result, err := client.GetDel(context.Background(), "")
@@ -925,6 +961,31 @@ func (suite *GlideTestSuite) TestHMGet_WithNotExistingField() {
 	})
 }
 
+func (suite *GlideTestSuite) TestHMGetMulti() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		// The keys map to different slots in cluster mode.
+		key1, key2, missing := uuid.NewString(), uuid.NewString(), uuid.NewString()
+		_, err := client.HSet(context.Background(), key1, map[string]string{"field1": "value1", "field2": "value2"})
+		suite.NoError(err)
+		_, err = client.HSet(context.Background(), key2, map[string]string{"field2": "value3"})
+		suite.NoError(err)
+
+		values, err := client.HMGetMulti(context.Background(), []string{key1, key2, missing}, []string{"field1", "field2"})
+		suite.NoError(err)
+		nullValue := models.CreateNilStringResult()
+		assert.Equal(suite.T(), map[string]map[string]models.Result[string]{
+			key1:    {"field1": models.CreateStringResult("value1"), "field2": models.CreateStringResult("value2")},
+			key2:    {"field1": nullValue, "field2": models.CreateStringResult("value3")},
+			missing: {"field1": nullValue, "field2": nullValue},
+		}, values)
+
+		_, err = client.Set(context.Background(), missing, "value")
+		suite.NoError(err)
+		_, err = client.HMGetMulti(context.Background(), []string{key1, missing}, []string{"field1"})
+		suite.ErrorContains(err, "WRONGTYPE")
+	})
+}
+
 func (suite *GlideTestSuite) TestHSetNX_WithExistingKey() {
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
 		fields := map[string]string{"field1": "value1", "field2": "value2"}
@@ -1459,11 +1520,29 @@ func (suite *GlideTestSuite) TestHRandField() {
 		// With values - negative count
 		rescv, err = client.HRandFieldWithCountWithValues(context.Background(), key, -5)
 		assert.NoError(suite.T(), err)
-		assert.Len(suite.T(), resc, 5)
+		assert.Len(suite.T(), rescv, 5)
 		for _, pair := range rescv {
 			assert.Contains(suite.T(), fields, pair[0])
 		}
 
+		// With field values - positive count
+		fieldValues, err := client.HRandFieldWithCountWithFieldValues(context.Background(), key, 5)
+		assert.NoError(suite.T(), err)
+		resvMap = make(map[string]string)
+		for _, pair := range fieldValues {
+			resvMap[pair.Field] = pair.Value
+		}
+		assert.Equal(suite.T(), data, resvMap)
+
+		// With field values - negative count
+		fieldValues, err = client.HRandFieldWithCountWithFieldValues(context.Background(), key, -5)
+		assert.NoError(suite.T(), err)
+		assert.Len(suite.T(), fieldValues, 5)
+		for _, pair := range fieldValues {
+			assert.Contains(suite.T(), fields, pair.Field)
+			assert.Equal(suite.T(), data[pair.Field], pair.Value)
+		}
+
 		// key exists but holds non hash type value
 		key = uuid.NewString()
 		suite.verifyOK(client.Set(context.Background(), key, "HRandField"))
@@ -2160,6 +2239,46 @@ func (suite *GlideTestSuite) TestRPush() {
 	})
 }
 
+func (suite *GlideTestSuite) TestPushValues() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+
+		res, err := client.RPushValues(context.Background(), key, "b", "c")
+		suite.NoError(err)
+		suite.Equal(int64(2), res)
+
+		res, err = client.LPushValues(context.Background(), key, "a")
+		suite.NoError(err)
+		suite.Equal(int64(3), res)
+
+		list, err := client.LRange(context.Background(), key, 0, -1)
+		suite.NoError(err)
+		suite.Equal([]string{"a", "b", "c"}, list)
+	})
+}
+
+func (suite *GlideTestSuite) TestRPushAllChunked() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		elements := make([]string, 2500)
+		for i := range elements {
+			elements[i] = fmt.Sprintf("element%d", i)
+		}
+
+		res, err := client.RPushAllChunked(context.Background(), key, elements, *options.NewChunkOptions().SetMaxElements(1000))
+		suite.NoError(err)
+		suite.Equal(int64(2500), res)
+
+		list, err := client.LRange(context.Background(), key, 0, -1)
+		suite.NoError(err)
+		suite.Equal(elements, list)
+
+		res, err = client.RPushAllChunked(context.Background(), key, nil, *options.NewChunkOptions())
+		suite.NoError(err)
+		suite.Equal(int64(2500), res)
+	})
+}
+
 func (suite *GlideTestSuite) TestSAdd() {
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
 		key := uuid.NewString()
@@ -3228,6 +3347,42 @@ func (suite *GlideTestSuite) TestLRange() {
 	})
 }
 
+func (suite *GlideTestSuite) TestListIterator() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		elements := make([]string, 25)
+		for i := range elements {
+			elements[i] = fmt.Sprintf("element%d", i)
+		}
+		_, err := client.RPush(context.Background(), key, elements)
+		suite.NoError(err)
+
+		iterator := glide.NewListIterator(client, key).WithPageSize(10)
+		var forward []string
+		for iterator.Next(context.Background()) {
+			forward = append(forward, iterator.Value())
+		}
+		suite.NoError(iterator.Err())
+		assert.Equal(suite.T(), elements, forward)
+
+		iterator = glide.NewListIterator(client, key).WithPageSize(10).WithReverse(true)
+		var backward []string
+		for iterator.Next(context.Background()) {
+			backward = append(backward, iterator.Value())
+		}
+		suite.NoError(iterator.Err())
+		assert.Equal(suite.T(), elements[24], backward[0])
+		assert.Equal(suite.T(), elements[0], backward[24])
+		assert.Len(suite.T(), backward, 25)
+
+		stringKey := uuid.NewString()
+		suite.verifyOK(client.Set(context.Background(), stringKey, "value"))
+		iterator = glide.NewListIterator(client, stringKey)
+		assert.False(suite.T(), iterator.Next(context.Background()))
+		suite.Error(iterator.Err())
+	})
+}
+
 func (suite *GlideTestSuite) TestLIndex() {
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
 		list := []string{"value4", "value3", "value2", "value1"}
@@ -5064,6 +5219,34 @@ func (suite *GlideTestSuite) TestUnlink() {
 	})
 }
 
+func (suite *GlideTestSuite) TestUnlinkMulti() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		keys := make([]string, 5)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("{UnlinkMulti%d}%s", i, uuid.NewString())
+		}
+		for _, key := range keys[:4] {
+			suite.verifyOK(client.Set(context.Background(), key, initialValue))
+		}
+
+		counts, err := client.UnlinkMulti(
+			context.Background(),
+			keys,
+			*options.NewUnlinkMultiOptions().SetChunkSize(2).SetKeysPerSecond(100),
+		)
+		suite.NoError(err)
+		assert.Equal(suite.T(), []int64{2, 2, 0}, counts)
+
+		exists, err := client.Exists(context.Background(), keys)
+		suite.NoError(err)
+		assert.Equal(suite.T(), int64(0), exists)
+
+		counts, err = client.UnlinkMulti(context.Background(), nil, *options.NewUnlinkMultiOptions())
+		suite.NoError(err)
+		assert.Empty(suite.T(), counts)
+	})
+}
+
 func (suite *GlideTestSuite) TestRename() {
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
 		// Test 1 Check if the command successfully renamed
@@ -7714,6 +7897,27 @@ func (suite *GlideTestSuite) TestZRemRangeByScore() {
 	})
 }
 
+func (suite *GlideTestSuite) TestZSetWindowCleaner() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		now := time.Now()
+		_, err := client.ZAdd(context.Background(), key, map[string]float64{
+			"old":    float64(now.Add(-2 * time.Hour).UnixMilli()),
+			"recent": float64(now.Add(-time.Minute).UnixMilli()),
+			"now":    float64(now.UnixMilli()),
+		})
+		suite.NoError(err)
+
+		cleaner := glide.NewZSetWindowCleaner(client).WithWindow(key, time.Hour)
+		suite.NoError(cleaner.CleanNow(context.Background()))
+
+		members, err := client.ZRange(context.Background(), key, options.NewRangeByIndexQuery(0, -1))
+		suite.NoError(err)
+		assert.Equal(suite.T(), []string{"recent", "now"}, members)
+		assert.Equal(suite.T(), int64(1), cleaner.Stats()[key].Removed)
+	})
+}
+
 func (suite *GlideTestSuite) TestZMScore() {
 	suite.SkipIfServerVersionLowerThan("6.2.0", suite.T())
 	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
@@ -11752,3 +11956,738 @@ func (suite *GlideTestSuite) TestRegisterClientNameAndVersion() {
 		assert.Regexp(suite.T(), "lib-ver=unknown|lib-ver=v", infoStr, "lib-ver not found or incorrect")
 	})
 }
+
+func (suite *GlideTestSuite) TestCacheWarmer_WarmKeys() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		prefix := "{warm}" + uuid.NewString()
+		keys := []string{prefix + "1", prefix + "2", prefix + "3"}
+		suite.verifyOK(client.Set(context.Background(), keys[0], "cached"))
+
+		loader := func(ctx context.Context, key string) (string, bool, error) {
+			if key == keys[2] {
+				return "", false, nil
+			}
+			return "loaded", true, nil
+		}
+		progress, err := glide.NewCacheWarmer(client, loader).
+			WithTTL(time.Minute).
+			WarmKeys(context.Background(), keys)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), glide.WarmProgress{Processed: 3, Loaded: 1, Skipped: 1, Missing: 1}, progress)
+
+		values, err := client.MGet(context.Background(), keys)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "cached", values[0].Value())
+		assert.Equal(suite.T(), "loaded", values[1].Value())
+		assert.True(suite.T(), values[2].IsNil())
+
+		ttl, err := client.TTL(context.Background(), keys[1])
+		require.NoError(suite.T(), err)
+		assert.Greater(suite.T(), ttl, int64(0))
+	})
+}
+
+func (suite *GlideTestSuite) TestErrorDetails_ServerError() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		_, err := client.LPush(context.Background(), key, []string{"value"})
+		suite.NoError(err)
+
+		_, err = client.Incr(context.Background(), key)
+		suite.ErrorIs(err, glide.ErrWrongType)
+		details, ok := glide.ErrorDetails(err)
+		suite.True(ok)
+		suite.Equal("INCR", details.Command)
+		suite.Equal(1, details.Attempts)
+		suite.NotEmpty(details.Node)
+		suite.False(details.MayHaveExecuted)
+	})
+}
+
+func (suite *GlideTestSuite) TestWithExecutionMetadata() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		_, metadata, err := glide.WithExecutionMetadata(
+			context.Background(),
+			func(ctx context.Context) (string, error) {
+				return client.Set(ctx, key, "value")
+			},
+		)
+		suite.NoError(err)
+		suite.Equal("SET", metadata.Command)
+		suite.Equal(1, metadata.Requests)
+		suite.Equal(1, metadata.Attempts)
+		suite.NotEmpty(metadata.RoutedNode)
+	})
+}
+
+func (suite *GlideTestSuite) TestInspect() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		_, err := client.RPush(context.Background(), key, []string{"a", "b", "c"})
+		suite.NoError(err)
+		_, err = client.Expire(context.Background(), key, time.Minute)
+		suite.NoError(err)
+
+		inspection, err := client.Inspect(context.Background(), key)
+		suite.NoError(err)
+		suite.True(inspection.Exists)
+		suite.Equal("list", inspection.Type)
+		suite.NotEmpty(inspection.Encoding)
+		suite.True(inspection.Expires)
+		suite.LessOrEqual(inspection.TTL, time.Minute)
+		suite.False(inspection.MemoryUsage.IsNil())
+		suite.Equal(int64(3), inspection.Length.Value())
+
+		inspection, err = client.Inspect(context.Background(), uuid.NewString())
+		suite.NoError(err)
+		suite.False(inspection.Exists)
+	})
+}
+
+func (suite *GlideTestSuite) TestZSetPaginator() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		_, err := client.ZAdd(context.Background(), key, map[string]float64{"a": 1, "b": 1, "c": 1, "d": 2, "e": 3})
+		suite.NoError(err)
+
+		paginator := glide.NewZSetPaginator(client, key)
+		page, err := paginator.Page(context.Background(), "", 2)
+		suite.NoError(err)
+		suite.Equal([]models.MemberAndScore{{Member: "a", Score: 1}, {Member: "b", Score: 1}}, page.Entries)
+
+		_, err = client.ZAdd(context.Background(), key, map[string]float64{"0": 0})
+		suite.NoError(err)
+		page, err = paginator.Page(context.Background(), page.Next, 2)
+		suite.NoError(err)
+		suite.Equal([]models.MemberAndScore{{Member: "c", Score: 1}, {Member: "d", Score: 2}}, page.Entries)
+
+		page, err = paginator.Page(context.Background(), page.Next, 2)
+		suite.NoError(err)
+		suite.Equal([]models.MemberAndScore{{Member: "e", Score: 3}}, page.Entries)
+		suite.Empty(page.Next)
+	})
+}
+
+func (suite *GlideTestSuite) TestHSetAllChunked() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		values := make(map[string]string, 2500)
+		for i := range 2500 {
+			values[fmt.Sprintf("field%d", i)] = fmt.Sprintf("value%d", i)
+		}
+
+		chunkOptions := options.NewChunkOptions().SetMaxElements(1000).SetVerifyLength(true)
+		res, err := client.HSetAllChunked(context.Background(), key, values, *chunkOptions)
+		suite.NoError(err)
+		suite.Equal(int64(2500), res)
+
+		hash, err := client.HGetAll(context.Background(), key)
+		suite.NoError(err)
+		suite.Equal(values, hash)
+
+		res, err = client.HSetAllChunked(context.Background(), key, map[string]string{"field0": "updated"}, *chunkOptions)
+		suite.NoError(err)
+		suite.Equal(int64(0), res)
+	})
+}
+
+func (suite *GlideTestSuite) TestStreamLag() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key1 := uuid.NewString()
+		key2 := uuid.NewString()
+		group := uuid.NewString()
+		for _, key := range []string{key1, key2} {
+			suite.verifyOK(client.XGroupCreateWithOptions(
+				context.Background(), key, group, "0-0", *options.NewXGroupCreateOptions().SetMakeStream(),
+			))
+		}
+		for i := 0; i < 3; i++ {
+			_, err := client.XAdd(context.Background(), key1, []models.FieldValue{{Field: "f", Value: "v"}})
+			suite.NoError(err)
+		}
+		_, err := client.XReadGroupWithOptions(
+			context.Background(), group, "consumer", map[string]string{key1: ">"}, *options.NewXReadGroupOptions().SetCount(1),
+		)
+		suite.NoError(err)
+
+		lag, err := client.StreamLag(context.Background(), key1, group)
+		suite.NoError(err)
+		suite.Equal(int64(1), lag.Pending)
+		suite.False(lag.OldestPendingId.IsNil())
+		if suite.serverVersion >= "7.0.0" {
+			suite.Equal(models.CreateInt64Result(2), lag.EntriesBehind)
+		}
+
+		summary, err := client.AggregateStreamLag(context.Background(), group, key1, key2)
+		suite.NoError(err)
+		suite.Equal(int64(1), summary.Pending)
+		suite.Len(summary.Streams, 2)
+		if suite.serverVersion >= "7.0.0" {
+			suite.Equal(int64(2), summary.EntriesBehind)
+			suite.Zero(summary.Unknown)
+		}
+
+		_, err = client.StreamLag(context.Background(), key1, uuid.NewString())
+		suite.Error(err)
+	})
+}
+
+func (suite *GlideTestSuite) TestGetAndRefresh() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		suite.verifyOK(client.Set(context.Background(), key, initialValue))
+
+		result, err := client.GetAndRefresh(context.Background(), key, time.Minute)
+		suite.NoError(err)
+		suite.Equal(initialValue, result.Value())
+		ttl, err := client.PTTL(context.Background(), key)
+		suite.NoError(err)
+		suite.Greater(ttl, int64(55000))
+
+		result, err = client.GetAndRefresh(context.Background(), uuid.NewString(), time.Minute)
+		suite.NoError(err)
+		suite.True(result.IsNil())
+
+		_, err = client.GetAndRefresh(context.Background(), key, 0)
+		suite.Error(err)
+	})
+}
+
+func (suite *GlideTestSuite) TestFtAlias() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		alias := uuid.NewString()
+		_, err := client.FtAliasDel(context.Background(), alias)
+		if err != nil && strings.Contains(strings.ToLower(err.Error()), "unknown command") {
+			suite.T().Skip("The search module isn't loaded")
+		}
+		suite.ErrorIs(err, glide.ErrAliasNotFound)
+
+		_, err = client.FtAliasAdd(context.Background(), alias, uuid.NewString())
+		suite.ErrorIs(err, glide.ErrIndexNotFound)
+	})
+}
+
+func (suite *GlideTestSuite) TestReplicationLag() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		var lags []models.ReplicaLag
+		var err error
+		switch c := client.(type) {
+		case *glide.Client:
+			lags, err = c.ReplicationLag(context.Background())
+		case *glide.ClusterClient:
+			lags, err = c.ReplicationLag(context.Background())
+		}
+		suite.NoError(err)
+		for _, lag := range lags {
+			suite.NotEmpty(lag.Replica)
+			suite.GreaterOrEqual(lag.OffsetLag, int64(0))
+		}
+	})
+}
+
+func (suite *GlideTestSuite) TestSetDurable() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+
+		replicas, err := client.SetDurable(context.Background(), key, "value", 0, time.Second)
+		suite.NoError(err)
+		suite.GreaterOrEqual(replicas, int64(0))
+
+		value, err := client.Get(context.Background(), key)
+		suite.NoError(err)
+		suite.Equal("value", value.Value())
+	})
+}
+
+func (suite *GlideTestSuite) TestSetDurable_WaitLongerThanRequestTimeout() {
+	client, err := suite.client(suite.defaultClientConfig().WithRequestTimeout(250 * time.Millisecond))
+	suite.Require().NoError(err)
+	clusterClient, err := suite.clusterClient(
+		suite.defaultClusterClientConfig().WithRequestTimeout(250 * time.Millisecond),
+	)
+	suite.Require().NoError(err)
+
+	// WAIT waits for its own timeout rather than the request timeout, as there are fewer replicas than requested.
+	clients := []interfaces.BaseClientCommands{client, clusterClient}
+	suite.runWithClients(clients, func(client interfaces.BaseClientCommands) {
+		start := time.Now()
+		replicas, err := client.SetDurable(context.Background(), uuid.NewString(), "value", 100, time.Second)
+		suite.NoError(err)
+		suite.Less(replicas, int64(100))
+		suite.GreaterOrEqual(time.Since(start), time.Second)
+	})
+}
+
+func (suite *GlideTestSuite) TestNamespaceAnalyzer() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		namespace := "{" + uuid.NewString() + "}"
+		for i := 0; i < 10; i++ {
+			_, err := client.Set(context.Background(), namespace+":"+strconv.Itoa(i), "value")
+			suite.NoError(err)
+		}
+		_, err := client.Expire(context.Background(), namespace+":0", time.Hour)
+		suite.NoError(err)
+
+		var analyzer *glide.NamespaceAnalyzer
+		switch c := client.(type) {
+		case *glide.Client:
+			analyzer = glide.NewNamespaceAnalyzer(c)
+		case *glide.ClusterClient:
+			analyzer = glide.NewNamespaceAnalyzer(c)
+		}
+		report, err := analyzer.WithMatch(namespace + ":*").WithSamplePercentage(100).Analyze(context.Background())
+		suite.NoError(err)
+		suite.Len(report.Namespaces, 1)
+		usage := report.Namespaces[0]
+		suite.Equal(namespace, usage.Namespace)
+		suite.Equal(int64(10), usage.Keys)
+		suite.Equal(int64(10), usage.SampledKeys)
+		suite.Positive(usage.EstimatedMemory)
+		suite.Equal(models.TTLDistribution{Persistent: 9, UnderDay: 1}, usage.TTL)
+	})
+}
+
+func (suite *GlideTestSuite) TestTTLAuditor() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		prefix := "{" + uuid.NewString() + "}:"
+		for i := 0; i < 4; i++ {
+			_, err := client.Set(context.Background(), prefix+strconv.Itoa(i), "value")
+			suite.NoError(err)
+		}
+		_, err := client.Expire(context.Background(), prefix+"0", 30*time.Second)
+		suite.NoError(err)
+
+		var auditor *glide.TTLAuditor
+		switch c := client.(type) {
+		case *glide.Client:
+			auditor = glide.NewTTLAuditor(c)
+		case *glide.ClusterClient:
+			auditor = glide.NewTTLAuditor(c)
+		}
+		histograms, err := auditor.Audit(context.Background(), prefix+"*")
+		suite.NoError(err)
+		suite.Len(histograms, 1)
+		suite.True(histograms[0].Complete)
+		suite.Equal(int64(4), histograms[0].SampledKeys)
+		suite.Equal(int64(3), histograms[0].Persistent)
+		suite.Equal(int64(1), histograms[0].Buckets[0].Keys)
+	})
+}
+
+func (suite *GlideTestSuite) TestCapabilities() {
+	client, err := suite.client(suite.defaultClientConfig().WithCapabilityDetection(true))
+	suite.Require().NoError(err)
+	clusterClient, err := suite.clusterClient(suite.defaultClusterClientConfig().WithCapabilityDetection(true))
+	suite.Require().NoError(err)
+
+	clients := []interfaces.BaseClientCommands{client, clusterClient}
+	suite.runWithClients(clients, func(client interfaces.BaseClientCommands) {
+		capabilities, err := client.Capabilities(context.Background())
+		suite.NoError(err)
+		suite.NotEmpty(capabilities.ServerName)
+		suite.True(capabilities.AtLeast(suite.serverVersion))
+		suite.Positive(capabilities.CommandCount)
+
+		// The commands the server doesn't support fail fast once its capabilities are detected.
+		if !capabilities.AtLeast("9.0.0") {
+			_, err = client.HTtl(context.Background(), uuid.NewString(), []string{"field"})
+			suite.ErrorIs(err, glide.ErrUnsupported)
+		}
+		if !capabilities.HasModule("search") {
+			_, err = client.FtAliasDel(context.Background(), uuid.NewString())
+			var unsupported *glide.UnsupportedError
+			if suite.ErrorAs(err, &unsupported) {
+				suite.Equal("search", unsupported.RequiredModule())
+			}
+		}
+	})
+
+	// The capabilities aren't detected by default.
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		_, err := client.Capabilities(context.Background())
+		var configurationErr *glide.ConfigurationError
+		suite.ErrorAs(err, &configurationErr)
+	})
+}
+
+func (suite *GlideTestSuite) TestScriptSandbox() {
+	script := options.NewScript(`
+		local stock = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+		local count = tonumber(ARGV[2])
+		if count > stock then
+			return redis.error_reply('insufficient stock')
+		end
+		redis.call('HINCRBY', KEYS[1], ARGV[1], -count)
+		for i = 1, count do
+			redis.call('RPUSH', KEYS[2], ARGV[1])
+		end
+		redis.call('PEXPIRE', KEYS[2], 60000)
+		return stock - count
+	`)
+	defer script.Close()
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		sandbox := glidetest.NewScriptSandbox(suite.T(), client)
+		sandbox.SetHash("stock", map[string]string{"apples": "3"})
+
+		suite.Equal(int64(1), sandbox.Run(script, []string{"stock", "reserved"}, "apples", "2"))
+		sandbox.AssertHash("stock", map[string]string{"apples": "1"})
+		sandbox.AssertList("reserved", "apples", "apples")
+		sandbox.AssertTTL("reserved", time.Minute)
+
+		err := sandbox.RunError(script, []string{"stock", "reserved"}, "apples", "2")
+		suite.ErrorContains(err, "insufficient stock")
+	})
+}
+
+func (suite *GlideTestSuite) TestWithTrace() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		var traces []models.RequestTrace
+		ctx := options.WithTraceHandler(context.Background(), func(trace models.RequestTrace) {
+			traces = append(traces, trace)
+		})
+
+		_, err := client.Set(ctx, key, "value")
+		require.NoError(suite.T(), err)
+		value, err := client.Get(ctx, key)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), "value", value.Value())
+		_, err = client.Get(context.Background(), key)
+		require.NoError(suite.T(), err)
+
+		require.Len(suite.T(), traces, 2)
+		assert.Equal(suite.T(), []models.TracedCommand{{Name: "SET", Args: []string{key, "value"}}}, traces[0].Commands)
+		assert.Equal(suite.T(), "OK", traces[0].Response)
+		assert.Equal(suite.T(), []models.TracedCommand{{Name: "GET", Args: []string{key}}}, traces[1].Commands)
+		assert.Equal(suite.T(), "value", traces[1].Response)
+		assert.Equal(suite.T(), 2, traces[1].Sequence)
+		assert.Positive(suite.T(), traces[1].Duration)
+		assert.NoError(suite.T(), traces[1].Err)
+	})
+}
+
+func (suite *GlideTestSuite) TestZSetExporter() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key := uuid.NewString()
+		members := make(map[string]float64, 25)
+		for i := 0; i < 25; i++ {
+			members[fmt.Sprintf("player%02d", i)] = float64(i * 10)
+		}
+		_, err := client.ZAdd(context.Background(), key, members)
+		require.NoError(suite.T(), err)
+
+		var exporter *glide.ZSetExporter
+		switch c := client.(type) {
+		case *glide.Client:
+			exporter = glide.NewZSetExporter(c)
+		case *glide.ClusterClient:
+			exporter = glide.NewZSetExporter(c)
+		}
+		var output strings.Builder
+		exported, err := exporter.WithPageSize(4).WithPagesPerBatch(3).WithReverse(true).Export(
+			context.Background(),
+			key,
+			&output,
+		)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(25), exported)
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		require.Len(suite.T(), lines, 26)
+		assert.Equal(suite.T(), "member,score", lines[0])
+		assert.Equal(suite.T(), "player24,240", lines[1])
+		assert.Equal(suite.T(), "player00,0", lines[25])
+	})
+}
+
+func (suite *GlideTestSuite) TestCacheInvalidator() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		key1 := "{invalidate}" + uuid.NewString()
+		key2 := "{invalidate}" + uuid.NewString()
+		suite.verifyOK(client.Set(context.Background(), key1, "stale"))
+		invalidator := glide.NewCacheInvalidator(client).WithDelay(100 * time.Millisecond)
+		defer invalidator.Close()
+
+		deleted, err := invalidator.Invalidate(context.Background(), key1, key2)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(1), deleted)
+
+		// A reader caches a stale value after the first delete, the second delete removes it.
+		suite.verifyOK(client.Set(context.Background(), key2, "stale"))
+		assert.Eventually(suite.T(), func() bool {
+			exists, err := client.Exists(context.Background(), []string{key1, key2})
+			return err == nil && exists == 0
+		}, 5*time.Second, 20*time.Millisecond)
+		assert.Equal(suite.T(), 0, invalidator.Pending())
+	})
+}
+
+func (suite *GlideTestSuite) TestIncrByWithOptions() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		ctx := context.Background()
+		key := uuid.NewString()
+		suite.verifyOK(client.Set(ctx, key, strconv.FormatInt(math.MaxInt64-1, 10)))
+
+		_, err := client.IncrByWithOptions(ctx, key, 2, *options.NewIncrOptions())
+		var overflowErr *glide.OverflowError
+		suite.Require().ErrorAs(err, &overflowErr)
+		suite.Equal(key, overflowErr.Key())
+		suite.ErrorIs(err, glide.ErrOverflow)
+
+		// Clamping saturates the value instead.
+		value, err := client.IncrByWithOptions(ctx, key, 2, *options.NewIncrOptions().SetClamp(math.MinInt64, math.MaxInt64))
+		suite.Require().NoError(err)
+		suite.Equal(int64(math.MaxInt64), value)
+
+		counter := uuid.NewString()
+		bounded := *options.NewIncrOptions().SetClamp(0, 10)
+		value, err = client.IncrByWithOptions(ctx, counter, 25, bounded)
+		suite.Require().NoError(err)
+		suite.Equal(int64(10), value)
+		value, err = client.DecrByWithOptions(ctx, counter, 4, bounded)
+		suite.Require().NoError(err)
+		suite.Equal(int64(6), value)
+		value, err = client.DecrByWithOptions(ctx, counter, 40, bounded)
+		suite.Require().NoError(err)
+		suite.Equal(int64(0), value)
+		stored, err := client.Get(ctx, counter)
+		suite.Require().NoError(err)
+		suite.Equal("0", stored.Value())
+
+		floatValue, err := client.IncrByFloatWithOptions(ctx, uuid.NewString(), 2.5,
+			*options.NewIncrByFloatOptions().SetClamp(-1, 1))
+		suite.Require().NoError(err)
+		suite.Equal(1.0, floatValue)
+	})
+}
+
+func (suite *GlideTestSuite) TestIncrWithTTL() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		ctx := context.Background()
+		key := uuid.NewString()
+		value, err := client.IncrWithTTL(ctx, key, time.Minute)
+		suite.Require().NoError(err)
+		suite.Equal(int64(1), value)
+		ttl, err := client.PTTL(ctx, key)
+		suite.Require().NoError(err)
+		suite.Greater(ttl, int64(0))
+
+		// The following increments keep the TTL of the window.
+		_, err = client.PExpire(ctx, key, 30*time.Second)
+		suite.Require().NoError(err)
+		value, err = client.IncrWithTTL(ctx, key, time.Minute)
+		suite.Require().NoError(err)
+		suite.Equal(int64(2), value)
+		ttl, err = client.PTTL(ctx, key)
+		suite.Require().NoError(err)
+		suite.LessOrEqual(ttl, int64(30000))
+
+		_, err = client.IncrWithTTL(ctx, key, 0)
+		suite.ErrorContains(err, "at least 1ms")
+	})
+}
+
+func (suite *GlideTestSuite) TestRequestJournal() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		var journaled []glide.JournalEntry
+		sink := glide.JournalSinkFunc(func(entry glide.JournalEntry) error {
+			journaled = append(journaled, entry)
+			return nil
+		})
+		journal := glide.NewRequestJournal(sink)
+		switch c := client.(type) {
+		case *glide.Client:
+			c.JournalWrites(journal)
+			defer c.JournalWrites(nil)
+		case *glide.ClusterClient:
+			c.JournalWrites(journal)
+			defer c.JournalWrites(nil)
+		}
+
+		key := uuid.NewString()
+		suite.verifyOK(client.Set(context.Background(), key, "secret"))
+		_, err := client.Get(context.Background(), key)
+		suite.Require().NoError(err)
+		_, err = client.Incr(context.Background(), key)
+		suite.Error(err)
+		_, err = client.Del(context.Background(), []string{key})
+		suite.Require().NoError(err)
+		journal.Close()
+
+		suite.Require().Len(journaled, 3)
+		suite.Equal("SET", journaled[0].Name)
+		suite.Equal([]string{key, glide.JournalRedacted}, journaled[0].Args)
+		suite.NoError(journaled[0].Err)
+		suite.Equal("INCR", journaled[1].Name)
+		suite.Error(journaled[1].Err)
+		suite.Equal("DEL", journaled[2].Name)
+		suite.Equal([]string{key}, journaled[2].Keys)
+		suite.Equal(uint64(3), journaled[2].Sequence)
+	})
+}
+
+func (suite *GlideTestSuite) TestAsyncWriter() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		var writer *glide.AsyncWriter
+		switch c := client.(type) {
+		case *glide.Client:
+			writer = glide.NewAsyncWriter(c)
+		case *glide.ClusterClient:
+			writer = glide.NewAsyncWriter(c)
+		}
+
+		key := uuid.NewString()
+		var failures atomic.Int64
+		onDone := func(response any, err error) {
+			if err != nil {
+				failures.Add(1)
+			}
+		}
+		for range 50 {
+			suite.Require().NoError(writer.IncrByAsync(key, 2, onDone))
+		}
+		suite.Require().NoError(writer.SetWithTTLAsync(key+":last", "now", time.Minute, onDone))
+		suite.Require().NoError(writer.CustomCommandAsync([]string{"SET", key, "not a number"}, onDone))
+		suite.Require().NoError(writer.IncrByAsync(key, 1, onDone))
+		writer.Close()
+
+		suite.Equal(int64(1), failures.Load())
+		suite.Equal(glide.AsyncWriterStats{Written: 52, Failed: 1}, writer.Stats())
+		value, err := client.Get(context.Background(), key)
+		suite.Require().NoError(err)
+		suite.Equal("not a number", value.Value())
+		ttl, err := client.PTTL(context.Background(), key+":last")
+		suite.Require().NoError(err)
+		suite.Positive(ttl)
+	})
+}
+
+func (suite *GlideTestSuite) TestKeyLock() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		ctx := context.Background()
+		key := uuid.NewString()
+		// Two lockers stand for two instances of a service.
+		lockers := []*glide.KeyLock{
+			glide.NewKeyLock(client).WithRetryInterval(time.Millisecond),
+			glide.NewKeyLock(client).WithRetryInterval(time.Millisecond),
+		}
+
+		var running, overlaps atomic.Int32
+		var wg sync.WaitGroup
+		for i := range 6 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := lockers[i%2].WithKeyLock(ctx, key, func(ctx context.Context) error {
+					if running.Add(1) > 1 {
+						overlaps.Add(1)
+					}
+					time.Sleep(5 * time.Millisecond)
+					running.Add(-1)
+					return nil
+				})
+				suite.NoError(err)
+			}()
+		}
+		wg.Wait()
+		suite.Zero(overlaps.Load())
+		exists, err := client.Exists(ctx, []string{glide.DefaultKeyLockPrefix + key})
+		suite.Require().NoError(err)
+		suite.Zero(exists)
+
+		// The lease is renewed while the work runs.
+		err = glide.NewKeyLock(client).WithTTL(100*time.Millisecond).WithKeyLock(ctx, key,
+			func(ctx context.Context) error {
+				time.Sleep(250 * time.Millisecond)
+				return ctx.Err()
+			})
+		suite.NoError(err)
+
+		// A work whose lock is taken over is canceled, and the lock of the new holder is kept.
+		lockKey := glide.DefaultKeyLockPrefix + key
+		err = glide.NewKeyLock(client).WithTTL(300*time.Millisecond).WithKeyLock(ctx, key,
+			func(ctx context.Context) error {
+				_, err := client.Set(ctx, lockKey, "other")
+				suite.Require().NoError(err)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		suite.ErrorIs(err, glide.ErrKeyLockLost)
+		value, err := client.Get(ctx, lockKey)
+		suite.Require().NoError(err)
+		suite.Equal("other", value.Value())
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err = lockers[0].WithKeyLock(timeoutCtx, key, func(ctx context.Context) error { return nil })
+		suite.ErrorIs(err, context.DeadlineExceeded)
+	})
+}
+
+func (suite *GlideTestSuite) TestSampleKeys() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		ctx := context.Background()
+		prefix := uuid.NewString()
+		written := make(map[string]bool)
+		for i := range 50 {
+			key := fmt.Sprintf("%s:%d", prefix, i)
+			suite.verifyOK(client.Set(ctx, key, "value"))
+			written[key] = true
+		}
+
+		keys, err := client.SampleKeys(ctx, 20, *options.NewSampleKeysOptions())
+		suite.Require().NoError(err)
+		suite.NotEmpty(keys)
+		suite.LessOrEqual(len(keys), 20)
+		distinct := make(map[string]bool)
+		for _, key := range keys {
+			distinct[key] = true
+		}
+		suite.Len(distinct, len(keys))
+
+		// A scan samples the keys matching the pattern only.
+		keys, err = client.SampleKeys(ctx, 20, *options.NewSampleKeysOptions().
+			SetMethod(options.SampleWithScan).
+			SetMatch(prefix + ":*").
+			SetScanCount(10))
+		suite.Require().NoError(err)
+		suite.Len(keys, 20)
+		clear(distinct)
+		for _, key := range keys {
+			suite.True(written[key], key)
+			distinct[key] = true
+		}
+		suite.Len(distinct, len(keys))
+
+		keys, err = client.SampleKeys(ctx, 100, *options.NewSampleKeysOptions().
+			SetMethod(options.SampleWithScan).
+			SetMatch(prefix + ":*"))
+		suite.Require().NoError(err)
+		suite.Len(keys, 50)
+
+		_, err = client.SampleKeys(ctx, 1, *options.NewSampleKeysOptions().SetMatch(prefix + ":*"))
+		suite.ErrorContains(err, "SampleWithScan")
+	})
+}
+
+func (suite *GlideTestSuite) TestFixtureLoader() {
+	suite.runWithDefaultClients(func(client interfaces.BaseClientCommands) {
+		prefix := "{" + uuid.NewString() + "}"
+		fixtures, err := glidetest.ParseFixtures([]byte(`{
+			"` + prefix + `:greeting": {"type": "string", "value": "hello", "ttl": "1m"},
+			"` + prefix + `:user": {"type": "hash", "fields": {"name": "Ada"}},
+			"` + prefix + `:queue": {"type": "list", "elements": ["b", "a"]},
+			"` + prefix + `:tags": {"type": "set", "elements": ["x", "y"]},
+			"` + prefix + `:leaderboard": {"type": "zset", "members": {"ada": 10, "bob": 5.5}},
+			"` + prefix + `:events": {"type": "stream", "entries": [{"kind": "login"}, {"kind": "logout"}]}
+		}`))
+		suite.Require().NoError(err)
+		loader := glidetest.NewFixtureLoader(suite.T(), client).Load(fixtures)
+
+		_, err = client.RPop(context.Background(), prefix+":queue")
+		suite.Require().NoError(err)
+		fixtures[prefix+":queue"] = glidetest.Fixture{Type: glidetest.FixtureList, Elements: []string{"b"}}
+		fixtures[prefix+":missing"] = glidetest.Fixture{Type: glidetest.FixtureMissing}
+		loader.Verify(fixtures)
+	})
+}
@@ -15,8 +15,10 @@ import (
 
 	"github.com/google/uuid"
 	glide "github.com/valkey-io/valkey-glide/go/v2"
+	"github.com/valkey-io/valkey-glide/go/v2/glidetest"
 	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
+	"github.com/valkey-io/valkey-glide/go/v2/pipeline"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +42,34 @@ func (suite *GlideTestSuite) TestCustomCommandPing_StringResponse() {
 	assert.Equal(suite.T(), "PONG", result.(string))
 }
 
+func (suite *GlideTestSuite) TestCustomCommandWithAttributes_NoAttributes() {
+	client := suite.defaultClient()
+	key := uuid.NewString()
+	suite.verifyOK(client.Set(context.Background(), key, "value"))
+
+	result, err := client.CustomCommandWithAttributes(context.Background(), []string{"GET", key})
+
+	suite.NoError(err)
+	assert.Equal(suite.T(), "value", result.Value)
+	assert.Nil(suite.T(), result.Attributes)
+}
+
+func (suite *GlideTestSuite) TestCustomCommandWithAttributes_VerbatimString() {
+	client := suite.defaultClient()
+
+	result, err := client.CustomCommandWithAttributes(context.Background(), []string{"LOLWUT"})
+
+	suite.NoError(err)
+	verbatim, ok := result.Value.(models.VerbatimString)
+	suite.True(ok, "%T", result.Value)
+	suite.Equal("txt", verbatim.Format)
+	suite.Contains(verbatim.Text, "ver")
+
+	text, err := client.CustomCommand(context.Background(), []string{"LOLWUT"})
+	suite.NoError(err)
+	suite.Equal(verbatim.Text, text)
+}
+
 func (suite *GlideTestSuite) TestCustomCommandClientInfo() {
 	clientName := "TEST_CLIENT_NAME"
 	config := config.NewClientConfiguration().
@@ -497,6 +527,238 @@ func (suite *GlideTestSuite) TestTime_Success() {
 	assert.Less(suite.T(), microseconds, int64(1000000))
 }
 
+func (suite *GlideTestSuite) TestServerClock() {
+	clock := glide.NewServerClock(suite.defaultClient())
+	estimate, err := clock.Estimate(context.Background())
+	suite.NoError(err)
+	suite.Positive(estimate.RoundTrip)
+	// The server runs on the same host as the tests.
+	suite.Less(estimate.Offset.Abs(), time.Second)
+
+	now, err := clock.Now(context.Background())
+	suite.NoError(err)
+	suite.WithinDuration(time.Now(), now, time.Second)
+}
+
+func (suite *GlideTestSuite) TestCaptureCommands() {
+	client := suite.defaultClient()
+	capture := glide.NewCommandCapture()
+	client.CaptureCommands(capture)
+	defer client.CaptureCommands(nil)
+	key := uuid.NewString()
+
+	_, err := client.Set(context.Background(), key, "value")
+	suite.NoError(err)
+	_, err = client.Unlink(context.Background(), []string{key})
+	suite.NoError(err)
+	glidetest.AssertSent(suite.T(), capture, 1, "UNLINK", key)
+	glidetest.AssertSent(suite.T(), capture, 1, "SET", key, "value")
+	glidetest.AssertNotSent(suite.T(), capture, "DEL")
+
+	// In dry run, the commands aren't sent.
+	client.CaptureCommands(glide.NewCommandCapture().WithDryRun())
+	_, err = client.Set(context.Background(), key, "value")
+	suite.ErrorIs(err, glide.ErrCommandCaptured)
+	client.CaptureCommands(nil)
+	exists, err := client.Exists(context.Background(), []string{key})
+	suite.NoError(err)
+	suite.Equal(int64(0), exists)
+}
+
+func (suite *GlideTestSuite) TestResumableScan() {
+	client := suite.defaultClient()
+	prefix := "resumable:" + uuid.NewString() + ":"
+	expected := make([]string, 30)
+	for i := range expected {
+		expected[i] = fmt.Sprintf("%s%d", prefix, i)
+		suite.verifyOK(client.Set(context.Background(), expected[i], "value"))
+	}
+	scanOptions := *options.NewScanOptions().SetMatch(prefix + "*").SetCount(5)
+
+	// The scan is interrupted after its first page, and resumed from its cursor by a new scan.
+	scan, err := client.ResumableScan("", scanOptions)
+	suite.NoError(err)
+	keys, err := scan.Next(context.Background())
+	suite.NoError(err)
+	resumed, err := client.ResumableScan(scan.Cursor(), scanOptions)
+	suite.NoError(err)
+	for !resumed.Finished() {
+		page, err := resumed.Next(context.Background())
+		suite.NoError(err)
+		keys = append(keys, page...)
+	}
+	// SCAN may return a key more than once.
+	assert.Subset(suite.T(), keys, expected)
+	assert.Subset(suite.T(), expected, keys)
+}
+
+func (suite *GlideTestSuite) TestResumableScan_AdaptiveCount() {
+	client := suite.defaultClient()
+	prefix := "adaptive:" + uuid.NewString() + ":"
+	expected := make([]string, 30)
+	for i := range expected {
+		expected[i] = fmt.Sprintf("%s%d", prefix, i)
+		suite.verifyOK(client.Set(context.Background(), expected[i], "value"))
+	}
+
+	scan, err := client.ResumableScan("", *options.NewScanOptions().SetMatch(prefix + "*"))
+	suite.Require().NoError(err)
+	scan.WithAdaptiveCount(options.NewAdaptiveCountOptions().SetLatencyTarget(time.Minute))
+	var keys []string
+	for !scan.Finished() {
+		page, err := scan.Next(context.Background())
+		suite.Require().NoError(err)
+		keys = append(keys, page...)
+	}
+	assert.Subset(suite.T(), keys, expected)
+	// The sparse match grows the COUNT of the pages.
+	stats := scan.Stats()
+	suite.Greater(stats.Count, int64(options.DefaultAdaptiveCountMin))
+	suite.Equal(int64(len(keys)), stats.Keys)
+}
+
+func (suite *GlideTestSuite) TestShutdown_Abort() {
+	suite.SkipIfServerVersionLowerThan("7.0.0", suite.T())
+	client := suite.defaultClient()
+
+	// Aborting fails without an ongoing shutdown, and the server keeps serving.
+	err := client.Shutdown(context.Background(), *options.NewShutdownOptions().SetAbort())
+	assert.IsType(suite.T(), &glide.RequestError{}, err)
+	_, err = client.Ping(context.Background())
+	assert.NoError(suite.T(), err)
+
+	err = client.Shutdown(context.Background(), *options.NewShutdownOptions().SetSaveMode(options.ShutdownSave).SetAbort())
+	assert.ErrorContains(suite.T(), err, "ABORT can't be combined")
+}
+
+func (suite *GlideTestSuite) TestShutdown() {
+	output, err := startDedicatedValkeyServer(suite, false)
+	require.NoError(suite.T(), err)
+	clusterFolder := extractClusterFolder(suite, output)
+	addresses := extractAddresses(suite, output)
+	// The server is shut down by the test, so stopping it may fail.
+	defer runClusterManager(suite, []string{"stop", "--cluster-folder", clusterFolder}, true)
+	clientInterface, err := createDedicatedClient(addresses, false, false)
+	require.NoError(suite.T(), err)
+	client := clientInterface.(*glide.Client)
+	defer client.Close()
+
+	err = client.Shutdown(context.Background(), *options.NewShutdownOptions().SetSaveMode(options.ShutdownNoSave))
+	assert.NoError(suite.T(), err)
+
+	// The server is gone: the requests fail until it's restarted.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = client.Ping(ctx)
+	assert.Error(suite.T(), err)
+
+	// Closed locally, the client reports it can't reach the server rather than a successful shutdown.
+	client.Close()
+	err = client.Shutdown(context.Background(), *options.NewShutdownOptions().SetSaveMode(options.ShutdownNoSave))
+	assert.IsType(suite.T(), &glide.ClosingError{}, err)
+}
+
+func (suite *GlideTestSuite) TestBgSave() {
+	client := suite.defaultClient()
+
+	status, err := client.BgSaveWithOptions(context.Background(), options.BgSaveSchedule)
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), status, "Background saving")
+
+	assert.Eventually(suite.T(), func() bool {
+		persistence, err := client.PersistenceInfo(context.Background())
+		return err == nil && !persistence.BgSaveInProgress && persistence.LastBgSaveStatus == "ok"
+	}, 10*time.Second, 50*time.Millisecond)
+	persistence, err := client.PersistenceInfo(context.Background())
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), persistence.Node)
+	assert.WithinDuration(suite.T(), time.Now(), persistence.LastSave, time.Minute)
+}
+
+func (suite *GlideTestSuite) TestExecBestEffort() {
+	client := suite.defaultClient()
+	key := uuid.NewString()
+
+	batch := pipeline.NewStandaloneBatch(false).Set(key, "value").Get(key).Get(uuid.NewString())
+	responses, err := client.ExecBestEffort(
+		context.Background(),
+		*batch,
+		*pipeline.NewBestEffortBatchOptions(5 * time.Second).WithChunkSize(2),
+	)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []any{"OK", "value", nil}, responses)
+
+	_, err = client.ExecBestEffort(
+		context.Background(),
+		*pipeline.NewStandaloneBatch(true).Get(key),
+		*pipeline.NewBestEffortBatchOptions(time.Second),
+	)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *GlideTestSuite) TestConnections() {
+	client := suite.defaultClient()
+	id, err := client.ClientId(context.Background())
+	suite.Require().NoError(err)
+
+	connections, err := client.Connections(context.Background())
+	suite.Require().NoError(err)
+	suite.Require().Len(connections, 1)
+	suite.Equal(id, connections[0].ID)
+	suite.NotEmpty(connections[0].Addr)
+	suite.False(connections[0].Dedicated)
+	suite.False(connections[0].CreatedAt.After(time.Now()))
+
+	pool := glide.NewDedicatedPool(suite.defaultClientConfig())
+	defer pool.Close()
+	handle, err := pool.Acquire(context.Background())
+	suite.Require().NoError(err)
+	defer handle.Release()
+	handleID, err := handle.ClientId(context.Background())
+	suite.Require().NoError(err)
+	dedicated := pool.Connections()
+	suite.Require().Len(dedicated, 1)
+	suite.Equal(handleID, dedicated[0].ID)
+	suite.True(dedicated[0].Dedicated)
+}
+
+func (suite *GlideTestSuite) TestTwoLevelCache() {
+	t := suite.T()
+	ctx := context.Background()
+	client := suite.defaultClient()
+	previous, err := client.ConfigGet(ctx, []string{"notify-keyspace-events"})
+	require.NoError(t, err)
+	suite.verifyOK(client.ConfigSet(ctx, map[string]string{"notify-keyspace-events": "KA"}))
+	defer client.ConfigSet(ctx, previous)
+
+	subscriber, err := suite.client(suite.defaultClientConfig())
+	require.NoError(t, err)
+	defer subscriber.Close()
+	prefix := "{two-level}" + uuid.NewString() + ":"
+	key := prefix + "user"
+	cache := glide.NewTwoLevelCache(client)
+	require.NoError(t, cache.Listen(ctx, subscriber, prefix+"*"))
+	defer cache.Close()
+
+	require.NoError(t, cache.Set(ctx, key, "alice"))
+	for range 2 {
+		value, err := cache.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", value.Value())
+	}
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.RemoteHits)
+	assert.Equal(t, uint64(1), stats.LocalHits)
+
+	// The write of another client is invalidated by the feed.
+	suite.verifyOK(subscriber.Set(ctx, key, "bob"))
+	assert.Eventually(t, func() bool {
+		value, err := cache.Get(ctx, key)
+		return err == nil && value.Value() == "bob"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Positive(t, cache.Stats().Invalidations)
+}
+
 func (suite *GlideTestSuite) TestTime_Error() {
 	client := suite.defaultClient()
 
@@ -849,6 +1111,95 @@ func (suite *GlideTestSuite) TestClientId() {
 	assert.Greater(suite.T(), result, int64(0))
 }
 
+func (suite *GlideTestSuite) TestClientInfo() {
+	client := suite.defaultClient()
+	id, err := client.ClientId(context.Background())
+	suite.NoError(err)
+
+	info, err := client.ClientInfo(context.Background())
+	suite.NoError(err)
+	suite.Equal(strconv.FormatInt(id, 10), info["id"])
+	suite.Contains(info, "addr")
+}
+
+func (suite *GlideTestSuite) TestDedicatedClient_ResetOnRelease() {
+	pool := glide.NewDedicatedPool(suite.defaultClientConfig()).WithValidation(true)
+	defer pool.Close()
+
+	handle, err := pool.Acquire(context.Background())
+	suite.Require().NoError(err)
+	_, err = handle.Watch(context.Background(), []string{uuid.NewString()})
+	suite.NoError(err)
+	handle.Release()
+
+	// The watching handle was reset and closed, so a new connection is handed out.
+	next, err := pool.Acquire(context.Background())
+	suite.Require().NoError(err)
+	defer next.Release()
+	suite.NotSame(handle.Client, next.Client)
+	result, err := next.Reset(context.Background())
+	suite.NoError(err)
+	suite.Equal("RESET", result)
+}
+
+func (suite *GlideTestSuite) TestDedicatedClient_SnapshotRead() {
+	ctx := context.Background()
+	client := suite.defaultClient()
+	pool := glide.NewDedicatedPool(suite.defaultClientConfig())
+	defer pool.Close()
+	handle, err := pool.Acquire(ctx)
+	suite.Require().NoError(err)
+	defer handle.Release()
+
+	balance, limit := uuid.NewString(), uuid.NewString()
+	suite.verifyOK(client.Set(ctx, balance, "100"))
+	values, err := handle.SnapshotRead(ctx, []string{balance, limit})
+	suite.Require().NoError(err)
+	suite.Equal([]models.Result[string]{models.CreateStringResult("100"), models.CreateNilStringResult()}, values)
+	result, err := handle.Exec(ctx, *pipeline.NewStandaloneBatch(true).Set(balance, "90"), true)
+	suite.Require().NoError(err)
+	suite.Equal([]any{"OK"}, result)
+
+	// The transaction is aborted if a key changed since the snapshot.
+	_, err = handle.SnapshotRead(ctx, []string{balance, limit})
+	suite.Require().NoError(err)
+	suite.verifyOK(client.Set(ctx, limit, "50"))
+	result, err = handle.Exec(ctx, *pipeline.NewStandaloneBatch(true).Set(balance, "80"), true)
+	suite.Require().NoError(err)
+	suite.Nil(result)
+	value, err := client.Get(ctx, balance)
+	suite.Require().NoError(err)
+	suite.Equal("90", value.Value())
+}
+
+func (suite *GlideTestSuite) TestClientView_PrefixRoute() {
+	ctx := context.Background()
+	client := suite.defaultClient()
+	// The routed requests are observed under the key prefix of the view they're routed to.
+	moved := client.With(glide.NewViewOptions().WithKeyPrefix("moved:"))
+	router := client.With(glide.NewViewOptions().WithPrefixRoute("sess:", moved))
+
+	session, cart := "sess:"+uuid.NewString(), "cart:"+uuid.NewString()
+	suite.verifyOK(router.Set(ctx, session, "token"))
+	suite.verifyOK(router.Set(ctx, cart, "apples"))
+	value, err := client.Get(ctx, "moved:"+session)
+	suite.Require().NoError(err)
+	suite.Equal("token", value.Value())
+	value, err = client.Get(ctx, cart)
+	suite.Require().NoError(err)
+	suite.Equal("apples", value.Value())
+	value, err = router.Get(ctx, session)
+	suite.Require().NoError(err)
+	suite.Equal("token", value.Value())
+
+	results, err := router.Exec(ctx, *pipeline.NewStandaloneBatch(false).Get(session).Ping(), true)
+	suite.Require().NoError(err)
+	suite.Equal([]any{"token", "PONG"}, results)
+
+	_, err = router.MGet(ctx, []string{session, cart})
+	suite.IsType(&glide.ConfigurationError{}, err)
+}
+
 func (suite *GlideTestSuite) TestLastSave() {
 	client := suite.defaultClient()
 	t := suite.T()
@@ -1384,3 +1735,91 @@ func (suite *GlideTestSuite) TestScriptKill() {
 	assert.Error(suite.T(), err)
 	assert.True(suite.T(), strings.Contains(strings.ToLower(err.Error()), "notbusy"))
 }
+
+func (suite *GlideTestSuite) TestDatabaseManager() {
+	t := suite.T()
+	ctx := context.Background()
+
+	manager, err := glide.NewDatabaseManager(suite.defaultClientConfig(), 0, 3)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	key := uuid.NewString()
+	db3, err := manager.DB(3)
+	require.NoError(t, err)
+	suite.verifyOK(db3.Set(ctx, key, "value"))
+	value, err := db3.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, "value", value.Value())
+
+	db0, err := manager.DB(0)
+	require.NoError(t, err)
+	value, err = db0.Get(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, value.IsNil())
+
+	client, err := manager.Open(5)
+	require.NoError(t, err)
+	value, err = client.Get(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, value.IsNil())
+	db5, err := manager.DB(5)
+	require.NoError(t, err)
+	assert.Same(t, client, db5)
+
+	_, err = manager.DB(7)
+	assert.EqualError(t, err, "database 7 wasn't opened by the database manager")
+
+	subscriptionConfig := suite.defaultClientConfig().
+		WithSubscriptionConfig(config.NewStandaloneSubscriptionConfig().WithSubscription(config.ExactChannelMode, "channel"))
+	_, err = glide.NewDatabaseManager(subscriptionConfig)
+	assert.Error(t, err)
+}
+
+func (suite *GlideTestSuite) TestKillClients() {
+	client := suite.defaultClient()
+	name := "kill-clients-" + uuid.NewString()
+	victim, err := suite.client(suite.defaultClientConfig().WithClientName(name))
+	suite.NoError(err)
+	defer victim.Close()
+
+	// The connections of the library are only killed once confirmed
+	filter := options.NewClientKillFilter().SetName(name)
+	connections, err := client.PreviewKillClients(context.Background(), *filter)
+	suite.NoError(err)
+	suite.Empty(connections)
+
+	filter.SetIncludeLibraryConnections(true)
+	connections, err = client.PreviewKillClients(context.Background(), *filter)
+	suite.NoError(err)
+	suite.Len(connections, 1)
+	suite.Equal(name, connections[0].Name)
+
+	killed, err := client.KillClients(context.Background(), *filter)
+	suite.NoError(err)
+	suite.Equal(int64(1), killed)
+
+	_, err = client.KillClients(context.Background(), *options.NewClientKillFilter())
+	suite.Error(err)
+}
+
+func (suite *GlideTestSuite) TestModuleCommands() {
+	client := suite.defaultClient()
+	modules, err := client.ModuleList(context.Background())
+	suite.NoError(err)
+	for _, module := range modules {
+		suite.NotEmpty(module.Name)
+	}
+
+	// The library doesn't exist, or loading modules at runtime is disabled by enable-module-command.
+	_, err = client.ModuleLoad(context.Background(), "/nonexistent/"+uuid.NewString()+".so", nil)
+	suite.Error(err)
+	_, err = client.ModuleLoadEx(
+		context.Background(),
+		"/nonexistent/"+uuid.NewString()+".so",
+		*options.NewModuleLoadExOptions().SetConfig("name", "value").SetArgs("arg"),
+	)
+	suite.Error(err)
+	_, err = client.ModuleUnload(context.Background(), uuid.NewString())
+	suite.Error(err)
+}
@@ -129,6 +129,26 @@ func ConvertArrayOfMemberAndScore(data any) (any, error) {
 	return memberAndScoreArray, nil
 }
 
+// HRandFieldWithCountWithFieldValues
+func ConvertArrayOfFieldValue(data any) (any, error) {
+	converted, err := arrayConverter[[]string]{
+		arrayConverter[string]{
+			nil,
+			false,
+		},
+		false,
+	}.convert(data)
+	if err != nil {
+		return nil, err
+	}
+	pairs := converted.([][]string)
+	fieldValueArray := make([]models.FieldValue, 0, len(pairs))
+	for _, pair := range pairs {
+		fieldValueArray = append(fieldValueArray, models.FieldValue{Field: pair[0], Value: pair[1]})
+	}
+	return fieldValueArray, nil
+}
+
 // XAutoClaim XAutoClaimWithOptions
 func ConvertXAutoClaimResponse(data any) (any, error) {
 	arr := data.([]any)
@@ -5,6 +5,7 @@ package interfaces
 import (
 	"context"
 
+	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
 	"github.com/valkey-io/valkey-glide/go/v2/pipeline"
 )
@@ -22,10 +23,14 @@ type BaseClientCommands interface {
 	GeoSpatialCommands
 	ScriptingAndFunctionBaseCommands
 	PubSubCommands
+	SearchCommands
 
 	Watch(ctx context.Context, keys []string) (string, error)
 	Unwatch(ctx context.Context) (string, error)
 
+	// Capabilities returns the version of the server of the client and the modules it loaded.
+	Capabilities(ctx context.Context) (models.Capabilities, error)
+
 	// Close terminates the client by closing all associated resources.
 	Close()
 }
@@ -46,6 +51,11 @@ type GlideClientCommands interface {
 		raiseOnError bool,
 		options pipeline.StandaloneBatchOptions,
 	) ([]any, error)
+	ExecBestEffort(
+		ctx context.Context,
+		batch pipeline.StandaloneBatch,
+		options pipeline.BestEffortBatchOptions,
+	) ([]any, error)
 }
 
 type GlideClusterClientCommands interface {
@@ -65,4 +75,9 @@ type GlideClusterClientCommands interface {
 		raiseOnError bool,
 		options pipeline.ClusterBatchOptions,
 	) ([]any, error)
+	ExecBestEffort(
+		ctx context.Context,
+		batch pipeline.ClusterBatch,
+		options pipeline.BestEffortBatchOptions,
+	) ([]any, error)
 }
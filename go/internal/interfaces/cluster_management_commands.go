@@ -5,6 +5,7 @@ package interfaces
 import (
 	"context"
 
+	"github.com/valkey-io/valkey-glide/go/v2/config"
 	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
 )
@@ -232,4 +233,22 @@ type ClusterManagementCommands interface {
 	//
 	// [valkey.io]: https://valkey.io/commands/cluster-links/
 	ClusterLinksWithRoute(ctx context.Context, route options.RouteOption) (models.ClusterValue[[]map[string]any], error)
+
+	// ClusterFailover makes the replica defined by route take over its primary.
+	ClusterFailover(ctx context.Context, route config.Route) (string, error)
+
+	// ClusterFailoverWithMode makes the replica defined by route take over its primary, in FORCE or TAKEOVER mode.
+	ClusterFailoverWithMode(ctx context.Context, route config.Route, mode options.ClusterFailoverMode) (string, error)
+
+	// ClusterMeet connects the node defined by route to the node at host:port.
+	ClusterMeet(ctx context.Context, route config.Route, host string, port int64) (string, error)
+
+	// ClusterForget removes a node from the nodes known by the nodes defined by route.
+	ClusterForget(ctx context.Context, route config.Route, nodeId string) (string, error)
+
+	// ClusterReset resets the node defined by route.
+	ClusterReset(ctx context.Context, route config.Route, mode options.ClusterResetMode) (string, error)
+
+	// ClusterSetConfigEpoch sets the configuration epoch of the new node defined by route.
+	ClusterSetConfigEpoch(ctx context.Context, route config.Route, epoch int64) (string, error)
 }
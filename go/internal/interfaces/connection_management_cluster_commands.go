@@ -27,6 +27,19 @@ type ConnectionManagementClusterCommands interface {
 
 	ClientIdWithOptions(ctx context.Context, routeOptions options.RouteOption) (models.ClusterValue[int64], error)
 
+	ClientInfo(ctx context.Context) (models.ClusterValue[map[string]string], error)
+
+	ClientInfoWithOptions(
+		ctx context.Context,
+		routeOptions options.RouteOption,
+	) (models.ClusterValue[map[string]string], error)
+
+	PreviewKillClients(ctx context.Context, filter options.ClientKillFilter) ([]models.ClientConnection, error)
+
+	Connections(ctx context.Context) ([]models.ConnectionIdentity, error)
+
+	KillClients(ctx context.Context, filter options.ClientKillFilter) (int64, error)
+
 	ClientSetName(ctx context.Context, connectionName string) (string, error)
 
 	ClientSetNameWithOptions(
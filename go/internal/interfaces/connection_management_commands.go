@@ -23,7 +23,17 @@ type ConnectionManagementCommands interface {
 
 	ClientId(ctx context.Context) (int64, error)
 
+	ClientInfo(ctx context.Context) (map[string]string, error)
+
+	PreviewKillClients(ctx context.Context, filter options.ClientKillFilter) ([]models.ClientConnection, error)
+
+	Connections(ctx context.Context) ([]models.ConnectionIdentity, error)
+
+	KillClients(ctx context.Context, filter options.ClientKillFilter) (int64, error)
+
 	ClientGetName(ctx context.Context) (models.Result[string], error)
 
 	ClientSetName(ctx context.Context, connectionName string) (string, error)
+
+	Reset(ctx context.Context) (string, error)
 }
@@ -67,6 +67,10 @@ type GenericBaseCommands interface {
 
 	Unlink(ctx context.Context, keys []string) (int64, error)
 
+	UnlinkMulti(ctx context.Context, keys []string, unlinkOptions options.UnlinkMultiOptions) ([]int64, error)
+
+	SampleKeys(ctx context.Context, count int, sampleOptions options.SampleKeysOptions) ([]string, error)
+
 	Touch(ctx context.Context, keys []string) (int64, error)
 
 	Type(ctx context.Context, key string) (string, error)
@@ -89,6 +93,8 @@ type GenericBaseCommands interface {
 
 	ObjectEncoding(ctx context.Context, key string) (models.Result[string], error)
 
+	Inspect(ctx context.Context, key string) (models.KeyInspection, error)
+
 	Dump(ctx context.Context, key string) (models.Result[string], error)
 
 	ObjectFreq(ctx context.Context, key string) (models.Result[int64], error)
@@ -18,6 +18,8 @@ import (
 type GenericClusterCommands interface {
 	CustomCommand(ctx context.Context, args []string) (models.ClusterValue[any], error)
 
+	CustomCommandWithAttributes(ctx context.Context, args []string) (models.AttributedValue, error)
+
 	CustomCommandWithRoute(ctx context.Context, args []string, route config.Route) (models.ClusterValue[any], error)
 
 	Scan(ctx context.Context, cursor models.ClusterScanCursor) (models.ClusterScanResult, error)
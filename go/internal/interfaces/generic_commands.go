@@ -17,6 +17,8 @@ import (
 type GenericCommands interface {
 	CustomCommand(ctx context.Context, args []string) (any, error)
 
+	CustomCommandWithAttributes(ctx context.Context, args []string) (models.AttributedValue, error)
+
 	Move(ctx context.Context, key string, dbIndex int64) (bool, error)
 
 	Scan(ctx context.Context, cursor models.Cursor) (models.ScanResult, error)
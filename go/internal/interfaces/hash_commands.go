@@ -22,8 +22,12 @@ type HashCommands interface {
 
 	HMGet(ctx context.Context, key string, fields []string) ([]models.Result[string], error)
 
+	HMGetMulti(ctx context.Context, keys []string, fields []string) (map[string]map[string]models.Result[string], error)
+
 	HSet(ctx context.Context, key string, values map[string]string) (int64, error)
 
+	HSetAllChunked(ctx context.Context, key string, values map[string]string, chunkOptions options.ChunkOptions) (int64, error)
+
 	HSetNX(ctx context.Context, key string, field string, value string) (bool, error)
 
 	HDel(ctx context.Context, key string, fields []string) (int64, error)
@@ -57,6 +61,8 @@ type HashCommands interface {
 
 	HRandFieldWithCountWithValues(ctx context.Context, key string, count int64) ([][]string, error)
 
+	HRandFieldWithCountWithFieldValues(ctx context.Context, key string, count int64) ([]models.FieldValue, error)
+
 	HSetEx(ctx context.Context, key string, fieldsAndValues map[string]string, options options.HSetExOptions) (int64, error)
 
 	HGetEx(ctx context.Context, key string, fields []string, options options.HGetExOptions) ([]models.Result[string], error)
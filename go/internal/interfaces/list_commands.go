@@ -19,6 +19,8 @@ import (
 type ListCommands interface {
 	LPush(ctx context.Context, key string, elements []string) (int64, error)
 
+	LPushValues(ctx context.Context, key string, values ...string) (int64, error)
+
 	LPop(ctx context.Context, key string) (models.Result[string], error)
 
 	LPopCount(ctx context.Context, key string, count int64) ([]string, error)
@@ -39,6 +41,10 @@ type ListCommands interface {
 
 	RPush(ctx context.Context, key string, elements []string) (int64, error)
 
+	RPushValues(ctx context.Context, key string, values ...string) (int64, error)
+
+	RPushAllChunked(ctx context.Context, key string, elements []string, chunkOptions options.ChunkOptions) (int64, error)
+
 	LRange(ctx context.Context, key string, start int64, end int64) ([]string, error)
 
 	LIndex(ctx context.Context, key string, index int64) (models.Result[string], error)
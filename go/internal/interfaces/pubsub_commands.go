@@ -25,6 +25,8 @@ type PubSubStandaloneCommands interface {
 type PubSubClusterCommands interface {
 	// Publish publishes a message to a channel. Returns the number of clients that received the message.
 	Publish(ctx context.Context, channel string, message string, sharded bool) (int64, error)
+	// SPublish publishes a message to a sharded channel. Returns the number of clients that received the message.
+	SPublish(ctx context.Context, channel string, message string) (int64, error)
 	PubSubShardChannels(ctx context.Context) ([]string, error)
 	PubSubShardChannelsWithPattern(ctx context.Context, pattern string) ([]string, error)
 	PubSubShardNumSub(ctx context.Context, channels ...string) (map[string]int64, error)
@@ -0,0 +1,18 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package interfaces
+
+import "context"
+
+// Supports the commands of the search module managing index aliases, for standalone and cluster clients.
+//
+// See [valkey.io] for details.
+//
+// [valkey.io]: https://valkey.io/commands/#search
+type SearchCommands interface {
+	FtAliasAdd(ctx context.Context, alias string, index string) (string, error)
+
+	FtAliasUpdate(ctx context.Context, alias string, index string) (string, error)
+
+	FtAliasDel(ctx context.Context, alias string) (string, error)
+}
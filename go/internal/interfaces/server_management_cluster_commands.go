@@ -21,6 +21,8 @@ type ServerManagementClusterCommands interface {
 
 	InfoWithOptions(ctx context.Context, options options.ClusterInfoOptions) (models.ClusterValue[string], error)
 
+	ReplicationLag(ctx context.Context) ([]models.ReplicaLag, error)
+
 	TimeWithOptions(ctx context.Context, routeOption options.RouteOption) (models.ClusterValue[[]string], error)
 
 	DBSizeWithOptions(ctx context.Context, routeOption options.RouteOption) (int64, error)
@@ -41,6 +43,16 @@ type ServerManagementClusterCommands interface {
 
 	LastSaveWithOptions(ctx context.Context, routeOption options.RouteOption) (models.ClusterValue[int64], error)
 
+	BgSave(ctx context.Context) (models.ClusterValue[string], error)
+
+	BgSaveWithOptions(ctx context.Context, bgSaveOptions options.ClusterBgSaveOptions) (models.ClusterValue[string], error)
+
+	BgRewriteAof(ctx context.Context) (models.ClusterValue[string], error)
+
+	BgRewriteAofWithOptions(ctx context.Context, routeOption options.RouteOption) (models.ClusterValue[string], error)
+
+	PersistenceInfo(ctx context.Context, routeOption options.RouteOption) ([]models.PersistenceInfo, error)
+
 	ConfigResetStat(ctx context.Context) (string, error)
 
 	ConfigResetStatWithOptions(ctx context.Context, routeOption options.RouteOption) (string, error)
@@ -57,10 +69,38 @@ type ServerManagementClusterCommands interface {
 		routeOption options.RouteOption,
 	) (models.ClusterValue[map[string]string], error)
 
+	ConfigDrift(ctx context.Context, parameters []string) ([]models.ConfigMismatch, error)
+
 	ConfigRewrite(ctx context.Context) (string, error)
 
 	ConfigRewriteWithOptions(ctx context.Context, routeOption options.RouteOption) (string, error)
 
+	Shutdown(ctx context.Context, shutdownOptions options.ShutdownOptions, route options.RouteOption) error
+
+	ModuleList(ctx context.Context) ([]models.ServerModule, error)
+
+	ModuleListWithOptions(
+		ctx context.Context,
+		routeOption options.RouteOption,
+	) (models.ClusterValue[[]models.ServerModule], error)
+
+	ModuleLoad(ctx context.Context, path string, args []string) (string, error)
+
+	ModuleLoadWithOptions(ctx context.Context, path string, args []string, routeOption options.RouteOption) (string, error)
+
+	ModuleLoadEx(ctx context.Context, path string, opts options.ModuleLoadExOptions) (string, error)
+
+	ModuleLoadExWithOptions(
+		ctx context.Context,
+		path string,
+		opts options.ModuleLoadExOptions,
+		routeOption options.RouteOption,
+	) (string, error)
+
+	ModuleUnload(ctx context.Context, name string) (string, error)
+
+	ModuleUnloadWithOptions(ctx context.Context, name string, routeOption options.RouteOption) (string, error)
+
 	// AclCat returns a list of all ACL categories.
 	//
 	// See [valkey.io] for details.
@@ -5,6 +5,7 @@ package interfaces
 import (
 	"context"
 
+	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
 )
 
@@ -24,6 +25,8 @@ type ServerManagementCommands interface {
 
 	InfoWithOptions(ctx context.Context, options options.InfoOptions) (string, error)
 
+	ReplicationLag(ctx context.Context) ([]models.ReplicaLag, error)
+
 	DBSize(ctx context.Context) (int64, error)
 
 	Time(ctx context.Context) ([]string, error)
@@ -42,10 +45,28 @@ type ServerManagementCommands interface {
 
 	LastSave(ctx context.Context) (int64, error)
 
+	BgSave(ctx context.Context) (string, error)
+
+	BgSaveWithOptions(ctx context.Context, mode options.BgSaveMode) (string, error)
+
+	BgRewriteAof(ctx context.Context) (string, error)
+
+	PersistenceInfo(ctx context.Context) (models.PersistenceInfo, error)
+
 	ConfigResetStat(ctx context.Context) (string, error)
 
 	ConfigRewrite(ctx context.Context) (string, error)
 
+	Shutdown(ctx context.Context, shutdownOptions options.ShutdownOptions) error
+
+	ModuleList(ctx context.Context) ([]models.ServerModule, error)
+
+	ModuleLoad(ctx context.Context, path string, args []string) (string, error)
+
+	ModuleLoadEx(ctx context.Context, path string, opts options.ModuleLoadExOptions) (string, error)
+
+	ModuleUnload(ctx context.Context, name string) (string, error)
+
 	// AclCat returns a list of all ACL categories.
 	//
 	// See [valkey.io] for details.
@@ -178,6 +178,10 @@ type StreamCommands interface {
 
 	XInfoGroups(ctx context.Context, key string) ([]models.XInfoGroupInfo, error)
 
+	StreamLag(ctx context.Context, key string, group string) (models.StreamLag, error)
+
+	AggregateStreamLag(ctx context.Context, group string, keys ...string) (models.StreamLagSummary, error)
+
 	XRange(
 		ctx context.Context,
 		key string,
@@ -4,6 +4,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
@@ -19,12 +20,16 @@ type StringCommands interface {
 
 	SetWithOptions(ctx context.Context, key string, value string, options options.SetOptions) (models.Result[string], error)
 
+	SetDurable(ctx context.Context, key string, value string, numberOfReplicas int64, timeout time.Duration) (int64, error)
+
 	Get(ctx context.Context, key string) (models.Result[string], error)
 
 	GetEx(ctx context.Context, key string) (models.Result[string], error)
 
 	GetExWithOptions(ctx context.Context, key string, options options.GetExOptions) (models.Result[string], error)
 
+	GetAndRefresh(ctx context.Context, key string, ttl time.Duration) (models.Result[string], error)
+
 	MSet(ctx context.Context, keyValueMap map[string]string) (string, error)
 
 	MGet(ctx context.Context, keys []string) ([]models.Result[string], error)
@@ -37,10 +42,23 @@ type StringCommands interface {
 
 	IncrByFloat(ctx context.Context, key string, amount float64) (float64, error)
 
+	IncrByWithOptions(ctx context.Context, key string, amount int64, incrOptions options.IncrOptions) (int64, error)
+
+	IncrByFloatWithOptions(
+		ctx context.Context,
+		key string,
+		amount float64,
+		incrOptions options.IncrByFloatOptions,
+	) (float64, error)
+
+	IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
 	Decr(ctx context.Context, key string) (int64, error)
 
 	DecrBy(ctx context.Context, key string, amount int64) (int64, error)
 
+	DecrByWithOptions(ctx context.Context, key string, amount int64, incrOptions options.IncrOptions) (int64, error)
+
 	Strlen(ctx context.Context, key string) (int64, error)
 
 	SetRange(ctx context.Context, key string, offset int, value string) (int64, error)
@@ -56,4 +74,6 @@ type StringCommands interface {
 	LCSWithOptions(ctx context.Context, key1, key2 string, opts options.LCSIdxOptions) (*models.LCSMatch, error)
 
 	GetDel(ctx context.Context, key string) (models.Result[string], error)
+
+	Take(ctx context.Context, key string) (models.Result[string], error)
 }
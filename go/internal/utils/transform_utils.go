@@ -88,6 +88,93 @@ func Concat(slices ...[]string) []string {
 	return newSlice
 }
 
+// Unique returns the strings of values without duplicates, in the order of their first occurrence.
+func Unique(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; !ok {
+			seen[value] = struct{}{}
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// GlobMatch reports whether value matches the glob-style pattern, with the semantics of the server: `*` matches any
+// sequence, `?` any single character, `[...]` a set or range of characters (`[^...]` negates it), and `\` escapes
+// the next character.
+func GlobMatch(pattern, value string) bool {
+	for len(pattern) > 0 && len(value) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for ; len(value) > 0; value = value[1:] {
+				if GlobMatch(pattern[1:], value) {
+					return true
+				}
+			}
+			return GlobMatch(pattern[1:], value)
+		case '?':
+			value = value[1:]
+		case '[':
+			pattern = pattern[1:]
+			negate := len(pattern) > 0 && pattern[0] == '^'
+			if negate {
+				pattern = pattern[1:]
+			}
+			match := false
+			for len(pattern) > 0 && pattern[0] != ']' {
+				switch {
+				case pattern[0] == '\\' && len(pattern) >= 2:
+					pattern = pattern[1:]
+					match = match || pattern[0] == value[0]
+				case len(pattern) >= 3 && pattern[1] == '-':
+					start, end := pattern[0], pattern[2]
+					if start > end {
+						start, end = end, start
+					}
+					match = match || (value[0] >= start && value[0] <= end)
+					pattern = pattern[2:]
+				default:
+					match = match || pattern[0] == value[0]
+				}
+				pattern = pattern[1:]
+			}
+			if match == negate {
+				return false
+			}
+			value = value[1:]
+			if len(pattern) == 0 {
+				// Unterminated set
+				return len(value) == 0
+			}
+		case '\\':
+			if len(pattern) >= 2 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if pattern[0] != value[0] {
+				return false
+			}
+			value = value[1:]
+		}
+		pattern = pattern[1:]
+	}
+	if len(value) == 0 {
+		for len(pattern) > 0 && pattern[0] == '*' {
+			pattern = pattern[1:]
+		}
+	}
+	return len(pattern) == 0 && len(value) == 0
+}
+
 func ToString(v any) (string, bool) {
 	switch val := v.(type) {
 	case string:
@@ -99,3 +99,43 @@ func TestConcat(t *testing.T) {
 		})
 	}
 }
+
+func TestUnique(t *testing.T) {
+	assert.Equal(t, []string{}, Unique(nil))
+	assert.Equal(t, []string{"a", "b"}, Unique([]string{"a", "b"}))
+	assert.Equal(t, []string{"b", "a", "c"}, Unique([]string{"b", "a", "b", "c", "a"}))
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		match   bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"user:*", "user:1", true},
+		{"user:*", "session:1", false},
+		{"user:*:name", "user:1:name", true},
+		{"user:*:name", "user:1:age", false},
+		{"h?llo", "hallo", true},
+		{"h?llo", "hllo", false},
+		{"h[ae]llo", "hello", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-b]llo", "hbllo", true},
+		{"h[b-a]llo", "hallo", true},
+		{"h[a-b]llo", "hcllo", false},
+		{"h\\*llo", "h*llo", true},
+		{"h\\*llo", "hello", false},
+		{"h*", "h", true},
+		{"a**b", "axyzb", true},
+		{"", "", true},
+		{"", "a", false},
+		{"abc", "ab", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, GlobMatch(tt.pattern, tt.value), "GlobMatch(%q, %q)", tt.pattern, tt.value)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// jsonIdentifier matches the member names written in the dot notation of JSONPath.
+var jsonIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// jsonClient is implemented by [Client] and [ClusterClient].
+type jsonClient interface {
+	jsonNumIncrBy(ctx context.Context, key string, path string, number string) (string, error)
+	jsonArrAppend(ctx context.Context, key string, path string, values []string) ([]models.Result[int64], error)
+}
+
+// jsonNumber is the type of the numeric fields of the documents updated by JsonIncrField.
+type jsonNumber interface {
+	~int | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// JsonFieldPath returns the JSONPath of field, a pointer to a field of document, in the JSON encoding of document,
+// e.g. "$.stats.views" for &page.Stats.Views. The path is derived from the json tags of the fields, as
+// [encoding/json] encodes them: the fields of embedded structs without a tag are promoted, and the fields tagged "-"
+// and the unexported fields aren't encoded. Only the fields of nested structs, not of pointers, maps or slices, can be
+// addressed.
+//
+// Example usage:
+//
+//	var page Page
+//	path, err := glide.JsonFieldPath(&page, &page.Stats.Views)
+func JsonFieldPath[T any](document *T, field any) (string, error) {
+	documentValue := reflect.ValueOf(document)
+	if document == nil || documentValue.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("the document must be a pointer to a struct, got %T", document)
+	}
+	fieldValue := reflect.ValueOf(field)
+	if fieldValue.Kind() != reflect.Pointer || fieldValue.IsNil() {
+		return "", fmt.Errorf("the field must be a pointer to a field of the document, got %T", field)
+	}
+	path, ok := jsonFieldPath(documentValue.Elem(), fieldValue, "$")
+	if !ok {
+		return "", fmt.Errorf("%T doesn't point to an encoded field of %T", field, document)
+	}
+	return path, nil
+}
+
+// jsonFieldPath returns the path of field among the fields of value, a struct at path.
+func jsonFieldPath(value reflect.Value, field reflect.Value, path string) (string, bool) {
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		name, encoded := jsonFieldName(structField)
+		if !encoded {
+			continue
+		}
+		member := value.Field(i)
+		memberPath := path + jsonPathSegment(name)
+		if structField.IsExported() && member.Addr().Pointer() == field.Pointer() && member.Type() == field.Type().Elem() {
+			return memberPath, true
+		}
+		if member.Kind() != reflect.Struct {
+			continue
+		}
+		if structField.Anonymous && structField.Tag.Get("json") == "" {
+			memberPath = path
+		}
+		if found, ok := jsonFieldPath(member, field, memberPath); ok {
+			return found, true
+		}
+	}
+	return "", false
+}
+
+// jsonFieldName returns the name of structField in JSON, and whether it's encoded.
+func jsonFieldName(structField reflect.StructField) (string, bool) {
+	if !structField.IsExported() && !(structField.Anonymous && structField.Type.Kind() == reflect.Struct) {
+		return "", false
+	}
+	tag := structField.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return structField.Name, true
+}
+
+// jsonPathSegment returns the JSONPath segment selecting the member name.
+func jsonPathSegment(name string) string {
+	if jsonIdentifier.MatchString(name) {
+		return "." + name
+	}
+	quoted, _ := json.Marshal(name)
+	return "[" + string(quoted) + "]"
+}
+
+// JsonIncrField increments field, a numeric field of document, in the JSON document of key by increment, atomically,
+// with JSON.NUMINCRBY, and returns its new value. The path of the field is derived from the json tags of document, see
+// [JsonFieldPath]. document is only used for its type and the address of field: it's neither read nor updated.
+//
+// The JSON module must be loaded on the server.
+//
+// Example usage:
+//
+//	var page Page
+//	views, err := glide.JsonIncrField(ctx, client, "page:1", &page, &page.Stats.Views, 1)
+func JsonIncrField[T any, N jsonNumber](
+	ctx context.Context,
+	client jsonClient,
+	key string,
+	document *T,
+	field *N,
+	increment N,
+) (N, error) {
+	path, err := JsonFieldPath(document, field)
+	if err != nil {
+		return 0, err
+	}
+	number, err := json.Marshal(increment)
+	if err != nil {
+		return 0, err
+	}
+	reply, err := client.jsonNumIncrBy(ctx, key, path, string(number))
+	if err != nil {
+		return 0, err
+	}
+	var values []*N
+	if err := json.Unmarshal([]byte(reply), &values); err != nil {
+		return 0, fmt.Errorf("unexpected reply %q of JSON.NUMINCRBY: %w", reply, err)
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("%s doesn't exist in the JSON document of %q", path, key)
+	}
+	if values[0] == nil {
+		return 0, fmt.Errorf("%s isn't a number in the JSON document of %q", path, key)
+	}
+	return *values[0], nil
+}
+
+// JsonAppendField appends values to field, a slice field of document, in the JSON document of key, atomically, with
+// JSON.ARRAPPEND, and returns the new length of the array. The values are encoded with [encoding/json], and the path
+// of the field is derived from the json tags of document, see [JsonFieldPath]. document is only used for its type and
+// the address of field: it's neither read nor updated.
+//
+// The JSON module must be loaded on the server.
+//
+// Example usage:
+//
+//	var cart Cart
+//	length, err := glide.JsonAppendField(ctx, client, "cart:1", &cart, &cart.Items, Item{SKU: "A-1", Quantity: 2})
+func JsonAppendField[T any, E any](
+	ctx context.Context,
+	client jsonClient,
+	key string,
+	document *T,
+	field *[]E,
+	values ...E,
+) (int64, error) {
+	path, err := JsonFieldPath(document, field)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("at least one value must be appended to %s", path)
+	}
+	encoded := make([]string, 0, len(values))
+	for _, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return 0, err
+		}
+		encoded = append(encoded, string(data))
+	}
+	lengths, err := client.jsonArrAppend(ctx, key, path, encoded)
+	if err != nil {
+		return 0, err
+	}
+	if len(lengths) == 0 {
+		return 0, fmt.Errorf("%s doesn't exist in the JSON document of %q", path, key)
+	}
+	if lengths[0].IsNil() {
+		return 0, fmt.Errorf("%s isn't an array in the JSON document of %q", path, key)
+	}
+	return lengths[0].Value(), nil
+}
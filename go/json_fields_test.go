@@ -0,0 +1,125 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+type jsonTestStats struct {
+	Views  int64   `json:"views"`
+	Rating float64 `json:"rating,omitempty"`
+}
+
+type jsonTestAudit struct {
+	Revision int
+}
+
+type jsonTestPage struct {
+	jsonTestAudit
+	Title    string        `json:"title"`
+	Stats    jsonTestStats `json:"stats"`
+	Tags     []string      `json:"tags"`
+	Counters jsonTestStats `json:"page-counters"`
+	Ignored  int           `json:"-"`
+	Untagged int
+	private  int
+}
+
+func TestJsonFieldPath(t *testing.T) {
+	var page jsonTestPage
+	paths := map[string]any{
+		"$.title":                  &page.Title,
+		"$.stats":                  &page.Stats,
+		"$.stats.views":            &page.Stats.Views,
+		"$.stats.rating":           &page.Stats.Rating,
+		"$.tags":                   &page.Tags,
+		`$["page-counters"].views`: &page.Counters.Views,
+		"$.Untagged":               &page.Untagged,
+		"$.Revision":               &page.Revision,
+	}
+	for expected, field := range paths {
+		path, err := JsonFieldPath(&page, field)
+		require.NoError(t, err)
+		assert.Equal(t, expected, path)
+	}
+
+	for _, field := range []any{&page.Ignored, &page.private, &page.jsonTestAudit, page.Stats, nil, new(int64)} {
+		_, err := JsonFieldPath(&page, field)
+		assert.Error(t, err, "%T", field)
+	}
+	title := "title"
+	_, err := JsonFieldPath(&title, &title)
+	assert.Error(t, err)
+}
+
+// fakeJsonClient records the JSON commands it's sent, and replies with canned responses.
+type fakeJsonClient struct {
+	paths         []string
+	values        []string
+	incrReply     string
+	appendLengths []models.Result[int64]
+}
+
+func (client *fakeJsonClient) jsonNumIncrBy(ctx context.Context, key string, path string, number string) (string, error) {
+	client.paths, client.values = append(client.paths, path), append(client.values, number)
+	return client.incrReply, nil
+}
+
+func (client *fakeJsonClient) jsonArrAppend(
+	ctx context.Context,
+	key string,
+	path string,
+	values []string,
+) ([]models.Result[int64], error) {
+	client.paths, client.values = append(client.paths, path), append(client.values, values...)
+	return client.appendLengths, nil
+}
+
+func TestJsonIncrField(t *testing.T) {
+	var page jsonTestPage
+	client := &fakeJsonClient{incrReply: "[42]"}
+
+	views, err := JsonIncrField(context.Background(), client, "page", &page, &page.Stats.Views, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), views)
+	assert.Equal(t, []string{"$.stats.views"}, client.paths)
+	assert.Equal(t, []string{"2"}, client.values)
+
+	client.incrReply = "[4.5]"
+	rating, err := JsonIncrField(context.Background(), client, "page", &page, &page.Stats.Rating, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, rating)
+
+	client.incrReply = "[]"
+	_, err = JsonIncrField(context.Background(), client, "page", &page, &page.Stats.Views, 1)
+	assert.ErrorContains(t, err, "doesn't exist")
+
+	client.incrReply = "[null]"
+	_, err = JsonIncrField(context.Background(), client, "page", &page, &page.Stats.Views, 1)
+	assert.ErrorContains(t, err, "isn't a number")
+}
+
+func TestJsonAppendField(t *testing.T) {
+	var page jsonTestPage
+	client := &fakeJsonClient{appendLengths: []models.Result[int64]{models.CreateInt64Result(3)}}
+
+	length, err := JsonAppendField(context.Background(), client, "page", &page, &page.Tags, "go", `"quoted"`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), length)
+	assert.Equal(t, []string{"$.tags"}, client.paths)
+	assert.Equal(t, []string{`"go"`, `"\"quoted\""`}, client.values)
+
+	client.appendLengths = []models.Result[int64]{models.CreateNilInt64Result()}
+	_, err = JsonAppendField(context.Background(), client, "page", &page, &page.Tags, "go")
+	assert.ErrorContains(t, err, "isn't an array")
+
+	_, err = JsonAppendField(context.Background(), client, "page", &page, &page.Tags)
+	assert.Error(t, err)
+}
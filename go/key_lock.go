@@ -0,0 +1,265 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+const (
+	// DefaultKeyLockTTL is the lease of the distributed locks of a [KeyLock], unless configured otherwise.
+	DefaultKeyLockTTL = 10 * time.Second
+	// DefaultKeyLockRetryInterval is the interval between two attempts of a [KeyLock] to take a distributed lock held
+	// by another instance, unless configured otherwise.
+	DefaultKeyLockRetryInterval = 50 * time.Millisecond
+	// DefaultKeyLockPrefix is the prefix of the keys of the distributed locks of a [KeyLock], unless configured
+	// otherwise.
+	DefaultKeyLockPrefix = "lock:"
+)
+
+// ErrKeyLockLost is returned by WithKeyLock of a [KeyLock] if the distributed lock expired, or was taken over, while
+// the work held it: the context of the work is canceled with it as cause.
+var ErrKeyLockLost = errors.New("the distributed lock of the key was lost")
+
+// renewKeyLockScript extends the lease of the lock KEYS[1] to ARGV[2] milliseconds if it's held with the token
+// ARGV[1], and returns 1, or 0 if it isn't.
+var renewKeyLockScript = sync.OnceValue(func() *options.Script {
+	return options.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+})
+
+// releaseKeyLockScript deletes the lock KEYS[1] if it's held with the token ARGV[1].
+var releaseKeyLockScript = sync.OnceValue(func() *options.Script {
+	return options.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+})
+
+// localKeyLock is the mutex of a key of a [KeyLock] within the process, held while its channel holds a value.
+type localKeyLock struct {
+	held chan struct{}
+	// users is the number of goroutines holding or waiting for the mutex, which is forgotten once there are none.
+	users int
+}
+
+// KeyLock serializes work per key, both within the process and across the instances of a service, e.g. the rebuild
+// of a cached value or the migration of a record, see WithKeyLock.
+//
+// The work of a key first takes a mutex of the key local to the process, so that the goroutines of an instance wait
+// in the process rather than polling Valkey, then a distributed lock: the key of the lock, the key prefixed with the
+// configured prefix, is set with SET NX and a lease, to a token of the holder. The lease is renewed in the background
+// while the work runs, every third of the TTL, and the lock is released once the work returns, only if it still
+// holds it, so that a holder whose lease expired never releases the lock of the next holder.
+//
+// A work whose lock is lost, its lease having expired, e.g. as the process stalled, or its lock key having been
+// deleted, has its context canceled with [ErrKeyLockLost]: the work must stop writing once its context is done, as
+// another holder may run meanwhile.
+//
+// Example usage:
+//
+//	locks := glide.NewKeyLock(client).WithTTL(30 * time.Second)
+//	err := locks.WithKeyLock(ctx, "report:"+id, func(ctx context.Context) error {
+//		return rebuildReport(ctx, id)
+//	})
+type KeyLock struct {
+	client        interfaces.BaseClientCommands
+	ttl           time.Duration
+	retryInterval time.Duration
+	prefix        string
+
+	mu    sync.Mutex
+	local map[string]*localKeyLock
+}
+
+// NewKeyLock returns a [KeyLock] taking the distributed locks with client.
+func NewKeyLock(client interfaces.BaseClientCommands) *KeyLock {
+	return &KeyLock{
+		client:        client,
+		ttl:           DefaultKeyLockTTL,
+		retryInterval: DefaultKeyLockRetryInterval,
+		prefix:        DefaultKeyLockPrefix,
+		local:         make(map[string]*localKeyLock),
+	}
+}
+
+// WithTTL sets the lease of the distributed locks, renewed while the work runs, which bounds how long a lock outlives
+// a holder which crashed. It must be at least 3ms. Defaults to DefaultKeyLockTTL.
+func (lock *KeyLock) WithTTL(ttl time.Duration) *KeyLock {
+	lock.ttl = ttl
+	return lock
+}
+
+// WithRetryInterval sets the interval between two attempts to take a distributed lock held by another instance.
+// Defaults to DefaultKeyLockRetryInterval.
+func (lock *KeyLock) WithRetryInterval(retryInterval time.Duration) *KeyLock {
+	lock.retryInterval = retryInterval
+	return lock
+}
+
+// WithPrefix sets the prefix of the keys of the distributed locks. Defaults to DefaultKeyLockPrefix.
+func (lock *KeyLock) WithPrefix(prefix string) *KeyLock {
+	lock.prefix = prefix
+	return lock
+}
+
+// WithKeyLock runs fn once it holds the lock of key, both within the process and across the instances sharing the
+// Valkey server, and releases the lock once fn returns.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the wait for the lock. The context of fn is derived from it.
+//	key - The key to serialize the work of.
+//	fn  - The work, whose context is canceled with [ErrKeyLockLost] if the lock is lost.
+//
+// Return value:
+//
+//	The error of fn, joined with [ErrKeyLockLost] if the lock was lost while fn ran, or the error of the wait for the
+//	lock, e.g. the error of ctx, in which case fn isn't run.
+func (lock *KeyLock) WithKeyLock(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	if lock.ttl < 3*time.Millisecond {
+		return errors.New("the TTL of a key lock must be at least 3ms")
+	}
+	if lock.retryInterval <= 0 {
+		return errors.New("the retry interval of a key lock must be positive")
+	}
+	unlock, err := lock.lockLocal(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lockKey := lock.prefix + key
+	token := uuid.NewString()
+	if err := lock.acquire(ctx, lockKey, token); err != nil {
+		return err
+	}
+	defer func() {
+		// The lock expires with its lease if it can't be released.
+		_, _ = lock.client.InvokeScriptWithOptions(
+			context.WithoutCancel(ctx),
+			*releaseKeyLockScript(),
+			*options.NewScriptOptions().WithKeys([]string{lockKey}).WithArgs([]string{token}),
+		)
+	}()
+
+	workCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		lock.renew(workCtx, lockKey, token, stop, cancel)
+	}()
+	err = fn(workCtx)
+	close(stop)
+	<-renewed
+	if errors.Is(context.Cause(workCtx), ErrKeyLockLost) {
+		return errors.Join(ErrKeyLockLost, err)
+	}
+	return err
+}
+
+// lockLocal takes the mutex of key within the process, and returns the function releasing it.
+func (lock *KeyLock) lockLocal(ctx context.Context, key string) (func(), error) {
+	lock.mu.Lock()
+	local, ok := lock.local[key]
+	if !ok {
+		local = &localKeyLock{held: make(chan struct{}, 1)}
+		lock.local[key] = local
+	}
+	local.users++
+	lock.mu.Unlock()
+	forget := func() {
+		lock.mu.Lock()
+		defer lock.mu.Unlock()
+		if local.users--; local.users == 0 {
+			delete(lock.local, key)
+		}
+	}
+	select {
+	case local.held <- struct{}{}:
+		return func() {
+			<-local.held
+			forget()
+		}, nil
+	case <-ctx.Done():
+		forget()
+		return nil, ctx.Err()
+	}
+}
+
+// acquire takes the distributed lock lockKey with token, retrying until it's free or ctx is done.
+func (lock *KeyLock) acquire(ctx context.Context, lockKey string, token string) error {
+	setOptions := options.NewSetOptions().SetOnlyIfDoesNotExist().SetExpiry(options.NewExpiryIn(lock.ttl))
+	for {
+		result, err := lock.client.SetWithOptions(ctx, lockKey, token, *setOptions)
+		if err != nil {
+			return err
+		}
+		if !result.IsNil() {
+			return nil
+		}
+		timer := time.NewTimer(lock.retryInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// renew extends the lease of the lock lockKey held with token every third of the TTL until stop is closed, and
+// cancels the work with [ErrKeyLockLost] if the lock isn't held anymore, or if its lease expired without a renewal.
+func (lock *KeyLock) renew(
+	ctx context.Context,
+	lockKey string,
+	token string,
+	stop chan struct{},
+	cancel context.CancelCauseFunc,
+) {
+	ticker := time.NewTicker(lock.ttl / 3)
+	defer ticker.Stop()
+	renewedAt := time.Now()
+	scriptOptions := options.NewScriptOptions().
+		WithKeys([]string{lockKey}).
+		WithArgs([]string{token, strconv.FormatInt(lock.ttl.Milliseconds(), 10)})
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		attemptedAt := time.Now()
+		result, err := lock.client.InvokeScriptWithOptions(context.WithoutCancel(ctx), *renewKeyLockScript(), *scriptOptions)
+		switch {
+		case err == nil && result == int64(1):
+			renewedAt = attemptedAt
+		case err == nil:
+			cancel(ErrKeyLockLost)
+			return
+		case time.Since(renewedAt) >= lock.ttl:
+			// The lease can't be known to be held past its expiry.
+			cancel(ErrKeyLockLost)
+			return
+		}
+	}
+}
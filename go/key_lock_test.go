@@ -0,0 +1,62 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyLock_LockLocal(t *testing.T) {
+	locks := NewKeyLock(nil)
+	ctx := context.Background()
+
+	var running, overlaps atomic.Int32
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.lockLocal(ctx, "report")
+			if !assert.NoError(t, err) {
+				return
+			}
+			if running.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Zero(t, overlaps.Load())
+	assert.Empty(t, locks.local)
+
+	// The keys are locked independently, and a wait stops with its context.
+	unlock, err := locks.lockLocal(ctx, "report")
+	require.NoError(t, err)
+	unlockOther, err := locks.lockLocal(ctx, "invoice")
+	require.NoError(t, err)
+	unlockOther()
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = locks.lockLocal(timeoutCtx, "report")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, locks.local["report"].users)
+	unlock()
+	assert.Empty(t, locks.local)
+}
+
+func TestKeyLock_Validation(t *testing.T) {
+	ctx := context.Background()
+	noop := func(ctx context.Context) error { return nil }
+	assert.ErrorContains(t, NewKeyLock(nil).WithTTL(time.Millisecond).WithKeyLock(ctx, "report", noop), "TTL")
+	assert.ErrorContains(t, NewKeyLock(nil).WithRetryInterval(0).WithKeyLock(ctx, "report", noop), "retry interval")
+}
@@ -0,0 +1,233 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+// keyLayout tells which arguments of a command are keys.
+type keyLayout int
+
+const (
+	// The first argument is the only key.
+	firstKey keyLayout = iota
+	// The first two arguments are keys, e.g. RENAME key newkey.
+	firstTwoKeys
+	// All the arguments are keys, e.g. DEL key [key ...].
+	allKeys
+	// All the arguments but the last are keys, e.g. BLPOP key [key ...] timeout.
+	allKeysButLast
+	// All the arguments but the first are keys, e.g. BITOP operation destkey key [key ...].
+	allKeysButFirst
+	// Every other argument is a key, e.g. MSET key value [key value ...].
+	alternateKeys
+	// The first argument is the number of keys following it, e.g. ZUNION numkeys key [key ...].
+	numKeysFirst
+	// The second argument is the number of keys following it, e.g. FCALL function numkeys key [key ...].
+	numKeysSecond
+	// The first argument is a key, and the second the number of keys following it, e.g. ZUNIONSTORE destination numkeys
+	// key [key ...].
+	keyThenNumKeys
+	// The keys follow the STREAMS token, followed by as many IDs, e.g. XREAD STREAMS key [key ...] id [id ...].
+	streamKeys
+)
+
+// keyLayouts are the layouts of the keys of the commands whose keys are known, e.g. to prefix them, by command name
+// as returned by describeCommand.
+var keyLayouts = map[string]keyLayout{}
+
+func init() {
+	layouts := map[keyLayout][]string{
+		firstKey: {
+			"APPEND", "DECR", "DECRBY", "GET", "GETDEL", "GETEX", "GETRANGE", "GETSET", "INCR", "INCRBY",
+			"INCRBYFLOAT", "PSETEX", "SET", "SETEX", "SETNX", "SETRANGE", "STRLEN", "SUBSTR",
+			"BITCOUNT", "BITFIELD", "BITFIELDREADONLY", "BITPOS", "GETBIT", "SETBIT",
+			"DUMP", "EXPIRE", "EXPIREAT", "EXPIRETIME", "MOVE", "OBJECTENCODING", "OBJECTFREQ", "OBJECTIDLETIME",
+			"OBJECTREFCOUNT", "PERSIST", "PEXPIRE", "PEXPIREAT", "PEXPIRETIME", "PTTL", "RESTORE", "SORTREADONLY",
+			"TTL", "TYPE", "MEMORYUSAGE", "CLUSTERKEYSLOT",
+			"GEOADD", "GEODIST", "GEOHASH", "GEOPOS", "GEOSEARCH", "GEORADIUSREADONLY", "GEORADIUSBYMEMBERREADONLY",
+			"HDEL", "HEXISTS", "HGET", "HGETALL", "HINCRBY", "HINCRBYFLOAT", "HKEYS", "HLEN", "HMGET", "HMSET",
+			"HRANDFIELD", "HSCAN", "HSET", "HSETNX", "HSTRLEN", "HVALS", "HSETEX", "HGETEX", "HEXPIRE", "HEXPIREAT",
+			"HPEXPIRE", "HPEXPIREAT", "HPERSIST", "HTTL", "HPTTL", "HEXPIRETIME", "HPEXPIRETIME",
+			"PFADD",
+			"LINDEX", "LINSERT", "LLEN", "LPOP", "LPOS", "LPUSH", "LPUSHX", "LRANGE", "LREM", "LSET", "LTRIM", "RPOP",
+			"RPUSH", "RPUSHX",
+			"SADD", "SCARD", "SISMEMBER", "SMEMBERS", "SMISMEMBER", "SPOP", "SRANDMEMBER", "SREM", "SSCAN",
+			"ZADD", "ZCARD", "ZCOUNT", "ZINCRBY", "ZLEXCOUNT", "ZMSCORE", "ZPOPMAX", "ZPOPMIN", "ZRANDMEMBER",
+			"ZRANGE", "ZRANGEBYLEX", "ZRANGEBYSCORE", "ZRANK", "ZREM", "ZREMRANGEBYLEX", "ZREMRANGEBYRANK",
+			"ZREMRANGEBYSCORE", "ZREVRANGE", "ZREVRANGEBYLEX", "ZREVRANGEBYSCORE", "ZREVRANK", "ZSCAN", "ZSCORE",
+			"XACK", "XADD", "XAUTOCLAIM", "XCLAIM", "XDEL", "XGROUPCREATE", "XGROUPCREATECONSUMER",
+			"XGROUPDELCONSUMER", "XGROUPDESTROY", "XGROUPSETID", "XINFOCONSUMERS", "XINFOGROUPS", "XINFOSTREAM",
+			"XLEN", "XPENDING", "XRANGE", "XREVRANGE", "XSETID", "XTRIM",
+			"JSONARRAPPEND", "JSONARRINDEX", "JSONARRINSERT", "JSONARRLEN", "JSONARRPOP", "JSONARRTRIM", "JSONCLEAR",
+			"JSONDEBUG", "JSONDEL", "JSONFORGET", "JSONGET", "JSONNUMINCRBY", "JSONNUMMULTBY", "JSONOBJKEYS",
+			"JSONOBJLEN", "JSONRESP", "JSONSET", "JSONSTRAPPEND", "JSONSTRLEN", "JSONTOGGLE", "JSONTYPE",
+		},
+		firstTwoKeys: {
+			"COPY", "RENAME", "RENAMENX", "LMOVE", "BLMOVE", "RPOPLPUSH", "BRPOPLPUSH", "SMOVE", "ZRANGESTORE",
+			"GEOSEARCHSTORE", "LCS",
+		},
+		allKeys: {
+			"DEL", "UNLINK", "EXISTS", "TOUCH", "MGET", "WATCH", "SDIFF", "SDIFFSTORE", "SINTER", "SINTERSTORE",
+			"SUNION", "SUNIONSTORE", "PFCOUNT", "PFMERGE",
+		},
+		allKeysButLast:  {"BLPOP", "BRPOP", "BZPOPMAX", "BZPOPMIN", "JSONMGET"},
+		allKeysButFirst: {"BITOP"},
+		alternateKeys:   {"MSET", "MSETNX"},
+		numKeysFirst:    {"SINTERCARD", "ZDIFF", "ZINTER", "ZINTERCARD", "ZUNION", "LMPOP", "ZMPOP"},
+		numKeysSecond: {
+			"BLMPOP", "BZMPOP", "EVAL", "EVALREADONLY", "EVAL_RO", "EVALSHA", "EVALSHAREADONLY", "EVALSHA_RO", "FCALL",
+			"FCALLREADONLY", "FCALL_RO",
+		},
+		keyThenNumKeys: {"ZDIFFSTORE", "ZINTERSTORE", "ZUNIONSTORE"},
+		streamKeys:     {"XREAD", "XREADGROUP"},
+	}
+	for layout, commands := range layouts {
+		for _, command := range commands {
+			keyLayouts[command] = layout
+		}
+	}
+}
+
+// keylessCommandNames are the commands without keys, e.g. sent unchanged by the clients with a key prefix.
+var keylessCommandNames = map[string]bool{
+	"PING": true, "ECHO": true, "INFO": true, "TIME": true, "DBSIZE": true, "SELECT": true, "AUTH": true,
+	"HELLO": true, "LASTSAVE": true, "SAVE": true, "BGSAVE": true, "BGREWRITEAOF": true, "FLUSHALL": true,
+	"FLUSHDB": true, "SWAPDB": true, "RANDOMKEY": true, "SCAN": true, "KEYS": true, "WAIT": true, "WAITAOF": true,
+	"MULTI": true, "EXEC": true, "DISCARD": true, "UNWATCH": true, "LOLWUT": true, "ROLE": true, "FAILOVER": true,
+	"PUBLISH": true, "SPUBLISH": true, "SUBSCRIBE": true, "SSUBSCRIBE": true, "PSUBSCRIBE": true,
+	"UNSUBSCRIBE": true, "SUNSUBSCRIBE": true, "PUNSUBSCRIBE": true, "RESET": true, "READONLY": true,
+	"READWRITE": true, "ASKING": true,
+}
+
+// keylessCommandPrefixes are the prefixes of the administrative commands, which have no keys, e.g. CONFIGGET.
+var keylessCommandPrefixes = []string{
+	"ACL", "CLIENT", "CLUSTER", "COMMAND", "CONFIG", "FUNCTION", "LATENCY", "MODULE", "PUBSUB", "SCRIPT", "SLOWLOG",
+}
+
+// commandNameArgs returns the name of the command of requestType, as keyed in keyLayouts, and its arguments: the
+// arguments following the name for custom commands, or else args.
+func commandNameArgs(requestType uint32, args []string) (string, []string) {
+	if requestType == uint32(protobuf.RequestType_CustomCommand) && len(args) > 0 {
+		return strings.ToUpper(args[0]), args[1:]
+	}
+	return strings.ToUpper(protobuf.RequestType_name[int32(requestType)]), args
+}
+
+// prefixKeys returns the arguments of command with prefix prepended to its keys, or an error if the keys of command
+// aren't known. args isn't modified.
+func prefixKeys(command string, args []string, prefix string) ([]string, error) {
+	indexes, known, err := keyIndexes(command, args)
+	if err != nil {
+		return nil, err
+	}
+	if !known {
+		return nil, fmt.Errorf("%s isn't supported by clients with a key prefix", command)
+	}
+	if len(indexes) == 0 {
+		return args, nil
+	}
+	prefixed := make([]string, len(args))
+	copy(prefixed, args)
+	for _, i := range indexes {
+		prefixed[i] = prefix + prefixed[i]
+	}
+	return prefixed, nil
+}
+
+// commandKeys returns the keys of command called with args, or false if the keys of command aren't known.
+func commandKeys(command string, args []string) ([]string, bool) {
+	indexes, known, err := keyIndexes(command, args)
+	if err != nil || !known {
+		return nil, false
+	}
+	keys := make([]string, len(indexes))
+	for i, index := range indexes {
+		keys[i] = args[index]
+	}
+	return keys, true
+}
+
+// keyIndexes returns the indexes of the keys in the arguments of command, or false if the keys of command aren't
+// known. Commands without keys have no indexes.
+func keyIndexes(command string, args []string) ([]int, bool, error) {
+	if keylessCommandNames[command] {
+		return nil, true, nil
+	}
+	for _, family := range keylessCommandPrefixes {
+		if strings.HasPrefix(command, family) && command != "CLUSTERKEYSLOT" {
+			return nil, true, nil
+		}
+	}
+	layout, ok := keyLayouts[command]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var indexes []int
+	indexRange := func(from, to int) {
+		for i := from; i < to && i < len(args); i++ {
+			indexes = append(indexes, i)
+		}
+	}
+	numKeys := func(index int) (int, error) {
+		if index >= len(args) {
+			return 0, fmt.Errorf("%s is missing its number of keys", command)
+		}
+		count, err := strconv.Atoi(args[index])
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("%s has an invalid number of keys: %q", command, args[index])
+		}
+		return count, nil
+	}
+
+	switch layout {
+	case firstKey:
+		indexRange(0, 1)
+	case firstTwoKeys:
+		indexRange(0, 2)
+	case allKeys:
+		indexRange(0, len(args))
+	case allKeysButLast:
+		indexRange(0, len(args)-1)
+	case allKeysButFirst:
+		indexRange(1, len(args))
+	case alternateKeys:
+		for i := 0; i < len(args); i += 2 {
+			indexes = append(indexes, i)
+		}
+	case numKeysFirst:
+		count, err := numKeys(0)
+		if err != nil {
+			return nil, true, err
+		}
+		indexRange(1, 1+count)
+	case numKeysSecond:
+		count, err := numKeys(1)
+		if err != nil {
+			return nil, true, err
+		}
+		indexRange(2, 2+count)
+	case keyThenNumKeys:
+		count, err := numKeys(1)
+		if err != nil {
+			return nil, true, err
+		}
+		indexRange(0, 1)
+		indexRange(2, 2+count)
+	case streamKeys:
+		for i, arg := range args {
+			if strings.EqualFold(arg, "STREAMS") {
+				count := (len(args) - i - 1) / 2
+				indexRange(i+1, i+1+count)
+				break
+			}
+		}
+	}
+	return indexes, true, nil
+}
@@ -0,0 +1,162 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// keySketchDepth is the number of rows of the count-min sketch of a keySampler.
+const keySketchDepth = 4
+
+// keySampler estimates the accesses of the key prefixes of the requests of a client, as configured by a
+// [config.KeySamplingConfiguration].
+type keySampler struct {
+	percentage float64
+	delimiter  string
+	width      uint64
+	maxHotKeys int
+	// roll returns a pseudo-random percentage in [0, 100).
+	roll func() float64
+
+	mu sync.Mutex
+	// accesses and bytes are count-min sketches of the sampled accesses and bytes per key prefix.
+	accesses [keySketchDepth][]uint64
+	bytes    [keySketchDepth][]uint64
+	// hotKeys are the key prefixes with the most estimated accesses so far, at most maxHotKeys.
+	hotKeys map[string]struct{}
+}
+
+func newKeySampler(samplingConfig *config.KeySamplingConfiguration) *keySampler {
+	sampler := &keySampler{
+		percentage: samplingConfig.GetSamplePercentage(),
+		delimiter:  samplingConfig.GetKeyPrefix(),
+		width:      uint64(samplingConfig.GetSketchWidth()),
+		maxHotKeys: samplingConfig.GetMaxHotKeys(),
+		roll:       func() float64 { return rand.Float64() * 100 },
+		hotKeys:    make(map[string]struct{}),
+	}
+	for row := range keySketchDepth {
+		sampler.accesses[row] = make([]uint64, sampler.width)
+		sampler.bytes[row] = make([]uint64, sampler.width)
+	}
+	return sampler
+}
+
+// sampled reports whether the next request is sampled.
+func (s *keySampler) sampled() bool {
+	return s.roll() < s.percentage
+}
+
+// record records an access to key with args.
+func (s *keySampler) record(key string, args []string) {
+	if key == "" {
+		return
+	}
+	if s.delimiter != "" {
+		key, _, _ = strings.Cut(key, s.delimiter)
+	}
+	var size uint64
+	for _, arg := range args {
+		size += uint64(len(arg))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	indexes := s.indexes(key)
+	for row, index := range indexes {
+		s.accesses[row][index]++
+		s.bytes[row][index] += size
+	}
+	if _, hot := s.hotKeys[key]; hot {
+		return
+	}
+	if len(s.hotKeys) < s.maxHotKeys {
+		s.hotKeys[key] = struct{}{}
+		return
+	}
+	// The key replaces the coldest hot key, if it's hotter.
+	coldest, coldestAccesses := "", uint64(0)
+	for hotKey := range s.hotKeys {
+		if accesses := s.estimate(&s.accesses, s.indexes(hotKey)); coldest == "" || accesses < coldestAccesses {
+			coldest, coldestAccesses = hotKey, accesses
+		}
+	}
+	if s.estimate(&s.accesses, indexes) > coldestAccesses {
+		delete(s.hotKeys, coldest)
+		s.hotKeys[key] = struct{}{}
+	}
+}
+
+// indexes returns the counter of key in each row of the sketches.
+func (s *keySampler) indexes(key string) [keySketchDepth]uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	sum := hash.Sum64()
+	h1, h2 := sum&0xffffffff, sum>>32|1
+	var indexes [keySketchDepth]uint64
+	for row := range indexes {
+		indexes[row] = (h1 + uint64(row)*h2) % s.width
+	}
+	return indexes
+}
+
+// estimate returns the smallest counter of indexes in sketch, the count-min estimate of its key.
+func (s *keySampler) estimate(sketch *[keySketchDepth][]uint64, indexes [keySketchDepth]uint64) uint64 {
+	estimate := sketch[0][indexes[0]]
+	for row := 1; row < keySketchDepth; row++ {
+		estimate = min(estimate, sketch[row][indexes[row]])
+	}
+	return estimate
+}
+
+// hottest returns the estimated accesses of the limit hottest key prefixes, all of them if limit isn't positive.
+func (s *keySampler) hottest(limit int) []models.KeyAccess {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scale := 100 / s.percentage
+	hottest := make([]models.KeyAccess, 0, len(s.hotKeys))
+	for key := range s.hotKeys {
+		indexes := s.indexes(key)
+		hottest = append(hottest, models.KeyAccess{
+			KeyPrefix: key,
+			Accesses:  uint64(float64(s.estimate(&s.accesses, indexes)) * scale),
+			Bytes:     uint64(float64(s.estimate(&s.bytes, indexes)) * scale),
+		})
+	}
+	sort.Slice(hottest, func(i, j int) bool {
+		if hottest[i].Accesses != hottest[j].Accesses {
+			return hottest[i].Accesses > hottest[j].Accesses
+		}
+		return hottest[i].KeyPrefix < hottest[j].KeyPrefix
+	})
+	if limit > 0 && len(hottest) > limit {
+		hottest = hottest[:limit]
+	}
+	return hottest
+}
+
+// GetHotKeys returns the key prefixes the client accessed the most, as estimated by its key sampler, when the client
+// is configured with a [config.KeySamplingConfiguration]. The requests are sampled by their first key.
+//
+// Parameters:
+//
+//	limit - The maximum number of key prefixes returned, or 0 for all the tracked prefixes.
+//
+// Return value:
+//
+//	The estimated accesses of the hottest key prefixes since the client was created, hottest first, or nil if key
+//	sampling is disabled.
+func (client *baseClient) GetHotKeys(limit int) []models.KeyAccess {
+	if client.keySampler == nil {
+		return nil
+	}
+	return client.keySampler.hottest(limit)
+}
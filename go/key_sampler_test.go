@@ -0,0 +1,57 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestKeySampler_HotKeys(t *testing.T) {
+	sampler := newKeySampler(config.NewKeySamplingConfiguration().
+		WithSamplePercentage(50).
+		WithKeyPrefix(":").
+		WithMaxHotKeys(2))
+	assert.True(t, fixedSample(sampler, 10))
+	assert.False(t, fixedSample(sampler, 50))
+
+	for i := range 30 {
+		sampler.record(fmt.Sprintf("user:%d", i), []string{fmt.Sprintf("user:%d", i), "12345"})
+	}
+	for i := range 10 {
+		sampler.record(fmt.Sprintf("session:%d", i), []string{fmt.Sprintf("session:%d", i)})
+	}
+	sampler.record("cart:1", []string{"cart:1"})
+	sampler.record("", []string{"ignored"})
+
+	// The estimates are scaled by the sample percentage.
+	assert.Equal(t, []models.KeyAccess{
+		{KeyPrefix: "user", Accesses: 60, Bytes: 2 * (30*5 + 10*6 + 20*7)},
+		{KeyPrefix: "session", Accesses: 20, Bytes: 2 * (10 * 9)},
+	}, sampler.hottest(0))
+	assert.Len(t, sampler.hottest(1), 1)
+
+	// A hotter key replaces the coldest hot key.
+	for range 15 {
+		sampler.record("cart:2", []string{"cart:2"})
+	}
+	hottest := sampler.hottest(0)
+	assert.Equal(t, "user", hottest[0].KeyPrefix)
+	assert.Equal(t, "cart", hottest[1].KeyPrefix)
+	assert.Equal(t, uint64(32), hottest[1].Accesses)
+}
+
+func TestKeySampler_Disabled(t *testing.T) {
+	assert.Nil(t, (&baseClient{}).GetHotKeys(10))
+}
+
+// fixedSample reports whether sampler samples a request for the given roll.
+func fixedSample(sampler *keySampler, roll float64) bool {
+	sampler.roll = func() float64 { return roll }
+	return sampler.sampled()
+}
@@ -0,0 +1,84 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+)
+
+// slotCount is the number of hash slots of a cluster.
+const slotCount = 16384
+
+// crc16Table is the table of the CRC16 (XMODEM) checksum used to hash the keys of a cluster.
+var crc16Table = func() [256]uint16 {
+	var table [256]uint16
+	for i := range table {
+		crc := uint16(i) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// keyHashTag returns the part of key which is hashed to find its slot: its hash tag, the content of its first {...}
+// if it's not empty, or else the whole key.
+func keyHashTag(key string) string {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key[start+1 : start+1+end]
+		}
+	}
+	return key
+}
+
+// keySlot returns the hash slot of key in a cluster, as CLUSTER KEYSLOT would.
+func keySlot(key string) int {
+	var crc uint16
+	tag := keyHashTag(key)
+	for i := 0; i < len(tag); i++ {
+		crc = crc<<8 ^ crc16Table[byte(crc>>8)^tag[i]]
+	}
+	return int(crc % slotCount)
+}
+
+// sameSlotKey returns a key of the same slot as key, named after suffix, or false if there is none, i.e. if key
+// has no hash tag and can't be used as one.
+func sameSlotKey(key string, suffix string) (string, bool) {
+	tag := keyHashTag(key)
+	if tag == key && strings.ContainsAny(key, "{}") {
+		return "", false
+	}
+	return "{" + tag + "}" + suffix, true
+}
+
+// checkTransactionSlot returns a [CrossSlotError] if the keys of the commands of batch, with prefix prepended, don't
+// map to the same slot. The commands whose keys aren't known are left to the server to check.
+func checkTransactionSlot(batch internal.Batch, prefix string) error {
+	slot := -1
+	var offending []string
+	seen := make(map[string]bool)
+	for _, cmd := range batch.Commands {
+		keys, _ := commandKeys(commandNameArgs(cmd.RequestType, cmd.Args))
+		for _, key := range keys {
+			key = prefix + key
+			if slot < 0 {
+				slot = keySlot(key)
+			} else if keySlot(key) != slot && !seen[key] {
+				seen[key] = true
+				offending = append(offending, key)
+			}
+		}
+	}
+	if len(offending) > 0 {
+		return &CrossSlotError{slot: slot, keys: offending}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestKeySlot(t *testing.T) {
+	// The slots returned by CLUSTER KEYSLOT.
+	assert.Equal(t, 12739, keySlot("123456789"))
+	assert.Equal(t, 12182, keySlot("foo"))
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.following"))
+	assert.Equal(t, keySlot("{}.following"), keySlot("{}.following"))
+	assert.NotEqual(t, keySlot("{}a"), keySlot("{}b"))
+	assert.Equal(t, keySlot("bar"), keySlot("foo{bar}{zap}"))
+}
+
+func TestSameSlotKey(t *testing.T) {
+	for _, key := range []string{"audience:1", "{campaign:7}:audience", "a{b"} {
+		other, ok := sameSlotKey(key, ":tmp")
+		if key == "a{b" {
+			assert.False(t, ok)
+			continue
+		}
+		assert.True(t, ok)
+		assert.Equal(t, keySlot(key), keySlot(other), key)
+	}
+}
+
+func TestCheckTransactionSlot(t *testing.T) {
+	identity := func(response any) (any, error) { return response, nil }
+	transaction := func(cmds ...internal.Cmd) internal.Batch {
+		return internal.Batch{Commands: cmds, IsAtomic: true}
+	}
+
+	sameSlot := transaction(
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"{user:1}:name", "alice"}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_Incr), []string{"{user:1}:visits"}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_Ping), nil, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_CustomCommand), []string{"get", "{user:1}:email"}, identity),
+	)
+	assert.NoError(t, checkTransactionSlot(sameSlot, ""))
+
+	crossSlot := transaction(
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"a", "1"}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_MSet), []string{"b", "2", "a", "3"}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_CustomCommand), []string{"DEL", "b", "c"}, identity),
+	)
+	err := checkTransactionSlot(crossSlot, "")
+	require.ErrorIs(t, err, ErrCrossSlot)
+	var crossSlotErr *CrossSlotError
+	require.ErrorAs(t, err, &crossSlotErr)
+	assert.Equal(t, keySlot("a"), crossSlotErr.Slot())
+	assert.Equal(t, []string{"b", "c"}, crossSlotErr.Keys())
+	assert.Contains(t, err.Error(), `"b"`)
+	assert.Contains(t, err.Error(), `"c"`)
+
+	// The keys are checked with the prefix of the view prepended.
+	prefixed := transaction(
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"a", "1"}, identity),
+		internal.MakeCmd(uint32(protobuf.RequestType_Set), []string{"b", "2"}, identity),
+	)
+	assert.NoError(t, checkTransactionSlot(prefixed, "{tenant:1}:"))
+}
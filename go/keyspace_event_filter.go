@@ -0,0 +1,78 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+const (
+	keyspaceChannelPrefix = "__keyspace@"
+	keyeventChannelPrefix = "__keyevent@"
+)
+
+// parseKeyspaceNotification returns the key and the event of a keyspace notification. ok is false if the message
+// isn't a keyspace notification.
+//
+// Notifications are published on `__keyspace@<db>__:<key>` with the event as message, and on
+// `__keyevent@<db>__:<event>` with the key as message.
+func parseKeyspaceNotification(message *models.PubSubMessage) (key string, event string, ok bool) {
+	var suffix string
+	isKeyspace := strings.HasPrefix(message.Channel, keyspaceChannelPrefix)
+	switch {
+	case isKeyspace:
+		suffix = message.Channel[len(keyspaceChannelPrefix):]
+	case strings.HasPrefix(message.Channel, keyeventChannelPrefix):
+		suffix = message.Channel[len(keyeventChannelPrefix):]
+	default:
+		return "", "", false
+	}
+	_, name, found := strings.Cut(suffix, "__:")
+	if !found {
+		return "", "", false
+	}
+	if isKeyspace {
+		return name, message.Message, true
+	}
+	return message.Message, name, true
+}
+
+// keyspaceFilterMatches reports whether the keyspace notification of key and event matches filter.
+func keyspaceFilterMatches(filter *config.KeyspaceEventFilter, key string, event string) bool {
+	if events := filter.GetEvents(); len(events) > 0 && !slices.Contains(events, config.KeyspaceEvent(event)) {
+		return false
+	}
+	patterns := filter.GetKeyPatterns()
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if utils.GlobMatch(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsMessage reports whether a received message must be dispatched: messages other than keyspace
+// notifications always are, and keyspace notifications are if the handler has no filter or they match one.
+func (handler *MessageHandler) acceptsMessage(message *models.PubSubMessage) bool {
+	if len(handler.keyspaceFilters) == 0 {
+		return true
+	}
+	key, event, ok := parseKeyspaceNotification(message)
+	if !ok {
+		return true
+	}
+	for _, filter := range handler.keyspaceFilters {
+		if keyspaceFilterMatches(filter, key, event) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParseKeyspaceNotification(t *testing.T) {
+	key, event, ok := parseKeyspaceNotification(models.NewPubSubMessage("expired", "__keyspace@0__:user:1"))
+	assert.True(t, ok)
+	assert.Equal(t, "user:1", key)
+	assert.Equal(t, "expired", event)
+
+	key, event, ok = parseKeyspaceNotification(models.NewPubSubMessage("user:1", "__keyevent@12__:del"))
+	assert.True(t, ok)
+	assert.Equal(t, "user:1", key)
+	assert.Equal(t, "del", event)
+
+	_, _, ok = parseKeyspaceNotification(models.NewPubSubMessage("hello", "news"))
+	assert.False(t, ok)
+}
+
+func TestMessageHandler_KeyspaceEventFilters(t *testing.T) {
+	handler := NewMessageHandler(nil, nil)
+	handler.keyspaceFilters = []*config.KeyspaceEventFilter{
+		config.NewKeyspaceEventFilter().
+			WithKeyPatterns("user:*", "session:*").
+			WithEvents(config.KeyspaceEventExpired, config.KeyspaceEventDel),
+		config.NewKeyspaceEventFilter().WithEvents(config.KeyspaceEventEvicted),
+	}
+
+	messages := []*models.PubSubMessage{
+		models.NewPubSubMessage("expired", "__keyspace@0__:user:1"),
+		models.NewPubSubMessage("set", "__keyspace@0__:user:1"),
+		models.NewPubSubMessage("session:2", "__keyevent@0__:del"),
+		models.NewPubSubMessage("cart:3", "__keyevent@0__:del"),
+		models.NewPubSubMessage("evicted", "__keyspace@0__:cart:3"),
+		models.NewPubSubMessage("hello", "news"),
+	}
+	for _, message := range messages {
+		handler.dispatchMessage(message)
+	}
+
+	var dispatched []*models.PubSubMessage
+	for message := handler.GetQueue().Pop(); message != nil; message = handler.GetQueue().Pop() {
+		dispatched = append(dispatched, message)
+	}
+	assert.Equal(t, []*models.PubSubMessage{messages[0], messages[2], messages[4], messages[5]}, dispatched)
+}
@@ -0,0 +1,121 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+)
+
+// DefaultListPageSize is the number of elements read per LRANGE by a [ListIterator], unless configured otherwise.
+const DefaultListPageSize = 1000
+
+// ListIterator iterates over the elements of a list, reading them lazily by pages of LRANGE, e.g. for export jobs
+// reading lists of millions of elements without pulling them in a single reply.
+//
+// The pages are read by index: elements pushed or popped at the head of the list while it's iterated over forward,
+// or at its tail while it's iterated over backward, shift the following pages, so that elements may be skipped or
+// returned twice.
+//
+// Example usage:
+//
+//	iterator := glide.NewListIterator(client, "events").WithPageSize(500)
+//	for iterator.Next(ctx) {
+//		export(iterator.Value())
+//	}
+//	if err := iterator.Err(); err != nil {
+//		return err
+//	}
+type ListIterator struct {
+	client   interfaces.BaseClientCommands
+	key      string
+	pageSize int64
+	reverse  bool
+
+	page []string
+	// index is the index of the current element in page, and offset the number of elements of the previous pages.
+	index  int
+	offset int64
+	last   bool
+	err    error
+}
+
+// NewListIterator returns a [ListIterator] iterating over the elements of the list of key from its head, by pages of
+// DefaultListPageSize elements.
+func NewListIterator(client interfaces.BaseClientCommands, key string) *ListIterator {
+	return &ListIterator{client: client, key: key, pageSize: DefaultListPageSize}
+}
+
+// WithPageSize sets the number of elements read per LRANGE.
+func (iterator *ListIterator) WithPageSize(pageSize int64) *ListIterator {
+	iterator.pageSize = pageSize
+	return iterator
+}
+
+// WithReverse sets whether the elements are iterated over from the tail of the list to its head. Disabled by default.
+func (iterator *ListIterator) WithReverse(reverse bool) *ListIterator {
+	iterator.reverse = reverse
+	return iterator
+}
+
+// Next advances to the next element, reading the next page once the elements of the current one were returned.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	Whether there is a next element, returned by Value. Once Next returns false, Err returns the error which stopped
+//	the iteration, if any.
+func (iterator *ListIterator) Next(ctx context.Context) bool {
+	if iterator.err != nil {
+		return false
+	}
+	iterator.index++
+	if iterator.index < len(iterator.page) {
+		return true
+	}
+	if iterator.last {
+		iterator.page = nil
+		return false
+	}
+	if iterator.pageSize <= 0 {
+		iterator.err = fmt.Errorf("the page size of a list iterator must be positive, got %d", iterator.pageSize)
+		return false
+	}
+
+	start, end := iterator.offset, iterator.offset+iterator.pageSize-1
+	if iterator.reverse {
+		start, end = -end-1, -start-1
+	}
+	page, err := iterator.client.LRange(ctx, iterator.key, start, end)
+	if err != nil {
+		iterator.page, iterator.err = nil, err
+		return false
+	}
+	if iterator.reverse {
+		for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+			page[i], page[j] = page[j], page[i]
+		}
+	}
+	iterator.page, iterator.index = page, 0
+	iterator.offset += int64(len(page))
+	iterator.last = int64(len(page)) < iterator.pageSize
+	return len(page) > 0
+}
+
+// Value returns the current element, once Next returned true.
+func (iterator *ListIterator) Value() string {
+	if iterator.index >= len(iterator.page) {
+		return ""
+	}
+	return iterator.page[iterator.index]
+}
+
+// Err returns the error which stopped the iteration, or nil if it ended with the last element of the list.
+func (iterator *ListIterator) Err() error {
+	return iterator.err
+}
@@ -0,0 +1,89 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+)
+
+// fakeListClient serves LRANGE from an in-memory list, with the index semantics of the server.
+type fakeListClient struct {
+	interfaces.BaseClientCommands
+	elements []string
+	ranges   [][2]int64
+	err      error
+}
+
+func (client *fakeListClient) LRange(ctx context.Context, key string, start int64, end int64) ([]string, error) {
+	client.ranges = append(client.ranges, [2]int64{start, end})
+	if client.err != nil {
+		return nil, client.err
+	}
+	length := int64(len(client.elements))
+	if start < 0 {
+		start = max(start+length, 0)
+	}
+	if end < 0 {
+		end += length
+	}
+	end = min(end, length-1)
+	if start > end {
+		return []string{}, nil
+	}
+	return append([]string(nil), client.elements[start:end+1]...), nil
+}
+
+func iterateList(iterator *ListIterator) []string {
+	var elements []string
+	for iterator.Next(context.Background()) {
+		elements = append(elements, iterator.Value())
+	}
+	return elements
+}
+
+func TestListIterator(t *testing.T) {
+	client := &fakeListClient{}
+	for i := range 7 {
+		client.elements = append(client.elements, strconv.Itoa(i))
+	}
+
+	iterator := NewListIterator(client, "events").WithPageSize(3)
+	assert.Equal(t, client.elements, iterateList(iterator))
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, [][2]int64{{0, 2}, {3, 5}, {6, 8}}, client.ranges)
+	assert.False(t, iterator.Next(context.Background()))
+
+	client.ranges = nil
+	iterator = NewListIterator(client, "events").WithPageSize(3).WithReverse(true)
+	assert.Equal(t, []string{"6", "5", "4", "3", "2", "1", "0"}, iterateList(iterator))
+	assert.NoError(t, iterator.Err())
+	assert.Equal(t, [][2]int64{{-3, -1}, {-6, -4}, {-9, -7}}, client.ranges)
+
+	// A list whose length is a multiple of the page size ends with an empty page.
+	client.ranges = nil
+	client.elements = client.elements[:6]
+	assert.Len(t, iterateList(NewListIterator(client, "events").WithPageSize(3)), 6)
+	assert.Equal(t, [][2]int64{{0, 2}, {3, 5}, {6, 8}}, client.ranges)
+
+	client.ranges = nil
+	assert.Empty(t, iterateList(NewListIterator(&fakeListClient{}, "missing")))
+}
+
+func TestListIterator_Errors(t *testing.T) {
+	failure := errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	iterator := NewListIterator(&fakeListClient{err: failure}, "events")
+	assert.False(t, iterator.Next(context.Background()))
+	assert.ErrorIs(t, iterator.Err(), failure)
+	assert.Equal(t, "", iterator.Value())
+
+	iterator = NewListIterator(&fakeListClient{}, "events").WithPageSize(0)
+	assert.False(t, iterator.Next(context.Background()))
+	assert.EqualError(t, iterator.Err(), "the page size of a list iterator must be positive, got 0")
+}
@@ -0,0 +1,188 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+)
+
+const (
+	// DefaultMaintenancePause is how long a [MaintenanceWindow] pauses writes, unless configured otherwise.
+	DefaultMaintenancePause = 30 * time.Second
+	// DefaultMaintenanceReplicationTimeout is how long a [MaintenanceWindow] waits for the replicas to catch up with
+	// their primary, unless configured otherwise.
+	DefaultMaintenanceReplicationTimeout = 5 * time.Second
+)
+
+// replicationPollInterval is the interval between two checks of the replication offsets of the nodes.
+const replicationPollInterval = 10 * time.Millisecond
+
+// MaintenanceWindow runs an operation while the writes to some nodes of a cluster are paused, e.g. a manual failover
+// or a backup which must observe the same data on the primaries and their replicas:
+//
+//  1. The writes of the clients of the nodes are paused with CLIENT PAUSE WRITE, for at most the configured pause.
+//  2. The window waits for the replicas of the nodes to catch up with their primary, i.e. to report the replication
+//     offset of their primary in INFO replication. Unlike WAIT, which only waits for the writes of the connection
+//     it's sent on, this covers the writes of all the clients.
+//  3. The operation runs, with a context done once the pause ends.
+//  4. The writes are resumed with CLIENT UNPAUSE, even if a step failed or the context of the window is done.
+//
+// Example usage:
+//
+//	window := glide.NewMaintenanceWindow(client, config.NewByAddressRoute("10.0.0.1", 6379)).
+//		WithPause(10 * time.Second)
+//	err := window.Run(ctx, func(ctx context.Context) error {
+//		_, err := client.ClusterFailover(ctx, config.NewByAddressRoute("10.0.0.2", 6379))
+//		return err
+//	})
+type MaintenanceWindow struct {
+	client             *ClusterClient
+	route              config.Route
+	pause              time.Duration
+	replicationTimeout time.Duration
+}
+
+// NewMaintenanceWindow returns a [MaintenanceWindow] pausing the writes to the nodes of route, e.g.
+// [config.AllPrimaries] or a [config.ByAddressRoute].
+func NewMaintenanceWindow(client *ClusterClient, route config.Route) *MaintenanceWindow {
+	return &MaintenanceWindow{
+		client:             client,
+		route:              route,
+		pause:              DefaultMaintenancePause,
+		replicationTimeout: DefaultMaintenanceReplicationTimeout,
+	}
+}
+
+// WithPause sets for how long the writes are paused at most. The servers resume the writes after this duration,
+// even if the window couldn't unpause them, e.g. because the client lost its connections. Defaults to
+// DefaultMaintenancePause.
+func (window *MaintenanceWindow) WithPause(pause time.Duration) *MaintenanceWindow {
+	window.pause = pause
+	return window
+}
+
+// WithReplicationTimeout sets how long the window waits for the replicas to catch up before giving up. Defaults to
+// DefaultMaintenanceReplicationTimeout.
+func (window *MaintenanceWindow) WithReplicationTimeout(timeout time.Duration) *MaintenanceWindow {
+	window.replicationTimeout = timeout
+	return window
+}
+
+// Run pauses the writes to the nodes, waits for their replicas to catch up, runs operation and resumes the writes.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the window.
+//	operation - The operation to run while the writes are paused. Its context is done once the pause ends.
+//
+// Return value:
+//
+//	The error of operation, or an error if the writes couldn't be paused, or the replicas didn't catch up in time,
+//	in which case operation isn't run. The error wraps the error of the unpause as well, if it failed too.
+func (window *MaintenanceWindow) Run(ctx context.Context, operation func(ctx context.Context) error) error {
+	pauseEnd := time.Now().Add(window.pause)
+	_, err := window.client.executeOkWithRoute(
+		ctx,
+		C.ClientPause,
+		[]string{utils.IntToString(window.pause.Milliseconds()), "WRITE"},
+		window.route,
+	)
+	if err != nil {
+		// Some nodes may have been paused.
+		return window.unpause(fmt.Errorf("failed to pause writes: %w", err))
+	}
+
+	if err := window.waitForReplicas(ctx); err != nil {
+		return window.unpause(err)
+	}
+
+	operationCtx, cancel := context.WithDeadline(ctx, pauseEnd)
+	defer cancel()
+	return window.unpause(operation(operationCtx))
+}
+
+// unpause resumes the writes to the nodes, and returns err joined with the error of the unpause, if any.
+func (window *MaintenanceWindow) unpause(err error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultMaintenanceReplicationTimeout)
+	defer cancel()
+	if _, unpauseErr := window.client.executeOkWithRoute(ctx, C.ClientUnpause, []string{}, window.route); unpauseErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to unpause writes: %w", unpauseErr))
+	}
+	return err
+}
+
+// waitForReplicas polls the replication offsets of the nodes until their replicas caught up.
+func (window *MaintenanceWindow) waitForReplicas(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, window.replicationTimeout)
+	defer cancel()
+	ticker := time.NewTicker(replicationPollInterval)
+	defer ticker.Stop()
+	for {
+		caughtUp, err := window.replicasCaughtUp(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check the replication offsets: %w", err)
+		}
+		if caughtUp {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the replicas didn't catch up with their primary in %v: %w", window.replicationTimeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (window *MaintenanceWindow) replicasCaughtUp(ctx context.Context) (bool, error) {
+	response, err := window.client.executeCommandWithRoute(ctx, C.Info, []string{"replication"}, window.route)
+	if err != nil {
+		return false, err
+	}
+	var infos []string
+	if window.route != nil && window.route.IsMultiNode() {
+		replies, err := handleStringToStringMapResponse(response)
+		if err != nil {
+			return false, err
+		}
+		for _, info := range replies {
+			infos = append(infos, info)
+		}
+	} else {
+		info, err := handleStringResponse(response)
+		if err != nil {
+			return false, err
+		}
+		infos = append(infos, info)
+	}
+	for _, info := range infos {
+		caughtUp, err := replicationCaughtUp(info)
+		if err != nil || !caughtUp {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// replicationCaughtUp reports whether the online replicas of a primary reached its replication offset, from the
+// replication section of its INFO. Replicas have no replicas to wait for.
+func replicationCaughtUp(info string) (bool, error) {
+	lags, err := parseReplicaLags("", info)
+	if err != nil {
+		return false, err
+	}
+	for _, lag := range lags {
+		if lag.State == "online" && lag.OffsetLag > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,36 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationCaughtUp(t *testing.T) {
+	primary := "# Replication\r\nrole:master\r\nconnected_slaves:2\r\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=2124,lag=0\r\n" +
+		"slave1:ip=10.0.0.3,port=6379,state=%s,offset=%d,lag=1\r\n" +
+		"master_failover_state:no-failover\r\nmaster_repl_offset:2124\r\nslave_read_repl_offset:2124\r\n"
+	tests := []struct {
+		name     string
+		info     string
+		expected bool
+	}{
+		{"caught up", fmt.Sprintf(primary, "online", 2124), true},
+		{"lagging", fmt.Sprintf(primary, "online", 2000), false},
+		{"lagging replica not online", fmt.Sprintf(primary, "wait_bgsave", 0), true},
+		{"no replicas", "# Replication\r\nrole:master\r\nconnected_slaves:0\r\nmaster_repl_offset:42\r\n", true},
+		{"replica", "# Replication\r\nrole:slave\r\nmaster_host:10.0.0.1\r\nslave_repl_offset:2124\r\n", true},
+	}
+	for _, test := range tests {
+		caughtUp, err := replicationCaughtUp(test.info)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, caughtUp, test.name)
+	}
+
+	_, err := replicationCaughtUp("# Replication\r\nrole:master\r\n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,231 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// openMetricsContentType is the content type of the OpenMetrics text format.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// metricsSource is implemented by [Client] and [ClusterClient].
+type metricsSource interface {
+	metricsSnapshot() clientMetricsSnapshot
+}
+
+// clientMetricsSnapshot holds the metrics of a client exposed by [MetricsHandler].
+type clientMetricsSnapshot struct {
+	requests   []models.RequestMetrics
+	statistics map[string]uint64
+	pubSub     models.PubSubMetrics
+	// scheduler is set for the clients configured with a request scheduler.
+	scheduler *schedulerUsage
+	// topology is set for the cluster clients monitoring the primaries of their cluster.
+	topology *topologyUsage
+}
+
+type schedulerUsage struct {
+	inUse, waiting, capacity int
+}
+
+type topologyUsage struct {
+	primaries, unassignedSlots int
+}
+
+func (client *baseClient) metricsSnapshot() clientMetricsSnapshot {
+	snapshot := clientMetricsSnapshot{
+		requests:   client.GetRequestMetrics(),
+		statistics: client.GetStatistics(),
+		pubSub:     client.GetPubSubMetrics(),
+	}
+	if client.scheduler != nil {
+		inUse, waiting, capacity := client.scheduler.usage()
+		snapshot.scheduler = &schedulerUsage{inUse: inUse, waiting: waiting, capacity: capacity}
+	}
+	if client.primaryMonitor != nil {
+		if primaries, unassignedSlots, ok := client.primaryMonitor.topology(); ok {
+			snapshot.topology = &topologyUsage{primaries: primaries, unassignedSlots: unassignedSlots}
+		}
+	}
+	return snapshot
+}
+
+// MetricsHandler returns an [http.Handler] exposing the metrics of client in the OpenMetrics text format, to be
+// scraped by Prometheus or any other OpenMetrics compatible collector, without the OpenTelemetry integration:
+//   - glide_requests_total, glide_request_errors_total and glide_request_duration_seconds report the requests of
+//     the client, labelled as configured by its [config.MetricsConfiguration]. They're only exposed by clients
+//     configured with one, see WithMetrics in the client configuration.
+//   - glide_connections and glide_clients report the connections and clients of the process.
+//   - glide_scheduler_* report the outstanding and waiting requests of clients configured with a request scheduler.
+//   - glide_cluster_primaries and glide_cluster_unassigned_slots report the topology of cluster clients monitoring
+//     their primaries, as of the last check.
+//   - glide_pubsub_* report the messages received by the subscriptions of the client, labelled by channel or by
+//     pattern. The messages of the subscriptions beyond the first [models.MaxPubSubMetricSubscriptions] to receive
+//     one are reported by a single series, labelled other="true".
+//
+// Example usage:
+//
+//	http.Handle("/metrics", glide.MetricsHandler(client))
+//
+// Parameters:
+//
+//	client - The [Client] or [ClusterClient] to expose the metrics of.
+func MetricsHandler(client metricsSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", openMetricsContentType)
+		if err := writeOpenMetrics(w, client.metricsSnapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// writeOpenMetrics writes snapshot to w in the OpenMetrics text format.
+func writeOpenMetrics(w io.Writer, snapshot clientMetricsSnapshot) error {
+	out := bufio.NewWriter(w)
+
+	if len(snapshot.requests) > 0 {
+		writeMetricFamily(out, "glide_requests", "counter", "Requests sent by the client.")
+		for _, series := range snapshot.requests {
+			writeSample(out, "glide_requests_total", requestLabels(series.Labels), strconv.FormatUint(series.Requests, 10))
+		}
+		writeMetricFamily(out, "glide_request_errors", "counter", "Failed requests, by kind of error.")
+		for _, series := range snapshot.requests {
+			kinds := make([]string, 0, len(series.Errors))
+			for kind := range series.Errors {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			for _, kind := range kinds {
+				labels := append(requestLabels(series.Labels), [2]string{"error", kind})
+				writeSample(out, "glide_request_errors_total", labels, strconv.FormatUint(series.Errors[kind], 10))
+			}
+		}
+		writeMetricFamily(out, "glide_request_duration_seconds", "histogram", "Latency of the requests.")
+		for _, series := range snapshot.requests {
+			// Clipped, so that appending the bound of each bucket doesn't overwrite the labels.
+			labels := slices.Clip(requestLabels(series.Labels))
+			for _, bucket := range series.LatencyBuckets {
+				bound := strconv.FormatFloat(bucket.UpperBound.Seconds(), 'g', -1, 64)
+				writeSample(out, "glide_request_duration_seconds_bucket", append(labels, [2]string{"le", bound}),
+					strconv.FormatUint(bucket.Count, 10))
+			}
+			requests := strconv.FormatUint(series.Requests, 10)
+			writeSample(out, "glide_request_duration_seconds_bucket", append(labels, [2]string{"le", "+Inf"}), requests)
+			writeSample(out, "glide_request_duration_seconds_sum", labels,
+				strconv.FormatFloat(series.LatencySum.Seconds(), 'g', -1, 64))
+			writeSample(out, "glide_request_duration_seconds_count", labels, requests)
+		}
+	}
+
+	writeGauge(out, "glide_connections", "Connections opened by the clients of the process.",
+		snapshot.statistics["total_connections"])
+	writeGauge(out, "glide_clients", "Clients of the process.", snapshot.statistics["total_clients"])
+
+	if scheduler := snapshot.scheduler; scheduler != nil {
+		writeGauge(out, "glide_scheduler_inflight_requests", "Requests admitted by the scheduler and not completed yet.",
+			uint64(scheduler.inUse))
+		writeGauge(out, "glide_scheduler_waiting_requests", "Requests waiting to be admitted by the scheduler.",
+			uint64(scheduler.waiting))
+		writeGauge(out, "glide_scheduler_capacity", "Maximum number of outstanding requests.", uint64(scheduler.capacity))
+	}
+
+	if topology := snapshot.topology; topology != nil {
+		writeGauge(out, "glide_cluster_primaries", "Primaries serving slots of the cluster.", uint64(topology.primaries))
+		writeGauge(out, "glide_cluster_unassigned_slots", "Slots of the cluster served by no primary.",
+			uint64(topology.unassignedSlots))
+	}
+
+	writeMetricFamily(out, "glide_pubsub_buffered_messages", "gauge", "Received messages not processed yet.")
+	for _, series := range snapshot.pubSub.Subscriptions {
+		writeSample(out, "glide_pubsub_buffered_messages", pubSubLabels(series), strconv.Itoa(series.BufferedMessages))
+	}
+	writeMetricFamily(out, "glide_pubsub_oldest_buffered_message_age_seconds", "gauge",
+		"Age of the oldest buffered message.")
+	for _, series := range snapshot.pubSub.Subscriptions {
+		writeSample(out, "glide_pubsub_oldest_buffered_message_age_seconds", pubSubLabels(series),
+			strconv.FormatFloat(series.OldestBufferedMessageAge.Seconds(), 'g', -1, 64))
+	}
+	writeMetricFamily(out, "glide_pubsub_processed_messages", "counter", "Received messages processed.")
+	for _, series := range snapshot.pubSub.Subscriptions {
+		writeSample(out, "glide_pubsub_processed_messages_total", pubSubLabels(series),
+			strconv.FormatUint(series.ProcessedMessages, 10))
+	}
+	writeMetricFamily(out, "glide_pubsub_dropped_messages", "counter",
+		"Received messages dropped as the buffer limit was reached.")
+	for _, series := range snapshot.pubSub.Subscriptions {
+		writeSample(out, "glide_pubsub_dropped_messages_total", pubSubLabels(series),
+			strconv.FormatUint(series.DroppedMessages, 10))
+	}
+
+	out.WriteString("# EOF\n")
+	return out.Flush()
+}
+
+// requestLabels returns the non-empty labels of a series of request metrics.
+func requestLabels(labels models.RequestMetricLabels) [][2]string {
+	var pairs [][2]string
+	for _, pair := range [][2]string{
+		{"command", labels.Command},
+		{"key_prefix", labels.KeyPrefix},
+	} {
+		if pair[1] != "" {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
+
+// pubSubLabels returns the labels of the Pub/Sub metrics of a subscription: its channel or its pattern, or other for
+// the series aggregating the subscriptions beyond models.MaxPubSubMetricSubscriptions.
+func pubSubLabels(series models.PubSubSubscriptionMetrics) [][2]string {
+	switch {
+	case series.Others:
+		return [][2]string{{"other", "true"}}
+	case series.Pattern:
+		return [][2]string{{"pattern", series.Subscription}}
+	default:
+		return [][2]string{{"channel", series.Subscription}}
+	}
+}
+
+func writeMetricFamily(out *bufio.Writer, name string, metricType string, help string) {
+	fmt.Fprintf(out, "# TYPE %s %s\n# HELP %s %s\n", name, metricType, name, help)
+}
+
+func writeGauge(out *bufio.Writer, name string, help string, value uint64) {
+	writeMetricFamily(out, name, "gauge", help)
+	writeSample(out, name, nil, strconv.FormatUint(value, 10))
+}
+
+func writeSample(out *bufio.Writer, name string, labels [][2]string, value string) {
+	out.WriteString(name)
+	if len(labels) > 0 {
+		out.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			fmt.Fprintf(out, "%s=\"%s\"", label[0], escapeLabelValue(label[1]))
+		}
+		out.WriteByte('}')
+	}
+	out.WriteByte(' ')
+	out.WriteString(value)
+	out.WriteByte('\n')
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
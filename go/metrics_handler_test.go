@@ -0,0 +1,91 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+type fakeMetricsSource clientMetricsSnapshot
+
+func (source fakeMetricsSource) metricsSnapshot() clientMetricsSnapshot {
+	return clientMetricsSnapshot(source)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	source := fakeMetricsSource{
+		requests: []models.RequestMetrics{{
+			Labels:     models.RequestMetricLabels{Command: "GET", KeyPrefix: `us"er`},
+			Requests:   3,
+			Errors:     map[string]uint64{"WRONGTYPE": 1},
+			LatencySum: 1500 * time.Microsecond,
+			LatencyBuckets: []models.LatencyBucket{
+				{UpperBound: 500 * time.Microsecond, Count: 2},
+				{UpperBound: time.Millisecond, Count: 3},
+			},
+		}},
+		statistics: map[string]uint64{"total_connections": 4, "total_clients": 2},
+		pubSub: models.PubSubMetrics{
+			BufferedMessages:  1,
+			ProcessedMessages: 7,
+			Subscriptions: []models.PubSubSubscriptionMetrics{
+				{Subscription: "news.*", Pattern: true, BufferedMessages: 1, ProcessedMessages: 3},
+				{Subscription: "orders", ProcessedMessages: 4},
+				{Others: true, DroppedMessages: 2},
+			},
+		},
+		scheduler: &schedulerUsage{inUse: 5, waiting: 1, capacity: 10},
+		topology:  &topologyUsage{primaries: 3},
+	}
+	recorder := httptest.NewRecorder()
+	MetricsHandler(source).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, openMetricsContentType, recorder.Header().Get("Content-Type"))
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE glide_requests counter",
+		`glide_requests_total{command="GET",key_prefix="us\"er"} 3`,
+		`glide_request_errors_total{command="GET",key_prefix="us\"er",error="WRONGTYPE"} 1`,
+		"# TYPE glide_request_duration_seconds histogram",
+		`glide_request_duration_seconds_bucket{command="GET",key_prefix="us\"er",le="0.0005"} 2`,
+		`glide_request_duration_seconds_bucket{command="GET",key_prefix="us\"er",le="0.001"} 3`,
+		`glide_request_duration_seconds_bucket{command="GET",key_prefix="us\"er",le="+Inf"} 3`,
+		`glide_request_duration_seconds_sum{command="GET",key_prefix="us\"er"} 0.0015`,
+		`glide_request_duration_seconds_count{command="GET",key_prefix="us\"er"} 3`,
+		"glide_connections 4",
+		"glide_clients 2",
+		"glide_scheduler_inflight_requests 5",
+		"glide_scheduler_waiting_requests 1",
+		"glide_scheduler_capacity 10",
+		"glide_cluster_primaries 3",
+		"glide_cluster_unassigned_slots 0",
+		`glide_pubsub_buffered_messages{pattern="news.*"} 1`,
+		`glide_pubsub_buffered_messages{channel="orders"} 0`,
+		`glide_pubsub_processed_messages_total{pattern="news.*"} 3`,
+		`glide_pubsub_processed_messages_total{channel="orders"} 4`,
+		`glide_pubsub_dropped_messages_total{other="true"} 2`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.True(t, strings.HasSuffix(body, "# EOF\n"))
+}
+
+func TestMetricsHandler_WithoutOptionalMetrics(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	MetricsHandler(fakeMetricsSource{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.NotContains(t, body, "glide_requests")
+	assert.NotContains(t, body, "glide_scheduler")
+	assert.NotContains(t, body, "glide_cluster")
+	assert.Contains(t, body, "glide_connections 0\n")
+}
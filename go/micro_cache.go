@@ -0,0 +1,217 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+)
+
+// microCacheReads are the commands which don't change their keys. The cached reads of the keys of the other commands
+// are invalidated once the client issuing them gets their response, so that the reads of a process following its
+// writes see them.
+var microCacheReads = map[string]bool{
+	"GET": true, "GETRANGE": true, "STRLEN": true, "SUBSTR": true, "MGET": true, "LCS": true, "BITCOUNT": true,
+	"BITFIELDREADONLY": true, "BITPOS": true, "GETBIT": true,
+	"EXISTS": true, "TOUCH": true, "WATCH": true, "DUMP": true, "TYPE": true, "TTL": true, "PTTL": true,
+	"EXPIRETIME": true, "PEXPIRETIME": true, "OBJECT": true, "OBJECTENCODING": true, "OBJECTFREQ": true,
+	"OBJECTIDLETIME": true, "OBJECTREFCOUNT": true, "MEMORY": true, "MEMORYUSAGE": true, "CLUSTERKEYSLOT": true,
+	"SORTREADONLY": true, "SORT_RO": true,
+	"HGET": true, "HGETALL": true, "HEXISTS": true, "HKEYS": true, "HLEN": true, "HMGET": true, "HRANDFIELD": true,
+	"HSCAN": true, "HSTRLEN": true, "HVALS": true, "HTTL": true, "HPTTL": true, "HEXPIRETIME": true,
+	"HPEXPIRETIME": true,
+	"LINDEX":       true, "LLEN": true, "LPOS": true, "LRANGE": true,
+	"SCARD": true, "SISMEMBER": true, "SMISMEMBER": true, "SMEMBERS": true, "SRANDMEMBER": true, "SSCAN": true,
+	"SDIFF": true, "SINTER": true, "SINTERCARD": true, "SUNION": true,
+	"ZCARD": true, "ZCOUNT": true, "ZLEXCOUNT": true, "ZMSCORE": true, "ZRANDMEMBER": true, "ZRANGE": true,
+	"ZRANGEBYLEX": true, "ZRANGEBYSCORE": true, "ZRANK": true, "ZREVRANGE": true, "ZREVRANGEBYLEX": true,
+	"ZREVRANGEBYSCORE": true, "ZREVRANK": true, "ZSCAN": true, "ZSCORE": true, "ZDIFF": true, "ZINTER": true,
+	"ZINTERCARD": true, "ZUNION": true,
+	"GEODIST": true, "GEOHASH": true, "GEOPOS": true, "GEOSEARCH": true, "GEORADIUSREADONLY": true,
+	"GEORADIUSBYMEMBERREADONLY": true, "GEORADIUS_RO": true, "GEORADIUSBYMEMBER_RO": true,
+	"PFCOUNT": true,
+	"XLEN":    true, "XRANGE": true, "XREVRANGE": true, "XREAD": true, "XPENDING": true, "XINFO": true,
+	"XINFOCONSUMERS": true, "XINFOGROUPS": true, "XINFOSTREAM": true,
+	"JSONGET": true, "JSONMGET": true, "JSONTYPE": true, "JSONSTRLEN": true, "JSONARRLEN": true, "JSONARRINDEX": true,
+	"JSONOBJLEN": true, "JSONOBJKEYS": true, "JSONRESP": true, "JSONDEBUG": true,
+	"EVALREADONLY": true, "EVAL_RO": true, "EVALSHAREADONLY": true, "EVALSHA_RO": true, "FCALLREADONLY": true,
+	"FCALL_RO": true,
+}
+
+// microCacheFlushes are the commands which may change any key, whose issuing clears the micro-cache, as do the other
+// commands whose keys aren't known, e.g. SORT with STORE.
+var microCacheFlushes = map[string]bool{"FLUSHALL": true, "FLUSHDB": true, "SWAPDB": true}
+
+// microCache serves repeated reads of hot keys locally for a tiny TTL, as configured by a
+// [config.MicroCacheConfiguration].
+type microCache struct {
+	patterns   []string
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu sync.Mutex
+	// entries are the cached reads by key, and by command and arguments, and size is their number.
+	entries map[string]map[string]*microCacheEntry
+	size    int
+}
+
+// microCacheEntry is a value of the micro-cache, or a read in progress until done is closed.
+type microCacheEntry struct {
+	done    chan struct{}
+	value   any
+	err     error
+	expires time.Time
+}
+
+func newMicroCache(cacheConfig *config.MicroCacheConfiguration) *microCache {
+	return &microCache{
+		patterns:   cacheConfig.GetPatterns(),
+		ttl:        cacheConfig.GetTTL(),
+		maxEntries: cacheConfig.GetMaxEntries(),
+		now:        time.Now,
+		entries:    make(map[string]map[string]*microCacheEntry),
+	}
+}
+
+// matches reports whether key is cached.
+func (cache *microCache) matches(key string) bool {
+	for _, pattern := range cache.patterns {
+		if utils.GlobMatch(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// microCached returns the result of the read of args, a command and its key followed by its other arguments, from
+// the micro-cache of client if the key is cached, or else the result of load. The concurrent reads of a missing
+// entry wait for the read of the first one, and read the key themselves if it fails.
+func microCached[T any](ctx context.Context, client *baseClient, args []string, load func() (T, error)) (T, error) {
+	cache := client.microCache
+	key := client.view.prefix() + args[1]
+	// The reads routed to another client by the view aren't cached: the writes, routed as well, wouldn't invalidate them.
+	if cache == nil || !cache.matches(key) || client.view.routeKey(args[1]) != nil {
+		return load()
+	}
+	read := strings.Join(args, "\x00")
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[key][read]
+	if ok {
+		select {
+		case <-entry.done:
+			if cache.now().Before(entry.expires) {
+				cache.mu.Unlock()
+				return entry.value.(T), nil
+			}
+			ok = false
+		default:
+		}
+	}
+	if ok {
+		cache.mu.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		if entry.err != nil {
+			return load()
+		}
+		return entry.value.(T), nil
+	}
+	entry = &microCacheEntry{done: make(chan struct{})}
+	cache.store(key, read, entry)
+	cache.mu.Unlock()
+
+	value, err := load()
+	cache.mu.Lock()
+	entry.value, entry.err, entry.expires = value, err, cache.now().Add(cache.ttl)
+	if err != nil && cache.entries[key][read] == entry {
+		cache.remove(key, read)
+	}
+	cache.mu.Unlock()
+	close(entry.done)
+	return value, err
+}
+
+// store adds entry to the cache, unless it's full of unexpired entries. It must be called with cache.mu held.
+func (cache *microCache) store(key string, read string, entry *microCacheEntry) {
+	if _, replaced := cache.entries[key][read]; !replaced && cache.size >= cache.maxEntries {
+		now := cache.now()
+		for cachedKey, reads := range cache.entries {
+			for cachedRead, cached := range reads {
+				select {
+				case <-cached.done:
+					if !now.Before(cached.expires) {
+						cache.remove(cachedKey, cachedRead)
+					}
+				default:
+				}
+			}
+		}
+		if cache.size >= cache.maxEntries {
+			return
+		}
+	}
+	reads, ok := cache.entries[key]
+	if !ok {
+		reads = make(map[string]*microCacheEntry)
+		cache.entries[key] = reads
+	}
+	if _, replaced := reads[read]; !replaced {
+		cache.size++
+	}
+	reads[read] = entry
+}
+
+// remove removes the entry of read of key. It must be called with cache.mu held.
+func (cache *microCache) remove(key string, read string) {
+	reads := cache.entries[key]
+	if _, ok := reads[read]; !ok {
+		return
+	}
+	delete(reads, read)
+	cache.size--
+	if len(reads) == 0 {
+		delete(cache.entries, key)
+	}
+}
+
+// invalidate removes the entries of the keys of the command of requestType called with args, unless it's a read, the
+// keys being prefixed already for the view issuing it, or all the entries if the command may write any key or its
+// keys aren't known. The reads in progress of the keys aren't cached once they complete, but are still returned to
+// the reads waiting for them.
+func (cache *microCache) invalidate(requestType uint32, args []string) {
+	command, commandArgs := commandNameArgs(requestType, args)
+	if microCacheReads[command] {
+		return
+	}
+	keys, ok := commandKeys(command, commandArgs)
+	if microCacheFlushes[command] || !ok {
+		cache.mu.Lock()
+		cache.entries, cache.size = make(map[string]map[string]*microCacheEntry), 0
+		cache.mu.Unlock()
+		return
+	}
+	cache.invalidateKeys(keys)
+}
+
+// invalidateKeys removes the entries of keys, e.g. the keys of a script, which may write them.
+func (cache *microCache) invalidateKeys(keys []string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, key := range keys {
+		if reads, ok := cache.entries[key]; ok {
+			cache.size -= len(reads)
+			delete(cache.entries, key)
+		}
+	}
+}
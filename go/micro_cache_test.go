@@ -0,0 +1,204 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func newTestMicroCacheClient(cacheConfig *config.MicroCacheConfiguration) (*baseClient, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	cache := newMicroCache(cacheConfig)
+	cache.now = func() time.Time { return now }
+	return &baseClient{microCache: cache}, &now
+}
+
+func TestMicroCache_ServesWithinTTL(t *testing.T) {
+	client, now := newTestMicroCacheClient(config.NewMicroCacheConfiguration("stock:*"))
+	var loads int
+	load := func() (string, error) {
+		loads++
+		return "42", nil
+	}
+
+	for range 3 {
+		value, err := microCached(context.Background(), client, []string{"GET", "stock:1"}, load)
+		assert.NoError(t, err)
+		assert.Equal(t, "42", value)
+	}
+	assert.Equal(t, 1, loads)
+
+	// Other commands, keys and fields are cached apart, and keys not matching the patterns aren't cached.
+	_, _ = microCached(context.Background(), client, []string{"HGET", "stock:1", "count"}, load)
+	_, _ = microCached(context.Background(), client, []string{"GET", "stock:2"}, load)
+	_, _ = microCached(context.Background(), client, []string{"GET", "user:1"}, load)
+	_, _ = microCached(context.Background(), client, []string{"GET", "user:1"}, load)
+	assert.Equal(t, 5, loads)
+
+	*now = now.Add(config.DefaultMicroCacheTTL)
+	_, _ = microCached(context.Background(), client, []string{"GET", "stock:1"}, load)
+	assert.Equal(t, 6, loads)
+}
+
+func TestMicroCache_CoalescesConcurrentReads(t *testing.T) {
+	client, _ := newTestMicroCacheClient(config.NewMicroCacheConfiguration("*"))
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (string, error) {
+		loads.Add(1)
+		<-release
+		return "hot", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = microCached(context.Background(), client, []string{"GET", "sale"}, load)
+		}()
+	}
+	assert.Eventually(t, func() bool { return loads.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), loads.Load())
+	for _, result := range results {
+		assert.Equal(t, "hot", result)
+	}
+}
+
+func TestMicroCache_DoesNotCacheFailures(t *testing.T) {
+	client, _ := newTestMicroCacheClient(config.NewMicroCacheConfiguration("*").WithMaxEntries(1))
+	failure := errors.New("failed")
+	_, err := microCached(context.Background(), client, []string{"GET", "a"}, func() (string, error) {
+		return "", failure
+	})
+	assert.ErrorIs(t, err, failure)
+	value, err := microCached(context.Background(), client, []string{"GET", "a"}, func() (string, error) {
+		return "value", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	// Once full, further keys aren't cached.
+	var loads int
+	for range 2 {
+		_, _ = microCached(context.Background(), client, []string{"GET", "b"}, func() (string, error) {
+			loads++
+			return "b", nil
+		})
+	}
+	assert.Equal(t, 2, loads)
+}
+
+func TestMicroCache_InvalidatesLocalWrites(t *testing.T) {
+	client, _ := newTestMicroCacheClient(config.NewMicroCacheConfiguration("stock:*"))
+	cache := client.microCache
+	var loads int
+	read := func(args ...string) {
+		_, _ = microCached(context.Background(), client, args, func() (string, error) {
+			loads++
+			return "42", nil
+		})
+	}
+	read("GET", "stock:1")
+	read("HGET", "stock:1", "count")
+	read("GET", "stock:2")
+	assert.Equal(t, 3, loads)
+
+	// Reads don't invalidate the cache.
+	cache.invalidate(uint32(protobuf.RequestType_Strlen), []string{"stock:1"})
+	read("GET", "stock:1")
+	assert.Equal(t, 3, loads)
+
+	for _, write := range []struct {
+		requestType protobuf.RequestType
+		args        []string
+	}{
+		{protobuf.RequestType_Set, []string{"stock:1", "41"}},
+		{protobuf.RequestType_GetDel, []string{"stock:1"}},
+		{protobuf.RequestType_Expire, []string{"stock:1", "10"}},
+		{protobuf.RequestType_Del, []string{"other", "stock:1"}},
+		{protobuf.RequestType_BitOp, []string{"AND", "stock:1", "a", "b"}},
+		{protobuf.RequestType_CustomCommand, []string{"getex", "stock:1", "PERSIST"}},
+		{protobuf.RequestType_SUnionStore, []string{"stock:1", "a", "b"}},
+		{protobuf.RequestType_ZUnionStore, []string{"stock:1", "2", "a", "b"}},
+		{protobuf.RequestType_GeoSearchStore, []string{"stock:1", "places", "FROMMEMBER", "a", "BYRADIUS", "1", "km"}},
+		{protobuf.RequestType_LMove, []string{"a", "stock:1", "LEFT", "RIGHT"}},
+		{protobuf.RequestType_SMove, []string{"a", "stock:1", "member"}},
+		{protobuf.RequestType_PfAdd, []string{"stock:1", "element"}},
+		{protobuf.RequestType_PfMerge, []string{"stock:1", "a"}},
+	} {
+		loads = 0
+		read("GET", "stock:1")
+		cache.invalidate(uint32(write.requestType), write.args)
+		read("GET", "stock:1")
+		read("HGET", "stock:1", "count")
+		assert.Equal(t, 2, loads, write.args)
+	}
+
+	// The other keys are kept, unless the database is flushed, or the keys of a write aren't known.
+	loads = 0
+	read("GET", "stock:2")
+	assert.Equal(t, 0, loads)
+	cache.invalidate(uint32(protobuf.RequestType_SInterStore), []string{"other", "a"})
+	read("GET", "stock:2")
+	assert.Equal(t, 0, loads)
+	cache.invalidate(uint32(protobuf.RequestType_FlushAll), nil)
+	read("GET", "stock:2")
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, 1, cache.size)
+	cache.invalidate(uint32(protobuf.RequestType_Sort), []string{"a", "STORE", "other"})
+	assert.Equal(t, 0, cache.size)
+}
+
+func TestMicroCache_InvalidatesReadsInProgress(t *testing.T) {
+	client, _ := newTestMicroCacheClient(config.NewMicroCacheConfiguration("*"))
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		value, _ := microCached(context.Background(), client, []string{"GET", "a"}, func() (string, error) {
+			close(loading)
+			<-release
+			return "old", nil
+		})
+		assert.Equal(t, "old", value)
+	}()
+
+	// The value read before the write completed isn't cached.
+	<-loading
+	client.microCache.invalidateKeys([]string{"a"})
+	close(release)
+	wg.Wait()
+	value, _ := microCached(context.Background(), client, []string{"GET", "a"}, func() (string, error) {
+		return "new", nil
+	})
+	assert.Equal(t, "new", value)
+}
+
+func TestMicroCache_InvalidatesCommandsOfClient(t *testing.T) {
+	cached, _ := newTestMicroCacheClient(config.NewMicroCacheConfiguration("*"))
+	client := &Client{baseClient{core: &coreClient{}, mu: &sync.Mutex{}, microCache: cached.microCache}}
+	_, _ = microCached(context.Background(), &client.baseClient, []string{"GET", "a"}, func() (string, error) {
+		return "old", nil
+	})
+
+	// The cached reads are invalidated even if the write fails, here as the client is closed.
+	_, err := client.Set(context.Background(), "a", "new")
+	assert.IsType(t, &ClosingError{}, err)
+	assert.Equal(t, 0, cached.microCache.size)
+}
@@ -0,0 +1,14 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+// AttributedValue is a value returned by the server, with the RESP3 attributes the server attached to it.
+//
+// Attributes are auxiliary data sent before a reply, e.g. the popularity or the TTL of the keys of the reply as
+// reported by some proxies. They're only sent on RESP3 connections.
+type AttributedValue struct {
+	// Value is the value returned by the server, as returned without its attributes.
+	Value any
+	// Attributes are the attributes of the value, or nil if the server attached none.
+	Attributes map[string]any
+}
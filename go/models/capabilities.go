@@ -0,0 +1,71 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ServerModule is a module loaded by the server, as listed by MODULE LIST.
+type ServerModule struct {
+	// Name is the name of the module, e.g. "json" or "search".
+	Name string
+	// Version is the version of the module, e.g. 10002 for 1.0.2.
+	Version int64
+	// Path is the path of the shared library of the module, when reported by the server.
+	Path string
+	// Args are the arguments the module was loaded with.
+	Args []string
+}
+
+// Capabilities reports the version of the server of a client and the modules it loaded, as detected when the client
+// connected.
+type Capabilities struct {
+	// ServerName is the name of the server, e.g. "valkey", or "redis" for the servers which don't report their name.
+	ServerName string
+	// Version is the version of the server, e.g. "8.1.3".
+	Version string
+	// CommandCount is the number of commands supported by the server, as counted by COMMAND COUNT.
+	CommandCount int64
+	// Modules are the modules loaded by the server.
+	Modules []ServerModule
+}
+
+// AtLeast reports whether the version of the server is at least version, e.g. "9.0" or "7.2.4". The missing
+// components of the versions are 0.
+func (capabilities Capabilities) AtLeast(version string) bool {
+	return compareVersions(capabilities.Version, version) >= 0
+}
+
+// HasModule reports whether the server loaded the module name, ignoring case.
+func (capabilities Capabilities) HasModule(name string) bool {
+	for _, module := range capabilities.Modules {
+		if strings.EqualFold(module.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareVersions compares the dotted versions a and b, returning -1, 0 or 1 if a is lower, equal to or greater than
+// b. The components which aren't numbers are 0.
+func compareVersions(a string, b string) int {
+	componentsA, componentsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(componentsA), len(componentsB)); i++ {
+		var componentA, componentB int
+		if i < len(componentsA) {
+			componentA, _ = strconv.Atoi(componentsA[i])
+		}
+		if i < len(componentsB) {
+			componentB, _ = strconv.Atoi(componentsB[i])
+		}
+		switch {
+		case componentA < componentB:
+			return -1
+		case componentA > componentB:
+			return 1
+		}
+	}
+	return 0
+}
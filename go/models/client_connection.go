@@ -0,0 +1,27 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// ClientConnection is a connection to a server, as listed by CLIENT LIST.
+type ClientConnection struct {
+	// Node is the address of the cluster node the connection is connected to. It's empty for standalone servers.
+	Node string
+	Id   int64
+	// Addr is the address of the client of the connection.
+	Addr string
+	// LAddr is the address of the server the connection is connected to.
+	LAddr   string
+	Name    string
+	User    string
+	LibName string
+	// Flags are the flags of the connection, e.g. "N" for normal connections, or "S" for replicas.
+	Flags string
+	// Age is how long the connection has been open.
+	Age time.Duration
+	// Idle is how long the connection has been idle.
+	Idle time.Duration
+	// Properties are all the properties of the connection listed by CLIENT LIST, e.g. "cmd" or "db".
+	Properties map[string]string
+}
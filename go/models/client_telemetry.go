@@ -0,0 +1,56 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// ClientTelemetry is a compact summary of the health of a client, collected by a TelemetryCollector, meant to be
+// shipped to a central service collecting the health of the clients of a fleet. The counters are cumulative since
+// the client was created, so that reports lost on their way don't skew the totals.
+type ClientTelemetry struct {
+	// Labels are the labels of the collector, identifying the client in the fleet, e.g. its service and instance.
+	Labels map[string]string `json:"labels,omitempty"`
+	// CollectedAt is when the telemetry was collected.
+	CollectedAt time.Time `json:"collected_at"`
+	// Connections is the number of connections opened by the clients of the process.
+	Connections uint64 `json:"connections"`
+	// Clients is the number of clients of the process.
+	Clients uint64 `json:"clients"`
+	// Requests is the number of requests of the client, failed or not. The request statistics are only collected
+	// for the clients configured with request metrics, see WithMetrics in the client configuration.
+	Requests uint64 `json:"requests"`
+	// Errors is the number of failed requests of the client, by kind of error, as reported by the request metrics.
+	Errors map[string]uint64 `json:"errors,omitempty"`
+	// MeanLatencyUs is the mean latency of the requests in microseconds, or 0 without requests.
+	MeanLatencyUs int64 `json:"mean_latency_us"`
+	// P50LatencyUs and P99LatencyUs are the upper bounds, in microseconds, of the first latency buckets holding at
+	// least half and 99% of the requests, or 0 without requests or if they're beyond the last bucket.
+	P50LatencyUs int64 `json:"p50_latency_us"`
+	P99LatencyUs int64 `json:"p99_latency_us"`
+	// Pool is set for the clients configured with a request scheduler.
+	Pool *PoolTelemetry `json:"pool,omitempty"`
+	// Topology is set for the cluster clients monitoring the primaries of their cluster, once a check succeeded.
+	Topology *TopologyTelemetry `json:"topology,omitempty"`
+	// PubSubBufferedMessages is the number of received messages not processed yet.
+	PubSubBufferedMessages int `json:"pubsub_buffered_messages"`
+	// PubSubDroppedMessages is the number of received messages dropped as the buffer limit was reached.
+	PubSubDroppedMessages uint64 `json:"pubsub_dropped_messages"`
+}
+
+// PoolTelemetry reports the usage of the request scheduler of a client.
+type PoolTelemetry struct {
+	// InFlight is the number of requests admitted by the scheduler and not completed yet.
+	InFlight int `json:"in_flight"`
+	// Waiting is the number of requests waiting to be admitted by the scheduler.
+	Waiting int `json:"waiting"`
+	// Capacity is the maximum number of outstanding requests.
+	Capacity int `json:"capacity"`
+}
+
+// TopologyTelemetry reports the topology of the cluster of a client, as of the last check of its primaries.
+type TopologyTelemetry struct {
+	// Primaries is the number of primaries serving slots of the cluster.
+	Primaries int `json:"primaries"`
+	// UnassignedSlots is the number of slots of the cluster served by no primary.
+	UnassignedSlots int `json:"unassigned_slots"`
+}
@@ -0,0 +1,17 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// ClockEstimate is the estimated offset of the clock of the server from the local clock, as measured with TIME.
+type ClockEstimate struct {
+	// Offset is how far the clock of the server is ahead of the local clock, negative if it's behind.
+	Offset time.Duration
+	// Uncertainty bounds the error of Offset: half the round trip of the TIME command it was measured with.
+	Uncertainty time.Duration
+	// RoundTrip is the round trip of the TIME command the offset was measured with.
+	RoundTrip time.Duration
+	// Synced is the local time the offset was measured at.
+	Synced time.Time
+}
@@ -0,0 +1,36 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+// CompressionPrefixStatistics reports the compression of the values of the keys matching a compression prefix rule.
+type CompressionPrefixStatistics struct {
+	// ValuesCompressed is the number of values compressed.
+	ValuesCompressed uint64 `json:"values_compressed"`
+	// OriginalBytes is the size of the values compressed before compression.
+	OriginalBytes uint64 `json:"original_bytes"`
+	// BytesCompressed is the size of the values compressed after compression.
+	BytesCompressed uint64 `json:"bytes_compressed"`
+	// SkippedSmallCount is the number of values not compressed because they were smaller than the minimum
+	// compression size of the rule.
+	SkippedSmallCount uint64 `json:"skipped_small_count"`
+	// SkippedCount is the number of values not compressed for another reason, e.g. because the rule disables
+	// compression, or because compression didn't reduce their size.
+	SkippedCount uint64 `json:"skipped_count"`
+}
+
+// CompressionRatio returns the ratio of the original size of the values compressed to their compressed size, e.g. 4
+// if they were compressed to a quarter of their size, or 0 if no value was compressed.
+func (statistics CompressionPrefixStatistics) CompressionRatio() float64 {
+	if statistics.BytesCompressed == 0 {
+		return 0
+	}
+	return float64(statistics.OriginalBytes) / float64(statistics.BytesCompressed)
+}
+
+// SavedBytes returns the number of bytes compression saved.
+func (statistics CompressionPrefixStatistics) SavedBytes() uint64 {
+	if statistics.BytesCompressed > statistics.OriginalBytes {
+		return 0
+	}
+	return statistics.OriginalBytes - statistics.BytesCompressed
+}
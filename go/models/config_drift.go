@@ -0,0 +1,18 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+// ConfigMismatch reports a node whose value of a configuration parameter differs from the value of most of the nodes
+// of the cluster.
+type ConfigMismatch struct {
+	// Node is the address of the node.
+	Node string
+	// Parameter is the name of the configuration parameter.
+	Parameter string
+	// Value is the value of the parameter on the node, empty if the node doesn't have the parameter.
+	Value string
+	// Missing reports whether the node doesn't have the parameter, e.g. because it runs an older server version.
+	Missing bool
+	// ExpectedValue is the value of the parameter on most of the nodes.
+	ExpectedValue string
+}
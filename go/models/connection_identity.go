@@ -0,0 +1,26 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// ConnectionIdentity identifies a connection of a client as the server sees it, from CLIENT INFO, e.g. to correlate
+// the output of CLIENT LIST with the clients of a process during an incident.
+type ConnectionIdentity struct {
+	// Node is the address of the cluster node the connection is connected to. It's empty for standalone servers.
+	Node string
+	// ID is the ID assigned to the connection by the server, as listed by CLIENT LIST.
+	ID int64
+	// Addr is the address of the client end of the connection, as seen by the server.
+	Addr string
+	// LAddr is the address of the server end of the connection.
+	LAddr string
+	// Protocol is the RESP version of the connection, e.g. 3.
+	Protocol int64
+	// Name is the name of the connection, set with the client name of the configuration or CLIENT SETNAME.
+	Name string
+	// CreatedAt is the time the connection was opened, with a resolution of a second.
+	CreatedAt time.Time
+	// Dedicated is set for the connections of the clients of a DedicatedPool.
+	Dedicated bool
+}
@@ -0,0 +1,16 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+// HedgingStatistics reports the read hedging of a client, as returned by GetHedgingStatistics.
+type HedgingStatistics struct {
+	// Reads is the number of reads which could be hedged.
+	Reads uint64
+	// Hedged is the number of reads for which a duplicate was sent, once the hedging delay elapsed.
+	Hedged uint64
+	// HedgeWins is the number of hedged reads whose duplicate succeeded first.
+	HedgeWins uint64
+	// BudgetExhausted is the number of reads which weren't hedged once the hedging delay elapsed, since the budget
+	// was spent.
+	BudgetExhausted uint64
+}
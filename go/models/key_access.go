@@ -0,0 +1,14 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+// KeyAccess reports the estimated accesses of a key prefix, as sampled by the key sampler of a client. The estimates
+// are scaled from the sampled requests to all the requests, and may exceed the actual values.
+type KeyAccess struct {
+	// KeyPrefix is the prefix of the keys, or the whole key if the keys are sampled whole.
+	KeyPrefix string
+	// Accesses is the estimated number of requests whose first key has the prefix.
+	Accesses uint64
+	// Bytes is the estimated number of bytes of the arguments of these requests.
+	Bytes uint64
+}
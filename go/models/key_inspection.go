@@ -0,0 +1,26 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// KeyInspection summarizes a key and its value, as returned by Inspect.
+type KeyInspection struct {
+	Key string
+	// Exists tells whether the key exists. The other fields are empty if it doesn't.
+	Exists bool
+	// Type is the type of the value, as returned by TYPE, e.g. "string" or "zset".
+	Type string
+	// Encoding is the internal encoding of the value, as returned by OBJECT ENCODING, e.g. "listpack".
+	Encoding string
+	// Expires tells whether the key has an expiration, in TTL.
+	Expires bool
+	// TTL is the remaining time to live of the key, if it expires.
+	TTL time.Duration
+	// MemoryUsage is the approximate number of bytes the key and its value take in memory, as returned by MEMORY
+	// USAGE, or nil if the server didn't report it, e.g. if MEMORY USAGE isn't permitted.
+	MemoryUsage Result[int64]
+	// Length is the length of the value: the length of a string, or the number of elements of a list, a set, a sorted
+	// set, a hash or a stream. It's nil for the other types, e.g. the types of modules.
+	Length Result[int64]
+}
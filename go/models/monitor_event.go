@@ -0,0 +1,18 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// MonitorEvent is a command processed by the server, as reported by MONITOR.
+type MonitorEvent struct {
+	// Time is the time the server processed the command.
+	Time time.Time
+	// Database is the index of the database the command was executed on.
+	Database int
+	// Client is the address of the client which sent the command, "lua" for commands executed by scripts, or "unix"
+	// followed by the socket path for clients connected with a Unix socket.
+	Client string
+	// Command is the name of the command followed by its arguments.
+	Command []string
+}
@@ -0,0 +1,49 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// TTLDistribution counts sampled keys by remaining time to live.
+type TTLDistribution struct {
+	// Persistent is the number of sampled keys without an expiration.
+	Persistent int64
+	// UnderMinute, UnderHour, UnderDay and UnderWeek are the numbers of sampled keys expiring in less than a minute,
+	// an hour, a day and a week, each excluding the keys of the previous ones.
+	UnderMinute int64
+	UnderHour   int64
+	UnderDay    int64
+	UnderWeek   int64
+	// Longer is the number of sampled keys expiring in a week or more.
+	Longer int64
+}
+
+// NamespaceUsage reports the keys of a namespace, as analyzed by a NamespaceAnalyzer.
+type NamespaceUsage struct {
+	// Namespace is the prefix shared by the keys, up to the delimiter ending it excluded, or "" for the keys without
+	// a delimiter.
+	Namespace string
+	// Keys is the number of scanned keys of the namespace.
+	Keys int64
+	// SampledKeys is the number of keys of which the memory usage and the TTL were sampled.
+	SampledKeys int64
+	// SampledMemory is the number of bytes the sampled keys take in memory, as reported by MEMORY USAGE.
+	SampledMemory int64
+	// EstimatedMemory is the number of bytes all the keys of the namespace are estimated to take in memory, scaled
+	// from the sampled keys.
+	EstimatedMemory int64
+	// TTL is the distribution of the TTLs of the sampled keys.
+	TTL TTLDistribution
+}
+
+// NamespaceReport is the result of a NamespaceAnalyzer.
+type NamespaceReport struct {
+	// Namespaces are the analyzed namespaces, largest estimated memory first.
+	Namespaces []NamespaceUsage
+	// ScannedKeys is the number of keys scanned, of all the namespaces.
+	ScannedKeys int64
+	// SampledKeys is the number of keys sampled, of all the namespaces.
+	SampledKeys int64
+	// Duration is how long the analysis took.
+	Duration time.Duration
+}
@@ -0,0 +1,34 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// PersistenceInfo reports the progress of the persistence of a node, from the persistence section of its INFO, e.g. to
+// wait for a background save to complete before copying the RDB file to a backup.
+type PersistenceInfo struct {
+	// Node is the address of the node, for cluster clients. It's empty for the server of a standalone client.
+	Node string
+	// Loading reports whether the node is loading a dump file.
+	Loading bool
+	// ChangesSinceLastSave is the number of changes since the last successful save.
+	ChangesSinceLastSave int64
+	// BgSaveInProgress reports whether an RDB save is ongoing.
+	BgSaveInProgress bool
+	// LastSave is the time of the last successful RDB save.
+	LastSave time.Time
+	// LastBgSaveStatus is the status of the last RDB save, "ok" or "err".
+	LastBgSaveStatus string
+	// CurrentBgSaveDuration is the duration of the ongoing RDB save, with a resolution of a second, or 0.
+	CurrentBgSaveDuration time.Duration
+	// AofEnabled reports whether AOF persistence is enabled.
+	AofEnabled bool
+	// AofRewriteInProgress reports whether an AOF rewrite is ongoing.
+	AofRewriteInProgress bool
+	// AofRewriteScheduled reports whether an AOF rewrite is scheduled to run once the ongoing RDB save is over.
+	AofRewriteScheduled bool
+	// LastAofRewriteStatus is the status of the last AOF rewrite, "ok" or "err".
+	LastAofRewriteStatus string
+	// CurrentAofRewriteDuration is the duration of the ongoing AOF rewrite, with a resolution of a second, or 0.
+	CurrentAofRewriteDuration time.Duration
+}
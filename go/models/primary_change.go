@@ -0,0 +1,22 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// PrimaryChangeEvent reports that the primary serving a range of slots of a cluster changed, e.g. after a failover.
+type PrimaryChangeEvent struct {
+	// SlotStart and SlotEnd are the first and the last slot of the range whose primary changed.
+	SlotStart int64
+	SlotEnd   int64
+	// OldPrimary and NewPrimary are the addresses of the previous and of the new primary, as "host:port".
+	OldPrimary string
+	NewPrimary string
+	// DetectedAt is the time the client detected the change.
+	DetectedAt time.Time
+	// DetectionLatency is the time the client took to detect the change once a command hit it: the time elapsed
+	// between the first MOVED or REDIRECT error since the previous check of the primaries and the detection of the
+	// change. If no command failed with such an error, it's the time elapsed since the previous check, the last time
+	// the old primary was observed serving the slots, an upper bound of the time the client took to detect the change.
+	DetectionLatency time.Duration
+}
@@ -0,0 +1,42 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// PubSubMetrics reports how far behind a subscriber is in processing the messages it received.
+type PubSubMetrics struct {
+	// BufferedMessages is the number of received messages that weren't processed yet: messages waiting for, or
+	// being processed by the message callback, or waiting in the message queue.
+	BufferedMessages int
+	// OldestBufferedMessageAge is the time elapsed since the oldest buffered message was received, or 0 if no
+	// message is buffered.
+	OldestBufferedMessageAge time.Duration
+	// ProcessedMessages is the number of messages the message callback returned for, or that were taken from the
+	// message queue.
+	ProcessedMessages uint64
+	// DroppedMessages is the number of messages discarded because the buffer limit of the subscriber was reached.
+	DroppedMessages uint64
+	// Subscriptions breaks the metrics down by the channel or the pattern the messages were received through,
+	// sorted by subscription. Once MaxPubSubMetricSubscriptions subscriptions received a message, the messages of
+	// the other subscriptions are aggregated into a last series, with Others set.
+	Subscriptions []PubSubSubscriptionMetrics
+}
+
+// MaxPubSubMetricSubscriptions is the number of subscriptions [PubSubMetrics] reports separately.
+const MaxPubSubMetricSubscriptions = 100
+
+// PubSubSubscriptionMetrics reports the messages received through a subscription, see [PubSubMetrics].
+type PubSubSubscriptionMetrics struct {
+	// Subscription is the channel, or the pattern, the messages were received through.
+	Subscription string
+	// Pattern is true if Subscription is a pattern.
+	Pattern bool
+	// Others is true for the series aggregating the subscriptions beyond MaxPubSubMetricSubscriptions, which have
+	// no Subscription.
+	Others                   bool
+	BufferedMessages         int
+	OldestBufferedMessageAge time.Duration
+	ProcessedMessages        uint64
+	DroppedMessages          uint64
+}
@@ -0,0 +1,30 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// ReplicaLag reports how far a replica is behind its primary, from the replication section of the INFO of the primary.
+type ReplicaLag struct {
+	// Primary is the address of the primary of the replica, for cluster clients. It's empty for the server of a
+	// standalone client.
+	Primary string
+	// Replica is the address of the replica, as "ip:port".
+	Replica string
+	// State is the replication state of the replica, e.g. "online", or "wait_bgsave" during a full synchronization.
+	State string
+	// PrimaryOffset is the replication offset of the primary.
+	PrimaryOffset int64
+	// Offset is the replication offset acknowledged by the replica.
+	Offset int64
+	// OffsetLag is the number of bytes of the replication stream the replica has yet to acknowledge.
+	OffsetLag int64
+	// Lag is the time since the last acknowledgement of the replica, with a resolution of a second.
+	Lag time.Duration
+}
+
+// Within reports whether the replica is online, and at most maxOffsetLag bytes and maxLag behind its primary, e.g. to
+// only keep reading from the replicas within these bounds during an incident.
+func (lag ReplicaLag) Within(maxOffsetLag int64, maxLag time.Duration) bool {
+	return lag.State == "online" && lag.OffsetLag <= maxOffsetLag && lag.Lag <= maxLag
+}
@@ -0,0 +1,38 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// RequestMetricLabels identifies a series of request metrics. The labels dropped by the metrics configuration of the
+// client are empty.
+type RequestMetricLabels struct {
+	// Command is the name of the command in upper case, e.g. "GET", EVALSHA for scripts, and EXEC or PIPELINE for
+	// atomic and non-atomic batches.
+	Command string
+	// KeyPrefix is the prefix of the key of the requests, "other" once the maximum number of prefixes is reached, or
+	// empty for the requests without keys.
+	KeyPrefix string
+}
+
+// LatencyBucket counts the requests of a [RequestMetrics] which completed within an upper bound.
+type LatencyBucket struct {
+	UpperBound time.Duration
+	// Count is the number of requests which completed in UpperBound or less, i.e. the buckets are cumulative.
+	Count uint64
+}
+
+// RequestMetrics reports the requests recorded with a set of labels since the client was created.
+type RequestMetrics struct {
+	Labels RequestMetricLabels
+	// Requests is the number of requests, failed or not.
+	Requests uint64
+	// Errors is the number of failed requests, by kind of error: the code of the server errors, e.g. "WRONGTYPE",
+	// or "TIMEOUT", "DISCONNECT", "CLOSING", "CONTEXT" and "OTHER" for the errors of the client.
+	Errors map[string]uint64
+	// LatencySum is the total time the requests took to complete.
+	LatencySum time.Duration
+	// LatencyBuckets are the cumulative latency buckets of the requests, ordered by upper bound. Requests slower
+	// than the last bucket are only counted by Requests.
+	LatencyBuckets []LatencyBucket
+}
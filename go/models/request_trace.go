@@ -0,0 +1,41 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// TracedCommand is a command of a traced request.
+type TracedCommand struct {
+	// Name is the name of the command in upper case, e.g. "SET" or "CONFIG GET", and "EVALSHA" for scripts.
+	Name string
+	// Args are the arguments of the command following its name, as sent, i.e. with the keys prefixed for the view of
+	// the client which sent it, except for the passwords, which are replaced by "<redacted>": those of AUTH, HELLO
+	// AUTH, MIGRATE AUTH and AUTH2, and CONFIG SET requirepass and masterauth.
+	Args []string
+}
+
+// RequestTrace describes the exchange of a request traced with options.WithTrace.
+type RequestTrace struct {
+	// Commands are the commands of the request, a single command, or the commands of a batch.
+	Commands []TracedCommand
+	// Batch is set for batches, and Atomic for the atomic ones, i.e. transactions.
+	Batch  bool
+	Atomic bool
+	// Node is the address of the node the request was sent to, when it was routed to a given node with
+	// config.ByAddressRoute. It's empty otherwise, as the node is then picked by the client.
+	Node string
+	// Sequence is the number of the request among the requests traced with the same context, starting at 1, e.g. 2
+	// for the first retry of a command by the caller. The retries of the client after redirections and reconnections
+	// aren't traced as requests.
+	Sequence int
+	// Start is the time the request was issued.
+	Start time.Time
+	// Duration is the time until the response arrived or the request failed, including its wait for the request
+	// scheduler of the client, if any.
+	Duration time.Duration
+	// Response is the decoded response of the request, as returned by CustomCommand, or the responses of the commands
+	// of a batch. It's nil if the request failed.
+	Response any
+	// Err is the error of the request, if it failed.
+	Err error
+}
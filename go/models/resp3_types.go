@@ -0,0 +1,16 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+// VerbatimString is a RESP3 verbatim string, e.g. the reply of INFO or LOLWUT, with the hint of its format.
+type VerbatimString struct {
+	// Format is the three characters long format of the text: "txt" for plain text, or "mkd" for markdown.
+	Format string
+	// Text is the text of the string, without its format.
+	Text string
+}
+
+// String returns the text of the verbatim string.
+func (verbatim VerbatimString) String() string {
+	return verbatim.Text
+}
@@ -0,0 +1,49 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// ServerLimits are the limits of the server configuration bounding the requests and the values of a client, as read
+// with CONFIG GET. The limits which the server doesn't report, e.g. set-max-listpack-entries before valkey 7.2, are
+// negative, but ListMaxListpackSize, which is 0, and MaxMemoryPolicy, which is "".
+type ServerLimits struct {
+	// ProtoMaxBulkLen is the maximum length in bytes of a single string of a request, proto-max-bulk-len.
+	ProtoMaxBulkLen int64
+	// ClientQueryBufferLimit is the maximum length in bytes of the buffered requests of a connection,
+	// client-query-buffer-limit.
+	ClientQueryBufferLimit int64
+	// MaxMemory is the memory limit of the server in bytes, maxmemory, or 0 if the memory isn't limited.
+	MaxMemory int64
+	// MaxMemoryPolicy is the eviction policy applied when the memory limit is reached, maxmemory-policy, e.g.
+	// "noeviction" or "allkeys-lru".
+	MaxMemoryPolicy string
+	// ListMaxListpackSize bounds the nodes of lists, list-max-listpack-size: the number of elements of a node if
+	// positive, or its size from -1 for 4 KiB to -5 for 64 KiB if negative.
+	ListMaxListpackSize int64
+	// HashMaxListpackEntries is the number of fields of the hashes encoded as listpacks, hash-max-listpack-entries.
+	HashMaxListpackEntries int64
+	// HashMaxListpackValue is the length of the fields and values of the hashes encoded as listpacks,
+	// hash-max-listpack-value.
+	HashMaxListpackValue int64
+	// SetMaxIntsetEntries is the number of members of the sets of integers encoded as intsets, set-max-intset-entries.
+	SetMaxIntsetEntries int64
+	// SetMaxListpackEntries is the number of members of the sets encoded as listpacks, set-max-listpack-entries.
+	SetMaxListpackEntries int64
+	// SetMaxListpackValue is the length of the members of the sets encoded as listpacks, set-max-listpack-value.
+	SetMaxListpackValue int64
+	// ZSetMaxListpackEntries is the number of members of the sorted sets encoded as listpacks,
+	// zset-max-listpack-entries.
+	ZSetMaxListpackEntries int64
+	// ZSetMaxListpackValue is the length of the members of the sorted sets encoded as listpacks,
+	// zset-max-listpack-value.
+	ZSetMaxListpackValue int64
+	// Timeout is how long the server keeps idle connections open, timeout, or 0 if they're never closed.
+	Timeout time.Duration
+}
+
+// FitsBulk reports whether a string of length bytes may be sent in a request, i.e. doesn't exceed ProtoMaxBulkLen.
+// Any length fits if ProtoMaxBulkLen isn't reported.
+func (limits ServerLimits) FitsBulk(length int) bool {
+	return limits.ProtoMaxBulkLen < 0 || int64(length) <= limits.ProtoMaxBulkLen
+}
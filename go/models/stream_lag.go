@@ -0,0 +1,38 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// StreamLag reports how far a consumer group is behind a stream, as returned by StreamLag.
+type StreamLag struct {
+	Stream string
+	Group  string
+	// EntriesBehind is the number of entries of the stream not yet delivered to the consumers of the group, as
+	// reported by XINFO GROUPS, or nil if the server can't determine it, e.g. before valkey 7.0.
+	EntriesBehind Result[int64]
+	// Pending is the number of entries delivered to the consumers of the group but not acknowledged yet.
+	Pending int64
+	// OldestPendingId is the ID of the oldest pending entry, or nil if there are no pending entries.
+	OldestPendingId Result[string]
+	// OldestPendingAge is how long ago the oldest pending entry was added to the stream, according to the timestamp
+	// of its ID and the clock of the client, or 0 if there are no pending entries.
+	OldestPendingAge time.Duration
+}
+
+// StreamLagSummary aggregates the lags of a consumer group over several streams, as returned by AggregateStreamLag.
+type StreamLagSummary struct {
+	Group string
+	// EntriesBehind is the number of entries not yet delivered to the consumers of the group, over the streams whose
+	// lag is known.
+	EntriesBehind int64
+	// Unknown is the number of streams whose number of entries not yet delivered is unknown.
+	Unknown int
+	// Pending is the number of entries delivered to the consumers of the group but not acknowledged yet, over all the
+	// streams.
+	Pending int64
+	// OldestPendingAge is the age of the oldest pending entry over all the streams.
+	OldestPendingAge time.Duration
+	// Streams are the lags of the group for each stream, in the order of the streams.
+	Streams []StreamLag
+}
@@ -0,0 +1,37 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package models
+
+import "time"
+
+// TTLBucket counts the sampled keys whose remaining time to live is less than UpperBound, and at least the upper bound
+// of the previous bucket.
+type TTLBucket struct {
+	UpperBound time.Duration
+	Keys       int64
+}
+
+// TTLHistogram reports the TTLs of the sampled keys matching a pattern, as audited by a TTLAuditor.
+type TTLHistogram struct {
+	// Pattern is the glob-style pattern of the keys.
+	Pattern string
+	// SampledKeys is the number of sampled keys.
+	SampledKeys int64
+	// Persistent is the number of sampled keys without an expiration.
+	Persistent int64
+	// Buckets count the sampled keys with an expiration by TTL, in increasing order of their upper bounds.
+	Buckets []TTLBucket
+	// Longer is the number of sampled keys with an expiration at least the upper bound of the last bucket.
+	Longer int64
+	// Complete tells whether all the keys matching the pattern were sampled, rather than the sample size reached.
+	Complete bool
+}
+
+// PersistentRatio returns the fraction of the sampled keys without an expiration, in [0, 1], or 0 if no key was
+// sampled.
+func (histogram TTLHistogram) PersistentRatio() float64 {
+	if histogram.SampledKeys == 0 {
+		return 0
+	}
+	return float64(histogram.Persistent) / float64(histogram.SampledKeys)
+}
@@ -0,0 +1,357 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// DefaultMonitorBufferSize is the number of events a [MonitorSession] buffers until they're received.
+const DefaultMonitorBufferSize = 1024
+
+// MonitorSession streams the commands processed by a server, as reported by MONITOR, for debugging sessions. Its
+// events must be received promptly: once its buffer is full, the session stops reading from the server, whose
+// output buffer for the session grows until the server disconnects it.
+//
+// MONITOR degrades the throughput of the server, and reports the arguments of the commands, passwords included: it
+// should only be used for short debugging sessions.
+type MonitorSession struct {
+	conn   net.Conn
+	events chan models.MonitorEvent
+	done   chan struct{}
+
+	closeOnce sync.Once
+	mu        sync.Mutex
+	err       error
+}
+
+// Monitor streams the commands processed by the server of the pool, see [MonitorSession]. MONITOR takes over the
+// connection it's sent on, so the session uses a connection of its own rather than a handle of the pool, closed with
+// the session.
+//
+// The connection is set up with the address, the TLS settings, client certificate included, and the password-based
+// credentials of the configuration of the pool. The configurations with several addresses or IAM authentication
+// aren't supported, and fail with a [ConfigurationError].
+//
+// Example usage:
+//
+//	session, err := pool.Monitor(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer session.Close()
+//	for event := range session.Events() {
+//		fmt.Println(event.Time, event.Client, event.Command)
+//	}
+//
+// Parameters:
+//
+//	ctx - The context bounding the session: the session ends once it's done.
+//
+// Return value:
+//
+//	The session, or an error if the connection couldn't be set up.
+func (pool *DedicatedPool) Monitor(ctx context.Context) (*MonitorSession, error) {
+	if pool.clientConfig == nil {
+		return nil, NewConfigurationError("the dedicated pool has no configuration to connect with")
+	}
+	request, err := pool.clientConfig.ToProtobuf()
+	if err != nil {
+		return nil, NewConfigurationError(err.Error())
+	}
+	conn, err := dialMonitor(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	session := &MonitorSession{
+		conn:   conn,
+		events: make(chan models.MonitorEvent, DefaultMonitorBufferSize),
+		done:   make(chan struct{}),
+	}
+	go session.read(bufio.NewReader(conn))
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.stop(ctx.Err())
+		case <-session.done:
+		}
+	}()
+	return session, nil
+}
+
+// dialMonitor connects to the address of request, authenticates and sends MONITOR.
+func dialMonitor(ctx context.Context, request *protobuf.ConnectionRequest) (net.Conn, error) {
+	host, port := config.DefaultHost, config.DefaultPort
+	switch addresses := request.GetAddresses(); len(addresses) {
+	case 0:
+	case 1:
+		host, port = addresses[0].GetHost(), int(addresses[0].GetPort())
+	default:
+		return nil, NewConfigurationError("MONITOR reports the commands of a single server, but the configuration " +
+			"has several addresses")
+	}
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	if request.GetAuthenticationInfo().GetIamCredentials() != nil {
+		return nil, NewConfigurationError("MONITOR doesn't support IAM authentication")
+	}
+
+	var dialer interface {
+		DialContext(ctx context.Context, network, address string) (net.Conn, error)
+	} = &net.Dialer{}
+	if request.GetTlsMode() != protobuf.TlsMode_NoTls {
+		tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: request.GetTlsMode() == protobuf.TlsMode_InsecureTls}
+		if rootCerts := request.GetRootCerts(); len(rootCerts) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			for _, cert := range rootCerts {
+				tlsConfig.RootCAs.AppendCertsFromPEM(cert)
+			}
+		}
+		if len(request.GetClientCert()) > 0 || len(request.GetClientKey()) > 0 {
+			certificate, err := tls.X509KeyPair(request.GetClientCert(), request.GetClientKey())
+			if err != nil {
+				return nil, NewConfigurationError(fmt.Sprintf("invalid client certificate: %v", err))
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+		dialer = &tls.Dialer{Config: tlsConfig}
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, NewConnectionError(fmt.Sprintf("failed to connect to %s: %v", address, err))
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	command := func(args ...string) error {
+		if _, err := conn.Write(encodeRespCommand(args)); err != nil {
+			return NewConnectionError(fmt.Sprintf("failed to send %s: %v", args[0], err))
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return NewConnectionError(fmt.Sprintf("failed to read the response of %s: %v", args[0], err))
+		}
+		if line = strings.TrimRight(line, "\r\n"); strings.HasPrefix(line, "-") {
+			return NewRequestError(line[1:])
+		}
+		return nil
+	}
+	if auth := request.GetAuthenticationInfo(); auth != nil {
+		if auth.GetPassword() != "" {
+			args := []string{"AUTH", auth.GetPassword()}
+			if auth.GetUsername() != "" {
+				args = []string{"AUTH", auth.GetUsername(), auth.GetPassword()}
+			}
+			if err := command(args...); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+	}
+	if err := command("MONITOR"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	if reader.Buffered() > 0 {
+		// Events following the reply of MONITOR in the same read are replayed to the session.
+		buffered, _ := reader.Peek(reader.Buffered())
+		return &prefixedConn{Conn: conn, prefix: append([]byte(nil), buffered...)}, nil
+	}
+	return conn, nil
+}
+
+// prefixedConn is a connection whose first reads return prefix.
+type prefixedConn struct {
+	net.Conn
+	prefix []byte
+}
+
+func (conn *prefixedConn) Read(b []byte) (int, error) {
+	if len(conn.prefix) > 0 {
+		n := copy(b, conn.prefix)
+		conn.prefix = conn.prefix[n:]
+		return n, nil
+	}
+	return conn.Conn.Read(b)
+}
+
+// encodeRespCommand encodes args as a RESP array of bulk strings.
+func encodeRespCommand(args []string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return []byte(b.String())
+}
+
+func (session *MonitorSession) read(reader *bufio.Reader) {
+	defer close(session.done)
+	defer close(session.events)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			session.setErr(NewConnectionError("the MONITOR connection failed: " + err.Error()))
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "-"):
+			session.setErr(NewRequestError(line[1:]))
+			return
+		case strings.HasPrefix(line, "+"):
+			event, err := parseMonitorEvent(line[1:])
+			if err != nil {
+				continue
+			}
+			session.events <- event
+		}
+	}
+}
+
+// Events returns the channel the events of the session are sent to. It's closed once the session ends.
+func (session *MonitorSession) Events() <-chan models.MonitorEvent {
+	return session.events
+}
+
+// Err returns why the session ended: the error of the context of the session, or of the connection, or nil if the
+// session was closed or didn't end yet.
+func (session *MonitorSession) Err() error {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.err
+}
+
+func (session *MonitorSession) setErr(err error) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.err == nil {
+		session.err = err
+	}
+}
+
+// stop ends the session with err, unless it's already ended.
+func (session *MonitorSession) stop(err error) {
+	session.closeOnce.Do(func() {
+		session.mu.Lock()
+		if session.err == nil {
+			session.err = err
+		}
+		session.mu.Unlock()
+		session.conn.Close()
+		// Unblock the reader if it's waiting for the buffer to drain.
+		go func() {
+			for range session.events {
+			}
+		}()
+	})
+}
+
+// Close ends the session and closes its connection. Closing a session more than once is a no-op.
+func (session *MonitorSession) Close() {
+	session.stop(errMonitorClosed)
+	<-session.done
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if errors.Is(session.err, errMonitorClosed) {
+		session.err = nil
+	}
+}
+
+var errMonitorClosed = errors.New("the MONITOR session is closed")
+
+// parseMonitorEvent parses a line of MONITOR, e.g. 1339518083.107412 [0 127.0.0.1:60866] "keys" "*".
+func parseMonitorEvent(line string) (models.MonitorEvent, error) {
+	var event models.MonitorEvent
+	timestamp, rest, ok := strings.Cut(line, " [")
+	if !ok {
+		return event, fmt.Errorf("invalid MONITOR line: %q", line)
+	}
+	seconds, err := strconv.ParseFloat(timestamp, 64)
+	if err != nil {
+		return event, fmt.Errorf("invalid MONITOR timestamp: %q", timestamp)
+	}
+	whole, fraction := math.Modf(seconds)
+	event.Time = time.Unix(int64(whole), int64(math.Round(fraction*1e6))*int64(time.Microsecond))
+
+	source, command, ok := strings.Cut(rest, "] ")
+	if !ok {
+		return event, fmt.Errorf("invalid MONITOR line: %q", line)
+	}
+	database, client, _ := strings.Cut(source, " ")
+	if event.Database, err = strconv.Atoi(database); err != nil {
+		return event, fmt.Errorf("invalid MONITOR database: %q", database)
+	}
+	event.Client = client
+	event.Command, err = parseMonitorArgs(command)
+	return event, err
+}
+
+// parseMonitorArgs parses the quoted arguments of a MONITOR line, escaped as by sdscatrepr.
+func parseMonitorArgs(command string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(command); {
+		if command[i] == ' ' {
+			i++
+			continue
+		}
+		if command[i] != '"' {
+			return nil, fmt.Errorf("invalid MONITOR arguments: %q", command)
+		}
+		var arg strings.Builder
+		i++
+		for ; i < len(command) && command[i] != '"'; i++ {
+			if command[i] != '\\' || i+1 >= len(command) {
+				arg.WriteByte(command[i])
+				continue
+			}
+			i++
+			switch command[i] {
+			case 'n':
+				arg.WriteByte('\n')
+			case 'r':
+				arg.WriteByte('\r')
+			case 't':
+				arg.WriteByte('\t')
+			case 'a':
+				arg.WriteByte('\a')
+			case 'b':
+				arg.WriteByte('\b')
+			case 'x':
+				if i+2 < len(command) {
+					if value, err := strconv.ParseUint(command[i+1:i+3], 16, 8); err == nil {
+						arg.WriteByte(byte(value))
+						i += 2
+						continue
+					}
+				}
+				arg.WriteByte('x')
+			default:
+				arg.WriteByte(command[i])
+			}
+		}
+		if i >= len(command) {
+			return nil, fmt.Errorf("unterminated MONITOR argument: %q", command)
+		}
+		i++
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
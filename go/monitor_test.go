@@ -0,0 +1,105 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestParseMonitorEvent(t *testing.T) {
+	event, err := parseMonitorEvent(`1339518083.107412 [0 127.0.0.1:60866] "set" "key" "a \"quoted\"\n\x01value"`)
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1339518083, 107412000), event.Time)
+	assert.Equal(t, 0, event.Database)
+	assert.Equal(t, "127.0.0.1:60866", event.Client)
+	assert.Equal(t, []string{"set", "key", "a \"quoted\"\n\x01value"}, event.Command)
+
+	event, err = parseMonitorEvent(`1339518083.000001 [3 lua] "get" "key"`)
+	require.NoError(t, err)
+	assert.Equal(t, 3, event.Database)
+	assert.Equal(t, "lua", event.Client)
+
+	_, err = parseMonitorEvent("OK")
+	assert.Error(t, err)
+	_, err = parseMonitorEvent(`1339518083.107412 [0 127.0.0.1:60866] "unterminated`)
+	assert.Error(t, err)
+}
+
+func TestDedicatedPool_Monitor(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	commands := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for range 2 {
+			var args []string
+			header, _ := reader.ReadString('\n')
+			for i := 0; i < int(header[1]-'0'); i++ {
+				_, _ = reader.ReadString('\n')
+				arg, _ := reader.ReadString('\n')
+				args = append(args, strings.TrimSpace(arg))
+			}
+			commands <- strings.Join(args, " ")
+			_, _ = conn.Write([]byte("+OK\r\n"))
+		}
+		_, _ = conn.Write([]byte("+1339518083.107412 [0 127.0.0.1:60866] \"ping\"\r\n"))
+		_, _ = reader.ReadString('\n')
+	}()
+
+	address := listener.Addr().(*net.TCPAddr)
+	clientConfig := config.NewClientConfiguration().
+		WithAddress(&config.NodeAddress{Host: "127.0.0.1", Port: address.Port}).
+		WithCredentials(config.NewServerCredentials("user", "secret"))
+	session, err := NewDedicatedPool(clientConfig).Monitor(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "AUTH user secret", <-commands)
+	assert.Equal(t, "MONITOR", <-commands)
+
+	event := <-session.Events()
+	assert.Equal(t, []string{"ping"}, event.Command)
+	session.Close()
+	_, open := <-session.Events()
+	assert.False(t, open)
+	assert.NoError(t, session.Err())
+}
+
+func TestDedicatedPool_MonitorUnsupportedConfiguration(t *testing.T) {
+	var configurationErr *ConfigurationError
+	_, err := NewDedicatedPool(config.NewClientConfiguration().
+		WithAddress(&config.NodeAddress{Host: "10.0.0.1"}).
+		WithAddress(&config.NodeAddress{Host: "10.0.0.2"})).Monitor(context.Background())
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.ErrorContains(t, err, "several addresses")
+
+	credentials, err := config.NewServerCredentialsWithIam("user",
+		config.NewIamAuthConfig("cluster", config.ElastiCache, "us-east-1"))
+	require.NoError(t, err)
+	_, err = NewDedicatedPool(config.NewClientConfiguration().WithCredentials(credentials)).Monitor(context.Background())
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.ErrorContains(t, err, "IAM authentication")
+
+	_, err = dialMonitor(context.Background(), &protobuf.ConnectionRequest{
+		TlsMode:    protobuf.TlsMode_SecureTls,
+		ClientCert: []byte("not a certificate"),
+		ClientKey:  []byte("not a key"),
+	})
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.ErrorContains(t, err, "invalid client certificate")
+}
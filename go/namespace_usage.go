@@ -0,0 +1,345 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+const (
+	// DefaultNamespaceSamplePercentage is the percentage of the keys sampled by a [NamespaceAnalyzer], unless
+	// configured otherwise.
+	DefaultNamespaceSamplePercentage = 5.0
+	// DefaultNamespaceScanCount is the COUNT of the scans of a [NamespaceAnalyzer], unless configured otherwise.
+	DefaultNamespaceScanCount = 1000
+	// DefaultMaxNamespaces is the maximum number of namespaces reported by a [NamespaceAnalyzer], unless configured
+	// otherwise.
+	DefaultMaxNamespaces = 10000
+	// OverflowNamespace is the namespace under which a [NamespaceAnalyzer] reports the keys of the namespaces found
+	// once the maximum number of namespaces is reached.
+	OverflowNamespace = "(other)"
+)
+
+// namespaceClient is implemented by [Client] and [ClusterClient].
+type namespaceClient interface {
+	scanKeys(ctx context.Context, match string, count int64, visit func(keys []string) error) error
+	keyUsages(ctx context.Context, keys []string) ([]keyUsage, error)
+}
+
+// keyUsage is the sampled memory usage and TTL of a key.
+type keyUsage struct {
+	// exists tells whether the key still existed when sampled.
+	exists bool
+	// memory is the memory usage of the key, or -1 if the server didn't report it.
+	memory int64
+	// ttl is the remaining time to live of the key, or -1 if it doesn't expire.
+	ttl time.Duration
+}
+
+// NamespaceAnalyzer reports the key count, the memory usage and the TTLs of the namespaces of a database, i.e. the
+// prefixes of its keys up to a delimiter, e.g. "user" for "user:1000:profile", for capacity planning.
+//
+// The keys are scanned with SCAN, of all the primaries of a cluster, and their namespaces counted. The memory usage and
+// the TTL of a random sample of the keys are read with MEMORY USAGE and PTTL, pipelined per page of the scan, from
+// which the memory of each namespace is estimated: the first key of each namespace is always sampled, so that every
+// namespace has an estimate. The keys written or deleted during the analysis may or may not be counted, and a key may
+// be counted twice in a cluster being resharded.
+//
+// Example usage:
+//
+//	report, err := glide.NewNamespaceAnalyzer(client).WithDepth(2).Analyze(ctx)
+//	for _, namespace := range report.Namespaces {
+//		fmt.Println(namespace.Namespace, namespace.Keys, namespace.EstimatedMemory)
+//	}
+type NamespaceAnalyzer struct {
+	client           namespaceClient
+	delimiters       string
+	depth            int
+	samplePercentage float64
+	scanCount        int64
+	match            string
+	maxNamespaces    int
+	// roll returns a pseudo-random percentage in [0, 100).
+	roll func() float64
+}
+
+// NewNamespaceAnalyzer returns a [NamespaceAnalyzer] for the keys of client, a [Client] or a [ClusterClient].
+func NewNamespaceAnalyzer(client namespaceClient) *NamespaceAnalyzer {
+	return &NamespaceAnalyzer{
+		client:           client,
+		delimiters:       ":",
+		depth:            1,
+		samplePercentage: DefaultNamespaceSamplePercentage,
+		scanCount:        DefaultNamespaceScanCount,
+		match:            "*",
+		maxNamespaces:    DefaultMaxNamespaces,
+		roll:             func() float64 { return rand.Float64() * 100 },
+	}
+}
+
+// WithDelimiters sets the characters delimiting the namespaces of the keys, any of which ends a namespace. Defaults to
+// ":".
+func (analyzer *NamespaceAnalyzer) WithDelimiters(delimiters string) *NamespaceAnalyzer {
+	analyzer.delimiters = delimiters
+	return analyzer
+}
+
+// WithDepth sets the number of delimited segments of the keys their namespace is made of, e.g. "tenant:42" at depth 2
+// for "tenant:42:user:1000". The keys with fewer delimiters are reported under their prefix up to their last
+// delimiter. Defaults to 1.
+func (analyzer *NamespaceAnalyzer) WithDepth(depth int) *NamespaceAnalyzer {
+	analyzer.depth = depth
+	return analyzer
+}
+
+// WithSamplePercentage sets the percentage of the keys whose memory usage and TTL are sampled, in (0, 100]. Defaults
+// to DefaultNamespaceSamplePercentage.
+func (analyzer *NamespaceAnalyzer) WithSamplePercentage(percentage float64) *NamespaceAnalyzer {
+	analyzer.samplePercentage = percentage
+	return analyzer
+}
+
+// WithScanCount sets the COUNT of the scans, the number of keys read per page. Defaults to DefaultNamespaceScanCount.
+func (analyzer *NamespaceAnalyzer) WithScanCount(count int64) *NamespaceAnalyzer {
+	analyzer.scanCount = count
+	return analyzer
+}
+
+// WithMatch restricts the analysis to the keys matching a glob-style pattern, e.g. "tenant:42:*". Defaults to all
+// the keys.
+func (analyzer *NamespaceAnalyzer) WithMatch(match string) *NamespaceAnalyzer {
+	analyzer.match = match
+	return analyzer
+}
+
+// WithMaxNamespaces sets the maximum number of namespaces reported: the keys of the namespaces found once it's
+// reached are reported under OverflowNamespace, bounding the memory of the analysis of keys without namespaces.
+// Defaults to DefaultMaxNamespaces.
+func (analyzer *NamespaceAnalyzer) WithMaxNamespaces(maxNamespaces int) *NamespaceAnalyzer {
+	analyzer.maxNamespaces = maxNamespaces
+	return analyzer
+}
+
+// Analyze scans the keys and reports their namespaces.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the analysis.
+//
+// Return value:
+//
+//	The report of the namespaces, or an error if a scan or a sample failed, or if ctx is done before the end of
+//	the scan.
+func (analyzer *NamespaceAnalyzer) Analyze(ctx context.Context) (models.NamespaceReport, error) {
+	if err := analyzer.validate(); err != nil {
+		return models.NamespaceReport{}, err
+	}
+	started := time.Now()
+	namespaces := make(map[string]*models.NamespaceUsage)
+	var report models.NamespaceReport
+	err := analyzer.client.scanKeys(ctx, analyzer.match, analyzer.scanCount, func(keys []string) error {
+		var sampled []string
+		var sampledNamespaces []*models.NamespaceUsage
+		for _, key := range keys {
+			namespace := analyzer.namespaceOf(key, namespaces)
+			usage, ok := namespaces[namespace]
+			if !ok {
+				usage = &models.NamespaceUsage{Namespace: namespace}
+				namespaces[namespace] = usage
+			}
+			usage.Keys++
+			report.ScannedKeys++
+			if usage.Keys == 1 || analyzer.roll() < analyzer.samplePercentage {
+				sampled = append(sampled, key)
+				sampledNamespaces = append(sampledNamespaces, usage)
+			}
+		}
+		if len(sampled) == 0 {
+			return nil
+		}
+		usages, err := analyzer.client.keyUsages(ctx, sampled)
+		if err != nil {
+			return err
+		}
+		for i, usage := range usages {
+			if usage.exists {
+				recordKeyUsage(sampledNamespaces[i], usage)
+				report.SampledKeys++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.NamespaceReport{}, err
+	}
+
+	report.Namespaces = make([]models.NamespaceUsage, 0, len(namespaces))
+	for _, usage := range namespaces {
+		if usage.SampledKeys > 0 {
+			usage.EstimatedMemory = usage.SampledMemory * usage.Keys / usage.SampledKeys
+		}
+		report.Namespaces = append(report.Namespaces, *usage)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		if report.Namespaces[i].EstimatedMemory != report.Namespaces[j].EstimatedMemory {
+			return report.Namespaces[i].EstimatedMemory > report.Namespaces[j].EstimatedMemory
+		}
+		return report.Namespaces[i].Namespace < report.Namespaces[j].Namespace
+	})
+	report.Duration = time.Since(started)
+	return report, nil
+}
+
+func (analyzer *NamespaceAnalyzer) validate() error {
+	if analyzer.delimiters == "" {
+		return fmt.Errorf("at least one delimiter must be set")
+	}
+	if analyzer.depth < 1 {
+		return fmt.Errorf("the depth must be at least 1, got %d", analyzer.depth)
+	}
+	if analyzer.samplePercentage <= 0 || analyzer.samplePercentage > 100 {
+		return fmt.Errorf("the sample percentage must be in (0, 100], got %v", analyzer.samplePercentage)
+	}
+	if analyzer.scanCount <= 0 {
+		return fmt.Errorf("the scan count must be positive, got %d", analyzer.scanCount)
+	}
+	if analyzer.maxNamespaces <= 0 {
+		return fmt.Errorf("the maximum number of namespaces must be positive, got %d", analyzer.maxNamespaces)
+	}
+	return nil
+}
+
+// namespaceOf returns the namespace key is reported under, given the namespaces found so far.
+func (analyzer *NamespaceAnalyzer) namespaceOf(key string, namespaces map[string]*models.NamespaceUsage) string {
+	namespace := keyNamespace(key, analyzer.delimiters, analyzer.depth)
+	if _, ok := namespaces[namespace]; !ok && len(namespaces) >= analyzer.maxNamespaces {
+		return OverflowNamespace
+	}
+	return namespace
+}
+
+// keyNamespace returns the prefix of key up to its depth-th delimiter excluded, or up to its last delimiter if it has
+// fewer, or "" if it has none.
+func keyNamespace(key string, delimiters string, depth int) string {
+	end := -1
+	for segment := 0; segment < depth; segment++ {
+		index := strings.IndexAny(key[end+1:], delimiters)
+		if index < 0 {
+			break
+		}
+		end += 1 + index
+	}
+	if end < 0 {
+		return ""
+	}
+	return key[:end]
+}
+
+// recordKeyUsage records the sampled usage of a key of namespace.
+func recordKeyUsage(namespace *models.NamespaceUsage, usage keyUsage) {
+	namespace.SampledKeys++
+	if usage.memory > 0 {
+		namespace.SampledMemory += usage.memory
+	}
+	ttl := &namespace.TTL
+	switch {
+	case usage.ttl < 0:
+		ttl.Persistent++
+	case usage.ttl < time.Minute:
+		ttl.UnderMinute++
+	case usage.ttl < time.Hour:
+		ttl.UnderHour++
+	case usage.ttl < 24*time.Hour:
+		ttl.UnderDay++
+	case usage.ttl < 7*24*time.Hour:
+		ttl.UnderWeek++
+	default:
+		ttl.Longer++
+	}
+}
+
+func (client *Client) scanKeys(ctx context.Context, match string, count int64, visit func(keys []string) error) error {
+	scanOptions := options.NewScanOptions().SetMatch(match).SetCount(count)
+	cursor := models.NewCursor()
+	for {
+		result, err := client.ScanWithOptions(ctx, cursor, *scanOptions)
+		if err != nil {
+			return err
+		}
+		if err := visit(result.Data); err != nil {
+			return err
+		}
+		if result.Cursor.IsFinished() {
+			return nil
+		}
+		cursor = result.Cursor
+	}
+}
+
+func (client *ClusterClient) scanKeys(
+	ctx context.Context,
+	match string,
+	count int64,
+	visit func(keys []string) error,
+) error {
+	scanOptions := options.NewClusterScanOptions().SetMatch(match).SetCount(count)
+	cursor := models.NewClusterScanCursor()
+	for !cursor.IsFinished() {
+		result, err := client.ScanWithOptions(ctx, cursor, *scanOptions)
+		if err != nil {
+			return err
+		}
+		if err := visit(result.Keys); err != nil {
+			return err
+		}
+		cursor = result.Cursor
+	}
+	return nil
+}
+
+// keyUsages returns the memory usage and TTL of keys, read in a single non-atomic pipeline of MEMORY USAGE and PTTL.
+func (client *baseClient) keyUsages(ctx context.Context, keys []string) ([]keyUsage, error) {
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{Commands: make([]internal.Cmd, 0, 2*len(keys))}
+	for _, key := range keys {
+		batch.Commands = append(batch.Commands,
+			internal.MakeCmd(uint32(protobuf.RequestType_MemoryUsage), []string{key}, identity),
+			internal.MakeCmd(uint32(protobuf.RequestType_PTTL), []string{key}, identity),
+		)
+	}
+	responses, err := client.executeBatch(ctx, batch, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyUsages(keys, responses)
+}
+
+// parseKeyUsages returns the usages of keys from the responses of the pipeline of keyUsages.
+func parseKeyUsages(keys []string, responses []any) ([]keyUsage, error) {
+	if len(responses) != 2*len(keys) {
+		return nil, fmt.Errorf("unexpected number of responses to sample %d keys: %d", len(keys), len(responses))
+	}
+	usages := make([]keyUsage, len(keys))
+	for i, key := range keys {
+		// MEMORY USAGE may not be permitted, leaving the memory unknown, but the TTL is needed.
+		usage, err := parseKeyTTL(key, responses[2*i+1])
+		if err != nil {
+			return nil, err
+		}
+		if memory, ok := responses[2*i].(int64); ok && usage.exists {
+			usage.memory = memory
+		}
+		usages[i] = usage
+	}
+	return usages, nil
+}
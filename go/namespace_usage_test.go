@@ -0,0 +1,146 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+type fakeNamespaceClient struct {
+	pages   [][]string
+	usages  map[string]keyUsage
+	sampled []string
+}
+
+func (client *fakeNamespaceClient) scanKeys(
+	_ context.Context,
+	_ string,
+	_ int64,
+	visit func(keys []string) error,
+) error {
+	for _, page := range client.pages {
+		if err := visit(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *fakeNamespaceClient) keyUsages(_ context.Context, keys []string) ([]keyUsage, error) {
+	client.sampled = append(client.sampled, keys...)
+	usages := make([]keyUsage, len(keys))
+	for i, key := range keys {
+		usages[i] = client.usages[key]
+	}
+	return usages, nil
+}
+
+func TestKeyNamespace(t *testing.T) {
+	assert.Equal(t, "user", keyNamespace("user:1000:profile", ":", 1))
+	assert.Equal(t, "user:1000", keyNamespace("user:1000:profile", ":", 2))
+	assert.Equal(t, "user:1000", keyNamespace("user:1000:profile", ":", 3))
+	assert.Equal(t, "tenant/42", keyNamespace("tenant/42.orders", "./", 2))
+	assert.Equal(t, "", keyNamespace("counter", ":", 1))
+	assert.Equal(t, "", keyNamespace(":leading", ":", 1))
+}
+
+func TestNamespaceAnalyzer_Analyze(t *testing.T) {
+	client := &fakeNamespaceClient{
+		pages: [][]string{{"user:1", "user:2", "session:a"}, {"user:3", "user:4", "counter"}},
+		usages: map[string]keyUsage{
+			"user:1":    {exists: true, memory: 100, ttl: -1},
+			"user:3":    {exists: true, memory: 300, ttl: 2 * time.Hour},
+			"session:a": {exists: true, memory: 50, ttl: 30 * time.Second},
+			"counter":   {exists: true, memory: -1, ttl: 10 * 24 * time.Hour},
+		},
+	}
+	analyzer := NewNamespaceAnalyzer(client)
+	// The first key of each namespace is sampled without a roll, user:3 is the only other sampled key.
+	rolls := []float64{50, 0, 50}
+	analyzer.roll = func() float64 {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+
+	report, err := analyzer.Analyze(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), report.ScannedKeys)
+	assert.Equal(t, int64(4), report.SampledKeys)
+	assert.Equal(t, []string{"user:1", "session:a", "user:3", "counter"}, client.sampled)
+	assert.Equal(t, []models.NamespaceUsage{
+		{
+			Namespace:       "user",
+			Keys:            4,
+			SampledKeys:     2,
+			SampledMemory:   400,
+			EstimatedMemory: 800,
+			TTL:             models.TTLDistribution{Persistent: 1, UnderDay: 1},
+		},
+		{
+			Namespace:       "session",
+			Keys:            1,
+			SampledKeys:     1,
+			SampledMemory:   50,
+			EstimatedMemory: 50,
+			TTL:             models.TTLDistribution{UnderMinute: 1},
+		},
+		{Namespace: "", Keys: 1, SampledKeys: 1, TTL: models.TTLDistribution{Longer: 1}},
+	}, report.Namespaces)
+}
+
+func TestNamespaceAnalyzer_MaxNamespaces(t *testing.T) {
+	client := &fakeNamespaceClient{
+		pages:  [][]string{{"a:1", "b:1", "c:1", "a:2"}},
+		usages: map[string]keyUsage{},
+	}
+	analyzer := NewNamespaceAnalyzer(client).WithMaxNamespaces(2)
+	analyzer.roll = func() float64 { return 100 }
+
+	report, err := analyzer.Analyze(context.Background())
+	assert.NoError(t, err)
+	keys := make(map[string]int64)
+	for _, namespace := range report.Namespaces {
+		keys[namespace.Namespace] = namespace.Keys
+	}
+	assert.Equal(t, map[string]int64{"a": 2, "b": 1, OverflowNamespace: 1}, keys)
+}
+
+func TestNamespaceAnalyzer_Validate(t *testing.T) {
+	client := &fakeNamespaceClient{}
+	for _, analyzer := range []*NamespaceAnalyzer{
+		NewNamespaceAnalyzer(client).WithDelimiters(""),
+		NewNamespaceAnalyzer(client).WithDepth(0),
+		NewNamespaceAnalyzer(client).WithSamplePercentage(0),
+		NewNamespaceAnalyzer(client).WithSamplePercentage(101),
+		NewNamespaceAnalyzer(client).WithScanCount(0),
+		NewNamespaceAnalyzer(client).WithMaxNamespaces(0),
+	} {
+		_, err := analyzer.Analyze(context.Background())
+		assert.Error(t, err)
+	}
+}
+
+func TestParseKeyUsages(t *testing.T) {
+	usages, err := parseKeyUsages(
+		[]string{"a", "b", "c"},
+		[]any{int64(64), int64(-1), errors.New("NOPERM"), int64(1500), nil, int64(-2)},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []keyUsage{
+		{exists: true, memory: 64, ttl: -1},
+		{exists: true, memory: -1, ttl: 1500 * time.Millisecond},
+		{},
+	}, usages)
+
+	_, err = parseKeyUsages([]string{"a"}, []any{int64(64), errors.New("NOPERM")})
+	assert.Error(t, err)
+	_, err = parseKeyUsages([]string{"a"}, []any{int64(64)})
+	assert.Error(t, err)
+}
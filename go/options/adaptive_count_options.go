@@ -0,0 +1,95 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultAdaptiveCountLatencyTarget is the latency of the pages of a scan with an adaptive COUNT, unless configured
+	// otherwise.
+	DefaultAdaptiveCountLatencyTarget = 10 * time.Millisecond
+	// DefaultAdaptiveCountMin is the lowest COUNT of a scan with an adaptive COUNT, unless configured otherwise. It's
+	// the COUNT used by the server when none is given.
+	DefaultAdaptiveCountMin = 10
+	// DefaultAdaptiveCountMax is the highest COUNT of a scan with an adaptive COUNT, unless configured otherwise.
+	DefaultAdaptiveCountMax = 10000
+	// DefaultAdaptiveCountMaxPageKeys is the number of keys of the pages of a scan with an adaptive COUNT, unless
+	// configured otherwise.
+	DefaultAdaptiveCountMaxPageKeys = 1000
+)
+
+// Optional arguments of the scans tuning their COUNT hint to the keyspace, e.g. `WithAdaptiveCount` of a ResumableScan.
+//
+// After each page, the COUNT of the next page is halved if the page took longer than LatencyTarget or returned more
+// than MaxPageKeys keys, and doubled if it took less than half of LatencyTarget and returned less than half of
+// MaxPageKeys keys, within MinCount and MaxCount. A sparse match of a large keyspace thus scans with a COUNT growing
+// until pages bring keys without exceeding the latency target, and a dense keyspace with a COUNT keeping pages short.
+type AdaptiveCountOptions struct {
+	// LatencyTarget bounds the time a page takes, from sending SCAN to receiving its reply.
+	LatencyTarget time.Duration
+	// MinCount is the lowest COUNT of the pages.
+	MinCount int64
+	// MaxCount is the highest COUNT of the pages.
+	MaxCount int64
+	// MaxPageKeys bounds the number of keys returned by a page.
+	MaxPageKeys int64
+}
+
+// NewAdaptiveCountOptions returns [AdaptiveCountOptions] with DefaultAdaptiveCountLatencyTarget,
+// DefaultAdaptiveCountMin, DefaultAdaptiveCountMax and DefaultAdaptiveCountMaxPageKeys.
+func NewAdaptiveCountOptions() *AdaptiveCountOptions {
+	return &AdaptiveCountOptions{
+		LatencyTarget: DefaultAdaptiveCountLatencyTarget,
+		MinCount:      DefaultAdaptiveCountMin,
+		MaxCount:      DefaultAdaptiveCountMax,
+		MaxPageKeys:   DefaultAdaptiveCountMaxPageKeys,
+	}
+}
+
+// SetLatencyTarget sets the time a page should take.
+func (options *AdaptiveCountOptions) SetLatencyTarget(latencyTarget time.Duration) *AdaptiveCountOptions {
+	options.LatencyTarget = latencyTarget
+	return options
+}
+
+// SetCountRange sets the lowest and the highest COUNT of the pages.
+func (options *AdaptiveCountOptions) SetCountRange(minCount int64, maxCount int64) *AdaptiveCountOptions {
+	options.MinCount = minCount
+	options.MaxCount = maxCount
+	return options
+}
+
+// SetMaxPageKeys sets the number of keys a page should return at most.
+func (options *AdaptiveCountOptions) SetMaxPageKeys(maxPageKeys int64) *AdaptiveCountOptions {
+	options.MaxPageKeys = maxPageKeys
+	return options
+}
+
+// Validate returns an error if the options are invalid.
+func (options *AdaptiveCountOptions) Validate() error {
+	if options.LatencyTarget <= 0 {
+		return errors.New("the latency target of an adaptive COUNT must be positive")
+	}
+	if options.MinCount <= 0 || options.MaxCount < options.MinCount {
+		return errors.New("the COUNT range of an adaptive COUNT must be positive and not empty")
+	}
+	if options.MaxPageKeys <= 0 {
+		return errors.New("the maximum number of keys of the pages of an adaptive COUNT must be positive")
+	}
+	return nil
+}
+
+// NextCount returns the COUNT of the page following a page scanned with count, which returned keys keys and took
+// latency.
+func (options *AdaptiveCountOptions) NextCount(count int64, keys int64, latency time.Duration) int64 {
+	switch {
+	case latency > options.LatencyTarget || keys > options.MaxPageKeys:
+		count /= 2
+	case 2*latency < options.LatencyTarget && 2*keys < options.MaxPageKeys:
+		count *= 2
+	}
+	return min(max(count, options.MinCount), options.MaxCount)
+}
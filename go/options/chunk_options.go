@@ -0,0 +1,50 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+const (
+	// DefaultChunkMaxBytes is the number of bytes of elements written per request by the chunked writes, unless
+	// configured otherwise.
+	DefaultChunkMaxBytes = 1 << 20
+	// DefaultChunkMaxElements is the number of elements written per request by the chunked writes, unless configured
+	// otherwise.
+	DefaultChunkMaxElements = 10000
+)
+
+// Optional arguments of the chunked writes, e.g. `RPushAllChunked` in [ListCommands], which split large writes into
+// several requests bounded in size, so that a single request doesn't exceed the request size limits or block the
+// server for long.
+type ChunkOptions struct {
+	// MaxBytes bounds the total length of the elements of a request. A single element longer than MaxBytes is sent in a
+	// request of its own.
+	MaxBytes int
+	// MaxElements bounds the number of elements of a request. The fields and the values of a hash count as two
+	// elements.
+	MaxElements int
+	// VerifyLength makes the chunked writes of hashes, e.g. `HSetAllChunked` in [HashCommands], check the length of the
+	// hash once written: the write fails if the hash holds fewer fields than written.
+	VerifyLength bool
+}
+
+// NewChunkOptions returns [ChunkOptions] with DefaultChunkMaxBytes and DefaultChunkMaxElements.
+func NewChunkOptions() *ChunkOptions {
+	return &ChunkOptions{MaxBytes: DefaultChunkMaxBytes, MaxElements: DefaultChunkMaxElements}
+}
+
+// SetMaxBytes sets the total length of the elements of a request.
+func (options *ChunkOptions) SetMaxBytes(maxBytes int) *ChunkOptions {
+	options.MaxBytes = maxBytes
+	return options
+}
+
+// SetMaxElements sets the number of elements of a request.
+func (options *ChunkOptions) SetMaxElements(maxElements int) *ChunkOptions {
+	options.MaxElements = maxElements
+	return options
+}
+
+// SetVerifyLength sets whether the length of a hash is checked once written.
+func (options *ChunkOptions) SetVerifyLength(verifyLength bool) *ChunkOptions {
+	options.VerifyLength = verifyLength
+	return options
+}
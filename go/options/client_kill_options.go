@@ -0,0 +1,124 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"time"
+)
+
+// ClientKillFilter selects the connections killed by KillClients: the connections matching all of its criteria. At
+// least one criterion must be set, not to kill all the connections by mistake.
+//
+// The connections of this library, i.e. of the clients of this process and of the other services using it, are
+// excluded unless SetIncludeLibraryConnections confirms they may be killed. The connection issuing the kills is never
+// killed.
+type ClientKillFilter struct {
+	// Ids are the ids of the connections, as reported by CLIENT LIST.
+	Ids []int64
+	// Type is the type of the connections: "normal", "master", "replica" or "pubsub".
+	Type string
+	// User is the ACL user the connections are authenticated as.
+	User string
+	// Addr is the address of the clients of the connections, as "ip:port".
+	Addr string
+	// LAddr is the address of the server the connections are connected to, as "ip:port".
+	LAddr string
+	// Name is the name of the connections, as set by CLIENT SETNAME.
+	Name string
+	// LibName is the name of the library of the connections, as set by CLIENT SETINFO.
+	LibName string
+	// MinIdle is the time the connections must have been idle for at least.
+	MinIdle time.Duration
+	// MinAge is the time the connections must have been open for at least.
+	MinAge time.Duration
+	// IncludeLibraryConnections confirms that the connections of this library may be killed.
+	IncludeLibraryConnections bool
+}
+
+// NewClientKillFilter returns a [ClientKillFilter] without criteria.
+func NewClientKillFilter() *ClientKillFilter {
+	return &ClientKillFilter{}
+}
+
+// SetIds selects the connections with any of ids.
+func (filter *ClientKillFilter) SetIds(ids ...int64) *ClientKillFilter {
+	filter.Ids = ids
+	return filter
+}
+
+// SetType selects the connections of type connectionType: "normal", "master", "replica" or "pubsub".
+func (filter *ClientKillFilter) SetType(connectionType string) *ClientKillFilter {
+	filter.Type = connectionType
+	return filter
+}
+
+// SetUser selects the connections authenticated as the ACL user user.
+func (filter *ClientKillFilter) SetUser(user string) *ClientKillFilter {
+	filter.User = user
+	return filter
+}
+
+// SetAddr selects the connections of the client at addr, as "ip:port".
+func (filter *ClientKillFilter) SetAddr(addr string) *ClientKillFilter {
+	filter.Addr = addr
+	return filter
+}
+
+// SetLAddr selects the connections to the server at laddr, as "ip:port".
+func (filter *ClientKillFilter) SetLAddr(laddr string) *ClientKillFilter {
+	filter.LAddr = laddr
+	return filter
+}
+
+// SetName selects the connections named name.
+func (filter *ClientKillFilter) SetName(name string) *ClientKillFilter {
+	filter.Name = name
+	return filter
+}
+
+// SetLibName selects the connections of the library libName, e.g. "redis-py".
+func (filter *ClientKillFilter) SetLibName(libName string) *ClientKillFilter {
+	filter.LibName = libName
+	return filter
+}
+
+// SetMinIdle selects the connections idle for at least idle, e.g. leaked connections.
+func (filter *ClientKillFilter) SetMinIdle(idle time.Duration) *ClientKillFilter {
+	filter.MinIdle = idle
+	return filter
+}
+
+// SetMinAge selects the connections open for at least age.
+func (filter *ClientKillFilter) SetMinAge(age time.Duration) *ClientKillFilter {
+	filter.MinAge = age
+	return filter
+}
+
+// SetIncludeLibraryConnections confirms that the connections of this library, including the other connections of the
+// client killing them, may be killed. They are excluded by default.
+func (filter *ClientKillFilter) SetIncludeLibraryConnections(confirm bool) *ClientKillFilter {
+	filter.IncludeLibraryConnections = confirm
+	return filter
+}
+
+// Validate checks that the filter has at least one criterion.
+func (filter *ClientKillFilter) Validate() error {
+	if len(filter.Ids) == 0 && filter.Type == "" && filter.User == "" && filter.Addr == "" && filter.LAddr == "" &&
+		filter.Name == "" && filter.LibName == "" && filter.MinIdle <= 0 && filter.MinAge <= 0 {
+		return errors.New("the filter of the connections to kill must have at least one criterion")
+	}
+	return nil
+}
+
+// ToArgs returns the arguments of the CLIENT LIST command listing the candidate connections. The other criteria are
+// matched against the connections listed.
+func (filter *ClientKillFilter) ToArgs() ([]string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if filter.Type != "" {
+		return []string{"TYPE", filter.Type}, nil
+	}
+	return []string{}, nil
+}
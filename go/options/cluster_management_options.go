@@ -0,0 +1,27 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+// ClusterFailoverMode specifies how a replica takes over its primary with CLUSTER FAILOVER.
+type ClusterFailoverMode string
+
+const (
+	// FailoverForce starts the failover without handshake with the primary, e.g. when the primary is unreachable.
+	// The replica still needs the agreement of the majority of the primaries.
+	FailoverForce ClusterFailoverMode = "FORCE"
+
+	// FailoverTakeover starts the failover without the agreement of the other primaries: the replica increments the
+	// configuration epoch on its own, and becomes primary right away.
+	FailoverTakeover ClusterFailoverMode = "TAKEOVER"
+)
+
+// ClusterResetMode specifies the kind of reset performed by CLUSTER RESET.
+type ClusterResetMode string
+
+const (
+	// ResetSoft forgets the other nodes and the served slots, and turns a replica into an empty primary.
+	ResetSoft ClusterResetMode = "SOFT"
+
+	// ResetHard additionally resets the epochs to zero and assigns a new node ID.
+	ResetHard ClusterResetMode = "HARD"
+)
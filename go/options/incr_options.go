@@ -0,0 +1,79 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import (
+	"errors"
+	"math"
+)
+
+// Optional arguments of `IncrByWithOptions` and `DecrByWithOptions` in [StringCommands].
+type IncrOptions struct {
+	// Clamp keeps the value within Min and Max: a value the increment takes out of the bounds is set to the bound,
+	// and so is a value the increment would overflow, instead of failing.
+	Clamp bool
+	// Min is the lowest value of the key when clamped.
+	Min int64
+	// Max is the highest value of the key when clamped.
+	Max int64
+}
+
+// NewIncrOptions returns [IncrOptions] without clamping.
+func NewIncrOptions() *IncrOptions {
+	return &IncrOptions{Min: math.MinInt64, Max: math.MaxInt64}
+}
+
+// SetClamp keeps the value within minValue and maxValue, e.g. math.MinInt64 and math.MaxInt64 to saturate the value
+// instead of failing when it would overflow.
+func (options *IncrOptions) SetClamp(minValue int64, maxValue int64) *IncrOptions {
+	options.Clamp = true
+	options.Min = minValue
+	options.Max = maxValue
+	return options
+}
+
+// Validate returns an error if the options are invalid.
+func (options *IncrOptions) Validate() error {
+	if options.Clamp && options.Min > options.Max {
+		return errors.New("the lowest value of a clamped increment must not exceed its highest value")
+	}
+	return nil
+}
+
+// Optional arguments of `IncrByFloatWithOptions` in [StringCommands].
+type IncrByFloatOptions struct {
+	// Clamp keeps the value within Min and Max: a value the increment takes out of the bounds is set to the bound,
+	// and so is a value the increment would make infinite, instead of failing.
+	Clamp bool
+	// Min is the lowest value of the key when clamped.
+	Min float64
+	// Max is the highest value of the key when clamped.
+	Max float64
+}
+
+// NewIncrByFloatOptions returns [IncrByFloatOptions] without clamping.
+func NewIncrByFloatOptions() *IncrByFloatOptions {
+	return &IncrByFloatOptions{Min: -math.MaxFloat64, Max: math.MaxFloat64}
+}
+
+// SetClamp keeps the value within minValue and maxValue.
+func (options *IncrByFloatOptions) SetClamp(minValue float64, maxValue float64) *IncrByFloatOptions {
+	options.Clamp = true
+	options.Min = minValue
+	options.Max = maxValue
+	return options
+}
+
+// Validate returns an error if the options are invalid.
+func (options *IncrByFloatOptions) Validate() error {
+	if !options.Clamp {
+		return nil
+	}
+	if math.IsNaN(options.Min) || math.IsNaN(options.Max) || math.IsInf(options.Min, 0) || math.IsInf(options.Max, 0) {
+		return errors.New("the bounds of a clamped increment must be finite")
+	}
+	if options.Min > options.Max {
+		return errors.New("the lowest value of a clamped increment must not exceed its highest value")
+	}
+	return nil
+}
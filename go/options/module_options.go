@@ -0,0 +1,53 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+const (
+	ModuleLoadExConfigKeyword = "CONFIG"
+	ModuleLoadExArgsKeyword   = "ARGS"
+)
+
+// moduleConfig is a configuration parameter of a module set by MODULE LOADEX.
+type moduleConfig struct {
+	name  string
+	value string
+}
+
+// Optional arguments to `ModuleLoadEx`: the configuration parameters of the module, and the arguments passed to it.
+type ModuleLoadExOptions struct {
+	configs []moduleConfig
+	args    []string
+}
+
+// NewModuleLoadExOptions creates a new ModuleLoadExOptions.
+func NewModuleLoadExOptions() *ModuleLoadExOptions {
+	return &ModuleLoadExOptions{}
+}
+
+// SetConfig sets the configuration parameter name of the module to value when it's loaded. May be called multiple
+// times, the parameters being set in order.
+func (options *ModuleLoadExOptions) SetConfig(name string, value string) *ModuleLoadExOptions {
+	options.configs = append(options.configs, moduleConfig{name: name, value: value})
+	return options
+}
+
+// SetArgs sets the arguments passed to the module when it's loaded.
+func (options *ModuleLoadExOptions) SetArgs(args ...string) *ModuleLoadExOptions {
+	options.args = args
+	return options
+}
+
+func (options *ModuleLoadExOptions) ToArgs() ([]string, error) {
+	args := []string{}
+	if options == nil {
+		return args, nil
+	}
+	for _, config := range options.configs {
+		args = append(args, ModuleLoadExConfigKeyword, config.name, config.value)
+	}
+	if len(options.args) > 0 {
+		args = append(args, ModuleLoadExArgsKeyword)
+		args = append(args, options.args...)
+	}
+	return args, nil
+}
@@ -0,0 +1,32 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+// BgSaveMode modifies the behavior of BGSAVE.
+type BgSaveMode string
+
+const (
+	// BgSaveSchedule schedules the save to run once the ongoing AOF rewrite, if any, is over, instead of failing.
+	BgSaveSchedule BgSaveMode = "SCHEDULE"
+
+	// BgSaveCancel cancels the ongoing or scheduled save. Since Valkey 8.1 and above.
+	BgSaveCancel BgSaveMode = "CANCEL"
+)
+
+// ClusterBgSaveOptions provides optional arguments for BgSaveWithOptions for cluster client.
+type ClusterBgSaveOptions struct {
+	// Mode modifies the behavior of BGSAVE, if set.
+	Mode BgSaveMode
+	// Specifies the routing configuration for the command.
+	// The client will route the command to the nodes defined by Route.
+	// The command will be routed to all primary nodes, unless Route is provided.
+	*RouteOption
+}
+
+// ToArgs converts the options to argument strings.
+func (opts *ClusterBgSaveOptions) ToArgs() []string {
+	if opts == nil || opts.Mode == "" {
+		return []string{}
+	}
+	return []string{string(opts.Mode)}
+}
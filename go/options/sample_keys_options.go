@@ -0,0 +1,73 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import "errors"
+
+// DefaultSampleKeysScanCount is the COUNT of the scans of `SampleKeys` sampling with [SampleWithScan], unless
+// configured otherwise.
+const DefaultSampleKeysScanCount = 1000
+
+// SampleKeysMethod is how `SampleKeys` draws its keys.
+type SampleKeysMethod int
+
+const (
+	// SampleWithRandomKey draws the keys with RANDOMKEY, spread over the primaries of a cluster in proportion to
+	// their DBSIZE. It costs a request per key, but its sample is only approximately uniform: RANDOMKEY favors the
+	// keys of sparse hash table buckets, and the keys drawn more than once reduce the sample.
+	SampleWithRandomKey SampleKeysMethod = iota
+	// SampleWithScan scans the whole keyspace with SCAN, and keeps a uniform sample of the keys scanned. It costs a
+	// full scan, but its sample is uniform, and may be narrowed to the keys matching a pattern.
+	SampleWithScan
+)
+
+// Optional arguments to `SampleKeys` in [GenericBaseCommands], which draws random keys, e.g. for cache-quality
+// monitoring jobs.
+type SampleKeysOptions struct {
+	// Method is how the keys are drawn.
+	Method SampleKeysMethod
+	// Match is the pattern the keys sampled with [SampleWithScan] match, or empty to sample all the keys.
+	Match string
+	// ScanCount is the COUNT of the scans of [SampleWithScan], the number of keys read per page.
+	ScanCount int64
+}
+
+// NewSampleKeysOptions returns [SampleKeysOptions] sampling all the keys with [SampleWithRandomKey].
+func NewSampleKeysOptions() *SampleKeysOptions {
+	return &SampleKeysOptions{Method: SampleWithRandomKey, ScanCount: DefaultSampleKeysScanCount}
+}
+
+// SetMethod sets how the keys are drawn.
+func (options *SampleKeysOptions) SetMethod(method SampleKeysMethod) *SampleKeysOptions {
+	options.Method = method
+	return options
+}
+
+// SetMatch narrows the sample to the keys matching pattern. It requires [SampleWithScan].
+func (options *SampleKeysOptions) SetMatch(pattern string) *SampleKeysOptions {
+	options.Match = pattern
+	return options
+}
+
+// SetScanCount sets the COUNT of the scans of [SampleWithScan].
+func (options *SampleKeysOptions) SetScanCount(count int64) *SampleKeysOptions {
+	options.ScanCount = count
+	return options
+}
+
+// Validate returns an error if the options are invalid.
+func (options *SampleKeysOptions) Validate() error {
+	switch options.Method {
+	case SampleWithRandomKey:
+		if options.Match != "" {
+			return errors.New("sampling the keys matching a pattern requires SampleWithScan")
+		}
+	case SampleWithScan:
+		if options.ScanCount <= 0 {
+			return errors.New("the scan count of a key sample must be positive")
+		}
+	default:
+		return errors.New("unknown key sampling method")
+	}
+	return nil
+}
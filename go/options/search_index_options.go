@@ -0,0 +1,50 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SearchIndexDefinition is the definition of a search index, as created by FT.CREATE: the type of the indexed keys,
+// their prefixes, and the schema of the indexed fields.
+type SearchIndexDefinition struct {
+	OnJson   bool
+	Prefixes []string
+	Schema   []string
+}
+
+// NewSearchIndexDefinition returns a [SearchIndexDefinition] of an index of hashes with schema, the arguments
+// following SCHEMA in FT.CREATE, e.g. "title", "TEXT", "price", "NUMERIC".
+func NewSearchIndexDefinition(schema ...string) *SearchIndexDefinition {
+	return &SearchIndexDefinition{Schema: schema}
+}
+
+// SetOnJson sets whether the index indexes JSON documents rather than hashes.
+func (definition *SearchIndexDefinition) SetOnJson(onJson bool) *SearchIndexDefinition {
+	definition.OnJson = onJson
+	return definition
+}
+
+// SetPrefixes sets the prefixes of the indexed keys. All the keys are indexed by default.
+func (definition *SearchIndexDefinition) SetPrefixes(prefixes ...string) *SearchIndexDefinition {
+	definition.Prefixes = prefixes
+	return definition
+}
+
+func (definition *SearchIndexDefinition) ToArgs() ([]string, error) {
+	if len(definition.Schema) == 0 {
+		return nil, fmt.Errorf("the schema of a search index must have at least one field")
+	}
+	args := []string{"ON", "HASH"}
+	if definition.OnJson {
+		args[1] = "JSON"
+	}
+	if len(definition.Prefixes) > 0 {
+		args = append(args, "PREFIX", strconv.Itoa(len(definition.Prefixes)))
+		args = append(args, definition.Prefixes...)
+	}
+	args = append(args, "SCHEMA")
+	return append(args, definition.Schema...), nil
+}
@@ -0,0 +1,75 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import "strconv"
+
+// SearchOptions are the optional arguments of the FT.SEARCH queries of SearchDocuments.
+type SearchOptions struct {
+	ScoreField string
+	// Offset and Count are sent with LIMIT if either is set, or if set with SetLimit. FT.SEARCH reads the first 10
+	// documents otherwise.
+	Offset          int64
+	Count           int64
+	Return          []string
+	HighlightFields []string
+	HighlightOpen   string
+	HighlightClose  string
+	// limit is set by SetLimit, so that LIMIT 0 0 is sent to only count the documents.
+	limit bool
+}
+
+// NewSearchOptions returns [SearchOptions] reading the first 10 documents.
+func NewSearchOptions() *SearchOptions {
+	return &SearchOptions{Count: 10}
+}
+
+// SetScoreField sets the field holding the scores of the documents, e.g. "score" for the vector queries ending with
+// "=>[KNN 10 @embedding $vector AS score]". Defaults to the field named like "__embedding_score", as the
+// scores of the vector queries are named by default. The field isn't an argument of FT.SEARCH.
+func (searchOptions *SearchOptions) SetScoreField(field string) *SearchOptions {
+	searchOptions.ScoreField = field
+	return searchOptions
+}
+
+// SetLimit sets the offset of the first document read, and the number of documents read. With a count of 0, the
+// documents are only counted.
+func (searchOptions *SearchOptions) SetLimit(offset int64, count int64) *SearchOptions {
+	searchOptions.Offset, searchOptions.Count, searchOptions.limit = offset, count, true
+	return searchOptions
+}
+
+// SetReturn sets the fields of the documents read. All the fields are read by default.
+func (searchOptions *SearchOptions) SetReturn(fields ...string) *SearchOptions {
+	searchOptions.Return = fields
+	return searchOptions
+}
+
+// SetHighlight sets the fields whose matched terms are highlighted, between openTag and closeTag, e.g. "<b>" and
+// "</b>". All the fields are highlighted if fields is empty.
+func (searchOptions *SearchOptions) SetHighlight(openTag string, closeTag string, fields ...string) *SearchOptions {
+	searchOptions.HighlightOpen, searchOptions.HighlightClose = openTag, closeTag
+	searchOptions.HighlightFields = append([]string{}, fields...)
+	return searchOptions
+}
+
+func (searchOptions *SearchOptions) ToArgs() ([]string, error) {
+	args := []string{}
+	if len(searchOptions.Return) > 0 {
+		args = append(args, "RETURN", strconv.Itoa(len(searchOptions.Return)))
+		args = append(args, searchOptions.Return...)
+	}
+	if searchOptions.HighlightFields != nil {
+		args = append(args, "HIGHLIGHT")
+		if len(searchOptions.HighlightFields) > 0 {
+			args = append(args, "FIELDS", strconv.Itoa(len(searchOptions.HighlightFields)))
+			args = append(args, searchOptions.HighlightFields...)
+		}
+		args = append(args, "TAGS", searchOptions.HighlightOpen, searchOptions.HighlightClose)
+	}
+	if searchOptions.limit || searchOptions.Offset != 0 || searchOptions.Count != 0 {
+		args = append(args, "LIMIT", strconv.FormatInt(searchOptions.Offset, 10),
+			strconv.FormatInt(searchOptions.Count, 10))
+	}
+	return args, nil
+}
@@ -0,0 +1,293 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+)
+
+// SearchQuery is a filter of a search query, rendered in the query syntax of FT.SEARCH by String. The filters are
+// built with TextQuery, TagQuery, NumericRangeQuery and GeoRadiusQuery, and composed with And, Or and Not. The field
+// names, terms and tags are escaped, so that values from the users of a service can't change the structure of the
+// query.
+//
+// Example usage:
+//
+//	query := options.And(
+//		options.TextQuery("title", "wireless headphones").SetSlop(1),
+//		options.TagQuery("brand", "sony", "bose"),
+//		options.NumericRangeQuery("price", 50, 100).SetExclusiveMax(),
+//		options.Not(options.TagQuery("status", "discontinued")),
+//	)
+//	// (@title:(wireless headphones) => { $slop: 1; }) @brand:{sony | bose} @price:[50 (100] -@status:{discontinued}
+//	query.String()
+type SearchQuery interface {
+	// String renders the query in the query syntax of FT.SEARCH.
+	String() string
+	// compound reports whether the query must be parenthesized when it's an operand of And or Not.
+	compound() bool
+}
+
+// andQuery matches the documents matching all its queries.
+type andQuery struct {
+	queries []SearchQuery
+}
+
+// And returns a [SearchQuery] matching the documents matching all of queries, or all the documents if there are none.
+func And(queries ...SearchQuery) SearchQuery {
+	return &andQuery{queries: queries}
+}
+
+func (query *andQuery) String() string {
+	if len(query.queries) == 0 {
+		return "*"
+	}
+	if len(query.queries) == 1 {
+		return query.queries[0].String()
+	}
+	operands := make([]string, len(query.queries))
+	for i, operand := range query.queries {
+		operands[i] = parenthesize(operand)
+	}
+	return strings.Join(operands, " ")
+}
+
+func (query *andQuery) compound() bool {
+	return len(query.queries) > 1 || len(query.queries) == 1 && query.queries[0].compound()
+}
+
+// orQuery matches the documents matching any of its queries.
+type orQuery struct {
+	queries []SearchQuery
+}
+
+// Or returns a [SearchQuery] matching the documents matching any of queries, of which there must be at least one.
+func Or(queries ...SearchQuery) SearchQuery {
+	return &orQuery{queries: queries}
+}
+
+func (query *orQuery) String() string {
+	if len(query.queries) == 1 {
+		return query.queries[0].String()
+	}
+	operands := make([]string, len(query.queries))
+	for i, operand := range query.queries {
+		operands[i] = parenthesize(operand)
+	}
+	return "(" + strings.Join(operands, " | ") + ")"
+}
+
+func (query *orQuery) compound() bool {
+	return len(query.queries) == 1 && query.queries[0].compound()
+}
+
+// notQuery matches the documents not matching its query.
+type notQuery struct {
+	query SearchQuery
+}
+
+// Not returns a [SearchQuery] matching the documents not matching query.
+func Not(query SearchQuery) SearchQuery {
+	return &notQuery{query: query}
+}
+
+func (query *notQuery) String() string {
+	return "-" + parenthesize(query.query)
+}
+
+func (query *notQuery) compound() bool {
+	return false
+}
+
+// parenthesize renders query as an operand.
+func parenthesize(query SearchQuery) string {
+	if query.compound() {
+		return "(" + query.String() + ")"
+	}
+	return query.String()
+}
+
+// TextSearchQuery matches the documents whose text field contains terms.
+type TextSearchQuery struct {
+	field   string
+	terms   []string
+	slop    int
+	inOrder bool
+}
+
+// TextQuery returns a [TextSearchQuery] matching the documents whose text field field contains all the words of
+// text, or whose text fields contain them if field is "".
+func TextQuery(field string, text string) *TextSearchQuery {
+	return &TextSearchQuery{field: field, terms: strings.Fields(text), slop: -1}
+}
+
+// SetSlop sets the number of terms allowed between the words of the text. The words may be arbitrarily far apart by
+// default.
+func (query *TextSearchQuery) SetSlop(slop int) *TextSearchQuery {
+	query.slop = slop
+	return query
+}
+
+// SetInOrder sets that the words of the text must appear in the order of the text.
+func (query *TextSearchQuery) SetInOrder() *TextSearchQuery {
+	query.inOrder = true
+	return query
+}
+
+func (query *TextSearchQuery) String() string {
+	terms := make([]string, len(query.terms))
+	for i, term := range query.terms {
+		terms[i] = escapeSearchTerm(term, false)
+	}
+	rendered := strings.Join(terms, " ")
+	if len(terms) != 1 {
+		rendered = "(" + rendered + ")"
+	}
+	if query.field != "" {
+		rendered = fieldPrefix(query.field) + rendered
+	}
+	if query.slop < 0 && !query.inOrder {
+		return rendered
+	}
+	rendered += " => { "
+	if query.slop >= 0 {
+		rendered += "$slop: " + strconv.Itoa(query.slop) + "; "
+	}
+	if query.inOrder {
+		rendered += "$inorder: true; "
+	}
+	return rendered + "}"
+}
+
+func (query *TextSearchQuery) compound() bool {
+	return query.slop >= 0 || query.inOrder
+}
+
+// tagQuery matches the documents whose tag field has any of its tags.
+type tagQuery struct {
+	field string
+	tags  []string
+}
+
+// TagQuery returns a [SearchQuery] matching the documents whose tag field field has any of tags.
+func TagQuery(field string, tags ...string) SearchQuery {
+	return &tagQuery{field: field, tags: tags}
+}
+
+func (query *tagQuery) String() string {
+	tags := make([]string, len(query.tags))
+	for i, tag := range query.tags {
+		tags[i] = escapeSearchTerm(tag, true)
+	}
+	return fieldPrefix(query.field) + "{" + strings.Join(tags, " | ") + "}"
+}
+
+func (query *tagQuery) compound() bool {
+	return false
+}
+
+// NumericSearchQuery matches the documents whose numeric field is in a range.
+type NumericSearchQuery struct {
+	field        string
+	min, max     float64
+	exclusiveMin bool
+	exclusiveMax bool
+}
+
+// NumericRangeQuery returns a [NumericSearchQuery] matching the documents whose numeric field field is between min and
+// max, inclusive. min and max may be infinite, e.g. math.Inf(1) for the documents greater than min.
+func NumericRangeQuery(field string, min float64, max float64) *NumericSearchQuery {
+	return &NumericSearchQuery{field: field, min: min, max: max}
+}
+
+// SetExclusiveMin excludes min from the range.
+func (query *NumericSearchQuery) SetExclusiveMin() *NumericSearchQuery {
+	query.exclusiveMin = true
+	return query
+}
+
+// SetExclusiveMax excludes max from the range.
+func (query *NumericSearchQuery) SetExclusiveMax() *NumericSearchQuery {
+	query.exclusiveMax = true
+	return query
+}
+
+func (query *NumericSearchQuery) String() string {
+	return fieldPrefix(query.field) + "[" + numericBound(query.min, query.exclusiveMin) + " " +
+		numericBound(query.max, query.exclusiveMax) + "]"
+}
+
+func (query *NumericSearchQuery) compound() bool {
+	return false
+}
+
+// numericBound renders a bound of a numeric range.
+func numericBound(bound float64, exclusive bool) string {
+	var rendered string
+	switch {
+	case math.IsInf(bound, 1):
+		return "+inf"
+	case math.IsInf(bound, -1):
+		return "-inf"
+	default:
+		rendered = strconv.FormatFloat(bound, 'f', -1, 64)
+	}
+	if exclusive {
+		return "(" + rendered
+	}
+	return rendered
+}
+
+// geoQuery matches the documents whose geo field is within a radius of a point.
+type geoQuery struct {
+	field     string
+	longitude float64
+	latitude  float64
+	radius    float64
+	unit      constants.GeoUnit
+}
+
+// GeoRadiusQuery returns a [SearchQuery] matching the documents whose geo field field is within radius, in unit, of
+// the point at longitude and latitude.
+func GeoRadiusQuery(
+	field string,
+	longitude float64,
+	latitude float64,
+	radius float64,
+	unit constants.GeoUnit,
+) SearchQuery {
+	return &geoQuery{field: field, longitude: longitude, latitude: latitude, radius: radius, unit: unit}
+}
+
+func (query *geoQuery) String() string {
+	format := func(value float64) string { return strconv.FormatFloat(value, 'f', -1, 64) }
+	return fieldPrefix(query.field) + "[" + format(query.longitude) + " " + format(query.latitude) + " " +
+		format(query.radius) + " " + string(query.unit) + "]"
+}
+
+func (query *geoQuery) compound() bool {
+	return false
+}
+
+// fieldPrefix renders the prefix of a query restricted to field.
+func fieldPrefix(field string) string {
+	return "@" + escapeSearchTerm(field, false) + ":"
+}
+
+// escapeSearchTerm escapes the characters of a term, a field name or a tag which have a meaning in the query syntax,
+// i.e. all but letters, digits and underscores. The spaces are only escaped in tags, the terms being split on spaces.
+func escapeSearchTerm(term string, tag bool) string {
+	var escaped strings.Builder
+	for _, c := range term {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && (c != ' ' || tag) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(c)
+	}
+	return escaped.String()
+}
@@ -0,0 +1,82 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import "errors"
+
+// ShutdownSaveMode overrides whether the server saves the dataset before shutting down.
+type ShutdownSaveMode string
+
+const (
+	// ShutdownNoSave shuts down without saving the dataset, even if save points are configured.
+	ShutdownNoSave ShutdownSaveMode = "NOSAVE"
+
+	// ShutdownSave saves the dataset before shutting down, even if no save points are configured.
+	ShutdownSave ShutdownSaveMode = "SAVE"
+)
+
+// ShutdownOptions represents the optional arguments of SHUTDOWN.
+type ShutdownOptions struct {
+	SaveMode ShutdownSaveMode
+	// Now skips waiting for the lagging replicas to catch up.
+	Now bool
+	// Force ignores the errors which would prevent the server from shutting down, e.g. a failure to save the
+	// dataset.
+	Force bool
+	// Abort cancels an ongoing shutdown waiting for the replicas. It can't be combined with the other options.
+	Abort bool
+}
+
+// NewShutdownOptions creates empty SHUTDOWN options, shutting down with the save points of the server.
+func NewShutdownOptions() *ShutdownOptions {
+	return &ShutdownOptions{}
+}
+
+// SetSaveMode sets whether the server saves the dataset before shutting down, overriding its save points.
+func (opts *ShutdownOptions) SetSaveMode(saveMode ShutdownSaveMode) *ShutdownOptions {
+	opts.SaveMode = saveMode
+	return opts
+}
+
+// SetNow skips waiting for the lagging replicas to catch up. Since Valkey 7.0 and above.
+func (opts *ShutdownOptions) SetNow() *ShutdownOptions {
+	opts.Now = true
+	return opts
+}
+
+// SetForce ignores the errors which would prevent the server from shutting down. Since Valkey 7.0 and above.
+func (opts *ShutdownOptions) SetForce() *ShutdownOptions {
+	opts.Force = true
+	return opts
+}
+
+// SetAbort cancels an ongoing shutdown instead of shutting down. Since Valkey 7.0 and above.
+func (opts *ShutdownOptions) SetAbort() *ShutdownOptions {
+	opts.Abort = true
+	return opts
+}
+
+// ToArgs converts the options to the arguments of SHUTDOWN.
+func (opts *ShutdownOptions) ToArgs() ([]string, error) {
+	args := []string{}
+	if opts.Abort {
+		if opts.SaveMode != "" || opts.Now || opts.Force {
+			return nil, errors.New("ABORT can't be combined with the other SHUTDOWN options")
+		}
+		return append(args, "ABORT"), nil
+	}
+	switch opts.SaveMode {
+	case "":
+	case ShutdownNoSave, ShutdownSave:
+		args = append(args, string(opts.SaveMode))
+	default:
+		return nil, errors.New("invalid SHUTDOWN save mode: " + string(opts.SaveMode))
+	}
+	if opts.Now {
+		args = append(args, "NOW")
+	}
+	if opts.Force {
+		args = append(args, "FORCE")
+	}
+	return args, nil
+}
@@ -0,0 +1,107 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+type traceContextKeyType struct{}
+
+// TraceContextKey is the context key used to store the [Trace] of requests.
+// This key is used by WithTrace(), WithTraceHandler() and TraceFromContext() functions.
+var TraceContextKey = traceContextKeyType{}
+
+// Trace reports the requests executed with the context of a traced call to its handler, see [WithTraceHandler].
+type Trace struct {
+	handler  func(trace models.RequestTrace)
+	requests atomic.Int32
+}
+
+// WithTrace returns a copy of ctx tracing the requests executed with it: their commands, as sent, their response or
+// error, and their timings are logged with the standard logger, for debugging a single call in production without
+// raising the verbosity of all the requests.
+//
+// The traces hold the arguments of the commands and their responses, which may be sensitive. The passwords sent with
+// the commands are redacted, see [models.TracedCommand], and the arguments and responses longer than
+// [MaxLoggedTraceLength] bytes are truncated in the logs.
+//
+// Example usage:
+//
+//	value, err := client.Get(options.WithTrace(ctx), "session:1")
+func WithTrace(ctx context.Context) context.Context {
+	return WithTraceHandler(ctx, LogRequestTrace)
+}
+
+// WithTraceHandler returns a copy of ctx tracing the requests executed with it, reporting them to handler once their
+// response arrives or they fail. The handler is called by the goroutine which issued the request, before the response
+// is returned.
+func WithTraceHandler(ctx context.Context, handler func(trace models.RequestTrace)) context.Context {
+	return context.WithValue(ctx, TraceContextKey, &Trace{handler: handler})
+}
+
+// TraceFromContext returns the [Trace] stored in ctx using [WithTrace] or [WithTraceHandler], or false if there is
+// none.
+func TraceFromContext(ctx context.Context) (*Trace, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	trace, ok := ctx.Value(TraceContextKey).(*Trace)
+	return trace, ok && trace != nil
+}
+
+// Record numbers requestTrace among the requests of the trace, and reports it to the handler of the trace.
+func (trace *Trace) Record(requestTrace models.RequestTrace) {
+	requestTrace.Sequence = int(trace.requests.Add(1))
+	trace.handler(requestTrace)
+}
+
+// MaxLoggedTraceLength is the length in bytes beyond which [LogRequestTrace] truncates an argument or a response.
+const MaxLoggedTraceLength = 256
+
+// LogRequestTrace logs trace with the standard logger, one line per command, the handler of [WithTrace]. The
+// arguments and responses longer than [MaxLoggedTraceLength] bytes are truncated.
+func LogRequestTrace(trace models.RequestTrace) {
+	node := trace.Node
+	if node == "" {
+		node = "the routed node"
+	}
+	responses, _ := trace.Response.([]any)
+	for i, command := range trace.Commands {
+		request := strings.TrimSpace(command.Name + " " + quoteArgs(command.Args))
+		switch {
+		case trace.Err != nil:
+			log.Printf("glide trace: request %d: %s on %s failed after %v: %v",
+				trace.Sequence, request, node, trace.Duration, trace.Err)
+		case trace.Batch && i < len(responses):
+			log.Printf("glide trace: request %d: %s on %s took %v: %s",
+				trace.Sequence, request, node, trace.Duration, truncate(fmt.Sprintf("%#v", responses[i])))
+		default:
+			log.Printf("glide trace: request %d: %s on %s took %v: %s",
+				trace.Sequence, request, node, trace.Duration, truncate(fmt.Sprintf("%#v", trace.Response)))
+		}
+	}
+}
+
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = truncate(strconv.Quote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// truncate returns value, cut to MaxLoggedTraceLength bytes if it's longer, followed by its length.
+func truncate(value string) string {
+	if len(value) <= MaxLoggedTraceLength {
+		return value
+	}
+	return fmt.Sprintf("%s... (%d bytes)", value[:MaxLoggedTraceLength], len(value))
+}
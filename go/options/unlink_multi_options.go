@@ -0,0 +1,33 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package options
+
+// DefaultUnlinkChunkSize is the number of keys deleted per UNLINK by `UnlinkMulti`, unless configured otherwise.
+const DefaultUnlinkChunkSize = 1000
+
+// Optional arguments to `UnlinkMulti` in [GenericBaseCommands], which deletes many keys in chunks of UNLINKs, e.g. for
+// mass invalidations in production.
+type UnlinkMultiOptions struct {
+	// ChunkSize is the number of keys deleted per UNLINK.
+	ChunkSize int
+	// KeysPerSecond bounds the rate of the deletions, or is 0 if they aren't rate limited.
+	KeysPerSecond float64
+}
+
+// NewUnlinkMultiOptions returns [UnlinkMultiOptions] with DefaultUnlinkChunkSize, without rate limit.
+func NewUnlinkMultiOptions() *UnlinkMultiOptions {
+	return &UnlinkMultiOptions{ChunkSize: DefaultUnlinkChunkSize}
+}
+
+// SetChunkSize sets the number of keys deleted per UNLINK.
+func (options *UnlinkMultiOptions) SetChunkSize(chunkSize int) *UnlinkMultiOptions {
+	options.ChunkSize = chunkSize
+	return options
+}
+
+// SetKeysPerSecond rate limits the deletions to keysPerSecond keys per second: the chunks are sent no faster than
+// this rate allows.
+func (options *UnlinkMultiOptions) SetKeysPerSecond(keysPerSecond float64) *UnlinkMultiOptions {
+	options.KeysPerSecond = keysPerSecond
+	return options
+}
@@ -0,0 +1,308 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// Saves the dataset to disk in the background, the server forking a child process writing the RDB file. Use
+// PersistenceInfo to wait for the save to complete.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	"Background saving started" when the save was started, otherwise an error is thrown, e.g. if a save or an AOF
+//	rewrite is ongoing.
+//
+// [valkey.io]: https://valkey.io/commands/bgsave/
+func (client *Client) BgSave(ctx context.Context) (string, error) {
+	response, err := client.executeCommand(ctx, C.BgSave, []string{})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleStringResponse(response)
+}
+
+// Saves the dataset to disk in the background, scheduling the save after the ongoing AOF rewrite, or cancels the
+// ongoing or scheduled save, depending on mode.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	mode - The mode of BGSAVE, [options.BgSaveSchedule] or [options.BgSaveCancel].
+//
+// Return value:
+//
+//	The status of the save, e.g. "Background saving scheduled".
+//
+// [valkey.io]: https://valkey.io/commands/bgsave/
+func (client *Client) BgSaveWithOptions(ctx context.Context, mode options.BgSaveMode) (string, error) {
+	response, err := client.executeCommand(ctx, C.BgSave, []string{string(mode)})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleStringResponse(response)
+}
+
+// Rewrites the append-only file in the background, the server forking a child process writing the rewritten file. Use
+// PersistenceInfo to wait for the rewrite to complete.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The status of the rewrite, e.g. "Background append only file rewriting started".
+//
+// [valkey.io]: https://valkey.io/commands/bgrewriteaof/
+func (client *Client) BgRewriteAof(ctx context.Context) (string, error) {
+	response, err := client.executeCommand(ctx, C.BgRewriteAof, []string{})
+	if err != nil {
+		return models.DefaultStringResponse, err
+	}
+	return handleStringResponse(response)
+}
+
+// PersistenceInfo reports the progress of the persistence of the server, from the persistence section of its INFO.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The persistence information of the server.
+//
+// [valkey.io]: https://valkey.io/commands/info/
+func (client *Client) PersistenceInfo(ctx context.Context) (models.PersistenceInfo, error) {
+	info, err := client.InfoWithOptions(
+		ctx,
+		options.InfoOptions{Sections: []constants.Section{constants.Persistence}},
+	)
+	if err != nil {
+		return models.PersistenceInfo{}, err
+	}
+	return parsePersistenceInfo("", info)
+}
+
+// Saves the dataset of the nodes to disk in the background, each node forking a child process writing its RDB file.
+// The command will be routed to all primary nodes.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The status of the save by node address, e.g. "Background saving started".
+//
+// [valkey.io]: https://valkey.io/commands/bgsave/
+func (client *ClusterClient) BgSave(ctx context.Context) (models.ClusterValue[string], error) {
+	return client.BgSaveWithOptions(ctx, options.ClusterBgSaveOptions{})
+}
+
+// Saves the dataset of the nodes to disk in the background, or cancels their ongoing or scheduled save, depending on
+// the mode of bgSaveOptions. The command will be routed to all primary nodes, unless a route is provided.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	bgSaveOptions - The mode of BGSAVE and the route of the command.
+//
+// Return value:
+//
+//	The status of the save, by node address for multi-node routes.
+//
+// [valkey.io]: https://valkey.io/commands/bgsave/
+func (client *ClusterClient) BgSaveWithOptions(
+	ctx context.Context,
+	bgSaveOptions options.ClusterBgSaveOptions,
+) (models.ClusterValue[string], error) {
+	route := options.RouteOption{Route: config.AllPrimaries}
+	if bgSaveOptions.RouteOption != nil && bgSaveOptions.RouteOption.Route != nil {
+		route = *bgSaveOptions.RouteOption
+	}
+	return client.persistenceCommand(ctx, C.BgSave, bgSaveOptions.ToArgs(), route)
+}
+
+// Rewrites the append-only file of the nodes in the background. The command will be routed to all primary nodes.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The status of the rewrite by node address, e.g. "Background append only file rewriting started".
+//
+// [valkey.io]: https://valkey.io/commands/bgrewriteaof/
+func (client *ClusterClient) BgRewriteAof(ctx context.Context) (models.ClusterValue[string], error) {
+	return client.persistenceCommand(ctx, C.BgRewriteAof, []string{}, options.RouteOption{Route: config.AllPrimaries})
+}
+
+// Rewrites the append-only file of the nodes of route in the background.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	The status of the rewrite, by node address for multi-node routes.
+//
+// [valkey.io]: https://valkey.io/commands/bgrewriteaof/
+func (client *ClusterClient) BgRewriteAofWithOptions(
+	ctx context.Context,
+	route options.RouteOption,
+) (models.ClusterValue[string], error) {
+	return client.persistenceCommand(ctx, C.BgRewriteAof, []string{}, route)
+}
+
+func (client *ClusterClient) persistenceCommand(
+	ctx context.Context,
+	requestType C.RequestType,
+	args []string,
+	route options.RouteOption,
+) (models.ClusterValue[string], error) {
+	response, err := client.executeCommandWithRoute(ctx, requestType, args, route.Route)
+	if err != nil {
+		return models.CreateEmptyClusterValue[string](), err
+	}
+	if route.Route != nil && route.Route.IsMultiNode() {
+		data, err := handleStringToStringMapResponse(response)
+		if err != nil {
+			return models.CreateEmptyClusterValue[string](), err
+		}
+		return models.CreateClusterMultiValue[string](data), nil
+	}
+	data, err := handleStringResponse(response)
+	if err != nil {
+		return models.CreateEmptyClusterValue[string](), err
+	}
+	return models.CreateClusterSingleValue[string](data), nil
+}
+
+// PersistenceInfo reports the progress of the persistence of the nodes of route, from the persistence section of
+// their INFO. The command will be routed to all primary nodes, unless a route is provided.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	route - Specifies the routing configuration for the command. The client will route the
+//	        command to the nodes defined by route.
+//
+// Return value:
+//
+//	The persistence information of each node, ordered by address. The node of single-node routes is unnamed.
+//
+// [valkey.io]: https://valkey.io/commands/info/
+func (client *ClusterClient) PersistenceInfo(
+	ctx context.Context,
+	route options.RouteOption,
+) ([]models.PersistenceInfo, error) {
+	if route.Route == nil {
+		route.Route = config.AllPrimaries
+	}
+	infos, err := client.InfoWithOptions(ctx, options.ClusterInfoOptions{
+		InfoOptions: &options.InfoOptions{Sections: []constants.Section{constants.Persistence}},
+		RouteOption: &route,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !infos.IsMultiValue() {
+		info, err := parsePersistenceInfo("", infos.SingleValue())
+		if err != nil {
+			return nil, err
+		}
+		return []models.PersistenceInfo{info}, nil
+	}
+	persistence := make([]models.PersistenceInfo, 0, len(infos.MultiValue()))
+	for node, info := range infos.MultiValue() {
+		nodeInfo, err := parsePersistenceInfo(node, info)
+		if err != nil {
+			return nil, fmt.Errorf("invalid persistence information of %s: %w", node, err)
+		}
+		persistence = append(persistence, nodeInfo)
+	}
+	sort.Slice(persistence, func(i, j int) bool { return persistence[i].Node < persistence[j].Node })
+	return persistence, nil
+}
+
+// parsePersistenceInfo returns the persistence information of node from the persistence section of its INFO.
+func parsePersistenceInfo(node string, info string) (models.PersistenceInfo, error) {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if name, value, found := strings.Cut(strings.TrimSpace(line), ":"); found {
+			fields[name] = value
+		}
+	}
+	var err error
+	integer := func(name string) int64 {
+		value, parseErr := strconv.ParseInt(fields[name], 10, 64)
+		if parseErr != nil && err == nil {
+			err = fmt.Errorf("invalid %s: %w", name, parseErr)
+		}
+		return value
+	}
+	// The durations of the ongoing operations are -1 when there's none.
+	seconds := func(name string) time.Duration {
+		return time.Duration(max(integer(name), 0)) * time.Second
+	}
+	persistence := models.PersistenceInfo{
+		Node:                      node,
+		Loading:                   integer("loading") == 1,
+		ChangesSinceLastSave:      integer("rdb_changes_since_last_save"),
+		BgSaveInProgress:          integer("rdb_bgsave_in_progress") == 1,
+		LastSave:                  time.Unix(integer("rdb_last_save_time"), 0),
+		LastBgSaveStatus:          fields["rdb_last_bgsave_status"],
+		CurrentBgSaveDuration:     seconds("rdb_current_bgsave_time_sec"),
+		AofEnabled:                integer("aof_enabled") == 1,
+		AofRewriteInProgress:      integer("aof_rewrite_in_progress") == 1,
+		AofRewriteScheduled:       integer("aof_rewrite_scheduled") == 1,
+		LastAofRewriteStatus:      fields["aof_last_bgrewrite_status"],
+		CurrentAofRewriteDuration: seconds("aof_current_rewrite_time_sec"),
+	}
+	if err != nil {
+		return models.PersistenceInfo{}, err
+	}
+	return persistence, nil
+}
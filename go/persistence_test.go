@@ -0,0 +1,38 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParsePersistenceInfo(t *testing.T) {
+	info := "# Persistence\r\nloading:0\r\nasync_loading:0\r\ncurrent_cow_peak:0\r\n" +
+		"rdb_changes_since_last_save:42\r\nrdb_bgsave_in_progress:1\r\nrdb_last_save_time:1700000000\r\n" +
+		"rdb_last_bgsave_status:ok\r\nrdb_last_bgsave_time_sec:1\r\nrdb_current_bgsave_time_sec:3\r\n" +
+		"aof_enabled:1\r\naof_rewrite_in_progress:0\r\naof_rewrite_scheduled:1\r\n" +
+		"aof_last_rewrite_time_sec:-1\r\naof_current_rewrite_time_sec:-1\r\naof_last_bgrewrite_status:err\r\n"
+
+	persistence, err := parsePersistenceInfo("10.0.0.1:6379", info)
+	require.NoError(t, err)
+	assert.Equal(t, models.PersistenceInfo{
+		Node:                  "10.0.0.1:6379",
+		ChangesSinceLastSave:  42,
+		BgSaveInProgress:      true,
+		LastSave:              time.Unix(1700000000, 0),
+		LastBgSaveStatus:      "ok",
+		CurrentBgSaveDuration: 3 * time.Second,
+		AofEnabled:            true,
+		AofRewriteScheduled:   true,
+		LastAofRewriteStatus:  "err",
+	}, persistence)
+
+	_, err = parsePersistenceInfo("", "# Persistence\r\nloading:0\r\n")
+	assert.ErrorContains(t, err, "invalid rdb_changes_since_last_save")
+}
@@ -882,6 +882,8 @@ func (b *BaseBatch[T]) HRandFieldWithCount(key string, count int64) *T {
 //	field name from the hash and `value` is the associated value of the field name.
 //	If the hash does not exist or is empty, the response will be an empty array.
 //
+// Deprecated: Use [BaseBatch.HRandFieldWithCountWithFieldValues] instead.
+//
 // [valkey.io]: https://valkey.io/commands/hrandfield/
 func (b *BaseBatch[T]) HRandFieldWithCountWithValues(key string, count int64) *T {
 	return b.addCmdAndConverter(
@@ -893,6 +895,38 @@ func (b *BaseBatch[T]) HRandFieldWithCountWithValues(key string, count int64) *T
 	)
 }
 
+// Retrieves up to `count` random field names along with their values from the hash
+// value stored at `key`.
+//
+// Since:
+//
+//	Valkey 6.2.0 and above.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	key - The key of the hash.
+//	count - The number of field names to return.
+//	  	If `count` is positive, returns unique elements.
+//		If negative, allows for duplicates.
+//
+// Command Response:
+//
+//	An array of [models.FieldValue], where `Field` is a random field name from the hash and `Value` is the
+//	associated value of the field name. If the hash does not exist or is empty, the response will be an empty array.
+//
+// [valkey.io]: https://valkey.io/commands/hrandfield/
+func (b *BaseBatch[T]) HRandFieldWithCountWithFieldValues(key string, count int64) *T {
+	return b.addCmdAndConverter(
+		C.HRandField,
+		[]string{key, utils.IntToString(count), constants.WithValuesKeyword},
+		reflect.Slice,
+		false,
+		internal.ConvertArrayOfFieldValue,
+	)
+}
+
 // Hash field expiration commands (Valkey 9.0+)
 
 // Sets the value of one or more fields of a given hash key, and optionally set their expiration time or time-to-live
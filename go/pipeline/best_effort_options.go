@@ -0,0 +1,46 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package pipeline
+
+import "time"
+
+// DefaultBestEffortChunkSize is the default number of commands sent together by a best-effort batch execution.
+const DefaultBestEffortChunkSize = 16
+
+// BestEffortBatchOptions contains the options of the best-effort execution of non-atomic batches, see
+// ExecBestEffort.
+type BestEffortBatchOptions struct {
+	// Budget is the total time the execution of the batch may take.
+	Budget time.Duration
+	// ChunkSize is the number of commands sent together, the commands of the chunks which weren't sent before the
+	// budget was exhausted being skipped.
+	ChunkSize int
+}
+
+// Create a new options instance for the best-effort execution of batches within budget.
+//
+// Parameters:
+//
+//	budget - The total time the execution of the batch may take.
+//
+// Returns:
+//
+//	A new BestEffortBatchOptions instance.
+func NewBestEffortBatchOptions(budget time.Duration) *BestEffortBatchOptions {
+	return &BestEffortBatchOptions{Budget: budget, ChunkSize: DefaultBestEffortChunkSize}
+}
+
+// Set the number of commands sent together. Smaller chunks skip fewer commands once the budget is exhausted, at the
+// cost of more round trips. Defaults to [DefaultBestEffortChunkSize].
+//
+// Parameters:
+//
+//	chunkSize - The number of commands sent together.
+//
+// Returns:
+//
+//	The updated BestEffortBatchOptions instance.
+func (options *BestEffortBatchOptions) WithChunkSize(chunkSize int) *BestEffortBatchOptions {
+	options.ChunkSize = chunkSize
+	return options
+}
@@ -0,0 +1,235 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// numSlots is the number of hash slots of a cluster.
+const numSlots = 16384
+
+// primaryChangeMonitor detects the primary changes of a cluster by comparing the primaries serving its slots, as
+// reported by CLUSTER SLOTS by all the primaries, from one check to the next.
+type primaryChangeMonitor struct {
+	client   *baseClient
+	callback config.PrimaryChangeCallback
+	interval time.Duration
+	// checkNow requests a check before the next periodic one.
+	checkNow chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	// mu guards primaries, which are read by the metrics of the client, and redirectedAt, which is set by the
+	// goroutines of the commands.
+	mu sync.Mutex
+	// primaries[slot] is the address of the primary serving slot at the last check, or "" if none.
+	primaries []string
+	// redirectedAt is the time of the first redirect error observed since the last check, or zero if none.
+	redirectedAt time.Time
+	lastCheck    time.Time
+}
+
+func newPrimaryChangeMonitor(client *baseClient, primaryChange *config.PrimaryChangeConfiguration) *primaryChangeMonitor {
+	return &primaryChangeMonitor{
+		client:   client,
+		callback: primaryChange.GetCallback(),
+		interval: primaryChange.GetCheckInterval(),
+		checkNow: make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+}
+
+func (monitor *primaryChangeMonitor) run() {
+	ticker := time.NewTicker(monitor.interval)
+	defer ticker.Stop()
+	monitor.check()
+	for {
+		select {
+		case <-monitor.stop:
+			return
+		case <-ticker.C:
+		case <-monitor.checkNow:
+		}
+		monitor.check()
+	}
+}
+
+// requestCheck makes the monitor check the topology without waiting for the next periodic check, after a command
+// failed with a redirect error.
+func (monitor *primaryChangeMonitor) requestCheck() {
+	monitor.mu.Lock()
+	if monitor.redirectedAt.IsZero() {
+		monitor.redirectedAt = time.Now()
+	}
+	monitor.mu.Unlock()
+	select {
+	case monitor.checkNow <- struct{}{}:
+	default:
+		// A check is pending already
+	}
+}
+
+func (monitor *primaryChangeMonitor) close() {
+	monitor.stopOnce.Do(func() { close(monitor.stop) })
+}
+
+// check fetches the primaries of the slots from all the primaries, and reports the changes since the previous check.
+// Failed checks, and the checks whose primaries don't agree on the topology, e.g. in the middle of a failover, are
+// ignored: the next check compares the topology with the last one fetched, so that the changes aren't reported back
+// and forth as the primaries learn about them. CLUSTER SLOTS is sent directly, so that the checks aren't captured,
+// journaled, traced or counted by the metrics of the requests of the client.
+func (monitor *primaryChangeMonitor) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), max(monitor.interval, time.Second))
+	defer cancel()
+	response, err := monitor.client.sendCommand(ctx, C.ClusterSlots, []string{}, config.AllPrimaries)
+	if err != nil {
+		return
+	}
+	slots, err := handleInterfaceResponse(response)
+	if err != nil {
+		return
+	}
+	primaries, err := agreedSlotPrimaries(slots)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	monitor.mu.Lock()
+	previous := monitor.primaries
+	latency := monitor.detectionLatency(now)
+	monitor.primaries = primaries
+	monitor.redirectedAt = time.Time{}
+	monitor.mu.Unlock()
+	monitor.lastCheck = now
+	if previous != nil {
+		for _, event := range primaryChanges(previous, primaries, now, latency) {
+			monitor.callback(event)
+		}
+	}
+}
+
+// detectionLatency returns the latency of the changes detected at now: the time since the first redirect error
+// observed since the last check, or else since the last check. It must be called with monitor.mu held.
+func (monitor *primaryChangeMonitor) detectionLatency(now time.Time) time.Duration {
+	if !monitor.redirectedAt.IsZero() {
+		return now.Sub(monitor.redirectedAt)
+	}
+	return now.Sub(monitor.lastCheck)
+}
+
+// topology returns the number of primaries serving slots and the number of unassigned slots at the last check, or
+// false if no check succeeded yet.
+func (monitor *primaryChangeMonitor) topology() (primaries int, unassignedSlots int, ok bool) {
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+	if monitor.primaries == nil {
+		return 0, 0, false
+	}
+	nodes := make(map[string]struct{})
+	for _, primary := range monitor.primaries {
+		if primary == "" {
+			unassignedSlots++
+		} else {
+			nodes[primary] = struct{}{}
+		}
+	}
+	return len(nodes), unassignedSlots, true
+}
+
+// agreedSlotPrimaries returns the address of the primary serving each slot from the CLUSTER SLOTS responses of the
+// primaries, by address, or an error if they don't all report the same primaries.
+func agreedSlotPrimaries(response any) ([]string, error) {
+	responses, ok := response.(map[string]any)
+	if !ok || len(responses) == 0 {
+		return nil, fmt.Errorf("unexpected CLUSTER SLOTS responses: %T", response)
+	}
+	var agreed []string
+	for node, slots := range responses {
+		primaries, err := parseSlotPrimaries(slots)
+		if err != nil {
+			return nil, err
+		}
+		if agreed != nil && !slices.Equal(agreed, primaries) {
+			return nil, fmt.Errorf("the primaries of the slots reported by %s differ from the other nodes", node)
+		}
+		agreed = primaries
+	}
+	return agreed, nil
+}
+
+// parseSlotPrimaries returns the address of the primary serving each slot from a CLUSTER SLOTS response.
+func parseSlotPrimaries(response any) ([]string, error) {
+	ranges, ok := response.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected CLUSTER SLOTS response: %T", response)
+	}
+	primaries := make([]string, numSlots)
+	for _, slotRange := range ranges {
+		fields, ok := slotRange.([]any)
+		if !ok || len(fields) < 3 {
+			return nil, fmt.Errorf("unexpected CLUSTER SLOTS range: %v", slotRange)
+		}
+		start, startOk := fields[0].(int64)
+		end, endOk := fields[1].(int64)
+		primary, primaryOk := fields[2].([]any)
+		if !startOk || !endOk || !primaryOk || len(primary) < 2 || start < 0 || end >= numSlots || start > end {
+			return nil, fmt.Errorf("unexpected CLUSTER SLOTS range: %v", slotRange)
+		}
+		address := fmt.Sprintf("%v:%v", primary[0], primary[1])
+		for slot := start; slot <= end; slot++ {
+			primaries[slot] = address
+		}
+	}
+	return primaries, nil
+}
+
+// primaryChanges returns an event per range of consecutive slots which moved from one primary to another.
+// Slots that were or became unassigned aren't reported.
+func primaryChanges(previous, current []string, detectedAt time.Time, latency time.Duration) []models.PrimaryChangeEvent {
+	var events []models.PrimaryChangeEvent
+	for slot := 0; slot < len(current) && slot < len(previous); slot++ {
+		oldPrimary, newPrimary := previous[slot], current[slot]
+		if oldPrimary == "" || newPrimary == "" || oldPrimary == newPrimary {
+			continue
+		}
+		if last := len(events) - 1; last >= 0 && events[last].SlotEnd == int64(slot-1) &&
+			events[last].OldPrimary == oldPrimary && events[last].NewPrimary == newPrimary {
+			events[last].SlotEnd = int64(slot)
+			continue
+		}
+		events = append(events, models.PrimaryChangeEvent{
+			SlotStart:        int64(slot),
+			SlotEnd:          int64(slot),
+			OldPrimary:       oldPrimary,
+			NewPrimary:       newPrimary,
+			DetectedAt:       detectedAt,
+			DetectionLatency: latency,
+		})
+	}
+	return events
+}
+
+// isRedirectError reports whether err is a MOVED or REDIRECT error, which hints at a topology change.
+func isRedirectError(err error) bool {
+	var requestErr *RequestError
+	return errors.Is(err, ErrMoved) || (errors.As(err, &requestErr) && requestErr.Code() == "REDIRECT")
+}
+
+// observeError requests a check of the primaries of the cluster when a command failed with a redirect error.
+func (client *baseClient) observeError(err error) {
+	if client.primaryMonitor != nil && isRedirectError(err) {
+		client.primaryMonitor.requestCheck()
+	}
+}
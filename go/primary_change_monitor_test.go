@@ -0,0 +1,119 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParseSlotPrimaries(t *testing.T) {
+	response := []any{
+		[]any{int64(0), int64(8191), []any{"10.0.0.1", int64(6379), "id1"}, []any{"10.0.0.3", int64(6379), "id3"}},
+		[]any{int64(8192), int64(16382), []any{"10.0.0.2", int64(6379), "id2"}},
+	}
+	primaries, err := parseSlotPrimaries(response)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:6379", primaries[0])
+	assert.Equal(t, "10.0.0.1:6379", primaries[8191])
+	assert.Equal(t, "10.0.0.2:6379", primaries[8192])
+	assert.Equal(t, "", primaries[16383])
+
+	_, err = parseSlotPrimaries([]any{[]any{int64(0), int64(numSlots), []any{"10.0.0.1", int64(6379)}}})
+	assert.Error(t, err)
+	_, err = parseSlotPrimaries("OK")
+	assert.Error(t, err)
+}
+
+func TestAgreedSlotPrimaries(t *testing.T) {
+	slots := func(primary string) any {
+		return []any{[]any{int64(0), int64(numSlots - 1), []any{primary, int64(6379), "id"}}}
+	}
+	primaries, err := agreedSlotPrimaries(map[string]any{"10.0.0.1:6379": slots("10.0.0.1"), "10.0.0.2:6379": slots("10.0.0.1")})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:6379", primaries[0])
+
+	// The primaries disagree in the middle of a failover.
+	_, err = agreedSlotPrimaries(map[string]any{"10.0.0.1:6379": slots("10.0.0.1"), "10.0.0.2:6379": slots("10.0.0.2")})
+	assert.Error(t, err)
+	_, err = agreedSlotPrimaries(map[string]any{})
+	assert.Error(t, err)
+	_, err = agreedSlotPrimaries(slots("10.0.0.1"))
+	assert.Error(t, err)
+}
+
+func TestPrimaryChangeMonitor_DetectionLatency(t *testing.T) {
+	monitor := newPrimaryChangeMonitor(nil, config.NewPrimaryChangeConfiguration(func(models.PrimaryChangeEvent) {}))
+	now := time.Now()
+	monitor.lastCheck = now.Add(-time.Minute)
+	assert.Equal(t, time.Minute, monitor.detectionLatency(now))
+
+	// The latency of the changes is measured from the first redirect error.
+	monitor.requestCheck()
+	redirectedAt := monitor.redirectedAt
+	monitor.requestCheck()
+	assert.Equal(t, redirectedAt, monitor.redirectedAt)
+	assert.Equal(t, now.Add(time.Second).Sub(redirectedAt), monitor.detectionLatency(now.Add(time.Second)))
+}
+
+func TestPrimaryChanges(t *testing.T) {
+	previous := make([]string, numSlots)
+	current := make([]string, numSlots)
+	for slot := range previous {
+		previous[slot] = "a:6379"
+		current[slot] = "a:6379"
+	}
+	for slot := 100; slot <= 200; slot++ {
+		current[slot] = "b:6379"
+	}
+	current[201] = "c:6379"
+	// Unassigned slots aren't reported
+	current[300] = ""
+
+	detectedAt := time.Now()
+	events := primaryChanges(previous, current, detectedAt, time.Second)
+	assert.Equal(t, []models.PrimaryChangeEvent{
+		{
+			SlotStart:        100,
+			SlotEnd:          200,
+			OldPrimary:       "a:6379",
+			NewPrimary:       "b:6379",
+			DetectedAt:       detectedAt,
+			DetectionLatency: time.Second,
+		},
+		{
+			SlotStart:        201,
+			SlotEnd:          201,
+			OldPrimary:       "a:6379",
+			NewPrimary:       "c:6379",
+			DetectedAt:       detectedAt,
+			DetectionLatency: time.Second,
+		},
+	}, events)
+
+	assert.Empty(t, primaryChanges(previous, previous, detectedAt, time.Second))
+}
+
+func TestIsRedirectError(t *testing.T) {
+	assert.True(t, isRedirectError(NewRequestError("MOVED 3999 127.0.0.1:6381")))
+	assert.True(t, isRedirectError(NewRequestError("REDIRECT 127.0.0.1:6380")))
+	assert.False(t, isRedirectError(NewRequestError("WRONGTYPE Operation against a key holding the wrong kind of value")))
+}
+
+func TestPrimaryChangeMonitor_Topology(t *testing.T) {
+	monitor := &primaryChangeMonitor{}
+	_, _, ok := monitor.topology()
+	assert.False(t, ok)
+
+	monitor.primaries = []string{"a:1", "a:1", "", "b:1"}
+	primaries, unassignedSlots, ok := monitor.topology()
+	assert.True(t, ok)
+	assert.Equal(t, 2, primaries)
+	assert.Equal(t, 1, unassignedSlots)
+}
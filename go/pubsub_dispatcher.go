@@ -0,0 +1,117 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// pubSubDispatcher hands the messages received by a client over to goroutines running the message callback,
+// according to a [config.PubSubDispatchMode].
+//
+// In the ordered modes, the messages of a channel are delivered one at a time, in the order they were received:
+// a goroutine picks the next message of a channel only once the previous one was delivered. Messages of different
+// channels are delivered concurrently, by at most maxWorkers goroutines when maxWorkers is positive.
+//
+// dispatch never blocks: it's called by the core for every message, and a slow callback must not hold back the
+// delivery of other messages. Goroutines are started on demand and exit once they've no message left to process.
+type pubSubDispatcher struct {
+	mode    config.PubSubDispatchMode
+	deliver func(*models.PubSubMessage)
+
+	mu sync.Mutex
+	// Messages waiting to be delivered, by channel. A channel has an entry while it has messages waiting or a
+	// message being delivered.
+	channels map[string]*channelQueue
+	// Channels with messages waiting and no message being delivered, in the order they became ready.
+	ready list.List
+	// The number of running goroutines, how many of them are delivering a message, and the limit of goroutines,
+	// or 0 for no limit.
+	workers    int
+	delivering int
+	maxWorkers int
+}
+
+// channelQueue holds the messages of a channel waiting to be delivered.
+type channelQueue struct {
+	messages []*models.PubSubMessage
+	// busy is set while a message of the channel is being delivered.
+	busy bool
+}
+
+func newPubSubDispatcher(
+	mode config.PubSubDispatchMode,
+	workerPoolSize int,
+	deliver func(*models.PubSubMessage),
+) *pubSubDispatcher {
+	maxWorkers := 0
+	if mode == config.DispatchWorkerPool {
+		maxWorkers = workerPoolSize
+	}
+	return &pubSubDispatcher{
+		mode:       mode,
+		deliver:    deliver,
+		channels:   make(map[string]*channelQueue),
+		maxWorkers: maxWorkers,
+	}
+}
+
+func (d *pubSubDispatcher) dispatch(message *models.PubSubMessage) {
+	if d.mode != config.DispatchOrderedPerChannel && d.mode != config.DispatchWorkerPool {
+		go d.deliver(message)
+		return
+	}
+
+	d.mu.Lock()
+	queue, ok := d.channels[message.Channel]
+	if !ok {
+		queue = &channelQueue{}
+		d.channels[message.Channel] = queue
+	}
+	queue.messages = append(queue.messages, message)
+	if len(queue.messages) == 1 && !queue.busy {
+		d.ready.PushBack(message.Channel)
+	}
+	// Goroutines which aren't delivering a message are about to pick a ready channel.
+	startWorker := d.ready.Len() > d.workers-d.delivering && (d.maxWorkers <= 0 || d.workers < d.maxWorkers)
+	if startWorker {
+		d.workers++
+	}
+	d.mu.Unlock()
+	if startWorker {
+		go d.runWorker()
+	}
+}
+
+// runWorker delivers the next message of the ready channels, until no channel is ready.
+func (d *pubSubDispatcher) runWorker() {
+	d.mu.Lock()
+	for d.ready.Len() > 0 {
+		channel := d.ready.Remove(d.ready.Front()).(string)
+		queue := d.channels[channel]
+		message := queue.messages[0]
+		queue.messages[0] = nil
+		queue.messages = queue.messages[1:]
+		queue.busy = true
+		d.delivering++
+		d.mu.Unlock()
+
+		d.deliver(message)
+
+		d.mu.Lock()
+		queue.busy = false
+		d.delivering--
+		if len(queue.messages) > 0 {
+			// Go to the back of the line, so channels with many messages don't starve the others.
+			d.ready.PushBack(channel)
+		} else {
+			delete(d.channels, channel)
+		}
+	}
+	d.workers--
+	d.mu.Unlock()
+}
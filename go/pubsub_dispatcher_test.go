@@ -0,0 +1,132 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestPubSubDispatcher_PerMessage(t *testing.T) {
+	var wg sync.WaitGroup
+	var delivered atomic.Int32
+	dispatcher := newPubSubDispatcher(config.DispatchPerMessage, 0, func(*models.PubSubMessage) {
+		delivered.Add(1)
+		wg.Done()
+	})
+
+	wg.Add(100)
+	for i := 0; i < 100; i++ {
+		dispatcher.dispatch(models.NewPubSubMessage(fmt.Sprint(i), "channel"))
+	}
+	wg.Wait()
+	assert.Equal(t, int32(100), delivered.Load())
+}
+
+func TestPubSubDispatcher_OrderedPerChannel(t *testing.T) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	received := make(map[string][]string)
+	dispatcher := newPubSubDispatcher(config.DispatchOrderedPerChannel, 0, func(message *models.PubSubMessage) {
+		defer wg.Done()
+		mu.Lock()
+		received[message.Channel] = append(received[message.Channel], message.Message)
+		mu.Unlock()
+	})
+
+	channels := []string{"a", "b", "c"}
+	wg.Add(300)
+	for i := 0; i < 100; i++ {
+		for _, channel := range channels {
+			dispatcher.dispatch(models.NewPubSubMessage(fmt.Sprint(i), channel))
+		}
+	}
+	wg.Wait()
+
+	for _, channel := range channels {
+		assert.Len(t, received[channel], 100)
+		for i, message := range received[channel] {
+			assert.Equal(t, fmt.Sprint(i), message, "channel %s", channel)
+		}
+	}
+	// Goroutines exit once all the messages of their channel are delivered
+	assert.Eventually(t, func() bool {
+		dispatcher.mu.Lock()
+		defer dispatcher.mu.Unlock()
+		return len(dispatcher.channels) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestPubSubDispatcher_WorkerPoolIsBounded(t *testing.T) {
+	var wg sync.WaitGroup
+	var running, maxRunning atomic.Int32
+	dispatcher := newPubSubDispatcher(config.DispatchWorkerPool, 3, func(*models.PubSubMessage) {
+		defer wg.Done()
+		current := running.Add(1)
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+	})
+
+	wg.Add(50)
+	for i := 0; i < 50; i++ {
+		dispatcher.dispatch(models.NewPubSubMessage(fmt.Sprint(i), fmt.Sprint("channel", i)))
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	assert.Eventually(t, func() bool {
+		dispatcher.mu.Lock()
+		defer dispatcher.mu.Unlock()
+		return dispatcher.workers == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestPubSubDispatcher_WorkerPoolPreservesChannelOrder(t *testing.T) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	received := make(map[string][]string)
+	inFlight := make(map[string]bool)
+	dispatcher := newPubSubDispatcher(config.DispatchWorkerPool, 4, func(message *models.PubSubMessage) {
+		defer wg.Done()
+		mu.Lock()
+		assert.False(t, inFlight[message.Channel], "concurrent delivery on channel %s", message.Channel)
+		inFlight[message.Channel] = true
+		mu.Unlock()
+
+		time.Sleep(10 * time.Microsecond)
+
+		mu.Lock()
+		inFlight[message.Channel] = false
+		received[message.Channel] = append(received[message.Channel], message.Message)
+		mu.Unlock()
+	})
+
+	channels := []string{"a", "b", "c", "d", "e", "f"}
+	wg.Add(100 * len(channels))
+	for i := 0; i < 100; i++ {
+		for _, channel := range channels {
+			dispatcher.dispatch(models.NewPubSubMessage(fmt.Sprint(i), channel))
+		}
+	}
+	wg.Wait()
+
+	for _, channel := range channels {
+		assert.Len(t, received[channel], 100)
+		for i, message := range received[channel] {
+			assert.Equal(t, fmt.Sprint(i), message, "channel %s", channel)
+		}
+	}
+}
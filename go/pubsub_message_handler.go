@@ -36,17 +36,54 @@ func (e *MessageCallbackError) Cause() error {
 // *** Message Handler ***
 
 type MessageHandler struct {
-	callback config.MessageCallback
-	context  any
-	queue    *PubSubMessageQueue
+	callback   config.MessageCallback
+	context    any
+	queue      *PubSubMessageQueue
+	dispatcher *pubSubDispatcher
+	metrics    *pubSubMetrics
+	// keyspaceFilters select the keyspace notifications to dispatch, see acceptsMessage.
+	keyspaceFilters []*config.KeyspaceEventFilter
 }
 
 func NewMessageHandler(callback config.MessageCallback, context any) *MessageHandler {
-	return &MessageHandler{
+	handler := &MessageHandler{
 		callback: callback,
 		context:  context,
 		queue:    NewPubSubMessageQueue(),
+		metrics:  newPubSubMetrics(),
 	}
+	handler.queue.onDequeue = handler.metrics.markProcessed
+	handler.setDispatchMode(config.DispatchPerMessage, config.DefaultWorkerPoolSize)
+	return handler
+}
+
+// setDispatchMode sets how messages are dispatched to the callback of the handler.
+func (handler *MessageHandler) setDispatchMode(mode config.PubSubDispatchMode, workerPoolSize int) {
+	handler.dispatcher = newPubSubDispatcher(mode, workerPoolSize, func(message *models.PubSubMessage) {
+		_ = handler.handleMessage(message)
+		handler.metrics.markProcessed(message)
+	})
+}
+
+// setMaxBufferedMessages sets the number of buffered messages beyond which the handler drops received messages, or
+// 0 for no limit.
+func (handler *MessageHandler) setMaxBufferedMessages(maxBufferedMessages int) {
+	handler.metrics.setMaxBuffered(maxBufferedMessages)
+}
+
+// dispatchMessage hands a received message over to the callback, according to the dispatch mode of the handler,
+// or pushes it to the queue of the handler if it has no callback. The message is dropped if the handler buffers too
+// many messages already, or if it's a keyspace notification discarded by the filters of the handler.
+func (handler *MessageHandler) dispatchMessage(message *models.PubSubMessage) {
+	if !handler.acceptsMessage(message) || !handler.metrics.received(message) {
+		return
+	}
+	if handler.callback == nil {
+		// Pushing never blocks, doing it right away keeps the messages in order.
+		handler.queue.Push(message)
+		return
+	}
+	handler.dispatcher.dispatch(message)
 }
 
 func (handler *MessageHandler) handleMessage(message *models.PubSubMessage) error {
@@ -81,6 +118,8 @@ type PubSubMessageQueue struct {
 	waiters                 []chan *models.PubSubMessage
 	nextMessageReadyCh      chan struct{}
 	nextMessageReadySignals []chan struct{}
+	// onDequeue, if set, is called with every message handed over to a consumer of the queue.
+	onDequeue func(*models.PubSubMessage)
 }
 
 func NewPubSubMessageQueue() *PubSubMessageQueue {
@@ -101,6 +140,7 @@ func (queue *PubSubMessageQueue) Push(message *models.PubSubMessage) {
 		waiterCh := queue.waiters[0]
 		queue.waiters = queue.waiters[1:]
 		waiterCh <- message
+		queue.dequeued(message)
 		return
 	}
 
@@ -134,9 +174,17 @@ func (queue *PubSubMessageQueue) Pop() *models.PubSubMessage {
 
 	message := queue.messages[0]
 	queue.messages = queue.messages[1:]
+	queue.dequeued(message)
 	return message
 }
 
+// dequeued reports a message handed over to a consumer of the queue. The caller must hold queue.mu.
+func (queue *PubSubMessageQueue) dequeued(message *models.PubSubMessage) {
+	if queue.onDequeue != nil {
+		queue.onDequeue(message)
+	}
+}
+
 func (queue *PubSubMessageQueue) WaitForMessage() <-chan *models.PubSubMessage {
 	queue.mu.Lock()
 	defer queue.mu.Unlock()
@@ -147,6 +195,7 @@ func (queue *PubSubMessageQueue) WaitForMessage() <-chan *models.PubSubMessage {
 		message := queue.messages[0]
 		queue.messages = queue.messages[1:]
 		messageCh <- message
+		queue.dequeued(message)
 		return messageCh
 	}
 
@@ -0,0 +1,169 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// pubSubMetrics tracks the messages buffered by a [MessageHandler], from the time they're received until they're
+// processed, overall and by subscription.
+type pubSubMetrics struct {
+	mu sync.Mutex
+	// maxBuffered is the number of buffered messages beyond which received messages are dropped, or 0 for no limit.
+	maxBuffered int
+	// all holds the messages of every subscription.
+	all      pubSubSeries
+	elements map[*models.PubSubMessage]bufferedMessage
+	// subscriptions holds the series of the first models.MaxPubSubMetricSubscriptions subscriptions to receive a
+	// message, and others the series aggregating the subscriptions beyond them.
+	subscriptions map[pubSubSubscription]*pubSubSeries
+	others        pubSubSeries
+	// now returns the current time.
+	now func() time.Time
+}
+
+// pubSubSubscription identifies the channel, or the pattern, a message was received through.
+type pubSubSubscription struct {
+	name    string
+	pattern bool
+}
+
+type pubSubSeries struct {
+	// buffered holds the receive time of the buffered messages, in receive order.
+	buffered  list.List
+	processed uint64
+	dropped   uint64
+}
+
+// bufferedMessage locates a buffered message in the series it's counted in.
+type bufferedMessage struct {
+	all, inSubscription *list.Element
+	subscription        *pubSubSeries
+}
+
+func newPubSubMetrics() *pubSubMetrics {
+	return &pubSubMetrics{
+		elements:      make(map[*models.PubSubMessage]bufferedMessage),
+		subscriptions: make(map[pubSubSubscription]*pubSubSeries),
+		now:           time.Now,
+	}
+}
+
+// setMaxBuffered sets the number of buffered messages beyond which received messages are dropped, or 0 for no limit.
+func (m *pubSubMetrics) setMaxBuffered(maxBuffered int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxBuffered = maxBuffered
+}
+
+// subscriptionSeries returns the series counting the messages of the subscription message was received through.
+// The caller must hold m.mu.
+func (m *pubSubMetrics) subscriptionSeries(message *models.PubSubMessage) *pubSubSeries {
+	subscription := pubSubSubscription{name: message.Channel}
+	if !message.Pattern.IsNil() {
+		subscription = pubSubSubscription{name: message.Pattern.Value(), pattern: true}
+	}
+	if series, ok := m.subscriptions[subscription]; ok {
+		return series
+	}
+	if len(m.subscriptions) >= models.MaxPubSubMetricSubscriptions {
+		return &m.others
+	}
+	series := &pubSubSeries{}
+	m.subscriptions[subscription] = series
+	return series
+}
+
+// received records a received message as buffered, and returns true. If the buffer limit is reached, the message
+// is counted as dropped instead, and false is returned.
+func (m *pubSubMetrics) received(message *models.PubSubMessage) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscription := m.subscriptionSeries(message)
+	if m.maxBuffered > 0 && m.all.buffered.Len() >= m.maxBuffered {
+		m.all.dropped++
+		subscription.dropped++
+		return false
+	}
+	receivedAt := m.now()
+	m.elements[message] = bufferedMessage{
+		all:            m.all.buffered.PushBack(receivedAt),
+		inSubscription: subscription.buffered.PushBack(receivedAt),
+		subscription:   subscription,
+	}
+	return true
+}
+
+// markProcessed records a buffered message as processed.
+func (m *pubSubMetrics) markProcessed(message *models.PubSubMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if buffered, ok := m.elements[message]; ok {
+		m.all.buffered.Remove(buffered.all)
+		m.all.processed++
+		buffered.subscription.buffered.Remove(buffered.inSubscription)
+		buffered.subscription.processed++
+		delete(m.elements, message)
+	}
+}
+
+func (m *pubSubMetrics) snapshot() models.PubSubMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	all := m.all.snapshot(now)
+	metrics := models.PubSubMetrics{
+		BufferedMessages:         all.BufferedMessages,
+		OldestBufferedMessageAge: all.OldestBufferedMessageAge,
+		ProcessedMessages:        all.ProcessedMessages,
+		DroppedMessages:          all.DroppedMessages,
+	}
+	for subscription, series := range m.subscriptions {
+		subscriptionMetrics := series.snapshot(now)
+		subscriptionMetrics.Subscription = subscription.name
+		subscriptionMetrics.Pattern = subscription.pattern
+		metrics.Subscriptions = append(metrics.Subscriptions, subscriptionMetrics)
+	}
+	sort.Slice(metrics.Subscriptions, func(i, j int) bool {
+		a, b := metrics.Subscriptions[i], metrics.Subscriptions[j]
+		if a.Subscription != b.Subscription {
+			return a.Subscription < b.Subscription
+		}
+		return !a.Pattern && b.Pattern
+	})
+	if len(m.subscriptions) >= models.MaxPubSubMetricSubscriptions {
+		others := m.others.snapshot(now)
+		others.Others = true
+		metrics.Subscriptions = append(metrics.Subscriptions, others)
+	}
+	return metrics
+}
+
+func (s *pubSubSeries) snapshot(now time.Time) models.PubSubSubscriptionMetrics {
+	metrics := models.PubSubSubscriptionMetrics{
+		BufferedMessages:  s.buffered.Len(),
+		ProcessedMessages: s.processed,
+		DroppedMessages:   s.dropped,
+	}
+	if oldest := s.buffered.Front(); oldest != nil {
+		metrics.OldestBufferedMessageAge = now.Sub(oldest.Value.(time.Time))
+	}
+	return metrics
+}
+
+// GetPubSubMetrics returns the metrics of the messages received by the subscriptions of the client, to detect a
+// subscriber falling behind.
+//
+// Return value:
+//
+//	A [models.PubSubMetrics] with the number of buffered messages and the age of the oldest one, and the number of
+//	messages processed and dropped since the client was created, overall and by subscription.
+func (client *baseClient) GetPubSubMetrics() models.PubSubMetrics {
+	return client.getMessageHandler().metrics.snapshot()
+}
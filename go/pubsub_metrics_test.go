@@ -0,0 +1,127 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestPubSubMetrics_QueueConsumer(t *testing.T) {
+	handler := NewMessageHandler(nil, nil)
+	now := time.Now()
+	handler.metrics.now = func() time.Time { return now }
+
+	first := models.NewPubSubMessage("first", "channel")
+	handler.dispatchMessage(first)
+	now = now.Add(time.Second)
+	handler.dispatchMessage(models.NewPubSubMessage("second", "channel"))
+	now = now.Add(time.Second)
+
+	metrics := handler.metrics.snapshot()
+	assert.Equal(t, 2, metrics.BufferedMessages)
+	assert.Equal(t, 2*time.Second, metrics.OldestBufferedMessageAge)
+	assert.Equal(t, uint64(0), metrics.ProcessedMessages)
+
+	assert.Equal(t, first, handler.GetQueue().Pop())
+	metrics = handler.metrics.snapshot()
+	assert.Equal(t, 1, metrics.BufferedMessages)
+	assert.Equal(t, time.Second, metrics.OldestBufferedMessageAge)
+	assert.Equal(t, uint64(1), metrics.ProcessedMessages)
+
+	<-handler.GetQueue().WaitForMessage()
+	waiter := handler.GetQueue().WaitForMessage()
+	handler.dispatchMessage(models.NewPubSubMessage("third", "channel"))
+	<-waiter
+	assert.Equal(t, models.PubSubMetrics{
+		ProcessedMessages: 3,
+		Subscriptions:     []models.PubSubSubscriptionMetrics{{Subscription: "channel", ProcessedMessages: 3}},
+	}, handler.metrics.snapshot())
+}
+
+func TestPubSubMetrics_DropsBeyondLimit(t *testing.T) {
+	handler := NewMessageHandler(nil, nil)
+	handler.setMaxBufferedMessages(2)
+
+	for _, message := range []string{"a", "b", "c", "d"} {
+		handler.dispatchMessage(models.NewPubSubMessage(message, "channel"))
+	}
+	metrics := handler.metrics.snapshot()
+	assert.Equal(t, 2, metrics.BufferedMessages)
+	assert.Equal(t, uint64(2), metrics.DroppedMessages)
+
+	assert.Equal(t, "a", handler.GetQueue().Pop().Message)
+	handler.dispatchMessage(models.NewPubSubMessage("e", "channel"))
+	assert.Equal(t, "b", handler.GetQueue().Pop().Message)
+	assert.Equal(t, "e", handler.GetQueue().Pop().Message)
+	assert.Equal(t, models.PubSubMetrics{
+		ProcessedMessages: 3,
+		DroppedMessages:   2,
+		Subscriptions: []models.PubSubSubscriptionMetrics{
+			{Subscription: "channel", ProcessedMessages: 3, DroppedMessages: 2},
+		},
+	}, handler.metrics.snapshot())
+}
+
+func TestPubSubMetrics_Callback(t *testing.T) {
+	release := make(chan struct{})
+	done := make(chan struct{})
+	handler := NewMessageHandler(func(*models.PubSubMessage, any) {
+		<-release
+		done <- struct{}{}
+	}, nil)
+
+	handler.dispatchMessage(models.NewPubSubMessage("message", "channel"))
+	assert.Equal(t, 1, handler.metrics.snapshot().BufferedMessages)
+
+	close(release)
+	<-done
+	require.Eventually(t, func() bool {
+		metrics := handler.metrics.snapshot()
+		return metrics.BufferedMessages == 0 && metrics.ProcessedMessages == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestPubSubMetrics_BySubscription(t *testing.T) {
+	handler := NewMessageHandler(nil, nil)
+	now := time.Now()
+	handler.metrics.now = func() time.Time { return now }
+	handler.setMaxBufferedMessages(3)
+
+	handler.dispatchMessage(models.NewPubSubMessageWithPattern("a", "news.sports", models.CreateStringResult("news.*")))
+	now = now.Add(time.Second)
+	handler.dispatchMessage(models.NewPubSubMessage("b", "news.sports"))
+	handler.dispatchMessage(models.NewPubSubMessageWithPattern("c", "news.tech", models.CreateStringResult("news.*")))
+	handler.dispatchMessage(models.NewPubSubMessage("d", "news.sports"))
+	now = now.Add(time.Second)
+	assert.Equal(t, "a", handler.GetQueue().Pop().Message)
+
+	assert.Equal(t, []models.PubSubSubscriptionMetrics{
+		{Subscription: "news.*", Pattern: true, BufferedMessages: 1, OldestBufferedMessageAge: time.Second, ProcessedMessages: 1},
+		{Subscription: "news.sports", BufferedMessages: 1, OldestBufferedMessageAge: time.Second, DroppedMessages: 1},
+	}, handler.metrics.snapshot().Subscriptions)
+}
+
+func TestPubSubMetrics_CapsSubscriptions(t *testing.T) {
+	handler := NewMessageHandler(nil, nil)
+	now := time.Now()
+	handler.metrics.now = func() time.Time { return now }
+	for i := 0; i < models.MaxPubSubMetricSubscriptions+2; i++ {
+		handler.dispatchMessage(models.NewPubSubMessage("message", fmt.Sprintf("channel-%03d", i)))
+	}
+	// A subscription reported already keeps its own series.
+	handler.dispatchMessage(models.NewPubSubMessage("message", "channel-000"))
+
+	subscriptions := handler.metrics.snapshot().Subscriptions
+	require.Len(t, subscriptions, models.MaxPubSubMetricSubscriptions+1)
+	assert.Equal(t, models.PubSubSubscriptionMetrics{Subscription: "channel-000", BufferedMessages: 2}, subscriptions[0])
+	others := subscriptions[models.MaxPubSubMetricSubscriptions]
+	assert.True(t, others.Others)
+	assert.Equal(t, 2, others.BufferedMessages)
+}
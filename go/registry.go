@@ -0,0 +1,234 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// registeredClient is implemented by [Client] and [ClusterClient].
+type registeredClient interface {
+	healthChecker
+	Close()
+}
+
+// registryEntry holds a named client of a [Registry], created on first use.
+type registryEntry struct {
+	name    string
+	cluster bool
+	connect func() (registeredClient, error)
+
+	// mu serializes the creation of the client, without blocking the lookups of the other clients.
+	mu     sync.Mutex
+	client registeredClient
+}
+
+// Registry creates and caches named clients, e.g. "sessions", "cache" and "queues", each of them configured with a
+// configuration of its own, so that the code of a service looks clients up by name rather than through global
+// variables. A client is created the first time it's looked up, and then shared by all the lookups.
+//
+// Closing the registry closes its clients in the reverse order of their registration: register first the clients
+// the others depend on while shutting down, e.g. a client whose connection is used to report the shutdown.
+//
+// Example usage:
+//
+//	registry := glide.NewRegistry().
+//		WithClusterClient("sessions", sessionsConfig).
+//		WithClient("cache", cacheConfig)
+//	defer registry.Close()
+//
+//	sessions, err := registry.ClusterClient("sessions")
+type Registry struct {
+	mu      sync.Mutex
+	entries map[string]*registryEntry
+	// order holds the entries in the order of their registration.
+	order  []*registryEntry
+	closed bool
+}
+
+// NewRegistry returns a [Registry] without any client.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]*registryEntry)}
+}
+
+// WithClient registers a standalone client named name, created with clientConfig on first use. Registering a name
+// again replaces the configuration of the client, if it isn't created yet.
+func (registry *Registry) WithClient(name string, clientConfig *config.ClientConfiguration) *Registry {
+	return registry.register(name, false, func() (registeredClient, error) {
+		return NewClient(clientConfig)
+	})
+}
+
+// WithClusterClient registers a cluster client named name, created with clientConfig on first use. Registering a
+// name again replaces the configuration of the client, if it isn't created yet.
+func (registry *Registry) WithClusterClient(name string, clientConfig *config.ClusterClientConfiguration) *Registry {
+	return registry.register(name, true, func() (registeredClient, error) {
+		return NewClusterClient(clientConfig)
+	})
+}
+
+func (registry *Registry) register(name string, cluster bool, connect func() (registeredClient, error)) *Registry {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if entry, ok := registry.entries[name]; ok {
+		entry.mu.Lock()
+		defer entry.mu.Unlock()
+		if entry.client == nil {
+			entry.cluster = cluster
+			entry.connect = connect
+		}
+		return registry
+	}
+	entry := &registryEntry{name: name, cluster: cluster, connect: connect}
+	registry.entries[name] = entry
+	registry.order = append(registry.order, entry)
+	return registry
+}
+
+// Client returns the standalone client named name, creating it if it's the first lookup.
+//
+// Return value:
+//
+//	The client, or a [ConfigurationError] if no standalone client is registered with this name, a [ClosingError]
+//	if the registry is closed, or the error of the creation of the client, in which case the next lookup retries it.
+func (registry *Registry) Client(name string) (*Client, error) {
+	client, err := registry.lookup(name, false)
+	if err != nil {
+		return nil, err
+	}
+	return client.(*Client), nil
+}
+
+// ClusterClient returns the cluster client named name, creating it if it's the first lookup.
+//
+// Return value:
+//
+//	The client, or a [ConfigurationError] if no cluster client is registered with this name, a [ClosingError] if
+//	the registry is closed, or the error of the creation of the client, in which case the next lookup retries it.
+func (registry *Registry) ClusterClient(name string) (*ClusterClient, error) {
+	client, err := registry.lookup(name, true)
+	if err != nil {
+		return nil, err
+	}
+	return client.(*ClusterClient), nil
+}
+
+func (registry *Registry) lookup(name string, cluster bool) (registeredClient, error) {
+	registry.mu.Lock()
+	entry, ok := registry.entries[name]
+	closed := registry.closed
+	registry.mu.Unlock()
+	if closed {
+		return nil, NewClosingError("the registry is closed")
+	}
+	if !ok {
+		return nil, NewConfigurationError(fmt.Sprintf("no client is registered as %q", name))
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.cluster != cluster {
+		if entry.cluster {
+			return nil, NewConfigurationError(fmt.Sprintf("%q is registered as a cluster client", name))
+		}
+		return nil, NewConfigurationError(fmt.Sprintf("%q is registered as a standalone client", name))
+	}
+	if entry.client != nil {
+		return entry.client, nil
+	}
+	// Checked again with the entry locked, so that no client is created once Close closed the entry.
+	registry.mu.Lock()
+	closed = registry.closed
+	registry.mu.Unlock()
+	if closed {
+		return nil, NewClosingError("the registry is closed")
+	}
+	client, err := entry.connect()
+	if err != nil {
+		return nil, err
+	}
+	entry.client = client
+	return client, nil
+}
+
+// Names returns the names of the registered clients, in the order of their registration.
+func (registry *Registry) Names() []string {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	names := make([]string, len(registry.order))
+	for i, entry := range registry.order {
+		names[i] = entry.name
+	}
+	return names
+}
+
+// Health checks the clients created so far, as a [HealthHandler] would, and returns their reports by name.
+//
+// Parameters:
+//
+//	ctx - The context bounding the checks.
+//	ping - Whether the nodes of the clients are sent a PING, see [HealthHandler.WithPing].
+//
+// Return value:
+//
+//	The report of each client created so far, by name. The clients which weren't looked up yet aren't reported.
+func (registry *Registry) Health(ctx context.Context, ping bool) map[string]HealthReport {
+	clients := registry.createdClients()
+	reports := make(map[string]HealthReport, len(clients))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report := client.checkHealth(ctx, ping)
+			mu.Lock()
+			reports[name] = report
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return reports
+}
+
+func (registry *Registry) createdClients() map[string]registeredClient {
+	registry.mu.Lock()
+	order := registry.order
+	registry.mu.Unlock()
+	clients := make(map[string]registeredClient)
+	for _, entry := range order {
+		entry.mu.Lock()
+		if entry.client != nil {
+			clients[entry.name] = entry.client
+		}
+		entry.mu.Unlock()
+	}
+	return clients
+}
+
+// Close closes the clients of the registry in the reverse order of their registration, waiting for the clients
+// being created. The lookups fail with a [ClosingError] once the registry is closed.
+func (registry *Registry) Close() {
+	registry.mu.Lock()
+	if registry.closed {
+		registry.mu.Unlock()
+		return
+	}
+	registry.closed = true
+	order := registry.order
+	registry.mu.Unlock()
+
+	for i := len(order) - 1; i >= 0; i-- {
+		entry := order[i]
+		entry.mu.Lock()
+		if entry.client != nil {
+			entry.client.Close()
+			entry.client = nil
+		}
+		entry.mu.Unlock()
+	}
+}
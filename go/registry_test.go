@@ -0,0 +1,78 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRegisteredClient struct {
+	name   string
+	closed *[]string
+}
+
+func (client *fakeRegisteredClient) checkHealth(ctx context.Context, ping bool) HealthReport {
+	return HealthReport{Status: HealthOK}
+}
+
+func (client *fakeRegisteredClient) Close() {
+	*client.closed = append(*client.closed, client.name)
+}
+
+func TestRegistry_Lifecycle(t *testing.T) {
+	var closed []string
+	connects := 0
+	registry := NewRegistry()
+	for _, name := range []string{"sessions", "cache", "queues"} {
+		registry.register(name, true, func() (registeredClient, error) {
+			connects++
+			return &fakeRegisteredClient{name: name, closed: &closed}, nil
+		})
+	}
+	assert.Equal(t, []string{"sessions", "cache", "queues"}, registry.Names())
+
+	first, err := registry.lookup("sessions", true)
+	require.NoError(t, err)
+	second, err := registry.lookup("sessions", true)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	_, err = registry.lookup("queues", true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, connects)
+
+	reports := registry.Health(context.Background(), false)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, HealthOK, reports["sessions"].Status)
+
+	registry.Close()
+	assert.Equal(t, []string{"queues", "sessions"}, closed)
+	_, err = registry.lookup("cache", true)
+	assert.IsType(t, &ClosingError{}, err)
+	registry.Close()
+	assert.Len(t, closed, 2)
+}
+
+func TestRegistry_LookupErrors(t *testing.T) {
+	failures := 0
+	registry := NewRegistry().register("cache", false, func() (registeredClient, error) {
+		failures++
+		return nil, errors.New("connection refused")
+	})
+
+	_, err := registry.Client("sessions")
+	assert.IsType(t, &ConfigurationError{}, err)
+	_, err = registry.ClusterClient("cache")
+	assert.ErrorContains(t, err, "registered as a standalone client")
+
+	// Failed creations are retried by the next lookup.
+	_, err = registry.Client("cache")
+	assert.ErrorContains(t, err, "connection refused")
+	_, err = registry.Client("cache")
+	assert.Error(t, err)
+	assert.Equal(t, 2, failures)
+}
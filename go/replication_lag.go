@@ -0,0 +1,129 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// ReplicationLag measures how far the replicas of the server are behind it, from the replication offsets of the
+// replication section of its INFO. The server reports no replicas if it is itself a replica.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The lag of each replica of the server, ordered by address.
+//
+// [valkey.io]: https://valkey.io/commands/info/
+func (client *Client) ReplicationLag(ctx context.Context) ([]models.ReplicaLag, error) {
+	info, err := client.InfoWithOptions(
+		ctx,
+		options.InfoOptions{Sections: []constants.Section{constants.Replication}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return parseReplicaLags("", info)
+}
+
+// ReplicationLag measures how far the replicas of all the primaries of the cluster are behind them, from the
+// replication offsets of the replication section of the INFO of the primaries.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The lag of each replica of the cluster, ordered by primary and replica address.
+//
+// [valkey.io]: https://valkey.io/commands/info/
+func (client *ClusterClient) ReplicationLag(ctx context.Context) ([]models.ReplicaLag, error) {
+	infos, err := client.InfoWithOptions(ctx, options.ClusterInfoOptions{
+		InfoOptions: &options.InfoOptions{Sections: []constants.Section{constants.Replication}},
+		RouteOption: &options.RouteOption{Route: config.AllPrimaries},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var lags []models.ReplicaLag
+	for primary, info := range infos.MultiValue() {
+		primaryLags, err := parseReplicaLags(primary, info)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replication information of %s: %w", primary, err)
+		}
+		lags = append(lags, primaryLags...)
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].Primary != lags[j].Primary {
+			return lags[i].Primary < lags[j].Primary
+		}
+		return lags[i].Replica < lags[j].Replica
+	})
+	return lags, nil
+}
+
+// parseReplicaLags returns the lags of the replicas of primary from the replication section of its INFO, ordered by
+// address. Replicas have no replicas to report.
+func parseReplicaLags(primary string, info string) ([]models.ReplicaLag, error) {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if name, value, found := strings.Cut(strings.TrimSpace(line), ":"); found {
+			fields[name] = value
+		}
+	}
+	if fields["role"] != "master" {
+		return nil, nil
+	}
+	primaryOffset, err := strconv.ParseInt(fields["master_repl_offset"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid master_repl_offset: %w", err)
+	}
+	var lags []models.ReplicaLag
+	for name, value := range fields {
+		if !strings.HasPrefix(name, "slave") || strings.HasPrefix(name, "slave_") {
+			continue
+		}
+		// e.g. slave0:ip=10.0.0.2,port=6379,state=online,offset=2124,lag=0
+		replica := make(map[string]string)
+		for _, pair := range strings.Split(value, ",") {
+			if key, value, found := strings.Cut(pair, "="); found {
+				replica[key] = value
+			}
+		}
+		offset, err := strconv.ParseInt(replica["offset"], 10, 64)
+		if err != nil && replica["state"] == "online" {
+			return nil, fmt.Errorf("invalid offset of %s: %w", name, err)
+		}
+		lagSeconds, _ := strconv.ParseInt(replica["lag"], 10, 64)
+		lags = append(lags, models.ReplicaLag{
+			Primary:       primary,
+			Replica:       net.JoinHostPort(replica["ip"], replica["port"]),
+			State:         replica["state"],
+			PrimaryOffset: primaryOffset,
+			Offset:        offset,
+			OffsetLag:     max(primaryOffset-offset, 0),
+			Lag:           time.Duration(lagSeconds) * time.Second,
+		})
+	}
+	sort.Slice(lags, func(i, j int) bool { return lags[i].Replica < lags[j].Replica })
+	return lags, nil
+}
@@ -0,0 +1,61 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestParseReplicaLags(t *testing.T) {
+	info := "# Replication\r\nrole:master\r\nconnected_slaves:3\r\n" +
+		"slave1:ip=10.0.0.3,port=6379,state=online,offset=2000,lag=2\r\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=2124,lag=0\r\n" +
+		"slave2:ip=10.0.0.4,port=6379,state=wait_bgsave,offset=0,lag=0\r\n" +
+		"master_failover_state:no-failover\r\nmaster_repl_offset:2124\r\nslave_read_repl_offset:2124\r\n"
+
+	lags, err := parseReplicaLags("10.0.0.1:6379", info)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ReplicaLag{
+		{
+			Primary:       "10.0.0.1:6379",
+			Replica:       "10.0.0.2:6379",
+			State:         "online",
+			PrimaryOffset: 2124,
+			Offset:        2124,
+		},
+		{
+			Primary:       "10.0.0.1:6379",
+			Replica:       "10.0.0.3:6379",
+			State:         "online",
+			PrimaryOffset: 2124,
+			Offset:        2000,
+			OffsetLag:     124,
+			Lag:           2 * time.Second,
+		},
+		{
+			Primary:       "10.0.0.1:6379",
+			Replica:       "10.0.0.4:6379",
+			State:         "wait_bgsave",
+			PrimaryOffset: 2124,
+			OffsetLag:     2124,
+		},
+	}, lags)
+
+	assert.True(t, lags[0].Within(0, 0))
+	assert.False(t, lags[1].Within(100, time.Second))
+	assert.True(t, lags[1].Within(1000, 5*time.Second))
+	assert.False(t, lags[2].Within(1<<20, time.Minute))
+}
+
+func TestParseReplicaLags_Replica(t *testing.T) {
+	lags, err := parseReplicaLags("", "# Replication\r\nrole:slave\r\nmaster_host:10.0.0.1\r\nslave_repl_offset:2124\r\n")
+	assert.NoError(t, err)
+	assert.Empty(t, lags)
+
+	_, err = parseReplicaLags("", "# Replication\r\nrole:master\r\n")
+	assert.Error(t, err)
+}
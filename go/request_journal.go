@@ -0,0 +1,607 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+)
+
+// DefaultJournalBufferSize is the number of commands, or batches, whose entries a [RequestJournal] buffers for its
+// sink, unless configured otherwise.
+const DefaultJournalBufferSize = 1024
+
+// JournalRedacted replaces the arguments of the journaled commands which aren't keys, unless the journal is configured
+// with another sanitizer, see [RequestJournal.WithSanitizer].
+const JournalRedacted = "[redacted]"
+
+// ErrJournalFull is the error of the write commands of a client rejected because the buffer of its [RequestJournal]
+// is full, see [JournalReject].
+var ErrJournalFull = errors.New("the request journal is full")
+
+// JournalBackpressure is the behavior of the write commands of a client whose [RequestJournal] buffer is full, because
+// its sink doesn't keep up.
+type JournalBackpressure int
+
+const (
+	// JournalBlock makes the write commands wait for room in the buffer before being sent, or fail with the error of
+	// their context once it's done.
+	JournalBlock JournalBackpressure = iota
+	// JournalReject makes the write commands fail with [ErrJournalFull] without being sent.
+	JournalReject
+)
+
+// JournalEntry is a write command issued by a client, as journaled by a [RequestJournal].
+type JournalEntry struct {
+	// Sequence numbers the entries of the journal from 1, in the order their commands were issued, or completed for
+	// the blocking commands, see [RequestJournal].
+	Sequence uint64
+	// Time is the time the command was issued at.
+	Time time.Time
+	// Duration is the time the command took, until its response or its error.
+	Duration time.Duration
+	// Name is the name of the command in upper case, e.g. "SET" or "JSON.SET", and "EVALSHA" for scripts.
+	Name string
+	// Keys are the keys of the command, with the keys prefixed for the view of the client which issued it, or nil if
+	// the keys of the command aren't known.
+	Keys []string
+	// Args are the arguments of the command following its name, as sanitized by the journal.
+	Args []string
+	// Node is the address of the node the command was routed to, when it was routed to a given node with
+	// [config.ByAddressRoute]. It's empty otherwise, as the node is then picked by the client.
+	Node string
+	// Batch is set for the commands of a batch.
+	Batch bool
+	// Err is the error of the command, or nil if it succeeded. The commands of a batch which failed as a whole have
+	// the error of the batch. A command which timed out may still have been applied.
+	Err error
+}
+
+// JournalSink stores the entries of a [RequestJournal], e.g. in an append-only file or an audit log service.
+type JournalSink interface {
+	// Write stores entry. It's called by a single goroutine, in the order of the entries.
+	Write(entry JournalEntry) error
+}
+
+// JournalSinkFunc is a [JournalSink] function.
+type JournalSinkFunc func(entry JournalEntry) error
+
+// Write calls sink with entry.
+func (sink JournalSinkFunc) Write(entry JournalEntry) error {
+	return sink(entry)
+}
+
+// journalLine is a [JournalEntry] as written by the sinks of [NewJSONLinesJournalSink].
+type journalLine struct {
+	Sequence uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	Duration int64     `json:"duration_us"`
+	Name     string    `json:"command"`
+	Keys     []string  `json:"keys,omitempty"`
+	Args     []string  `json:"args"`
+	Node     string    `json:"node,omitempty"`
+	Batch    bool      `json:"batch,omitempty"`
+	Err      string    `json:"error,omitempty"`
+}
+
+// NewJSONLinesJournalSink returns a [JournalSink] writing each entry to w as a line of JSON, e.g.
+//
+//	{"seq":1,"time":"2024-05-01T10:00:00Z","duration_us":180,"command":"SET","keys":["user:1"],
+//	"args":["user:1","[redacted]"]}
+//
+// on a single line.
+func NewJSONLinesJournalSink(w io.Writer) JournalSink {
+	encoder := json.NewEncoder(w)
+	return JournalSinkFunc(func(entry JournalEntry) error {
+		line := journalLine{
+			Sequence: entry.Sequence,
+			Time:     entry.Time,
+			Duration: entry.Duration.Microseconds(),
+			Name:     entry.Name,
+			Keys:     entry.Keys,
+			Args:     entry.Args,
+			Node:     entry.Node,
+			Batch:    entry.Batch,
+		}
+		if entry.Err != nil {
+			line.Err = entry.Err.Error()
+		}
+		return encoder.Encode(line)
+	})
+}
+
+// JournalStats reports the activity of a [RequestJournal].
+type JournalStats struct {
+	// Written is the number of entries written to the sink.
+	Written uint64
+	// Failed is the number of entries the sink failed to write.
+	Failed uint64
+	// Rejected is the number of write commands rejected with [ErrJournalFull].
+	Rejected uint64
+	// Pending is the number of commands, or batches, whose entries are buffered, because they're in progress or their
+	// entries wait for the sink. The blocking commands in progress aren't buffered.
+	Pending int
+}
+
+// journalRecord holds the entries of a command, or of the commands of a batch, buffered by a [RequestJournal], whose
+// command is in progress until done is closed.
+type journalRecord struct {
+	entries []JournalEntry
+	done    chan struct{}
+	// slot is set if the record holds a slot of the buffer, which the blocking commands don't.
+	slot bool
+}
+
+// RequestJournal journals the write commands issued by a [Client] or a [ClusterClient] with JournalWrites, e.g. for
+// the audits of the mutations of a cache: their names, keys, sanitized arguments, timestamps and outcomes.
+//
+// The entries are written to the sink by a single goroutine, in the order the commands were issued, each one once its
+// command completed: an entry waits for the completion of the commands issued before. The entries are buffered
+// meanwhile, and once the buffer is full, the write commands wait for room in it, or fail without being sent, as
+// configured by [RequestJournal.WithBackpressure]: no write command is sent without being journaled. An entry the
+// sink fails to write is reported to the error handler, and counted in [JournalStats.Failed].
+//
+// The blocking commands, e.g. BLPOP, may wait indefinitely, so they neither wait for room in the buffer nor hold the
+// entries of the commands issued after them: their entries are numbered and buffered once they complete, in the order
+// of their completion. The entries of the blocking commands completing once the journal is closed are reported as
+// failed to write.
+//
+// The write commands are the commands changing or deleting keys, the scripts and the functions, including the ones
+// of batches, unless configured otherwise with [RequestJournal.WithFilter]. The arguments which aren't keys are
+// replaced with JournalRedacted, unless configured otherwise with [RequestJournal.WithSanitizer].
+//
+// Example usage:
+//
+//	file, err := os.OpenFile("audit.jsonl", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+//	journal := glide.NewRequestJournal(glide.NewJSONLinesJournalSink(file))
+//	client.JournalWrites(journal)
+//	defer journal.Close()
+//	defer client.JournalWrites(nil)
+type RequestJournal struct {
+	sink         JournalSink
+	bufferSize   int
+	backpressure JournalBackpressure
+	filter       func(name string) bool
+	sanitize     func(name string, args []string) []string
+	onError      func(entry JournalEntry, err error)
+
+	start    sync.Once
+	mu       sync.Mutex
+	sequence uint64
+	// queue holds the records buffered, in the order of their sequence numbers, which wake signals.
+	queue []*journalRecord
+	wake  *sync.Cond
+	// slots holds a value per record buffered holding a slot, to bound their number. They're acquired without holding
+	// mu, so that the writers waiting for room don't block the others.
+	slots   chan struct{}
+	closed  bool
+	stopped chan struct{}
+
+	written  atomic.Uint64
+	failed   atomic.Uint64
+	rejected atomic.Uint64
+}
+
+// NewRequestJournal returns a [RequestJournal] writing the entries of the write commands to sink.
+func NewRequestJournal(sink JournalSink) *RequestJournal {
+	return &RequestJournal{
+		sink:       sink,
+		bufferSize: DefaultJournalBufferSize,
+		filter:     isJournaledWrite,
+		stopped:    make(chan struct{}),
+	}
+}
+
+// WithBufferSize sets the number of commands, or batches, whose entries are buffered for the sink. Defaults to
+// DefaultJournalBufferSize. It must be set before the journal is used.
+func (journal *RequestJournal) WithBufferSize(bufferSize int) *RequestJournal {
+	journal.bufferSize = bufferSize
+	return journal
+}
+
+// WithBackpressure sets the behavior of the write commands once the buffer is full. Defaults to JournalBlock.
+func (journal *RequestJournal) WithBackpressure(backpressure JournalBackpressure) *RequestJournal {
+	journal.backpressure = backpressure
+	return journal
+}
+
+// WithFilter sets the function telling whether the commands named name, e.g. "SET" or "EVALSHA", are journaled.
+// Defaults to the commands changing or deleting keys, the scripts and the functions.
+func (journal *RequestJournal) WithFilter(filter func(name string) bool) *RequestJournal {
+	journal.filter = filter
+	return journal
+}
+
+// WithSanitizer sets the function returning the arguments journaled for the arguments of a command named name, e.g.
+// to keep some options verbatim, or to hash values. The arguments passed to sanitize must not be modified. Defaults to
+// replacing the arguments which aren't keys with JournalRedacted.
+func (journal *RequestJournal) WithSanitizer(sanitize func(name string, args []string) []string) *RequestJournal {
+	journal.sanitize = sanitize
+	return journal
+}
+
+// WithErrorHandler sets a function called with the entries the sink fails to write, and its error. Calls are made
+// from the goroutine writing the entries: the following entries wait for it to return.
+func (journal *RequestJournal) WithErrorHandler(onError func(entry JournalEntry, err error)) *RequestJournal {
+	journal.onError = onError
+	return journal
+}
+
+// Stats returns the activity of the journal.
+func (journal *RequestJournal) Stats() JournalStats {
+	journal.init()
+	return JournalStats{
+		Written:  journal.written.Load(),
+		Failed:   journal.failed.Load(),
+		Rejected: journal.rejected.Load(),
+		Pending:  len(journal.slots),
+	}
+}
+
+// Close waits until the entries buffered are written, and stops the journal. The write commands of the clients still
+// journaling their writes with it fail with a [ClosingError] once it's closed: clients should stop journaling with it,
+// with JournalWrites(nil), before it's closed.
+func (journal *RequestJournal) Close() {
+	journal.mu.Lock()
+	if journal.closed {
+		journal.mu.Unlock()
+		<-journal.stopped
+		return
+	}
+	journal.closed = true
+	started := journal.wake != nil
+	if started {
+		journal.wake.Broadcast()
+	}
+	journal.mu.Unlock()
+	if !started {
+		close(journal.stopped)
+	}
+	<-journal.stopped
+}
+
+// init creates the buffer and starts the goroutine writing the entries, unless the journal is closed already.
+func (journal *RequestJournal) init() {
+	journal.start.Do(func() {
+		journal.mu.Lock()
+		defer journal.mu.Unlock()
+		journal.slots = make(chan struct{}, max(journal.bufferSize, 1))
+		if !journal.closed {
+			journal.wake = sync.NewCond(&journal.mu)
+			go journal.run()
+		}
+	})
+}
+
+// run writes the entries of the buffered records to the sink once their command completed.
+func (journal *RequestJournal) run() {
+	defer close(journal.stopped)
+	for {
+		journal.mu.Lock()
+		for len(journal.queue) == 0 && !journal.closed {
+			journal.wake.Wait()
+		}
+		if len(journal.queue) == 0 {
+			journal.mu.Unlock()
+			return
+		}
+		record := journal.queue[0]
+		journal.queue[0] = nil
+		journal.queue = journal.queue[1:]
+		journal.mu.Unlock()
+
+		<-record.done
+		for _, entry := range record.entries {
+			if err := journal.sink.Write(entry); err != nil {
+				journal.fail(entry, err)
+				continue
+			}
+			journal.written.Add(1)
+		}
+		if record.slot {
+			<-journal.slots
+		}
+	}
+}
+
+// fail reports entry, which couldn't be written because of err.
+func (journal *RequestJournal) fail(entry JournalEntry, err error) {
+	journal.failed.Add(1)
+	if journal.onError != nil {
+		journal.onError(entry, err)
+	}
+}
+
+// journaled returns the entry of the command of requestType called with args, the arguments being prefixed for the
+// view of the client, or false if the command isn't journaled.
+func (journal *RequestJournal) journaled(requestType uint32, args []string, route config.Route) (JournalEntry, bool) {
+	command := capturedCommand(requestType, args, route)
+	if !journal.filter(command.Name) {
+		return JournalEntry{}, false
+	}
+	layoutName, layoutArgs := commandNameArgs(requestType, args)
+	return journal.entry(command.Name, layoutName, layoutArgs, command.Node), true
+}
+
+// entry returns the entry of the command named name, whose key layout is named layoutName, called with args.
+func (journal *RequestJournal) entry(name string, layoutName string, args []string, node string) JournalEntry {
+	entry := JournalEntry{Name: name, Node: node}
+	indexes, known, err := keyIndexes(layoutName, args)
+	if !known || err != nil {
+		indexes = nil
+	} else {
+		entry.Keys = make([]string, len(indexes))
+		for i, index := range indexes {
+			entry.Keys[i] = args[index]
+		}
+	}
+	if journal.sanitize != nil {
+		entry.Args = journal.sanitize(name, args)
+		return entry
+	}
+	entry.Args = make([]string, len(args))
+	for i := range entry.Args {
+		entry.Args[i] = JournalRedacted
+	}
+	for _, index := range indexes {
+		entry.Args[index] = args[index]
+	}
+	return entry
+}
+
+// begin buffers the entries of commands issued together, e.g. the commands of a batch, once there's room in the
+// buffer, and returns the function recording the outcome of each command, given the index of its entry. It returns
+// ErrJournalFull, the error of ctx, or a ClosingError if the commands must not be sent. The entries of blocking
+// commands are only buffered once the commands complete.
+func (journal *RequestJournal) begin(ctx context.Context, entries []JournalEntry) (func(errs func(i int) error), error) {
+	journal.init()
+	if journal.isClosed() {
+		return nil, NewClosingError("the request journal is closed")
+	}
+	record := &journalRecord{entries: entries, done: make(chan struct{}), slot: !isJournalBlocking(entries)}
+	now := time.Now()
+	for i := range entries {
+		entries[i].Time = now
+	}
+	finish := func(errs func(i int) error) {
+		duration := time.Since(now)
+		for i := range entries {
+			entries[i].Duration = duration
+			entries[i].Err = errs(i)
+		}
+		close(record.done)
+	}
+	if !record.slot {
+		return func(errs func(i int) error) {
+			finish(errs)
+			if !journal.enqueue(record) {
+				for _, entry := range entries {
+					journal.fail(entry, NewClosingError("the request journal is closed"))
+				}
+			}
+		}, nil
+	}
+
+	if journal.backpressure == JournalReject {
+		select {
+		case journal.slots <- struct{}{}:
+		default:
+			journal.rejected.Add(1)
+			return nil, ErrJournalFull
+		}
+	} else {
+		select {
+		case journal.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if !journal.enqueue(record) {
+		<-journal.slots
+		return nil, NewClosingError("the request journal is closed")
+	}
+	return finish, nil
+}
+
+// isClosed reports whether the journal is closed.
+func (journal *RequestJournal) isClosed() bool {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	return journal.closed
+}
+
+// enqueue numbers the entries of record and buffers it for the sink, unless the journal is closed.
+func (journal *RequestJournal) enqueue(record *journalRecord) bool {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	if journal.closed {
+		return false
+	}
+	// The entries are numbered once buffered, in the order of the buffer, which journal.mu serializes.
+	for i := range record.entries {
+		journal.sequence++
+		record.entries[i].Sequence = journal.sequence
+	}
+	journal.queue = append(journal.queue, record)
+	journal.wake.Signal()
+	return true
+}
+
+// isJournalBlocking reports whether the commands of entries include a blocking command, which may wait indefinitely.
+func isJournalBlocking(entries []JournalEntry) bool {
+	for _, entry := range entries {
+		if journalBlockingCommands[entry.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// journalBlockingCommands are the blocking commands, whose entries are buffered once they complete.
+var journalBlockingCommands = map[string]bool{
+	"BLPOP": true, "BRPOP": true, "BLMOVE": true, "BRPOPLPUSH": true, "BLMPOP": true, "BZPOPMIN": true,
+	"BZPOPMAX": true, "BZMPOP": true, "XREAD": true, "XREADGROUP": true,
+}
+
+// isJournaledWrite reports whether the commands named name are journaled by default: the commands changing or
+// deleting keys, the scripts and the functions.
+func isJournaledWrite(name string) bool {
+	return journaledWrites[name] || strings.HasPrefix(name, "EVAL") || strings.HasPrefix(name, "FCALL")
+}
+
+// journaledWrites are the commands changing or deleting keys, journaled by default.
+var journaledWrites = map[string]bool{
+	"SET": true, "SETEX": true, "PSETEX": true, "SETNX": true, "SETRANGE": true, "GETSET": true, "GETDEL": true,
+	"GETEX": true, "APPEND": true, "INCR": true, "INCRBY": true, "INCRBYFLOAT": true, "DECR": true, "DECRBY": true,
+	"MSET": true, "MSETNX": true, "SETBIT": true, "BITFIELD": true, "BITOP": true,
+	"DEL": true, "UNLINK": true, "EXPIRE": true, "EXPIREAT": true, "PEXPIRE": true, "PEXPIREAT": true, "PERSIST": true,
+	"RENAME": true, "RENAMENX": true, "COPY": true, "MOVE": true, "RESTORE": true, "SORT": true,
+	"HSET": true, "HSETNX": true, "HMSET": true, "HDEL": true, "HINCRBY": true, "HINCRBYFLOAT": true, "HSETEX": true,
+	"HGETEX": true, "HGETDEL": true, "HEXPIRE": true, "HEXPIREAT": true, "HPEXPIRE": true, "HPEXPIREAT": true,
+	"HPERSIST": true,
+	"LPUSH":    true, "LPUSHX": true, "RPUSH": true, "RPUSHX": true, "LPOP": true, "RPOP": true, "LINSERT": true,
+	"LSET": true, "LREM": true, "LTRIM": true, "LMOVE": true, "BLMOVE": true, "RPOPLPUSH": true, "BRPOPLPUSH": true,
+	"BLPOP": true, "BRPOP": true, "LMPOP": true, "BLMPOP": true,
+	"SADD": true, "SREM": true, "SPOP": true, "SMOVE": true, "SINTERSTORE": true, "SUNIONSTORE": true,
+	"SDIFFSTORE": true,
+	"ZADD":       true, "ZINCRBY": true, "ZREM": true, "ZREMRANGEBYRANK": true, "ZREMRANGEBYSCORE": true,
+	"ZREMRANGEBYLEX": true, "ZPOPMIN": true, "ZPOPMAX": true, "BZPOPMIN": true, "BZPOPMAX": true, "ZMPOP": true,
+	"BZMPOP": true, "ZRANGESTORE": true, "ZINTERSTORE": true, "ZUNIONSTORE": true, "ZDIFFSTORE": true,
+	"XADD": true, "XDEL": true, "XTRIM": true, "XACK": true, "XCLAIM": true, "XAUTOCLAIM": true,
+	"XGROUP CREATE": true, "XGROUP DESTROY": true, "XGROUP CREATECONSUMER": true, "XGROUP DELCONSUMER": true,
+	"XGROUP SETID": true,
+	"PFADD":        true, "PFMERGE": true, "GEOADD": true, "GEOSEARCHSTORE": true,
+	"JSON.SET": true, "JSON.DEL": true, "JSON.FORGET": true, "JSON.MSET": true, "JSON.MERGE": true,
+	"JSON.NUMINCRBY": true, "JSON.NUMMULTBY": true, "JSON.STRAPPEND": true, "JSON.ARRAPPEND": true,
+	"JSON.ARRINSERT": true, "JSON.ARRPOP": true, "JSON.ARRTRIM": true, "JSON.TOGGLE": true, "JSON.CLEAR": true,
+	"FLUSHALL": true, "FLUSHDB": true, "SWAPDB": true,
+}
+
+// journalCommand buffers the entry of the command of requestType, if the client journals its writes and the command
+// is journaled, and returns the function recording its outcome, or nil. It returns an error if the command must not
+// be sent.
+func (client *baseClient) journalCommand(
+	ctx context.Context,
+	requestType uint32,
+	args []string,
+	route config.Route,
+) (func(err error), error) {
+	if client.journal == nil {
+		return nil, nil
+	}
+	journal := client.journal.Load()
+	if journal == nil {
+		return nil, nil
+	}
+	entry, ok := journal.journaled(requestType, args, route)
+	if !ok {
+		return nil, nil
+	}
+	finish, err := journal.begin(ctx, []JournalEntry{entry})
+	if err != nil {
+		return nil, err
+	}
+	return func(err error) { finish(func(int) error { return err }) }, nil
+}
+
+// journalScript buffers the entry of the EVALSHA of the script of hash, if the client journals its writes and EVALSHA
+// is journaled, and returns the function recording its outcome, or nil. It returns an error if the script must not
+// be sent.
+func (client *baseClient) journalScript(
+	ctx context.Context,
+	hash string,
+	keys []string,
+	args []string,
+	route config.Route,
+) (func(err error), error) {
+	if client.journal == nil {
+		return nil, nil
+	}
+	journal := client.journal.Load()
+	if journal == nil || !journal.filter("EVALSHA") {
+		return nil, nil
+	}
+	scriptArgs := append(append([]string{hash, strconv.Itoa(len(keys))}, keys...), args...)
+	entry := journal.entry("EVALSHA", "EVALSHA", scriptArgs, routeNode(route))
+	finish, err := journal.begin(ctx, []JournalEntry{entry})
+	if err != nil {
+		return nil, err
+	}
+	return func(err error) { finish(func(int) error { return err }) }, nil
+}
+
+// journalBatch buffers the entries of the journaled commands of batch, if the client journals its writes, and
+// returns the function recording their outcomes given the results of the batch, or nil. It returns an error if the
+// batch must not be sent.
+func (client *baseClient) journalBatch(
+	ctx context.Context,
+	batch internal.Batch,
+	options *internal.BatchOptions,
+) (func(result []any, err error), error) {
+	if client.journal == nil {
+		return nil, nil
+	}
+	journal := client.journal.Load()
+	if journal == nil {
+		return nil, nil
+	}
+	var route config.Route
+	if options != nil {
+		route = options.Route
+	}
+	var entries []JournalEntry
+	// indexes holds the index of the command of each entry in the batch.
+	var indexes []int
+	for i, command := range batch.Commands {
+		if entry, ok := journal.journaled(command.RequestType, command.Args, route); ok {
+			entry.Batch = true
+			entries = append(entries, entry)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	finish, err := journal.begin(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	return func(result []any, err error) {
+		finish(func(i int) error {
+			if err != nil {
+				return err
+			}
+			if index := indexes[i]; index < len(result) {
+				if commandErr, ok := result[index].(error); ok {
+					return commandErr
+				}
+			}
+			return nil
+		})
+	}, nil
+}
+
+// JournalWrites makes the client, and its views, journal the write commands they issue with journal, until it's
+// called again. A nil journal stops the journaling.
+//
+// The commands are journaled once prefixed for the view which issued them, before they're sent, and their outcome
+// once they complete: the commands which fail, or time out, are journaled too.
+//
+// Parameters:
+//
+//	journal - The journal of the write commands, or nil.
+func (client *baseClient) JournalWrites(journal *RequestJournal) {
+	if client.journal != nil {
+		client.journal.Store(journal)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+// journalEntries records the entries written to a RequestJournal.
+type journalEntries struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+}
+
+func (sink *journalEntries) Write(entry JournalEntry) error {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	sink.entries = append(sink.entries, entry)
+	return nil
+}
+
+func (sink *journalEntries) written() []JournalEntry {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	return append([]JournalEntry(nil), sink.entries...)
+}
+
+func newJournalTestClient(journal *RequestJournal) *baseClient {
+	client := &baseClient{journal: &atomic.Pointer[RequestJournal]{}}
+	client.JournalWrites(journal)
+	return client
+}
+
+func TestRequestJournal_Order(t *testing.T) {
+	sink := &journalEntries{}
+	journal := NewRequestJournal(sink)
+	client := newJournalTestClient(journal)
+	ctx := context.Background()
+
+	finishSet, err := client.journalCommand(ctx, uint32(protobuf.RequestType_Set),
+		[]string{"user:1", "secret", "EX", "10"}, nil)
+	require.NoError(t, err)
+	finishGet, err := client.journalCommand(ctx, uint32(protobuf.RequestType_Get), []string{"user:1"}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, finishGet)
+	finishDel, err := client.journalCommand(ctx, uint32(protobuf.RequestType_CustomCommand),
+		[]string{"del", "user:2", "user:3"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, journal.Stats().Pending)
+
+	// The DEL completes first, but is journaled after the SET issued before.
+	delErr := errors.New("READONLY You can't write against a read only replica.")
+	finishDel(delErr)
+	finishSet(nil)
+	journal.Close()
+
+	entries := sink.written()
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(1), entries[0].Sequence)
+	assert.Equal(t, "SET", entries[0].Name)
+	assert.Equal(t, []string{"user:1"}, entries[0].Keys)
+	assert.Equal(t, []string{"user:1", JournalRedacted, JournalRedacted, JournalRedacted}, entries[0].Args)
+	assert.NoError(t, entries[0].Err)
+	assert.Equal(t, uint64(2), entries[1].Sequence)
+	assert.Equal(t, "DEL", entries[1].Name)
+	assert.Equal(t, []string{"user:2", "user:3"}, entries[1].Args)
+	assert.Equal(t, delErr, entries[1].Err)
+	assert.False(t, entries[1].Time.Before(entries[0].Time))
+	assert.Equal(t, JournalStats{Written: 2}, journal.Stats())
+
+	_, err = client.journalCommand(ctx, uint32(protobuf.RequestType_Set), []string{"user:1", "value"}, nil)
+	assert.IsType(t, &ClosingError{}, err)
+}
+
+func TestRequestJournal_Batch(t *testing.T) {
+	sink := &journalEntries{}
+	journal := NewRequestJournal(sink)
+	client := newJournalTestClient(journal)
+
+	batch := internal.Batch{Commands: []internal.Cmd{
+		{RequestType: uint32(protobuf.RequestType_Set), Args: []string{"a", "1"}},
+		{RequestType: uint32(protobuf.RequestType_Get), Args: []string{"a"}},
+		{RequestType: uint32(protobuf.RequestType_Incr), Args: []string{"a"}},
+	}}
+	finish, err := client.journalBatch(context.Background(), batch, nil)
+	require.NoError(t, err)
+	incrErr := NewRequestError("ERR value is not an integer or out of range")
+	finish([]any{"OK", "1", incrErr}, nil)
+	journal.Close()
+
+	entries := sink.written()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "SET", entries[0].Name)
+	assert.True(t, entries[0].Batch)
+	assert.NoError(t, entries[0].Err)
+	assert.Equal(t, "INCR", entries[1].Name)
+	assert.Equal(t, incrErr, entries[1].Err)
+}
+
+func TestRequestJournal_Backpressure(t *testing.T) {
+	journal := NewRequestJournal(&journalEntries{}).WithBufferSize(1).WithBackpressure(JournalReject)
+	client := newJournalTestClient(journal)
+	args := []string{"key", "value"}
+
+	finish, err := client.journalCommand(context.Background(), uint32(protobuf.RequestType_Set), args, nil)
+	require.NoError(t, err)
+	_, err = client.journalCommand(context.Background(), uint32(protobuf.RequestType_Set), args, nil)
+	assert.ErrorIs(t, err, ErrJournalFull)
+	assert.Equal(t, uint64(1), journal.Stats().Rejected)
+
+	// Blocking commands give up once their context is done.
+	journal.WithBackpressure(JournalBlock)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.journalCommand(ctx, uint32(protobuf.RequestType_Set), args, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	finish(nil)
+	finish, err = client.journalCommand(context.Background(), uint32(protobuf.RequestType_Set), args, nil)
+	require.NoError(t, err)
+	finish(nil)
+	journal.Close()
+	assert.Equal(t, uint64(2), journal.Stats().Written)
+}
+
+func TestRequestJournal_BlockedHead(t *testing.T) {
+	sink := &journalEntries{}
+	journal := NewRequestJournal(sink).WithBufferSize(1)
+	client := newJournalTestClient(journal)
+	args := []string{"key", "value"}
+
+	// The command holding the only slot doesn't complete: the writers waiting for room give up with their context,
+	// without blocking the others.
+	finishHead, err := client.journalCommand(context.Background(), uint32(protobuf.RequestType_Set), args, nil)
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, err := client.journalCommand(ctx, uint32(protobuf.RequestType_Set), args, nil)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}()
+	}
+	waited := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the writers waiting for room in the journal didn't give up with their context")
+	}
+	assert.Equal(t, 1, journal.Stats().Pending)
+
+	// A blocking command neither waits for room nor holds the entries of the commands issued after it.
+	finishBLPop, err := client.journalCommand(context.Background(), uint32(protobuf.RequestType_BLPop),
+		[]string{"queue", "0"}, nil)
+	require.NoError(t, err)
+	finishHead(nil)
+	finishSet, err := client.journalCommand(context.Background(), uint32(protobuf.RequestType_Set), args, nil)
+	require.NoError(t, err)
+	finishSet(nil)
+	assert.Eventually(t, func() bool { return journal.Stats().Written == 2 }, time.Second, time.Millisecond)
+	finishBLPop(nil)
+
+	// The blocking commands completing once the journal is closed are reported as failed.
+	finishBLPop, err = client.journalCommand(context.Background(), uint32(protobuf.RequestType_BLPop),
+		[]string{"queue", "0"}, nil)
+	require.NoError(t, err)
+	journal.Close()
+	finishBLPop(nil)
+
+	entries := sink.written()
+	require.Len(t, entries, 3)
+	assert.Equal(t, []string{"SET", "SET", "BLPOP"}, []string{entries[0].Name, entries[1].Name, entries[2].Name})
+	assert.Equal(t, uint64(3), entries[2].Sequence)
+	assert.Equal(t, uint64(1), journal.Stats().Failed)
+}
+
+func TestRequestJournal_SinkErrors(t *testing.T) {
+	sinkErr := errors.New("disk full")
+	var failed []JournalEntry
+	journal := NewRequestJournal(JournalSinkFunc(func(entry JournalEntry) error { return sinkErr })).
+		WithErrorHandler(func(entry JournalEntry, err error) {
+			assert.Equal(t, sinkErr, err)
+			failed = append(failed, entry)
+		}).
+		WithSanitizer(func(name string, args []string) []string { return args })
+	client := newJournalTestClient(journal)
+
+	finish, err := client.journalScript(context.Background(), "abc123", []string{"key"}, []string{"arg"}, nil)
+	require.NoError(t, err)
+	finish(nil)
+	journal.Close()
+
+	require.Len(t, failed, 1)
+	assert.Equal(t, "EVALSHA", failed[0].Name)
+	assert.Equal(t, []string{"key"}, failed[0].Keys)
+	assert.Equal(t, []string{"abc123", "1", "key", "arg"}, failed[0].Args)
+	assert.Equal(t, JournalStats{Failed: 1}, journal.Stats())
+}
+
+func TestJSONLinesJournalSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewJSONLinesJournalSink(&out)
+	require.NoError(t, sink.Write(JournalEntry{
+		Sequence: 7,
+		Name:     "DEL",
+		Keys:     []string{"user:1"},
+		Args:     []string{"user:1"},
+		Err:      errors.New("READONLY"),
+	}))
+	assert.Equal(t,
+		`{"seq":7,"time":"0001-01-01T00:00:00Z","duration_us":0,"command":"DEL","keys":["user:1"],"args":["user:1"],`+
+			`"error":"READONLY"}`+"\n",
+		out.String())
+}
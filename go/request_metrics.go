@@ -0,0 +1,194 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// requestLatencyBuckets are the upper bounds of the latency buckets of the request metrics.
+var requestLatencyBuckets = []time.Duration{
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// commandKey returns the first key of command called with args, or "" if it has no keys, or they aren't known.
+func commandKey(command string, args []string) string {
+	indexes, known, err := keyIndexes(command, args)
+	if err != nil || !known || len(indexes) == 0 {
+		return ""
+	}
+	return args[indexes[0]]
+}
+
+// requestMetricSeries accumulates the requests recorded with a set of labels.
+type requestMetricSeries struct {
+	requests   uint64
+	errors     map[string]uint64
+	latencySum time.Duration
+	// buckets[i] counts the requests which completed in requestLatencyBuckets[i] or less, but more than the
+	// previous bound.
+	buckets []uint64
+}
+
+// requestMetrics records the requests of a client, labelled as configured by a [config.MetricsConfiguration].
+type requestMetrics struct {
+	commandLabel       bool
+	keyPrefixDelimiter string
+	maxKeyPrefixes     int
+
+	mu     sync.Mutex
+	series map[models.RequestMetricLabels]*requestMetricSeries
+	// keyPrefixes are the key prefixes tracked so far, at most maxKeyPrefixes.
+	keyPrefixes map[string]struct{}
+}
+
+func newRequestMetrics(metricsConfig *config.MetricsConfiguration) *requestMetrics {
+	delimiter, maxPrefixes := metricsConfig.GetKeyPrefixLabel()
+	return &requestMetrics{
+		commandLabel:       metricsConfig.HasCommandLabel(),
+		keyPrefixDelimiter: delimiter,
+		maxKeyPrefixes:     maxPrefixes,
+		series:             make(map[models.RequestMetricLabels]*requestMetricSeries),
+		keyPrefixes:        make(map[string]struct{}),
+	}
+}
+
+// record records a request which completed in latency, and failed with err if it isn't nil.
+func (m *requestMetrics) record(request requestDescription, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	labels := m.labels(request)
+	series, ok := m.series[labels]
+	if !ok {
+		series = &requestMetricSeries{errors: make(map[string]uint64), buckets: make([]uint64, len(requestLatencyBuckets))}
+		m.series[labels] = series
+	}
+	series.requests++
+	series.latencySum += latency
+	if bucket := sort.Search(len(requestLatencyBuckets), func(i int) bool {
+		return latency <= requestLatencyBuckets[i]
+	}); bucket < len(requestLatencyBuckets) {
+		series.buckets[bucket]++
+	}
+	if err != nil {
+		series.errors[errorKind(err)]++
+	}
+}
+
+// labels returns the labels of request. It must be called with m.mu held.
+func (m *requestMetrics) labels(request requestDescription) models.RequestMetricLabels {
+	var labels models.RequestMetricLabels
+	if m.commandLabel {
+		labels.Command = request.command
+	}
+	if m.keyPrefixDelimiter != "" && request.key != "" {
+		prefix, _, _ := strings.Cut(request.key, m.keyPrefixDelimiter)
+		if _, tracked := m.keyPrefixes[prefix]; !tracked {
+			if len(m.keyPrefixes) >= m.maxKeyPrefixes {
+				prefix = config.OtherKeyPrefix
+			} else {
+				m.keyPrefixes[prefix] = struct{}{}
+			}
+		}
+		labels.KeyPrefix = prefix
+	}
+	return labels
+}
+
+// snapshot returns the metrics of each series, ordered by labels.
+func (m *requestMetrics) snapshot() []models.RequestMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make([]models.RequestMetrics, 0, len(m.series))
+	for labels, series := range m.series {
+		metrics := models.RequestMetrics{
+			Labels:         labels,
+			Requests:       series.requests,
+			Errors:         make(map[string]uint64, len(series.errors)),
+			LatencySum:     series.latencySum,
+			LatencyBuckets: make([]models.LatencyBucket, len(requestLatencyBuckets)),
+		}
+		for kind, count := range series.errors {
+			metrics.Errors[kind] = count
+		}
+		var count uint64
+		for i, bound := range requestLatencyBuckets {
+			count += series.buckets[i]
+			metrics.LatencyBuckets[i] = models.LatencyBucket{UpperBound: bound, Count: count}
+		}
+		snapshot = append(snapshot, metrics)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		a, b := snapshot[i].Labels, snapshot[j].Labels
+		if a.Command != b.Command {
+			return a.Command < b.Command
+		}
+		return a.KeyPrefix < b.KeyPrefix
+	})
+	return snapshot
+}
+
+// errorKind returns the kind of err reported by the request metrics.
+func errorKind(err error) string {
+	var requestErr *RequestError
+	var timeoutErr *TimeoutError
+	var disconnectErr *DisconnectError
+	var closingErr *ClosingError
+	switch {
+	case errors.As(err, &requestErr) && requestErr.Code() != "":
+		return requestErr.Code()
+	case errors.Is(err, ErrExecAbort):
+		return "EXECABORT"
+	case errors.As(err, &timeoutErr):
+		return "TIMEOUT"
+	case errors.As(err, &disconnectErr):
+		return "DISCONNECT"
+	case errors.As(err, &closingErr):
+		return "CLOSING"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "CONTEXT"
+	default:
+		return "OTHER"
+	}
+}
+
+// recordRequest records a request started at start in the request metrics of the client, if they're enabled.
+func (client *baseClient) recordRequest(request requestDescription, start time.Time, err error) {
+	if client.metrics != nil {
+		client.metrics.record(request, time.Since(start), err)
+	}
+}
+
+// GetRequestMetrics returns the metrics of the requests of the client, per set of labels, when the client is
+// configured with a [config.MetricsConfiguration].
+//
+// Return value:
+//
+//	The number of requests, their errors and their latency for each set of labels recorded since the client was
+//	created, ordered by labels, or nil if the request metrics of the client are disabled.
+func (client *baseClient) GetRequestMetrics() []models.RequestMetrics {
+	if client.metrics == nil {
+		return nil
+	}
+	return client.metrics.snapshot()
+}
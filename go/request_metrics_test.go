@@ -0,0 +1,92 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestCommandKey(t *testing.T) {
+	assert.Equal(t, "user:1", commandKey("GET", []string{"user:1"}))
+	assert.Equal(t, "user:1", commandKey("OBJECTENCODING", []string{"user:1"}))
+	assert.Equal(t, "", commandKey("PING", []string{"hello"}))
+	assert.Equal(t, "", commandKey("CONFIGGET", []string{"maxmemory"}))
+	assert.Equal(t, "", commandKey("DBSIZE", nil))
+	assert.Equal(t, "a", commandKey("SINTERCARD", []string{"2", "a", "b"}))
+	assert.Equal(t, "dest", commandKey("ZUNIONSTORE", []string{"dest", "2", "a", "b"}))
+	assert.Equal(t, "", commandKey("NOTACOMMAND", []string{"key"}))
+}
+
+func TestRequestMetrics_Labels(t *testing.T) {
+	metrics := newRequestMetrics(config.NewMetricsConfiguration())
+	metrics.record(requestDescription{command: "GET", key: "user:1"}, time.Millisecond, nil)
+	metrics.record(requestDescription{command: "GET", node: "10.0.0.1:6379", key: "user:2"}, time.Millisecond, nil)
+	metrics.record(requestDescription{command: "SET", key: "user:1"}, time.Millisecond, nil)
+
+	snapshot := metrics.snapshot()
+	require.Len(t, snapshot, 2)
+	assert.Equal(t, models.RequestMetricLabels{Command: "GET"}, snapshot[0].Labels)
+	assert.Equal(t, uint64(2), snapshot[0].Requests)
+	assert.Equal(t, models.RequestMetricLabels{Command: "SET"}, snapshot[1].Labels)
+}
+
+func TestRequestMetrics_DroppedLabels(t *testing.T) {
+	metrics := newRequestMetrics(config.NewMetricsConfiguration().WithCommandLabel(false))
+	metrics.record(requestDescription{command: "GET", node: "10.0.0.1:6379"}, time.Millisecond, nil)
+	metrics.record(requestDescription{command: "SET", node: "10.0.0.2:6379"}, time.Millisecond, nil)
+
+	snapshot := metrics.snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, models.RequestMetricLabels{}, snapshot[0].Labels)
+	assert.Equal(t, uint64(2), snapshot[0].Requests)
+}
+
+func TestRequestMetrics_KeyPrefixes(t *testing.T) {
+	metrics := newRequestMetrics(config.NewMetricsConfiguration().WithCommandLabel(false).
+		WithKeyPrefixLabel(":", 2))
+	for _, key := range []string{"user:1", "user:2", "session:1", "cart:1", "order:1", ""} {
+		metrics.record(requestDescription{command: "GET", key: key}, time.Millisecond, nil)
+	}
+
+	requests := make(map[string]uint64)
+	for _, series := range metrics.snapshot() {
+		requests[series.Labels.KeyPrefix] = series.Requests
+	}
+	assert.Equal(t, map[string]uint64{"user": 2, "session": 1, config.OtherKeyPrefix: 2, "": 1}, requests)
+}
+
+func TestRequestMetrics_ErrorsAndLatencies(t *testing.T) {
+	metrics := newRequestMetrics(config.NewMetricsConfiguration())
+	request := requestDescription{command: "INCR"}
+	metrics.record(request, 300*time.Microsecond, nil)
+	metrics.record(request, 3*time.Millisecond, NewRequestError("WRONGTYPE Operation against a key"))
+	metrics.record(request, 20*time.Millisecond, NewTimeoutError("timed out"))
+	metrics.record(request, time.Minute, context.DeadlineExceeded)
+	metrics.record(request, time.Millisecond, errors.New("unexpected"))
+
+	snapshot := metrics.snapshot()
+	require.Len(t, snapshot, 1)
+	series := snapshot[0]
+	assert.Equal(t, uint64(5), series.Requests)
+	assert.Equal(t, map[string]uint64{"WRONGTYPE": 1, "TIMEOUT": 1, "CONTEXT": 1, "OTHER": 1}, series.Errors)
+	assert.Equal(t, time.Minute+24300*time.Microsecond, series.LatencySum)
+
+	counts := make(map[time.Duration]uint64)
+	for _, bucket := range series.LatencyBuckets {
+		counts[bucket.UpperBound] = bucket.Count
+	}
+	assert.Equal(t, uint64(1), counts[500*time.Microsecond])
+	assert.Equal(t, uint64(2), counts[time.Millisecond])
+	assert.Equal(t, uint64(3), counts[5*time.Millisecond])
+	assert.Equal(t, uint64(4), counts[25*time.Millisecond])
+	assert.Equal(t, uint64(4), counts[5*time.Second])
+}
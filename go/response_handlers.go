@@ -8,13 +8,16 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
 	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
 	"github.com/valkey-io/valkey-glide/go/v2/models"
 	"github.com/valkey-io/valkey-glide/go/v2/options"
 )
@@ -37,6 +40,10 @@ func checkResponseType(response *C.struct_CommandResponse, expectedType C.Respon
 	if response.response_type == expectedTypeInt {
 		return nil
 	}
+	// Verbatim strings are read as strings, without their format.
+	if expectedType == C.String && response.response_type == uint32(C.VerbatimString) {
+		return nil
+	}
 
 	actualTypeStr := C.get_response_type_string(response.response_type)
 	return fmt.Errorf(
@@ -56,6 +63,9 @@ func convertCharArrayToString(response *C.struct_CommandResponse, isNilable bool
 		return models.CreateNilStringResult(), nil
 	}
 	byteSlice := C.GoBytes(unsafe.Pointer(response.string_value), C.int(int64(response.string_value_len)))
+	if response.response_type == C.VerbatimString {
+		return models.CreateStringResult(toVerbatimString(string(byteSlice)).Text), nil
+	}
 
 	// Create Go string from byte slice (preserving null characters)
 	return models.CreateStringResult(string(byteSlice)), nil
@@ -114,6 +124,12 @@ func convertToStringArray(input []any) ([]string, error) {
 }
 
 func parseInterface(response *C.struct_CommandResponse) (any, error) {
+	return parseValue(response, false)
+}
+
+// parseValue parses a response into a Go value. With preserveTypes, verbatim strings are parsed into
+// models.VerbatimString, keeping their format, instead of strings.
+func parseValue(response *C.struct_CommandResponse, preserveTypes bool) (any, error) {
 	if response == nil {
 		return nil, nil
 	}
@@ -123,6 +139,21 @@ func parseInterface(response *C.struct_CommandResponse) (any, error) {
 		return nil, nil
 	case C.String:
 		return parseString(response)
+	case C.VerbatimString:
+		if !preserveTypes {
+			return parseString(response)
+		}
+		text, err := stringValue(response)
+		if err != nil {
+			return nil, err
+		}
+		return toVerbatimString(text), nil
+	case C.BigNumber:
+		text, err := stringValue(response)
+		if err != nil {
+			return nil, err
+		}
+		return parseBigNumber(text)
 	case C.Int:
 		return int64(response.int_value), nil
 	case C.Float:
@@ -130,9 +161,9 @@ func parseInterface(response *C.struct_CommandResponse) (any, error) {
 	case C.Bool:
 		return bool(response.bool_value), nil
 	case C.Array:
-		return parseArray(response)
+		return parseArrayValue(response, preserveTypes)
 	case C.Map:
-		return parseMap(response)
+		return parseMapValue(response, preserveTypes)
 	case C.Sets:
 		return parseSet(response)
 	case C.Ok:
@@ -146,7 +177,7 @@ func parseInterface(response *C.struct_CommandResponse) (any, error) {
 		if !ok {
 			return nil, errors.New("error message isn't a string")
 		}
-		return errors.New(errStrString), nil
+		return NewRequestError(errStrString), nil
 	}
 
 	return nil, errors.New("unexpected return type from Valkey")
@@ -157,19 +188,52 @@ func parseString(response *C.struct_CommandResponse) (any, error) {
 		return nil, nil
 	}
 	byteSlice := C.GoBytes(unsafe.Pointer(response.string_value), C.int(int64(response.string_value_len)))
+	if response.response_type == C.VerbatimString {
+		return toVerbatimString(string(byteSlice)).Text, nil
+	}
 
 	// Create Go string from byte slice (preserving null characters)
 	return string(byteSlice), nil
 }
 
+// stringValue returns the string value of a response, as sent by the core.
+func stringValue(response *C.struct_CommandResponse) (string, error) {
+	if response.string_value == nil {
+		return "", errors.New("unexpected nil string value")
+	}
+	return C.GoStringN(response.string_value, C.int(response.string_value_len)), nil
+}
+
+// toVerbatimString splits a verbatim string sent by the core, e.g. "txt:Some string", into its format and text.
+func toVerbatimString(value string) models.VerbatimString {
+	format, text, found := strings.Cut(value, ":")
+	if !found || len(format) != 3 {
+		return models.VerbatimString{Text: value}
+	}
+	return models.VerbatimString{Format: format, Text: text}
+}
+
+// parseBigNumber parses the decimal representation of a big number.
+func parseBigNumber(value string) (*big.Int, error) {
+	number, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid big number: %q", value)
+	}
+	return number, nil
+}
+
 func parseArray(response *C.struct_CommandResponse) (any, error) {
+	return parseArrayValue(response, false)
+}
+
+func parseArrayValue(response *C.struct_CommandResponse, preserveTypes bool) (any, error) {
 	if response.array_value == nil {
 		return nil, nil
 	}
 
 	var slice []any
 	for _, v := range unsafe.Slice(response.array_value, response.array_value_len) {
-		res, err := parseInterface(&v)
+		res, err := parseValue(&v, preserveTypes)
 		if err != nil {
 			return nil, err
 		}
@@ -179,6 +243,10 @@ func parseArray(response *C.struct_CommandResponse) (any, error) {
 }
 
 func parseMap(response *C.struct_CommandResponse) (any, error) {
+	return parseMapValue(response, false)
+}
+
+func parseMapValue(response *C.struct_CommandResponse, preserveTypes bool) (any, error) {
 	if response.array_value == nil {
 		return nil, nil
 	}
@@ -189,7 +257,7 @@ func parseMap(response *C.struct_CommandResponse) (any, error) {
 		if err != nil {
 			return nil, err
 		}
-		res_val, err := parseInterface(v.map_value)
+		res_val, err := parseValue(v.map_value, preserveTypes)
 		if err != nil {
 			return nil, err
 		}
@@ -342,6 +410,24 @@ func handleInterfaceResponse(response *C.struct_CommandResponse) (any, error) {
 	return parseInterface(response)
 }
 
+func handleAttributedResponse(response *C.struct_CommandResponse) (models.AttributedValue, error) {
+	defer C.free_command_response(response)
+
+	value, err := parseValue(response, true)
+	if err != nil {
+		return models.AttributedValue{}, err
+	}
+	if response == nil || response.attributes == nil {
+		return models.AttributedValue{Value: value}, nil
+	}
+	attributes, err := parseMapValue(response.attributes, true)
+	if err != nil {
+		return models.AttributedValue{}, err
+	}
+	attributesMap, _ := attributes.(map[string]any)
+	return models.AttributedValue{Value: value, Attributes: attributesMap}, nil
+}
+
 func handleStringResponse(response *C.struct_CommandResponse) (string, error) {
 	defer C.free_command_response(response)
 
@@ -498,6 +584,16 @@ func handleStringArrayResponse(response *C.struct_CommandResponse) ([]string, er
 	return convertStringArray(response, false)
 }
 
+// handleChannelArrayResponse handles a list of Pub/Sub channels. Channels are listed once, even if the response
+// aggregates the channels of several cluster nodes that have subscribers of the same channel.
+func handleChannelArrayResponse(response *C.struct_CommandResponse) ([]string, error) {
+	channels, err := handleStringArrayResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Unique(channels), nil
+}
+
 func handleStringArrayOrNilResponse(response *C.struct_CommandResponse) ([]string, error) {
 	defer C.free_command_response(response)
 
@@ -711,6 +807,37 @@ func handleStringDoubleMapResponse(response *C.struct_CommandResponse) (map[stri
 	return result, nil
 }
 
+func handleClientInfoResponse(response *C.struct_CommandResponse) (map[string]string, error) {
+	info, err := handleStringResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return parseClientInfo(info), nil
+}
+
+func handleClientInfoMapResponse(response *C.struct_CommandResponse) (map[string]map[string]string, error) {
+	infos, err := handleStringToStringMapResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string, len(infos))
+	for node, info := range infos {
+		result[node] = parseClientInfo(info)
+	}
+	return result, nil
+}
+
+// parseClientInfo parses the properties of a connection, formatted by CLIENT INFO and CLIENT LIST as
+// space-separated "property=value" pairs, e.g. "id=3 addr=127.0.0.1:53126 name= db=0".
+func parseClientInfo(info string) map[string]string {
+	properties := make(map[string]string)
+	for _, pair := range strings.Fields(info) {
+		property, value, _ := strings.Cut(pair, "=")
+		properties[property] = value
+	}
+	return properties
+}
+
 func handleStringToStringMapResponse(response *C.struct_CommandResponse) (map[string]string, error) {
 	defer C.free_command_response(response)
 
@@ -935,6 +1062,27 @@ func handleMemberAndScoreArrayResponse(response *C.struct_CommandResponse) ([]mo
 	return result, nil
 }
 
+func handleFieldValueArrayResponse(response *C.struct_CommandResponse) ([]models.FieldValue, error) {
+	defer C.free_command_response(response)
+
+	typeErr := checkResponseType(response, C.Array, false)
+	if typeErr != nil {
+		return nil, typeErr
+	}
+
+	slice, err := parseArray(response)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.FieldValue, 0, len(slice.([]any)))
+	for _, arr := range slice.([]any) {
+		pair := arr.([]any)
+		result = append(result, models.FieldValue{Field: pair[0].(string), Value: pair[1].(string)})
+	}
+	return result, nil
+}
+
 func handleScanResponse(response *C.struct_CommandResponse) (models.ScanResult, error) {
 	defer C.free_command_response(response)
 
@@ -1625,6 +1773,31 @@ func handleFunctionListMultiNodeResponse(response *C.struct_CommandResponse) (ma
 	return multiNodeLibs, nil
 }
 
+func handleModuleListResponse(response *C.struct_CommandResponse) ([]models.ServerModule, error) {
+	data, err := handleAnyResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return parseServerModules(data)
+}
+
+func handleModuleListMultiNodeResponse(response *C.struct_CommandResponse) (map[string][]models.ServerModule, error) {
+	data, err := handleStringToAnyMapResponse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	multiNodeModules := make(map[string][]models.ServerModule, len(data))
+	for node, nodeData := range data {
+		modules, err := parseServerModules(nodeData)
+		if err != nil {
+			return nil, err
+		}
+		multiNodeModules[node] = modules
+	}
+	return multiNodeModules, nil
+}
+
 func handleSortedSetWithScoresResponse(response *C.struct_CommandResponse, reverse bool) ([]models.MemberAndScore, error) {
 	defer C.free_command_response(response)
 
@@ -1799,3 +1972,120 @@ func handleStringToArrayOfMapsMapResponse(
 
 	return resultMap, nil
 }
+
+// searchReplyDocument is a document of the reply of FT.SEARCH: its key and its fields.
+type searchReplyDocument struct {
+	key    string
+	fields map[string]string
+}
+
+// handleSearchResponse returns the total number of documents and the documents of the reply of FT.SEARCH, which the
+// core converts to the total followed by a map of the documents by key. The documents are kept in the order of the
+// reply, i.e. of the search.
+func handleSearchResponse(response *C.struct_CommandResponse) (int64, []searchReplyDocument, error) {
+	defer C.free_command_response(response)
+
+	typeErr := checkResponseType(response, C.Array, false)
+	if typeErr != nil {
+		return 0, nil, typeErr
+	}
+	elements := unsafe.Slice(response.array_value, response.array_value_len)
+	if len(elements) == 0 {
+		return 0, nil, fmt.Errorf("unexpected empty reply of FT.SEARCH")
+	}
+	if err := checkResponseType(&elements[0], C.Int, false); err != nil {
+		return 0, nil, err
+	}
+	total := int64(elements[0].int_value)
+	if len(elements) == 1 {
+		return total, nil, nil
+	}
+	if err := checkResponseType(&elements[1], C.Map, false); err != nil {
+		return 0, nil, err
+	}
+	entries := unsafe.Slice(elements[1].array_value, elements[1].array_value_len)
+	documents := make([]searchReplyDocument, 0, len(entries))
+	for _, entry := range entries {
+		key, err := parseString(entry.map_key)
+		if err != nil {
+			return 0, nil, err
+		}
+		fields, err := parseMap(entry.map_value)
+		if err != nil {
+			return 0, nil, err
+		}
+		values, _ := fields.(map[string]any)
+		document := searchReplyDocument{key: key.(string), fields: make(map[string]string, len(values))}
+		for name, value := range values {
+			if value != nil {
+				document.fields[name] = fmt.Sprint(value)
+			}
+		}
+		documents = append(documents, document)
+	}
+	return total, documents, nil
+}
+
+// parseHMGetMulti returns the values of fields in the hashes of keys from the responses of the HMGETs of HMGetMulti.
+func parseHMGetMulti(keys []string, fields []string, responses []any) (map[string]map[string]models.Result[string], error) {
+	if len(responses) != len(keys) {
+		return nil, fmt.Errorf("unexpected number of responses to HMGET: %d for %d keys", len(responses), len(keys))
+	}
+	values := make(map[string]map[string]models.Result[string], len(keys))
+	for i, key := range keys {
+		if err, ok := responses[i].(error); ok {
+			return nil, fmt.Errorf("HMGET of %q failed: %w", key, err)
+		}
+		var elements []any
+		if responses[i] != nil {
+			var ok bool
+			if elements, ok = responses[i].([]any); !ok || len(elements) != len(fields) {
+				return nil, fmt.Errorf("unexpected response to HMGET of %q: %v", key, responses[i])
+			}
+		}
+		keyValues := make(map[string]models.Result[string], len(fields))
+		for j, field := range fields {
+			keyValues[field] = models.CreateNilStringResult()
+			if elements == nil || elements[j] == nil {
+				continue
+			}
+			value, ok := elements[j].(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected type of the value of %q of %q: %T", field, key, elements[j])
+			}
+			keyValues[field] = models.CreateStringResult(value)
+		}
+		values[key] = keyValues
+	}
+	return values, nil
+}
+
+// parseSnapshotRead parses the responses to the WATCH and the MGET of keys pipelined by SnapshotRead.
+func parseSnapshotRead(keys []string, responses []any) ([]models.Result[string], error) {
+	if len(responses) != 2 {
+		return nil, fmt.Errorf("unexpected number of responses to the snapshot read: %d", len(responses))
+	}
+	if err, ok := responses[0].(error); ok {
+		return nil, fmt.Errorf("WATCH failed: %w", err)
+	}
+	if err, ok := responses[1].(error); ok {
+		return nil, fmt.Errorf("MGET failed: %w", err)
+	}
+	elements, ok := responses[1].([]any)
+	if !ok || len(elements) != len(keys) {
+		return nil, fmt.Errorf("unexpected response to MGET: %v", responses[1])
+	}
+	values := make([]models.Result[string], len(keys))
+	for i, element := range elements {
+		if element == nil {
+			values[i] = models.CreateNilStringResult()
+			continue
+		}
+		value, ok := element.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type of the value of %q: %T", keys[i], element)
+		}
+		values[i] = models.CreateStringResult(value)
+	}
+	return values, nil
+}
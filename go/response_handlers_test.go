@@ -0,0 +1,85 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestToVerbatimString(t *testing.T) {
+	assert.Equal(t, models.VerbatimString{Format: "txt", Text: "Some string"}, toVerbatimString("txt:Some string"))
+	assert.Equal(t, models.VerbatimString{Format: "mkd", Text: "# Title: subtitle"}, toVerbatimString("mkd:# Title: subtitle"))
+	assert.Equal(t, models.VerbatimString{Format: "txt", Text: ""}, toVerbatimString("txt:"))
+	assert.Equal(t, models.VerbatimString{Text: "no format"}, toVerbatimString("no format"))
+	assert.Equal(t, "Some string", toVerbatimString("txt:Some string").String())
+}
+
+func TestParseBigNumber(t *testing.T) {
+	expected, _ := new(big.Int).SetString("3492890328409238509324850943850943825024385", 10)
+	number, err := parseBigNumber("3492890328409238509324850943850943825024385")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expected.Cmp(number))
+
+	number, err = parseBigNumber("-12")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-12), number.Int64())
+
+	_, err = parseBigNumber("12.5")
+	assert.Error(t, err)
+}
+
+func TestParseClientInfo(t *testing.T) {
+	info := "id=3 addr=127.0.0.1:53126 laddr=127.0.0.1:6379 fd=8 name= db=0 cmd=client|info user=default\n"
+	assert.Equal(t, map[string]string{
+		"id":    "3",
+		"addr":  "127.0.0.1:53126",
+		"laddr": "127.0.0.1:6379",
+		"fd":    "8",
+		"name":  "",
+		"db":    "0",
+		"cmd":   "client|info",
+		"user":  "default",
+	}, parseClientInfo(info))
+	assert.Empty(t, parseClientInfo(""))
+}
+
+func TestParseHMGetMulti(t *testing.T) {
+	values, err := parseHMGetMulti(
+		[]string{"user:1", "user:2", "user:3"},
+		[]string{"name", "email"},
+		[]any{[]any{"Ada", nil}, []any{nil, nil}, nil},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]models.Result[string]{
+		"user:1": {"name": models.CreateStringResult("Ada"), "email": models.CreateNilStringResult()},
+		"user:2": {"name": models.CreateNilStringResult(), "email": models.CreateNilStringResult()},
+		"user:3": {"name": models.CreateNilStringResult(), "email": models.CreateNilStringResult()},
+	}, values)
+
+	_, err = parseHMGetMulti(
+		[]string{"user:1"},
+		[]string{"name"},
+		[]any{errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")},
+	)
+	assert.EqualError(t, err, `HMGET of "user:1" failed: WRONGTYPE Operation against a key holding the wrong kind of value`)
+	_, err = parseHMGetMulti([]string{"user:1"}, []string{"name"}, []any{})
+	assert.Error(t, err)
+}
+
+func TestParseSnapshotRead(t *testing.T) {
+	values, err := parseSnapshotRead([]string{"balance", "limit"}, []any{"OK", []any{"100", nil}})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Result[string]{models.CreateStringResult("100"), models.CreateNilStringResult()}, values)
+
+	_, err = parseSnapshotRead([]string{"balance"}, []any{errors.New("ERR WATCH inside MULTI is not allowed"), nil})
+	assert.EqualError(t, err, "WATCH failed: ERR WATCH inside MULTI is not allowed")
+	_, err = parseSnapshotRead([]string{"balance"}, []any{"OK", []any{}})
+	assert.Error(t, err)
+}
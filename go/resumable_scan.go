@@ -0,0 +1,315 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// nodeScanner scans the keys of the nodes of a server or a cluster one node at a time, for a [ResumableScan].
+type nodeScanner interface {
+	// scanNodes returns the nodes whose keys are scanned.
+	scanNodes(ctx context.Context) ([]string, error)
+	// scanNode returns the next cursor of node, "0" once its scan is finished, and the keys of the page of cursor.
+	scanNode(ctx context.Context, node string, cursor string, scanOptions options.ScanOptions) (string, []string, error)
+}
+
+// resumableScanState is the position of a [ResumableScan], as serialized in its cursor.
+type resumableScanState struct {
+	// Nodes holds the SCAN cursor of each node whose scan isn't finished, by address.
+	Nodes map[string]string `json:"nodes"`
+}
+
+// ResumableScan scans the keys of a server, or of all the primaries of a cluster, with SCAN, from a position which can
+// be exported as an opaque cursor and resumed from, e.g. so that multi-hour audit scans survive process restarts.
+//
+// Unlike the cursor of a cluster scan, which is held by the client, the cursor of a ResumableScan holds the SCAN
+// cursor of each primary, so that it can be persisted. The primaries are scanned one after the other, in the order of
+// their addresses. A scan resumed after a failover or a slot migration may miss or return again the keys moved
+// between the primaries, and fails if a primary it was scanning is no longer reachable at its address.
+//
+// The COUNT hint of the pages can be tuned to the keyspace along the scan, see [ResumableScan.WithAdaptiveCount].
+//
+// A ResumableScan must not be used concurrently.
+//
+// Example usage:
+//
+//	scan, err := clusterClient.ResumableScan(savedCursor, *options.NewScanOptions().SetMatch("user:*"))
+//	for err == nil && !scan.Finished() {
+//		var keys []string
+//		if keys, err = scan.Next(ctx); err == nil {
+//			audit(keys)
+//			save(scan.Cursor())
+//		}
+//	}
+type ResumableScan struct {
+	scanner     nodeScanner
+	scanOptions options.ScanOptions
+	// state is nil until the nodes are listed by the first call to Next.
+	state *resumableScanState
+	// adaptive is nil unless the COUNT of the pages is tuned to the keyspace.
+	adaptive *options.AdaptiveCountOptions
+	stats    ResumableScanStats
+}
+
+// ResumableScanStats reports the progress of a [ResumableScan] since it was created or resumed.
+type ResumableScanStats struct {
+	// Pages is the number of pages scanned.
+	Pages int64
+	// Keys is the number of keys returned.
+	Keys int64
+	// Count is the COUNT hint of the next page, or 0 if the pages are scanned with the default COUNT of the server.
+	Count int64
+	// CountChanges is the number of times an adaptive COUNT was changed.
+	CountChanges int64
+	// LastLatency is the time the last page took.
+	LastLatency time.Duration
+}
+
+func newResumableScan(scanner nodeScanner, cursor string, scanOptions options.ScanOptions) (*ResumableScan, error) {
+	scan := &ResumableScan{scanner: scanner, scanOptions: scanOptions}
+	scan.stats.Count = scanOptions.Count
+	if cursor == "" {
+		return scan, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scan cursor %q: %w", cursor, err)
+	}
+	scan.state = &resumableScanState{}
+	if err := json.Unmarshal(decoded, scan.state); err != nil {
+		return nil, fmt.Errorf("invalid scan cursor %q: %w", cursor, err)
+	}
+	return scan, nil
+}
+
+// WithAdaptiveCount tunes the COUNT hint of the pages to the keyspace, after each page, from the number of keys it
+// returned and the time it took, as configured by adaptiveOptions. The first page is scanned with the COUNT of the
+// scan options, within the range of adaptiveOptions, or with its lowest COUNT if the scan options have none. The
+// COUNT isn't saved in the cursor, a resumed scan tunes it again. Passing nil scans with the COUNT of the scan options.
+func (scan *ResumableScan) WithAdaptiveCount(adaptiveOptions *options.AdaptiveCountOptions) *ResumableScan {
+	scan.adaptive = adaptiveOptions
+	scan.stats.Count = scan.scanOptions.Count
+	if adaptiveOptions != nil {
+		if scan.stats.Count <= 0 {
+			scan.stats.Count = adaptiveOptions.MinCount
+		}
+		scan.stats.Count = min(max(scan.stats.Count, adaptiveOptions.MinCount), adaptiveOptions.MaxCount)
+	}
+	return scan
+}
+
+// Stats returns the progress of the scan, and the COUNT hint of its next page.
+func (scan *ResumableScan) Stats() ResumableScanStats {
+	return scan.stats
+}
+
+// Next returns the next page of keys, which may be empty, and advances the scan. The nodes are listed by the first
+// call to Next of a new scan.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//
+// Return value:
+//
+//	The keys of the page. The position of the scan is unchanged if an error is returned, so that Next can be
+//	called again.
+func (scan *ResumableScan) Next(ctx context.Context) ([]string, error) {
+	if scan.adaptive != nil {
+		if err := scan.adaptive.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if scan.state == nil {
+		nodes, err := scan.scanner.scanNodes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		scan.state = &resumableScanState{Nodes: make(map[string]string, len(nodes))}
+		for _, node := range nodes {
+			scan.state.Nodes[node] = "0"
+		}
+	}
+	if scan.Finished() {
+		return nil, nil
+	}
+	node := scan.currentNode()
+	pageOptions := scan.scanOptions
+	pageOptions.Count = scan.stats.Count
+	start := time.Now()
+	next, keys, err := scan.scanner.scanNode(ctx, node, scan.state.Nodes[node], pageOptions)
+	if err != nil {
+		return nil, err
+	}
+	scan.recordPage(int64(len(keys)), time.Since(start))
+	if next == "0" {
+		delete(scan.state.Nodes, node)
+	} else {
+		scan.state.Nodes[node] = next
+	}
+	return keys, nil
+}
+
+// recordPage updates the stats of the scan with a page which returned keys keys and took latency, and tunes the COUNT
+// of the next page if the COUNT is adaptive.
+func (scan *ResumableScan) recordPage(keys int64, latency time.Duration) {
+	scan.stats.Pages++
+	scan.stats.Keys += keys
+	scan.stats.LastLatency = latency
+	if scan.adaptive == nil {
+		return
+	}
+	if count := scan.adaptive.NextCount(scan.stats.Count, keys, latency); count != scan.stats.Count {
+		scan.stats.Count = count
+		scan.stats.CountChanges++
+	}
+}
+
+// currentNode returns the node scanned by the next call to Next, the first unfinished node by address.
+func (scan *ResumableScan) currentNode() string {
+	nodes := make([]string, 0, len(scan.state.Nodes))
+	for node := range scan.state.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes[0]
+}
+
+// Finished reports whether the keys of all the nodes were scanned.
+func (scan *ResumableScan) Finished() bool {
+	return scan.state != nil && len(scan.state.Nodes) == 0
+}
+
+// Cursor returns the position of the scan as an opaque URL-safe cursor, to resume the scan from with ResumableScan,
+// with the same scan options. The cursor of a new scan, before the first call to Next, is "".
+func (scan *ResumableScan) Cursor() string {
+	if scan.state == nil {
+		return ""
+	}
+	encoded, _ := json.Marshal(scan.state)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// ResumableScan returns a [ResumableScan] scanning the keys of the server with scanOptions, from cursor, the Cursor
+// of a previous scan, or from the start if cursor is "".
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	cursor - The cursor to resume the scan from, or "" to start a new scan.
+//	scanOptions - The scan options. Can specify MATCH, COUNT, and TYPE configurations.
+//
+// Return value:
+//
+//	The scan, or an error if the cursor is invalid.
+//
+// [valkey.io]: https://valkey.io/commands/scan/
+func (client *Client) ResumableScan(cursor string, scanOptions options.ScanOptions) (*ResumableScan, error) {
+	return newResumableScan(client, cursor, scanOptions)
+}
+
+func (client *Client) scanNodes(ctx context.Context) ([]string, error) {
+	return []string{""}, nil
+}
+
+func (client *Client) scanNode(
+	ctx context.Context,
+	node string,
+	cursor string,
+	scanOptions options.ScanOptions,
+) (string, []string, error) {
+	result, err := client.ScanWithOptions(ctx, models.NewCursorFromString(cursor), scanOptions)
+	if err != nil {
+		return "", nil, err
+	}
+	return result.Cursor.String(), result.Data, nil
+}
+
+// ResumableScan returns a [ResumableScan] scanning the keys of all the primaries of the cluster with scanOptions, from
+// cursor, the Cursor of a previous scan, or from the start if cursor is "".
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	cursor - The cursor to resume the scan from, or "" to start a new scan.
+//	scanOptions - The scan options. Can specify MATCH, COUNT, and TYPE configurations.
+//
+// Return value:
+//
+//	The scan, or an error if the cursor is invalid.
+//
+// [valkey.io]: https://valkey.io/commands/scan/
+func (client *ClusterClient) ResumableScan(cursor string, scanOptions options.ScanOptions) (*ResumableScan, error) {
+	return newResumableScan(client, cursor, scanOptions)
+}
+
+func (client *ClusterClient) scanNodes(ctx context.Context) ([]string, error) {
+	pongs, err := client.CustomCommandWithRoute(ctx, []string{"PING"}, config.AllPrimaries)
+	if err != nil {
+		return nil, err
+	}
+	if !pongs.IsMultiValue() {
+		return nil, fmt.Errorf("unexpected response to PING of all the primaries: %v", pongs.SingleValue())
+	}
+	nodes := make([]string, 0, len(pongs.MultiValue()))
+	for node := range pongs.MultiValue() {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (client *ClusterClient) scanNode(
+	ctx context.Context,
+	node string,
+	cursor string,
+	scanOptions options.ScanOptions,
+) (string, []string, error) {
+	route, err := config.NewByAddressRouteWithHost(node)
+	if err != nil {
+		return "", nil, err
+	}
+	args, err := scanOptions.ToArgs()
+	if err != nil {
+		return "", nil, err
+	}
+	response, err := client.CustomCommandWithRoute(ctx, append([]string{"SCAN", cursor}, args...), route)
+	if err != nil {
+		return "", nil, err
+	}
+	return parseScanReply(response.SingleValue())
+}
+
+// parseScanReply returns the next cursor and the keys of the reply to a SCAN sent as a custom command.
+func parseScanReply(reply any) (string, []string, error) {
+	page, ok := reply.([]any)
+	if !ok || len(page) != 2 {
+		return "", nil, fmt.Errorf("unexpected reply to SCAN: %v", reply)
+	}
+	next, ok := page[0].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected cursor in the reply to SCAN: %v", page[0])
+	}
+	elements, ok := page[1].([]any)
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected keys in the reply to SCAN: %v", page[1])
+	}
+	keys := make([]string, len(elements))
+	for i, element := range elements {
+		if keys[i], ok = element.(string); !ok {
+			return "", nil, fmt.Errorf("unexpected key in the reply to SCAN: %v", element)
+		}
+	}
+	return next, keys, nil
+}
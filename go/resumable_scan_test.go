@@ -0,0 +1,172 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeNodeScanner serves the keys of each node by pages of two keys, the cursor being the index of the page.
+type fakeNodeScanner struct {
+	keys   map[string][]string
+	scans  []string
+	counts []int64
+	err    error
+}
+
+func (scanner *fakeNodeScanner) scanNodes(ctx context.Context) ([]string, error) {
+	nodes := make([]string, 0, len(scanner.keys))
+	for node := range scanner.keys {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (scanner *fakeNodeScanner) scanNode(
+	ctx context.Context,
+	node string,
+	cursor string,
+	scanOptions options.ScanOptions,
+) (string, []string, error) {
+	scanner.scans = append(scanner.scans, node+"@"+cursor)
+	scanner.counts = append(scanner.counts, scanOptions.Count)
+	if scanner.err != nil {
+		return "", nil, scanner.err
+	}
+	page, _ := strconv.Atoi(cursor)
+	keys := scanner.keys[node][min(2*page, len(scanner.keys[node])):min(2*page+2, len(scanner.keys[node]))]
+	if 2*page+2 >= len(scanner.keys[node]) {
+		return "0", keys, nil
+	}
+	return strconv.Itoa(page + 1), keys, nil
+}
+
+func scanAll(t *testing.T, scan *ResumableScan) []string {
+	var keys []string
+	for !scan.Finished() {
+		page, err := scan.Next(context.Background())
+		require.NoError(t, err)
+		keys = append(keys, page...)
+	}
+	return keys
+}
+
+func TestResumableScan(t *testing.T) {
+	scanner := &fakeNodeScanner{keys: map[string][]string{
+		"10.0.0.2:6379": {"d", "e"},
+		"10.0.0.1:6379": {"a", "b", "c"},
+	}}
+	scan, err := newResumableScan(scanner, "", *options.NewScanOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "", scan.Cursor())
+	assert.False(t, scan.Finished())
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, scanAll(t, scan))
+	assert.Equal(t, []string{"10.0.0.1:6379@0", "10.0.0.1:6379@1", "10.0.0.2:6379@0"}, scanner.scans)
+	keys, err := scan.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestResumableScan_Resume(t *testing.T) {
+	scanner := &fakeNodeScanner{keys: map[string][]string{
+		"10.0.0.1:6379": {"a", "b", "c"},
+		"10.0.0.2:6379": {"d", "e"},
+	}}
+	scan, err := newResumableScan(scanner, "", *options.NewScanOptions())
+	require.NoError(t, err)
+	keys, err := scan.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	// The scan resumed from the cursor, e.g. after a restart, returns the remaining keys.
+	cursor := scan.Cursor()
+	scanner.scans = nil
+	resumed, err := newResumableScan(scanner, cursor, *options.NewScanOptions())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d", "e"}, scanAll(t, resumed))
+	assert.Equal(t, []string{"10.0.0.1:6379@1", "10.0.0.2:6379@0"}, scanner.scans)
+
+	finished, err := newResumableScan(scanner, resumed.Cursor(), *options.NewScanOptions())
+	require.NoError(t, err)
+	assert.True(t, finished.Finished())
+
+	_, err = newResumableScan(scanner, "not a cursor", *options.NewScanOptions())
+	assert.ErrorContains(t, err, `invalid scan cursor "not a cursor"`)
+}
+
+func TestResumableScan_Error(t *testing.T) {
+	scanner := &fakeNodeScanner{keys: map[string][]string{"10.0.0.1:6379": {"a", "b", "c"}}}
+	scan, err := newResumableScan(scanner, "", *options.NewScanOptions())
+	require.NoError(t, err)
+	_, err = scan.Next(context.Background())
+	require.NoError(t, err)
+	cursor := scan.Cursor()
+
+	// The position is unchanged by a failed page, which is read again by the next call.
+	scanner.err = errors.New("connection refused")
+	_, err = scan.Next(context.Background())
+	assert.ErrorIs(t, err, scanner.err)
+	assert.Equal(t, cursor, scan.Cursor())
+	scanner.err = nil
+	keys, err := scan.Next(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, keys)
+}
+
+func TestParseScanReply(t *testing.T) {
+	next, keys, err := parseScanReply([]any{"17", []any{"a", "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, "17", next)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	_, _, err = parseScanReply("OK")
+	assert.EqualError(t, err, "unexpected reply to SCAN: OK")
+	_, _, err = parseScanReply([]any{"0", []any{int64(1)}})
+	assert.EqualError(t, err, "unexpected key in the reply to SCAN: 1")
+}
+
+func TestResumableScan_AdaptiveCount(t *testing.T) {
+	scanner := &fakeNodeScanner{keys: map[string][]string{"10.0.0.1:6379": {"a", "b", "c", "d", "e", "f", "g"}}}
+	scan, err := newResumableScan(scanner, "", *options.NewScanOptions().SetCount(20))
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), scan.Stats().Count)
+
+	// The pages are fast and small, so the COUNT doubles up to the highest COUNT.
+	scan.WithAdaptiveCount(options.NewAdaptiveCountOptions().SetLatencyTarget(time.Hour).SetCountRange(10, 50))
+	assert.Len(t, scanAll(t, scan), 7)
+	assert.Equal(t, []int64{20, 40, 50, 50}, scanner.counts)
+	stats := scan.Stats()
+	assert.Equal(t, int64(4), stats.Pages)
+	assert.Equal(t, int64(7), stats.Keys)
+	assert.Equal(t, int64(50), stats.Count)
+	assert.Equal(t, int64(2), stats.CountChanges)
+
+	scan, err = newResumableScan(scanner, "", *options.NewScanOptions())
+	require.NoError(t, err)
+	_, err = scan.WithAdaptiveCount(options.NewAdaptiveCountOptions().SetCountRange(10, 5)).Next(context.Background())
+	assert.ErrorContains(t, err, "COUNT range")
+}
+
+func TestAdaptiveCountOptions_NextCount(t *testing.T) {
+	adaptive := options.NewAdaptiveCountOptions().SetCountRange(10, 1000).SetMaxPageKeys(100)
+	target := adaptive.LatencyTarget
+	assert.Equal(t, int64(200), adaptive.NextCount(100, 10, target/4))
+	assert.Equal(t, int64(1000), adaptive.NextCount(800, 10, target/4))
+	// Slow pages and large pages halve the COUNT.
+	assert.Equal(t, int64(50), adaptive.NextCount(100, 10, 2*target))
+	assert.Equal(t, int64(50), adaptive.NextCount(100, 150, target/4))
+	assert.Equal(t, int64(10), adaptive.NextCount(15, 150, target/4))
+	// Pages close to the targets keep the COUNT.
+	assert.Equal(t, int64(100), adaptive.NextCount(100, 60, target/4))
+	assert.Equal(t, int64(100), adaptive.NextCount(100, 10, 3*target/4))
+}
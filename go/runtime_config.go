@@ -0,0 +1,139 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// UpdateRuntimeConfiguration updates options of the client while it's in use, without recreating it or its
+// connections. See [config.RuntimeConfiguration] for the options which can be updated: the request timeout, the
+// timeout classes and the scheduling limits. The timeouts can't exceed the ones the client was created with.
+//
+// Example usage:
+//
+//	err := client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().
+//		WithRequestTimeout(200 * time.Millisecond).
+//		WithScheduling(config.NewSchedulingConfiguration(500)))
+//
+// Parameters:
+//
+//	runtimeConfig - The options to update.
+//
+// Return value:
+//
+//	nil once the options are updated, or a [ConfigurationError] if they're invalid, in which case none is updated.
+func (client *baseClient) UpdateRuntimeConfiguration(runtimeConfig *config.RuntimeConfiguration) error {
+	if err := runtimeConfig.Validate(); err != nil {
+		return NewConfigurationError(err.Error())
+	}
+	scheduling := runtimeConfig.GetScheduling()
+	if scheduling != nil && client.scheduler == nil {
+		return NewConfigurationError("the scheduling limits can only be updated on clients created with a scheduling " +
+			"configuration")
+	}
+	if requestTimeout, ok := runtimeConfig.GetRequestTimeout(); ok && client.coreTimeout > 0 &&
+		requestTimeout > client.coreTimeout {
+		return NewConfigurationError(fmt.Sprintf("the request timeout, %v, exceeds the longest request timeout the "+
+			"client was created with, %v", requestTimeout, client.coreTimeout))
+	}
+	for name, timeout := range runtimeConfig.GetTimeoutClasses() {
+		if client.coreTimeout > 0 && timeout > client.coreTimeout {
+			return NewConfigurationError(fmt.Sprintf("the timeout of the timeout class %q, %v, exceeds the "+
+				"longest request timeout the client was created with, %v", name, timeout, client.coreTimeout))
+		}
+	}
+	if client.isClosed() {
+		return NewClosingError("UpdateRuntimeConfiguration failed. The client is closed.")
+	}
+
+	if requestTimeout, ok := runtimeConfig.GetRequestTimeout(); ok {
+		client.requestTimeout.Store(int64(requestTimeout))
+	}
+	client.updateTimeoutClasses(runtimeConfig.GetTimeoutClasses())
+	if scheduling != nil {
+		client.scheduler.resize(scheduling.GetMaxConcurrentRequests(), scheduling.GetMaxBatchCommands())
+	}
+	return nil
+}
+
+// withRequestTimeout returns ctx bounded by the timeout of its timeout class, or else the request timeout of the view
+// of the client, or else the one set at runtime, or else the one the client was created with if it has timeout
+// classes, see defaultTimeout. Once the timeout elapses, the returned context is done with a
+// [TimeoutError] as its cause, see contextErr. A [ConfigurationError] is returned if the client doesn't define the
+// timeout class of ctx.
+//
+// None of these timeouts bounds the blocking commands, see isBlockingCommand, which the core waits for until their
+// own timeout elapses.
+func (client *baseClient) withRequestTimeout(
+	ctx context.Context,
+	blocking bool,
+) (context.Context, context.CancelFunc, error) {
+	timeout, err := client.classTimeout(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if blocking {
+		return ctx, func() {}, nil
+	}
+	switch {
+	case timeout > 0:
+	case client.view != nil && client.view.timeout != nil:
+		timeout = *client.view.timeout
+	case client.requestTimeout != nil:
+		timeout = time.Duration(client.requestTimeout.Load())
+	}
+	if timeout <= 0 {
+		timeout = client.defaultTimeout
+	}
+	if timeout <= 0 {
+		return ctx, func() {}, nil
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, NewTimeoutError("Request timed out"))
+	return ctx, cancel, nil
+}
+
+// isBlockingCommand reports whether command called with args waits for a timeout of its own, which the core then
+// waits for instead of the request timeout: BLPOP, BRPOP, BLMOVE, BRPOPLPUSH, BZPOPMIN, BZPOPMAX, BLMPOP, BZMPOP,
+// XREAD and XREADGROUP with BLOCK, WAIT, WAITAOF and MIGRATE.
+func isBlockingCommand(command string, args []string) bool {
+	switch command {
+	case "BLPOP", "BRPOP", "BLMOVE", "BRPOPLPUSH", "BZPOPMIN", "BZPOPMAX", "BLMPOP", "BZMPOP", "WAIT", "WAITAOF",
+		"MIGRATE":
+		return true
+	case "XREAD", "XREADGROUP":
+		return slices.ContainsFunc(args, func(arg string) bool { return strings.EqualFold(arg, "BLOCK") })
+	}
+	return false
+}
+
+// remainingMillis returns the milliseconds left until the deadline of ctx, rounded up, or 0 if it has none. The core
+// bounds the time it splits across the attempts of a command by it, see config.WithDeadlineSplitting.
+func remainingMillis(ctx context.Context) uint64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 1
+	}
+	return uint64((remaining + time.Millisecond - 1) / time.Millisecond)
+}
+
+// contextErr returns the error of a request whose context is done: a [TimeoutError] if the request timeout set at
+// runtime elapsed, or the error of ctx otherwise.
+func contextErr(ctx context.Context) error {
+	var timeoutErr *TimeoutError
+	if cause := context.Cause(ctx); errors.As(cause, &timeoutErr) {
+		return cause
+	}
+	return ctx.Err()
+}
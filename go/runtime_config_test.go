@@ -0,0 +1,115 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+func newRuntimeConfigTestClient(scheduler *requestScheduler) *baseClient {
+	var core int
+	return &baseClient{
+		core:           &coreClient{ptr: unsafe.Pointer(&core)},
+		mu:             &sync.Mutex{},
+		requestTimeout: &atomic.Int64{},
+		timeoutClasses: &atomic.Pointer[map[string]time.Duration]{},
+		scheduler:      scheduler,
+	}
+}
+
+func TestUpdateRuntimeConfiguration(t *testing.T) {
+	client := newRuntimeConfigTestClient(newRequestScheduler(1, 0))
+	err := client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().
+		WithRequestTimeout(50 * time.Millisecond).
+		WithScheduling(config.NewSchedulingConfiguration(5).WithMaxBatchCommands(2)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(50*time.Millisecond), client.requestTimeout.Load())
+	_, _, capacity := client.scheduler.usage()
+	assert.Equal(t, 5, capacity)
+	assert.Equal(t, 2, client.scheduler.maxBatchCommands)
+
+	// Options which aren't set keep their value.
+	require.NoError(t, client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration()))
+	assert.Equal(t, int64(50*time.Millisecond), client.requestTimeout.Load())
+}
+
+func TestUpdateRuntimeConfiguration_Invalid(t *testing.T) {
+	client := newRuntimeConfigTestClient(nil)
+	var configurationErr *ConfigurationError
+	err := client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().WithRequestTimeout(-time.Second))
+	assert.ErrorAs(t, err, &configurationErr)
+	err = client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().
+		WithScheduling(config.NewSchedulingConfiguration(10)))
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.Equal(t, int64(0), client.requestTimeout.Load())
+
+	// The request timeout can't be raised above the one of the core.
+	client.coreTimeout = 250 * time.Millisecond
+	err = client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().WithRequestTimeout(time.Second))
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.ErrorContains(t, err, "the request timeout, 1s, exceeds the longest request timeout the client was created")
+	assert.NoError(t, client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().
+		WithRequestTimeout(100*time.Millisecond)))
+
+	client.core.ptr = nil
+	err = client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().WithRequestTimeout(time.Millisecond))
+	assert.ErrorIs(t, err, ErrConnectionClosed)
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	client := newRuntimeConfigTestClient(nil)
+	ctx, cancel, err := client.withRequestTimeout(context.Background(), false)
+	require.NoError(t, err)
+	defer cancel()
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+
+	client.requestTimeout.Store(int64(time.Millisecond))
+	ctx, cancel, err = client.withRequestTimeout(context.Background(), false)
+	require.NoError(t, err)
+	defer cancel()
+	<-ctx.Done()
+	err = contextErr(ctx)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.True(t, IsRetryable(err))
+
+	// An earlier deadline of the caller takes precedence.
+	parent, cancelParent := context.WithCancel(context.Background())
+	cancelParent()
+	client.requestTimeout.Store(int64(time.Hour))
+	ctx, cancel, err = client.withRequestTimeout(parent, false)
+	require.NoError(t, err)
+	defer cancel()
+	assert.ErrorIs(t, contextErr(ctx), context.Canceled)
+
+	// The blocking commands wait for their own timeout.
+	ctx, cancel, err = client.withRequestTimeout(context.Background(), true)
+	require.NoError(t, err)
+	defer cancel()
+	_, hasDeadline = ctx.Deadline()
+	assert.False(t, hasDeadline)
+}
+
+func TestRemainingMillis(t *testing.T) {
+	assert.Equal(t, uint64(0), remainingMillis(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	remaining := remainingMillis(ctx)
+	assert.LessOrEqual(t, remaining, uint64(1000))
+	assert.Greater(t, remaining, uint64(900))
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	assert.Equal(t, uint64(1), remainingMillis(expired))
+}
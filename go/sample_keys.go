@@ -0,0 +1,284 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// keyScanner is implemented by [Client] and [ClusterClient].
+type keyScanner interface {
+	scanKeys(ctx context.Context, match string, count int64, visit func(keys []string) error) error
+}
+
+// SampleKeys draws up to count distinct random keys of the database, e.g. for cache-quality monitoring jobs, with
+// RANDOMKEY, pipelined, or by sampling a full SCAN, see [options.SampleKeysMethod].
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the sampling.
+//	count - The number of keys to draw.
+//	sampleOptions - How the keys are drawn, see [options.SampleKeysOptions].
+//
+// Return value:
+//
+//	The keys drawn, in random order. Fewer than count keys are returned if the database holds fewer keys, or, with
+//	RANDOMKEY, if some keys were drawn more than once.
+//
+// [valkey.io]: https://valkey.io/commands/randomkey/
+func (client *Client) SampleKeys(
+	ctx context.Context,
+	count int,
+	sampleOptions options.SampleKeysOptions,
+) ([]string, error) {
+	if err := validateSampleKeys(count, sampleOptions); err != nil || count == 0 {
+		return []string{}, err
+	}
+	if sampleOptions.Method == options.SampleWithScan {
+		return scanSampleKeys(ctx, client, count, sampleOptions, rand.IntN)
+	}
+	return client.randomKeys(ctx, count)
+}
+
+// SampleKeys draws up to count distinct random keys of the cluster, e.g. for cache-quality monitoring jobs, with
+// RANDOMKEY, or by sampling a full cluster SCAN, see [options.SampleKeysMethod]. With RANDOMKEY, the keys are drawn
+// from the primaries in proportion to the number of keys of their selected database, as reported by INFO keyspace,
+// so that the keys of all the primaries are as likely to be drawn, with a pipeline per primary, sent concurrently.
+//
+// See [valkey.io] for details.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the sampling.
+//	count - The number of keys to draw.
+//	sampleOptions - How the keys are drawn, see [options.SampleKeysOptions].
+//
+// Return value:
+//
+//	The keys drawn, in random order. Fewer than count keys are returned if the cluster holds fewer keys, or, with
+//	RANDOMKEY, if some keys were drawn more than once.
+//
+// [valkey.io]: https://valkey.io/commands/randomkey/
+func (client *ClusterClient) SampleKeys(
+	ctx context.Context,
+	count int,
+	sampleOptions options.SampleKeysOptions,
+) ([]string, error) {
+	if err := validateSampleKeys(count, sampleOptions); err != nil || count == 0 {
+		return []string{}, err
+	}
+	if sampleOptions.Method == options.SampleWithScan {
+		return scanSampleKeys(ctx, client, count, sampleOptions, rand.IntN)
+	}
+	sizes, err := client.primaryDBSizes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	draws := allocateSamples(sizes, count, rand.Int64N)
+	var mu sync.Mutex
+	var keys []string
+	var errs []error
+	var wg sync.WaitGroup
+	for node, nodeDraws := range draws {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nodeKeys, err := client.nodeRandomKeys(ctx, node, nodeDraws)
+			mu.Lock()
+			defer mu.Unlock()
+			keys = append(keys, nodeKeys...)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	keys = utils.Unique(keys)
+	rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	return keys, nil
+}
+
+func validateSampleKeys(count int, sampleOptions options.SampleKeysOptions) error {
+	if count < 0 {
+		return errors.New("the number of keys to sample must not be negative")
+	}
+	return sampleOptions.Validate()
+}
+
+// randomKeys draws count keys with RANDOMKEY, sent in a single non-atomic pipeline, and returns the distinct ones.
+func (client *Client) randomKeys(ctx context.Context, count int) ([]string, error) {
+	keys, err := client.pipelineRandomKeys(ctx, count, nil)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Unique(keys), nil
+}
+
+// pipelineRandomKeys draws count keys with RANDOMKEY, sent in a single non-atomic pipeline with batchOptions, and
+// returns them, duplicates included. The draws of an empty database return no key.
+func (client *baseClient) pipelineRandomKeys(
+	ctx context.Context,
+	count int,
+	batchOptions *internal.BatchOptions,
+) ([]string, error) {
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{Commands: make([]internal.Cmd, count)}
+	for i := range batch.Commands {
+		batch.Commands[i] = internal.MakeCmd(uint32(protobuf.RequestType_RandomKey), []string{}, identity)
+	}
+	responses, err := client.executeBatch(ctx, batch, false, batchOptions)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(responses))
+	for _, response := range responses {
+		switch response := response.(type) {
+		case nil:
+			// The database is empty, or was emptied while the keys were drawn.
+		case error:
+			return nil, fmt.Errorf("RANDOMKEY failed: %w", response)
+		case string:
+			keys = append(keys, response)
+		default:
+			return nil, fmt.Errorf("unexpected response to RANDOMKEY: %v", response)
+		}
+	}
+	return keys, nil
+}
+
+// primaryDBSizes returns the number of keys of the selected database of each primary of the cluster, by address.
+func (client *ClusterClient) primaryDBSizes(ctx context.Context) (map[string]int64, error) {
+	// The DBSIZE of all the primaries is summed up by the client, so the sizes are read from their keyspace INFO.
+	infos, err := client.InfoWithOptions(ctx, options.ClusterInfoOptions{
+		InfoOptions: &options.InfoOptions{Sections: []constants.Section{constants.Keyspace}},
+		RouteOption: &options.RouteOption{Route: config.AllPrimaries},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !infos.IsMultiValue() {
+		return nil, errors.New("unexpected response to INFO from all the primaries")
+	}
+	sizes := make(map[string]int64, len(infos.MultiValue()))
+	for node, info := range infos.MultiValue() {
+		size, err := keyspaceSize(info, client.selectedDatabase())
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyspace INFO of %s: %w", node, err)
+		}
+		sizes[node] = size
+	}
+	return sizes, nil
+}
+
+// keyspaceSize returns the number of keys of database in the keyspace section of INFO, e.g. "db0:keys=3,expires=0",
+// or 0 if the database holds no keys, in which case it isn't listed.
+func keyspaceSize(info string, database int64) (int64, error) {
+	prefix := "db" + utils.IntToString(database) + ":"
+	for _, line := range strings.Split(info, "\n") {
+		fields, found := strings.CutPrefix(strings.TrimSpace(line), prefix)
+		if !found {
+			continue
+		}
+		for _, field := range strings.Split(fields, ",") {
+			if value, found := strings.CutPrefix(field, "keys="); found {
+				return strconv.ParseInt(value, 10, 64)
+			}
+		}
+		return 0, fmt.Errorf("no key count in %q", line)
+	}
+	return 0, nil
+}
+
+// nodeRandomKeys draws count keys of node with RANDOMKEY, pipelined to the node.
+func (client *ClusterClient) nodeRandomKeys(ctx context.Context, node string, count int) ([]string, error) {
+	host, port, ok := splitNodeAddress(node)
+	if !ok {
+		return nil, fmt.Errorf("invalid node address %q", node)
+	}
+	return client.pipelineRandomKeys(ctx, count, &internal.BatchOptions{Route: config.NewByAddressRoute(host, port)})
+}
+
+// allocateSamples draws the node of each of count keys in proportion to the sizes of the nodes, with int64N drawing
+// a pseudo-random number in [0, n), and returns the number of keys to draw from each node.
+func allocateSamples(sizes map[string]int64, count int, int64N func(n int64) int64) map[string]int {
+	nodes := make([]string, 0, len(sizes))
+	var total int64
+	for node, size := range sizes {
+		if size > 0 {
+			nodes = append(nodes, node)
+			total += size
+		}
+	}
+	draws := make(map[string]int, len(nodes))
+	if total == 0 {
+		return draws
+	}
+	sort.Strings(nodes)
+	for range count {
+		draw := int64N(total)
+		for _, node := range nodes {
+			if draw < sizes[node] {
+				draws[node]++
+				break
+			}
+			draw -= sizes[node]
+		}
+	}
+	return draws
+}
+
+// scanSampleKeys scans the keys of client matching the pattern of sampleOptions, and keeps a uniform sample of count
+// of them, by reservoir sampling, with intN drawing a pseudo-random number in [0, n).
+func scanSampleKeys(
+	ctx context.Context,
+	client keyScanner,
+	count int,
+	sampleOptions options.SampleKeysOptions,
+	intN func(n int) int,
+) ([]string, error) {
+	sample := make([]string, 0, count)
+	sampled := make(map[string]bool, count)
+	scanned := 0
+	err := client.scanKeys(ctx, sampleOptions.Match, sampleOptions.ScanCount, func(keys []string) error {
+		for _, key := range keys {
+			// SCAN may return a key more than once.
+			if sampled[key] {
+				continue
+			}
+			scanned++
+			if len(sample) < count {
+				sample = append(sample, key)
+				sampled[key] = true
+			} else if i := intN(scanned); i < count {
+				delete(sampled, sample[i])
+				sample[i] = key
+				sampled[key] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	rand.Shuffle(len(sample), func(i, j int) { sample[i], sample[j] = sample[j], sample[i] })
+	return sample, nil
+}
@@ -0,0 +1,100 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// pagedScanner scans its pages of keys.
+type pagedScanner [][]string
+
+func (scanner pagedScanner) scanKeys(ctx context.Context, match string, count int64, visit func(keys []string) error) error {
+	for _, page := range scanner {
+		if err := visit(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAllocateSamples(t *testing.T) {
+	sizes := map[string]int64{"10.0.0.1:6379": 300, "10.0.0.2:6379": 100, "10.0.0.3:6379": 0}
+	draws := allocateSamples(sizes, 4000, rand.New(rand.NewPCG(1, 2)).Int64N)
+	assert.Equal(t, 4000, draws["10.0.0.1:6379"]+draws["10.0.0.2:6379"])
+	assert.InDelta(t, 3000, draws["10.0.0.1:6379"], 150)
+	assert.NotContains(t, draws, "10.0.0.3:6379")
+
+	assert.Empty(t, allocateSamples(map[string]int64{"10.0.0.1:6379": 0}, 10, rand.Int64N))
+}
+
+func TestScanSampleKeys(t *testing.T) {
+	var pages pagedScanner
+	for page := range 10 {
+		keys := make([]string, 10)
+		for i := range keys {
+			keys[i] = fmt.Sprintf("key:%d", page*10+i)
+		}
+		pages = append(pages, keys)
+	}
+	sampleOptions := *options.NewSampleKeysOptions().SetMethod(options.SampleWithScan)
+
+	// Every key is as likely to be sampled.
+	counts := make(map[string]int)
+	random := rand.New(rand.NewPCG(1, 2))
+	for range 2000 {
+		sample, err := scanSampleKeys(context.Background(), pages, 5, sampleOptions, random.IntN)
+		require.NoError(t, err)
+		require.Len(t, sample, 5)
+		for _, key := range sample {
+			counts[key]++
+		}
+	}
+	assert.Len(t, counts, 100)
+	for key, count := range counts {
+		assert.InDelta(t, 100, count, 45, key)
+	}
+
+	// The keys returned twice by the scan are sampled once.
+	sample, err := scanSampleKeys(context.Background(), pagedScanner{{"a", "b"}, {"b", "a"}}, 5, sampleOptions, rand.IntN)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, sample)
+}
+
+func TestSampleKeysOptions_Validate(t *testing.T) {
+	assert.NoError(t, options.NewSampleKeysOptions().Validate())
+	assert.NoError(t, options.NewSampleKeysOptions().SetMethod(options.SampleWithScan).SetMatch("user:*").Validate())
+	assert.ErrorContains(t, options.NewSampleKeysOptions().SetMatch("user:*").Validate(), "SampleWithScan")
+	assert.ErrorContains(t,
+		options.NewSampleKeysOptions().SetMethod(options.SampleWithScan).SetScanCount(0).Validate(), "scan count")
+	assert.ErrorContains(t, validateSampleKeys(-1, *options.NewSampleKeysOptions()), "negative")
+}
+
+func TestKeyspaceSize(t *testing.T) {
+	info := "# Keyspace\r\ndb0:keys=42,expires=1,avg_ttl=0\r\ndb3:keys=7,expires=0,avg_ttl=0\r\n"
+	size, err := keyspaceSize(info, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), size)
+	size, err = keyspaceSize(info, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), size)
+
+	// The databases without keys aren't listed.
+	size, err = keyspaceSize(info, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+	size, err = keyspaceSize("# Keyspace\r\n", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+
+	_, err = keyspaceSize("db0:expires=1\r\n", 0)
+	assert.Error(t, err)
+}
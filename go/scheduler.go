@@ -0,0 +1,196 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Priority is the scheduling priority of a request.
+//
+// Priorities only matter for clients configured with a [config.SchedulingConfiguration]: when the client
+// reached its limit of outstanding requests, waiting high priority requests are sent before any waiting
+// low priority request.
+type Priority int
+
+const (
+	// PriorityHigh is the priority of requests that aren't tagged using [WithPriority].
+	PriorityHigh Priority = iota
+	// PriorityLow is the priority of background requests, e.g. scans, that shouldn't delay interactive requests.
+	PriorityLow
+)
+
+// numPriorities is the number of scheduling lanes, one per [Priority].
+const numPriorities = 2
+
+type priorityContextKeyType struct{}
+
+// PriorityContextKey is the context key used to store the [Priority] of requests.
+// This key is used by WithPriority() and PriorityFromContext() functions.
+var PriorityContextKey = priorityContextKeyType{}
+
+// WithPriority returns a copy of ctx that tags the requests executed with it with the given priority.
+//
+// Example usage:
+//
+//	// Let interactive requests sharing the client go first
+//	ctx = glide.WithPriority(ctx, glide.PriorityLow)
+//	result, err := client.Scan(ctx, cursor)
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, PriorityContextKey, priority)
+}
+
+// PriorityFromContext returns the priority stored in ctx using [WithPriority], or [PriorityHigh] if there is none.
+func PriorityFromContext(ctx context.Context) Priority {
+	if ctx == nil {
+		return PriorityHigh
+	}
+	if priority, ok := ctx.Value(PriorityContextKey).(Priority); ok && priority >= PriorityHigh && priority <= PriorityLow {
+		return priority
+	}
+	return PriorityHigh
+}
+
+// requestScheduler limits the number of outstanding requests of a client, and admits waiting requests in order
+// of priority, and in FIFO order within the same priority.
+//
+// When maxBatchCommands is set, a batch takes one slot per command, up to maxBatchCommands slots, and the batches
+// outstanding at the same time take at most maxBatchCommands slots overall. A batch waiting for other batches to
+// complete doesn't hold back the single requests queued behind it, so they're interleaved with the batches.
+type requestScheduler struct {
+	mu       sync.Mutex
+	capacity int
+	inUse    int
+	// maxBatchCommands is the number of slots batches may take overall, or 0 if a batch takes a single slot.
+	maxBatchCommands int
+	batchInUse       int
+	// lanes[p] holds the *schedulerWaiter of the requests of priority p waiting to be admitted.
+	lanes [numPriorities]list.List
+}
+
+type schedulerWaiter struct {
+	// ready is closed once the request is admitted.
+	ready chan struct{}
+	// batchSlots is the number of slots taken by a batch, or 0 for a request taking a single slot.
+	batchSlots int
+}
+
+func newRequestScheduler(capacity int, maxBatchCommands int) *requestScheduler {
+	return &requestScheduler{capacity: capacity, maxBatchCommands: min(maxBatchCommands, capacity)}
+}
+
+// batchSlots returns the number of slots taken by a batch of the given number of commands.
+func (s *requestScheduler) batchSlots(batchCommands int) int {
+	return max(min(batchCommands, s.maxBatchCommands), 0)
+}
+
+// acquireSlots blocks until a request of the given priority may be sent, or ctx is done. batchCommands is the number
+// of commands of a batch, or 0 for any other request.
+// It returns the number of batch slots taken by the request, to be passed to releaseSlots once the request
+// completed. The slots taken don't depend on the limits of the scheduler at the time the request completes, so the
+// scheduler may be resized while requests are outstanding.
+func (s *requestScheduler) acquireSlots(ctx context.Context, priority Priority, batchCommands int) (int, error) {
+	s.mu.Lock()
+	waiter := &schedulerWaiter{ready: make(chan struct{}), batchSlots: s.batchSlots(batchCommands)}
+	element := s.lanes[priority].PushBack(waiter)
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return waiter.batchSlots, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-waiter.ready:
+			// Admitted concurrently with the cancellation, hand the slot over to the next request.
+			s.mu.Unlock()
+			s.releaseSlots(waiter.batchSlots)
+		default:
+			s.lanes[priority].Remove(element)
+			// The request may have held back the requests queued behind it.
+			s.dispatch()
+			s.mu.Unlock()
+		}
+		return 0, ctx.Err()
+	}
+}
+
+// releaseSlots frees the slots of a completed request which took batchSlots batch slots, as returned by
+// acquireSlots, and admits the next waiting requests, if any.
+func (s *requestScheduler) releaseSlots(batchSlots int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inUse -= max(batchSlots, 1)
+	s.batchInUse -= batchSlots
+	s.dispatch()
+}
+
+// dispatch admits waiting requests while there are free slots. Requests are admitted in order: a request that
+// doesn't fit holds back the requests of the same or a lower priority queued behind it, unless it's a batch
+// waiting for other batches to complete.
+// The caller must hold s.mu.
+func (s *requestScheduler) dispatch() {
+	for p := range s.lanes {
+		for element := s.lanes[p].Front(); element != nil; {
+			waiter := element.Value.(*schedulerWaiter)
+			next := element.Next()
+			switch {
+			case s.inUse+max(waiter.batchSlots, 1) <= s.capacity && s.batchInUse+waiter.batchSlots <= s.maxBatchCommands:
+				s.lanes[p].Remove(element)
+				s.inUse += max(waiter.batchSlots, 1)
+				s.batchInUse += waiter.batchSlots
+				close(waiter.ready)
+			case waiter.batchSlots > 0 && s.batchInUse+waiter.batchSlots > s.maxBatchCommands:
+				// Let the requests queued behind the batch go first.
+			default:
+				return
+			}
+			element = next
+		}
+	}
+}
+
+// resize sets the capacity and the batch limit of the scheduler. Requests admitted in excess of a reduced capacity
+// complete normally, and no request is admitted until the slots in use are below the new capacity.
+func (s *requestScheduler) resize(capacity int, maxBatchCommands int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.capacity = capacity
+	s.maxBatchCommands = min(maxBatchCommands, capacity)
+	// Waiting batches must fit in the new batch limit to be admitted.
+	for p := range s.lanes {
+		for element := s.lanes[p].Front(); element != nil; element = element.Next() {
+			waiter := element.Value.(*schedulerWaiter)
+			waiter.batchSlots = min(waiter.batchSlots, s.maxBatchCommands)
+		}
+	}
+	s.dispatch()
+}
+
+// usage returns the number of slots in use, the number of requests waiting to be admitted, and the capacity of the
+// scheduler.
+func (s *requestScheduler) usage() (inUse int, waiting int, capacity int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for p := range s.lanes {
+		waiting += s.lanes[p].Len()
+	}
+	return s.inUse, waiting, s.capacity
+}
+
+// acquireSchedulerSlot waits until the scheduler of the client, if any, admits a request executed with ctx.
+// batchCommands is the number of commands of a batch, or 0 for any other request.
+// The returned function must be called once the request completed.
+func (client *baseClient) acquireSchedulerSlot(ctx context.Context, batchCommands int) (func(), error) {
+	if client.scheduler == nil {
+		return func() {}, nil
+	}
+	batchSlots, err := client.scheduler.acquireSlots(ctx, PriorityFromContext(ctx), batchCommands)
+	if err != nil {
+		return nil, err
+	}
+	return func() { client.scheduler.releaseSlots(batchSlots) }, nil
+}
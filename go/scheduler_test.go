@@ -0,0 +1,207 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityFromContext(t *testing.T) {
+	assert.Equal(t, PriorityHigh, PriorityFromContext(context.Background()))
+	assert.Equal(t, PriorityLow, PriorityFromContext(WithPriority(context.Background(), PriorityLow)))
+	assert.Equal(t, PriorityHigh, PriorityFromContext(WithPriority(context.Background(), PriorityHigh)))
+	assert.Equal(t, PriorityHigh, PriorityFromContext(WithPriority(context.Background(), Priority(42))))
+}
+
+// acquireAsync starts acquiring a slot of the scheduler and returns a channel receiving the result.
+func acquireAsync(ctx context.Context, s *requestScheduler, priority Priority, batchCommands int) chan error {
+	result := make(chan error, 1)
+	go func() {
+		_, err := s.acquireSlots(ctx, priority, batchCommands)
+		result <- err
+	}()
+	return result
+}
+
+// mustAcquire acquires a slot of the scheduler and returns the number of batch slots taken.
+func mustAcquire(t *testing.T, s *requestScheduler, priority Priority, batchCommands int) int {
+	slots, err := s.acquireSlots(context.Background(), priority, batchCommands)
+	require.NoError(t, err)
+	return slots
+}
+
+// waitForWaiters waits until the given number of requests are queued in the lane of the given priority.
+func waitForWaiters(t *testing.T, s *requestScheduler, priority Priority, count int) {
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.lanes[priority].Len() == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestRequestScheduler_AdmitsUpToCapacity(t *testing.T) {
+	s := newRequestScheduler(2, 0)
+	ctx := context.Background()
+	mustAcquire(t, s, PriorityHigh, 0)
+	mustAcquire(t, s, PriorityLow, 0)
+
+	waiting := acquireAsync(ctx, s, PriorityHigh, 0)
+	waitForWaiters(t, s, PriorityHigh, 1)
+
+	inUse, waitingRequests, capacity := s.usage()
+	assert.Equal(t, 2, inUse)
+	assert.Equal(t, 1, waitingRequests)
+	assert.Equal(t, 2, capacity)
+
+	s.releaseSlots(0)
+	assert.NoError(t, <-waiting)
+	assert.Equal(t, 2, s.inUse)
+}
+
+func TestRequestScheduler_HighPriorityFirst(t *testing.T) {
+	s := newRequestScheduler(1, 0)
+	ctx := context.Background()
+	mustAcquire(t, s, PriorityHigh, 0)
+
+	low := acquireAsync(ctx, s, PriorityLow, 0)
+	waitForWaiters(t, s, PriorityLow, 1)
+	high := acquireAsync(ctx, s, PriorityHigh, 0)
+	waitForWaiters(t, s, PriorityHigh, 1)
+
+	// The high priority request is admitted first, even though it was queued last
+	s.releaseSlots(0)
+	assert.NoError(t, <-high)
+	select {
+	case <-low:
+		t.Fatal("low priority request admitted while the scheduler is full")
+	default:
+	}
+
+	s.releaseSlots(0)
+	assert.NoError(t, <-low)
+}
+
+func TestRequestScheduler_CancelWhileWaiting(t *testing.T) {
+	s := newRequestScheduler(1, 0)
+	mustAcquire(t, s, PriorityHigh, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waiting := acquireAsync(ctx, s, PriorityLow, 0)
+	waitForWaiters(t, s, PriorityLow, 1)
+	cancel()
+	assert.ErrorIs(t, <-waiting, context.Canceled)
+	waitForWaiters(t, s, PriorityLow, 0)
+
+	s.releaseSlots(0)
+	assert.Equal(t, 0, s.inUse)
+	mustAcquire(t, s, PriorityLow, 0)
+}
+
+func TestRequestScheduler_BatchCountsAsSingleRequestByDefault(t *testing.T) {
+	s := newRequestScheduler(2, 0)
+	batchSlots := mustAcquire(t, s, PriorityHigh, 100)
+	mustAcquire(t, s, PriorityHigh, 0)
+	assert.Equal(t, 0, batchSlots)
+	assert.Equal(t, 2, s.inUse)
+
+	s.releaseSlots(batchSlots)
+	s.releaseSlots(0)
+	assert.Equal(t, 0, s.inUse)
+}
+
+func TestRequestScheduler_BatchSlotsAreCapped(t *testing.T) {
+	s := newRequestScheduler(10, 4)
+	ctx := context.Background()
+	batchSlots := mustAcquire(t, s, PriorityHigh, 3)
+	assert.Equal(t, 3, batchSlots)
+	assert.Equal(t, 3, s.inUse)
+
+	// A second batch has to wait until the first one completes, but single commands don't
+	batch := acquireAsync(ctx, s, PriorityHigh, 1000)
+	waitForWaiters(t, s, PriorityHigh, 1)
+	mustAcquire(t, s, PriorityHigh, 0)
+	mustAcquire(t, s, PriorityLow, 0)
+	assert.Equal(t, 5, s.inUse)
+
+	s.releaseSlots(batchSlots)
+	assert.NoError(t, <-batch)
+	assert.Equal(t, 6, s.inUse)
+	assert.Equal(t, 4, s.batchInUse)
+
+	// The second batch took the 4 slots batches may take overall
+	s.releaseSlots(4)
+	s.releaseSlots(0)
+	s.releaseSlots(0)
+	assert.Equal(t, 0, s.inUse)
+	assert.Equal(t, 0, s.batchInUse)
+}
+
+func TestRequestScheduler_BatchWaitingForSlotsHoldsBackLaterRequests(t *testing.T) {
+	s := newRequestScheduler(4, 4)
+	ctx := context.Background()
+	mustAcquire(t, s, PriorityHigh, 0)
+	mustAcquire(t, s, PriorityHigh, 0)
+
+	// The batch doesn't fit, and isn't waiting for other batches: later requests must not starve it
+	batch := acquireAsync(ctx, s, PriorityHigh, 4)
+	waitForWaiters(t, s, PriorityHigh, 1)
+	single := acquireAsync(ctx, s, PriorityHigh, 0)
+	waitForWaiters(t, s, PriorityHigh, 2)
+
+	s.releaseSlots(0)
+	s.releaseSlots(0)
+	assert.NoError(t, <-batch)
+	s.releaseSlots(4)
+	assert.NoError(t, <-single)
+}
+
+func TestRequestScheduler_Resize(t *testing.T) {
+	s := newRequestScheduler(1, 0)
+	ctx := context.Background()
+	mustAcquire(t, s, PriorityHigh, 0)
+	waiting := acquireAsync(ctx, s, PriorityHigh, 0)
+	waitForWaiters(t, s, PriorityHigh, 1)
+
+	// Growing the scheduler admits the waiting request right away.
+	s.resize(2, 0)
+	assert.NoError(t, <-waiting)
+
+	// Shrinking it holds back new requests until the outstanding ones complete.
+	s.resize(1, 0)
+	waiting = acquireAsync(ctx, s, PriorityHigh, 0)
+	waitForWaiters(t, s, PriorityHigh, 1)
+	s.releaseSlots(0)
+	select {
+	case <-waiting:
+		t.Fatal("request admitted while the scheduler is full")
+	default:
+	}
+	s.releaseSlots(0)
+	assert.NoError(t, <-waiting)
+}
+
+func TestRequestScheduler_ResizeBatchLimit(t *testing.T) {
+	s := newRequestScheduler(10, 8)
+	ctx := context.Background()
+	slots, err := s.acquireSlots(ctx, PriorityHigh, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 6, slots)
+	batch := make(chan error, 1)
+	go func() {
+		_, err := s.acquireSlots(ctx, PriorityHigh, 6)
+		batch <- err
+	}()
+	waitForWaiters(t, s, PriorityHigh, 1)
+
+	// The waiting batch is clamped to the new batch limit, and the outstanding one releases the slots it took.
+	s.resize(10, 0)
+	s.releaseSlots(slots)
+	assert.NoError(t, <-batch)
+	assert.Equal(t, 1, s.inUse)
+	assert.Equal(t, 0, s.batchInUse)
+}
@@ -0,0 +1,204 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// searchDocumentsClient is implemented by [Client] and [ClusterClient].
+type searchDocumentsClient interface {
+	ftSearch(ctx context.Context, index string, query string, args []string) (int64, []searchReplyDocument, error)
+}
+
+// SearchHit is a document found by SearchDocuments.
+type SearchHit[T any] struct {
+	// Key is the key of the document.
+	Key string
+	// Score is the score of the document, if the search returns one, e.g. the distance of a vector query.
+	Score float64
+	// Document is the document, decoded from its fields.
+	Document T
+	// Fields are the fields of the document, as returned by the search, e.g. with their matched terms highlighted.
+	Fields map[string]string
+}
+
+// SearchResult is the result of SearchDocuments.
+type SearchResult[T any] struct {
+	// Total is the number of documents matching the query, of which Hits are the ones read.
+	Total int64
+	Hits  []SearchHit[T]
+}
+
+// SearchDocuments searches index for query with FT.SEARCH, and decodes the documents found into values of T, a
+// struct or a map[string]string, in the order of the search.
+//
+// The fields of hashes are decoded into the fields of T named like them, as in the json tags of T, see
+// [JsonFieldPath]: strings are copied, numbers and booleans are parsed, and the other types are decoded from JSON.
+// JSON documents, whose document is returned in the "$" field, are decoded with [encoding/json]. The fields which T
+// doesn't have are ignored, and remain in the Fields of the hits.
+//
+// The search module must be loaded on the server.
+//
+// Example usage:
+//
+//	type Product struct {
+//		Title string  `json:"title"`
+//		Price float64 `json:"price"`
+//	}
+//	query := options.And(options.TextQuery("title", "headphones"), options.NumericRangeQuery("price", 0, 100))
+//	result, err := glide.SearchDocuments[Product](ctx, client, "products", query.String(), *options.NewSearchOptions())
+func SearchDocuments[T any](
+	ctx context.Context,
+	client searchDocumentsClient,
+	index string,
+	query string,
+	searchOptions options.SearchOptions,
+) (SearchResult[T], error) {
+	args, err := searchOptions.ToArgs()
+	if err != nil {
+		return SearchResult[T]{}, err
+	}
+	total, documents, err := client.ftSearch(ctx, index, query, args)
+	if err != nil {
+		return SearchResult[T]{}, err
+	}
+	return decodeSearchDocuments[T](total, documents, searchOptions.ScoreField)
+}
+
+// decodeSearchDocuments decodes the documents of a reply of FT.SEARCH into values of T.
+func decodeSearchDocuments[T any](
+	total int64,
+	documents []searchReplyDocument,
+	scoreField string,
+) (SearchResult[T], error) {
+	result := SearchResult[T]{Total: total, Hits: make([]SearchHit[T], 0, len(documents))}
+	for _, document := range documents {
+		hit := SearchHit[T]{Key: document.key, Fields: document.fields}
+		if score, ok := searchScore(document.fields, scoreField); ok {
+			hit.Score = score
+		}
+		if err := decodeSearchFields(document.fields, reflect.ValueOf(&hit.Document).Elem()); err != nil {
+			return SearchResult[T]{}, fmt.Errorf("failed to decode the document of %q: %w", document.key, err)
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+	return result, nil
+}
+
+// searchScore returns the score of a document from its field scoreField, or from its field named like the
+// scores of vector queries, e.g. "__embedding_score", if scoreField is "" and there is one.
+func searchScore(fields map[string]string, scoreField string) (float64, bool) {
+	value, ok := fields[scoreField]
+	if scoreField == "" {
+		for name, fieldValue := range fields {
+			if strings.HasPrefix(name, "__") && strings.HasSuffix(name, "_score") {
+				value, ok = fieldValue, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	score, err := strconv.ParseFloat(value, 64)
+	return score, err == nil
+}
+
+// decodeSearchFields decodes the fields of a document into target, a struct or a map[string]string.
+func decodeSearchFields(fields map[string]string, target reflect.Value) error {
+	if target.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String &&
+		target.Type().Elem().Kind() == reflect.String {
+		target.Set(reflect.MakeMapWithSize(target.Type(), len(fields)))
+		for name, value := range fields {
+			target.SetMapIndex(reflect.ValueOf(name).Convert(target.Type().Key()),
+				reflect.ValueOf(value).Convert(target.Type().Elem()))
+		}
+		return nil
+	}
+	if target.Kind() != reflect.Struct {
+		return fmt.Errorf("documents can only be decoded into structs or string maps, not %v", target.Type())
+	}
+	if document, ok := fields["$"]; ok {
+		return json.Unmarshal([]byte(document), target.Addr().Interface())
+	}
+	return decodeSearchStruct(fields, target)
+}
+
+// decodeSearchStruct decodes the fields of a hash into the fields of target, a struct, named as in their json tags.
+func decodeSearchStruct(fields map[string]string, target reflect.Value) error {
+	for i := 0; i < target.NumField(); i++ {
+		structField := target.Type().Field(i)
+		name, encoded := jsonFieldName(structField)
+		if !encoded {
+			continue
+		}
+		member := target.Field(i)
+		if structField.Anonymous && structField.Tag.Get("json") == "" && member.Kind() == reflect.Struct {
+			if err := decodeSearchStruct(fields, member); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := fields[name]
+		if !ok || !member.CanSet() {
+			continue
+		}
+		if err := decodeSearchValue(value, member); err != nil {
+			return fmt.Errorf("invalid value %q of field %s: %w", value, name, err)
+		}
+	}
+	return nil
+}
+
+// decodeSearchValue decodes value, the value of the field of a hash, into target.
+func decodeSearchValue(value string, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.Pointer:
+		element := reflect.New(target.Type().Elem())
+		if err := decodeSearchValue(value, element.Elem()); err != nil {
+			return err
+		}
+		target.Set(element)
+	case reflect.String:
+		target.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		target.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(parsed)
+	default:
+		if target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes([]byte(value))
+			return nil
+		}
+		return json.Unmarshal([]byte(value), target.Addr().Interface())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+type searchTestMetadata struct {
+	Brand string `json:"brand"`
+}
+
+type searchTestProduct struct {
+	searchTestMetadata
+	Title    string   `json:"title"`
+	Price    float64  `json:"price"`
+	Stock    int      `json:"stock,omitempty"`
+	InStock  bool     `json:"in_stock"`
+	Discount *int64   `json:"discount"`
+	Tags     []string `json:"tags"`
+	Ignored  string   `json:"-"`
+}
+
+// fakeSearchDocumentsClient replies to FT.SEARCH with canned documents.
+type fakeSearchDocumentsClient struct {
+	args      []string
+	total     int64
+	documents []searchReplyDocument
+}
+
+func (client *fakeSearchDocumentsClient) ftSearch(
+	ctx context.Context,
+	index string,
+	query string,
+	args []string,
+) (int64, []searchReplyDocument, error) {
+	client.args = append([]string{index, query}, args...)
+	return client.total, client.documents, nil
+}
+
+func TestSearchDocuments_Hashes(t *testing.T) {
+	client := &fakeSearchDocumentsClient{total: 5, documents: []searchReplyDocument{
+		{key: "product:2", fields: map[string]string{
+			"title": "<b>headphones</b>", "price": "79.5", "stock": "3", "in_stock": "1", "discount": "10",
+			"tags": `["audio","wireless"]`, "brand": "sony", "Ignored": "x", "__embedding_score": "0.25",
+		}},
+		{key: "product:1", fields: map[string]string{"title": "earbuds", "price": "20"}},
+	}}
+	searchOptions := options.NewSearchOptions().SetLimit(0, 2).SetReturn("title", "price").SetHighlight("<b>", "</b>", "title")
+
+	result, err := SearchDocuments[searchTestProduct](context.Background(), client, "products", "@title:headphones",
+		*searchOptions)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"products", "@title:headphones", "RETURN", "2", "title", "price",
+		"HIGHLIGHT", "FIELDS", "1", "title", "TAGS", "<b>", "</b>", "LIMIT", "0", "2",
+	}, client.args)
+	assert.Equal(t, int64(5), result.Total)
+	require.Len(t, result.Hits, 2)
+	discount := int64(10)
+	assert.Equal(t, "product:2", result.Hits[0].Key)
+	assert.Equal(t, 0.25, result.Hits[0].Score)
+	assert.Equal(t, searchTestProduct{
+		searchTestMetadata: searchTestMetadata{Brand: "sony"},
+		Title:              "<b>headphones</b>",
+		Price:              79.5,
+		Stock:              3,
+		InStock:            true,
+		Discount:           &discount,
+		Tags:               []string{"audio", "wireless"},
+	}, result.Hits[0].Document)
+	assert.Equal(t, "product:1", result.Hits[1].Key)
+	assert.Equal(t, searchTestProduct{Title: "earbuds", Price: 20}, result.Hits[1].Document)
+}
+
+func TestSearchDocuments_Json(t *testing.T) {
+	client := &fakeSearchDocumentsClient{total: 1, documents: []searchReplyDocument{
+		{key: "product:1", fields: map[string]string{"$": `{"title":"earbuds","price":20,"tags":["audio"]}`, "score": "3"}},
+	}}
+
+	result, err := SearchDocuments[searchTestProduct](context.Background(), client, "products", "*",
+		*options.NewSearchOptions().SetScoreField("score"))
+	require.NoError(t, err)
+
+	assert.Equal(t, searchTestProduct{Title: "earbuds", Price: 20, Tags: []string{"audio"}}, result.Hits[0].Document)
+	assert.Equal(t, 3.0, result.Hits[0].Score)
+}
+
+func TestSearchDocuments_Maps(t *testing.T) {
+	fields := map[string]string{"title": "earbuds"}
+	client := &fakeSearchDocumentsClient{total: 1, documents: []searchReplyDocument{{key: "product:1", fields: fields}}}
+
+	result, err := SearchDocuments[map[string]string](context.Background(), client, "products", "*",
+		*options.NewSearchOptions())
+	require.NoError(t, err)
+	assert.Equal(t, fields, result.Hits[0].Document)
+
+	_, err = SearchDocuments[int](context.Background(), client, "products", "*", *options.NewSearchOptions())
+	assert.Error(t, err)
+
+	client.documents[0].fields = map[string]string{"price": "cheap"}
+	_, err = SearchDocuments[searchTestProduct](context.Background(), client, "products", "*", *options.NewSearchOptions())
+	assert.ErrorContains(t, err, "price")
+}
+
+func TestSearchDocuments_Limit(t *testing.T) {
+	client := &fakeSearchDocumentsClient{}
+
+	_, err := SearchDocuments[map[string]string](context.Background(), client, "products", "*", options.SearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"products", "*"}, client.args)
+
+	_, err = SearchDocuments[map[string]string](context.Background(), client, "products", "*",
+		*options.NewSearchOptions().SetLimit(0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"products", "*", "LIMIT", "0", "0"}, client.args)
+}
@@ -0,0 +1,51 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+func TestSearchQuery(t *testing.T) {
+	tests := map[string]options.SearchQuery{
+		"*":                     options.And(),
+		"@title:headphones":     options.TextQuery("title", "headphones"),
+		"(wireless headphones)": options.TextQuery("", " wireless  headphones "),
+		"@title:(noise cancelling) => { $slop: 2; $inorder: true; }": options.TextQuery("title", "noise cancelling").
+			SetSlop(2).
+			SetInOrder(),
+		`@brand:{sony | bang\ \&\ olufsen}`: options.TagQuery("brand", "sony", "bang & olufsen"),
+		"@price:[50 (100]":                  options.NumericRangeQuery("price", 50, 100).SetExclusiveMax(),
+		"@price:[(0.5 +inf]":                options.NumericRangeQuery("price", 0.5, math.Inf(1)).SetExclusiveMin(),
+		"@price:[-inf -1]":                  options.NumericRangeQuery("price", math.Inf(-1), -1),
+		"@location:[-122.41 37.77 5 km]": options.GeoRadiusQuery(
+			"location", -122.41, 37.77, 5, constants.GeoUnitKilometers,
+		),
+		"@a:{x} (@b:{y} | @c:{z}) -@d:{w}": options.And(
+			options.TagQuery("a", "x"),
+			options.Or(options.TagQuery("b", "y"), options.TagQuery("c", "z")),
+			options.Not(options.TagQuery("d", "w")),
+		),
+		"((@a:{x} @b:{y}) | (@c:x => { $slop: 0; }))": options.Or(
+			options.And(options.TagQuery("a", "x"), options.TagQuery("b", "y")),
+			options.TextQuery("c", "x").SetSlop(0),
+		),
+		"-(@a:{x} @b:{y})": options.Not(options.Or(options.And(options.TagQuery("a", "x"), options.TagQuery("b", "y")))),
+	}
+	for expected, query := range tests {
+		assert.Equal(t, expected, query.String())
+	}
+}
+
+func TestSearchQuery_Escaping(t *testing.T) {
+	// Values can't close their clause and inject other clauses.
+	assert.Equal(t, `@name:(foo\) \-\@admin\:\{true\})`, options.TextQuery("name", "foo) -@admin:{true}").String())
+	assert.Equal(t, `@tags:{a\}\ \|\ \@admin\:\{true}`, options.TagQuery("tags", "a} | @admin:{true").String())
+	assert.Equal(t, `@user\-name:{café}`, options.TagQuery("user-name", "café").String())
+}
@@ -0,0 +1,191 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// DefaultReindexPollInterval is the interval between two checks of the backfill of a new index by a [SearchReindexer],
+// unless configured otherwise.
+const DefaultReindexPollInterval = 500 * time.Millisecond
+
+// searchClient is implemented by [Client] and [ClusterClient].
+type searchClient interface {
+	ftCreate(ctx context.Context, index string, args []string) (string, error)
+	ftDropIndex(ctx context.Context, index string) (string, error)
+	ftInfo(ctx context.Context, index string) (any, error)
+	FtAliasUpdate(ctx context.Context, alias string, index string) (string, error)
+}
+
+// ReindexResult reports a migration of a [SearchReindexer].
+type ReindexResult struct {
+	// Index is the new index, which the alias points to.
+	Index string
+	// Documents is the number of documents of the new index once backfilled, as reported by FT.INFO, or -1 if it
+	// isn't reported.
+	Documents int64
+	// Dropped tells whether the previous index was dropped.
+	Dropped bool
+	// Duration is how long the migration took, mostly backfilling the new index.
+	Duration time.Duration
+}
+
+// SearchReindexer migrates a search index to a new schema without downtime, for the clients querying it through an
+// alias: the new index is created next to the previous one, and once it's backfilled, the alias is swapped to it with
+// FT.ALIASUPDATE, atomically, and the previous index is dropped.
+//
+// The new index is backfilled by the server, which scans the keys matching its prefixes in the background once it's
+// created: the reindexer waits until FT.INFO no longer reports a backfill in progress. The indexed keys are neither
+// read nor written by the reindexer, and the previous index keeps serving the queries through the alias meanwhile.
+// If the migration fails before the alias is swapped, the new index is dropped.
+//
+// The search module must be loaded on the server.
+//
+// Example usage:
+//
+//	definition := options.NewSearchIndexDefinition("title", "TEXT", "price", "NUMERIC", "SORTABLE").
+//		SetPrefixes("product:")
+//	result, err := glide.NewSearchReindexer(client).Reindex(ctx, "products", "products-v1", "products-v2", *definition)
+type SearchReindexer struct {
+	client       searchClient
+	pollInterval time.Duration
+	keepPrevious bool
+}
+
+// NewSearchReindexer returns a [SearchReindexer] migrating the indexes of client, a [Client] or a [ClusterClient].
+func NewSearchReindexer(client searchClient) *SearchReindexer {
+	return &SearchReindexer{client: client, pollInterval: DefaultReindexPollInterval}
+}
+
+// WithPollInterval sets the interval between two checks of the backfill of the new index. Defaults to
+// DefaultReindexPollInterval.
+func (reindexer *SearchReindexer) WithPollInterval(interval time.Duration) *SearchReindexer {
+	reindexer.pollInterval = interval
+	return reindexer
+}
+
+// WithKeepPrevious sets whether the previous index is kept once the alias is swapped, e.g. to roll back. Disabled by
+// default.
+func (reindexer *SearchReindexer) WithKeepPrevious(keep bool) *SearchReindexer {
+	reindexer.keepPrevious = keep
+	return reindexer
+}
+
+// Reindex creates index from definition, waits until it's backfilled, points alias to it and drops previous.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the migration, including the wait for the backfill.
+//	alias - The alias the indexes are queried through.
+//	previous - The index the alias points to, or "" if the alias doesn't exist yet.
+//	index - The name of the new index.
+//	definition - The definition of the new index.
+//
+// Return value:
+//
+//	The result of the migration, or an error if it failed. The alias is only swapped if no error is returned, or if
+//	the error is about dropping the previous index.
+func (reindexer *SearchReindexer) Reindex(
+	ctx context.Context,
+	alias string,
+	previous string,
+	index string,
+	definition options.SearchIndexDefinition,
+) (ReindexResult, error) {
+	started := time.Now()
+	if index == previous {
+		return ReindexResult{}, fmt.Errorf("the new index must differ from the previous one, got %q", index)
+	}
+	args, err := definition.ToArgs()
+	if err != nil {
+		return ReindexResult{}, err
+	}
+	if _, err := reindexer.client.ftCreate(ctx, index, args); err != nil {
+		return ReindexResult{}, fmt.Errorf("failed to create index %q: %w", index, err)
+	}
+	documents, err := reindexer.waitForBackfill(ctx, index)
+	if err == nil {
+		if _, err = reindexer.client.FtAliasUpdate(ctx, alias, index); err != nil {
+			err = fmt.Errorf("failed to point alias %q to index %q: %w", alias, index, err)
+		}
+	}
+	if err != nil {
+		// The new index is dropped even if ctx is done, not to leave it behind.
+		if _, dropErr := reindexer.client.ftDropIndex(context.WithoutCancel(ctx), index); dropErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to drop index %q: %w", index, dropErr))
+		}
+		return ReindexResult{}, err
+	}
+
+	result := ReindexResult{Index: index, Documents: documents}
+	if previous != "" && !reindexer.keepPrevious {
+		if _, err := reindexer.client.ftDropIndex(ctx, previous); err != nil {
+			result.Duration = time.Since(started)
+			return result, fmt.Errorf("failed to drop previous index %q: %w", previous, err)
+		}
+		result.Dropped = true
+	}
+	result.Duration = time.Since(started)
+	return result, nil
+}
+
+// waitForBackfill waits until the backfill of index is complete, and returns its number of documents.
+func (reindexer *SearchReindexer) waitForBackfill(ctx context.Context, index string) (int64, error) {
+	for {
+		info, err := reindexer.client.ftInfo(ctx, index)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the information of index %q: %w", index, err)
+		}
+		fields := searchIndexInfoFields(info)
+		if backfilling, _ := searchInfoInt(fields["backfill_in_progress"]); backfilling == 0 {
+			documents, ok := searchInfoInt(fields["num_docs"])
+			if !ok {
+				documents = -1
+			}
+			return documents, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("index %q wasn't backfilled: %w", index, ctx.Err())
+		case <-time.After(reindexer.pollInterval):
+		}
+	}
+}
+
+// searchIndexInfoFields returns the fields of the reply of FT.INFO, a map or a flat array of names and values.
+func searchIndexInfoFields(info any) map[string]any {
+	switch info := info.(type) {
+	case map[string]any:
+		return info
+	case []any:
+		fields := make(map[string]any, len(info)/2)
+		for i := 0; i+1 < len(info); i += 2 {
+			if name, ok := info[i].(string); ok {
+				fields[name] = info[i+1]
+			}
+		}
+		return fields
+	}
+	return nil
+}
+
+// searchInfoInt returns value, a number of the reply of FT.INFO, as an integer.
+func searchInfoInt(value any) (int64, bool) {
+	switch value := value.(type) {
+	case int64:
+		return value, true
+	case float64:
+		return int64(value), true
+	case string:
+		number, err := strconv.ParseFloat(value, 64)
+		return int64(number), err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,104 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeSearchClient records the search commands it's sent, and reports a backfill for a number of FT.INFO calls.
+type fakeSearchClient struct {
+	commands    [][]string
+	backfilling int
+	aliasErr    error
+}
+
+func (client *fakeSearchClient) ftCreate(ctx context.Context, index string, args []string) (string, error) {
+	client.commands = append(client.commands, append([]string{"FT.CREATE", index}, args...))
+	return "OK", nil
+}
+
+func (client *fakeSearchClient) ftDropIndex(ctx context.Context, index string) (string, error) {
+	client.commands = append(client.commands, []string{"FT.DROPINDEX", index})
+	return "OK", nil
+}
+
+func (client *fakeSearchClient) ftInfo(ctx context.Context, index string) (any, error) {
+	client.commands = append(client.commands, []string{"FT.INFO", index})
+	if client.backfilling > 0 {
+		client.backfilling--
+		return []any{"index_name", index, "num_docs", "3", "backfill_in_progress", "1"}, nil
+	}
+	return map[string]any{"index_name": index, "num_docs": int64(10), "backfill_in_progress": int64(0)}, nil
+}
+
+func (client *fakeSearchClient) FtAliasUpdate(ctx context.Context, alias string, index string) (string, error) {
+	client.commands = append(client.commands, []string{"FT.ALIASUPDATE", alias, index})
+	return "OK", client.aliasErr
+}
+
+func TestSearchReindexer_Reindex(t *testing.T) {
+	client := &fakeSearchClient{backfilling: 2}
+	definition := options.NewSearchIndexDefinition("title", "TEXT").SetPrefixes("product:")
+
+	result, err := NewSearchReindexer(client).
+		WithPollInterval(time.Millisecond).
+		Reindex(context.Background(), "products", "products-v1", "products-v2", *definition)
+	require.NoError(t, err)
+
+	assert.Equal(t, "products-v2", result.Index)
+	assert.Equal(t, int64(10), result.Documents)
+	assert.True(t, result.Dropped)
+	assert.Equal(t, [][]string{
+		{"FT.CREATE", "products-v2", "ON", "HASH", "PREFIX", "1", "product:", "SCHEMA", "title", "TEXT"},
+		{"FT.INFO", "products-v2"},
+		{"FT.INFO", "products-v2"},
+		{"FT.INFO", "products-v2"},
+		{"FT.ALIASUPDATE", "products", "products-v2"},
+		{"FT.DROPINDEX", "products-v1"},
+	}, client.commands)
+}
+
+func TestSearchReindexer_KeepPrevious(t *testing.T) {
+	client := &fakeSearchClient{}
+	definition := options.NewSearchIndexDefinition("$.title", "AS", "title", "TEXT").SetOnJson(true)
+
+	result, err := NewSearchReindexer(client).
+		WithKeepPrevious(true).
+		Reindex(context.Background(), "products", "products-v1", "products-v2", *definition)
+	require.NoError(t, err)
+
+	assert.False(t, result.Dropped)
+	assert.Equal(t, []string{"FT.CREATE", "products-v2", "ON", "JSON", "SCHEMA", "$.title", "AS", "title", "TEXT"},
+		client.commands[0])
+	assert.Equal(t, []string{"FT.ALIASUPDATE", "products", "products-v2"}, client.commands[len(client.commands)-1])
+}
+
+func TestSearchReindexer_DropsNewIndexOnFailure(t *testing.T) {
+	client := &fakeSearchClient{aliasErr: errors.New("alias failure")}
+
+	_, err := NewSearchReindexer(client).
+		Reindex(context.Background(), "products", "products-v1", "products-v2", *options.NewSearchIndexDefinition("a", "TAG"))
+	assert.ErrorIs(t, err, client.aliasErr)
+	assert.Equal(t, []string{"FT.DROPINDEX", "products-v2"}, client.commands[len(client.commands)-1])
+
+	client = &fakeSearchClient{backfilling: 100}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = NewSearchReindexer(client).
+		WithPollInterval(time.Millisecond).
+		Reindex(ctx, "products", "", "products-v2", *options.NewSearchIndexDefinition("a", "TAG"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"FT.DROPINDEX", "products-v2"}, client.commands[len(client.commands)-1])
+
+	_, err = NewSearchReindexer(client).Reindex(context.Background(), "products", "v1", "v1", *options.NewSearchIndexDefinition())
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+const (
+	// DefaultServerClockSamples is the number of TIME commands a [ServerClock] sends per synchronization, unless
+	// configured otherwise.
+	DefaultServerClockSamples = 5
+	// DefaultServerClockResyncInterval is how long a [ServerClock] uses its estimated offset before measuring it
+	// again, unless configured otherwise.
+	DefaultServerClockResyncInterval = time.Minute
+)
+
+// serverClockClient is implemented by [Client] and [ClusterClient].
+type serverClockClient interface {
+	Time(ctx context.Context) ([]string, error)
+}
+
+// ServerClock estimates the offset of the clock of the server from the local clock with TIME, and provides
+// timestamps aligned with the clock of the server, so that the deadlines shared through the server, e.g. the scores
+// of delayed jobs or the expirations of locks, don't depend on the local clocks of the clients, which may be skewed.
+//
+// Each synchronization sends several TIME commands and keeps the sample with the shortest round trip, assuming the
+// server read its clock halfway through it. The offset is thus known within half that round trip.
+//
+// In clusters, TIME is sent to a random node: the clocks of the nodes are assumed to be synchronized with each other.
+//
+// Example usage:
+//
+//	clock := glide.NewServerClock(client)
+//	now, err := clock.Now(ctx)
+//	_, err = client.ZAdd(ctx, "jobs:delayed", map[string]float64{job: float64(now.Add(time.Minute).UnixMilli())})
+type ServerClock struct {
+	client         serverClockClient
+	samples        int
+	resyncInterval time.Duration
+	now            func() time.Time
+
+	mu       sync.Mutex
+	estimate *models.ClockEstimate
+}
+
+// NewServerClock returns a [ServerClock] for the server of client, a [Client] or a [ClusterClient].
+func NewServerClock(client serverClockClient) *ServerClock {
+	return &ServerClock{
+		client:         client,
+		samples:        DefaultServerClockSamples,
+		resyncInterval: DefaultServerClockResyncInterval,
+		now:            time.Now,
+	}
+}
+
+// WithSamples sets the number of TIME commands sent per synchronization, at least 1. Defaults to
+// DefaultServerClockSamples.
+func (clock *ServerClock) WithSamples(samples int) *ServerClock {
+	clock.samples = max(samples, 1)
+	return clock
+}
+
+// WithResyncInterval sets how long the estimated offset is used before it's measured again. Defaults to
+// DefaultServerClockResyncInterval.
+func (clock *ServerClock) WithResyncInterval(interval time.Duration) *ServerClock {
+	clock.resyncInterval = interval
+	return clock
+}
+
+// Sync measures the offset of the clock of the server again.
+func (clock *ServerClock) Sync(ctx context.Context) error {
+	var best *models.ClockEstimate
+	for i := 0; i < clock.samples; i++ {
+		sent := clock.now()
+		reply, err := clock.client.Time(ctx)
+		received := clock.now()
+		if err != nil {
+			return err
+		}
+		serverTime, err := parseServerTime(reply)
+		if err != nil {
+			return err
+		}
+		roundTrip := received.Sub(sent)
+		if best != nil && roundTrip >= best.RoundTrip {
+			continue
+		}
+		best = &models.ClockEstimate{
+			Offset:      serverTime.Sub(sent.Add(roundTrip / 2)),
+			Uncertainty: roundTrip / 2,
+			RoundTrip:   roundTrip,
+			Synced:      received,
+		}
+	}
+	clock.mu.Lock()
+	clock.estimate = best
+	clock.mu.Unlock()
+	return nil
+}
+
+// Estimate returns the estimated offset of the clock of the server, measured again if it's older than the resync
+// interval.
+func (clock *ServerClock) Estimate(ctx context.Context) (models.ClockEstimate, error) {
+	clock.mu.Lock()
+	estimate := clock.estimate
+	clock.mu.Unlock()
+	if estimate != nil && clock.now().Sub(estimate.Synced) < clock.resyncInterval {
+		return *estimate, nil
+	}
+	if err := clock.Sync(ctx); err != nil {
+		return models.ClockEstimate{}, err
+	}
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return *clock.estimate, nil
+}
+
+// Now returns the current time of the server, estimated from the local clock and the offset of the clock of the
+// server.
+func (clock *ServerClock) Now(ctx context.Context) (time.Time, error) {
+	estimate, err := clock.Estimate(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return clock.now().Add(estimate.Offset), nil
+}
+
+// ServerTime converts the local time t to the time of the server, e.g. to compute a deadline shared through the
+// server from a local one.
+func (clock *ServerClock) ServerTime(ctx context.Context, t time.Time) (time.Time, error) {
+	estimate, err := clock.Estimate(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(estimate.Offset), nil
+}
+
+// LocalTime converts the time of the server t, e.g. a deadline read from the server, to the local time, e.g. to wait
+// for it with a local timer.
+func (clock *ServerClock) LocalTime(ctx context.Context, t time.Time) (time.Time, error) {
+	estimate, err := clock.Estimate(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(-estimate.Offset), nil
+}
+
+// parseServerTime parses the reply of TIME, the UNIX time in seconds and the microseconds elapsed in the current
+// second.
+func parseServerTime(reply []string) (time.Time, error) {
+	if len(reply) != 2 {
+		return time.Time{}, errors.New("invalid reply of TIME: expected the seconds and the microseconds")
+	}
+	seconds, err := strconv.ParseInt(reply[0], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid seconds %q of TIME: %w", reply[0], err)
+	}
+	microseconds, err := strconv.ParseInt(reply[1], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid microseconds %q of TIME: %w", reply[1], err)
+	}
+	return time.Unix(seconds, microseconds*int64(time.Microsecond)), nil
+}
@@ -0,0 +1,91 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTimeClient serves TIME from a server clock offset from now, each command taking the next of roundTrips.
+type fakeTimeClient struct {
+	now        *time.Time
+	offset     time.Duration
+	roundTrips []time.Duration
+	calls      int
+	err        error
+}
+
+func (client *fakeTimeClient) Time(ctx context.Context) ([]string, error) {
+	if client.err != nil {
+		return nil, client.err
+	}
+	roundTrip := client.roundTrips[client.calls%len(client.roundTrips)]
+	client.calls++
+	*client.now = client.now.Add(roundTrip / 2)
+	serverTime := client.now.Add(client.offset)
+	*client.now = client.now.Add(roundTrip / 2)
+	return []string{
+		strconv.FormatInt(serverTime.Unix(), 10),
+		strconv.FormatInt(int64(serverTime.Nanosecond()/1000), 10),
+	}, nil
+}
+
+func TestServerClock_Estimate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	client := &fakeTimeClient{
+		now:        &now,
+		offset:     -1500 * time.Millisecond,
+		roundTrips: []time.Duration{8 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond},
+	}
+	clock := NewServerClock(client).WithSamples(3).WithResyncInterval(time.Minute)
+	clock.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	estimate, err := clock.Estimate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.calls)
+	assert.Equal(t, -1500*time.Millisecond, estimate.Offset)
+	assert.Equal(t, 2*time.Millisecond, estimate.RoundTrip)
+	assert.Equal(t, time.Millisecond, estimate.Uncertainty)
+
+	serverNow, err := clock.Now(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-1500*time.Millisecond), serverNow)
+	assert.Equal(t, 3, client.calls)
+
+	local, err := clock.LocalTime(ctx, serverNow)
+	require.NoError(t, err)
+	assert.Equal(t, now, local)
+	remote, err := clock.ServerTime(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, serverNow, remote)
+
+	// The offset is measured again once older than the resync interval.
+	client.offset = time.Second
+	now = now.Add(time.Minute)
+	estimate, err = clock.Estimate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 6, client.calls)
+	assert.Equal(t, time.Second, estimate.Offset)
+
+	client.err = errors.New("NOPERM")
+	assert.EqualError(t, clock.Sync(ctx), "NOPERM")
+}
+
+func TestParseServerTime(t *testing.T) {
+	serverTime, err := parseServerTime([]string{"1700000000", "250000"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1700000000, 250000000), serverTime)
+
+	_, err = parseServerTime([]string{"1700000000"})
+	assert.Error(t, err)
+	_, err = parseServerTime([]string{"1700000000", "soon"})
+	assert.EqualError(t, err, `invalid microseconds "soon" of TIME: strconv.ParseInt: parsing "soon": invalid syntax`)
+}
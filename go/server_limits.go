@@ -0,0 +1,165 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// DefaultServerLimitsRefreshInterval is how long a [ServerLimitsReader] uses the limits read from the server
+// configuration, unless configured otherwise.
+const DefaultServerLimitsRefreshInterval = 5 * time.Minute
+
+// serverLimitParameters are the configuration parameters read by a [ServerLimitsReader], which all hold integers but
+// maxmemory-policy.
+var serverLimitParameters = []string{
+	"proto-max-bulk-len",
+	"client-query-buffer-limit",
+	"maxmemory",
+	"maxmemory-policy",
+	"list-max-listpack-size",
+	"hash-max-listpack-entries",
+	"hash-max-listpack-value",
+	"set-max-intset-entries",
+	"set-max-listpack-entries",
+	"set-max-listpack-value",
+	"zset-max-listpack-entries",
+	"zset-max-listpack-value",
+	"timeout",
+}
+
+// serverLimitsClient is implemented by [Client] and [ClusterClient].
+type serverLimitsClient interface {
+	ConfigGet(ctx context.Context, parameters []string) (map[string]string, error)
+}
+
+// ServerLimitsReader reads the limits of the server configuration, e.g. proto-max-bulk-len or maxmemory, with CONFIG
+// GET and caches them as [models.ServerLimits], so that client-side validations and chunked writes may respect the
+// actual limits of the server rather than their defaults.
+//
+// Example usage:
+//
+//	reader := glide.NewServerLimitsReader(client)
+//	limits, err := reader.Limits(ctx)
+//	if !limits.FitsBulk(len(value)) {
+//		// Split the value before writing it.
+//	}
+type ServerLimitsReader struct {
+	client          serverLimitsClient
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu      sync.Mutex
+	limits  *models.ServerLimits
+	fetched time.Time
+}
+
+// NewServerLimitsReader returns a [ServerLimitsReader] for the server of client, a [Client] or a [ClusterClient]. In
+// clusters, the limits are read from a random node, and thus assumed to be the same on all the nodes.
+func NewServerLimitsReader(client serverLimitsClient) *ServerLimitsReader {
+	return &ServerLimitsReader{client: client, refreshInterval: DefaultServerLimitsRefreshInterval, now: time.Now}
+}
+
+// WithRefreshInterval sets how long the limits read from the server configuration are used before they're read again.
+// Defaults to DefaultServerLimitsRefreshInterval.
+func (reader *ServerLimitsReader) WithRefreshInterval(interval time.Duration) *ServerLimitsReader {
+	reader.refreshInterval = interval
+	return reader
+}
+
+// Refresh reads the limits from the server configuration again, e.g. after a CONFIG SET.
+func (reader *ServerLimitsReader) Refresh(ctx context.Context) error {
+	values, err := reader.client.ConfigGet(ctx, serverLimitParameters)
+	if err != nil {
+		return err
+	}
+	limits, err := parseServerLimits(values)
+	if err != nil {
+		return err
+	}
+	reader.mu.Lock()
+	reader.limits, reader.fetched = &limits, reader.now()
+	reader.mu.Unlock()
+	return nil
+}
+
+// Limits returns the cached limits of the server, read again if they're older than the refresh interval.
+func (reader *ServerLimitsReader) Limits(ctx context.Context) (models.ServerLimits, error) {
+	reader.mu.Lock()
+	limits, fetched := reader.limits, reader.fetched
+	reader.mu.Unlock()
+	if limits != nil && reader.now().Sub(fetched) < reader.refreshInterval {
+		return *limits, nil
+	}
+	if err := reader.Refresh(ctx); err != nil {
+		return models.ServerLimits{}, err
+	}
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+	return *reader.limits, nil
+}
+
+// ChunkOptions returns [options.ChunkOptions] for the chunked writes, e.g. `RPushAllChunked`, with the default bounds
+// lowered to proto-max-bulk-len and client-query-buffer-limit when the server is configured with smaller limits.
+func (reader *ServerLimitsReader) ChunkOptions(ctx context.Context) (options.ChunkOptions, error) {
+	limits, err := reader.Limits(ctx)
+	if err != nil {
+		return options.ChunkOptions{}, err
+	}
+	chunkOptions := *options.NewChunkOptions()
+	for _, limit := range []int64{limits.ProtoMaxBulkLen, limits.ClientQueryBufferLimit} {
+		if limit > 0 && limit < int64(chunkOptions.MaxBytes) {
+			chunkOptions.MaxBytes = int(limit)
+		}
+	}
+	return chunkOptions, nil
+}
+
+// parseServerLimits parses the values of serverLimitParameters returned by CONFIG GET.
+func parseServerLimits(values map[string]string) (models.ServerLimits, error) {
+	integers := make(map[string]int64, len(serverLimitParameters))
+	for _, parameter := range serverLimitParameters {
+		if parameter == "maxmemory-policy" {
+			continue
+		}
+		value, ok := values[parameter]
+		if !ok {
+			// The negative sizes of list-max-listpack-size are valid, and 0 isn't.
+			if parameter != "list-max-listpack-size" {
+				integers[parameter] = -1
+			}
+			continue
+		}
+		integer, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return models.ServerLimits{}, fmt.Errorf("invalid value %q of %s: %w", value, parameter, err)
+		}
+		integers[parameter] = integer
+	}
+	timeout := time.Duration(-1)
+	if seconds := integers["timeout"]; seconds >= 0 {
+		timeout = time.Duration(seconds) * time.Second
+	}
+	return models.ServerLimits{
+		ProtoMaxBulkLen:        integers["proto-max-bulk-len"],
+		ClientQueryBufferLimit: integers["client-query-buffer-limit"],
+		MaxMemory:              integers["maxmemory"],
+		MaxMemoryPolicy:        values["maxmemory-policy"],
+		ListMaxListpackSize:    integers["list-max-listpack-size"],
+		HashMaxListpackEntries: integers["hash-max-listpack-entries"],
+		HashMaxListpackValue:   integers["hash-max-listpack-value"],
+		SetMaxIntsetEntries:    integers["set-max-intset-entries"],
+		SetMaxListpackEntries:  integers["set-max-listpack-entries"],
+		SetMaxListpackValue:    integers["set-max-listpack-value"],
+		ZSetMaxListpackEntries: integers["zset-max-listpack-entries"],
+		ZSetMaxListpackValue:   integers["zset-max-listpack-value"],
+		Timeout:                timeout,
+	}, nil
+}
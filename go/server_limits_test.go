@@ -0,0 +1,105 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeConfigClient serves CONFIG GET from config.
+type fakeConfigClient struct {
+	config    map[string]string
+	configGet int
+}
+
+func (client *fakeConfigClient) ConfigGet(ctx context.Context, parameters []string) (map[string]string, error) {
+	client.configGet++
+	values := make(map[string]string)
+	for _, parameter := range parameters {
+		if value, ok := client.config[parameter]; ok {
+			values[parameter] = value
+		}
+	}
+	return values, nil
+}
+
+func TestServerLimitsReader_Limits(t *testing.T) {
+	client := &fakeConfigClient{config: map[string]string{
+		"proto-max-bulk-len":        "536870912",
+		"client-query-buffer-limit": "1073741824",
+		"maxmemory":                 "0",
+		"maxmemory-policy":          "noeviction",
+		"list-max-listpack-size":    "-2",
+		"hash-max-listpack-entries": "128",
+		"hash-max-listpack-value":   "64",
+		"set-max-intset-entries":    "512",
+		"zset-max-listpack-entries": "128",
+		"zset-max-listpack-value":   "64",
+		"timeout":                   "300",
+	}}
+	now := time.Now()
+	reader := NewServerLimitsReader(client).WithRefreshInterval(time.Minute)
+	reader.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	limits, err := reader.Limits(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, models.ServerLimits{
+		ProtoMaxBulkLen:        536870912,
+		ClientQueryBufferLimit: 1073741824,
+		MaxMemory:              0,
+		MaxMemoryPolicy:        "noeviction",
+		ListMaxListpackSize:    -2,
+		HashMaxListpackEntries: 128,
+		HashMaxListpackValue:   64,
+		SetMaxIntsetEntries:    512,
+		SetMaxListpackEntries:  -1,
+		SetMaxListpackValue:    -1,
+		ZSetMaxListpackEntries: 128,
+		ZSetMaxListpackValue:   64,
+		Timeout:                5 * time.Minute,
+	}, limits)
+	assert.True(t, limits.FitsBulk(536870912))
+	assert.False(t, limits.FitsBulk(536870913))
+
+	// The limits are cached for the refresh interval.
+	client.config["proto-max-bulk-len"] = "1024"
+	_, err = reader.Limits(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.configGet)
+
+	now = now.Add(time.Minute)
+	limits, err = reader.Limits(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.configGet)
+	assert.Equal(t, int64(1024), limits.ProtoMaxBulkLen)
+
+	chunkOptions, err := reader.ChunkOptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, options.ChunkOptions{MaxBytes: 1024, MaxElements: options.DefaultChunkMaxElements}, chunkOptions)
+
+	client.config["timeout"] = "forever"
+	assert.EqualError(t, reader.Refresh(ctx), `invalid value "forever" of timeout: strconv.ParseInt: parsing "forever": invalid syntax`)
+}
+
+func TestServerLimitsReader_ChunkOptions(t *testing.T) {
+	// The default bounds are kept when the server doesn't report or doesn't lower the limits.
+	reader := NewServerLimitsReader(&fakeConfigClient{config: map[string]string{"proto-max-bulk-len": "536870912"}})
+	chunkOptions, err := reader.ChunkOptions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, *options.NewChunkOptions(), chunkOptions)
+
+	limits, err := reader.Limits(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), limits.ListMaxListpackSize)
+	assert.Equal(t, time.Duration(-1), limits.Timeout)
+	assert.True(t, models.ServerLimits{ProtoMaxBulkLen: -1}.FitsBulk(1<<30))
+}
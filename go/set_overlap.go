@@ -0,0 +1,247 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+const (
+	// DefaultOverlapSampleSize is the number of members a [SetOverlap] samples to estimate an intersection size,
+	// unless configured otherwise.
+	DefaultOverlapSampleSize = 1000
+	// DefaultOverlapChunkSize is the number of members a [SetOverlap] checks per request, unless configured otherwise.
+	DefaultOverlapChunkSize = 500
+)
+
+// overlapTempKeyTTL bounds the lifetime of the temporary keys of a [SetOverlap], if they can't be deleted.
+const overlapTempKeyTTL = time.Minute
+
+// SetOverlap computes the size of the intersection of many sets, e.g. the overlap of audiences, whether their keys
+// map to the same cluster slot or not.
+//
+// The sets whose keys share a slot are intersected by the server: with SINTERCARD if all the keys share a slot or the
+// client is a standalone client, or else with SINTERSTORE into a temporary key of their slot, deleted once the size
+// is computed, and expiring after a minute in case it can't be. The intersections of the different slots are then
+// intersected by the client: the members of the smallest one are checked against the others with SMISMEMBER, in
+// chunks. Estimate only checks a random sample of the members of the smallest intersection, and scales the size of
+// the sample intersection.
+//
+// Example usage:
+//
+//	overlap := glide.NewSetOverlap(client).WithSampleSize(5000)
+//	exact, err := overlap.Exact(ctx, []string{"audience:sports", "audience:travel", "audience:tech"})
+//	estimated, err := overlap.Estimate(ctx, []string{"audience:sports", "audience:travel"})
+type SetOverlap struct {
+	client     interfaces.BaseClientCommands
+	sampleSize int64
+	chunkSize  int64
+}
+
+// NewSetOverlap returns a [SetOverlap] intersecting the sets of client.
+func NewSetOverlap(client interfaces.BaseClientCommands) *SetOverlap {
+	return &SetOverlap{client: client, sampleSize: DefaultOverlapSampleSize, chunkSize: DefaultOverlapChunkSize}
+}
+
+// WithSampleSize sets the number of members sampled by Estimate. Defaults to DefaultOverlapSampleSize.
+func (overlap *SetOverlap) WithSampleSize(sampleSize int64) *SetOverlap {
+	overlap.sampleSize = sampleSize
+	return overlap
+}
+
+// WithChunkSize sets the number of members checked per request. Defaults to DefaultOverlapChunkSize.
+func (overlap *SetOverlap) WithChunkSize(chunkSize int64) *SetOverlap {
+	overlap.chunkSize = chunkSize
+	return overlap
+}
+
+// Exact returns the number of members of all the sets of keys.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	keys - The keys of the sets to intersect.
+//
+// Return value:
+//
+//	The size of the intersection of the sets, 0 if keys is empty.
+func (overlap *SetOverlap) Exact(ctx context.Context, keys []string) (int64, error) {
+	return overlap.size(ctx, keys, false)
+}
+
+// Estimate returns an estimation of the number of members of all the sets of keys, computed from a sample of the
+// members of the smallest intersection of the sets of a slot. The estimation is exact if this intersection has no
+// more members than the sample size.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	keys - The keys of the sets to intersect.
+//
+// Return value:
+//
+//	The estimated size of the intersection of the sets, 0 if keys is empty.
+func (overlap *SetOverlap) Estimate(ctx context.Context, keys []string) (int64, error) {
+	return overlap.size(ctx, keys, true)
+}
+
+func (overlap *SetOverlap) size(ctx context.Context, keys []string, estimate bool) (int64, error) {
+	groups := groupKeysBySlot(keys)
+	if len(groups) == 0 {
+		return 0, nil
+	}
+	if _, standalone := overlap.client.(*Client); standalone || len(groups) == 1 {
+		var distinct []string
+		for _, group := range groups {
+			distinct = append(distinct, group...)
+		}
+		return overlap.client.SInterCard(ctx, distinct)
+	}
+
+	sets, tempKeys, empty, err := overlap.intersectSlots(ctx, groups)
+	defer func() {
+		if len(tempKeys) > 0 {
+			cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), overlapTempKeyTTL)
+			defer cancel()
+			for _, key := range tempKeys {
+				_, _ = overlap.client.Del(cleanupCtx, []string{key})
+			}
+		}
+	}()
+	if err != nil || empty {
+		return 0, err
+	}
+
+	// The members of the smallest set are checked against the other sets.
+	smallest, smallestCard := 0, int64(-1)
+	for i, set := range sets {
+		card, err := overlap.client.SCard(ctx, set)
+		if err != nil {
+			return 0, err
+		}
+		if card == 0 {
+			return 0, nil
+		}
+		if smallestCard < 0 || card < smallestCard {
+			smallest, smallestCard = i, card
+		}
+	}
+	others := append(append([]string(nil), sets[:smallest]...), sets[smallest+1:]...)
+
+	if estimate && smallestCard > overlap.sampleSize {
+		sample, err := overlap.client.SRandMemberCount(ctx, sets[smallest], overlap.sampleSize)
+		if err != nil || len(sample) == 0 {
+			return 0, err
+		}
+		var matches int64
+		for start := 0; start < len(sample); start += int(overlap.chunkSize) {
+			chunk := sample[start:min(start+int(overlap.chunkSize), len(sample))]
+			count, err := overlap.countMembers(ctx, others, chunk)
+			if err != nil {
+				return 0, err
+			}
+			matches += count
+		}
+		return (matches*smallestCard + int64(len(sample))/2) / int64(len(sample)), nil
+	}
+
+	var matches int64
+	cursor := models.NewCursor()
+	scanOptions := options.NewBaseScanOptions().SetCount(overlap.chunkSize)
+	for !cursor.IsFinished() {
+		result, err := overlap.client.SScanWithOptions(ctx, sets[smallest], cursor, *scanOptions)
+		if err != nil {
+			return 0, err
+		}
+		count, err := overlap.countMembers(ctx, others, result.Data)
+		if err != nil {
+			return 0, err
+		}
+		matches += count
+		cursor = result.Cursor
+	}
+	return matches, nil
+}
+
+// intersectSlots returns a set per group of keys: the key itself for groups of a single key, or else a temporary key
+// holding the intersection of the sets of the group. empty is true if an intersection is empty.
+func (overlap *SetOverlap) intersectSlots(
+	ctx context.Context,
+	groups [][]string,
+) (sets []string, tempKeys []string, empty bool, err error) {
+	suffix := ":glide-overlap:" + uuid.NewString()
+	for _, group := range groups {
+		if len(group) == 1 {
+			sets = append(sets, group[0])
+			continue
+		}
+		tempKey, ok := sameSlotKey(group[0], suffix)
+		if !ok {
+			// The sets are intersected by the client.
+			sets = append(sets, group...)
+			continue
+		}
+		tempKeys = append(tempKeys, tempKey)
+		card, err := overlap.client.SInterStore(ctx, tempKey, group)
+		if err != nil {
+			return nil, tempKeys, false, err
+		}
+		if card == 0 {
+			return nil, tempKeys, true, nil
+		}
+		if _, err := overlap.client.Expire(ctx, tempKey, overlapTempKeyTTL); err != nil {
+			return nil, tempKeys, false, err
+		}
+		sets = append(sets, tempKey)
+	}
+	return sets, tempKeys, false, nil
+}
+
+// countMembers returns the number of members which are members of all the sets.
+func (overlap *SetOverlap) countMembers(ctx context.Context, sets []string, members []string) (int64, error) {
+	for _, set := range sets {
+		if len(members) == 0 {
+			return 0, nil
+		}
+		isMember, err := overlap.client.SMIsMember(ctx, set, members)
+		if err != nil {
+			return 0, err
+		}
+		remaining := members[:0:0]
+		for i, member := range members {
+			if isMember[i] {
+				remaining = append(remaining, member)
+			}
+		}
+		members = remaining
+	}
+	return int64(len(members)), nil
+}
+
+// groupKeysBySlot returns the distinct keys grouped by slot, in the order of their first key.
+func groupKeysBySlot(keys []string) [][]string {
+	var groups [][]string
+	groupsBySlot := make(map[int]int)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		slot := keySlot(key)
+		if i, ok := groupsBySlot[slot]; ok {
+			groups[i] = append(groups[i], key)
+			continue
+		}
+		groupsBySlot[slot] = len(groups)
+		groups = append(groups, []string{key})
+	}
+	return groups
+}
@@ -0,0 +1,174 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeSetClient implements the set commands used by [SetOverlap] in memory, enforcing that multi-key commands are
+// sent keys of a single slot.
+type fakeSetClient struct {
+	interfaces.BaseClientCommands
+	sets map[string]map[string]struct{}
+}
+
+func (client *fakeSetClient) checkSlot(keys ...string) {
+	for _, key := range keys {
+		if keySlot(key) != keySlot(keys[0]) {
+			panic(fmt.Sprintf("CROSSSLOT %v", keys))
+		}
+	}
+}
+
+func (client *fakeSetClient) inter(keys []string) map[string]struct{} {
+	client.checkSlot(keys...)
+	result := make(map[string]struct{})
+	for member := range client.sets[keys[0]] {
+		all := true
+		for _, key := range keys[1:] {
+			if _, ok := client.sets[key][member]; !ok {
+				all = false
+			}
+		}
+		if all {
+			result[member] = struct{}{}
+		}
+	}
+	return result
+}
+
+func (client *fakeSetClient) SInterCard(ctx context.Context, keys []string) (int64, error) {
+	return int64(len(client.inter(keys))), nil
+}
+
+func (client *fakeSetClient) SInterStore(ctx context.Context, destination string, keys []string) (int64, error) {
+	client.checkSlot(append([]string{destination}, keys...)...)
+	client.sets[destination] = client.inter(keys)
+	return int64(len(client.sets[destination])), nil
+}
+
+func (client *fakeSetClient) Expire(ctx context.Context, key string, expireTime time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (client *fakeSetClient) Del(ctx context.Context, keys []string) (int64, error) {
+	client.checkSlot(keys...)
+	delete(client.sets, keys[0])
+	return 1, nil
+}
+
+func (client *fakeSetClient) SCard(ctx context.Context, key string) (int64, error) {
+	return int64(len(client.sets[key])), nil
+}
+
+func (client *fakeSetClient) SRandMemberCount(ctx context.Context, key string, count int64) ([]string, error) {
+	var members []string
+	for member := range client.sets[key] {
+		if int64(len(members)) == count {
+			break
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (client *fakeSetClient) SScanWithOptions(
+	ctx context.Context,
+	key string,
+	cursor models.Cursor,
+	scanOptions options.BaseScanOptions,
+) (models.ScanResult, error) {
+	var members []string
+	for member := range client.sets[key] {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	start, _ := strconv.Atoi(cursor.String())
+	end := min(start+2, len(members))
+	next := "0"
+	if end < len(members) {
+		next = strconv.Itoa(end)
+	}
+	return models.ScanResult{Cursor: models.NewCursorFromString(next), Data: members[start:end]}, nil
+}
+
+func (client *fakeSetClient) SMIsMember(ctx context.Context, key string, members []string) ([]bool, error) {
+	result := make([]bool, len(members))
+	for i, member := range members {
+		_, result[i] = client.sets[key][member]
+	}
+	return result, nil
+}
+
+func newFakeSets(sets map[string][]string) *fakeSetClient {
+	client := &fakeSetClient{sets: make(map[string]map[string]struct{})}
+	for key, members := range sets {
+		client.sets[key] = make(map[string]struct{})
+		for _, member := range members {
+			client.sets[key][member] = struct{}{}
+		}
+	}
+	return client
+}
+
+func TestSetOverlap_Exact(t *testing.T) {
+	client := newFakeSets(map[string][]string{
+		"{a}:sports": {"u1", "u2", "u3", "u4", "u5"},
+		"{a}:travel": {"u1", "u2", "u3", "u6"},
+		"tech":       {"u1", "u3", "u5", "u7"},
+		"music":      {"u1", "u2", "u3", "u7"},
+	})
+	require.NotEqual(t, keySlot("tech"), keySlot("music"))
+	overlap := NewSetOverlap(client)
+
+	size, err := overlap.Exact(context.Background(), []string{"{a}:sports", "{a}:travel"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), size)
+
+	size, err = overlap.Exact(context.Background(), []string{"{a}:sports", "{a}:travel", "tech", "music", "tech"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), size)
+	// The temporary key is deleted.
+	assert.Len(t, client.sets, 4)
+
+	size, err = overlap.Exact(context.Background(), []string{"tech", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+
+	size, err = overlap.Exact(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+}
+
+func TestSetOverlap_Estimate(t *testing.T) {
+	members := func(from, to int) []string {
+		var members []string
+		for i := from; i < to; i++ {
+			members = append(members, strconv.Itoa(i))
+		}
+		return members
+	}
+	client := newFakeSets(map[string][]string{"tech": members(0, 100), "music": members(0, 200)})
+	overlap := NewSetOverlap(client).WithSampleSize(100)
+	size, err := overlap.Estimate(context.Background(), []string{"tech", "music"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), size)
+
+	overlap.WithSampleSize(10).WithChunkSize(3)
+	size, err = overlap.Estimate(context.Background(), []string{"tech", "music"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), size)
+}
@@ -0,0 +1,40 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+func TestShutdownOptions(t *testing.T) {
+	args, err := options.NewShutdownOptions().ToArgs()
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	args, err = options.NewShutdownOptions().SetSaveMode(options.ShutdownNoSave).SetNow().SetForce().ToArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NOSAVE", "NOW", "FORCE"}, args)
+
+	args, err = options.NewShutdownOptions().SetAbort().ToArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ABORT"}, args)
+
+	_, err = options.NewShutdownOptions().SetSaveMode(options.ShutdownSave).SetAbort().ToArgs()
+	assert.EqualError(t, err, "ABORT can't be combined with the other SHUTDOWN options")
+	_, err = options.NewShutdownOptions().SetSaveMode("LATER").ToArgs()
+	assert.EqualError(t, err, "invalid SHUTDOWN save mode: LATER")
+}
+
+func TestHandleShutdownError(t *testing.T) {
+	assert.NoError(t, handleShutdownError(nil))
+	assert.NoError(t, handleShutdownError(NewDisconnectError("connection closed")))
+	closingErr := NewClosingError("executeCommand failed: the client is closed")
+	assert.Equal(t, closingErr, handleShutdownError(closingErr))
+	saveErr := NewRequestError("ERR Errors trying to SHUTDOWN. Check logs.")
+	assert.Equal(t, saveErr, handleShutdownError(saveErr))
+}
@@ -0,0 +1,281 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+const (
+	// DefaultSlotMigrationBatchSize is the number of keys a [SlotMigrator] moves with each MIGRATE command, unless
+	// configured otherwise.
+	DefaultSlotMigrationBatchSize = 100
+	// DefaultSlotMigrationTimeout is the timeout of the MIGRATE commands of a [SlotMigrator], unless configured
+	// otherwise. The client waits for the response of a MIGRATE command for its timeout, see
+	// [SlotMigrator.WithMigrateTimeout].
+	DefaultSlotMigrationTimeout = 5 * time.Second
+)
+
+// SlotMigrationProgress reports the progress of the migration of a slot by a [SlotMigrator].
+type SlotMigrationProgress struct {
+	// Slot is the slot being migrated.
+	Slot int64
+	// MigratedKeys is the number of keys moved to the target node so far.
+	MigratedKeys int64
+	// RemainingKeys is the number of keys of the slot left on the source node.
+	RemainingKeys int64
+}
+
+// SlotMigrator moves hash slots between the primaries of a cluster, as done by `valkey-cli --cluster reshard`:
+//
+//  1. The target node is set as importing the slot from the source node, and the source node as migrating the slot
+//     to the target node, with CLUSTER SETSLOT.
+//  2. The keys of the slot are moved from the source node to the target node in batches, with CLUSTER GETKEYSINSLOT
+//     and MIGRATE.
+//  3. The slot is assigned to the target node on the target node, the source node and the other primaries, with
+//     CLUSTER SETSLOT NODE.
+//
+// If the migration fails before the slot is assigned to the target node, it's rolled back: the keys moved so far
+// are moved back to the source node, and the slot states of both nodes are cleared with CLUSTER SETSLOT STABLE.
+// The rollback isn't bound to the context of the migration, so it runs even if the migration failed because its
+// context is done.
+//
+// Example usage:
+//
+//	migrator := glide.NewSlotMigrator(client).
+//		WithProgress(func(progress glide.SlotMigrationProgress) { log.Printf("%+v", progress) })
+//	err := migrator.MigrateSlot(ctx, 42, config.NodeAddress{Host: "10.0.0.1", Port: 6379},
+//		config.NodeAddress{Host: "10.0.0.2", Port: 6379})
+type SlotMigrator struct {
+	client     *ClusterClient
+	batchSize  int64
+	timeout    time.Duration
+	onProgress func(SlotMigrationProgress)
+}
+
+// NewSlotMigrator returns a [SlotMigrator] moving slots of the cluster of client.
+func NewSlotMigrator(client *ClusterClient) *SlotMigrator {
+	return &SlotMigrator{
+		client:    client,
+		batchSize: DefaultSlotMigrationBatchSize,
+		timeout:   DefaultSlotMigrationTimeout,
+	}
+}
+
+// WithBatchSize sets the number of keys moved with each MIGRATE command. Defaults to DefaultSlotMigrationBatchSize.
+func (migrator *SlotMigrator) WithBatchSize(batchSize int64) *SlotMigrator {
+	migrator.batchSize = batchSize
+	return migrator
+}
+
+// WithMigrateTimeout sets the timeout of the MIGRATE commands. Defaults to DefaultSlotMigrationTimeout.
+//
+// The timeout is sent with each MIGRATE command, bounding the time the source node waits for the target node, and
+// the client waits for the response of the command for the timeout plus half a second, instead of the request
+// timeout of the client, which is typically too short to move a batch of keys. The context of
+// [SlotMigrator.MigrateSlot] still bounds the whole migration.
+func (migrator *SlotMigrator) WithMigrateTimeout(timeout time.Duration) *SlotMigrator {
+	migrator.timeout = timeout
+	return migrator
+}
+
+// WithProgress sets a function called with the progress of the migration after every batch of keys moved.
+func (migrator *SlotMigrator) WithProgress(onProgress func(SlotMigrationProgress)) *SlotMigrator {
+	migrator.onProgress = onProgress
+	return migrator
+}
+
+// MigrateSlot moves a slot, and its keys, from the source primary to the target primary.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the migration.
+//	slot - The hash slot to move (0-16383).
+//	source - The address of the primary serving the slot.
+//	target - The address of the primary to move the slot to, as reachable from the source node.
+//
+// Return value:
+//
+//	nil once the slot is assigned to the target node. An error if the migration failed. The error wraps the error
+//	of the rollback as well, if it failed too.
+func (migrator *SlotMigrator) MigrateSlot(ctx context.Context, slot int64, source, target config.NodeAddress) error {
+	if source == target {
+		return fmt.Errorf("the source and the target of the migration of slot %d are the same node", slot)
+	}
+	sourceRoute := config.NewByAddressRoute(source.Host, int32(source.Port))
+	targetRoute := config.NewByAddressRoute(target.Host, int32(target.Port))
+	sourceId, err := migrator.nodeId(ctx, sourceRoute)
+	if err != nil {
+		return err
+	}
+	targetId, err := migrator.nodeId(ctx, targetRoute)
+	if err != nil {
+		return err
+	}
+
+	if err := migrator.setSlot(ctx, targetRoute, slot, "IMPORTING", sourceId); err != nil {
+		return migrator.fail(slot, err, nil)
+	}
+	if err := migrator.setSlot(ctx, sourceRoute, slot, "MIGRATING", targetId); err != nil {
+		return migrator.fail(slot, err, func(ctx context.Context) error {
+			return migrator.setSlot(ctx, targetRoute, slot, "STABLE")
+		})
+	}
+	rollback := func(ctx context.Context) error {
+		if err := migrator.moveKeys(ctx, slot, targetRoute, source, nil); err != nil {
+			return err
+		}
+		return errors.Join(
+			migrator.setSlot(ctx, targetRoute, slot, "STABLE"),
+			migrator.setSlot(ctx, sourceRoute, slot, "STABLE"),
+		)
+	}
+
+	if err := migrator.moveKeys(ctx, slot, sourceRoute, target, migrator.onProgress); err != nil {
+		return migrator.fail(slot, err, rollback)
+	}
+
+	// Once the target node owns the slot, the migration can't be rolled back anymore: the new configuration
+	// propagates to the other nodes.
+	if err := migrator.setSlot(ctx, targetRoute, slot, "NODE", targetId); err != nil {
+		return migrator.fail(slot, err, rollback)
+	}
+	if err := migrator.setSlot(ctx, sourceRoute, slot, "NODE", targetId); err != nil {
+		return fmt.Errorf("slot %d migration: failed to assign the slot on the source node: %w", slot, err)
+	}
+	if err := migrator.setSlot(ctx, config.AllPrimaries, slot, "NODE", targetId); err != nil {
+		return fmt.Errorf("slot %d migration: failed to assign the slot on the other primaries: %w", slot, err)
+	}
+	return nil
+}
+
+// fail rolls back a failed migration, if rollback isn't nil, and returns the error of the migration.
+func (migrator *SlotMigrator) fail(slot int64, err error, rollback func(ctx context.Context) error) error {
+	err = fmt.Errorf("slot %d migration failed: %w", slot, err)
+	if rollback == nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*migrator.timeout)
+	defer cancel()
+	if rollbackErr := rollback(ctx); rollbackErr != nil {
+		return errors.Join(err, fmt.Errorf("slot %d migration rollback failed: %w", slot, rollbackErr))
+	}
+	return err
+}
+
+// moveKeys moves the keys of slot from the node of route to the node at to, until the node of route has none left.
+func (migrator *SlotMigrator) moveKeys(
+	ctx context.Context,
+	slot int64,
+	from *config.ByAddressRoute,
+	to config.NodeAddress,
+	onProgress func(SlotMigrationProgress),
+) error {
+	progress := SlotMigrationProgress{Slot: slot}
+	for {
+		keys, err := migrator.keysInSlot(ctx, from, slot)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := migrator.migrate(ctx, from, to, keys); err != nil {
+			return err
+		}
+		if onProgress != nil {
+			progress.MigratedKeys += int64(len(keys))
+			progress.RemainingKeys, err = migrator.countKeysInSlot(ctx, from, slot)
+			if err != nil {
+				return err
+			}
+			onProgress(progress)
+		}
+	}
+}
+
+func (migrator *SlotMigrator) nodeId(ctx context.Context, route config.Route) (string, error) {
+	id, err := migrator.client.ClusterMyIdWithRoute(ctx, options.RouteOption{Route: route})
+	if err != nil {
+		return "", err
+	}
+	return id.SingleValue(), nil
+}
+
+func (migrator *SlotMigrator) setSlot(ctx context.Context, route config.Route, slot int64, args ...string) error {
+	_, err := migrator.client.executeOkWithRoute(
+		ctx,
+		C.ClusterSetslot,
+		append([]string{utils.IntToString(slot)}, args...),
+		route,
+	)
+	return err
+}
+
+func (migrator *SlotMigrator) keysInSlot(ctx context.Context, route config.Route, slot int64) ([]string, error) {
+	args := []string{utils.IntToString(slot), utils.IntToString(max(migrator.batchSize, 1))}
+	response, err := migrator.client.executeCommandWithRoute(ctx, C.ClusterGetKeysInSlot, args, route)
+	if err != nil {
+		return nil, err
+	}
+	return handleStringArrayResponse(response)
+}
+
+func (migrator *SlotMigrator) countKeysInSlot(ctx context.Context, route config.Route, slot int64) (int64, error) {
+	response, err := migrator.client.executeCommandWithRoute(
+		ctx,
+		C.ClusterCountKeysInSlot,
+		[]string{utils.IntToString(slot)},
+		route,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return handleIntResponse(response)
+}
+
+// migrate moves keys from the node of route to the node at to, authenticated with the credentials of the client.
+// MIGRATE is accepted by the nodes migrating or importing the slot of the keys without ASKING. The core waits for
+// its response for migrator.timeout, sent as its timeout, rather than for the request timeout.
+func (migrator *SlotMigrator) migrate(
+	ctx context.Context,
+	route *config.ByAddressRoute,
+	to config.NodeAddress,
+	keys []string,
+) error {
+	username, password := migrator.client.credentials()
+	response, err := migrator.client.executeCommandWithRoute(
+		ctx,
+		C.Migrate,
+		migrateArgs(to, username, password, migrator.timeout, keys),
+		route,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = handleOkOrStringOrNilResponse(response)
+	return err
+}
+
+// migrateArgs returns the arguments of a MIGRATE command moving keys to the node at to, authenticated with username
+// and password, or without authentication if password is empty.
+func migrateArgs(to config.NodeAddress, username string, password string, timeout time.Duration, keys []string) []string {
+	args := []string{to.Host, utils.IntToString(int64(to.Port)), "", "0", utils.IntToString(timeout.Milliseconds())}
+	switch {
+	case password != "" && username != "":
+		args = append(args, "AUTH2", username, password)
+	case password != "":
+		args = append(args, "AUTH", password)
+	}
+	return utils.Concat(args, []string{"KEYS"}, keys)
+}
@@ -0,0 +1,23 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+func TestMigrateArgs(t *testing.T) {
+	to := config.NodeAddress{Host: "10.0.0.2", Port: 6379}
+	keys := []string{"a", "b"}
+	assert.Equal(t, []string{"10.0.0.2", "6379", "", "0", "5000", "KEYS", "a", "b"},
+		migrateArgs(to, "", "", 5*time.Second, keys))
+	assert.Equal(t, []string{"10.0.0.2", "6379", "", "0", "5000", "AUTH", "secret", "KEYS", "a", "b"},
+		migrateArgs(to, "", "secret", 5*time.Second, keys))
+	assert.Equal(t, []string{"10.0.0.2", "6379", "", "0", "5000", "AUTH2", "user", "secret", "KEYS", "a", "b"},
+		migrateArgs(to, "user", "secret", 5*time.Second, keys))
+}
@@ -0,0 +1,115 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// StreamLag returns how far the consumer group group is behind the stream of key: the number of entries not yet
+// delivered to its consumers, the number of entries delivered but not acknowledged, and the age of the oldest of
+// them. The lag is read with XINFO GROUPS and XPENDING, e.g. to scale the consumers of the stream.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key of the stream.
+//	group - The consumer group name.
+//
+// Return value:
+//
+//	The lag of the group, or an error if the stream or the group doesn't exist.
+func (client *baseClient) StreamLag(ctx context.Context, key string, group string) (models.StreamLag, error) {
+	groups, err := client.XInfoGroups(ctx, key)
+	if err != nil {
+		return models.StreamLag{}, err
+	}
+	pending, err := client.XPending(ctx, key, group)
+	if err != nil {
+		return models.StreamLag{}, err
+	}
+	return newStreamLag(key, group, groups, pending, time.Now())
+}
+
+// AggregateStreamLag returns the lags of the consumer group group behind each of the streams of keys, and their
+// aggregate, e.g. for the consumers of a stream sharded over several keys. See StreamLag.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	group - The consumer group name.
+//	keys - The keys of the streams.
+//
+// Return value:
+//
+//	The aggregated lag of the group, or an error if any stream or its group doesn't exist.
+func (client *baseClient) AggregateStreamLag(
+	ctx context.Context,
+	group string,
+	keys ...string,
+) (models.StreamLagSummary, error) {
+	summary := models.StreamLagSummary{Group: group, Streams: make([]models.StreamLag, 0, len(keys))}
+	for _, key := range keys {
+		lag, err := client.StreamLag(ctx, key, group)
+		if err != nil {
+			return models.StreamLagSummary{Group: group}, err
+		}
+		summary.Streams = append(summary.Streams, lag)
+	}
+	aggregateStreamLags(&summary)
+	return summary, nil
+}
+
+// newStreamLag returns the lag of group behind the stream of key from the groups of the stream and the summary of
+// the pending entries of group.
+func newStreamLag(
+	key string,
+	group string,
+	groups []models.XInfoGroupInfo,
+	pending models.XPendingSummary,
+	now time.Time,
+) (models.StreamLag, error) {
+	lag := models.StreamLag{
+		Stream:          key,
+		Group:           group,
+		EntriesBehind:   models.CreateNilInt64Result(),
+		Pending:         pending.NumOfMessages,
+		OldestPendingId: pending.StartId,
+	}
+	found := false
+	for _, info := range groups {
+		if info.Name == group {
+			lag.EntriesBehind, found = info.Lag, true
+			break
+		}
+	}
+	if !found {
+		return models.StreamLag{}, fmt.Errorf("the consumer group %q of stream %q doesn't exist", group, key)
+	}
+	if !pending.StartId.IsNil() {
+		milliseconds, _, _ := strings.Cut(pending.StartId.Value(), "-")
+		if added, err := strconv.ParseInt(milliseconds, 10, 64); err == nil {
+			lag.OldestPendingAge = max(now.Sub(time.UnixMilli(added)), 0)
+		}
+	}
+	return lag, nil
+}
+
+// aggregateStreamLags sums the lags of the streams of summary.
+func aggregateStreamLags(summary *models.StreamLagSummary) {
+	for _, lag := range summary.Streams {
+		if lag.EntriesBehind.IsNil() {
+			summary.Unknown++
+		} else {
+			summary.EntriesBehind += lag.EntriesBehind.Value()
+		}
+		summary.Pending += lag.Pending
+		summary.OldestPendingAge = max(summary.OldestPendingAge, lag.OldestPendingAge)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestNewStreamLag(t *testing.T) {
+	now := time.UnixMilli(1700000060000)
+	groups := []models.XInfoGroupInfo{
+		{Name: "other", Lag: models.CreateInt64Result(100)},
+		{Name: "workers", Lag: models.CreateInt64Result(7)},
+	}
+	pending := models.XPendingSummary{
+		NumOfMessages: 3,
+		StartId:       models.CreateStringResult("1700000000000-0"),
+		EndId:         models.CreateStringResult("1700000050000-2"),
+	}
+
+	lag, err := newStreamLag("events", "workers", groups, pending, now)
+	require.NoError(t, err)
+	assert.Equal(t, models.StreamLag{
+		Stream:           "events",
+		Group:            "workers",
+		EntriesBehind:    models.CreateInt64Result(7),
+		Pending:          3,
+		OldestPendingId:  models.CreateStringResult("1700000000000-0"),
+		OldestPendingAge: time.Minute,
+	}, lag)
+
+	lag, err = newStreamLag("events", "workers", groups, models.CreateNilXPendingSummary(), now)
+	require.NoError(t, err)
+	assert.Zero(t, lag.Pending)
+	assert.True(t, lag.OldestPendingId.IsNil())
+	assert.Zero(t, lag.OldestPendingAge)
+
+	_, err = newStreamLag("events", "missing", groups, pending, now)
+	assert.ErrorContains(t, err, `"missing"`)
+}
+
+func TestAggregateStreamLags(t *testing.T) {
+	summary := models.StreamLagSummary{Group: "workers", Streams: []models.StreamLag{
+		{EntriesBehind: models.CreateInt64Result(5), Pending: 1, OldestPendingAge: time.Second},
+		{EntriesBehind: models.CreateNilInt64Result(), Pending: 2, OldestPendingAge: time.Minute},
+		{EntriesBehind: models.CreateInt64Result(10)},
+	}}
+
+	aggregateStreamLags(&summary)
+
+	assert.Equal(t, int64(15), summary.EntriesBehind)
+	assert.Equal(t, 1, summary.Unknown)
+	assert.Equal(t, int64(3), summary.Pending)
+	assert.Equal(t, time.Minute, summary.OldestPendingAge)
+}
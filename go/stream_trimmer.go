@@ -0,0 +1,225 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// DefaultStreamTrimInterval is the interval between two trims of the streams of a [StreamTrimmer], unless configured
+// otherwise.
+const DefaultStreamTrimInterval = time.Minute
+
+// StreamTrimStats reports the trims of a stream by a [StreamTrimmer] since it was created.
+type StreamTrimStats struct {
+	// Trims is the number of XTRIM commands sent for the stream, failed or not.
+	Trims int64
+	// Trimmed is the number of entries removed from the stream.
+	Trimmed int64
+	// Errors is the number of XTRIM commands which failed.
+	Errors int64
+	// LastTrim is when the stream was last trimmed, failed or not.
+	LastTrim time.Time
+	// LastError is the error of the last XTRIM command which failed, if any.
+	LastError error
+}
+
+// streamTrimPolicy is the trimming policy of a stream of a [StreamTrimmer].
+type streamTrimPolicy struct {
+	key     string
+	options options.XTrimOptions
+	// adds is the number of entries added since the last trim, as reported by [StreamTrimmer.Added].
+	adds int64
+}
+
+// StreamTrimmer enforces the trimming policies of a set of streams, e.g. MAXLEN ~ 100000 or a MINID threshold, in
+// the background, so that the producers of the streams don't have to trim them themselves.
+//
+// The streams are trimmed with XTRIM on a schedule, every interval, and, if configured with WithTrimEvery, once the
+// producers report, with Added, that a number of entries was added to a stream since its last trim. The trims of each
+// stream are counted, see Stats.
+//
+// Example usage:
+//
+//	trimmer := glide.NewStreamTrimmer(client).
+//		WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(100000).SetNearlyExactTrimming()).
+//		WithTrimEvery(1000)
+//	trimmer.Start(ctx)
+//	defer trimmer.Stop()
+//	...
+//	_, err := client.XAdd(ctx, "events", values)
+//	trimmer.Added("events")
+type StreamTrimmer struct {
+	client    interfaces.BaseClientCommands
+	interval  time.Duration
+	trimEvery int64
+	policies  []*streamTrimPolicy
+
+	mu      sync.Mutex
+	stats   map[string]StreamTrimStats
+	trigger chan *streamTrimPolicy
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewStreamTrimmer returns a [StreamTrimmer] trimming streams with client every DefaultStreamTrimInterval.
+func NewStreamTrimmer(client interfaces.BaseClientCommands) *StreamTrimmer {
+	return &StreamTrimmer{
+		client:   client,
+		interval: DefaultStreamTrimInterval,
+		stats:    make(map[string]StreamTrimStats),
+	}
+}
+
+// WithPolicy adds the stream of key, trimmed with trimOptions. Setting the policy of a stream added before replaces
+// its policy.
+func (trimmer *StreamTrimmer) WithPolicy(key string, trimOptions options.XTrimOptions) *StreamTrimmer {
+	for _, policy := range trimmer.policies {
+		if policy.key == key {
+			policy.options = trimOptions
+			return trimmer
+		}
+	}
+	trimmer.policies = append(trimmer.policies, &streamTrimPolicy{key: key, options: trimOptions})
+	return trimmer
+}
+
+// WithInterval sets the interval between two scheduled trims of the streams, or disables the scheduled trims if
+// interval isn't positive. Defaults to DefaultStreamTrimInterval.
+func (trimmer *StreamTrimmer) WithInterval(interval time.Duration) *StreamTrimmer {
+	trimmer.interval = interval
+	return trimmer
+}
+
+// WithTrimEvery sets the number of entries reported by Added after which a stream is trimmed, ahead of the schedule.
+// Disabled by default.
+func (trimmer *StreamTrimmer) WithTrimEvery(adds int64) *StreamTrimmer {
+	trimmer.trimEvery = adds
+	return trimmer
+}
+
+// Start starts trimming the streams in the background, until ctx is done or the trimmer is stopped. Starting a
+// trimmer which is already started is a no-op.
+func (trimmer *StreamTrimmer) Start(ctx context.Context) {
+	trimmer.mu.Lock()
+	defer trimmer.mu.Unlock()
+	if trimmer.done != nil {
+		return
+	}
+	ctx, trimmer.cancel = context.WithCancel(ctx)
+	trimmer.done = make(chan struct{})
+	trimmer.trigger = make(chan *streamTrimPolicy, len(trimmer.policies))
+	go trimmer.run(ctx, trimmer.trigger, trimmer.done)
+}
+
+// Stop stops the trims started by Start, and waits for the trim in progress, if any.
+func (trimmer *StreamTrimmer) Stop() {
+	trimmer.mu.Lock()
+	cancel, done := trimmer.cancel, trimmer.done
+	trimmer.cancel, trimmer.done, trimmer.trigger = nil, nil, nil
+	trimmer.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (trimmer *StreamTrimmer) run(ctx context.Context, trigger <-chan *streamTrimPolicy, done chan<- struct{}) {
+	defer close(done)
+	var tick <-chan time.Time
+	if trimmer.interval > 0 {
+		ticker := time.NewTicker(trimmer.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			_ = trimmer.TrimNow(ctx)
+		case policy := <-trigger:
+			_ = trimmer.trim(ctx, policy)
+		}
+	}
+}
+
+// Added reports that an entry was added to the stream of key, so that it's trimmed once WithTrimEvery entries
+// were added since its last trim. The trim runs in the background, if the trimmer is started. Added is a no-op for
+// the streams without a policy.
+func (trimmer *StreamTrimmer) Added(key string) {
+	if trimmer.trimEvery <= 0 {
+		return
+	}
+	trimmer.mu.Lock()
+	defer trimmer.mu.Unlock()
+	for _, policy := range trimmer.policies {
+		if policy.key != key {
+			continue
+		}
+		if policy.adds++; policy.adds >= trimmer.trimEvery && trimmer.trigger != nil {
+			policy.adds = 0
+			select {
+			case trimmer.trigger <- policy:
+			default:
+				// A trim of the stream is pending already.
+			}
+		}
+		return
+	}
+}
+
+// TrimNow trims all the streams once, whether the trimmer is started or not.
+//
+// Return value:
+//
+//	The errors of the XTRIM commands which failed, joined.
+func (trimmer *StreamTrimmer) TrimNow(ctx context.Context) error {
+	var errs []error
+	for _, policy := range trimmer.policies {
+		if err := trimmer.trim(ctx, policy); err != nil {
+			errs = append(errs, fmt.Errorf("failed to trim stream %q: %w", policy.key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (trimmer *StreamTrimmer) trim(ctx context.Context, policy *streamTrimPolicy) error {
+	trimmer.mu.Lock()
+	trimOptions := policy.options
+	policy.adds = 0
+	trimmer.mu.Unlock()
+
+	trimmed, err := trimmer.client.XTrim(ctx, policy.key, trimOptions)
+	trimmer.mu.Lock()
+	defer trimmer.mu.Unlock()
+	stats := trimmer.stats[policy.key]
+	stats.Trims++
+	stats.LastTrim = time.Now()
+	if err != nil {
+		stats.Errors++
+		stats.LastError = err
+	} else {
+		stats.Trimmed += trimmed
+	}
+	trimmer.stats[policy.key] = stats
+	return err
+}
+
+// Stats returns the trims of each stream trimmed so far, by key.
+func (trimmer *StreamTrimmer) Stats() map[string]StreamTrimStats {
+	trimmer.mu.Lock()
+	defer trimmer.mu.Unlock()
+	stats := make(map[string]StreamTrimStats, len(trimmer.stats))
+	for key, stat := range trimmer.stats {
+		stats[key] = stat
+	}
+	return stats
+}
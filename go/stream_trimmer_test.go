@@ -0,0 +1,138 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeTrimClient trims fake streams, removing a fixed number of entries per XTRIM.
+type fakeTrimClient struct {
+	interfaces.BaseClientCommands
+	mu          sync.Mutex
+	trims       map[string][][]string
+	trimmed     int64
+	err         error
+	trimmedKeys chan string
+}
+
+func (client *fakeTrimClient) XTrim(ctx context.Context, key string, trimOptions options.XTrimOptions) (int64, error) {
+	args, err := trimOptions.ToArgs()
+	if err != nil {
+		return 0, err
+	}
+	client.mu.Lock()
+	client.trims[key] = append(client.trims[key], args)
+	client.mu.Unlock()
+	if client.trimmedKeys != nil {
+		client.trimmedKeys <- key
+	}
+	if client.err != nil {
+		return 0, client.err
+	}
+	return client.trimmed, nil
+}
+
+func newFakeTrimClient(trimmed int64) *fakeTrimClient {
+	return &fakeTrimClient{trims: make(map[string][][]string), trimmed: trimmed}
+}
+
+func TestStreamTrimmer_TrimNow(t *testing.T) {
+	client := newFakeTrimClient(3)
+	trimmer := NewStreamTrimmer(client).
+		WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(100).SetExactTrimming()).
+		WithPolicy("audit", *options.NewXTrimOptionsWithMinId("1700000000000-0"))
+
+	require.NoError(t, trimmer.TrimNow(context.Background()))
+	require.NoError(t, trimmer.TrimNow(context.Background()))
+
+	assert.Equal(t, [][]string{{"MAXLEN", "=", "100"}, {"MAXLEN", "=", "100"}}, client.trims["events"])
+	assert.Equal(t, [][]string{{"MINID", "1700000000000-0"}, {"MINID", "1700000000000-0"}}, client.trims["audit"])
+	stats := trimmer.Stats()
+	assert.Equal(t, int64(2), stats["events"].Trims)
+	assert.Equal(t, int64(6), stats["events"].Trimmed)
+	assert.Equal(t, int64(0), stats["events"].Errors)
+	assert.False(t, stats["events"].LastTrim.IsZero())
+}
+
+func TestStreamTrimmer_ReplacesPolicy(t *testing.T) {
+	client := newFakeTrimClient(0)
+	trimmer := NewStreamTrimmer(client).
+		WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(100)).
+		WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(10))
+
+	require.NoError(t, trimmer.TrimNow(context.Background()))
+
+	assert.Equal(t, [][]string{{"MAXLEN", "10"}}, client.trims["events"])
+}
+
+func TestStreamTrimmer_Errors(t *testing.T) {
+	client := newFakeTrimClient(0)
+	client.err = errors.New("WRONGTYPE")
+	trimmer := NewStreamTrimmer(client).WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(100))
+
+	err := trimmer.TrimNow(context.Background())
+
+	assert.ErrorIs(t, err, client.err)
+	assert.ErrorContains(t, err, `"events"`)
+	stats := trimmer.Stats()["events"]
+	assert.Equal(t, int64(1), stats.Trims)
+	assert.Equal(t, int64(1), stats.Errors)
+	assert.Equal(t, client.err, stats.LastError)
+}
+
+func TestStreamTrimmer_TrimEvery(t *testing.T) {
+	client := newFakeTrimClient(1)
+	client.trimmedKeys = make(chan string, 10)
+	trimmer := NewStreamTrimmer(client).
+		WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(100)).
+		WithInterval(0).
+		WithTrimEvery(3)
+	trimmer.Start(context.Background())
+	defer trimmer.Stop()
+
+	trimmer.Added("events")
+	trimmer.Added("events")
+	trimmer.Added("other")
+	select {
+	case key := <-client.trimmedKeys:
+		t.Fatalf("unexpected trim of %q", key)
+	case <-time.After(20 * time.Millisecond):
+	}
+	trimmer.Added("events")
+	select {
+	case key := <-client.trimmedKeys:
+		assert.Equal(t, "events", key)
+	case <-time.After(time.Second):
+		t.Fatal("the stream wasn't trimmed")
+	}
+}
+
+func TestStreamTrimmer_Interval(t *testing.T) {
+	client := newFakeTrimClient(1)
+	client.trimmedKeys = make(chan string, 10)
+	trimmer := NewStreamTrimmer(client).
+		WithPolicy("events", *options.NewXTrimOptionsWithMaxLen(100)).
+		WithInterval(time.Millisecond)
+	trimmer.Start(context.Background())
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-client.trimmedKeys:
+		case <-time.After(time.Second):
+			t.Fatal("the stream wasn't trimmed")
+		}
+	}
+	trimmer.Stop()
+	assert.GreaterOrEqual(t, trimmer.Stats()["events"].Trims, int64(2))
+}
@@ -0,0 +1,180 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+// subscriberConnections counts the isolated subscriber connections of the clients of the process, by node address,
+// see config.SubscriberIsolationConfiguration.
+var subscriberConnections = struct {
+	sync.Mutex
+	nodes map[string]int
+}{nodes: make(map[string]int)}
+
+// subscriptionRequests are the requests sent through the isolated subscriber connections of a client.
+var subscriptionRequests = map[C.RequestType]bool{
+	C.Subscribe:            true,
+	C.SubscribeBlocking:    true,
+	C.PSubscribe:           true,
+	C.PSubscribeBlocking:   true,
+	C.SSubscribe:           true,
+	C.SSubscribeBlocking:   true,
+	C.Unsubscribe:          true,
+	C.UnsubscribeBlocking:  true,
+	C.PUnsubscribe:         true,
+	C.PUnsubscribeBlocking: true,
+	C.SUnsubscribe:         true,
+	C.SUnsubscribeBlocking: true,
+	C.GetSubscriptions:     true,
+}
+
+// isolatedSubscriber is the client holding the isolated subscriber connections of a client.
+type isolatedSubscriber struct {
+	client *baseClient
+	// nodes are the addresses of the nodes counted in subscriberConnections for the subscriber.
+	nodes []string
+}
+
+// acquireSubscriberConnections counts a subscriber connection to each of nodes, or returns a [ConfigurationError],
+// counting none, if there are maxPerNode connections to one of them already.
+func acquireSubscriberConnections(nodes []string, maxPerNode int) error {
+	subscriberConnections.Lock()
+	defer subscriberConnections.Unlock()
+	for _, node := range nodes {
+		if subscriberConnections.nodes[node] >= maxPerNode {
+			return NewConfigurationError(fmt.Sprintf(
+				"the clients of the process have %d isolated subscriber connections to %s already, the maximum",
+				subscriberConnections.nodes[node], node))
+		}
+	}
+	for _, node := range nodes {
+		subscriberConnections.nodes[node]++
+	}
+	return nil
+}
+
+// releaseSubscriberConnections forgets a subscriber connection to each of nodes.
+func releaseSubscriberConnections(nodes []string) {
+	subscriberConnections.Lock()
+	defer subscriberConnections.Unlock()
+	for _, node := range nodes {
+		if subscriberConnections.nodes[node]--; subscriberConnections.nodes[node] <= 0 {
+			delete(subscriberConnections.nodes, node)
+		}
+	}
+}
+
+// subscriberNodes returns the distinct addresses of the nodes request connects to: the addresses it's configured
+// with, which are the nodes of a standalone client, but only the seed nodes of a cluster client.
+func subscriberNodes(request *protobuf.ConnectionRequest) []string {
+	var nodes []string
+	seen := make(map[string]bool)
+	for _, address := range request.GetAddresses() {
+		node := net.JoinHostPort(address.GetHost(), strconv.FormatUint(uint64(address.GetPort()), 10))
+		if !seen[node] {
+			seen[node] = true
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// clusterSubscriberNodes returns the distinct addresses of the nodes serving slots, primaries and replicas, which the
+// subscriber connects to, from a CLUSTER SLOTS response.
+func clusterSubscriberNodes(response any) ([]string, error) {
+	ranges, ok := response.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected CLUSTER SLOTS response: %T", response)
+	}
+	var nodes []string
+	seen := make(map[string]bool)
+	for _, slotRange := range ranges {
+		fields, ok := slotRange.([]any)
+		if !ok || len(fields) < 3 {
+			return nil, fmt.Errorf("unexpected CLUSTER SLOTS range: %v", slotRange)
+		}
+		for _, field := range fields[2:] {
+			address, ok := field.([]any)
+			if !ok || len(address) < 2 {
+				return nil, fmt.Errorf("unexpected CLUSTER SLOTS node: %v", field)
+			}
+			host, hostOk := address[0].(string)
+			port, portOk := address[1].(int64)
+			if !hostOk || !portOk {
+				return nil, fmt.Errorf("unexpected CLUSTER SLOTS node: %v", field)
+			}
+			node := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+			if !seen[node] {
+				seen[node] = true
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// isolateSubscriber connects the isolated subscriber connections of the client with subscriberConfig, which receive
+// the messages with the message handler of the client.
+func (client *baseClient) isolateSubscriber(
+	subscriberConfig clientConfiguration,
+	isolation *config.SubscriberIsolationConfiguration,
+) error {
+	request, err := subscriberConfig.ToProtobuf()
+	if err != nil {
+		return err
+	}
+	subscriber, err := createClient(subscriberConfig)
+	if err != nil {
+		return err
+	}
+	nodes := subscriberNodes(request)
+	if request.GetClusterModeEnabled() {
+		// The nodes of the cluster are only known from its topology once connected, as the client may be
+		// configured with some of them only.
+		if nodes, err = subscriber.slotNodes(); err != nil {
+			subscriber.Close()
+			return err
+		}
+	}
+	if err := acquireSubscriberConnections(nodes, isolation.GetMaxConnectionsPerNode()); err != nil {
+		subscriber.Close()
+		return err
+	}
+	subscriber.setMessageHandler(client.getMessageHandler())
+	client.subscriber = &isolatedSubscriber{client: subscriber, nodes: nodes}
+	return nil
+}
+
+// slotNodes returns the addresses of the nodes serving the slots of the cluster of the client.
+func (client *baseClient) slotNodes() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), max(client.coreTimeout, time.Second))
+	defer cancel()
+	response, err := client.sendCommand(ctx, C.ClusterSlots, []string{}, config.RandomRoute)
+	if err != nil {
+		return nil, err
+	}
+	slots, err := handleInterfaceResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return clusterSubscriberNodes(slots)
+}
+
+// close closes the subscriber connections.
+func (subscriber *isolatedSubscriber) close() {
+	subscriber.client.Close()
+	releaseSubscriberConnections(subscriber.nodes)
+}
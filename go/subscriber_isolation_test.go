@@ -0,0 +1,55 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestSubscriberNodes(t *testing.T) {
+	request := &protobuf.ConnectionRequest{Addresses: []*protobuf.NodeAddress{
+		{Host: "node-1", Port: 6379},
+		{Host: "::1", Port: 7000},
+		{Host: "node-1", Port: 6379},
+	}}
+	assert.Equal(t, []string{"node-1:6379", "[::1]:7000"}, subscriberNodes(request))
+}
+
+func TestClusterSubscriberNodes(t *testing.T) {
+	nodes, err := clusterSubscriberNodes([]any{
+		[]any{int64(0), int64(8191), []any{"10.0.0.1", int64(6379), "id-1"}, []any{"10.0.0.2", int64(6379), "id-2"}},
+		[]any{int64(8192), int64(10000), []any{"10.0.0.3", int64(6379), "id-3"}},
+		[]any{int64(10001), int64(16383), []any{"10.0.0.3", int64(6379), "id-3"}, []any{"10.0.0.1", int64(6379)}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:6379", "10.0.0.2:6379", "10.0.0.3:6379"}, nodes)
+
+	_, err = clusterSubscriberNodes([]any{[]any{int64(0), int64(16383), []any{"10.0.0.1"}}})
+	assert.Error(t, err)
+}
+
+func TestSubscriberConnections_Cap(t *testing.T) {
+	nodes := []string{"cap-node-1:6379", "cap-node-2:6379"}
+	require.NoError(t, acquireSubscriberConnections(nodes, 2))
+	require.NoError(t, acquireSubscriberConnections(nodes[:1], 2))
+
+	// A subscriber exceeding the cap of one of its nodes counts no connection.
+	err := acquireSubscriberConnections(nodes, 2)
+	assert.IsType(t, &ConfigurationError{}, err)
+	assert.ErrorContains(t, err, "cap-node-1:6379")
+	require.NoError(t, acquireSubscriberConnections(nodes[1:], 2))
+
+	releaseSubscriberConnections(nodes)
+	require.NoError(t, acquireSubscriberConnections(nodes[:1], 2))
+	releaseSubscriberConnections(nodes)
+	releaseSubscriberConnections(nodes)
+	subscriberConnections.Lock()
+	defer subscriberConnections.Unlock()
+	assert.NotContains(t, subscriberConnections.nodes, nodes[0])
+	assert.NotContains(t, subscriberConnections.nodes, nodes[1])
+}
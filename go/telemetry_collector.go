@@ -0,0 +1,238 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"log"
+	"maps"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+const (
+	// DefaultTelemetryInterval is the interval between two reports of a [TelemetryCollector], unless configured
+	// otherwise.
+	DefaultTelemetryInterval = time.Minute
+	// DefaultTelemetryReportTimeout bounds the reports of a [TelemetryCollector], unless configured otherwise.
+	DefaultTelemetryReportTimeout = 10 * time.Second
+)
+
+// TelemetryReporter ships the telemetry collected by a [TelemetryCollector], e.g. to a central service collecting
+// the health of the clients of a fleet.
+type TelemetryReporter interface {
+	// Report ships telemetry. It's called by a single goroutine, with a context bounded by the report timeout of the
+	// collector.
+	Report(ctx context.Context, telemetry models.ClientTelemetry) error
+}
+
+// TelemetryReporterFunc is a [TelemetryReporter] function.
+type TelemetryReporterFunc func(ctx context.Context, telemetry models.ClientTelemetry) error
+
+// Report calls reporter with telemetry.
+func (reporter TelemetryReporterFunc) Report(ctx context.Context, telemetry models.ClientTelemetry) error {
+	return reporter(ctx, telemetry)
+}
+
+// TelemetryCollector periodically collects the statistics of a client, its requests, their errors and latency, its
+// connections, the usage of its request scheduler, the topology of its cluster and the backlog of its subscriptions,
+// into a compact [models.ClientTelemetry], and reports it to a [TelemetryReporter], so that a central service can
+// collect the health of the clients of a fleet without each team wiring their metrics individually.
+//
+// The telemetry holds the statistics the client collects: the request statistics are only collected for the clients
+// configured with request metrics, see WithMetrics in the client configuration, and are aggregated over all their
+// labels. Collecting the telemetry doesn't send any request.
+//
+// Example usage:
+//
+//	collector := glide.NewTelemetryCollector(client, glide.TelemetryReporterFunc(
+//		func(ctx context.Context, telemetry models.ClientTelemetry) error {
+//			return postJSON(ctx, fleetURL, telemetry)
+//		})).
+//		WithLabels(map[string]string{"service": "checkout", "instance": hostname})
+//	collector.Start()
+//	defer collector.Close()
+type TelemetryCollector struct {
+	client        metricsSource
+	reporter      TelemetryReporter
+	interval      time.Duration
+	reportTimeout time.Duration
+	labels        map[string]string
+	onError       func(error)
+	now           func() time.Time
+
+	mu      sync.Mutex
+	stop    chan struct{}
+	stopped chan struct{}
+	closed  bool
+}
+
+// NewTelemetryCollector returns a [TelemetryCollector] reporting the telemetry of client, a [Client] or a
+// [ClusterClient], to reporter once started.
+func NewTelemetryCollector(client metricsSource, reporter TelemetryReporter) *TelemetryCollector {
+	return &TelemetryCollector{
+		client:        client,
+		reporter:      reporter,
+		interval:      DefaultTelemetryInterval,
+		reportTimeout: DefaultTelemetryReportTimeout,
+		now:           time.Now,
+	}
+}
+
+// WithInterval sets the interval between two reports. Defaults to DefaultTelemetryInterval.
+func (collector *TelemetryCollector) WithInterval(interval time.Duration) *TelemetryCollector {
+	collector.interval = interval
+	return collector
+}
+
+// WithReportTimeout sets the timeout of the reports. Defaults to DefaultTelemetryReportTimeout.
+func (collector *TelemetryCollector) WithReportTimeout(timeout time.Duration) *TelemetryCollector {
+	collector.reportTimeout = timeout
+	return collector
+}
+
+// WithLabels sets the labels of the telemetry, identifying the client in the fleet, e.g. its service and instance.
+func (collector *TelemetryCollector) WithLabels(labels map[string]string) *TelemetryCollector {
+	collector.labels = maps.Clone(labels)
+	return collector
+}
+
+// WithOnError sets the callback the errors of the reports are passed to. The errors are logged with the standard
+// logger by default.
+func (collector *TelemetryCollector) WithOnError(onError func(error)) *TelemetryCollector {
+	collector.onError = onError
+	return collector
+}
+
+// Collect returns the telemetry of the client now, without reporting it.
+func (collector *TelemetryCollector) Collect() models.ClientTelemetry {
+	return summarizeTelemetry(collector.client.metricsSnapshot(), collector.labels, collector.now())
+}
+
+// Start starts reporting the telemetry every interval, until the collector is closed. Starting a collector which is
+// running or closed is a no-op.
+func (collector *TelemetryCollector) Start() {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if collector.closed || collector.stop != nil {
+		return
+	}
+	collector.stop = make(chan struct{})
+	collector.stopped = make(chan struct{})
+	go collector.run(collector.stop, collector.stopped)
+}
+
+func (collector *TelemetryCollector) run(stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	interval := collector.interval
+	if interval <= 0 {
+		interval = DefaultTelemetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			collector.report()
+		}
+	}
+}
+
+// report reports the telemetry of the client now.
+func (collector *TelemetryCollector) report() {
+	ctx, cancel := context.WithTimeout(context.Background(), collector.reportTimeout)
+	defer cancel()
+	if err := collector.reporter.Report(ctx, collector.Collect()); err != nil {
+		if collector.onError != nil {
+			collector.onError(err)
+		} else {
+			log.Printf("glide: failed to report the client telemetry: %v", err)
+		}
+	}
+}
+
+// Close stops reporting the telemetry, and waits for the report in progress, if any, to return.
+func (collector *TelemetryCollector) Close() {
+	collector.mu.Lock()
+	collector.closed = true
+	stop, stopped := collector.stop, collector.stopped
+	collector.stop = nil
+	collector.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-stopped
+	}
+}
+
+// summarizeTelemetry summarizes the metrics of a client into its telemetry.
+func summarizeTelemetry(
+	snapshot clientMetricsSnapshot,
+	labels map[string]string,
+	collectedAt time.Time,
+) models.ClientTelemetry {
+	telemetry := models.ClientTelemetry{
+		Labels:                 labels,
+		CollectedAt:            collectedAt,
+		Connections:            snapshot.statistics["total_connections"],
+		Clients:                snapshot.statistics["total_clients"],
+		PubSubBufferedMessages: snapshot.pubSub.BufferedMessages,
+		PubSubDroppedMessages:  snapshot.pubSub.DroppedMessages,
+	}
+	var latencySum time.Duration
+	buckets := map[time.Duration]uint64{}
+	for _, series := range snapshot.requests {
+		telemetry.Requests += series.Requests
+		latencySum += series.LatencySum
+		for kind, count := range series.Errors {
+			if telemetry.Errors == nil {
+				telemetry.Errors = make(map[string]uint64)
+			}
+			telemetry.Errors[kind] += count
+		}
+		for _, bucket := range series.LatencyBuckets {
+			buckets[bucket.UpperBound] += bucket.Count
+		}
+	}
+	if telemetry.Requests > 0 {
+		telemetry.MeanLatencyUs = (latencySum / time.Duration(telemetry.Requests)).Microseconds()
+		telemetry.P50LatencyUs = latencyQuantile(buckets, telemetry.Requests, 0.5).Microseconds()
+		telemetry.P99LatencyUs = latencyQuantile(buckets, telemetry.Requests, 0.99).Microseconds()
+	}
+	if scheduler := snapshot.scheduler; scheduler != nil {
+		telemetry.Pool = &models.PoolTelemetry{
+			InFlight: scheduler.inUse,
+			Waiting:  scheduler.waiting,
+			Capacity: scheduler.capacity,
+		}
+	}
+	if topology := snapshot.topology; topology != nil {
+		telemetry.Topology = &models.TopologyTelemetry{
+			Primaries:       topology.primaries,
+			UnassignedSlots: topology.unassignedSlots,
+		}
+	}
+	return telemetry
+}
+
+// latencyQuantile returns the upper bound of the first of the cumulative latency buckets holding at least quantile
+// of requests, or 0 if none does.
+func latencyQuantile(buckets map[time.Duration]uint64, requests uint64, quantile float64) time.Duration {
+	bounds := make([]time.Duration, 0, len(buckets))
+	for bound := range buckets {
+		bounds = append(bounds, bound)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+	rank := uint64(math.Ceil(quantile * float64(requests)))
+	for _, bound := range bounds {
+		if buckets[bound] >= rank {
+			return bound
+		}
+	}
+	return 0
+}
@@ -0,0 +1,122 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+func TestSummarizeTelemetry(t *testing.T) {
+	buckets := func(counts ...uint64) []models.LatencyBucket {
+		bounds := []time.Duration{time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond}
+		result := make([]models.LatencyBucket, len(counts))
+		for i, count := range counts {
+			result[i] = models.LatencyBucket{UpperBound: bounds[i], Count: count}
+		}
+		return result
+	}
+	source := fakeMetricsSource{
+		requests: []models.RequestMetrics{
+			{
+				Labels:         models.RequestMetricLabels{Command: "GET"},
+				Requests:       90,
+				Errors:         map[string]uint64{"TIMEOUT": 1},
+				LatencySum:     90 * time.Millisecond,
+				LatencyBuckets: buckets(80, 90, 90),
+			},
+			{
+				Labels:         models.RequestMetricLabels{Command: "SET"},
+				Requests:       10,
+				Errors:         map[string]uint64{"TIMEOUT": 2, "WRONGTYPE": 1},
+				LatencySum:     110 * time.Millisecond,
+				LatencyBuckets: buckets(0, 5, 10),
+			},
+		},
+		statistics: map[string]uint64{"total_connections": 4, "total_clients": 2},
+		pubSub:     models.PubSubMetrics{BufferedMessages: 3, DroppedMessages: 1},
+		scheduler:  &schedulerUsage{inUse: 5, waiting: 1, capacity: 10},
+		topology:   &topologyUsage{primaries: 3, unassignedSlots: 4},
+	}
+	collector := NewTelemetryCollector(source, nil).WithLabels(map[string]string{"service": "checkout"})
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	collector.now = func() time.Time { return now }
+
+	telemetry := collector.Collect()
+	assert.Equal(t, models.ClientTelemetry{
+		Labels:                 map[string]string{"service": "checkout"},
+		CollectedAt:            now,
+		Connections:            4,
+		Clients:                2,
+		Requests:               100,
+		Errors:                 map[string]uint64{"TIMEOUT": 3, "WRONGTYPE": 1},
+		MeanLatencyUs:          2000,
+		P50LatencyUs:           1000,
+		P99LatencyUs:           100000,
+		Pool:                   &models.PoolTelemetry{InFlight: 5, Waiting: 1, Capacity: 10},
+		Topology:               &models.TopologyTelemetry{Primaries: 3, UnassignedSlots: 4},
+		PubSubBufferedMessages: 3,
+		PubSubDroppedMessages:  1,
+	}, telemetry)
+
+	encoded, err := json.Marshal(collector.Collect())
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"p99_latency_us":100000`)
+	assert.Contains(t, string(encoded), `"pool":{"in_flight":5,"waiting":1,"capacity":10}`)
+
+	// The optional statistics are left out.
+	encoded, err = json.Marshal(summarizeTelemetry(clientMetricsSnapshot{}, nil, now))
+	require.NoError(t, err)
+	assert.Equal(t, `{"collected_at":"2024-05-01T10:00:00Z","connections":0,"clients":0,"requests":0,`+
+		`"mean_latency_us":0,"p50_latency_us":0,"p99_latency_us":0,"pubsub_buffered_messages":0,`+
+		`"pubsub_dropped_messages":0}`, string(encoded))
+}
+
+func TestTelemetryCollector_Reports(t *testing.T) {
+	var mu sync.Mutex
+	var reports []models.ClientTelemetry
+	reporter := TelemetryReporterFunc(func(ctx context.Context, telemetry models.ClientTelemetry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, telemetry)
+		if len(reports) == 1 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	errs := make(chan error, 1)
+	source := fakeMetricsSource{statistics: map[string]uint64{"total_clients": 1}}
+	collector := NewTelemetryCollector(source, reporter).
+		WithInterval(time.Millisecond).
+		WithOnError(func(err error) { errs <- err })
+	collector.Start()
+	collector.Start()
+
+	assert.EqualError(t, <-errs, "connection refused")
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(reports) >= 3
+	}, time.Second, time.Millisecond)
+	collector.Close()
+	mu.Lock()
+	count := len(reports)
+	mu.Unlock()
+	assert.Equal(t, uint64(1), reports[count-1].Clients)
+
+	// A closed collector doesn't report anymore.
+	collector.Start()
+	time.Sleep(5 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, reports, count)
+}
@@ -0,0 +1,260 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// TenantCredentials returns the credentials of the ACL user of a tenant, e.g. read from a secret store. The ACL user
+// of a tenant should only be granted the keys of its prefix, e.g. with "~tenant-42:*".
+type TenantCredentials func(tenantID string) (*config.ServerCredentials, error)
+
+// tenantClient is a client of a [TenantPool], [*Client] or [*ClusterClient].
+type tenantClient[T any] interface {
+	With(viewOptions *ViewOptions) T
+	Close()
+}
+
+// tenantEntry is the client of a tenant of a [TenantPool], or its connection in progress until done is closed.
+type tenantEntry[T tenantClient[T]] struct {
+	tenantID string
+	done     chan struct{}
+	client   T
+	view     T
+	err      error
+	// element is the element of the tenant in the recency list of the pool.
+	element *list.Element
+}
+
+// TenantPool maintains a client per tenant of a multi-tenant service sharing a server or a cluster, for the isolation
+// of the tenants: the client of a tenant authenticates with the ACL user of the tenant, and prefixes its keys with the
+// key prefix of the tenant, e.g. "tenant-42:", see [ViewOptions.WithKeyPrefix].
+//
+// The clients are created on demand, by the first call to Get for their tenant, and are cached by tenant ID. They
+// share the configuration the pool is created with, e.g. its addresses and TLS, and only differ by their credentials.
+// With a maximum number of tenants, the client of the least recently used tenant is closed once the maximum is
+// exceeded, so that the pool doesn't keep connections open for inactive tenants: the requests still using it fail
+// with a [ClosingError], and the next call to Get for the tenant creates a new client.
+//
+// Example usage:
+//
+//	pool := glide.NewClusterTenantPool(clusterConfig, func(tenantID string) (*config.ServerCredentials, error) {
+//		return config.NewServerCredentials("tenant-"+tenantID, secrets.Password(tenantID)), nil
+//	}).WithMaxTenants(500)
+//	defer pool.Close()
+//
+//	client, err := pool.Get(request.TenantID)
+//	if err != nil {
+//		return err
+//	}
+//	value, err := client.Get(ctx, "profile") // Gets tenant-42:profile as the ACL user of tenant 42.
+type TenantPool[T tenantClient[T]] struct {
+	credentials TenantCredentials
+	connect     func(credentials *config.ServerCredentials) (T, error)
+	keyPrefix   func(tenantID string) string
+	maxTenants  int
+
+	mu      sync.Mutex
+	tenants map[string]*tenantEntry[T]
+	// recency orders the tenants from the most to the least recently used.
+	recency *list.List
+	closed  bool
+}
+
+// NewTenantPool returns a [TenantPool] of standalone clients configured with clientConfig, authenticating with the
+// credentials of each tenant. The keys of a tenant are prefixed with its ID and a colon, unless configured otherwise.
+// Pub/Sub subscriptions aren't supported.
+func NewTenantPool(clientConfig *config.ClientConfiguration, credentials TenantCredentials) *TenantPool[*Client] {
+	return newTenantPool(credentials, func(tenantCredentials *config.ServerCredentials) (*Client, error) {
+		if clientConfig.HasSubscription() {
+			return nil, errors.New("a tenant pool doesn't support Pub/Sub subscriptions")
+		}
+		tenantConfig := *clientConfig
+		return NewClient(tenantConfig.WithCredentials(tenantCredentials))
+	})
+}
+
+// NewClusterTenantPool returns a [TenantPool] of cluster clients configured with clientConfig, authenticating with the
+// credentials of each tenant. The keys of a tenant are prefixed with its ID and a colon, unless configured otherwise.
+// Pub/Sub subscriptions aren't supported.
+func NewClusterTenantPool(
+	clientConfig *config.ClusterClientConfiguration,
+	credentials TenantCredentials,
+) *TenantPool[*ClusterClient] {
+	return newTenantPool(credentials, func(tenantCredentials *config.ServerCredentials) (*ClusterClient, error) {
+		if clientConfig.HasSubscription() {
+			return nil, errors.New("a tenant pool doesn't support Pub/Sub subscriptions")
+		}
+		tenantConfig := *clientConfig
+		return NewClusterClient(tenantConfig.WithCredentials(tenantCredentials))
+	})
+}
+
+func newTenantPool[T tenantClient[T]](
+	credentials TenantCredentials,
+	connect func(credentials *config.ServerCredentials) (T, error),
+) *TenantPool[T] {
+	return &TenantPool[T]{
+		credentials: credentials,
+		connect:     connect,
+		keyPrefix:   func(tenantID string) string { return tenantID + ":" },
+		tenants:     make(map[string]*tenantEntry[T]),
+		recency:     list.New(),
+	}
+}
+
+// WithKeyPrefix sets the function returning the key prefix of a tenant, e.g. for prefixes with a hash tag in cluster
+// mode, keeping all the keys of a tenant in the same slot. Defaults to the ID of the tenant followed by a colon.
+func (pool *TenantPool[T]) WithKeyPrefix(keyPrefix func(tenantID string) string) *TenantPool[T] {
+	pool.keyPrefix = keyPrefix
+	return pool
+}
+
+// WithMaxTenants sets the number of tenants whose client is kept open, the client of the least recently used tenant
+// being closed once it's exceeded, or 0 to keep the clients of all the tenants open. Defaults to 0.
+func (pool *TenantPool[T]) WithMaxTenants(maxTenants int) *TenantPool[T] {
+	pool.maxTenants = maxTenants
+	return pool
+}
+
+// Get returns the client of a tenant, and creates it if the pool has none yet. The concurrent calls for a tenant
+// whose client is being created wait for its creation.
+//
+// Parameters:
+//
+//	tenantID - The ID of the tenant.
+//
+// Return value:
+//
+//	The client of the tenant, a view prefixing its keys with the key prefix of the tenant, or a [ClosingError] if the
+//	pool is closed, or the error of the credentials or of the creation of the client of the tenant. Failures aren't
+//	cached: the next call for the tenant tries again.
+func (pool *TenantPool[T]) Get(tenantID string) (T, error) {
+	var zero T
+	pool.mu.Lock()
+	if pool.closed {
+		pool.mu.Unlock()
+		return zero, NewClosingError("the tenant pool is closed")
+	}
+	if entry, ok := pool.tenants[tenantID]; ok {
+		pool.recency.MoveToFront(entry.element)
+		pool.mu.Unlock()
+		<-entry.done
+		if entry.err != nil {
+			return zero, entry.err
+		}
+		return entry.view, nil
+	}
+	entry := &tenantEntry[T]{tenantID: tenantID, done: make(chan struct{})}
+	entry.element = pool.recency.PushFront(entry)
+	pool.tenants[tenantID] = entry
+	evicted := pool.evict()
+	pool.mu.Unlock()
+	closeTenants(evicted)
+
+	entry.client, entry.err = pool.open(tenantID)
+	if entry.err == nil {
+		entry.view = entry.client.With(NewViewOptions().WithKeyPrefix(pool.keyPrefix(tenantID)))
+	}
+	pool.mu.Lock()
+	current, tracked := pool.tenants[tenantID]
+	if entry.err != nil && tracked && current == entry {
+		pool.remove(entry)
+	}
+	// A client created for a tenant evicted or removed meanwhile, or once the pool is closed, is closed right away.
+	orphaned := entry.err == nil && (!tracked || current != entry)
+	pool.mu.Unlock()
+	close(entry.done)
+	if orphaned {
+		entry.client.Close()
+		return zero, NewClosingError(fmt.Sprintf("the client of tenant %q was closed while it was created", tenantID))
+	}
+	return entry.view, entry.err
+}
+
+// open creates the client of tenantID.
+func (pool *TenantPool[T]) open(tenantID string) (T, error) {
+	var zero T
+	credentials, err := pool.credentials(tenantID)
+	if err != nil {
+		return zero, fmt.Errorf("failed to get the credentials of tenant %q: %w", tenantID, err)
+	}
+	client, err := pool.connect(credentials)
+	if err != nil {
+		return zero, fmt.Errorf("failed to create the client of tenant %q: %w", tenantID, err)
+	}
+	return client, nil
+}
+
+// evict removes the least recently used tenants exceeding the maximum number of tenants, and returns them. It must
+// be called with pool.mu held.
+func (pool *TenantPool[T]) evict() []*tenantEntry[T] {
+	var evicted []*tenantEntry[T]
+	for pool.maxTenants > 0 && len(pool.tenants) > pool.maxTenants {
+		entry := pool.recency.Back().Value.(*tenantEntry[T])
+		pool.remove(entry)
+		evicted = append(evicted, entry)
+	}
+	return evicted
+}
+
+// remove stops tracking the tenant of entry. It must be called with pool.mu held.
+func (pool *TenantPool[T]) remove(entry *tenantEntry[T]) {
+	delete(pool.tenants, entry.tenantID)
+	pool.recency.Remove(entry.element)
+}
+
+// closeTenants closes the clients of entries once they're created. The clients still being created are closed by
+// the call to Get creating them.
+func closeTenants[T tenantClient[T]](entries []*tenantEntry[T]) {
+	for _, entry := range entries {
+		select {
+		case <-entry.done:
+			if entry.err == nil {
+				entry.client.Close()
+			}
+		default:
+		}
+	}
+}
+
+// Evict closes the client of a tenant, if the pool has one, e.g. once the tenant is offboarded or its credentials
+// are rotated. The next call to Get for the tenant creates a new client.
+func (pool *TenantPool[T]) Evict(tenantID string) {
+	pool.mu.Lock()
+	entry, ok := pool.tenants[tenantID]
+	if ok {
+		pool.remove(entry)
+	}
+	pool.mu.Unlock()
+	if ok {
+		closeTenants([]*tenantEntry[T]{entry})
+	}
+}
+
+// Tenants returns the number of tenants whose client is open or being created.
+func (pool *TenantPool[T]) Tenants() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return len(pool.tenants)
+}
+
+// Close closes the clients of all the tenants. Get fails with a [ClosingError] once the pool is closed.
+func (pool *TenantPool[T]) Close() {
+	pool.mu.Lock()
+	pool.closed = true
+	entries := make([]*tenantEntry[T], 0, len(pool.tenants))
+	for _, entry := range pool.tenants {
+		entries = append(entries, entry)
+	}
+	pool.tenants = make(map[string]*tenantEntry[T])
+	pool.recency.Init()
+	pool.mu.Unlock()
+	closeTenants(entries)
+}
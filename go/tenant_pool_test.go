@@ -0,0 +1,145 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+)
+
+// fakeTenantClient records the credentials it's created with, the key prefix of its view and whether it's closed.
+type fakeTenantClient struct {
+	credentials *config.ServerCredentials
+	keyPrefix   string
+	closed      atomic.Bool
+	parent      *fakeTenantClient
+}
+
+func (client *fakeTenantClient) With(viewOptions *ViewOptions) *fakeTenantClient {
+	return &fakeTenantClient{credentials: client.credentials, keyPrefix: viewOptions.keyPrefix, parent: client}
+}
+
+func (client *fakeTenantClient) Close() {
+	client.closed.Store(true)
+}
+
+var tenantCredentials = map[string]*config.ServerCredentials{
+	"7":  config.NewServerCredentials("tenant-7", "secret-7"),
+	"8":  config.NewServerCredentials("tenant-8", "secret-8"),
+	"42": config.NewServerCredentials("tenant-42", "secret-42"),
+}
+
+func newFakeTenantPool(connectErr error) (*TenantPool[*fakeTenantClient], *atomic.Int32) {
+	var connects atomic.Int32
+	credentials := func(tenantID string) (*config.ServerCredentials, error) {
+		if tenantID == "unknown" {
+			return nil, errors.New("no such tenant")
+		}
+		if creds, ok := tenantCredentials[tenantID]; ok {
+			return creds, nil
+		}
+		return config.NewServerCredentials("tenant-"+tenantID, "secret-"+tenantID), nil
+	}
+	pool := newTenantPool(credentials, func(credentials *config.ServerCredentials) (*fakeTenantClient, error) {
+		connects.Add(1)
+		if connectErr != nil {
+			return nil, connectErr
+		}
+		return &fakeTenantClient{credentials: credentials}, nil
+	})
+	return pool, &connects
+}
+
+func TestTenantPool_Get(t *testing.T) {
+	pool, connects := newFakeTenantPool(nil)
+
+	client, err := pool.Get("42")
+	require.NoError(t, err)
+	assert.Same(t, tenantCredentials["42"], client.credentials)
+	assert.Equal(t, "42:", client.keyPrefix)
+	again, err := pool.Get("42")
+	require.NoError(t, err)
+	assert.Same(t, client, again)
+	other, err := pool.Get("7")
+	require.NoError(t, err)
+	assert.Same(t, tenantCredentials["7"], other.credentials)
+	assert.Equal(t, int32(2), connects.Load())
+
+	pool.WithKeyPrefix(func(tenantID string) string { return "{t" + tenantID + "}:" })
+	hashTagged, err := pool.Get("8")
+	require.NoError(t, err)
+	assert.Equal(t, "{t8}:", hashTagged.keyPrefix)
+
+	pool.Evict("42")
+	assert.True(t, client.parent.closed.Load())
+	assert.Equal(t, 2, pool.Tenants())
+
+	pool.Close()
+	assert.True(t, other.parent.closed.Load())
+	assert.True(t, hashTagged.parent.closed.Load())
+	_, err = pool.Get("7")
+	assert.IsType(t, &ClosingError{}, err)
+}
+
+func TestTenantPool_Errors(t *testing.T) {
+	pool, _ := newFakeTenantPool(nil)
+	_, err := pool.Get("unknown")
+	assert.ErrorContains(t, err, `failed to get the credentials of tenant "unknown": no such tenant`)
+	assert.Equal(t, 0, pool.Tenants())
+
+	connectErr := errors.New("WRONGPASS invalid username-password pair")
+	pool, connects := newFakeTenantPool(connectErr)
+	_, err = pool.Get("42")
+	assert.ErrorIs(t, err, connectErr)
+	assert.ErrorContains(t, err, `failed to create the client of tenant "42"`)
+	// Failures aren't cached.
+	_, err = pool.Get("42")
+	assert.ErrorIs(t, err, connectErr)
+	assert.Equal(t, int32(2), connects.Load())
+	assert.Equal(t, 0, pool.Tenants())
+}
+
+func TestTenantPool_MaxTenants(t *testing.T) {
+	pool, _ := newFakeTenantPool(nil)
+	pool.WithMaxTenants(2)
+
+	first, err := pool.Get("1")
+	require.NoError(t, err)
+	second, err := pool.Get("2")
+	require.NoError(t, err)
+	_, err = pool.Get("1")
+	require.NoError(t, err)
+
+	// The least recently used tenant is evicted.
+	_, err = pool.Get("3")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pool.Tenants())
+	assert.True(t, second.parent.closed.Load())
+	assert.False(t, first.parent.closed.Load())
+}
+
+func TestTenantPool_Concurrent(t *testing.T) {
+	pool, connects := newFakeTenantPool(nil)
+	var wg sync.WaitGroup
+	clients := make([]*fakeTenantClient, 20)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i], _ = pool.Get("42")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), connects.Load())
+	for _, client := range clients {
+		assert.Same(t, clients[0], client)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type timeoutClassContextKeyType struct{}
+
+// TimeoutClassContextKey is the context key used to store the timeout class of requests.
+// This key is used by WithTimeoutClass() and TimeoutClassFromContext() functions.
+var TimeoutClassContextKey = timeoutClassContextKeyType{}
+
+// WithTimeoutClass returns a copy of ctx that tags the requests executed with it with the timeout class name, which
+// must be defined in the configuration of the client, see [config.ClientConfiguration.WithTimeoutClass]. The requests
+// wait the timeout of the class for their response, instead of the request timeout of the client or of its view,
+// unless ctx has an earlier deadline.
+//
+// The requests tagged with a class the client doesn't define fail with a [ConfigurationError].
+//
+// Example usage:
+//
+//	// With a client configured with WithTimeoutClass("fast", 20*time.Millisecond)
+//	ctx = glide.WithTimeoutClass(ctx, "fast")
+//	value, err := client.Get(ctx, "session:1")
+func WithTimeoutClass(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, TimeoutClassContextKey, name)
+}
+
+// TimeoutClassFromContext returns the timeout class stored in ctx using [WithTimeoutClass], or false if there is none.
+func TimeoutClassFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	name, ok := ctx.Value(TimeoutClassContextKey).(string)
+	return name, ok
+}
+
+// classTimeout returns the timeout of the timeout class of ctx, or 0 if ctx has no timeout class, or a
+// [ConfigurationError] if the client doesn't define the class of ctx.
+func (client *baseClient) classTimeout(ctx context.Context) (time.Duration, error) {
+	name, ok := TimeoutClassFromContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	var timeoutClasses map[string]time.Duration
+	if client.timeoutClasses != nil {
+		if loaded := client.timeoutClasses.Load(); loaded != nil {
+			timeoutClasses = *loaded
+		}
+	}
+	timeout, ok := timeoutClasses[name]
+	if !ok {
+		return 0, NewConfigurationError(fmt.Sprintf("the timeout class %q isn't defined", name))
+	}
+	return timeout, nil
+}
+
+// updateTimeoutClasses defines the timeout classes of updates, or replaces their timeouts, keeping the other classes.
+func (client *baseClient) updateTimeoutClasses(updates map[string]time.Duration) {
+	if len(updates) == 0 {
+		return
+	}
+	for {
+		loaded := client.timeoutClasses.Load()
+		timeoutClasses := make(map[string]time.Duration)
+		if loaded != nil {
+			for name, timeout := range *loaded {
+				timeoutClasses[name] = timeout
+			}
+		}
+		for name, timeout := range updates {
+			timeoutClasses[name] = timeout
+		}
+		if client.timeoutClasses.CompareAndSwap(loaded, &timeoutClasses) {
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+)
+
+func TestTimeoutClasses(t *testing.T) {
+	client := newRuntimeConfigTestClient(nil)
+	client.updateTimeoutClasses(config.NewClientConfiguration().
+		WithTimeoutClass("fast", 20*time.Millisecond).
+		WithTimeoutClass("bulk", 5*time.Second).
+		GetTimeoutClasses())
+	client.requestTimeout.Store(int64(time.Hour))
+
+	// The timeout of the class of the context takes precedence over the request timeout of the client and its views.
+	view := client.newView(NewViewOptions().WithTimeout(time.Minute))
+	ctx, cancel, err := view.withRequestTimeout(WithTimeoutClass(context.Background(), "fast"), false)
+	require.NoError(t, err)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(20*time.Millisecond), deadline, 10*time.Millisecond)
+	<-ctx.Done()
+	assert.ErrorIs(t, contextErr(ctx), ErrTimeout)
+
+	// The classes are updated at runtime, and the others are kept.
+	require.NoError(t, client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().
+		WithTimeoutClass("bulk", 10*time.Second).
+		WithTimeoutClass("report", time.Minute)))
+	for name, expected := range map[string]time.Duration{
+		"fast":   20 * time.Millisecond,
+		"bulk":   10 * time.Second,
+		"report": time.Minute,
+	} {
+		timeout, err := client.classTimeout(WithTimeoutClass(context.Background(), name))
+		require.NoError(t, err)
+		assert.Equal(t, expected, timeout, name)
+	}
+
+	_, _, err = client.withRequestTimeout(WithTimeoutClass(context.Background(), "slow"), false)
+	var configurationErr *ConfigurationError
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.EqualError(t, err, `the timeout class "slow" isn't defined`)
+
+	// The classes updated at runtime can't exceed the timeout of the core.
+	client.coreTimeout = 5 * time.Second
+	err = client.UpdateRuntimeConfiguration(config.NewRuntimeConfiguration().WithTimeoutClass("report", time.Hour))
+	assert.ErrorAs(t, err, &configurationErr)
+	assert.ErrorContains(t, err, `the timeout of the timeout class "report", 1h0m0s, exceeds`)
+
+	name, ok := TimeoutClassFromContext(WithTimeoutClass(context.Background(), "fast"))
+	assert.True(t, ok)
+	assert.Equal(t, "fast", name)
+	_, ok = TimeoutClassFromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestTimeoutClasses_DefaultTimeout(t *testing.T) {
+	// The core waits for the longest class, so the client applies its own timeout to the requests without a class.
+	client := newRuntimeConfigTestClient(nil)
+	client.defaultTimeout = 250 * time.Millisecond
+	ctx, cancel, err := client.withRequestTimeout(context.Background(), false)
+	require.NoError(t, err)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(250*time.Millisecond), deadline, 10*time.Millisecond)
+
+	// The request timeout set at runtime takes precedence.
+	client.requestTimeout.Store(int64(time.Minute))
+	ctx, cancel, err = client.withRequestTimeout(context.Background(), false)
+	require.NoError(t, err)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 10*time.Millisecond)
+}
+
+func TestTimeoutClasses_BlockingCommands(t *testing.T) {
+	// The core waits for the blocking commands until their own timeout elapses, so neither the default timeout nor
+	// the class of the context bounds them.
+	client := newRuntimeConfigTestClient(nil)
+	client.updateTimeoutClasses(config.NewClientConfiguration().
+		WithTimeoutClass("fast", 20*time.Millisecond).
+		GetTimeoutClasses())
+	client.defaultTimeout = 250 * time.Millisecond
+	for _, ctx := range []context.Context{context.Background(), WithTimeoutClass(context.Background(), "fast")} {
+		ctx, cancel, err := client.withRequestTimeout(ctx, true)
+		require.NoError(t, err)
+		defer cancel()
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+	}
+
+	assert.True(t, isBlockingCommand("BLPOP", []string{"list", "5"}))
+	assert.True(t, isBlockingCommand("BZMPOP", []string{"5", "1", "zset", "MIN"}))
+	assert.True(t, isBlockingCommand("WAIT", []string{"1", "1000"}))
+	assert.True(t, isBlockingCommand("WAITAOF", []string{"1", "1", "1000"}))
+	assert.True(t, isBlockingCommand("MIGRATE", []string{"host", "6379", "", "0", "5000", "KEYS", "key"}))
+	assert.True(t, isBlockingCommand("XREAD", []string{"BLOCK", "1000", "STREAMS", "stream", "$"}))
+	assert.True(t, isBlockingCommand("XREADGROUP", []string{"GROUP", "group", "consumer", "block", "0", "STREAMS", "s", ">"}))
+	assert.False(t, isBlockingCommand("XREAD", []string{"STREAMS", "stream", "0"}))
+	assert.False(t, isBlockingCommand("GET", []string{"key"}))
+	// SetDurable sends WAIT, which must wait for its own timeout.
+	assert.True(t, isBlockingCommand(commandNameArgs(uint32(protobuf.RequestType_Wait), []string{"1", "1000"})))
+}
@@ -0,0 +1,163 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+// #include "lib.h"
+import "C"
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// contextTrace returns the trace of the requests of ctx, see [options.WithTrace], or false if they aren't traced.
+func contextTrace(ctx context.Context) (*options.Trace, bool) {
+	return options.TraceFromContext(ctx)
+}
+
+// redactedArg replaces the passwords in the arguments of the traced commands.
+const redactedArg = "<redacted>"
+
+// tracedCommand returns the command of requestType with args, with its passwords redacted.
+func tracedCommand(requestType uint32, args []string) models.TracedCommand {
+	command := capturedCommand(requestType, args, nil)
+	return models.TracedCommand{Name: command.Name, Args: redactPasswords(command.Name, command.Args)}
+}
+
+// redactPasswords replaces the passwords in args, the arguments of command, with redactedArg, and returns args.
+func redactPasswords(command string, args []string) []string {
+	redact := func(i int) {
+		if i < len(args) {
+			args[i] = redactedArg
+		}
+	}
+	switch command {
+	case "AUTH":
+		// AUTH [username] password
+		redact(len(args) - 1)
+	case "HELLO":
+		// HELLO [protover [AUTH username password] [SETNAME clientname]]
+		for i := 1; i < len(args); i++ {
+			if strings.EqualFold(args[i], "AUTH") {
+				redact(i + 2)
+				i += 2
+			}
+		}
+	case "MIGRATE":
+		// MIGRATE host port key|"" destination-db timeout [COPY] [REPLACE] [AUTH password | AUTH2 username password]
+		// [KEYS key [key ...]]
+		for i := 5; i < len(args) && !strings.EqualFold(args[i], "KEYS"); i++ {
+			switch strings.ToUpper(args[i]) {
+			case "AUTH":
+				redact(i + 1)
+				i++
+			case "AUTH2":
+				redact(i + 2)
+				i += 2
+			}
+		}
+	case "CONFIG", "CONFIG SET":
+		parameters := args
+		if command == "CONFIG" {
+			if len(args) == 0 || !strings.EqualFold(args[0], "SET") {
+				return args
+			}
+			parameters = args[1:]
+		}
+		// CONFIG SET parameter value [parameter value ...]
+		for i := 0; i+1 < len(parameters); i += 2 {
+			if strings.EqualFold(parameters[i], "requirepass") || strings.EqualFold(parameters[i], "masterauth") {
+				parameters[i+1] = redactedArg
+			}
+		}
+	}
+	return args
+}
+
+// traceCommand reports the command of requestType to trace, with its response, which isn't freed.
+func traceCommand(
+	trace *options.Trace,
+	requestType C.RequestType,
+	args []string,
+	route config.Route,
+	start time.Time,
+	response *C.struct_CommandResponse,
+	err error,
+) {
+	requestTrace := models.RequestTrace{
+		Commands: []models.TracedCommand{tracedCommand(uint32(requestType), args)},
+		Node:     routeNode(route),
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if err == nil {
+		requestTrace.Response, requestTrace.Err = parseValue(response, true)
+	}
+	trace.Record(requestTrace)
+}
+
+// traceScript reports the EVALSHA of the script of hash to trace, with its response, which isn't freed.
+func traceScript(
+	trace *options.Trace,
+	hash string,
+	keys []string,
+	args []string,
+	route config.Route,
+	start time.Time,
+	response *C.struct_CommandResponse,
+	err error,
+) {
+	requestTrace := models.RequestTrace{
+		Commands: []models.TracedCommand{{
+			Name: "EVALSHA",
+			Args: append(append([]string{hash, strconv.Itoa(len(keys))}, keys...), args...),
+		}},
+		Node:     routeNode(route),
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if err == nil {
+		requestTrace.Response, requestTrace.Err = parseValue(response, true)
+	}
+	trace.Record(requestTrace)
+}
+
+// traceBatch reports batch to trace, with the responses of its commands.
+func traceBatch(
+	trace *options.Trace,
+	batch internal.Batch,
+	batchOptions *internal.BatchOptions,
+	start time.Time,
+	responses []any,
+	err error,
+) {
+	var route config.Route
+	if batchOptions != nil {
+		route = batchOptions.Route
+	}
+	requestTrace := models.RequestTrace{
+		Commands: make([]models.TracedCommand, len(batch.Commands)),
+		Batch:    true,
+		Atomic:   batch.IsAtomic,
+		Node:     routeNode(route),
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	for i, command := range batch.Commands {
+		requestTrace.Commands[i] = tracedCommand(command.RequestType, command.Args)
+	}
+	if err == nil {
+		requestTrace.Response = responses
+	}
+	trace.Record(requestTrace)
+}
@@ -0,0 +1,160 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/config"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+func TestWithTrace(t *testing.T) {
+	client := &Client{baseClient{}}
+	var traces []models.RequestTrace
+	ctx, cancel := context.WithCancel(
+		options.WithTraceHandler(context.Background(), func(trace models.RequestTrace) { traces = append(traces, trace) }),
+	)
+	cancel()
+
+	_, err := client.Get(ctx, "session:1")
+	assert.ErrorIs(t, err, context.Canceled)
+	sessions := client.With(NewViewOptions().WithKeyPrefix("session:"))
+	_, err = sessions.CustomCommand(ctx, []string{"type", "2"})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	require.Len(t, traces, 2)
+	assert.Equal(t, []models.TracedCommand{{Name: "GET", Args: []string{"session:1"}}}, traces[0].Commands)
+	assert.Equal(t, 1, traces[0].Sequence)
+	assert.ErrorIs(t, traces[0].Err, context.Canceled)
+	assert.False(t, traces[0].Start.IsZero())
+	assert.Equal(t, []models.TracedCommand{{Name: "TYPE", Args: []string{"session:2"}}}, traces[1].Commands)
+	assert.Equal(t, 2, traces[1].Sequence)
+
+	// The requests of other contexts aren't traced.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = client.Get(cancelled, "session:1")
+	assert.Len(t, traces, 2)
+}
+
+func TestTraceBatch(t *testing.T) {
+	trace := make(chan models.RequestTrace, 1)
+	ctx := options.WithTraceHandler(context.Background(), func(requestTrace models.RequestTrace) { trace <- requestTrace })
+	requestTrace, ok := contextTrace(ctx)
+	require.True(t, ok)
+
+	batch := internal.Batch{IsAtomic: true, Commands: []internal.Cmd{
+		{RequestType: uint32(protobuf.RequestType_Set), Args: []string{"key", "value"}},
+		{RequestType: uint32(protobuf.RequestType_Get), Args: []string{"key"}},
+	}}
+	route := config.NewByAddressRoute("10.0.0.2", 6379)
+	traceBatch(requestTrace, batch, &internal.BatchOptions{Route: route}, time.Now(), []any{"OK", "value"}, nil)
+
+	assert.Equal(t, models.RequestTrace{
+		Commands: []models.TracedCommand{
+			{Name: "SET", Args: []string{"key", "value"}},
+			{Name: "GET", Args: []string{"key"}},
+		},
+		Batch:    true,
+		Atomic:   true,
+		Node:     "10.0.0.2:6379",
+		Sequence: 1,
+		Response: []any{"OK", "value"},
+	}, withoutTimings(<-trace))
+}
+
+func TestLogRequestTrace(t *testing.T) {
+	var output bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&output)
+
+	options.LogRequestTrace(models.RequestTrace{
+		Commands: []models.TracedCommand{{Name: "SET", Args: []string{"key", "a value"}}, {Name: "GET", Args: []string{"key"}}},
+		Batch:    true,
+		Sequence: 2,
+		Duration: 3 * time.Millisecond,
+		Response: []any{"OK", "a value"},
+	})
+
+	assert.Contains(t, output.String(), `glide trace: request 2: SET "key" "a value" on the routed node took 3ms: "OK"`)
+	assert.Contains(t, output.String(), `glide trace: request 2: GET "key" on the routed node took 3ms: "a value"`)
+}
+
+func TestLogRequestTrace_Truncated(t *testing.T) {
+	var output bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&output)
+
+	value := strings.Repeat("v", 1000)
+	options.LogRequestTrace(models.RequestTrace{
+		Commands: []models.TracedCommand{{Name: "SET", Args: []string{"key", value}}},
+		Sequence: 1,
+		Response: value,
+	})
+
+	quoted := strconv.Quote(value)
+	truncated := quoted[:options.MaxLoggedTraceLength] + "... (1002 bytes)"
+	assert.Contains(t, output.String(), `SET "key" `+truncated+` on the routed node took 0s: `+truncated)
+	assert.NotContains(t, output.String(), quoted)
+}
+
+func TestTracedCommand_RedactsPasswords(t *testing.T) {
+	custom := uint32(protobuf.RequestType_CustomCommand)
+	tests := []struct {
+		args     []string
+		expected models.TracedCommand
+	}{
+		{[]string{"AUTH", "secret"}, models.TracedCommand{Name: "AUTH", Args: []string{redactedArg}}},
+		{[]string{"auth", "user", "secret"}, models.TracedCommand{Name: "AUTH", Args: []string{"user", redactedArg}}},
+		{
+			[]string{"HELLO", "3", "AUTH", "user", "secret", "SETNAME", "app"},
+			models.TracedCommand{Name: "HELLO", Args: []string{"3", "AUTH", "user", redactedArg, "SETNAME", "app"}},
+		},
+		{
+			[]string{"MIGRATE", "host", "6379", "", "0", "5000", "AUTH", "secret", "KEYS", "AUTH", "key"},
+			models.TracedCommand{
+				Name: "MIGRATE",
+				Args: []string{"host", "6379", "", "0", "5000", "AUTH", redactedArg, "KEYS", "AUTH", "key"},
+			},
+		},
+		{
+			[]string{"MIGRATE", "host", "6379", "key", "0", "5000", "REPLACE", "AUTH2", "user", "secret"},
+			models.TracedCommand{
+				Name: "MIGRATE",
+				Args: []string{"host", "6379", "key", "0", "5000", "REPLACE", "AUTH2", "user", redactedArg},
+			},
+		},
+		{
+			[]string{"CONFIG", "SET", "maxmemory", "100mb", "requirepass", "secret"},
+			models.TracedCommand{Name: "CONFIG", Args: []string{"SET", "maxmemory", "100mb", "requirepass", redactedArg}},
+		},
+		{[]string{"GET", "AUTH"}, models.TracedCommand{Name: "GET", Args: []string{"AUTH"}}},
+	}
+	for _, test := range tests {
+		args := append([]string(nil), test.args...)
+		assert.Equal(t, test.expected, tracedCommand(custom, args), test.args[0])
+		assert.Equal(t, test.args, args, "the arguments of the command aren't modified")
+	}
+
+	configSet := tracedCommand(uint32(protobuf.RequestType_ConfigSet), []string{"masterauth", "secret"})
+	assert.Equal(t, models.TracedCommand{Name: "CONFIG SET", Args: []string{"masterauth", redactedArg}}, configSet)
+}
+
+func withoutTimings(trace models.RequestTrace) models.RequestTrace {
+	trace.Start = time.Time{}
+	trace.Duration = 0
+	return trace
+}
@@ -0,0 +1,239 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// DefaultTTLAuditSampleSize is the maximum number of keys sampled per pattern by a [TTLAuditor], unless configured
+// otherwise.
+const DefaultTTLAuditSampleSize = 1000
+
+// DefaultTTLAuditBuckets are the upper bounds of the buckets of the TTL histograms of a [TTLAuditor], unless
+// configured otherwise.
+var DefaultTTLAuditBuckets = []time.Duration{
+	time.Minute,
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// errTTLSampleComplete stops the scan of a pattern once enough keys are sampled.
+var errTTLSampleComplete = errors.New("the sample is complete")
+
+// ttlAuditClient is implemented by [Client] and [ClusterClient].
+type ttlAuditClient interface {
+	scanKeys(ctx context.Context, match string, count int64, visit func(keys []string) error) error
+	keyTTLs(ctx context.Context, keys []string) ([]keyUsage, error)
+}
+
+// TTLAuditor reports histograms of the TTLs of the keys matching patterns, and how many of them don't expire, e.g. to
+// catch the namespaces of a cache silently accumulating keys without an expiration.
+//
+// The keys of each pattern are scanned with SCAN MATCH, of all the primaries of a cluster, and the TTLs of the first
+// keys scanned, up to the sample size, are read with PTTL, pipelined per page of the scan. SCAN returns the keys in
+// the order of their hashes, so that the first keys are a fair sample of all the keys.
+//
+// Example usage:
+//
+//	histograms, err := glide.NewTTLAuditor(client).Audit(ctx, "session:*", "cache:*")
+//	for _, histogram := range histograms {
+//		if histogram.PersistentRatio() > 0.01 {
+//			log.Printf("%d keys of %s don't expire", histogram.Persistent, histogram.Pattern)
+//		}
+//	}
+type TTLAuditor struct {
+	client     ttlAuditClient
+	sampleSize int64
+	scanCount  int64
+	buckets    []time.Duration
+}
+
+// NewTTLAuditor returns a [TTLAuditor] for the keys of client, a [Client] or a [ClusterClient].
+func NewTTLAuditor(client ttlAuditClient) *TTLAuditor {
+	return &TTLAuditor{
+		client:     client,
+		sampleSize: DefaultTTLAuditSampleSize,
+		scanCount:  DefaultNamespaceScanCount,
+		buckets:    DefaultTTLAuditBuckets,
+	}
+}
+
+// WithSampleSize sets the maximum number of keys sampled per pattern. Defaults to DefaultTTLAuditSampleSize.
+func (auditor *TTLAuditor) WithSampleSize(sampleSize int64) *TTLAuditor {
+	auditor.sampleSize = sampleSize
+	return auditor
+}
+
+// WithScanCount sets the COUNT of the scans, the number of keys read per page. Defaults to DefaultNamespaceScanCount.
+func (auditor *TTLAuditor) WithScanCount(count int64) *TTLAuditor {
+	auditor.scanCount = count
+	return auditor
+}
+
+// WithBuckets sets the upper bounds of the buckets of the histograms, in increasing order. Defaults to
+// DefaultTTLAuditBuckets.
+func (auditor *TTLAuditor) WithBuckets(upperBounds ...time.Duration) *TTLAuditor {
+	auditor.buckets = upperBounds
+	return auditor
+}
+
+// Audit samples the keys matching each of patterns, and reports the histograms of their TTLs.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the audit.
+//	patterns - The glob-style patterns of the keys to audit, e.g. "session:*".
+//
+// Return value:
+//
+//	The histograms of the TTLs of the keys of each pattern, in the order of patterns, or an error if a scan or a
+//	sample failed.
+func (auditor *TTLAuditor) Audit(ctx context.Context, patterns ...string) ([]models.TTLHistogram, error) {
+	if err := auditor.validate(patterns); err != nil {
+		return nil, err
+	}
+	histograms := make([]models.TTLHistogram, 0, len(patterns))
+	for _, pattern := range patterns {
+		histogram, err := auditor.audit(ctx, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to audit the TTLs of %q: %w", pattern, err)
+		}
+		histograms = append(histograms, histogram)
+	}
+	return histograms, nil
+}
+
+func (auditor *TTLAuditor) validate(patterns []string) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("at least one pattern must be audited")
+	}
+	if auditor.sampleSize <= 0 {
+		return fmt.Errorf("the sample size must be positive, got %d", auditor.sampleSize)
+	}
+	if auditor.scanCount <= 0 {
+		return fmt.Errorf("the scan count must be positive, got %d", auditor.scanCount)
+	}
+	if len(auditor.buckets) == 0 {
+		return fmt.Errorf("at least one bucket must be set")
+	}
+	if !sort.SliceIsSorted(auditor.buckets, func(i, j int) bool { return auditor.buckets[i] <= auditor.buckets[j] }) ||
+		auditor.buckets[0] <= 0 {
+		return fmt.Errorf("the upper bounds of the buckets must be positive and increasing, got %v", auditor.buckets)
+	}
+	return nil
+}
+
+// audit samples the keys matching pattern.
+func (auditor *TTLAuditor) audit(ctx context.Context, pattern string) (models.TTLHistogram, error) {
+	histogram := models.TTLHistogram{Pattern: pattern, Buckets: make([]models.TTLBucket, len(auditor.buckets))}
+	for i, upperBound := range auditor.buckets {
+		histogram.Buckets[i].UpperBound = upperBound
+	}
+	err := auditor.client.scanKeys(ctx, pattern, auditor.scanCount, func(keys []string) error {
+		if remaining := auditor.sampleSize - histogram.SampledKeys; int64(len(keys)) > remaining {
+			keys = keys[:remaining]
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		usages, err := auditor.client.keyTTLs(ctx, keys)
+		if err != nil {
+			return err
+		}
+		for _, usage := range usages {
+			if usage.exists {
+				auditor.record(&histogram, usage.ttl)
+			}
+		}
+		if histogram.SampledKeys >= auditor.sampleSize {
+			return errTTLSampleComplete
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+		histogram.Complete = true
+	case !errors.Is(err, errTTLSampleComplete):
+		return models.TTLHistogram{}, err
+	}
+	return histogram, nil
+}
+
+// record records the TTL of a sampled key, or -1 if it doesn't expire.
+func (auditor *TTLAuditor) record(histogram *models.TTLHistogram, ttl time.Duration) {
+	histogram.SampledKeys++
+	if ttl < 0 {
+		histogram.Persistent++
+		return
+	}
+	for i := range histogram.Buckets {
+		if ttl < histogram.Buckets[i].UpperBound {
+			histogram.Buckets[i].Keys++
+			return
+		}
+	}
+	histogram.Longer++
+}
+
+// keyTTLs returns the TTLs of keys, read in a single non-atomic pipeline of PTTL. The memory usage of the keys isn't
+// read.
+func (client *baseClient) keyTTLs(ctx context.Context, keys []string) ([]keyUsage, error) {
+	identity := func(response any) (any, error) { return response, nil }
+	batch := internal.Batch{Commands: make([]internal.Cmd, 0, len(keys))}
+	for _, key := range keys {
+		batch.Commands = append(batch.Commands,
+			internal.MakeCmd(uint32(protobuf.RequestType_PTTL), []string{key}, identity))
+	}
+	responses, err := client.executeBatch(ctx, batch, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyTTLs(keys, responses)
+}
+
+// parseKeyTTLs returns the TTLs of keys from the responses of the pipeline of keyTTLs.
+func parseKeyTTLs(keys []string, responses []any) ([]keyUsage, error) {
+	if len(responses) != len(keys) {
+		return nil, fmt.Errorf("unexpected number of responses to sample %d keys: %d", len(keys), len(responses))
+	}
+	usages := make([]keyUsage, len(keys))
+	for i, key := range keys {
+		usage, err := parseKeyTTL(key, responses[i])
+		if err != nil {
+			return nil, err
+		}
+		usages[i] = usage
+	}
+	return usages, nil
+}
+
+// parseKeyTTL returns the usage of key from response, the response of PTTL, with an unknown memory usage.
+func parseKeyTTL(key string, response any) (keyUsage, error) {
+	ttl, ok := response.(int64)
+	if !ok {
+		if err, isErr := response.(error); isErr {
+			return keyUsage{}, fmt.Errorf("failed to sample key %q, PTTL failed: %w", key, err)
+		}
+		return keyUsage{}, fmt.Errorf("unexpected type of the response of PTTL: %T", response)
+	}
+	// -2 is the TTL of a key which no longer exists.
+	if ttl == -2 {
+		return keyUsage{}, nil
+	}
+	usage := keyUsage{exists: true, memory: -1, ttl: time.Duration(ttl) * time.Millisecond}
+	if ttl < 0 {
+		usage.ttl = -1
+	}
+	return usage, nil
+}
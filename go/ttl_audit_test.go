@@ -0,0 +1,113 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+type fakeTTLAuditClient struct {
+	pages   map[string][][]string
+	ttls    map[string]keyUsage
+	sampled []string
+}
+
+func (client *fakeTTLAuditClient) scanKeys(
+	_ context.Context,
+	match string,
+	_ int64,
+	visit func(keys []string) error,
+) error {
+	for _, page := range client.pages[match] {
+		if err := visit(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (client *fakeTTLAuditClient) keyTTLs(_ context.Context, keys []string) ([]keyUsage, error) {
+	client.sampled = append(client.sampled, keys...)
+	usages := make([]keyUsage, len(keys))
+	for i, key := range keys {
+		usages[i] = client.ttls[key]
+	}
+	return usages, nil
+}
+
+func TestTTLAuditor_Audit(t *testing.T) {
+	client := &fakeTTLAuditClient{
+		pages: map[string][][]string{
+			"session:*": {{"session:1", "session:2"}, {"session:3", "session:4"}, {"session:5"}},
+			"cache:*":   {{"cache:1", "cache:2", "cache:3"}},
+		},
+		ttls: map[string]keyUsage{
+			"session:1": {exists: true, ttl: -1},
+			"session:2": {exists: true, ttl: 30 * time.Second},
+			"session:3": {exists: true, ttl: 2 * time.Hour},
+			"cache:1":   {exists: true, ttl: time.Minute},
+			"cache:3":   {exists: true, ttl: 5 * time.Hour},
+		},
+	}
+	histograms, err := NewTTLAuditor(client).
+		WithSampleSize(3).
+		WithBuckets(time.Minute, time.Hour).
+		Audit(context.Background(), "session:*", "cache:*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"session:1", "session:2", "session:3", "cache:1", "cache:2", "cache:3"}, client.sampled)
+	assert.Equal(t, []models.TTLHistogram{
+		{
+			Pattern:     "session:*",
+			SampledKeys: 3,
+			Persistent:  1,
+			Buckets:     []models.TTLBucket{{UpperBound: time.Minute, Keys: 1}, {UpperBound: time.Hour}},
+			Longer:      1,
+		},
+		{
+			Pattern:     "cache:*",
+			SampledKeys: 2,
+			Buckets:     []models.TTLBucket{{UpperBound: time.Minute}, {UpperBound: time.Hour, Keys: 1}},
+			Longer:      1,
+			Complete:    true,
+		},
+	}, histograms)
+	assert.InDelta(t, 1.0/3, histograms[0].PersistentRatio(), 1e-9)
+	assert.Zero(t, histograms[1].PersistentRatio())
+}
+
+func TestTTLAuditor_Validate(t *testing.T) {
+	client := &fakeTTLAuditClient{}
+	_, err := NewTTLAuditor(client).Audit(context.Background())
+	assert.Error(t, err)
+	for _, auditor := range []*TTLAuditor{
+		NewTTLAuditor(client).WithSampleSize(0),
+		NewTTLAuditor(client).WithScanCount(0),
+		NewTTLAuditor(client).WithBuckets(),
+		NewTTLAuditor(client).WithBuckets(time.Hour, time.Minute),
+		NewTTLAuditor(client).WithBuckets(0, time.Minute),
+	} {
+		_, err := auditor.Audit(context.Background(), "*")
+		assert.Error(t, err)
+	}
+}
+
+func TestParseKeyTTLs(t *testing.T) {
+	usages, err := parseKeyTTLs([]string{"a", "b", "c"}, []any{int64(-1), int64(1500), int64(-2)})
+	assert.NoError(t, err)
+	assert.Equal(t, []keyUsage{
+		{exists: true, memory: -1, ttl: -1},
+		{exists: true, memory: -1, ttl: 1500 * time.Millisecond},
+		{},
+	}, usages)
+
+	_, err = parseKeyTTLs([]string{"a"}, []any{errors.New("NOPERM")})
+	assert.Error(t, err)
+	_, err = parseKeyTTLs([]string{"a"}, []any{})
+	assert.Error(t, err)
+}
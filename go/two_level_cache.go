@@ -0,0 +1,657 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/utils"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+const (
+	// DefaultTwoLevelCacheMaxEntries is the number of values of the local layer of a [TwoLevelCache], unless
+	// configured otherwise.
+	DefaultTwoLevelCacheMaxEntries = 10000
+	// DefaultTwoLevelCacheLocalTTL is how long the local layer of a [TwoLevelCache] serves a value at most, unless
+	// configured otherwise.
+	DefaultTwoLevelCacheLocalTTL = time.Minute
+	// twoLevelCacheSubscribeTimeoutMs bounds the wait for the confirmation of the subscriptions of the invalidation
+	// feed.
+	twoLevelCacheSubscribeTimeoutMs = 5000
+)
+
+// ErrInvalidationQueued matches the errors of the deletes of a [TwoLevelCache] in degraded mode which failed as Valkey
+// was unreachable, and were queued to be replayed, see [TwoLevelCache.WithDegradedMode].
+var ErrInvalidationQueued = errors.New("invalidation queued for replay")
+
+// keyspaceSubscriber is the client receiving the invalidation feed of a [TwoLevelCache], e.g. a [Client].
+type keyspaceSubscriber interface {
+	PSubscribe(ctx context.Context, patterns []string, timeoutMs int) error
+	PUnsubscribe(ctx context.Context, patterns []string, timeoutMs int) error
+	GetQueue() (*PubSubMessageQueue, error)
+	ConfigGet(ctx context.Context, parameters []string) (map[string]string, error)
+}
+
+// TwoLevelCacheStats reports the lookups of a [TwoLevelCache] by the layer serving them.
+type TwoLevelCacheStats struct {
+	// LocalHits is the number of values served by the local layer.
+	LocalHits uint64
+	// RemoteHits is the number of values served by Valkey.
+	RemoteHits uint64
+	// Misses is the number of keys found in neither layer.
+	Misses uint64
+	// Invalidations is the number of values of the local layer dropped by the invalidation feed or by the writes of
+	// the cache.
+	Invalidations uint64
+	// Evictions is the number of least recently used values of the local layer evicted for newer ones.
+	Evictions uint64
+	// StaleHits is the number of values served by the local layer past their local TTL, as Valkey was unreachable,
+	// in degraded mode.
+	StaleHits uint64
+	// Entries is the number of values of the local layer.
+	Entries int
+	// QueuedInvalidations is the number of keys whose deletes, which failed as Valkey was unreachable, are queued to
+	// be replayed, in degraded mode.
+	QueuedInvalidations int
+}
+
+// TwoLevelCacheValue is a value read by [TwoLevelCache.Lookup].
+type TwoLevelCacheValue struct {
+	// Value is the value of the key, or a nil result if it doesn't exist.
+	Value models.Result[string]
+	// Stale is set if Value is a local value past its local TTL, served as Valkey is unreachable, in degraded mode.
+	Stale bool
+}
+
+// twoLevelEntry is a value of the local layer of a [TwoLevelCache].
+type twoLevelEntry struct {
+	key     string
+	value   string
+	expires time.Time
+}
+
+// twoLevelLoad is a read of Valkey, whose value is stored in the local layer if the key isn't invalidated meanwhile.
+type twoLevelLoad struct{}
+
+// TwoLevelCache layers an in-process LRU cache of string values over Valkey: the values read are kept locally, and
+// served from memory until they're invalidated, evicted or older than the local TTL.
+//
+// The local values are invalidated by a feed of keyspace notifications, published by the server for the writes of
+// every client, see Listen: the local layer is only used once the cache listens to the feed, the reads are served by
+// Valkey until then. Client-side tracking can't feed the cache, since its invalidation messages pushed on the RESP3
+// connections aren't delivered to the clients. The server must publish the keyspace notifications of the generic,
+// string, expired and evicted events, e.g. with `notify-keyspace-events Kg$xe`.
+//
+// The reads in progress when their key is invalidated don't store their value locally, so that a value read before a
+// write is never served after its invalidation. The notifications missed while the subscriber reconnects, or the
+// databases flushed, which don't publish notifications, aren't invalidated: the local TTL bounds how long such values
+// are served. The local values never outlive the TTL of their keys.
+//
+// Keyspace notifications are published by each node to its own subscribers, so the cache is meant for standalone
+// servers, the notifications of the other nodes of a cluster wouldn't reach the subscriber.
+//
+// In degraded mode, see WithDegradedMode, the reads survive short outages of Valkey: the local values past their local
+// TTL are served, flagged as stale, while Valkey is unreachable, and the deletes are queued to be replayed once it's
+// reachable again.
+//
+// Example usage:
+//
+//	cache := glide.NewTwoLevelCache(client).WithMaxEntries(50000)
+//	if err := cache.Listen(ctx, subscriber, "user:*"); err != nil {
+//		return err
+//	}
+//	defer cache.Close()
+//
+//	user, err := cache.Get(ctx, "user:1")
+type TwoLevelCache struct {
+	remote     interfaces.BaseClientCommands
+	maxEntries int
+	localTTL   time.Duration
+	// maxStale is how long the local values are served past their local TTL while Valkey is unreachable, 0 outside of
+	// degraded mode.
+	maxStale time.Duration
+	now      func() time.Time
+	// replayMu is held by the replays of the queued invalidations, and read-locked by the writes, so that a replay
+	// never deletes a key written meanwhile.
+	replayMu sync.RWMutex
+
+	mu sync.Mutex
+	// entries are the elements of lru by key, the most recently used first.
+	entries map[string]*list.Element
+	lru     *list.List
+	loads   map[string]*twoLevelLoad
+	// listening is set once Listen is called, and subscriber once the feed is subscribed to.
+	listening  bool
+	subscriber keyspaceSubscriber
+	channels   []string
+	keys       []string
+	done       chan struct{}
+	stopped    chan struct{}
+	closed     bool
+	// queued are the keys whose deletes are queued to be replayed.
+	queued map[string]struct{}
+
+	localHits     atomic.Uint64
+	remoteHits    atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+	evictions     atomic.Uint64
+	staleHits     atomic.Uint64
+	queuedCount   atomic.Int64
+}
+
+// NewTwoLevelCache returns a [TwoLevelCache] over the values of remote.
+func NewTwoLevelCache(remote interfaces.BaseClientCommands) *TwoLevelCache {
+	return &TwoLevelCache{
+		remote:     remote,
+		maxEntries: DefaultTwoLevelCacheMaxEntries,
+		localTTL:   DefaultTwoLevelCacheLocalTTL,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		loads:      make(map[string]*twoLevelLoad),
+		queued:     make(map[string]struct{}),
+	}
+}
+
+// WithMaxEntries sets the number of values of the local layer, the least recently used ones being evicted. Defaults to
+// DefaultTwoLevelCacheMaxEntries.
+func (cache *TwoLevelCache) WithMaxEntries(maxEntries int) *TwoLevelCache {
+	cache.maxEntries = maxEntries
+	return cache
+}
+
+// WithLocalTTL sets how long the local layer serves a value at most, which bounds how long the values whose
+// invalidation is missed are served. Defaults to DefaultTwoLevelCacheLocalTTL.
+func (cache *TwoLevelCache) WithLocalTTL(ttl time.Duration) *TwoLevelCache {
+	cache.localTTL = ttl
+	return cache
+}
+
+// WithDegradedMode enables the degraded mode of the cache, for the reads to survive short outages of Valkey, up to
+// maxStale, e.g. the outage the service level of the application tolerates:
+//
+//   - The local values are retained up to maxStale past their local TTL. While Valkey is unreachable, i.e. its reads
+//     fail with a [TimeoutError], a [DisconnectError] or a [ConnectionError], such values are served rather than the
+//     error, flagged as stale by Lookup. The values served may be outdated, or their keys expired, since the
+//     invalidation feed is down as well while Valkey is unreachable.
+//   - The deletes failing as Valkey is unreachable are queued: they return an error matching
+//     [ErrInvalidationQueued], and are replayed by the next operation of the cache, before it runs. The keys queued
+//     aren't served from either layer until their deletes are replayed, nor replayed once they're set by the cache
+//     meanwhile. The deletes still queued when the cache is closed are dropped.
+//
+// The degraded mode is disabled by default, the errors of Valkey being returned as is.
+func (cache *TwoLevelCache) WithDegradedMode(maxStale time.Duration) *TwoLevelCache {
+	cache.maxStale = maxStale
+	return cache
+}
+
+// Listen subscribes subscriber to the keyspace notifications of the keys matching keyPatterns, all the keys if none is
+// given, and starts serving these keys from the local layer. The keys not matching keyPatterns are always read from
+// Valkey.
+//
+// subscriber is a [Client] dedicated to the feed: the cache consumes all the messages of its queue, so it must not be
+// configured with a callback, nor be subscribed to other channels. The client of the cache itself can be the
+// subscriber if its subscriptions are isolated, see config.ClientConfiguration.WithSubscriberIsolation. Listen fails
+// if the server is found not to publish the keyspace notifications the cache relies on.
+func (cache *TwoLevelCache) Listen(ctx context.Context, subscriber keyspaceSubscriber, keyPatterns ...string) error {
+	if cache.maxEntries <= 0 {
+		return errors.New("the maximum number of entries of a two-level cache must be positive")
+	}
+	if cache.localTTL <= 0 {
+		return errors.New("the local TTL of a two-level cache must be positive")
+	}
+	if cache.maxStale < 0 {
+		return errors.New("the maximum staleness of a two-level cache must not be negative")
+	}
+	if len(keyPatterns) == 0 {
+		keyPatterns = []string{"*"}
+	}
+	if err := checkKeyspaceNotifications(ctx, subscriber); err != nil {
+		return err
+	}
+	queue, err := subscriber.GetQueue()
+	if err != nil {
+		return err
+	}
+	channels := make([]string, len(keyPatterns))
+	for i, pattern := range keyPatterns {
+		channels[i] = keyspaceChannelPrefix + "*__:" + pattern
+	}
+
+	cache.mu.Lock()
+	if cache.closed {
+		cache.mu.Unlock()
+		return NewClosingError("the two-level cache is closed")
+	}
+	if cache.listening {
+		cache.mu.Unlock()
+		return errors.New("the two-level cache listens to its invalidation feed already")
+	}
+	cache.listening = true
+	cache.mu.Unlock()
+	if err := subscriber.PSubscribe(ctx, channels, twoLevelCacheSubscribeTimeoutMs); err != nil {
+		cache.mu.Lock()
+		cache.listening = false
+		cache.mu.Unlock()
+		return err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.closed {
+		_ = subscriber.PUnsubscribe(context.WithoutCancel(ctx), channels, twoLevelCacheSubscribeTimeoutMs)
+		return NewClosingError("the two-level cache is closed")
+	}
+	cache.subscriber = subscriber
+	cache.channels = channels
+	cache.keys = append([]string(nil), keyPatterns...)
+	cache.done = make(chan struct{})
+	cache.stopped = make(chan struct{})
+	go cache.listen(queue, cache.done, cache.stopped)
+	return nil
+}
+
+// checkKeyspaceNotifications returns an error if the server doesn't publish the keyspace notifications of the events
+// invalidating string values. The check is skipped if the configuration can't be read, e.g. on managed services.
+func checkKeyspaceNotifications(ctx context.Context, subscriber keyspaceSubscriber) error {
+	configured, err := subscriber.ConfigGet(ctx, []string{"notify-keyspace-events"})
+	if err != nil {
+		return nil
+	}
+	events, ok := configured["notify-keyspace-events"]
+	if !ok {
+		return nil
+	}
+	if strings.Contains(events, "K") && (strings.Contains(events, "A") || containsAll(events, "g$xe")) {
+		return nil
+	}
+	return NewConfigurationError(
+		"the server must publish the keyspace notifications of the generic, string, expired and evicted events " +
+			"for a two-level cache, e.g. with notify-keyspace-events Kg$xe, got \"" + events + "\"")
+}
+
+// containsAll reports whether s contains every character of chars.
+func containsAll(s string, chars string) bool {
+	for _, char := range chars {
+		if !strings.ContainsRune(s, char) {
+			return false
+		}
+	}
+	return true
+}
+
+// listen invalidates the keys of the notifications of queue until done is closed.
+func (cache *TwoLevelCache) listen(queue *PubSubMessageQueue, done chan struct{}, stopped chan struct{}) {
+	defer close(stopped)
+	signal := make(chan struct{}, 1)
+	queue.RegisterSignalChannel(signal)
+	defer queue.UnregisterSignalChannel(signal)
+	for {
+		for message := queue.Pop(); message != nil; message = queue.Pop() {
+			if key, _, ok := parseKeyspaceNotification(message); ok {
+				cache.invalidate(key)
+			}
+		}
+		select {
+		case <-signal:
+		case <-done:
+			return
+		}
+	}
+}
+
+// Get returns the value of key, from the local layer if it holds it, or else from Valkey. In degraded mode, the value
+// may be stale, see Lookup.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the read of Valkey.
+//	key - The key to read.
+//
+// Return value:
+//
+//	The value of `key`, or a nil result if it doesn't exist.
+func (cache *TwoLevelCache) Get(ctx context.Context, key string) (models.Result[string], error) {
+	value, err := cache.Lookup(ctx, key)
+	return value.Value, err
+}
+
+// Lookup returns the value of key, from the local layer if it holds it, or else from Valkey, and whether it's stale:
+// in degraded mode, the local value past its local TTL is served if Valkey is unreachable, see WithDegradedMode.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the read of Valkey.
+//	key - The key to read.
+//
+// Return value:
+//
+//	The value of `key`, a nil result if it doesn't exist, and whether it's stale.
+func (cache *TwoLevelCache) Lookup(ctx context.Context, key string) (TwoLevelCacheValue, error) {
+	if err := cache.replay(ctx); err != nil && cache.isQueued(key) {
+		return TwoLevelCacheValue{Value: models.CreateNilStringResult()}, err
+	}
+	load, entry := cache.lookup(key)
+	if entry != nil && load == nil {
+		cache.localHits.Add(1)
+		return TwoLevelCacheValue{Value: models.CreateStringResult(entry.value)}, nil
+	}
+	value, err := cache.remote.Get(ctx, key)
+	if err != nil {
+		cache.abandon(key, load)
+		if entry != nil && isUnreachable(err) && cache.retains(key, entry) {
+			cache.staleHits.Add(1)
+			return TwoLevelCacheValue{Value: models.CreateStringResult(entry.value), Stale: true}, nil
+		}
+		return TwoLevelCacheValue{Value: value}, err
+	}
+	if value.IsNil() {
+		cache.misses.Add(1)
+		cache.abandon(key, load)
+		cache.drop(key, entry)
+		return TwoLevelCacheValue{Value: value}, nil
+	}
+	cache.remoteHits.Add(1)
+	if load == nil {
+		return TwoLevelCacheValue{Value: value}, nil
+	}
+	expires := cache.now().Add(cache.localTTL)
+	// The local value mustn't outlive its key, whose expiry may only be notified once the key is deleted.
+	if ttl, err := cache.remote.PTTL(ctx, key); err != nil || ttl == -2 {
+		cache.abandon(key, load)
+		cache.drop(key, entry)
+		return TwoLevelCacheValue{Value: value}, nil
+	} else if keyExpires := cache.now().Add(time.Duration(ttl) * time.Millisecond); ttl >= 0 && keyExpires.Before(expires) {
+		expires = keyExpires
+	}
+	cache.store(key, load, value.Value(), expires)
+	return TwoLevelCacheValue{Value: value}, nil
+}
+
+// isUnreachable reports whether err is a failure to reach Valkey, rather than an error of the request.
+func isUnreachable(err error) bool {
+	var timeoutErr *TimeoutError
+	var disconnectErr *DisconnectError
+	var connectionErr *ConnectionError
+	return errors.As(err, &timeoutErr) || errors.As(err, &disconnectErr) ||
+		(errors.As(err, &connectionErr) && !errors.Is(err, ErrAuth))
+}
+
+// lookup returns the local value of key, or else the load of key to store its value with, nil if the key isn't cached
+// locally, along with the local value past its local TTL retained in degraded mode, if any.
+func (cache *TwoLevelCache) lookup(key string) (*twoLevelLoad, *twoLevelEntry) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.subscriber == nil || !cache.matches(key) {
+		return nil, nil
+	}
+	var stale *twoLevelEntry
+	if element, ok := cache.entries[key]; ok {
+		entry := element.Value.(*twoLevelEntry)
+		now := cache.now()
+		if now.Before(entry.expires) {
+			cache.lru.MoveToFront(element)
+			return nil, entry
+		}
+		if now.Before(entry.expires.Add(cache.maxStale)) {
+			stale = entry
+		} else {
+			cache.lru.Remove(element)
+			delete(cache.entries, key)
+		}
+	}
+	load := &twoLevelLoad{}
+	cache.loads[key] = load
+	return load, stale
+}
+
+// retains reports whether entry is still the local value of key, and within the maximum staleness of the cache.
+func (cache *TwoLevelCache) retains(key string, entry *twoLevelEntry) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	element, ok := cache.entries[key]
+	return ok && element.Value == entry && cache.now().Before(entry.expires.Add(cache.maxStale))
+}
+
+// drop drops entry, a local value past its local TTL, unless key was stored or invalidated since.
+func (cache *TwoLevelCache) drop(key string, entry *twoLevelEntry) {
+	if entry == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if element, ok := cache.entries[key]; ok && element.Value == entry {
+		cache.lru.Remove(element)
+		delete(cache.entries, key)
+	}
+}
+
+// matches reports whether key is cached locally. It must be called with cache.mu held.
+func (cache *TwoLevelCache) matches(key string) bool {
+	for _, pattern := range cache.keys {
+		if utils.GlobMatch(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// store stores value locally for key, unless key was invalidated since load started.
+func (cache *TwoLevelCache) store(key string, load *twoLevelLoad, value string, expires time.Time) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.loads[key] != load {
+		return
+	}
+	delete(cache.loads, key)
+	if element, ok := cache.entries[key]; ok {
+		element.Value = &twoLevelEntry{key: key, value: value, expires: expires}
+		cache.lru.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.lru.PushFront(&twoLevelEntry{key: key, value: value, expires: expires})
+	for cache.lru.Len() > cache.maxEntries {
+		oldest := cache.lru.Back()
+		cache.lru.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*twoLevelEntry).key)
+		cache.evictions.Add(1)
+	}
+}
+
+// abandon forgets load, whose value isn't stored.
+func (cache *TwoLevelCache) abandon(key string, load *twoLevelLoad) {
+	if load == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.loads[key] == load {
+		delete(cache.loads, key)
+	}
+}
+
+// invalidate drops the local value of key, and prevents the loads of key in progress from storing theirs.
+func (cache *TwoLevelCache) invalidate(key string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	delete(cache.loads, key)
+	if element, ok := cache.entries[key]; ok {
+		cache.lru.Remove(element)
+		delete(cache.entries, key)
+		cache.invalidations.Add(1)
+	}
+}
+
+// Set sets key to value in Valkey, and invalidates its local value.
+//
+// Parameters:
+//
+//	ctx   - The context for controlling the write of Valkey.
+//	key   - The key to set.
+//	value - The value to set.
+//
+// Return value:
+//
+//	An error if the write fails, in which case the local value is invalidated as well, since the write may have been
+//	applied.
+func (cache *TwoLevelCache) Set(ctx context.Context, key string, value string) error {
+	// The value isn't stored locally: a write of another client notified before this one completes would be lost.
+	defer cache.invalidate(key)
+	cache.invalidate(key)
+	_ = cache.replay(ctx)
+	cache.replayMu.RLock()
+	defer cache.replayMu.RUnlock()
+	_, err := cache.remote.Set(ctx, key, value)
+	if err == nil {
+		cache.dequeue([]string{key})
+	}
+	return err
+}
+
+// Delete deletes keys in Valkey, and invalidates their local values.
+//
+// Parameters:
+//
+//	ctx  - The context for controlling the delete of Valkey.
+//	keys - The keys to delete.
+//
+// Return value:
+//
+//	The number of keys deleted. In degraded mode, the deletes failing as Valkey is unreachable return an error matching
+//	[ErrInvalidationQueued], and are replayed by the next operation of the cache.
+func (cache *TwoLevelCache) Delete(ctx context.Context, keys ...string) (int64, error) {
+	defer func() {
+		for _, key := range keys {
+			cache.invalidate(key)
+		}
+	}()
+	_ = cache.replay(ctx)
+	cache.replayMu.RLock()
+	defer cache.replayMu.RUnlock()
+	deleted, err := cache.remote.Del(ctx, keys)
+	switch {
+	case err == nil:
+		cache.dequeue(keys)
+	case cache.maxStale > 0 && isUnreachable(err):
+		cache.queue(keys)
+		return 0, fmt.Errorf("%w: %w", ErrInvalidationQueued, err)
+	}
+	return deleted, err
+}
+
+// queue queues the deletes of keys to be replayed.
+func (cache *TwoLevelCache) queue(keys []string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.closed {
+		return
+	}
+	for _, key := range keys {
+		cache.queued[key] = struct{}{}
+	}
+	cache.queuedCount.Store(int64(len(cache.queued)))
+}
+
+// dequeue drops the queued deletes of keys, which were written or deleted since.
+func (cache *TwoLevelCache) dequeue(keys []string) {
+	if cache.queuedCount.Load() == 0 {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for _, key := range keys {
+		delete(cache.queued, key)
+	}
+	cache.queuedCount.Store(int64(len(cache.queued)))
+}
+
+// isQueued reports whether the delete of key is queued.
+func (cache *TwoLevelCache) isQueued(key string) bool {
+	if cache.queuedCount.Load() == 0 {
+		return false
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	_, ok := cache.queued[key]
+	return ok
+}
+
+// replay replays the queued deletes, if any, and returns an error if they failed, in which case they stay queued.
+func (cache *TwoLevelCache) replay(ctx context.Context) error {
+	if cache.queuedCount.Load() == 0 {
+		return nil
+	}
+	cache.replayMu.Lock()
+	defer cache.replayMu.Unlock()
+	cache.mu.Lock()
+	keys := make([]string, 0, len(cache.queued))
+	for key := range cache.queued {
+		keys = append(keys, key)
+	}
+	cache.mu.Unlock()
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	if _, err := cache.remote.Del(ctx, keys); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		cache.invalidate(key)
+	}
+	cache.dequeue(keys)
+	return nil
+}
+
+// Stats returns the lookups of the cache by the layer serving them.
+func (cache *TwoLevelCache) Stats() TwoLevelCacheStats {
+	cache.mu.Lock()
+	entries := cache.lru.Len()
+	cache.mu.Unlock()
+	return TwoLevelCacheStats{
+		LocalHits:           cache.localHits.Load(),
+		RemoteHits:          cache.remoteHits.Load(),
+		Misses:              cache.misses.Load(),
+		Invalidations:       cache.invalidations.Load(),
+		Evictions:           cache.evictions.Load(),
+		StaleHits:           cache.staleHits.Load(),
+		Entries:             entries,
+		QueuedInvalidations: int(cache.queuedCount.Load()),
+	}
+}
+
+// Close stops listening to the invalidation feed, unsubscribing the subscriber, and drops the local values. The reads
+// are served by Valkey once the cache is closed. Close must be called before closing the clients.
+func (cache *TwoLevelCache) Close() {
+	cache.mu.Lock()
+	if cache.closed {
+		cache.mu.Unlock()
+		return
+	}
+	cache.closed = true
+	subscriber, channels, done, stopped := cache.subscriber, cache.channels, cache.done, cache.stopped
+	cache.subscriber = nil
+	cache.entries = make(map[string]*list.Element)
+	cache.lru.Init()
+	clear(cache.loads)
+	clear(cache.queued)
+	cache.queuedCount.Store(0)
+	cache.mu.Unlock()
+	if subscriber == nil {
+		return
+	}
+	close(done)
+	<-stopped
+	_ = subscriber.PUnsubscribe(context.Background(), channels, twoLevelCacheSubscribeTimeoutMs)
+}
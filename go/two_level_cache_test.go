@@ -0,0 +1,285 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// twoLevelRemote holds the values read by a TwoLevelCache. Only Get, PTTL, Set and Del are implemented.
+type twoLevelRemote struct {
+	interfaces.BaseClientCommands
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]int64
+	reads  int
+	// err, if set, is returned by Get, Set and Del, as if Valkey were unreachable.
+	err error
+	// onGet, if set, is called by Get before it returns.
+	onGet func(key string)
+}
+
+func (remote *twoLevelRemote) Get(ctx context.Context, key string) (models.Result[string], error) {
+	remote.mu.Lock()
+	remote.reads++
+	value, ok := remote.values[key]
+	onGet, err := remote.onGet, remote.err
+	remote.mu.Unlock()
+	if onGet != nil {
+		onGet(key)
+	}
+	if err != nil {
+		return models.CreateNilStringResult(), err
+	}
+	if !ok {
+		return models.CreateNilStringResult(), nil
+	}
+	return models.CreateStringResult(value), nil
+}
+
+func (remote *twoLevelRemote) PTTL(ctx context.Context, key string) (int64, error) {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+	if ttl, ok := remote.ttls[key]; ok {
+		return ttl, nil
+	}
+	return -1, nil
+}
+
+func (remote *twoLevelRemote) Set(ctx context.Context, key string, value string) (string, error) {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+	if remote.err != nil {
+		return "", remote.err
+	}
+	remote.values[key] = value
+	return OK, nil
+}
+
+func (remote *twoLevelRemote) Del(ctx context.Context, keys []string) (int64, error) {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+	if remote.err != nil {
+		return 0, remote.err
+	}
+	for _, key := range keys {
+		delete(remote.values, key)
+	}
+	return int64(len(keys)), nil
+}
+
+func (remote *twoLevelRemote) readCount() int {
+	remote.mu.Lock()
+	defer remote.mu.Unlock()
+	return remote.reads
+}
+
+// twoLevelSubscriber feeds the keyspace notifications pushed to its queue to a TwoLevelCache.
+type twoLevelSubscriber struct {
+	queue    *PubSubMessageQueue
+	events   string
+	patterns []string
+}
+
+func (subscriber *twoLevelSubscriber) PSubscribe(ctx context.Context, patterns []string, timeoutMs int) error {
+	subscriber.patterns = append(subscriber.patterns, patterns...)
+	return nil
+}
+
+func (subscriber *twoLevelSubscriber) PUnsubscribe(ctx context.Context, patterns []string, timeoutMs int) error {
+	subscriber.patterns = nil
+	return nil
+}
+
+func (subscriber *twoLevelSubscriber) GetQueue() (*PubSubMessageQueue, error) {
+	return subscriber.queue, nil
+}
+
+func (subscriber *twoLevelSubscriber) ConfigGet(ctx context.Context, parameters []string) (map[string]string, error) {
+	return map[string]string{"notify-keyspace-events": subscriber.events}, nil
+}
+
+func (subscriber *twoLevelSubscriber) notify(key string, event string) {
+	subscriber.queue.Push(models.NewPubSubMessage(event, "__keyspace@0__:"+key))
+}
+
+func newTwoLevelTest(t *testing.T) (*TwoLevelCache, *twoLevelRemote, *twoLevelSubscriber) {
+	remote := &twoLevelRemote{values: map[string]string{"user:1": "alice", "user:2": "bob"}, ttls: map[string]int64{}}
+	subscriber := &twoLevelSubscriber{queue: NewPubSubMessageQueue(), events: "Kg$xe"}
+	cache := NewTwoLevelCache(remote)
+	require.NoError(t, cache.Listen(context.Background(), subscriber, "user:*"))
+	t.Cleanup(cache.Close)
+	return cache, remote, subscriber
+}
+
+func TestTwoLevelCache_Layers(t *testing.T) {
+	cache, remote, subscriber := newTwoLevelTest(t)
+	ctx := context.Background()
+	assert.Equal(t, []string{"__keyspace@*__:user:*"}, subscriber.patterns)
+
+	for range 3 {
+		value, err := cache.Get(ctx, "user:1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", value.Value())
+	}
+	assert.Equal(t, 1, remote.readCount())
+	value, err := cache.Get(ctx, "user:3")
+	require.NoError(t, err)
+	assert.True(t, value.IsNil())
+	assert.Equal(t, TwoLevelCacheStats{LocalHits: 2, RemoteHits: 1, Misses: 1, Entries: 1}, cache.Stats())
+
+	// The writes of other clients are invalidated by the feed.
+	remote.values["user:1"] = "carol"
+	subscriber.notify("user:1", "set")
+	assert.Eventually(t, func() bool { return cache.Stats().Invalidations == 1 }, time.Second, time.Millisecond)
+	value, err = cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "carol", value.Value())
+
+	// The writes of the cache are invalidated right away.
+	require.NoError(t, cache.Set(ctx, "user:1", "dave"))
+	value, err = cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "dave", value.Value())
+	deleted, err := cache.Delete(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	value, err = cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.True(t, value.IsNil())
+
+	// The keys not matching the patterns are always read from Valkey.
+	remote.values["session:1"] = "token"
+	for range 2 {
+		_, err = cache.Get(ctx, "session:1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, cache.Stats().Entries)
+
+	cache.Close()
+	assert.Nil(t, subscriber.patterns)
+	reads := remote.readCount()
+	_, err = cache.Get(ctx, "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, reads+1, remote.readCount())
+}
+
+func TestTwoLevelCache_InvalidatedLoad(t *testing.T) {
+	cache, remote, _ := newTwoLevelTest(t)
+	ctx := context.Background()
+
+	// A value read before its invalidation isn't stored.
+	remote.onGet = func(key string) { cache.invalidate(key) }
+	value, err := cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", value.Value())
+	assert.Equal(t, 0, cache.Stats().Entries)
+
+	remote.onGet = nil
+	_, err = cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.Stats().Entries)
+}
+
+func TestTwoLevelCache_Expiry(t *testing.T) {
+	cache, remote, _ := newTwoLevelTest(t)
+	cache.WithMaxEntries(1)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// The local value doesn't outlive its key.
+	remote.ttls["user:1"] = 100
+	_, err := cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	now = now.Add(100 * time.Millisecond)
+	_, err = cache.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, remote.readCount())
+
+	_, err = cache.Get(ctx, "user:2")
+	require.NoError(t, err)
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, 1, stats.Entries)
+
+	now = now.Add(DefaultTwoLevelCacheLocalTTL)
+	_, err = cache.Get(ctx, "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, 4, remote.readCount())
+}
+
+func TestTwoLevelCache_DegradedMode(t *testing.T) {
+	cache, remote, _ := newTwoLevelTest(t)
+	cache.WithDegradedMode(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+	remote.values["user:3"] = "carol"
+	for _, key := range []string{"user:1", "user:2", "user:3"} {
+		_, err := cache.Get(ctx, key)
+		require.NoError(t, err)
+	}
+
+	// The values past their local TTL are served, flagged as stale, while Valkey is unreachable only.
+	now = now.Add(DefaultTwoLevelCacheLocalTTL)
+	remote.err = NewRequestError("WRONGTYPE Operation against a key holding the wrong kind of value")
+	_, err := cache.Lookup(ctx, "user:1")
+	assert.ErrorIs(t, err, ErrWrongType)
+	remote.err = NewTimeoutError("Request timed out")
+	value, err := cache.Lookup(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, TwoLevelCacheValue{Value: models.CreateStringResult("alice"), Stale: true}, value)
+	assert.Equal(t, uint64(1), cache.Stats().StaleHits)
+
+	// The deletes are queued, and their keys aren't served meanwhile.
+	_, err = cache.Delete(ctx, "user:2")
+	assert.ErrorIs(t, err, ErrInvalidationQueued)
+	var timeoutErr *TimeoutError
+	assert.ErrorAs(t, err, &timeoutErr)
+	_, err = cache.Delete(ctx, "user:3")
+	assert.ErrorIs(t, err, ErrInvalidationQueued)
+	assert.Equal(t, 2, cache.Stats().QueuedInvalidations)
+	_, err = cache.Get(ctx, "user:2")
+	assert.IsType(t, &TimeoutError{}, err)
+
+	// The values are read from Valkey once it's reachable again, and the deletes replayed, unless their keys were
+	// written since.
+	now = now.Add(time.Minute)
+	_, err = cache.Get(ctx, "user:1")
+	assert.IsType(t, &TimeoutError{}, err)
+	remote.err = nil
+	require.NoError(t, cache.Set(ctx, "user:3", "dave"))
+	assert.Equal(t, map[string]string{"user:1": "alice", "user:3": "dave"}, remote.values)
+	assert.Equal(t, 0, cache.Stats().QueuedInvalidations)
+	value, err = cache.Lookup(ctx, "user:3")
+	require.NoError(t, err)
+	assert.Equal(t, TwoLevelCacheValue{Value: models.CreateStringResult("dave")}, value)
+}
+
+func TestTwoLevelCache_Listen(t *testing.T) {
+	remote := &twoLevelRemote{values: map[string]string{}}
+	subscriber := &twoLevelSubscriber{queue: NewPubSubMessageQueue(), events: "Ex"}
+	err := NewTwoLevelCache(remote).Listen(context.Background(), subscriber)
+	assert.IsType(t, &ConfigurationError{}, err)
+
+	subscriber.events = "KA"
+	cache := NewTwoLevelCache(remote)
+	require.NoError(t, cache.Listen(context.Background(), subscriber))
+	assert.Equal(t, []string{"__keyspace@*__:*"}, subscriber.patterns)
+	assert.ErrorContains(t, cache.Listen(context.Background(), subscriber), "already")
+	cache.Close()
+	assert.IsType(t, &ClosingError{}, cache.Listen(context.Background(), subscriber))
+
+	err = NewTwoLevelCache(remote).WithLocalTTL(0).Listen(context.Background(), subscriber)
+	assert.ErrorContains(t, err, "local TTL")
+}
@@ -0,0 +1,76 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// UnlinkMulti deletes keys in chunks of UNLINKs of at most unlinkOptions.ChunkSize keys, sent one after the other and
+// optionally rate limited, so that mass invalidations don't flood the server. Each chunk is split across the slots
+// of its keys in cluster mode, like [Client.Unlink].
+//
+// The deletion stops at the first chunk which fails: the keys of the following chunks aren't deleted.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the deletion. The deletion stops once ctx is done.
+//	keys - The keys to delete.
+//	unlinkOptions - The size of the chunks and the rate limit of the deletions, see [options.UnlinkMultiOptions].
+//
+// Return value:
+//
+//	The number of keys deleted by each chunk sent, in order, as confirmed by the server. The counts of the chunks
+//	deleted before a failure are returned along with it.
+func (client *baseClient) UnlinkMulti(
+	ctx context.Context,
+	keys []string,
+	unlinkOptions options.UnlinkMultiOptions,
+) ([]int64, error) {
+	chunks, err := chunkElements(keys, 1, options.ChunkOptions{
+		MaxBytes:    options.DefaultChunkMaxBytes,
+		MaxElements: unlinkOptions.ChunkSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unlinkChunks(ctx, chunks, unlinkOptions.KeysPerSecond, client.Unlink, time.Now)
+}
+
+// unlinkChunks deletes the keys of chunks with unlink, one chunk after the other, sending each chunk once the keys of
+// the previous chunks fit in keysPerSecond since the first chunk, unless keysPerSecond is 0.
+func unlinkChunks(
+	ctx context.Context,
+	chunks [][]string,
+	keysPerSecond float64,
+	unlink func(ctx context.Context, keys []string) (int64, error),
+	now func() time.Time,
+) ([]int64, error) {
+	counts := make([]int64, 0, len(chunks))
+	start := now()
+	sent := 0
+	for _, chunk := range chunks {
+		if keysPerSecond > 0 && sent > 0 {
+			due := start.Add(time.Duration(float64(sent) / keysPerSecond * float64(time.Second)))
+			if wait := due.Sub(now()); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return counts, ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+		count, err := unlink(ctx, chunk)
+		if err != nil {
+			return counts, err
+		}
+		counts = append(counts, count)
+		sent += len(chunk)
+	}
+	return counts, nil
+}
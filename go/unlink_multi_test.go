@@ -0,0 +1,69 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUnlinker struct {
+	chunks [][]string
+	err    error
+	failAt int
+}
+
+func (unlinker *fakeUnlinker) unlink(ctx context.Context, keys []string) (int64, error) {
+	if unlinker.err != nil && len(unlinker.chunks) == unlinker.failAt {
+		return 0, unlinker.err
+	}
+	unlinker.chunks = append(unlinker.chunks, keys)
+	return int64(len(keys)) - 1, nil
+}
+
+func TestUnlinkChunks(t *testing.T) {
+	ctx := context.Background()
+	chunks := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+
+	unlinker := &fakeUnlinker{}
+	counts, err := unlinkChunks(ctx, chunks, 0, unlinker.unlink, time.Now)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 1, 0}, counts)
+	assert.Equal(t, chunks, unlinker.chunks)
+
+	// At 200 keys per second, the second chunk is sent after 10ms and the third one after 20ms.
+	unlinker = &fakeUnlinker{}
+	start := time.Now()
+	counts, err = unlinkChunks(ctx, chunks, 200, unlinker.unlink, time.Now)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 1, 0}, counts)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// The deletion stops at the first chunk which fails.
+	failure := errors.New("READONLY You can't write against a read only replica.")
+	unlinker = &fakeUnlinker{err: failure, failAt: 1}
+	counts, err = unlinkChunks(ctx, chunks, 0, unlinker.unlink, time.Now)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []int64{1}, counts)
+	assert.Equal(t, chunks[:1], unlinker.chunks)
+}
+
+func TestUnlinkChunks_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	unlinker := &fakeUnlinker{}
+	unlink := func(ctx context.Context, keys []string) (int64, error) {
+		cancel()
+		return unlinker.unlink(ctx, keys)
+	}
+
+	// At 1 key per second, the deletion is canceled while it waits for the second chunk.
+	counts, err := unlinkChunks(ctx, [][]string{{"a", "b"}, {"c"}}, 1, unlink, time.Now)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int64{1}, counts)
+	assert.Len(t, unlinker.chunks, 1)
+}
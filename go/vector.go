@@ -0,0 +1,177 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// EncodeFloat32Vector encodes vector in the binary format of the FLOAT32 vector fields of search indexes: the
+// little-endian IEEE 754 representations of its components, e.g. to store it in a hash or to pass it as the parameter
+// of a KNN query.
+func EncodeFloat32Vector(vector []float32) string {
+	data := make([]byte, 4*len(vector))
+	for i, component := range vector {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(component))
+	}
+	return string(data)
+}
+
+// EncodeFloat64Vector encodes vector in the binary format of the FLOAT32 vector fields of search indexes, its
+// components being converted to float32. See EncodeFloat32Vector.
+func EncodeFloat64Vector(vector []float64) string {
+	data := make([]byte, 4*len(vector))
+	for i, component := range vector {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(float32(component)))
+	}
+	return string(data)
+}
+
+// DecodeFloat32Vector decodes data, a vector in the binary format of the FLOAT32 vector fields of search indexes.
+func DecodeFloat32Vector(data string) ([]float32, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("a FLOAT32 vector must have a multiple of 4 bytes, got %d", len(data))
+	}
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32([]byte(data[4*i : 4*i+4])))
+	}
+	return vector, nil
+}
+
+// DecodeFloat64Vector decodes data, a vector in the binary format of the FLOAT32 vector fields of search indexes, into
+// float64 components.
+func DecodeFloat64Vector(data string) ([]float64, error) {
+	vector32, err := DecodeFloat32Vector(data)
+	if err != nil {
+		return nil, err
+	}
+	vector := make([]float64, len(vector32))
+	for i, component := range vector32 {
+		vector[i] = float64(component)
+	}
+	return vector, nil
+}
+
+// searchInfoClient is implemented by [Client] and [ClusterClient].
+type searchInfoClient interface {
+	ftInfo(ctx context.Context, index string) (any, error)
+}
+
+// vectorField is a vector field of the schema of a search index.
+type vectorField struct {
+	dimensions int
+	dataType   string
+}
+
+// VectorEncoder encodes the vectors of the vector fields of a search index, validating their dimensions against the
+// schema of the index: vectors of other dimensions would be rejected when indexed, or match nothing when queried.
+//
+// Example usage:
+//
+//	encoder, err := glide.NewVectorEncoder(ctx, client, "products")
+//	vector, err := encoder.Encode("embedding", embedding)
+//	_, err = client.HSet(ctx, "product:1", map[string]string{"embedding": vector})
+type VectorEncoder struct {
+	index  string
+	fields map[string]vectorField
+}
+
+// NewVectorEncoder returns a [VectorEncoder] for the vector fields of index, read with FT.INFO.
+//
+// The search module must be loaded on the server.
+func NewVectorEncoder(ctx context.Context, client searchInfoClient, index string) (*VectorEncoder, error) {
+	info, err := client.ftInfo(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	return &VectorEncoder{index: index, fields: parseVectorFields(info)}, nil
+}
+
+// Dimensions returns the number of dimensions of the vector field field, and whether the index has the field.
+func (encoder *VectorEncoder) Dimensions(field string) (int, bool) {
+	vector, ok := encoder.fields[field]
+	return vector.dimensions, ok
+}
+
+// Encode encodes vector for the vector field field, see EncodeFloat32Vector, or returns an error if the index has no
+// such FLOAT32 field, or if vector doesn't have its dimensions.
+func (encoder *VectorEncoder) Encode(field string, vector []float32) (string, error) {
+	if err := encoder.validate(field, len(vector)); err != nil {
+		return "", err
+	}
+	return EncodeFloat32Vector(vector), nil
+}
+
+// Encode64 encodes vector for the vector field field, see EncodeFloat64Vector and Encode.
+func (encoder *VectorEncoder) Encode64(field string, vector []float64) (string, error) {
+	if err := encoder.validate(field, len(vector)); err != nil {
+		return "", err
+	}
+	return EncodeFloat64Vector(vector), nil
+}
+
+func (encoder *VectorEncoder) validate(field string, dimensions int) error {
+	vector, ok := encoder.fields[field]
+	if !ok {
+		return fmt.Errorf("index %q has no vector field %q", encoder.index, field)
+	}
+	if vector.dataType != "" && !strings.EqualFold(vector.dataType, "FLOAT32") {
+		return fmt.Errorf("the vector field %q of index %q holds %s vectors, not FLOAT32 ones", field, encoder.index,
+			vector.dataType)
+	}
+	if dimensions != vector.dimensions {
+		return fmt.Errorf("the vector field %q of index %q has %d dimensions, got a vector of %d", field, encoder.index,
+			vector.dimensions, dimensions)
+	}
+	return nil
+}
+
+// parseVectorFields returns the vector fields of the reply of FT.INFO, by name. The attributes of the fields are
+// flat arrays of names and values, or maps, in which the parameters of vector fields may be nested, e.g. in "index".
+func parseVectorFields(info any) map[string]vectorField {
+	fields := make(map[string]vectorField)
+	attributes, _ := searchIndexInfoFields(info)["attributes"].([]any)
+	for _, attribute := range attributes {
+		properties := flattenSearchInfo(attribute)
+		if typ, _ := properties["type"].(string); !strings.EqualFold(typ, "VECTOR") {
+			continue
+		}
+		name, _ := properties["attribute"].(string)
+		if name == "" {
+			name, _ = properties["identifier"].(string)
+		}
+		dimensions, ok := searchInfoInt(properties["dimensions"])
+		if !ok {
+			dimensions, _ = searchInfoInt(properties["dim"])
+		}
+		dataType, _ := properties["data_type"].(string)
+		fields[name] = vectorField{dimensions: int(dimensions), dataType: dataType}
+	}
+	return fields
+}
+
+// flattenSearchInfo returns the properties of value, a map or a flat array of names and values of the reply of
+// FT.INFO, including the properties of its nested maps and arrays.
+func flattenSearchInfo(value any) map[string]any {
+	fields := searchIndexInfoFields(value)
+	properties := make(map[string]any, len(fields))
+	for name, property := range fields {
+		properties[name] = property
+	}
+	for _, property := range fields {
+		switch property.(type) {
+		case map[string]any, []any:
+			for nestedName, nested := range flattenSearchInfo(property) {
+				if _, ok := properties[nestedName]; !ok {
+					properties[nestedName] = nested
+				}
+			}
+		}
+	}
+	return properties
+}
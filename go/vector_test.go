@@ -0,0 +1,86 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorEncoding(t *testing.T) {
+	encoded := EncodeFloat32Vector([]float32{1, -2.5})
+	assert.Equal(t, "\x00\x00\x80\x3f\x00\x00\x20\xc0", encoded)
+	assert.Equal(t, encoded, EncodeFloat64Vector([]float64{1, -2.5}))
+
+	vector, err := DecodeFloat32Vector(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, -2.5}, vector)
+	vector64, err := DecodeFloat64Vector(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, -2.5}, vector64)
+
+	_, err = DecodeFloat32Vector("\x00\x00\x80")
+	assert.Error(t, err)
+}
+
+// fakeSearchInfoClient replies to FT.INFO with a canned reply.
+type fakeSearchInfoClient struct {
+	info any
+}
+
+func (client *fakeSearchInfoClient) ftInfo(ctx context.Context, index string) (any, error) {
+	return client.info, nil
+}
+
+func TestVectorEncoder(t *testing.T) {
+	replies := map[string]any{
+		"nested arrays": []any{
+			"index_name", "products",
+			"attributes",
+			[]any{
+				[]any{"identifier", "title", "attribute", "title", "type", "TEXT"},
+				[]any{
+					"identifier", "$.embedding", "attribute", "embedding", "type", "VECTOR",
+					"index",
+					[]any{"capacity", int64(1000), "dimensions", int64(3), "data_type", "FLOAT32"},
+				},
+				[]any{"identifier", "binary", "type", "VECTOR", "dim", "2", "data_type", "FLOAT64"},
+			},
+		},
+		"maps": map[string]any{
+			"index_name": "products",
+			"attributes": []any{
+				map[string]any{"identifier": "$.embedding", "attribute": "embedding", "type": "VECTOR", "dim": int64(3)},
+				map[string]any{"identifier": "binary", "type": "VECTOR", "dim": int64(2), "data_type": "FLOAT64"},
+			},
+		},
+	}
+	for name, reply := range replies {
+		t.Run(name, func(t *testing.T) {
+			encoder, err := NewVectorEncoder(context.Background(), &fakeSearchInfoClient{info: reply}, "products")
+			require.NoError(t, err)
+
+			dimensions, ok := encoder.Dimensions("embedding")
+			assert.True(t, ok)
+			assert.Equal(t, 3, dimensions)
+			_, ok = encoder.Dimensions("title")
+			assert.False(t, ok)
+
+			encoded, err := encoder.Encode("embedding", []float32{1, 2, 3})
+			require.NoError(t, err)
+			assert.Equal(t, EncodeFloat32Vector([]float32{1, 2, 3}), encoded)
+			_, err = encoder.Encode64("embedding", []float64{1, 2, 3})
+			require.NoError(t, err)
+
+			_, err = encoder.Encode("embedding", []float32{1, 2})
+			assert.ErrorContains(t, err, "3 dimensions")
+			_, err = encoder.Encode("title", []float32{1})
+			assert.Error(t, err)
+			_, err = encoder.Encode("binary", []float32{1, 2})
+			assert.ErrorContains(t, err, "FLOAT64")
+		})
+	}
+}
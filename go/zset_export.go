@@ -0,0 +1,233 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/internal"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/protobuf"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+const (
+	// DefaultZSetExportPageSize is the default number of members read by each ZRANGE of a [ZSetExporter].
+	DefaultZSetExportPageSize = 1000
+	// DefaultZSetExportPagesPerBatch is the default number of ZRANGE pipelined by a [ZSetExporter].
+	DefaultZSetExportPagesPerBatch = 10
+)
+
+// ZSetExportFormat is the format of the members written by a [ZSetExporter].
+type ZSetExportFormat int
+
+const (
+	// ZSetExportCSV writes a "member,score" header, then a CSV record per member.
+	ZSetExportCSV ZSetExportFormat = iota
+	// ZSetExportJSONLines writes a JSON object per line and member, e.g. {"member":"alice","score":1200}. The infinite
+	// scores are written as the strings "+inf" and "-inf".
+	ZSetExportJSONLines
+)
+
+// zsetExportClient is implemented by [Client] and [ClusterClient].
+type zsetExportClient interface {
+	zrangePages(ctx context.Context, key string, start int64, pageSize int64, pages int, reverse bool) (
+		[][]models.MemberAndScore,
+		error,
+	)
+}
+
+// ZSetExporter exports the members and the scores of sorted sets to a writer, e.g. for the nightly archival of
+// leaderboards, without blocking the server with a single ZRANGE of a whole large sorted set.
+//
+// The members are read by pages of ZRANGE by rank, several pages being pipelined at a time, and written in the order
+// of the sorted set as each batch of pages arrives, so that the export doesn't hold the whole sorted set in memory. The
+// members added or removed during the export shift the ranks of the following pages: members may then be missed or
+// written twice. To export a consistent snapshot, copy the sorted set first, e.g. with COPY, and export the copy.
+//
+// Example usage:
+//
+//	exporter := glide.NewZSetExporter(client).WithFormat(glide.ZSetExportJSONLines).WithReverse(true)
+//	exported, err := exporter.Export(ctx, "leaderboard:2024-06-01", archive)
+type ZSetExporter struct {
+	client        zsetExportClient
+	pageSize      int64
+	pagesPerBatch int
+	format        ZSetExportFormat
+	reverse       bool
+}
+
+// NewZSetExporter returns a [ZSetExporter] for the sorted sets of client, a [Client] or a [ClusterClient], exporting
+// them as CSV by increasing score.
+func NewZSetExporter(client zsetExportClient) *ZSetExporter {
+	return &ZSetExporter{
+		client:        client,
+		pageSize:      DefaultZSetExportPageSize,
+		pagesPerBatch: DefaultZSetExportPagesPerBatch,
+	}
+}
+
+// WithPageSize sets the number of members read by each ZRANGE. Defaults to [DefaultZSetExportPageSize].
+func (exporter *ZSetExporter) WithPageSize(pageSize int64) *ZSetExporter {
+	exporter.pageSize = pageSize
+	return exporter
+}
+
+// WithPagesPerBatch sets the number of ZRANGE pipelined at a time. Defaults to [DefaultZSetExportPagesPerBatch].
+func (exporter *ZSetExporter) WithPagesPerBatch(pagesPerBatch int) *ZSetExporter {
+	exporter.pagesPerBatch = pagesPerBatch
+	return exporter
+}
+
+// WithFormat sets the format of the export. Defaults to [ZSetExportCSV].
+func (exporter *ZSetExporter) WithFormat(format ZSetExportFormat) *ZSetExporter {
+	exporter.format = format
+	return exporter
+}
+
+// WithReverse sets whether the members are exported by decreasing score, and decreasing member for equal scores, e.g.
+// from the top of a leaderboard. Disabled by default.
+func (exporter *ZSetExporter) WithReverse(reverse bool) *ZSetExporter {
+	exporter.reverse = reverse
+	return exporter
+}
+
+// Export writes the members and the scores of the sorted set of key to w.
+//
+// Parameters:
+//
+//	ctx - The context for controlling the command execution.
+//	key - The key of the sorted set.
+//	w   - The writer of the export.
+//
+// Return value:
+//
+//	The number of members written, or an error if the sorted set couldn't be read, or the export couldn't be written.
+//	The members written before the error are counted.
+func (exporter *ZSetExporter) Export(ctx context.Context, key string, w io.Writer) (int64, error) {
+	if exporter.pageSize <= 0 {
+		return 0, fmt.Errorf("the page size must be positive, got %d", exporter.pageSize)
+	}
+	if exporter.pagesPerBatch <= 0 {
+		return 0, fmt.Errorf("the number of pages per batch must be positive, got %d", exporter.pagesPerBatch)
+	}
+	output := newZSetExportWriter(w, exporter.format)
+	if err := output.header(); err != nil {
+		return 0, err
+	}
+	var exported int64
+	for start := int64(0); ; start += exporter.pageSize * int64(exporter.pagesPerBatch) {
+		pages, err := exporter.client.zrangePages(ctx, key, start, exporter.pageSize, exporter.pagesPerBatch, exporter.reverse)
+		if err != nil {
+			return exported, fmt.Errorf("failed to read sorted set %q: %w", key, err)
+		}
+		for _, page := range pages {
+			for _, entry := range page {
+				if err := output.write(entry); err != nil {
+					return exported, err
+				}
+				exported++
+			}
+			if int64(len(page)) < exporter.pageSize {
+				return exported, output.flush()
+			}
+		}
+		if err := output.flush(); err != nil {
+			return exported, err
+		}
+	}
+}
+
+// zsetExportWriter writes the members of a sorted set in a [ZSetExportFormat].
+type zsetExportWriter struct {
+	format ZSetExportFormat
+	csv    *csv.Writer
+	json   *bufio.Writer
+}
+
+func newZSetExportWriter(w io.Writer, format ZSetExportFormat) *zsetExportWriter {
+	if format == ZSetExportJSONLines {
+		return &zsetExportWriter{format: format, json: bufio.NewWriter(w)}
+	}
+	return &zsetExportWriter{format: format, csv: csv.NewWriter(w)}
+}
+
+func (writer *zsetExportWriter) header() error {
+	if writer.csv != nil {
+		return writer.csv.Write([]string{"member", "score"})
+	}
+	return nil
+}
+
+func (writer *zsetExportWriter) write(entry models.MemberAndScore) error {
+	if writer.csv != nil {
+		return writer.csv.Write([]string{entry.Member, strconv.FormatFloat(entry.Score, 'g', -1, 64)})
+	}
+	member, err := json.Marshal(entry.Member)
+	if err != nil {
+		return err
+	}
+	score := strconv.FormatFloat(entry.Score, 'g', -1, 64)
+	switch {
+	case math.IsInf(entry.Score, 1):
+		score = `"+inf"`
+	case math.IsInf(entry.Score, -1):
+		score = `"-inf"`
+	}
+	_, err = fmt.Fprintf(writer.json, "{\"member\":%s,\"score\":%s}\n", member, score)
+	return err
+}
+
+func (writer *zsetExportWriter) flush() error {
+	if writer.csv != nil {
+		writer.csv.Flush()
+		return writer.csv.Error()
+	}
+	return writer.json.Flush()
+}
+
+// zrangePages returns pages of pageSize members of the sorted set of key, from rank start, read in a single
+// non-atomic pipeline of ZRANGE.
+func (client *baseClient) zrangePages(
+	ctx context.Context,
+	key string,
+	start int64,
+	pageSize int64,
+	pages int,
+	reverse bool,
+) ([][]models.MemberAndScore, error) {
+	batch := internal.Batch{Commands: make([]internal.Cmd, pages)}
+	for i := range batch.Commands {
+		pageStart := start + int64(i)*pageSize
+		args := []string{key, strconv.FormatInt(pageStart, 10), strconv.FormatInt(pageStart+pageSize-1, 10)}
+		if reverse {
+			args = append(args, "REV")
+		}
+		args = append(args, constants.WithScoresKeyword)
+		batch.Commands[i] = internal.MakeCmd(
+			uint32(protobuf.RequestType_ZRange),
+			args,
+			internal.MakeConvertMapOfMemberAndScore(reverse),
+		)
+	}
+	responses, err := client.executeBatch(ctx, batch, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]models.MemberAndScore, len(responses))
+	for i, response := range responses {
+		page, ok := response.([]models.MemberAndScore)
+		if !ok {
+			return nil, fmt.Errorf("unexpected response to ZRANGE: %v", response)
+		}
+		result[i] = page
+	}
+	return result, nil
+}
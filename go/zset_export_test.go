@@ -0,0 +1,97 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+)
+
+// fakeZRangeClient serves the pages of a sorted set, recording the start rank and the number of pages of each batch.
+type fakeZRangeClient struct {
+	entries []models.MemberAndScore
+	batches [][2]int64
+	err     error
+}
+
+func (client *fakeZRangeClient) zrangePages(
+	ctx context.Context,
+	key string,
+	start int64,
+	pageSize int64,
+	pages int,
+	reverse bool,
+) ([][]models.MemberAndScore, error) {
+	client.batches = append(client.batches, [2]int64{start, int64(pages)})
+	if client.err != nil {
+		return nil, client.err
+	}
+	result := make([][]models.MemberAndScore, pages)
+	for i := range result {
+		from := min(start+int64(i)*pageSize, int64(len(client.entries)))
+		to := min(from+pageSize, int64(len(client.entries)))
+		result[i] = client.entries[from:to]
+	}
+	return result, nil
+}
+
+func TestZSetExporter_CSV(t *testing.T) {
+	client := &fakeZRangeClient{entries: []models.MemberAndScore{
+		{Member: "alice", Score: 1200},
+		{Member: "bob, jr", Score: 1350.5},
+		{Member: "carol", Score: 1500},
+		{Member: "dave", Score: 1800},
+		{Member: "erin", Score: 2100},
+	}}
+	var output bytes.Buffer
+
+	exported, err := NewZSetExporter(client).WithPageSize(2).WithPagesPerBatch(2).Export(
+		context.Background(),
+		"leaderboard",
+		&output,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), exported)
+	assert.Equal(t, "member,score\nalice,1200\n\"bob, jr\",1350.5\ncarol,1500\ndave,1800\nerin,2100\n", output.String())
+	assert.Equal(t, [][2]int64{{0, 2}, {4, 2}}, client.batches)
+}
+
+func TestZSetExporter_JSONLines(t *testing.T) {
+	client := &fakeZRangeClient{entries: []models.MemberAndScore{
+		{Member: "alice", Score: math.Inf(-1)}, {Member: "bob \"b\"", Score: 2.5}, {Member: "carol", Score: math.Inf(1)},
+	}}
+	var output bytes.Buffer
+
+	exported, err := NewZSetExporter(client).WithFormat(ZSetExportJSONLines).WithPageSize(3).Export(
+		context.Background(),
+		"leaderboard",
+		&output,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), exported)
+	assert.Equal(t,
+		"{\"member\":\"alice\",\"score\":\"-inf\"}\n"+
+			"{\"member\":\"bob \\\"b\\\"\",\"score\":2.5}\n"+
+			"{\"member\":\"carol\",\"score\":\"+inf\"}\n",
+		output.String())
+	// The full last page is followed by an empty one.
+	assert.Equal(t, [][2]int64{{0, 10}}, client.batches)
+}
+
+func TestZSetExporter_Errors(t *testing.T) {
+	client := &fakeZRangeClient{err: errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")}
+	_, err := NewZSetExporter(client).Export(context.Background(), "leaderboard", &bytes.Buffer{})
+	assert.ErrorIs(t, err, client.err)
+	assert.ErrorContains(t, err, `failed to read sorted set "leaderboard"`)
+
+	_, err = NewZSetExporter(client).WithPageSize(0).Export(context.Background(), "leaderboard", &bytes.Buffer{})
+	assert.EqualError(t, err, "the page size must be positive, got 0")
+}
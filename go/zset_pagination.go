@@ -0,0 +1,144 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// ZSetPage is a page of the members of a sorted set, as returned by [ZSetPaginator.Page].
+type ZSetPage struct {
+	Entries []models.MemberAndScore
+	// Next is the cursor of the next page, or "" if the page is the last one.
+	Next string
+}
+
+// ZSetPaginator pages through the members of a sorted set by score, e.g. to expose the entries of a leaderboard or a
+// timeline through a paginated API.
+//
+// The cursors are keyset cursors rather than offsets: a cursor holds the score and the member of the last entry of its
+// page, and the next page starts right after that entry, in the order of the sorted set, i.e. by score and then by
+// member. Pages are thus consistent while the sorted set changes: entries added or removed before the cursor neither
+// shift the next pages nor cause duplicates. The cursors are opaque URL-safe strings.
+//
+// Example usage:
+//
+//	paginator := glide.NewZSetPaginator(client, "leaderboard").WithReverse(true)
+//	page, err := paginator.Page(ctx, request.Cursor, 50)
+//	// Respond with page.Entries, and page.Next as the cursor of the next request.
+type ZSetPaginator struct {
+	client  interfaces.BaseClientCommands
+	key     string
+	reverse bool
+}
+
+// NewZSetPaginator returns a [ZSetPaginator] paging through the sorted set of key by increasing score.
+func NewZSetPaginator(client interfaces.BaseClientCommands, key string) *ZSetPaginator {
+	return &ZSetPaginator{client: client, key: key}
+}
+
+// WithReverse sets whether the pages are ordered by decreasing score, and decreasing member for equal scores.
+// Disabled by default.
+func (paginator *ZSetPaginator) WithReverse(reverse bool) *ZSetPaginator {
+	paginator.reverse = reverse
+	return paginator
+}
+
+// Page returns the count entries following cursor.
+//
+// Parameters:
+//
+//	ctx    - The context for controlling the command execution.
+//	cursor - The cursor of the page, the Next cursor of the previous page, or "" for the first page.
+//	count  - The maximum number of entries of the page.
+//
+// Return value:
+//
+//	The page, whose Next cursor is empty if there are no more entries, or an error if the cursor is invalid.
+func (paginator *ZSetPaginator) Page(ctx context.Context, cursor string, count int64) (ZSetPage, error) {
+	if count <= 0 {
+		return ZSetPage{}, fmt.Errorf("the count of a page must be positive, got %d", count)
+	}
+	start := options.NewInfiniteScoreBoundary(constants.NegativeInfinity)
+	end := options.NewInfiniteScoreBoundary(constants.PositiveInfinity)
+	if paginator.reverse {
+		start, end = end, start
+	}
+	var last *models.MemberAndScore
+	if cursor != "" {
+		decoded, err := decodeZSetCursor(cursor)
+		if err != nil {
+			return ZSetPage{}, err
+		}
+		last = &decoded
+		start = options.NewInclusiveScoreBoundary(decoded.Score)
+	}
+
+	// The entries of the score of the cursor up to its member are skipped. One more entry than count is read to tell
+	// whether there is a next page.
+	var page ZSetPage
+	for offset := int64(0); ; {
+		query := options.NewRangeByScoreQuery(start, end).SetLimit(offset, count+1)
+		if paginator.reverse {
+			query.SetReverse()
+		}
+		entries, err := paginator.client.ZRangeWithScores(ctx, paginator.key, query)
+		if err != nil {
+			return ZSetPage{}, err
+		}
+		for _, entry := range entries {
+			if last != nil && entry.Score == last.Score && paginator.notAfter(entry.Member, last.Member) {
+				continue
+			}
+			last = nil
+			if int64(len(page.Entries)) == count {
+				page.Next = encodeZSetCursor(page.Entries[count-1])
+				return page, nil
+			}
+			page.Entries = append(page.Entries, entry)
+		}
+		if int64(len(entries)) <= count {
+			return page, nil
+		}
+		offset += int64(len(entries))
+	}
+}
+
+// notAfter reports whether member comes before or is cursorMember, among the members of the same score.
+func (paginator *ZSetPaginator) notAfter(member string, cursorMember string) bool {
+	if paginator.reverse {
+		return member >= cursorMember
+	}
+	return member <= cursorMember
+}
+
+// encodeZSetCursor returns the cursor of the page following entry.
+func encodeZSetCursor(entry models.MemberAndScore) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatFloat(entry.Score, 'g', -1, 64) + ":" + entry.Member))
+}
+
+// decodeZSetCursor returns the last entry of the page preceding cursor.
+func decodeZSetCursor(cursor string) (models.MemberAndScore, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return models.MemberAndScore{}, fmt.Errorf("invalid sorted set cursor %q: %w", cursor, err)
+	}
+	score, member, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return models.MemberAndScore{}, fmt.Errorf("invalid sorted set cursor %q", cursor)
+	}
+	value, err := strconv.ParseFloat(score, 64)
+	if err != nil {
+		return models.MemberAndScore{}, fmt.Errorf("invalid sorted set cursor %q: %w", cursor, err)
+	}
+	return models.MemberAndScore{Member: member, Score: value}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/models"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeZSetClient serves ZRANGE BYSCORE queries from an in-memory sorted set.
+type fakeZSetClient struct {
+	interfaces.BaseClientCommands
+	entries []models.MemberAndScore
+	queries int
+}
+
+func (client *fakeZSetClient) add(member string, score float64) {
+	client.entries = append(client.entries, models.MemberAndScore{Member: member, Score: score})
+	sort.Slice(client.entries, func(i, j int) bool {
+		a, b := client.entries[i], client.entries[j]
+		return a.Score < b.Score || a.Score == b.Score && a.Member < b.Member
+	})
+}
+
+func (client *fakeZSetClient) ZRangeWithScores(
+	ctx context.Context,
+	key string,
+	rangeQuery options.ZRangeQueryWithScores,
+) ([]models.MemberAndScore, error) {
+	client.queries++
+	args, err := rangeQuery.ToArgs()
+	if err != nil {
+		return nil, err
+	}
+	bound := func(arg string) float64 {
+		value, _ := strconv.ParseFloat(arg, 64)
+		return value
+	}
+	low, high := bound(args[0]), bound(args[1])
+	entries := append([]models.MemberAndScore(nil), client.entries...)
+	if args[3] == "REV" {
+		low, high = high, low
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+	offset, _ := strconv.Atoi(args[len(args)-2])
+	count, _ := strconv.Atoi(args[len(args)-1])
+	var result []models.MemberAndScore
+	for _, entry := range entries {
+		if entry.Score < low || entry.Score > high {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if len(result) == count {
+			break
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func collectZSetPages(t *testing.T, paginator *ZSetPaginator, count int64) []string {
+	var members []string
+	cursor := ""
+	for {
+		page, err := paginator.Page(context.Background(), cursor, count)
+		require.NoError(t, err)
+		for _, entry := range page.Entries {
+			members = append(members, entry.Member)
+		}
+		if page.Next == "" {
+			return members
+		}
+		cursor = page.Next
+	}
+}
+
+func TestZSetPaginator_Pages(t *testing.T) {
+	client := &fakeZSetClient{}
+	for i, member := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		client.add(member, float64(i/3))
+	}
+	client.add("inf", math.Inf(1))
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g", "inf"}, collectZSetPages(t, NewZSetPaginator(client, "z"), 2))
+	assert.Equal(t, []string{"inf", "g", "f", "e", "d", "c", "b", "a"},
+		collectZSetPages(t, NewZSetPaginator(client, "z").WithReverse(true), 3))
+
+	page, err := NewZSetPaginator(client, "z").Page(context.Background(), "", 8)
+	require.NoError(t, err)
+	assert.Len(t, page.Entries, 8)
+	assert.Empty(t, page.Next)
+}
+
+func TestZSetPaginator_StableCursor(t *testing.T) {
+	client := &fakeZSetClient{}
+	for _, member := range []string{"m1", "m2", "m3", "m4", "m5", "m6"} {
+		client.add(member, 10)
+	}
+	paginator := NewZSetPaginator(client, "z")
+	page, err := paginator.Page(context.Background(), "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "m2", page.Entries[1].Member)
+
+	// Entries added before the cursor don't shift the next page.
+	client.add("m0", 10)
+	client.add("a", 1)
+	page, err = paginator.Page(context.Background(), page.Next, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []models.MemberAndScore{{Member: "m3", Score: 10}, {Member: "m4", Score: 10}}, page.Entries)
+
+	// Entries of the score of the cursor are skipped across several reads.
+	client.queries = 0
+	page, err = paginator.Page(context.Background(), page.Next, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "m5", page.Entries[0].Member)
+	assert.Equal(t, 4, client.queries)
+}
+
+func TestZSetPaginator_InvalidArguments(t *testing.T) {
+	paginator := NewZSetPaginator(&fakeZSetClient{}, "z")
+	_, err := paginator.Page(context.Background(), "", 0)
+	assert.Error(t, err)
+	_, err = paginator.Page(context.Background(), "not a cursor!", 10)
+	assert.Error(t, err)
+
+	entry, err := decodeZSetCursor(encodeZSetCursor(models.MemberAndScore{Member: "a:b", Score: -1.5}))
+	require.NoError(t, err)
+	assert.Equal(t, models.MemberAndScore{Member: "a:b", Score: -1.5}, entry)
+}
@@ -0,0 +1,199 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/valkey-io/valkey-glide/go/v2/constants"
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// DefaultZSetCleanupInterval is the interval between two cleanups of the sorted sets of a [ZSetWindowCleaner], unless
+// configured otherwise.
+const DefaultZSetCleanupInterval = time.Minute
+
+// ZSetCleanupStats reports the cleanups of a sorted set by a [ZSetWindowCleaner] since it was created.
+type ZSetCleanupStats struct {
+	// Cleanups is the number of ZREMRANGEBYSCORE commands sent for the sorted set, failed or not.
+	Cleanups int64
+	// Removed is the number of members removed from the sorted set.
+	Removed int64
+	// Errors is the number of ZREMRANGEBYSCORE commands which failed.
+	Errors int64
+	// LastCleanup is when the sorted set was last cleaned up, failed or not.
+	LastCleanup time.Time
+	// LastError is the error of the last ZREMRANGEBYSCORE command which failed, if any.
+	LastError error
+}
+
+// zsetWindow is the time window of a sorted set of a [ZSetWindowCleaner].
+type zsetWindow struct {
+	key    string
+	window time.Duration
+}
+
+// ZSetWindowCleaner removes the members of a set of sorted sets scored by timestamps once they're older than a moving
+// time window, in the background, e.g. for the sorted sets of sliding-window rate limiters or of activity feeds.
+//
+// The sorted sets are cleaned up with ZREMRANGEBYSCORE every interval: the members scored before the current time
+// minus the window of their sorted set are removed. The scores are timestamps in milliseconds since the Unix epoch,
+// unless configured otherwise with WithScoreUnit. The cleanups of each sorted set are counted, see Stats.
+//
+// Example usage:
+//
+//	cleaner := glide.NewZSetWindowCleaner(client).
+//		WithWindow("rate:user:1", time.Minute).
+//		WithWindow("feed:user:1", 7*24*time.Hour)
+//	cleaner.Start(ctx)
+//	defer cleaner.Stop()
+type ZSetWindowCleaner struct {
+	client    interfaces.BaseClientCommands
+	interval  time.Duration
+	scoreUnit time.Duration
+	windows   []*zsetWindow
+	now       func() time.Time
+
+	mu     sync.Mutex
+	stats  map[string]ZSetCleanupStats
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewZSetWindowCleaner returns a [ZSetWindowCleaner] cleaning up sorted sets with client every
+// DefaultZSetCleanupInterval.
+func NewZSetWindowCleaner(client interfaces.BaseClientCommands) *ZSetWindowCleaner {
+	return &ZSetWindowCleaner{
+		client:    client,
+		interval:  DefaultZSetCleanupInterval,
+		scoreUnit: time.Millisecond,
+		now:       time.Now,
+		stats:     make(map[string]ZSetCleanupStats),
+	}
+}
+
+// WithWindow adds the sorted set of key, whose members are removed once they're older than window. Setting the window
+// of a sorted set added before replaces its window.
+func (cleaner *ZSetWindowCleaner) WithWindow(key string, window time.Duration) *ZSetWindowCleaner {
+	for _, zsetWindow := range cleaner.windows {
+		if zsetWindow.key == key {
+			zsetWindow.window = window
+			return cleaner
+		}
+	}
+	cleaner.windows = append(cleaner.windows, &zsetWindow{key: key, window: window})
+	return cleaner
+}
+
+// WithInterval sets the interval between two scheduled cleanups of the sorted sets, or disables the scheduled
+// cleanups if interval isn't positive. Defaults to DefaultZSetCleanupInterval.
+func (cleaner *ZSetWindowCleaner) WithInterval(interval time.Duration) *ZSetWindowCleaner {
+	cleaner.interval = interval
+	return cleaner
+}
+
+// WithScoreUnit sets the unit of the timestamps scoring the members, e.g. time.Second for Unix timestamps in seconds.
+// Defaults to time.Millisecond.
+func (cleaner *ZSetWindowCleaner) WithScoreUnit(unit time.Duration) *ZSetWindowCleaner {
+	cleaner.scoreUnit = unit
+	return cleaner
+}
+
+// Start starts cleaning up the sorted sets in the background, until ctx is done or the cleaner is stopped. Starting a
+// cleaner which is already started is a no-op.
+func (cleaner *ZSetWindowCleaner) Start(ctx context.Context) {
+	cleaner.mu.Lock()
+	defer cleaner.mu.Unlock()
+	if cleaner.done != nil {
+		return
+	}
+	ctx, cleaner.cancel = context.WithCancel(ctx)
+	cleaner.done = make(chan struct{})
+	go cleaner.run(ctx, cleaner.done)
+}
+
+// Stop stops the cleanups started by Start, and waits for the cleanup in progress, if any.
+func (cleaner *ZSetWindowCleaner) Stop() {
+	cleaner.mu.Lock()
+	cancel, done := cleaner.cancel, cleaner.done
+	cleaner.cancel, cleaner.done = nil, nil
+	cleaner.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (cleaner *ZSetWindowCleaner) run(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+	if cleaner.interval <= 0 {
+		<-ctx.Done()
+		return
+	}
+	ticker := time.NewTicker(cleaner.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = cleaner.CleanNow(ctx)
+		}
+	}
+}
+
+// CleanNow cleans up all the sorted sets once, whether the cleaner is started or not.
+//
+// Return value:
+//
+//	The errors of the ZREMRANGEBYSCORE commands which failed, joined.
+func (cleaner *ZSetWindowCleaner) CleanNow(ctx context.Context) error {
+	var errs []error
+	for _, zsetWindow := range cleaner.windows {
+		if err := cleaner.clean(ctx, zsetWindow); err != nil {
+			errs = append(errs, fmt.Errorf("failed to clean up sorted set %q: %w", zsetWindow.key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (cleaner *ZSetWindowCleaner) clean(ctx context.Context, zsetWindow *zsetWindow) error {
+	now := cleaner.now()
+	// The members scored at the start of the window are kept.
+	cutoff := float64(now.Add(-zsetWindow.window).UnixNano()) / float64(cleaner.scoreUnit)
+	query := options.NewRangeByScoreQuery(
+		options.NewInfiniteScoreBoundary(constants.NegativeInfinity),
+		options.NewScoreBoundary(cutoff, false),
+	)
+	removed, err := cleaner.client.ZRemRangeByScore(ctx, zsetWindow.key, *query)
+
+	cleaner.mu.Lock()
+	defer cleaner.mu.Unlock()
+	stats := cleaner.stats[zsetWindow.key]
+	stats.Cleanups++
+	stats.LastCleanup = now
+	if err != nil {
+		stats.Errors++
+		stats.LastError = err
+	} else {
+		stats.Removed += removed
+	}
+	cleaner.stats[zsetWindow.key] = stats
+	return err
+}
+
+// Stats returns the cleanups of each sorted set cleaned up so far, by key.
+func (cleaner *ZSetWindowCleaner) Stats() map[string]ZSetCleanupStats {
+	cleaner.mu.Lock()
+	defer cleaner.mu.Unlock()
+	stats := make(map[string]ZSetCleanupStats, len(cleaner.stats))
+	for key, stat := range cleaner.stats {
+		stats[key] = stat
+	}
+	return stats
+}
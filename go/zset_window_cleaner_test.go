@@ -0,0 +1,103 @@
+// Copyright Valkey GLIDE Project Contributors - SPDX Identifier: Apache-2.0
+
+package glide
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/valkey-io/valkey-glide/go/v2/internal/interfaces"
+	"github.com/valkey-io/valkey-glide/go/v2/options"
+)
+
+// fakeZRemClient records the ranges of ZREMRANGEBYSCORE, removing a fixed number of members per command.
+type fakeZRemClient struct {
+	interfaces.BaseClientCommands
+	mu      sync.Mutex
+	ranges  map[string][][]string
+	removed int64
+	err     error
+	cleaned chan string
+}
+
+func (client *fakeZRemClient) ZRemRangeByScore(
+	ctx context.Context,
+	key string,
+	rangeQuery options.RangeByScore,
+) (int64, error) {
+	args, _ := rangeQuery.ToArgsRemRange()
+	client.mu.Lock()
+	client.ranges[key] = append(client.ranges[key], args)
+	client.mu.Unlock()
+	if client.cleaned != nil {
+		client.cleaned <- key
+	}
+	if client.err != nil {
+		return 0, client.err
+	}
+	return client.removed, nil
+}
+
+func newFakeZRemClient(removed int64) *fakeZRemClient {
+	return &fakeZRemClient{ranges: make(map[string][][]string), removed: removed}
+}
+
+func TestZSetWindowCleaner_CleanNow(t *testing.T) {
+	client := newFakeZRemClient(4)
+	cleaner := NewZSetWindowCleaner(client).
+		WithWindow("rate:user:1", time.Minute).
+		WithWindow("feed:user:1", time.Hour).
+		WithWindow("rate:user:1", 10*time.Second)
+	now := time.UnixMilli(1700000000000)
+	cleaner.now = func() time.Time { return now }
+
+	require.NoError(t, cleaner.CleanNow(context.Background()))
+
+	assert.Equal(t, [][]string{{"-inf", "(1.69999999e+12"}}, client.ranges["rate:user:1"])
+	assert.Equal(t, [][]string{{"-inf", "(1.6999964e+12"}}, client.ranges["feed:user:1"])
+	stats := cleaner.Stats()
+	assert.Equal(t, ZSetCleanupStats{Cleanups: 1, Removed: 4, LastCleanup: now}, stats["rate:user:1"])
+
+	// The scores are Unix timestamps in seconds.
+	client = newFakeZRemClient(0)
+	cleaner = NewZSetWindowCleaner(client).WithWindow("feed:user:1", time.Minute).WithScoreUnit(time.Second)
+	cleaner.now = func() time.Time { return now }
+	require.NoError(t, cleaner.CleanNow(context.Background()))
+	assert.Equal(t, [][]string{{"-inf", "(1.69999994e+09"}}, client.ranges["feed:user:1"])
+}
+
+func TestZSetWindowCleaner_Errors(t *testing.T) {
+	client := newFakeZRemClient(0)
+	client.err = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	cleaner := NewZSetWindowCleaner(client).WithWindow("feed:user:1", time.Hour)
+
+	err := cleaner.CleanNow(context.Background())
+	assert.ErrorIs(t, err, client.err)
+	assert.ErrorContains(t, err, `failed to clean up sorted set "feed:user:1"`)
+	stats := cleaner.Stats()["feed:user:1"]
+	assert.Equal(t, int64(1), stats.Errors)
+	assert.Equal(t, client.err, stats.LastError)
+}
+
+func TestZSetWindowCleaner_Interval(t *testing.T) {
+	client := newFakeZRemClient(1)
+	client.cleaned = make(chan string, 10)
+	cleaner := NewZSetWindowCleaner(client).WithWindow("rate:user:1", time.Minute).WithInterval(time.Millisecond)
+	cleaner.Start(context.Background())
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-client.cleaned:
+		case <-time.After(time.Second):
+			t.Fatal("the sorted set wasn't cleaned up")
+		}
+	}
+	cleaner.Stop()
+	assert.GreaterOrEqual(t, cleaner.Stats()["rate:user:1"].Cleanups, int64(2))
+}
@@ -73,7 +73,9 @@ class _GlideFFI:
                 Map = 6,
                 Sets = 7,
                 Ok = 8,
-                Error = 9
+                Error = 9,
+                BigNumber = 10,
+                VerbatimString = 11
             } ResponseType;
 
             typedef struct CommandResponse {
@@ -89,6 +91,7 @@ class _GlideFFI:
                 struct CommandResponse* map_value;
                 struct CommandResponse* sets_value;
                 long sets_value_len;
+                struct CommandResponse* attributes;
             } CommandResponse;
 
             typedef struct {
@@ -257,6 +257,8 @@ class BaseClient(CoreCommands):
             7: self._handle_set_response,
             8: self._handle_ok_response,
             9: self._handle_error_response,
+            10: self._handle_big_number_response,
+            11: self._handle_verbatim_string_response,
         }
 
         handler = handlers.get(msg.response_type)
@@ -283,6 +285,13 @@ class BaseClient(CoreCommands):
         except Exception as e:
             raise RequestError(f"Error decoding string value: {e}")
 
+    def _handle_big_number_response(self, msg):
+        return int(self._handle_string_response(msg))
+
+    def _handle_verbatim_string_response(self, msg):
+        # The text is prefixed by its format, e.g. b"txt:"
+        return self._handle_string_response(msg)[4:]
+
     def _handle_array_response(self, msg):
         array = []
         for i in range(msg.array_value_len):